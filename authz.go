@@ -66,6 +66,11 @@ func BucketResource(id ID) resource {
 	return resource(fmt.Sprintf("bucket/%s", id))
 }
 
+// TelegrafResource constructs a telegraf config resource.
+func TelegrafResource(id ID) resource {
+	return resource(fmt.Sprintf("telegraf/%s", id))
+}
+
 // Permission defines an action and a resource.
 type Permission struct {
 	Action   action   `json:"action"`
@@ -104,3 +109,11 @@ func WriteBucketPermission(id ID) Permission {
 		Resource: BucketResource(id),
 	}
 }
+
+// ReadTelegrafPermission constructs a permission for reading a telegraf config.
+func ReadTelegrafPermission(id ID) Permission {
+	return Permission{
+		Action:   ReadAction,
+		Resource: TelegrafResource(id),
+	}
+}
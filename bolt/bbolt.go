@@ -123,6 +123,31 @@ func (c *Client) initialize(ctx context.Context) error {
 			return err
 		}
 
+		// Always create Telegraf Agent bucket.
+		if err := c.initializeTelegrafAgents(ctx, tx); err != nil {
+			return err
+		}
+
+		// Always create Check bucket.
+		if err := c.initializeChecks(ctx, tx); err != nil {
+			return err
+		}
+
+		// Always create Notification Endpoint bucket.
+		if err := c.initializeNotificationEndpoints(ctx, tx); err != nil {
+			return err
+		}
+
+		// Always create Replication Rule bucket.
+		if err := c.initializeReplicationRules(ctx, tx); err != nil {
+			return err
+		}
+
+		// Always create Task Template bucket.
+		if err := c.initializeTaskTemplates(ctx, tx); err != nil {
+			return err
+		}
+
 		// Always create Source bucket.
 		if err := c.initializeSources(ctx, tx); err != nil {
 			return err
@@ -237,7 +237,7 @@ func filterBucketsFn(filter platform.BucketFilter) func(b *platform.Bucket) bool
 // Filters using ID, or OrganizationID and bucket Name should be efficient.
 // Other filters will do a linear scan across all buckets searching for a match.
 func (c *Client) FindBuckets(ctx context.Context, filter platform.BucketFilter, opt ...platform.FindOptions) ([]*platform.Bucket, int, error) {
-	if filter.ID != nil {
+	if filter.ID != nil && filter.Label == nil {
 		b, err := c.FindBucketByID(ctx, *filter.ID)
 		if err != nil {
 			return nil, 0, err
@@ -246,7 +246,7 @@ func (c *Client) FindBuckets(ctx context.Context, filter platform.BucketFilter,
 		return []*platform.Bucket{b}, 1, nil
 	}
 
-	if filter.Name != nil && filter.OrganizationID != nil {
+	if filter.Name != nil && filter.OrganizationID != nil && filter.Label == nil {
 		b, err := c.FindBucketByName(ctx, *filter.OrganizationID, *filter.Name)
 		if err != nil {
 			return nil, 0, err
@@ -284,9 +284,20 @@ func (c *Client) findBuckets(ctx context.Context, tx *bolt.Tx, filter platform.B
 		filter.OrganizationID = &o.ID
 	}
 
+	var labelled platform.LabelledResources
+	if filter.Label != nil {
+		ids, err := c.labelledResources(ctx, tx, platform.BucketResourceType, *filter.Label)
+		if err != nil {
+			return nil, &platform.Error{
+				Err: err,
+			}
+		}
+		labelled = ids
+	}
+
 	filterFn := filterBucketsFn(filter)
 	err := c.forEachBucket(ctx, tx, func(b *platform.Bucket) bool {
-		if filterFn(b) {
+		if filterFn(b) && (labelled == nil || labelled.Has(b.ID, b.OrganizationID)) {
 			bs = append(bs, b)
 		}
 		return true
@@ -486,6 +497,10 @@ func (c *Client) updateBucket(ctx context.Context, tx *bolt.Tx, id platform.ID,
 		b.RetentionPeriod = *upd.RetentionPeriod
 	}
 
+	if upd.ShardGroupDuration != nil {
+		b.ShardGroupDuration = *upd.ShardGroupDuration
+	}
+
 	if upd.Name != nil {
 		key, err := bucketIndexKey(b)
 		if err != nil {
@@ -561,7 +576,7 @@ func (c *Client) deleteBucket(ctx context.Context, tx *bolt.Tx, id platform.ID)
 		}
 	}
 
-	if err := c.deleteLabels(ctx, tx, platform.LabelFilter{ResourceID: id}); err != nil {
+	if err := c.deleteResourceLabelMappings(ctx, tx, id); err != nil {
 		return &platform.Error{
 			Err: err,
 		}
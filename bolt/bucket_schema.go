@@ -0,0 +1,64 @@
+package bolt
+
+import (
+	"context"
+
+	"github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+)
+
+var _ platform.BucketSchemaService = (*Client)(nil)
+
+const bucketSchemaUpdatedEvent = "Bucket Schema Updated"
+
+// FindBucketSchema returns the schema of a bucket, or nil if it has none.
+func (c *Client) FindBucketSchema(ctx context.Context, id platform.ID) (*platform.BucketSchema, error) {
+	var s *platform.BucketSchema
+	err := c.db.View(func(tx *bolt.Tx) error {
+		b, pe := c.findBucketByID(ctx, tx, id)
+		if pe != nil {
+			pe.Op = getOp(platform.OpFindBucketSchema)
+			return pe
+		}
+		s = b.Schema
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// SetBucketSchema sets the schema of a bucket, which is stored with the bucket.
+// A nil schema removes the schema of the bucket.
+func (c *Client) SetBucketSchema(ctx context.Context, id platform.ID, s *platform.BucketSchema) error {
+	if s != nil {
+		if err := s.Validate(); err != nil {
+			return &platform.Error{
+				Op:  getOp(platform.OpSetBucketSchema),
+				Err: err,
+			}
+		}
+	}
+
+	return c.db.Update(func(tx *bolt.Tx) error {
+		b, pe := c.findBucketByID(ctx, tx, id)
+		if pe != nil {
+			pe.Op = getOp(platform.OpSetBucketSchema)
+			return pe
+		}
+		b.Schema = s
+
+		if err := c.appendBucketEventToLog(ctx, tx, b.ID, bucketSchemaUpdatedEvent); err != nil {
+			return err
+		}
+
+		if pe := c.putBucket(ctx, tx, b); pe != nil {
+			pe.Op = getOp(platform.OpSetBucketSchema)
+			return pe
+		}
+		return nil
+	})
+}
@@ -0,0 +1,75 @@
+package bolt_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/platform"
+)
+
+func TestClient_BucketSchema(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	ctx := context.Background()
+	org := &platform.Organization{Name: "org"}
+	if err := c.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+	bucket := &platform.Bucket{OrganizationID: org.ID, Name: "bucket"}
+	if err := c.CreateBucket(ctx, bucket); err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err := c.FindBucketSchema(ctx, bucket.ID); err != nil {
+		t.Fatal(err)
+	} else if s != nil {
+		t.Fatalf("unexpected schema: %+v", s)
+	}
+
+	schema := &platform.BucketSchema{
+		Measurements: []platform.MeasurementSchema{{
+			Name:   "cpu",
+			Tags:   []string{"host"},
+			Fields: []platform.FieldSchema{{Name: "usage", Type: platform.SchemaFieldFloat}},
+		}},
+	}
+	if err := c.SetBucketSchema(ctx, bucket.ID, schema); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := c.FindBucketSchema(ctx, bucket.ID); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(s, schema) {
+		t.Fatalf("unexpected schema: got %+v, want %+v", s, schema)
+	}
+
+	// The schema is kept when the bucket is updated.
+	name := "renamed"
+	if b, err := c.UpdateBucket(ctx, bucket.ID, platform.BucketUpdate{Name: &name}); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(b.Schema, schema) {
+		t.Fatalf("unexpected schema after update: got %+v, want %+v", b.Schema, schema)
+	}
+
+	invalid := &platform.BucketSchema{Measurements: []platform.MeasurementSchema{{Name: "cpu"}}}
+	if err := c.SetBucketSchema(ctx, bucket.ID, invalid); platform.ErrorCode(err) != platform.EInvalid {
+		t.Fatalf("expected invalid error, got %v", err)
+	}
+
+	if err := c.SetBucketSchema(ctx, bucket.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := c.FindBucketSchema(ctx, bucket.ID); err != nil {
+		t.Fatal(err)
+	} else if s != nil {
+		t.Fatalf("unexpected schema after removal: %+v", s)
+	}
+
+	if _, err := c.FindBucketSchema(ctx, platform.ID(1)); platform.ErrorCode(err) != platform.ENotFound {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
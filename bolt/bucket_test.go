@@ -26,6 +26,16 @@ func initBucketService(f platformtesting.BucketFields, t *testing.T) (platform.B
 			t.Fatalf("failed to populate buckets")
 		}
 	}
+	for _, l := range f.Labels {
+		if err := c.PutLabel(ctx, l); err != nil {
+			t.Fatalf("failed to populate labels: %v", err)
+		}
+	}
+	for _, m := range f.LabelMappings {
+		if err := c.CreateLabelMapping(ctx, m); err != nil {
+			t.Fatalf("failed to populate label mappings: %v", err)
+		}
+	}
 	return c, bolt.OpPrefix, func() {
 		defer closeFn()
 		for _, o := range f.Organizations {
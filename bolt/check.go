@@ -0,0 +1,183 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+)
+
+var (
+	checkBucket = []byte("checksv1")
+)
+
+var _ platform.CheckService = (*Client)(nil)
+
+func (c *Client) initializeChecks(ctx context.Context, tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(checkBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindCheckByID returns a single check by ID.
+func (c *Client) FindCheckByID(ctx context.Context, id platform.ID) (*platform.Check, error) {
+	var ch *platform.Check
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var err error
+		ch, err = c.findCheckByID(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "bolt/find check by id",
+			Err:  err,
+		}
+	}
+	return ch, nil
+}
+
+func (c *Client) findCheckByID(ctx context.Context, tx *bolt.Tx, id platform.ID) (*platform.Check, error) {
+	encodedID, err := id.Encode()
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
+	}
+	v := tx.Bucket(checkBucket).Get(encodedID)
+	if v == nil {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  fmt.Sprintf("check with ID %v not found", id),
+		}
+	}
+	ch := &platform.Check{}
+	if err := json.Unmarshal(v, ch); err != nil {
+		return nil, err
+	}
+	return ch, nil
+}
+
+// FindChecks returns the checks that match filter.
+func (c *Client) FindChecks(ctx context.Context, filter platform.CheckFilter) ([]*platform.Check, error) {
+	cs := []*platform.Check{}
+	err := c.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(checkBucket).ForEach(func(k, v []byte) error {
+			ch := &platform.Check{}
+			if err := json.Unmarshal(v, ch); err != nil {
+				return err
+			}
+			if filter.OrganizationID != nil && ch.OrganizationID != *filter.OrganizationID {
+				return nil
+			}
+			if filter.TaskID != nil && ch.TaskID != *filter.TaskID {
+				return nil
+			}
+			cs = append(cs, ch)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Op:  "bolt/find checks",
+			Err: err,
+		}
+	}
+	return cs, nil
+}
+
+// CreateCheck creates a new check and sets ch.ID with the new identifier.
+func (c *Client) CreateCheck(ctx context.Context, ch *platform.Check) error {
+	op := "bolt/create check"
+	if ch.Status == "" {
+		ch.Status = platform.CheckActive
+	}
+	if err := ch.Valid(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Err:  err,
+		}
+	}
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		ch.ID = c.IDGenerator.ID()
+		return c.putCheck(ctx, tx, ch)
+	})
+	if err != nil {
+		return &platform.Error{
+			Op:  op,
+			Err: err,
+		}
+	}
+	return nil
+}
+
+// PutCheck will put a check without setting an ID.
+func (c *Client) PutCheck(ctx context.Context, ch *platform.Check) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return c.putCheck(ctx, tx, ch)
+	})
+}
+
+func (c *Client) putCheck(ctx context.Context, tx *bolt.Tx, ch *platform.Check) error {
+	encodedID, err := ch.ID.Encode()
+	if err != nil {
+		return err
+	}
+	v, err := json.Marshal(ch)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(checkBucket).Put(encodedID, v)
+}
+
+// UpdateCheck updates a single check with a changeset and returns the updated check.
+func (c *Client) UpdateCheck(ctx context.Context, id platform.ID, upd platform.CheckUpdate) (*platform.Check, error) {
+	var ch *platform.Check
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		ch, err = c.findCheckByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		upd.Apply(ch)
+		if err := ch.Valid(); err != nil {
+			return err
+		}
+		return c.putCheck(ctx, tx, ch)
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "bolt/update check",
+			Err:  err,
+		}
+	}
+	return ch, nil
+}
+
+// DeleteCheck removes a check by ID.
+func (c *Client) DeleteCheck(ctx context.Context, id platform.ID) error {
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		if _, err := c.findCheckByID(ctx, tx, id); err != nil {
+			return err
+		}
+		encodedID, err := id.Encode()
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(checkBucket).Delete(encodedID)
+	})
+	if err != nil {
+		return &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "bolt/delete check",
+			Err:  err,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package bolt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initCheckService(f platformtesting.CheckFields, t *testing.T) (platform.CheckService, func()) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	if f.IDGenerator != nil {
+		c.IDGenerator = f.IDGenerator
+	}
+	ctx := context.TODO()
+	for _, v := range f.Checks {
+		if err := c.PutCheck(ctx, v); err != nil {
+			t.Fatalf("failed to populate checks: %v", err)
+		}
+	}
+	return c, closeFn
+}
+
+func TestCheckService(t *testing.T) {
+	platformtesting.CheckService(initCheckService, t)
+}
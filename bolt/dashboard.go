@@ -122,7 +122,7 @@ func filterDashboardsFn(filter platform.DashboardFilter) func(d *platform.Dashbo
 
 // FindDashboards retrives all dashboards that match an arbitrary dashboard filter.
 func (c *Client) FindDashboards(ctx context.Context, filter platform.DashboardFilter, opts platform.FindOptions) ([]*platform.Dashboard, int, error) {
-	if len(filter.IDs) == 1 {
+	if len(filter.IDs) == 1 && filter.Label == nil {
 		d, err := c.FindDashboardByID(ctx, *filter.IDs[0])
 		if err != nil {
 			return nil, 0, err
@@ -153,9 +153,18 @@ func (c *Client) FindDashboards(ctx context.Context, filter platform.DashboardFi
 func (c *Client) findDashboards(ctx context.Context, tx *bolt.Tx, filter platform.DashboardFilter) ([]*platform.Dashboard, error) {
 	ds := []*platform.Dashboard{}
 
+	var labelled platform.LabelledResources
+	if filter.Label != nil {
+		ids, err := c.labelledResources(ctx, tx, platform.DashboardResourceType, *filter.Label)
+		if err != nil {
+			return nil, err
+		}
+		labelled = ids
+	}
+
 	filterFn := filterDashboardsFn(filter)
 	err := c.forEachDashboard(ctx, tx, func(d *platform.Dashboard) bool {
-		if filterFn(d) {
+		if filterFn(d) && (labelled == nil || labelled[d.ID] != nil) {
 			ds = append(ds, d)
 		}
 		return true
@@ -470,7 +479,7 @@ func (c *Client) deleteDashboard(ctx context.Context, tx *bolt.Tx, id platform.I
 		return err
 	}
 
-	err = c.deleteLabels(ctx, tx, platform.LabelFilter{ResourceID: id})
+	err = c.deleteResourceLabelMappings(ctx, tx, id)
 	if err != nil {
 		return err
 	}
@@ -1,29 +1,237 @@
 package bolt
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 
 	bolt "github.com/coreos/bbolt"
 	"github.com/influxdata/platform"
+	"go.uber.org/zap"
 )
 
 var (
-	labelBucket = []byte("labelsv1")
+	labelBucket        = []byte("labelsv2")
+	labelMappingBucket = []byte("labelmappingsv1")
 )
 
+var _ platform.LabelService = (*Client)(nil)
+
 func (c *Client) initializeLabels(ctx context.Context, tx *bolt.Tx) error {
 	if _, err := tx.CreateBucketIfNotExists([]byte(labelBucket)); err != nil {
 		return err
 	}
+	if _, err := tx.CreateBucketIfNotExists([]byte(labelMappingBucket)); err != nil {
+		return err
+	}
+	return c.migrateLegacyLabels(ctx, tx)
+}
+
+var (
+	// legacyLabelBucket holds the labels of earlier versions, which were
+	// names applied to a single resource, keyed by the resource ID followed
+	// by the name.
+	legacyLabelBucket = []byte("labelsv1")
+
+	// taskOrgBucket is the bucket of the task store mapping task IDs to the
+	// IDs of their organization. It is nested in the root bucket of the store.
+	taskOrgBucket = []byte("/tasks/v1/org_by_task_id")
+)
+
+// legacyLabel is a label of legacyLabelBucket.
+type legacyLabel struct {
+	ResourceID platform.ID `json:"resource_id"`
+	Name       string      `json:"name"`
+}
+
+// migrateLegacyLabels turns each label of legacyLabelBucket into a label of
+// the organization of its resource, applied to the resource. Labels of
+// resources whose organization cannot be found are left in legacyLabelBucket.
+func (c *Client) migrateLegacyLabels(ctx context.Context, tx *bolt.Tx) error {
+	b := tx.Bucket(legacyLabelBucket)
+	if b == nil {
+		return nil
+	}
+
+	var migrated [][]byte
+	err := b.ForEach(func(k, v []byte) error {
+		var ll legacyLabel
+		if err := json.Unmarshal(v, &ll); err != nil {
+			return err
+		}
+
+		orgID, rt, err := c.legacyLabelResource(ctx, tx, ll.ResourceID)
+		if err != nil {
+			return err
+		} else if !orgID.Valid() {
+			c.Logger.Warn("Unable to migrate label of resource without organization",
+				zap.String("resource_id", ll.ResourceID.String()), zap.String("label", ll.Name))
+			return nil
+		}
+
+		ls, err := c.findLabels(ctx, tx, platform.LabelFilter{OrganizationID: &orgID, Name: ll.Name})
+		if err != nil {
+			return err
+		}
+		var l *platform.Label
+		if len(ls) > 0 {
+			l = ls[0]
+		} else {
+			l = &platform.Label{OrganizationID: orgID, Name: ll.Name}
+			if pErr := c.createLabel(ctx, tx, l); pErr != nil {
+				return pErr
+			}
+		}
+
+		m := &platform.LabelMapping{LabelID: l.ID, ResourceID: ll.ResourceID, ResourceType: rt}
+		if pErr := c.createLabelMapping(ctx, tx, m); pErr != nil && pErr.Code != platform.EConflict {
+			return pErr
+		}
+
+		migrated = append(migrated, append([]byte(nil), k...))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("migrating labels: %v", err)
+	}
+
+	for _, k := range migrated {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	if k, _ := b.Cursor().First(); k == nil {
+		return tx.DeleteBucket(legacyLabelBucket)
+	}
 	return nil
 }
 
+// legacyLabelResource returns the organization and type of the resource
+// with id. An invalid organization ID is returned if it cannot be found.
+func (c *Client) legacyLabelResource(ctx context.Context, tx *bolt.Tx, id platform.ID) (platform.ID, platform.ResourceType, error) {
+	encodedID, err := id.Encode()
+	if err != nil {
+		return 0, "", err
+	}
+
+	if v := tx.Bucket(organizationBucket).Get(encodedID); len(v) != 0 {
+		return id, platform.OrgResourceType, nil
+	}
+
+	if v := tx.Bucket(bucketBucket).Get(encodedID); len(v) != 0 {
+		var b platform.Bucket
+		if err := json.Unmarshal(v, &b); err != nil {
+			return 0, "", err
+		}
+		return b.OrganizationID, platform.BucketResourceType, nil
+	}
+
+	var taskOrgID platform.ID
+	err = tx.ForEach(func(_ []byte, root *bolt.Bucket) error {
+		if b := root.Bucket(taskOrgBucket); b != nil && !taskOrgID.Valid() {
+			if v := b.Get(encodedID); len(v) != 0 {
+				return taskOrgID.Decode(v)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, "", err
+	} else if taskOrgID.Valid() {
+		return taskOrgID, platform.TaskResourceType, nil
+	}
+
+	// Dashboards, views and telegraf configs belong to the organization of
+	// the users they are mapped to.
+	cur := tx.Bucket(userResourceMappingBucket).Cursor()
+	for k, v := cur.Seek(encodedID); k != nil && bytes.HasPrefix(k, encodedID); k, v = cur.Next() {
+		var m platform.UserResourceMapping
+		if err := json.Unmarshal(v, &m); err != nil {
+			return 0, "", err
+		}
+		orgID, err := c.userOrganization(ctx, tx, m.UserID)
+		if err != nil {
+			return 0, "", err
+		} else if orgID.Valid() {
+			return orgID, m.ResourceType, nil
+		}
+	}
+
+	return 0, "", nil
+}
+
+// userOrganization returns the ID of the first organization the user with
+// id is mapped to, or an invalid ID if there is none.
+func (c *Client) userOrganization(ctx context.Context, tx *bolt.Tx, id platform.ID) (platform.ID, error) {
+	var orgID platform.ID
+	cur := tx.Bucket(userResourceMappingBucket).Cursor()
+	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+		var m platform.UserResourceMapping
+		if err := json.Unmarshal(v, &m); err != nil {
+			return 0, err
+		}
+		if m.UserID == id && m.ResourceType == platform.OrgResourceType {
+			orgID = m.ResourceID
+			break
+		}
+	}
+	return orgID, nil
+}
+
+// FindLabelByID finds a label by its ID.
+func (c *Client) FindLabelByID(ctx context.Context, id platform.ID) (*platform.Label, error) {
+	var l *platform.Label
+	op := "bolt/find label by id"
+	err := c.db.View(func(tx *bolt.Tx) error {
+		label, pErr := c.findLabelByID(ctx, tx, id)
+		if pErr != nil {
+			pErr.Op = op
+			return pErr
+		}
+		l = label
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (c *Client) findLabelByID(ctx context.Context, tx *bolt.Tx, id platform.ID) (*platform.Label, *platform.Error) {
+	encodedID, err := id.Encode()
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
+	}
+
+	v := tx.Bucket(labelBucket).Get(encodedID)
+	if len(v) == 0 {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  fmt.Sprintf("label with ID %v not found", id),
+		}
+	}
+
+	l := &platform.Label{}
+	if err := json.Unmarshal(v, l); err != nil {
+		return nil, &platform.Error{
+			Err: err,
+		}
+	}
+
+	return l, nil
+}
+
 func filterLabelsFn(filter platform.LabelFilter) func(l *platform.Label) bool {
 	return func(label *platform.Label) bool {
-		return (filter.Name == "" || (filter.Name == label.Name)) &&
-			(!filter.ResourceID.Valid() || (filter.ResourceID == label.ResourceID))
+		return (!filter.ID.Valid() || filter.ID == label.ID) &&
+			(filter.OrganizationID == nil || *filter.OrganizationID == label.OrganizationID) &&
+			(filter.Name == "" || filter.Name == label.Name)
 	}
 }
 
@@ -63,49 +271,302 @@ func (c *Client) findLabels(ctx context.Context, tx *bolt.Tx, filter platform.La
 	return ls, nil
 }
 
+// FindResourceLabels returns the labels applied to a resource.
+func (c *Client) FindResourceLabels(ctx context.Context, filter platform.LabelMappingFilter) ([]*platform.Label, error) {
+	ls := []*platform.Label{}
+	op := "bolt/find resource labels"
+	err := c.db.View(func(tx *bolt.Tx) error {
+		labels, pErr := c.findResourceLabels(ctx, tx, filter)
+		if pErr != nil {
+			pErr.Op = op
+			return pErr
+		}
+		ls = labels
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ls, nil
+}
+
+func (c *Client) findResourceLabels(ctx context.Context, tx *bolt.Tx, filter platform.LabelMappingFilter) ([]*platform.Label, *platform.Error) {
+	ls := []*platform.Label{}
+	err := c.forEachResourceLabelMapping(ctx, tx, filter.ResourceID, func(m *platform.LabelMapping) error {
+		if filter.ResourceType != "" && filter.ResourceType != m.ResourceType {
+			return nil
+		}
+
+		l, pErr := c.findLabelByID(ctx, tx, m.LabelID)
+		if pErr != nil {
+			return pErr
+		}
+		ls = append(ls, l)
+		return nil
+	})
+
+	if err != nil {
+		return nil, &platform.Error{
+			Err: err,
+		}
+	}
+
+	return ls, nil
+}
+
+// FindLabelledResources returns the resources of type rt that have a label
+// named name.
+func (c *Client) FindLabelledResources(ctx context.Context, rt platform.ResourceType, name string) (platform.LabelledResources, error) {
+	var labelled platform.LabelledResources
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var err error
+		labelled, err = c.labelledResources(ctx, tx, rt, name)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return labelled, nil
+}
+
+// labelledResources returns the resources of type rt that have a label
+// named name.
+func (c *Client) labelledResources(ctx context.Context, tx *bolt.Tx, rt platform.ResourceType, name string) (platform.LabelledResources, error) {
+	labels, err := c.findLabels(ctx, tx, platform.LabelFilter{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := make(map[platform.ID]platform.ID, len(labels))
+	for _, l := range labels {
+		orgs[l.ID] = l.OrganizationID
+	}
+
+	labelled := platform.LabelledResources{}
+	err = c.forEachLabelMapping(ctx, tx, func(m *platform.LabelMapping) error {
+		if orgID, ok := orgs[m.LabelID]; ok && m.ResourceType == rt {
+			labelled[m.ResourceID] = append(labelled[m.ResourceID], orgID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return labelled, nil
+}
+
+// CreateLabel creates a new label.
 func (c *Client) CreateLabel(ctx context.Context, l *platform.Label) error {
+	op := "bolt/create label"
 	return c.db.Update(func(tx *bolt.Tx) error {
-		return c.createLabel(ctx, tx, l)
+		if pErr := c.createLabel(ctx, tx, l); pErr != nil {
+			pErr.Op = op
+			return pErr
+		}
+		return nil
 	})
 }
 
-func (c *Client) createLabel(ctx context.Context, tx *bolt.Tx, l *platform.Label) error {
-	unique := c.uniqueLabel(ctx, tx, l)
+func (c *Client) createLabel(ctx context.Context, tx *bolt.Tx, l *platform.Label) *platform.Error {
+	if err := l.Validate(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
+	}
 
-	if !unique {
-		return fmt.Errorf("label %s already exists", l.Name)
+	if pErr := c.uniqueLabelName(ctx, tx, l); pErr != nil {
+		return pErr
 	}
 
+	l.ID = c.IDGenerator.ID()
+
+	return c.putLabel(ctx, tx, l)
+}
+
+// uniqueLabelName returns an error if another label of the organization of
+// l has the same name.
+func (c *Client) uniqueLabelName(ctx context.Context, tx *bolt.Tx, l *platform.Label) *platform.Error {
+	ls, err := c.findLabels(ctx, tx, platform.LabelFilter{
+		OrganizationID: &l.OrganizationID,
+		Name:           l.Name,
+	})
+	if err != nil {
+		return &platform.Error{
+			Err: err,
+		}
+	}
+
+	for _, existing := range ls {
+		if existing.ID != l.ID {
+			return &platform.Error{
+				Code: platform.EConflict,
+				Msg:  fmt.Sprintf("label %s already exists", l.Name),
+			}
+		}
+	}
+
+	return nil
+}
+
+// PutLabel stores a label with its current ID.
+func (c *Client) PutLabel(ctx context.Context, l *platform.Label) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		if pErr := c.putLabel(ctx, tx, l); pErr != nil {
+			return pErr
+		}
+		return nil
+	})
+}
+
+func (c *Client) putLabel(ctx context.Context, tx *bolt.Tx, l *platform.Label) *platform.Error {
 	v, err := json.Marshal(l)
 	if err != nil {
-		return err
+		return &platform.Error{
+			Err: err,
+		}
 	}
 
-	key, err := labelKey(l)
+	encodedID, err := l.ID.Encode()
 	if err != nil {
-		return err
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
 	}
 
-	if err := tx.Bucket(labelBucket).Put(key, v); err != nil {
-		return err
+	if err := tx.Bucket(labelBucket).Put(encodedID, v); err != nil {
+		return &platform.Error{
+			Err: err,
+		}
 	}
 
 	return nil
 }
 
-func labelKey(l *platform.Label) ([]byte, error) {
-	encodedResourceID, err := l.ResourceID.Encode()
+// CreateLabelMapping applies a label to a resource.
+func (c *Client) CreateLabelMapping(ctx context.Context, m *platform.LabelMapping) error {
+	op := "bolt/create label mapping"
+	return c.db.Update(func(tx *bolt.Tx) error {
+		if pErr := c.createLabelMapping(ctx, tx, m); pErr != nil {
+			pErr.Op = op
+			return pErr
+		}
+		return nil
+	})
+}
+
+func (c *Client) createLabelMapping(ctx context.Context, tx *bolt.Tx, m *platform.LabelMapping) *platform.Error {
+	if err := m.Validate(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
+	}
+
+	if _, pErr := c.findLabelByID(ctx, tx, m.LabelID); pErr != nil {
+		return pErr
+	}
+
+	key, err := labelMappingKey(m)
+	if err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
+	}
+
+	if v := tx.Bucket(labelMappingBucket).Get(key); len(v) != 0 {
+		return &platform.Error{
+			Code: platform.EConflict,
+			Msg:  "label is already applied to the resource",
+		}
+	}
+
+	v, err := json.Marshal(m)
+	if err != nil {
+		return &platform.Error{
+			Err: err,
+		}
+	}
+
+	if err := tx.Bucket(labelMappingBucket).Put(key, v); err != nil {
+		return &platform.Error{
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+// labelMappingKey is the resource ID followed by the label ID, so that the
+// labels of a resource can be found with a prefix scan.
+func labelMappingKey(m *platform.LabelMapping) ([]byte, error) {
+	encodedResourceID, err := m.ResourceID.Encode()
 	if err != nil {
 		return nil, err
 	}
 
-	key := make([]byte, len(encodedResourceID)+len(l.Name))
+	encodedLabelID, err := m.LabelID.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	key := make([]byte, len(encodedResourceID)+len(encodedLabelID))
 	copy(key, encodedResourceID)
-	copy(key[len(encodedResourceID):], l.Name)
+	copy(key[len(encodedResourceID):], encodedLabelID)
 
 	return key, nil
 }
 
+// UpdateLabel updates a label with a changeset.
+func (c *Client) UpdateLabel(ctx context.Context, id platform.ID, upd platform.LabelUpdate) (*platform.Label, error) {
+	var l *platform.Label
+	op := "bolt/update label"
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		label, pErr := c.updateLabel(ctx, tx, id, upd)
+		if pErr != nil {
+			pErr.Op = op
+			return pErr
+		}
+		l = label
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (c *Client) updateLabel(ctx context.Context, tx *bolt.Tx, id platform.ID, upd platform.LabelUpdate) (*platform.Label, *platform.Error) {
+	l, pErr := c.findLabelByID(ctx, tx, id)
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	if err := upd.Apply(l); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
+	}
+
+	if pErr := c.uniqueLabelName(ctx, tx, l); pErr != nil {
+		return nil, pErr
+	}
+
+	if pErr := c.putLabel(ctx, tx, l); pErr != nil {
+		return nil, pErr
+	}
+
+	return l, nil
+}
+
 func (c *Client) forEachLabel(ctx context.Context, tx *bolt.Tx, fn func(*platform.Label) bool) error {
 	cur := tx.Bucket(labelBucket).Cursor()
 	for k, v := cur.First(); k != nil; k, v = cur.Next() {
@@ -121,53 +582,159 @@ func (c *Client) forEachLabel(ctx context.Context, tx *bolt.Tx, fn func(*platfor
 	return nil
 }
 
-func (c *Client) uniqueLabel(ctx context.Context, tx *bolt.Tx, l *platform.Label) bool {
-	key, err := labelKey(l)
+func (c *Client) forEachLabelMapping(ctx context.Context, tx *bolt.Tx, fn func(*platform.LabelMapping) error) error {
+	cur := tx.Bucket(labelMappingBucket).Cursor()
+	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+		m := &platform.LabelMapping{}
+		if err := json.Unmarshal(v, m); err != nil {
+			return err
+		}
+		if err := fn(m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) forEachResourceLabelMapping(ctx context.Context, tx *bolt.Tx, resourceID platform.ID, fn func(*platform.LabelMapping) error) error {
+	prefix, err := resourceID.Encode()
 	if err != nil {
-		return false
+		return err
 	}
 
-	v := tx.Bucket(labelBucket).Get(key)
-	return len(v) == 0
+	cur := tx.Bucket(labelMappingBucket).Cursor()
+	for k, v := cur.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cur.Next() {
+		m := &platform.LabelMapping{}
+		if err := json.Unmarshal(v, m); err != nil {
+			return err
+		}
+		if err := fn(m); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-// DeleteLabel deletes a label.
-func (c *Client) DeleteLabel(ctx context.Context, l platform.Label) error {
+// DeleteLabel deletes a label and removes it from every resource.
+func (c *Client) DeleteLabel(ctx context.Context, id platform.ID) error {
+	op := "bolt/delete label"
 	return c.db.Update(func(tx *bolt.Tx) error {
-		return c.deleteLabel(ctx, tx, platform.LabelFilter(l))
+		if pErr := c.deleteLabel(ctx, tx, id); pErr != nil {
+			pErr.Op = op
+			return pErr
+		}
+		return nil
 	})
 }
 
-func (c *Client) deleteLabel(ctx context.Context, tx *bolt.Tx, filter platform.LabelFilter) error {
-	ls, err := c.findLabels(ctx, tx, filter)
+func (c *Client) deleteLabel(ctx context.Context, tx *bolt.Tx, id platform.ID) *platform.Error {
+	l, pErr := c.findLabelByID(ctx, tx, id)
+	if pErr != nil {
+		return pErr
+	}
+
+	var keys [][]byte
+	err := c.forEachLabelMapping(ctx, tx, func(m *platform.LabelMapping) error {
+		if m.LabelID != l.ID {
+			return nil
+		}
+		key, err := labelMappingKey(m)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+		return nil
+	})
 	if err != nil {
-		return err
+		return &platform.Error{
+			Err: err,
+		}
 	}
-	if len(ls) == 0 {
-		return platform.ErrLabelNotFound
+
+	for _, key := range keys {
+		if err := tx.Bucket(labelMappingBucket).Delete(key); err != nil {
+			return &platform.Error{
+				Err: err,
+			}
+		}
 	}
 
-	key, err := labelKey(ls[0])
+	encodedID, err := l.ID.Encode()
 	if err != nil {
-		return err
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
+	}
+
+	if err := tx.Bucket(labelBucket).Delete(encodedID); err != nil {
+		return &platform.Error{
+			Err: err,
+		}
 	}
 
-	return tx.Bucket(labelBucket).Delete(key)
+	return nil
 }
 
-func (c *Client) deleteLabels(ctx context.Context, tx *bolt.Tx, filter platform.LabelFilter) error {
-	ls, err := c.findLabels(ctx, tx, filter)
+// DeleteLabelMapping removes a label from a resource.
+func (c *Client) DeleteLabelMapping(ctx context.Context, m *platform.LabelMapping) error {
+	op := "bolt/delete label mapping"
+	return c.db.Update(func(tx *bolt.Tx) error {
+		if pErr := c.deleteLabelMapping(ctx, tx, m); pErr != nil {
+			pErr.Op = op
+			return pErr
+		}
+		return nil
+	})
+}
+
+func (c *Client) deleteLabelMapping(ctx context.Context, tx *bolt.Tx, m *platform.LabelMapping) *platform.Error {
+	key, err := labelMappingKey(m)
 	if err != nil {
-		return err
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
 	}
-	for _, l := range ls {
-		key, err := labelKey(l)
+
+	if v := tx.Bucket(labelMappingBucket).Get(key); len(v) == 0 {
+		return &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  "label is not applied to the resource",
+		}
+	}
+
+	if err := tx.Bucket(labelMappingBucket).Delete(key); err != nil {
+		return &platform.Error{
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+// deleteResourceLabelMappings removes every label from a resource.
+func (c *Client) deleteResourceLabelMappings(ctx context.Context, tx *bolt.Tx, resourceID platform.ID) error {
+	var keys [][]byte
+	err := c.forEachResourceLabelMapping(ctx, tx, resourceID, func(m *platform.LabelMapping) error {
+		key, err := labelMappingKey(m)
 		if err != nil {
 			return err
 		}
-		if err = tx.Bucket(labelBucket).Delete(key); err != nil {
+		keys = append(keys, key)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := tx.Bucket(labelMappingBucket).Delete(key); err != nil {
 			return err
 		}
 	}
+
 	return nil
 }
@@ -2,8 +2,11 @@ package bolt_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
+	bbolt "github.com/coreos/bbolt"
 	"github.com/influxdata/platform"
 	platformtesting "github.com/influxdata/platform/testing"
 )
@@ -13,17 +16,23 @@ func initLabelService(f platformtesting.LabelFields, t *testing.T) (platform.Lab
 	if err != nil {
 		t.Fatalf("failed to create new bolt client: %v", err)
 	}
+	c.IDGenerator = f.IDGenerator
 	ctx := context.Background()
 	for _, l := range f.Labels {
-		if err := c.CreateLabel(ctx, l); err != nil {
-			t.Fatalf("failed to populate labels")
+		if err := c.PutLabel(ctx, l); err != nil {
+			t.Fatalf("failed to populate labels: %v", err)
+		}
+	}
+	for _, m := range f.Mappings {
+		if err := c.CreateLabelMapping(ctx, m); err != nil {
+			t.Fatalf("failed to populate label mappings: %v", err)
 		}
 	}
 
 	return c, func() {
 		defer closeFn()
 		for _, l := range f.Labels {
-			if err := c.DeleteLabel(ctx, *l); err != nil {
+			if err := c.DeleteLabel(ctx, l.ID); err != nil {
 				t.Logf("failed to remove label: %v", err)
 			}
 		}
@@ -33,3 +42,97 @@ func initLabelService(f platformtesting.LabelFields, t *testing.T) (platform.Lab
 func TestLabelService_LabelService(t *testing.T) {
 	platformtesting.LabelService(initLabelService, t)
 }
+
+func TestClient_MigrateLegacyLabels(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeFn()
+	ctx := context.Background()
+
+	org := &platform.Organization{Name: "o"}
+	if err := c.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+	b := &platform.Bucket{Name: "b", OrganizationID: org.ID}
+	if err := c.CreateBucket(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	d := &platform.Dashboard{Name: "d"}
+	if err := c.CreateDashboard(ctx, d); err != nil {
+		t.Fatal(err)
+	}
+	userID := platformtesting.MustIDBase16("020f755c3c082010")
+	for _, m := range []*platform.UserResourceMapping{
+		{ResourceID: org.ID, ResourceType: platform.OrgResourceType, UserID: userID, UserType: platform.Owner},
+		{ResourceID: d.ID, ResourceType: platform.DashboardResourceType, UserID: userID, UserType: platform.Owner},
+	} {
+		if err := c.CreateUserResourceMapping(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Write labels in the format of earlier versions and reopen the client.
+	orphanID := platformtesting.MustIDBase16("020f755c3c082099")
+	err = c.DB().Update(func(tx *bbolt.Tx) error {
+		lb, err := tx.CreateBucketIfNotExists([]byte("labelsv1"))
+		if err != nil {
+			return err
+		}
+		for _, l := range []struct {
+			id   platform.ID
+			name string
+		}{{b.ID, "prod"}, {d.ID, "prod"}, {orphanID, "lost"}} {
+			encodedID, _ := l.id.Encode()
+			v := fmt.Sprintf(`{"resource_id":%q,"name":%q}`, l.id, l.name)
+			if err := lb.Put(append(encodedID, l.name...), []byte(v)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	ls, err := c.FindLabels(ctx, platform.LabelFilter{OrganizationID: &org.ID})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(ls) != 1 || ls[0].Name != "prod" {
+		t.Fatalf("got labels %+v, expected a single label named prod", ls)
+	}
+
+	for _, m := range []platform.LabelMappingFilter{
+		{ResourceID: b.ID, ResourceType: platform.BucketResourceType},
+		{ResourceID: d.ID, ResourceType: platform.DashboardResourceType},
+	} {
+		ls, err := c.FindResourceLabels(ctx, m)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(ls) != 1 || ls[0].Name != "prod" {
+			t.Fatalf("got labels %+v of %s %s, expected prod", ls, m.ResourceType, m.ResourceID)
+		}
+	}
+
+	// The label of a resource without organization is kept as is.
+	err = c.DB().View(func(tx *bbolt.Tx) error {
+		lb := tx.Bucket([]byte("labelsv1"))
+		if lb == nil {
+			return errors.New("legacy labels were removed")
+		}
+		if n := lb.Stats().KeyN; n != 1 {
+			return fmt.Errorf("got %d legacy labels, expected 1", n)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,177 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+)
+
+var (
+	notificationEndpointBucket = []byte("notificationendpointsv1")
+)
+
+var _ platform.NotificationEndpointService = (*Client)(nil)
+
+func (c *Client) initializeNotificationEndpoints(ctx context.Context, tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(notificationEndpointBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindNotificationEndpointByID returns a single notification endpoint by ID.
+func (c *Client) FindNotificationEndpointByID(ctx context.Context, id platform.ID) (*platform.NotificationEndpoint, error) {
+	var e *platform.NotificationEndpoint
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var err error
+		e, err = c.findNotificationEndpointByID(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "bolt/find notification endpoint by id",
+			Err:  err,
+		}
+	}
+	return e, nil
+}
+
+func (c *Client) findNotificationEndpointByID(ctx context.Context, tx *bolt.Tx, id platform.ID) (*platform.NotificationEndpoint, error) {
+	encodedID, err := id.Encode()
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
+	}
+	v := tx.Bucket(notificationEndpointBucket).Get(encodedID)
+	if v == nil {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  fmt.Sprintf("notification endpoint with ID %v not found", id),
+		}
+	}
+	e := &platform.NotificationEndpoint{}
+	if err := json.Unmarshal(v, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// FindNotificationEndpoints returns the notification endpoints that match filter.
+func (c *Client) FindNotificationEndpoints(ctx context.Context, filter platform.NotificationEndpointFilter) ([]*platform.NotificationEndpoint, error) {
+	es := []*platform.NotificationEndpoint{}
+	err := c.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(notificationEndpointBucket).ForEach(func(k, v []byte) error {
+			e := &platform.NotificationEndpoint{}
+			if err := json.Unmarshal(v, e); err != nil {
+				return err
+			}
+			if filter.OrganizationID != nil && e.OrganizationID != *filter.OrganizationID {
+				return nil
+			}
+			es = append(es, e)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Op:  "bolt/find notification endpoints",
+			Err: err,
+		}
+	}
+	return es, nil
+}
+
+// CreateNotificationEndpoint creates a new notification endpoint and sets e.ID with the new identifier.
+func (c *Client) CreateNotificationEndpoint(ctx context.Context, e *platform.NotificationEndpoint) error {
+	op := "bolt/create notification endpoint"
+	if err := e.Valid(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Err:  err,
+		}
+	}
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		e.ID = c.IDGenerator.ID()
+		return c.putNotificationEndpoint(ctx, tx, e)
+	})
+	if err != nil {
+		return &platform.Error{
+			Op:  op,
+			Err: err,
+		}
+	}
+	return nil
+}
+
+// PutNotificationEndpoint will put a notification endpoint without setting an ID.
+func (c *Client) PutNotificationEndpoint(ctx context.Context, e *platform.NotificationEndpoint) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return c.putNotificationEndpoint(ctx, tx, e)
+	})
+}
+
+func (c *Client) putNotificationEndpoint(ctx context.Context, tx *bolt.Tx, e *platform.NotificationEndpoint) error {
+	encodedID, err := e.ID.Encode()
+	if err != nil {
+		return err
+	}
+	v, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(notificationEndpointBucket).Put(encodedID, v)
+}
+
+// UpdateNotificationEndpoint updates a single notification endpoint with a changeset and returns the updated endpoint.
+func (c *Client) UpdateNotificationEndpoint(ctx context.Context, id platform.ID, upd platform.NotificationEndpointUpdate) (*platform.NotificationEndpoint, error) {
+	var e *platform.NotificationEndpoint
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		e, err = c.findNotificationEndpointByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		upd.Apply(e)
+		if err := e.Valid(); err != nil {
+			return err
+		}
+		return c.putNotificationEndpoint(ctx, tx, e)
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "bolt/update notification endpoint",
+			Err:  err,
+		}
+	}
+	return e, nil
+}
+
+// DeleteNotificationEndpoint removes a notification endpoint by ID.
+func (c *Client) DeleteNotificationEndpoint(ctx context.Context, id platform.ID) error {
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		if _, err := c.findNotificationEndpointByID(ctx, tx, id); err != nil {
+			return err
+		}
+		encodedID, err := id.Encode()
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(notificationEndpointBucket).Delete(encodedID)
+	})
+	if err != nil {
+		return &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "bolt/delete notification endpoint",
+			Err:  err,
+		}
+	}
+	return nil
+}
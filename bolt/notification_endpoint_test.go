@@ -0,0 +1,30 @@
+package bolt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initNotificationEndpointService(f platformtesting.NotificationEndpointFields, t *testing.T) (platform.NotificationEndpointService, func()) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	if f.IDGenerator != nil {
+		c.IDGenerator = f.IDGenerator
+	}
+	ctx := context.TODO()
+	for _, v := range f.NotificationEndpoints {
+		if err := c.PutNotificationEndpoint(ctx, v); err != nil {
+			t.Fatalf("failed to populate notification endpoints: %v", err)
+		}
+	}
+	return c, closeFn
+}
+
+func TestNotificationEndpointService(t *testing.T) {
+	platformtesting.NotificationEndpointService(initNotificationEndpointService, t)
+}
@@ -0,0 +1,180 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+)
+
+var (
+	replicationRuleBucket = []byte("replicationrulesv1")
+)
+
+var _ platform.ReplicationRuleService = (*Client)(nil)
+
+func (c *Client) initializeReplicationRules(ctx context.Context, tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(replicationRuleBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindReplicationRuleByID returns a single replication rule by ID.
+func (c *Client) FindReplicationRuleByID(ctx context.Context, id platform.ID) (*platform.ReplicationRule, error) {
+	var r *platform.ReplicationRule
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var err error
+		r, err = c.findReplicationRuleByID(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "bolt/find replication rule by id",
+			Err:  err,
+		}
+	}
+	return r, nil
+}
+
+func (c *Client) findReplicationRuleByID(ctx context.Context, tx *bolt.Tx, id platform.ID) (*platform.ReplicationRule, error) {
+	encodedID, err := id.Encode()
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
+	}
+	v := tx.Bucket(replicationRuleBucket).Get(encodedID)
+	if v == nil {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  fmt.Sprintf("replication rule with ID %v not found", id),
+		}
+	}
+	r := &platform.ReplicationRule{}
+	if err := json.Unmarshal(v, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// FindReplicationRules returns the replication rules that match filter.
+func (c *Client) FindReplicationRules(ctx context.Context, filter platform.ReplicationRuleFilter) ([]*platform.ReplicationRule, error) {
+	rs := []*platform.ReplicationRule{}
+	err := c.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(replicationRuleBucket).ForEach(func(k, v []byte) error {
+			r := &platform.ReplicationRule{}
+			if err := json.Unmarshal(v, r); err != nil {
+				return err
+			}
+			if filter.OrganizationID != nil && r.OrganizationID != *filter.OrganizationID {
+				return nil
+			}
+			if filter.BucketID != nil && r.BucketID != *filter.BucketID {
+				return nil
+			}
+			rs = append(rs, r)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Op:  "bolt/find replication rules",
+			Err: err,
+		}
+	}
+	return rs, nil
+}
+
+// CreateReplicationRule creates a new replication rule and sets r.ID with the new identifier.
+func (c *Client) CreateReplicationRule(ctx context.Context, r *platform.ReplicationRule) error {
+	op := "bolt/create replication rule"
+	if err := r.Valid(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Err:  err,
+		}
+	}
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		r.ID = c.IDGenerator.ID()
+		return c.putReplicationRule(ctx, tx, r)
+	})
+	if err != nil {
+		return &platform.Error{
+			Op:  op,
+			Err: err,
+		}
+	}
+	return nil
+}
+
+// PutReplicationRule will put a replication rule without setting an ID.
+func (c *Client) PutReplicationRule(ctx context.Context, r *platform.ReplicationRule) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return c.putReplicationRule(ctx, tx, r)
+	})
+}
+
+func (c *Client) putReplicationRule(ctx context.Context, tx *bolt.Tx, r *platform.ReplicationRule) error {
+	encodedID, err := r.ID.Encode()
+	if err != nil {
+		return err
+	}
+	v, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(replicationRuleBucket).Put(encodedID, v)
+}
+
+// UpdateReplicationRule updates a single replication rule with a changeset and returns the updated endpoint.
+func (c *Client) UpdateReplicationRule(ctx context.Context, id platform.ID, upd platform.ReplicationRuleUpdate) (*platform.ReplicationRule, error) {
+	var r *platform.ReplicationRule
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		r, err = c.findReplicationRuleByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		upd.Apply(r)
+		if err := r.Valid(); err != nil {
+			return err
+		}
+		return c.putReplicationRule(ctx, tx, r)
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "bolt/update replication rule",
+			Err:  err,
+		}
+	}
+	return r, nil
+}
+
+// DeleteReplicationRule removes a replication rule by ID.
+func (c *Client) DeleteReplicationRule(ctx context.Context, id platform.ID) error {
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		if _, err := c.findReplicationRuleByID(ctx, tx, id); err != nil {
+			return err
+		}
+		encodedID, err := id.Encode()
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(replicationRuleBucket).Delete(encodedID)
+	})
+	if err != nil {
+		return &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "bolt/delete replication rule",
+			Err:  err,
+		}
+	}
+	return nil
+}
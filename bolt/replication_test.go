@@ -0,0 +1,30 @@
+package bolt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initReplicationRuleService(f platformtesting.ReplicationRuleFields, t *testing.T) (platform.ReplicationRuleService, func()) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	if f.IDGenerator != nil {
+		c.IDGenerator = f.IDGenerator
+	}
+	ctx := context.TODO()
+	for _, v := range f.ReplicationRules {
+		if err := c.PutReplicationRule(ctx, v); err != nil {
+			t.Fatalf("failed to populate replication rules: %v", err)
+		}
+	}
+	return c, closeFn
+}
+
+func TestReplicationRuleService(t *testing.T) {
+	platformtesting.ReplicationRuleService(initReplicationRuleService, t)
+}
@@ -0,0 +1,191 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+)
+
+var (
+	taskTemplateBucket = []byte("tasktemplatesv1")
+)
+
+var _ platform.TaskTemplateService = (*Client)(nil)
+
+func (c *Client) initializeTaskTemplates(ctx context.Context, tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(taskTemplateBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindTaskTemplateByID returns a single task template by ID.
+func (c *Client) FindTaskTemplateByID(ctx context.Context, id platform.ID) (*platform.TaskTemplate, error) {
+	var t *platform.TaskTemplate
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var err error
+		t, err = c.findTaskTemplateByID(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "bolt/find task template by id",
+			Err:  err,
+		}
+	}
+	return t, nil
+}
+
+func (c *Client) findTaskTemplateByID(ctx context.Context, tx *bolt.Tx, id platform.ID) (*platform.TaskTemplate, error) {
+	encodedID, err := id.Encode()
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
+	}
+	v := tx.Bucket(taskTemplateBucket).Get(encodedID)
+	if v == nil {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  fmt.Sprintf("task template with ID %v not found", id),
+		}
+	}
+	t := &platform.TaskTemplate{}
+	if err := json.Unmarshal(v, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// FindTaskTemplates returns the task templates that match filter.
+func (c *Client) FindTaskTemplates(ctx context.Context, filter platform.TaskTemplateFilter) ([]*platform.TaskTemplate, error) {
+	ts := []*platform.TaskTemplate{}
+	err := c.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(taskTemplateBucket).ForEach(func(k, v []byte) error {
+			t := &platform.TaskTemplate{}
+			if err := json.Unmarshal(v, t); err != nil {
+				return err
+			}
+			if filterTaskTemplate(t, filter) {
+				ts = append(ts, t)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Op:  "bolt/find task templates",
+			Err: err,
+		}
+	}
+	return ts, nil
+}
+
+func filterTaskTemplate(t *platform.TaskTemplate, filter platform.TaskTemplateFilter) bool {
+	if filter.OrganizationID != nil && t.OrganizationID != *filter.OrganizationID {
+		return false
+	}
+	if filter.TaskID != nil {
+		for _, inst := range t.Instances {
+			if inst.TaskID == *filter.TaskID {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// CreateTaskTemplate creates a new task template and sets t.ID with the new identifier.
+func (c *Client) CreateTaskTemplate(ctx context.Context, t *platform.TaskTemplate) error {
+	op := "bolt/create task template"
+	if err := t.Valid(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Err:  err,
+		}
+	}
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		t.ID = c.IDGenerator.ID()
+		return c.putTaskTemplate(ctx, tx, t)
+	})
+	if err != nil {
+		return &platform.Error{
+			Op:  op,
+			Err: err,
+		}
+	}
+	return nil
+}
+
+// PutTaskTemplate will put a task template without setting an ID.
+func (c *Client) PutTaskTemplate(ctx context.Context, t *platform.TaskTemplate) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return c.putTaskTemplate(ctx, tx, t)
+	})
+}
+
+func (c *Client) putTaskTemplate(ctx context.Context, tx *bolt.Tx, t *platform.TaskTemplate) error {
+	encodedID, err := t.ID.Encode()
+	if err != nil {
+		return err
+	}
+	v, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(taskTemplateBucket).Put(encodedID, v)
+}
+
+// UpdateTaskTemplate updates a single task template with a changeset and returns the updated template.
+func (c *Client) UpdateTaskTemplate(ctx context.Context, id platform.ID, upd platform.TaskTemplateUpdate) (*platform.TaskTemplate, error) {
+	var t *platform.TaskTemplate
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		t, err = c.findTaskTemplateByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		upd.Apply(t)
+		if err := t.Valid(); err != nil {
+			return err
+		}
+		return c.putTaskTemplate(ctx, tx, t)
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "bolt/update task template",
+			Err:  err,
+		}
+	}
+	return t, nil
+}
+
+// DeleteTaskTemplate removes a task template by ID.
+func (c *Client) DeleteTaskTemplate(ctx context.Context, id platform.ID) error {
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		if _, err := c.findTaskTemplateByID(ctx, tx, id); err != nil {
+			return err
+		}
+		encodedID, err := id.Encode()
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(taskTemplateBucket).Delete(encodedID)
+	})
+	if err != nil {
+		return &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "bolt/delete task template",
+			Err:  err,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package bolt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initTaskTemplateService(f platformtesting.TaskTemplateFields, t *testing.T) (platform.TaskTemplateService, func()) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	if f.IDGenerator != nil {
+		c.IDGenerator = f.IDGenerator
+	}
+	ctx := context.TODO()
+	for _, v := range f.TaskTemplates {
+		if err := c.PutTaskTemplate(ctx, v); err != nil {
+			t.Fatalf("failed to populate task templates: %v", err)
+		}
+	}
+	return c, closeFn
+}
+
+func TestTaskTemplateService(t *testing.T) {
+	platformtesting.TaskTemplateService(initTaskTemplateService, t)
+}
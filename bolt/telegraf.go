@@ -66,7 +66,7 @@ func (c *Client) findTelegrafConfigByID(ctx context.Context, tx *bolt.Tx, id pla
 // FindTelegrafConfig returns the first telegraf config that matches filter.
 func (c *Client) FindTelegrafConfig(ctx context.Context, filter platform.UserResourceMappingFilter) (*platform.TelegrafConfig, error) {
 	op := "bolt/find telegraf config"
-	tcs, n, err := c.FindTelegrafConfigs(ctx, filter)
+	tcs, n, err := c.FindTelegrafConfigs(ctx, platform.TelegrafConfigFilter{UserResourceMappingFilter: filter})
 	if err != nil {
 		return nil, err
 	}
@@ -79,9 +79,9 @@ func (c *Client) FindTelegrafConfig(ctx context.Context, filter platform.UserRes
 	}
 }
 
-func (c *Client) findTelegrafConfigs(ctx context.Context, tx *bolt.Tx, filter platform.UserResourceMappingFilter, opt ...platform.FindOptions) ([]*platform.TelegrafConfig, int, *platform.Error) {
+func (c *Client) findTelegrafConfigs(ctx context.Context, tx *bolt.Tx, filter platform.TelegrafConfigFilter, opt ...platform.FindOptions) ([]*platform.TelegrafConfig, int, *platform.Error) {
 	tcs := make([]*platform.TelegrafConfig, 0)
-	m, err := c.findUserResourceMappings(ctx, tx, filter)
+	m, err := c.findUserResourceMappings(ctx, tx, filter.UserResourceMappingFilter)
 	if err != nil {
 		return nil, 0, &platform.Error{
 			Err: err,
@@ -90,7 +90,18 @@ func (c *Client) findTelegrafConfigs(ctx context.Context, tx *bolt.Tx, filter pl
 	if len(m) == 0 {
 		return tcs, 0, nil
 	}
+	var labelled platform.LabelledResources
+	if filter.Label != nil {
+		if labelled, err = c.labelledResources(ctx, tx, platform.TelegrafResourceType, *filter.Label); err != nil {
+			return nil, 0, &platform.Error{
+				Err: err,
+			}
+		}
+	}
 	for _, item := range m {
+		if labelled != nil && labelled[item.ResourceID] == nil {
+			continue
+		}
 		tc, err := c.findTelegrafConfigByID(ctx, tx, item.ResourceID)
 		if err != nil {
 			return nil, 0, &platform.Error{
@@ -100,7 +111,7 @@ func (c *Client) findTelegrafConfigs(ctx context.Context, tx *bolt.Tx, filter pl
 		}
 		tcs = append(tcs, tc)
 	}
-	if len(tcs) == 0 {
+	if len(tcs) == 0 && labelled == nil {
 		return nil, 0, &platform.Error{
 			Msg: "inconsistent user resource mapping and telegraf config",
 		}
@@ -110,7 +121,7 @@ func (c *Client) findTelegrafConfigs(ctx context.Context, tx *bolt.Tx, filter pl
 
 // FindTelegrafConfigs returns a list of telegraf configs that match filter and the total count of matching telegraf configs.
 // Additional options provide pagination & sorting.
-func (c *Client) FindTelegrafConfigs(ctx context.Context, filter platform.UserResourceMappingFilter, opt ...platform.FindOptions) (tcs []*platform.TelegrafConfig, n int, err error) {
+func (c *Client) FindTelegrafConfigs(ctx context.Context, filter platform.TelegrafConfigFilter, opt ...platform.FindOptions) (tcs []*platform.TelegrafConfig, n int, err error) {
 	op := "bolt/find telegraf configs"
 	err = c.db.View(func(tx *bolt.Tx) error {
 		var pErr *platform.Error
@@ -213,6 +224,12 @@ func (c *Client) DeleteTelegrafConfig(ctx context.Context, id platform.ID) error
 		if err != nil {
 			return err
 		}
+		if err := c.deleteResourceLabelMappings(ctx, tx, id); err != nil {
+			return err
+		}
+		if err := c.deleteTelegrafAgents(ctx, tx, id); err != nil {
+			return err
+		}
 		return c.deleteUserResourceMappings(ctx, tx, platform.UserResourceMappingFilter{
 			ResourceID:   id,
 			ResourceType: platform.TelegrafResourceType,
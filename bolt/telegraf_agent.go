@@ -0,0 +1,121 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+)
+
+var (
+	telegrafAgentBucket = []byte("telegrafagentsv1")
+)
+
+var _ platform.TelegrafAgentService = (*Client)(nil)
+
+func (c *Client) initializeTelegrafAgents(ctx context.Context, tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(telegrafAgentBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// telegrafAgentKey is the telegraf config ID followed by the agent hostname,
+// so that the agents of a config can be found by prefix.
+func telegrafAgentKey(a *platform.TelegrafAgent) ([]byte, error) {
+	encodedID, err := a.TelegrafConfigID.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return append(encodedID, []byte(a.Hostname)...), nil
+}
+
+// FindTelegrafAgents returns the telegraf agents that match filter.
+func (c *Client) FindTelegrafAgents(ctx context.Context, filter platform.TelegrafAgentFilter) ([]*platform.TelegrafAgent, error) {
+	op := "bolt/find telegraf agents"
+	as := []*platform.TelegrafAgent{}
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var prefix []byte
+		if filter.TelegrafConfigID != nil {
+			encodedID, err := filter.TelegrafConfigID.Encode()
+			if err != nil {
+				return &platform.Error{
+					Code: platform.EInvalid,
+					Err:  err,
+				}
+			}
+			prefix = encodedID
+		}
+
+		cur := tx.Bucket(telegrafAgentBucket).Cursor()
+		for k, v := cur.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cur.Next() {
+			a := &platform.TelegrafAgent{}
+			if err := json.Unmarshal(v, a); err != nil {
+				return &platform.Error{
+					Err: err,
+				}
+			}
+			if filter.Hostname != nil && a.Hostname != *filter.Hostname {
+				continue
+			}
+			as = append(as, a)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   op,
+			Err:  err,
+		}
+	}
+	return as, nil
+}
+
+// CheckInTelegrafAgent records that an agent fetched its config, adding the
+// agent if it has not checked in before.
+func (c *Client) CheckInTelegrafAgent(ctx context.Context, a *platform.TelegrafAgent) error {
+	op := "bolt/check in telegraf agent"
+	if err := a.Valid(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Err:  err,
+		}
+	}
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		key, err := telegrafAgentKey(a)
+		if err != nil {
+			return err
+		}
+		v, err := json.Marshal(a)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(telegrafAgentBucket).Put(key, v)
+	})
+	if err != nil {
+		return &platform.Error{
+			Op:  op,
+			Err: err,
+		}
+	}
+	return nil
+}
+
+func (c *Client) deleteTelegrafAgents(ctx context.Context, tx *bolt.Tx, id platform.ID) error {
+	prefix, err := id.Encode()
+	if err != nil {
+		return err
+	}
+	b := tx.Bucket(telegrafAgentBucket)
+	cur := b.Cursor()
+	for k, _ := cur.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cur.Seek(prefix) {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package bolt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initTelegrafAgentService(f platformtesting.TelegrafAgentFields, t *testing.T) (platform.TelegrafAgentService, func()) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	ctx := context.TODO()
+	for _, a := range f.TelegrafAgents {
+		if err := c.CheckInTelegrafAgent(ctx, a); err != nil {
+			t.Fatalf("failed to populate telegraf agents: %v", err)
+		}
+	}
+	return c, closeFn
+}
+
+func TestTelegrafAgentService(t *testing.T) {
+	platformtesting.TelegrafAgentService(initTelegrafAgentService, t)
+}
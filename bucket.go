@@ -25,6 +25,37 @@ type Bucket struct {
 	Name                string        `json:"name"`
 	RetentionPolicyName string        `json:"rp,omitempty"` // This to support v1 sources
 	RetentionPeriod     time.Duration `json:"retentionPeriod"`
+	// ShardGroupDuration is the span of time of the data of the bucket that
+	// storage keeps together, and drops together once it expires. When zero,
+	// it defaults to DefaultShardGroupDuration of the retention period.
+	ShardGroupDuration time.Duration `json:"shardGroupDuration,omitempty"`
+	// Schema declares the data that may be written to the bucket. Any data
+	// may be written to buckets without a schema.
+	Schema *BucketSchema `json:"schema,omitempty"`
+}
+
+// DefaultShardGroupDuration returns the shard group duration of buckets with
+// the retention period rp that do not set one.
+func DefaultShardGroupDuration(rp time.Duration) time.Duration {
+	switch {
+	case rp == InfiniteRetention:
+		return 7 * 24 * time.Hour
+	case rp < 2*24*time.Hour:
+		return time.Hour
+	case rp < 180*24*time.Hour:
+		return 24 * time.Hour
+	default:
+		return 7 * 24 * time.Hour
+	}
+}
+
+// EffectiveShardGroupDuration returns the shard group duration of the bucket,
+// defaulting it from the retention period when unset.
+func (b *Bucket) EffectiveShardGroupDuration() time.Duration {
+	if b.ShardGroupDuration > 0 {
+		return b.ShardGroupDuration
+	}
+	return DefaultShardGroupDuration(b.RetentionPeriod)
 }
 
 // ops for buckets error and buckets op logs.
@@ -63,8 +94,9 @@ type BucketService interface {
 // BucketUpdate represents updates to a bucket.
 // Only fields which are set are updated.
 type BucketUpdate struct {
-	Name            *string        `json:"name,omitempty"`
-	RetentionPeriod *time.Duration `json:"retentionPeriod,omitempty"`
+	Name               *string        `json:"name,omitempty"`
+	RetentionPeriod    *time.Duration `json:"retentionPeriod,omitempty"`
+	ShardGroupDuration *time.Duration `json:"shardGroupDuration,omitempty"`
 }
 
 // BucketFilter represents a set of filter that restrict the returned results.
@@ -73,6 +105,8 @@ type BucketFilter struct {
 	Name           *string
 	OrganizationID *ID
 	Organization   *string
+	// Label restricts the buckets to those with a label of this name.
+	Label *string
 }
 
 // FindOptions represents options passed to all find methods with multiple results.
@@ -0,0 +1,152 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+)
+
+// ops for bucket schemas error and bucket schemas op logs.
+var (
+	OpFindBucketSchema = "FindBucketSchema"
+	OpSetBucketSchema  = "SetBucketSchema"
+)
+
+// BucketSchemaService represents a service for managing the schemas of buckets.
+type BucketSchemaService interface {
+	// FindBucketSchema returns the schema of the bucket with the provided id,
+	// or nil if the bucket has no schema.
+	FindBucketSchema(ctx context.Context, id ID) (*BucketSchema, error)
+
+	// SetBucketSchema sets the schema of the bucket with the provided id. A nil
+	// schema removes the schema of the bucket.
+	SetBucketSchema(ctx context.Context, id ID, s *BucketSchema) error
+}
+
+// SchemaFieldType is the type of the values of a field of a measurement schema.
+type SchemaFieldType string
+
+// Field types of measurement schemas.
+const (
+	SchemaFieldFloat    SchemaFieldType = "float"
+	SchemaFieldInteger  SchemaFieldType = "integer"
+	SchemaFieldUnsigned SchemaFieldType = "unsigned"
+	SchemaFieldString   SchemaFieldType = "string"
+	SchemaFieldBoolean  SchemaFieldType = "boolean"
+)
+
+// Valid returns true if t is a known field type.
+func (t SchemaFieldType) Valid() bool {
+	switch t {
+	case SchemaFieldFloat, SchemaFieldInteger, SchemaFieldUnsigned, SchemaFieldString, SchemaFieldBoolean:
+		return true
+	}
+	return false
+}
+
+// BucketSchema declares the measurements that may be written to a bucket,
+// along with their tag keys and typed fields. Writes to a bucket that do not
+// conform to its schema are dropped.
+type BucketSchema struct {
+	Measurements []MeasurementSchema `json:"measurements"`
+}
+
+// MeasurementSchema declares the tag keys and fields of a measurement.
+type MeasurementSchema struct {
+	Name   string        `json:"name"`
+	Tags   []string      `json:"tags,omitempty"`
+	Fields []FieldSchema `json:"fields"`
+	// AllowExtraColumns permits writes of tags and fields that the schema does
+	// not declare. Declared fields must still have their declared type.
+	AllowExtraColumns bool `json:"allowExtraColumns,omitempty"`
+}
+
+// FieldSchema declares a field of a measurement and the type of its values.
+type FieldSchema struct {
+	Name string          `json:"name"`
+	Type SchemaFieldType `json:"type"`
+}
+
+// reservedSchemaKeys are the tag keys and field names used by storage that
+// cannot be declared by a schema.
+var reservedSchemaKeys = map[string]bool{
+	"_measurement": true,
+	"_field":       true,
+	"_m":           true,
+	"_f":           true,
+	"time":         true,
+}
+
+// Validate returns an error if the schema is invalid.
+func (s *BucketSchema) Validate() error {
+	measurements := make(map[string]bool, len(s.Measurements))
+	for _, m := range s.Measurements {
+		if m.Name == "" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "measurement name is required",
+			}
+		}
+		if measurements[m.Name] {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("measurement %q is declared more than once", m.Name),
+			}
+		}
+		measurements[m.Name] = true
+
+		if err := m.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MeasurementSchema) validate() error {
+	columns := make(map[string]bool, len(m.Tags)+len(m.Fields))
+	column := func(name string) error {
+		switch {
+		case name == "":
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("measurement %q has a column without a name", m.Name),
+			}
+		case reservedSchemaKeys[name]:
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("measurement %q declares the reserved column %q", m.Name, name),
+			}
+		case columns[name]:
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("measurement %q declares column %q more than once", m.Name, name),
+			}
+		}
+		columns[name] = true
+		return nil
+	}
+
+	for _, tag := range m.Tags {
+		if err := column(tag); err != nil {
+			return err
+		}
+	}
+
+	if len(m.Fields) == 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("measurement %q must declare at least one field", m.Name),
+		}
+	}
+	for _, f := range m.Fields {
+		if err := column(f.Name); err != nil {
+			return err
+		}
+		if !f.Type.Valid() {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("field %q of measurement %q has invalid type %q", f.Name, m.Name, f.Type),
+			}
+		}
+	}
+	return nil
+}
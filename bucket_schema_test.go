@@ -0,0 +1,86 @@
+package platform_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/platform"
+)
+
+func TestBucketSchemaValidate(t *testing.T) {
+	float := []platform.FieldSchema{{Name: "value", Type: platform.SchemaFieldFloat}}
+
+	tests := []struct {
+		name    string
+		schema  platform.BucketSchema
+		wantErr bool
+	}{
+		{
+			name: "valid schema",
+			schema: platform.BucketSchema{
+				Measurements: []platform.MeasurementSchema{
+					{Name: "cpu", Tags: []string{"host"}, Fields: float},
+					{Name: "mem", Fields: float, AllowExtraColumns: true},
+				},
+			},
+		},
+		{
+			name: "measurement requires a name",
+			schema: platform.BucketSchema{
+				Measurements: []platform.MeasurementSchema{{Fields: float}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "measurement declared twice",
+			schema: platform.BucketSchema{
+				Measurements: []platform.MeasurementSchema{
+					{Name: "cpu", Fields: float},
+					{Name: "cpu", Fields: float},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "measurement requires a field",
+			schema: platform.BucketSchema{
+				Measurements: []platform.MeasurementSchema{{Name: "cpu", Tags: []string{"host"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "column declared as tag and field",
+			schema: platform.BucketSchema{
+				Measurements: []platform.MeasurementSchema{{Name: "cpu", Tags: []string{"value"}, Fields: float}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reserved column",
+			schema: platform.BucketSchema{
+				Measurements: []platform.MeasurementSchema{{Name: "cpu", Tags: []string{"_measurement"}, Fields: float}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid field type",
+			schema: platform.BucketSchema{
+				Measurements: []platform.MeasurementSchema{
+					{Name: "cpu", Fields: []platform.FieldSchema{{Name: "value", Type: "decimal"}}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schema.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.EInvalid {
+				t.Fatalf("Validate() error code = %s, want %s", platform.ErrorCode(err), platform.EInvalid)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package platform
+
+import (
+	"context"
+	"time"
+)
+
+// CheckType is the kind of condition a check evaluates.
+type CheckType string
+
+const (
+	// ThresholdCheck compares the latest value of each series against thresholds.
+	ThresholdCheck CheckType = "threshold"
+	// DeadmanCheck reports series that have not had data for longer than StaleAfter.
+	DeadmanCheck CheckType = "deadman"
+	// RelativeChangeCheck compares the percentage change in the latest value of each series,
+	// since the check was last evaluated, against thresholds.
+	RelativeChangeCheck CheckType = "relative_change"
+)
+
+// CheckLevel is the status a check assigns to a series.
+type CheckLevel string
+
+const (
+	// UnknownLevel is the level of a series that has not been evaluated.
+	UnknownLevel CheckLevel = "unknown"
+	OKLevel      CheckLevel = "ok"
+	InfoLevel    CheckLevel = "info"
+	WarnLevel    CheckLevel = "warn"
+	CritLevel    CheckLevel = "crit"
+)
+
+// Severity orders levels from least to most severe. Unrecognized levels have a severity of -1.
+func (l CheckLevel) Severity() int {
+	switch l {
+	case UnknownLevel:
+		return 0
+	case OKLevel:
+		return 1
+	case InfoLevel:
+		return 2
+	case WarnLevel:
+		return 3
+	case CritLevel:
+		return 4
+	}
+	return -1
+}
+
+// Check statuses.
+const (
+	CheckActive   = "active"
+	CheckInactive = "inactive"
+)
+
+// Check is a Flux query that is evaluated on a schedule, assigning a level to each series it returns.
+// When the level of a series changes, the new status is recorded and sent to the check's notification endpoints.
+type Check struct {
+	ID             ID        `json:"id,omitempty"`
+	OrganizationID ID        `json:"orgID"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description,omitempty"`
+	Type           CheckType `json:"type"`
+	Status         string    `json:"status"`
+
+	// Query is the Flux query to check. The last row of each table it returns is evaluated,
+	// so it should return a numeric _value for threshold and relative change checks.
+	Query string `json:"query"`
+	// Every is how often the check is evaluated, as a duration such as "1m".
+	Every string `json:"every"`
+
+	// Thresholds are the conditions of threshold and relative change checks.
+	// The most severe threshold that a series crosses determines its level; if it crosses none, its level is ok.
+	Thresholds []CheckThreshold `json:"thresholds,omitempty"`
+
+	// StaleAfter is how long a series of a deadman check may go without data before it is given DeadmanLevel.
+	StaleAfter string `json:"staleAfter,omitempty"`
+	// DeadmanLevel is the level of stale series. It defaults to crit.
+	DeadmanLevel CheckLevel `json:"deadmanLevel,omitempty"`
+
+	// NotificationEndpointIDs are the endpoints notified when the level of a series changes.
+	NotificationEndpointIDs []ID `json:"notificationEndpointIDs,omitempty"`
+
+	// TaskID is the task that schedules evaluation of the check.
+	TaskID ID `json:"taskID,omitempty"`
+}
+
+// CheckThreshold is crossed when a value is above Above, or below Below.
+type CheckThreshold struct {
+	Level CheckLevel `json:"level"`
+	Above *float64   `json:"above,omitempty"`
+	Below *float64   `json:"below,omitempty"`
+}
+
+// Crossed reports whether v crosses the threshold.
+func (t CheckThreshold) Crossed(v float64) bool {
+	return (t.Above != nil && v > *t.Above) || (t.Below != nil && v < *t.Below)
+}
+
+// Valid returns an error if the check cannot be evaluated.
+func (c *Check) Valid() error {
+	if !c.OrganizationID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check requires an organization",
+		}
+	}
+	if c.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check name is required",
+		}
+	}
+	if c.Query == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check query is required",
+		}
+	}
+	if c.Every == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check every is required",
+		}
+	}
+	if c.Status != CheckActive && c.Status != CheckInactive {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check status must be active or inactive",
+		}
+	}
+
+	switch c.Type {
+	case ThresholdCheck, RelativeChangeCheck:
+		if len(c.Thresholds) == 0 {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "check requires at least one threshold",
+			}
+		}
+		for _, t := range c.Thresholds {
+			if t.Level.Severity() <= OKLevel.Severity() {
+				return &Error{
+					Code: EInvalid,
+					Msg:  "threshold level must be info, warn or crit",
+				}
+			}
+			if t.Above == nil && t.Below == nil {
+				return &Error{
+					Code: EInvalid,
+					Msg:  "threshold requires above or below",
+				}
+			}
+		}
+	case DeadmanCheck:
+		if _, err := time.ParseDuration(c.StaleAfter); err != nil {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "deadman check requires a valid staleAfter duration",
+				Err:  err,
+			}
+		}
+		if c.DeadmanLevel != "" && c.DeadmanLevel.Severity() <= OKLevel.Severity() {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "deadman level must be info, warn or crit",
+			}
+		}
+	default:
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check type must be threshold, deadman or relative_change",
+		}
+	}
+	return nil
+}
+
+// CheckUpdate is a set of changes to a check. Nil fields are left unchanged.
+type CheckUpdate struct {
+	Name                    *string           `json:"name,omitempty"`
+	Description             *string           `json:"description,omitempty"`
+	Status                  *string           `json:"status,omitempty"`
+	Query                   *string           `json:"query,omitempty"`
+	Every                   *string           `json:"every,omitempty"`
+	Thresholds              *[]CheckThreshold `json:"thresholds,omitempty"`
+	StaleAfter              *string           `json:"staleAfter,omitempty"`
+	DeadmanLevel            *CheckLevel       `json:"deadmanLevel,omitempty"`
+	NotificationEndpointIDs *[]ID             `json:"notificationEndpointIDs,omitempty"`
+}
+
+// Apply applies the non-nil fields of the update to c.
+func (u CheckUpdate) Apply(c *Check) {
+	if u.Name != nil {
+		c.Name = *u.Name
+	}
+	if u.Description != nil {
+		c.Description = *u.Description
+	}
+	if u.Status != nil {
+		c.Status = *u.Status
+	}
+	if u.Query != nil {
+		c.Query = *u.Query
+	}
+	if u.Every != nil {
+		c.Every = *u.Every
+	}
+	if u.Thresholds != nil {
+		c.Thresholds = *u.Thresholds
+	}
+	if u.StaleAfter != nil {
+		c.StaleAfter = *u.StaleAfter
+	}
+	if u.DeadmanLevel != nil {
+		c.DeadmanLevel = *u.DeadmanLevel
+	}
+	if u.NotificationEndpointIDs != nil {
+		c.NotificationEndpointIDs = *u.NotificationEndpointIDs
+	}
+}
+
+// CheckFilter represents a set of filters that restrict the returned checks.
+type CheckFilter struct {
+	OrganizationID *ID
+	TaskID         *ID
+}
+
+// CheckService manages checks.
+type CheckService interface {
+	// FindCheckByID returns a single check by ID.
+	FindCheckByID(ctx context.Context, id ID) (*Check, error)
+
+	// FindChecks returns the checks that match filter.
+	FindChecks(ctx context.Context, filter CheckFilter) ([]*Check, error)
+
+	// CreateCheck creates a new check and sets c.ID with the new identifier.
+	CreateCheck(ctx context.Context, c *Check) error
+
+	// UpdateCheck updates a single check with a changeset and returns the updated check.
+	UpdateCheck(ctx context.Context, id ID, upd CheckUpdate) (*Check, error)
+
+	// DeleteCheck removes a check by ID.
+	DeleteCheck(ctx context.Context, id ID) error
+}
@@ -0,0 +1,310 @@
+package checks
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/query"
+	"go.uber.org/zap"
+)
+
+// Evaluator evaluates checks, records the status of each series whose level changes,
+// and sends those statuses to the check's notification endpoints.
+//
+// The level and last value of each series are kept in memory,
+// so after a restart the first evaluation of a check compares against unknown levels.
+type Evaluator struct {
+	QueryService query.QueryService
+	PointsWriter PointsWriter
+	Endpoints    platform.NotificationEndpointService
+	Notifier     *Notifier
+
+	logger *zap.Logger
+
+	mu     sync.Mutex
+	series map[platform.ID]map[string]*seriesState // Keyed by check ID, then series name.
+}
+
+// seriesState is what an Evaluator remembers about a series between evaluations.
+type seriesState struct {
+	tags     map[string]string
+	level    platform.CheckLevel
+	value    float64
+	hasValue bool
+	lastSeen time.Time
+}
+
+// sample is the last row of a table returned by a check's query.
+type sample struct {
+	tags     map[string]string
+	value    float64
+	hasValue bool
+	time     time.Time
+}
+
+// NewEvaluator returns an Evaluator that runs queries with qs, writes statuses with pw,
+// and notifies the endpoints found in endpoints.
+func NewEvaluator(logger *zap.Logger, qs query.QueryService, pw PointsWriter, endpoints platform.NotificationEndpointService) *Evaluator {
+	return &Evaluator{
+		QueryService: qs,
+		PointsWriter: pw,
+		Endpoints:    endpoints,
+		Notifier:     NewNotifier(),
+		logger:       logger,
+		series:       make(map[platform.ID]map[string]*seriesState),
+	}
+}
+
+// Evaluate runs the check's query as of now and returns the statuses of the series whose level changed.
+// The statuses are written to the organization's system bucket and sent to the check's notification endpoints;
+// failures to notify are logged rather than returned.
+func (e *Evaluator) Evaluate(ctx context.Context, c *platform.Check, now time.Time) ([]Status, error) {
+	samples, err := e.query(ctx, c, now)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, apply, err := e.transitions(c, samples, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(statuses) == 0 {
+		return nil, nil
+	}
+
+	pts, err := statusPoints(c.OrganizationID, statuses)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.PointsWriter.WritePoints(pts); err != nil {
+		// The levels are left unchanged, so the next evaluation reports the same changes again.
+		return nil, err
+	}
+	apply()
+
+	e.notify(ctx, c, statuses)
+	return statuses, nil
+}
+
+// query returns the last row of each table the check's query returns.
+func (e *Evaluator) query(ctx context.Context, c *platform.Check, now time.Time) ([]sample, error) {
+	spec, err := flux.Compile(ctx, c.Query, now)
+	if err != nil {
+		return nil, err
+	}
+
+	it, err := e.QueryService.Query(ctx, &query.Request{
+		OrganizationID: c.OrganizationID,
+		Compiler:       lang.SpecCompiler{Spec: spec},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	var samples []sample
+	for it.More() {
+		err := it.Next().Tables().Do(func(tbl flux.Table) error {
+			s, ok, err := lastSample(tbl)
+			if ok {
+				samples = append(samples, s)
+			}
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return samples, it.Err()
+}
+
+// lastSample returns the tags of the table's group key, and the _value and _time of its last row.
+// It returns false if the table has no rows.
+func lastSample(tbl flux.Table) (sample, bool, error) {
+	s := sample{tags: make(map[string]string)}
+	key := tbl.Key()
+	for j, col := range key.Cols() {
+		if col.Label == execute.DefaultStartColLabel || col.Label == execute.DefaultStopColLabel || col.Type != flux.TString {
+			continue
+		}
+		s.tags[col.Label] = key.ValueString(j)
+	}
+
+	found := false
+	err := tbl.Do(func(cr flux.ColReader) error {
+		n := cr.Len()
+		if n == 0 {
+			return nil
+		}
+		found = true
+		for j, col := range cr.Cols() {
+			switch col.Label {
+			case execute.DefaultValueColLabel:
+				switch col.Type {
+				case flux.TFloat:
+					s.value, s.hasValue = cr.Floats(j)[n-1], true
+				case flux.TInt:
+					s.value, s.hasValue = float64(cr.Ints(j)[n-1]), true
+				case flux.TUInt:
+					s.value, s.hasValue = float64(cr.UInts(j)[n-1]), true
+				}
+			case execute.DefaultTimeColLabel:
+				if col.Type == flux.TTime {
+					s.time = cr.Times(j)[n-1].Time()
+				}
+			}
+		}
+		return nil
+	})
+	return s, found, err
+}
+
+// thresholdLevel returns the level of the most severe threshold that v crosses, or ok if it crosses none.
+func thresholdLevel(thresholds []platform.CheckThreshold, v float64) platform.CheckLevel {
+	level := platform.OKLevel
+	for _, t := range thresholds {
+		if t.Crossed(v) && t.Level.Severity() > level.Severity() {
+			level = t.Level
+		}
+	}
+	return level
+}
+
+// transitions updates the state of the check's series with samples, returning a status for each series whose level changed.
+// The new levels are only recorded once apply is called, after the statuses are written.
+func (e *Evaluator) transitions(c *platform.Check, samples []sample, now time.Time) (statuses []Status, apply func(), err error) {
+	var staleAfter time.Duration
+	deadmanLevel := c.DeadmanLevel
+	if c.Type == platform.DeadmanCheck {
+		d, err := time.ParseDuration(c.StaleAfter)
+		if err != nil {
+			return nil, nil, err
+		}
+		staleAfter = d
+		if deadmanLevel == "" {
+			deadmanLevel = platform.CritLevel
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	series := e.series[c.ID]
+	if series == nil {
+		series = make(map[string]*seriesState)
+		e.series[c.ID] = series
+	}
+
+	levels := make(map[*seriesState]platform.CheckLevel)
+	transition := func(st *seriesState, level platform.CheckLevel, value *float64) {
+		if level == st.level {
+			return
+		}
+		statuses = append(statuses, Status{
+			CheckID:       c.ID,
+			CheckName:     c.Name,
+			Level:         level,
+			PreviousLevel: st.level,
+			Tags:          st.tags,
+			Value:         value,
+			Message:       statusMessage(c, st.tags, level, value),
+			Time:          now,
+		})
+		levels[st] = level
+	}
+
+	seen := make(map[string]bool, len(samples))
+	for _, s := range samples {
+		name := seriesName(s.tags)
+		seen[name] = true
+
+		st, ok := series[name]
+		if !ok {
+			st = &seriesState{tags: s.tags, level: platform.UnknownLevel}
+			series[name] = st
+		}
+
+		var value *float64
+		if s.hasValue {
+			v := s.value
+			value = &v
+		}
+
+		switch c.Type {
+		case platform.ThresholdCheck:
+			if s.hasValue {
+				transition(st, thresholdLevel(c.Thresholds, s.value), value)
+			}
+		case platform.RelativeChangeCheck:
+			// The first value of a series is only a baseline, as is any change from zero.
+			if s.hasValue && st.hasValue && st.value != 0 {
+				change := (s.value - st.value) / math.Abs(st.value) * 100
+				transition(st, thresholdLevel(c.Thresholds, change), &change)
+			}
+		case platform.DeadmanCheck:
+			if s.time.IsZero() {
+				st.lastSeen = now
+			} else {
+				st.lastSeen = s.time
+			}
+			level := platform.OKLevel
+			if now.Sub(st.lastSeen) > staleAfter {
+				level = deadmanLevel
+			}
+			transition(st, level, value)
+		}
+
+		if s.hasValue {
+			st.value, st.hasValue = s.value, true
+		}
+	}
+
+	if c.Type == platform.DeadmanCheck {
+		// Series the query no longer returns at all are stale once they go unseen for long enough.
+		for name, st := range series {
+			if !seen[name] && now.Sub(st.lastSeen) > staleAfter {
+				transition(st, deadmanLevel, nil)
+			}
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return seriesName(statuses[i].Tags) < seriesName(statuses[j].Tags)
+	})
+	apply = func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		for st, level := range levels {
+			st.level = level
+		}
+	}
+	return statuses, apply, nil
+}
+
+// notify sends the statuses to each of the check's notification endpoints.
+func (e *Evaluator) notify(ctx context.Context, c *platform.Check, statuses []Status) {
+	for _, id := range c.NotificationEndpointIDs {
+		ep, err := e.Endpoints.FindNotificationEndpointByID(ctx, id)
+		if err != nil {
+			e.logger.Info("Failed to find notification endpoint", zap.Stringer("check_id", c.ID), zap.Stringer("endpoint_id", id), zap.Error(err))
+			continue
+		}
+
+		for _, s := range statuses {
+			// A series first seen as ok has nothing to report.
+			if s.PreviousLevel == platform.UnknownLevel && s.Level == platform.OKLevel {
+				continue
+			}
+			if err := e.Notifier.Notify(ctx, ep, s); err != nil {
+				e.logger.Info("Failed to send notification", zap.Stringer("check_id", c.ID), zap.Stringer("endpoint_id", id), zap.String("series", seriesName(s.Tags)), zap.Error(err))
+			}
+		}
+	}
+}
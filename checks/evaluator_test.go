@@ -0,0 +1,327 @@
+package checks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/checks"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/query"
+	_ "github.com/influxdata/platform/query/builtin"
+	qmock "github.com/influxdata/platform/query/mock"
+	"go.uber.org/zap"
+)
+
+const testQuery = `from(bucket: "telegraf") |> range(start: -5m) |> filter(fn: (r) => r._measurement == "cpu") |> last()`
+
+var (
+	testOrgID      = platform.ID(1)
+	testEndpointID = platform.ID(2)
+)
+
+// hostTable returns a table of the usage_user values of a host.
+func hostTable(host string, ts execute.Time, v float64) *executetest.Table {
+	return &executetest.Table{
+		KeyCols: []string{"_start", "_stop", "_measurement", "host"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_start", Type: flux.TTime},
+			{Label: "_stop", Type: flux.TTime},
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_measurement", Type: flux.TString},
+			{Label: "host", Type: flux.TString},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(0), ts, ts, "cpu", host, v},
+		},
+	}
+}
+
+// tableQueryService returns a QueryService whose queries return the tables set with its set function.
+func tableQueryService() (query.QueryService, func(...*executetest.Table)) {
+	var (
+		mu     sync.Mutex
+		tables []*executetest.Table
+	)
+	qs := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			res := &executetest.Result{Nm: "_result", Tbls: tables}
+			return flux.NewSliceResultIterator([]flux.Result{res}), nil
+		},
+	}
+	return qs, func(tbls ...*executetest.Table) {
+		mu.Lock()
+		tables = tbls
+		mu.Unlock()
+	}
+}
+
+// webhook is a stub http notification endpoint that records the statuses posted to it.
+type webhook struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []checks.Status
+}
+
+func newWebhook(t *testing.T) *webhook {
+	w := &webhook{}
+	w.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var s checks.Status
+		if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+			t.Errorf("failed to decode status: %v", err)
+		}
+		w.mu.Lock()
+		w.statuses = append(w.statuses, s)
+		w.mu.Unlock()
+	}))
+	return w
+}
+
+func (w *webhook) levels() []platform.CheckLevel {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	levels := make([]platform.CheckLevel, len(w.statuses))
+	for i, s := range w.statuses {
+		levels[i] = s.Level
+	}
+	w.statuses = nil
+	return levels
+}
+
+func newTestEvaluator(t *testing.T, qs query.QueryService, pw checks.PointsWriter, hook *webhook) *checks.Evaluator {
+	endpoints := mock.NewNotificationEndpointService()
+	endpoints.FindNotificationEndpointByIDFn = func(ctx context.Context, id platform.ID) (*platform.NotificationEndpoint, error) {
+		if id != testEndpointID {
+			t.Fatalf("unexpected notification endpoint %s", id)
+		}
+		return &platform.NotificationEndpoint{ID: id, Name: "hook", Type: platform.HTTPEndpoint, URL: hook.URL}, nil
+	}
+	return checks.NewEvaluator(zap.NewNop(), qs, pw, endpoints)
+}
+
+func levels(statuses []checks.Status) map[string]platform.CheckLevel {
+	m := make(map[string]platform.CheckLevel, len(statuses))
+	for _, s := range statuses {
+		m[s.Tags["host"]] = s.Level
+	}
+	return m
+}
+
+func assertLevels(t *testing.T, got []checks.Status, want map[string]platform.CheckLevel) {
+	t.Helper()
+	g := levels(got)
+	if len(g) != len(want) {
+		t.Fatalf("expected status changes %v, got %v", want, g)
+	}
+	for host, l := range want {
+		if g[host] != l {
+			t.Fatalf("expected status changes %v, got %v", want, g)
+		}
+	}
+}
+
+func float64Ptr(f float64) *float64 { return &f }
+
+func TestEvaluator_Threshold(t *testing.T) {
+	qs, setTables := tableQueryService()
+	pw := &mock.PointsWriter{}
+	hook := newWebhook(t)
+	defer hook.Close()
+	ev := newTestEvaluator(t, qs, pw, hook)
+
+	c := &platform.Check{
+		ID:             3,
+		OrganizationID: testOrgID,
+		Name:           "cpu",
+		Type:           platform.ThresholdCheck,
+		Query:          testQuery,
+		Every:          "1m",
+		Thresholds: []platform.CheckThreshold{
+			{Level: platform.WarnLevel, Above: float64Ptr(70)},
+			{Level: platform.CritLevel, Above: float64Ptr(90)},
+		},
+		NotificationEndpointIDs: []platform.ID{testEndpointID},
+	}
+	now := time.Unix(600, 0)
+	ctx := context.Background()
+
+	setTables(hostTable("a", execute.Time(now.UnixNano()), 95), hostTable("b", execute.Time(now.UnixNano()), 10))
+	statuses, err := ev.Evaluate(ctx, c, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertLevels(t, statuses, map[string]platform.CheckLevel{"a": platform.CritLevel, "b": platform.OKLevel})
+	// Each status is written as a point per field: previousLevel, message and value.
+	if len(pw.Points) != 6 {
+		t.Fatalf("expected 6 status points to be written, got %d", len(pw.Points))
+	}
+	// A series that is ok from the start does not notify.
+	if got := hook.levels(); len(got) != 1 || got[0] != platform.CritLevel {
+		t.Fatalf("expected a single crit notification, got %v", got)
+	}
+
+	// Levels that do not change are neither recorded nor notified.
+	now = now.Add(time.Minute)
+	setTables(hostTable("a", execute.Time(now.UnixNano()), 75), hostTable("b", execute.Time(now.UnixNano()), 20))
+	statuses, err = ev.Evaluate(ctx, c, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertLevels(t, statuses, map[string]platform.CheckLevel{"a": platform.WarnLevel})
+	if s := statuses[0]; s.PreviousLevel != platform.CritLevel || s.Value == nil || *s.Value != 75 {
+		t.Fatalf("unexpected status %+v", s)
+	}
+	if got := hook.levels(); len(got) != 1 || got[0] != platform.WarnLevel {
+		t.Fatalf("expected a single warn notification, got %v", got)
+	}
+}
+
+func TestEvaluator_WriteError(t *testing.T) {
+	qs, setTables := tableQueryService()
+	pw := &mock.PointsWriter{}
+	hook := newWebhook(t)
+	defer hook.Close()
+	ev := newTestEvaluator(t, qs, pw, hook)
+
+	c := &platform.Check{
+		ID:             3,
+		OrganizationID: testOrgID,
+		Name:           "cpu",
+		Type:           platform.ThresholdCheck,
+		Query:          testQuery,
+		Every:          "1m",
+		Thresholds: []platform.CheckThreshold{
+			{Level: platform.CritLevel, Above: float64Ptr(90)},
+		},
+		NotificationEndpointIDs: []platform.ID{testEndpointID},
+	}
+	now := time.Unix(600, 0)
+	ctx := context.Background()
+
+	// A change whose status fails to be written is neither recorded nor notified.
+	pw.ForceError(errors.New("engine is closed"))
+	setTables(hostTable("a", execute.Time(now.UnixNano()), 95))
+	if _, err := ev.Evaluate(ctx, c, now); err == nil {
+		t.Fatal("expected the write error to be returned")
+	}
+	if got := hook.levels(); len(got) != 0 {
+		t.Fatalf("expected no notifications, got %v", got)
+	}
+
+	// The next evaluation reports the same change.
+	pw.ForceError(nil)
+	now = now.Add(time.Minute)
+	setTables(hostTable("a", execute.Time(now.UnixNano()), 95))
+	statuses, err := ev.Evaluate(ctx, c, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertLevels(t, statuses, map[string]platform.CheckLevel{"a": platform.CritLevel})
+	if s := statuses[0]; s.PreviousLevel != platform.UnknownLevel {
+		t.Fatalf("expected the previous level to be unknown, got %v", s.PreviousLevel)
+	}
+	if got := hook.levels(); len(got) != 1 || got[0] != platform.CritLevel {
+		t.Fatalf("expected a single crit notification, got %v", got)
+	}
+}
+
+func TestEvaluator_RelativeChange(t *testing.T) {
+	qs, setTables := tableQueryService()
+	hook := newWebhook(t)
+	defer hook.Close()
+	ev := newTestEvaluator(t, qs, &mock.PointsWriter{}, hook)
+
+	c := &platform.Check{
+		ID:             3,
+		OrganizationID: testOrgID,
+		Name:           "requests",
+		Type:           platform.RelativeChangeCheck,
+		Query:          testQuery,
+		Every:          "1m",
+		Thresholds: []platform.CheckThreshold{
+			{Level: platform.WarnLevel, Above: float64Ptr(20), Below: float64Ptr(-20)},
+		},
+	}
+	now := time.Unix(600, 0)
+	ctx := context.Background()
+
+	// The first value is only a baseline.
+	setTables(hostTable("a", execute.Time(now.UnixNano()), 100))
+	statuses, err := ev.Evaluate(ctx, c, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertLevels(t, statuses, nil)
+
+	now = now.Add(time.Minute)
+	setTables(hostTable("a", execute.Time(now.UnixNano()), 110))
+	if statuses, err = ev.Evaluate(ctx, c, now); err != nil {
+		t.Fatal(err)
+	}
+	assertLevels(t, statuses, map[string]platform.CheckLevel{"a": platform.OKLevel})
+
+	now = now.Add(time.Minute)
+	setTables(hostTable("a", execute.Time(now.UnixNano()), 55))
+	if statuses, err = ev.Evaluate(ctx, c, now); err != nil {
+		t.Fatal(err)
+	}
+	assertLevels(t, statuses, map[string]platform.CheckLevel{"a": platform.WarnLevel})
+	if v := statuses[0].Value; v == nil || *v != -50 {
+		t.Fatalf("expected a change of -50%%, got %v", v)
+	}
+}
+
+func TestEvaluator_Deadman(t *testing.T) {
+	qs, setTables := tableQueryService()
+	hook := newWebhook(t)
+	defer hook.Close()
+	ev := newTestEvaluator(t, qs, &mock.PointsWriter{}, hook)
+
+	c := &platform.Check{
+		ID:                      3,
+		OrganizationID:          testOrgID,
+		Name:                    "heartbeat",
+		Type:                    platform.DeadmanCheck,
+		Query:                   testQuery,
+		Every:                   "1m",
+		StaleAfter:              "5m",
+		NotificationEndpointIDs: []platform.ID{testEndpointID},
+	}
+	now := time.Unix(600, 0)
+	ctx := context.Background()
+
+	setTables(hostTable("a", execute.Time(now.UnixNano()), 1), hostTable("b", execute.Time(now.Add(-10*time.Minute).UnixNano()), 1))
+	statuses, err := ev.Evaluate(ctx, c, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertLevels(t, statuses, map[string]platform.CheckLevel{"a": platform.OKLevel, "b": platform.CritLevel})
+
+	// A series that disappears from the query's results goes stale once it is unseen for long enough.
+	setTables()
+	if statuses, err = ev.Evaluate(ctx, c, now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	assertLevels(t, statuses, nil)
+	if statuses, err = ev.Evaluate(ctx, c, now.Add(6*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	assertLevels(t, statuses, map[string]platform.CheckLevel{"a": platform.CritLevel})
+
+	if got := hook.levels(); len(got) != 2 || got[0] != platform.CritLevel || got[1] != platform.CritLevel {
+		t.Fatalf("expected two crit notifications, got %v", got)
+	}
+}
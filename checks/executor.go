@@ -0,0 +1,138 @@
+package checks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"go.uber.org/zap"
+)
+
+// Executor is a backend.Executor that evaluates a check when the task scheduling it runs,
+// and delegates the runs of every other task.
+type Executor struct {
+	next      backend.Executor
+	checks    platform.CheckService
+	evaluator *Evaluator
+	logger    *zap.Logger
+	wg        sync.WaitGroup
+}
+
+var _ backend.Executor = (*Executor)(nil)
+
+// NewExecutor returns an Executor that evaluates checks found in checks with ev, and passes other runs to next.
+func NewExecutor(logger *zap.Logger, next backend.Executor, checks platform.CheckService, ev *Evaluator) *Executor {
+	return &Executor{
+		next:      next,
+		checks:    checks,
+		evaluator: ev,
+		logger:    logger,
+	}
+}
+
+// Execute evaluates the check scheduled by the run's task, or executes the run with the next Executor
+// if the task does not schedule a check.
+func (e *Executor) Execute(ctx context.Context, run backend.QueuedRun) (backend.RunPromise, error) {
+	cs, err := e.checks.FindChecks(ctx, platform.CheckFilter{TaskID: &run.TaskID})
+	if err != nil {
+		return nil, err
+	}
+	if len(cs) == 0 {
+		return e.next.Execute(ctx, run)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	p := &checkRunPromise{
+		qr:     run,
+		cancel: cancel,
+		ready:  make(chan struct{}),
+	}
+
+	c := cs[0]
+	logger := e.logger.With(zap.Stringer("check_id", c.ID), zap.Stringer("task_id", run.TaskID), zap.Stringer("run_id", run.RunID))
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+
+		statuses, err := e.evaluator.Evaluate(ctx, c, time.Unix(run.Now, 0))
+		if err != nil {
+			logger.Info("Failed to evaluate check", zap.Error(err))
+			// Failing to query or record statuses may be transient, so the run may be retried.
+			p.finish(&checkRunResult{err: err}, nil)
+			return
+		}
+		logger.Debug("Evaluated check", zap.Int("status_changes", len(statuses)))
+		p.finish(&checkRunResult{}, nil)
+	}()
+
+	go func() {
+		select {
+		case <-p.ready:
+		case <-ctx.Done():
+			p.finish(nil, ctx.Err())
+		}
+	}()
+
+	return p, nil
+}
+
+// Wait blocks until all runs executed by e, and by the next Executor, have finished.
+func (e *Executor) Wait() {
+	e.next.Wait()
+	e.wg.Wait()
+}
+
+// checkRunPromise is the backend.RunPromise of a run that evaluates a check.
+type checkRunPromise struct {
+	qr     backend.QueuedRun
+	cancel context.CancelFunc
+
+	finishOnce sync.Once
+	ready      chan struct{} // Closed inside finish. Indicates Wait will no longer block.
+	res        *checkRunResult
+	err        error
+}
+
+var _ backend.RunPromise = (*checkRunPromise)(nil)
+
+func (p *checkRunPromise) Run() backend.QueuedRun {
+	return p.qr
+}
+
+func (p *checkRunPromise) Wait() (backend.RunResult, error) {
+	<-p.ready
+
+	// Need an explicit return nil to avoid the non-nil interface value issue.
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.res, nil
+}
+
+func (p *checkRunPromise) Cancel() {
+	p.finish(nil, backend.ErrRunCanceled)
+}
+
+func (p *checkRunPromise) finish(res *checkRunResult, err error) {
+	p.finishOnce.Do(func() {
+		defer p.cancel()
+
+		p.res, p.err = res, err
+		close(p.ready)
+	})
+}
+
+// checkRunResult is the backend.RunResult of a run that evaluates a check.
+type checkRunResult struct {
+	err error
+}
+
+var _ backend.RunResult = (*checkRunResult)(nil)
+
+func (r *checkRunResult) Err() error { return r.err }
+
+func (r *checkRunResult) IsRetryable() bool { return r.err != nil }
+
+func (r *checkRunResult) Statistics() platform.RunStatistics { return platform.RunStatistics{} }
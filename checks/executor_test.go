@@ -0,0 +1,73 @@
+package checks_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/checks"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/task/backend"
+	tmock "github.com/influxdata/platform/task/mock"
+	"go.uber.org/zap"
+)
+
+func TestExecutor(t *testing.T) {
+	check := &platform.Check{
+		ID:             3,
+		OrganizationID: testOrgID,
+		Name:           "cpu",
+		Type:           platform.ThresholdCheck,
+		Query:          testQuery,
+		Every:          "1m",
+		Thresholds:     []platform.CheckThreshold{{Level: platform.CritLevel, Above: float64Ptr(90)}},
+		TaskID:         4,
+	}
+	cs := mock.NewCheckService()
+	cs.FindChecksFn = func(ctx context.Context, filter platform.CheckFilter) ([]*platform.Check, error) {
+		if filter.TaskID != nil && *filter.TaskID == check.TaskID {
+			return []*platform.Check{check}, nil
+		}
+		return nil, nil
+	}
+
+	qs, setTables := tableQueryService()
+	pw := &mock.PointsWriter{}
+	hook := newWebhook(t)
+	defer hook.Close()
+	next := tmock.NewExecutor()
+	ex := checks.NewExecutor(zap.NewNop(), next, cs, newTestEvaluator(t, qs, pw, hook))
+
+	now := time.Unix(600, 0)
+	setTables(hostTable("a", execute.Time(now.UnixNano()), 95))
+	rp, err := ex.Execute(context.Background(), backend.QueuedRun{TaskID: check.TaskID, RunID: 5, Now: now.Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := rp.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Err() != nil {
+		t.Fatalf("expected the check to be evaluated, got %v", res.Err())
+	}
+	if len(pw.Points) == 0 {
+		t.Fatal("expected the check's statuses to be written")
+	}
+	if len(next.RunningFor(check.TaskID)) != 0 {
+		t.Fatal("expected the check's run not to be delegated")
+	}
+
+	// Runs of tasks that do not schedule a check are delegated.
+	rp, err = ex.Execute(context.Background(), backend.QueuedRun{TaskID: 6, RunID: 7, Now: now.Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := next.PollForNumberRunning(6, 1); err != nil {
+		t.Fatal(err)
+	}
+	rp.(*tmock.RunPromise).Finish(tmock.NewRunResult(nil, false), nil)
+	ex.Wait()
+}
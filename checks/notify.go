@@ -0,0 +1,179 @@
+package checks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+// defaultNotifyTimeout bounds how long a notification may take to send.
+const defaultNotifyTimeout = 10 * time.Second
+
+// Notifier sends statuses to notification endpoints.
+type Notifier struct {
+	// Client sends the requests of http, slack and pagerduty endpoints.
+	Client *http.Client
+	// SendMail sends the email of smtp endpoints. It defaults to smtp.SendMail.
+	SendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewNotifier returns a Notifier that gives up on an endpoint after ten seconds.
+func NewNotifier() *Notifier {
+	return &Notifier{
+		Client:   &http.Client{Timeout: defaultNotifyTimeout},
+		SendMail: smtp.SendMail,
+	}
+}
+
+// Notify sends s to the endpoint.
+func (n *Notifier) Notify(ctx context.Context, e *platform.NotificationEndpoint, s Status) error {
+	switch e.Type {
+	case platform.HTTPEndpoint:
+		return n.notifyHTTP(ctx, e, s)
+	case platform.SlackEndpoint:
+		return n.notifySlack(ctx, e, s)
+	case platform.PagerDutyEndpoint:
+		return n.notifyPagerDuty(ctx, e, s)
+	case platform.SMTPEndpoint:
+		return n.notifySMTP(e, s)
+	}
+	return fmt.Errorf("unknown notification endpoint type %q", e.Type)
+}
+
+func (n *Notifier) post(ctx context.Context, url string, headers map[string]string, body interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("notification to %s failed with status %s", url, resp.Status)
+	}
+	return nil
+}
+
+// notifyHTTP posts the JSON encoded status.
+func (n *Notifier) notifyHTTP(ctx context.Context, e *platform.NotificationEndpoint, s Status) error {
+	headers := make(map[string]string, len(e.Headers)+1)
+	for k, v := range e.Headers {
+		headers[k] = v
+	}
+	if e.Token != "" {
+		headers["Authorization"] = "Bearer " + e.Token
+	}
+	return n.post(ctx, e.URL, headers, s)
+}
+
+// slackMessage is the body accepted by Slack incoming webhooks.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+func (n *Notifier) notifySlack(ctx context.Context, e *platform.NotificationEndpoint, s Status) error {
+	return n.post(ctx, e.URL, nil, slackMessage{Text: s.Message})
+}
+
+// pagerDutyEvent is the body accepted by version 2 of the PagerDuty events API.
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Timestamp     string            `json:"timestamp"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+// pagerDutySeverity maps a check level to a PagerDuty severity.
+func pagerDutySeverity(l platform.CheckLevel) string {
+	switch l {
+	case platform.CritLevel:
+		return "critical"
+	case platform.WarnLevel:
+		return "warning"
+	}
+	return "info"
+}
+
+// notifyPagerDuty triggers an event for each series that is not ok, and resolves it once the series is ok again.
+func (n *Notifier) notifyPagerDuty(ctx context.Context, e *platform.NotificationEndpoint, s Status) error {
+	ev := pagerDutyEvent{
+		RoutingKey:  e.Token,
+		EventAction: "trigger",
+		DedupKey:    s.CheckID.String() + ":" + seriesName(s.Tags),
+	}
+	if s.Level == platform.OKLevel {
+		ev.EventAction = "resolve"
+	} else {
+		ev.Payload = &pagerDutyPayload{
+			Summary:       s.Message,
+			Source:        s.CheckName,
+			Severity:      pagerDutySeverity(s.Level),
+			Timestamp:     s.Time.UTC().Format(time.RFC3339),
+			CustomDetails: s.Tags,
+		}
+	}
+
+	url := e.URL
+	if url == "" {
+		url = platform.DefaultPagerDutyURL
+	}
+	return n.post(ctx, url, nil, ev)
+}
+
+func (n *Notifier) notifySMTP(e *platform.NotificationEndpoint, s Status) error {
+	cfg := e.SMTP
+	if cfg == nil {
+		return fmt.Errorf("smtp endpoint %q has no smtp config", e.Name)
+	}
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", cfg.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(cfg.To, ", "))
+	// The check name is user input, so it is encoded to keep it from breaking out of the header.
+	subject := fmt.Sprintf("[%s] %s", strings.ToUpper(string(s.Level)), s.CheckName)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", s.Time.UTC().Format(time.RFC1123Z))
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(s.Message)
+	msg.WriteString("\r\n")
+
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	return n.SendMail(addr, auth, cfg.From, cfg.To, msg.Bytes())
+}
@@ -0,0 +1,262 @@
+package checks_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"mime"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/checks"
+)
+
+var testStatus = checks.Status{
+	CheckID:       3,
+	CheckName:     "cpu",
+	Level:         platform.CritLevel,
+	PreviousLevel: platform.OKLevel,
+	Tags:          map[string]string{"host": "a"},
+	Value:         float64Ptr(95),
+	Message:       `check "cpu" is crit for host=a: value 95`,
+	Time:          time.Unix(600, 0),
+}
+
+// recordBody returns a stub server that decodes the JSON body of each request into v.
+func recordBody(t *testing.T, v interface{}, headers http.Header) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k := range r.Header {
+			headers.Set(k, r.Header.Get(k))
+		}
+		if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+	}))
+}
+
+func TestNotifier_HTTP(t *testing.T) {
+	var got checks.Status
+	headers := http.Header{}
+	srv := recordBody(t, &got, headers)
+	defer srv.Close()
+
+	e := &platform.NotificationEndpoint{
+		Type:    platform.HTTPEndpoint,
+		URL:     srv.URL,
+		Headers: map[string]string{"X-Team": "ops"},
+		Token:   "secret",
+	}
+	if err := checks.NewNotifier().Notify(context.Background(), e, testStatus); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Message != testStatus.Message || got.Level != platform.CritLevel || got.Tags["host"] != "a" {
+		t.Fatalf("unexpected status %+v", got)
+	}
+	if headers.Get("X-Team") != "ops" || headers.Get("Authorization") != "Bearer secret" {
+		t.Fatalf("unexpected headers %v", headers)
+	}
+}
+
+func TestNotifier_HTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	e := &platform.NotificationEndpoint{Type: platform.HTTPEndpoint, URL: srv.URL}
+	if err := checks.NewNotifier().Notify(context.Background(), e, testStatus); err == nil {
+		t.Fatal("expected an error from an endpoint that fails")
+	}
+}
+
+func TestNotifier_Slack(t *testing.T) {
+	var got struct {
+		Text string `json:"text"`
+	}
+	srv := recordBody(t, &got, http.Header{})
+	defer srv.Close()
+
+	e := &platform.NotificationEndpoint{Type: platform.SlackEndpoint, URL: srv.URL}
+	if err := checks.NewNotifier().Notify(context.Background(), e, testStatus); err != nil {
+		t.Fatal(err)
+	}
+	if got.Text != testStatus.Message {
+		t.Fatalf("expected text %q, got %q", testStatus.Message, got.Text)
+	}
+}
+
+func TestNotifier_PagerDuty(t *testing.T) {
+	type event struct {
+		RoutingKey  string `json:"routing_key"`
+		EventAction string `json:"event_action"`
+		DedupKey    string `json:"dedup_key"`
+		Payload     *struct {
+			Summary  string `json:"summary"`
+			Severity string `json:"severity"`
+		} `json:"payload"`
+	}
+
+	var events []event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev event
+		if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+			t.Errorf("failed to decode event: %v", err)
+		}
+		events = append(events, ev)
+	}))
+	defer srv.Close()
+
+	e := &platform.NotificationEndpoint{Type: platform.PagerDutyEndpoint, URL: srv.URL, Token: "routing-key"}
+	n := checks.NewNotifier()
+	ok := testStatus
+	ok.Level, ok.PreviousLevel = platform.OKLevel, platform.CritLevel
+	for _, s := range []checks.Status{testStatus, ok} {
+		if err := n.Notify(context.Background(), e, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	trigger, resolve := events[0], events[1]
+	if trigger.RoutingKey != "routing-key" || trigger.EventAction != "trigger" || trigger.Payload == nil || trigger.Payload.Severity != "critical" {
+		t.Fatalf("unexpected trigger event %+v", trigger)
+	}
+	if resolve.EventAction != "resolve" || resolve.DedupKey != trigger.DedupKey || resolve.Payload != nil {
+		t.Fatalf("expected the resolve event to match the trigger's dedup key %q, got %+v", trigger.DedupKey, resolve)
+	}
+}
+
+// smtpStub is a minimal SMTP server that accepts a single message without authentication.
+func smtpStub(t *testing.T) (addr string, msg <-chan string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch := make(chan string, 1)
+	go func() {
+		defer ln.Close()
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+		reply("220 localhost stub")
+		var data []string
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data = append(data, l)
+				}
+				ch <- strings.Join(data, "")
+				reply("250 ok")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+	return ln.Addr().String(), ch
+}
+
+func TestNotifier_SMTP(t *testing.T) {
+	addr, msgs := smtpStub(t)
+	host, port, _ := net.SplitHostPort(addr)
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := &platform.NotificationEndpoint{
+		Type: platform.SMTPEndpoint,
+		SMTP: &platform.SMTPConfig{
+			Host: host,
+			Port: p,
+			From: "alerts@example.com",
+			To:   []string{"ops@example.com"},
+		},
+	}
+	if err := checks.NewNotifier().Notify(context.Background(), e, testStatus); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg := <-msgs:
+		for _, want := range []string{"To: ops@example.com", "Subject: [CRIT] cpu", testStatus.Message} {
+			if !strings.Contains(msg, want) {
+				t.Fatalf("expected message to contain %q, got:\n%s", want, msg)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for message")
+	}
+}
+
+func TestNotifier_SMTPSubject(t *testing.T) {
+	var msg []byte
+	n := checks.NewNotifier()
+	n.SendMail = func(addr string, a smtp.Auth, from string, to []string, m []byte) error {
+		msg = m
+		return nil
+	}
+
+	e := &platform.NotificationEndpoint{
+		Type: platform.SMTPEndpoint,
+		SMTP: &platform.SMTPConfig{
+			Host: "localhost",
+			Port: 25,
+			From: "alerts@example.com",
+			To:   []string{"ops@example.com"},
+		},
+	}
+	s := testStatus
+	s.CheckName = "cpu\r\nBcc: attacker@example.com"
+	if err := n.Notify(context.Background(), e, s); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(msg), "\r\nBcc:") {
+		t.Fatalf("expected the check name to stay in the subject, got:\n%s", msg)
+	}
+	var subject string
+	for _, line := range strings.Split(string(msg), "\r\n") {
+		if strings.HasPrefix(line, "Subject: ") {
+			subject = strings.TrimPrefix(line, "Subject: ")
+		}
+	}
+	if got, err := new(mime.WordDecoder).DecodeHeader(subject); err != nil {
+		t.Fatal(err)
+	} else if exp := "[CRIT] " + s.CheckName; got != exp {
+		t.Fatalf("got subject %q, expected %q", got, exp)
+	}
+}
@@ -0,0 +1,108 @@
+// Package checks evaluates platform.Checks on the schedule of their tasks,
+// records the status of each series whose level changes, and sends those changes to notification endpoints.
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/platform"
+	pctx "github.com/influxdata/platform/context"
+)
+
+// Service is a platform.CheckService that creates, updates and deletes the task that schedules each check
+// alongside the check itself.
+type Service struct {
+	platform.CheckService
+	TaskService platform.TaskService
+}
+
+var _ platform.CheckService = (*Service)(nil)
+
+// NewService returns a Service that stores checks in cs and their tasks in ts.
+func NewService(cs platform.CheckService, ts platform.TaskService) *Service {
+	return &Service{
+		CheckService: cs,
+		TaskService:  ts,
+	}
+}
+
+// taskScript returns the script of the task that schedules c.
+// Only the task's options are used: when the task runs, the Executor evaluates the check instead of the script.
+func taskScript(c *platform.Check) string {
+	return fmt.Sprintf("option task = {name: %q, every: %s}\n\n%s", "check: "+c.Name, c.Every, c.Query)
+}
+
+// CreateCheck creates the check and the task that schedules it, setting c.ID and c.TaskID.
+func (s *Service) CreateCheck(ctx context.Context, c *platform.Check) error {
+	if c.Status == "" {
+		c.Status = platform.CheckActive
+	}
+	if err := c.Valid(); err != nil {
+		return err
+	}
+
+	t := &platform.Task{
+		Organization: c.OrganizationID,
+		Flux:         taskScript(c),
+		Status:       c.Status,
+	}
+	if auth, err := pctx.GetAuthorizer(ctx); err == nil {
+		t.Owner.ID = auth.GetUserID()
+	}
+	if err := s.TaskService.CreateTask(ctx, t); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "failed to schedule check",
+			Op:   "checks/create check",
+			Err:  err,
+		}
+	}
+
+	c.TaskID = t.ID
+	if err := s.CheckService.CreateCheck(ctx, c); err != nil {
+		// Don't leave behind a task for a check that does not exist.
+		_ = s.TaskService.DeleteTask(ctx, t.ID)
+		return err
+	}
+	return nil
+}
+
+// UpdateCheck updates the check, and its task if the check's name, query, schedule or status changed.
+// The task is updated first, so that a check is never left with a schedule its task could not accept.
+func (s *Service) UpdateCheck(ctx context.Context, id platform.ID, upd platform.CheckUpdate) (*platform.Check, error) {
+	if upd.Name != nil || upd.Query != nil || upd.Every != nil || upd.Status != nil {
+		c, err := s.CheckService.FindCheckByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		upd.Apply(c)
+		if err := c.Valid(); err != nil {
+			return nil, err
+		}
+
+		flux := taskScript(c)
+		if _, err := s.TaskService.UpdateTask(ctx, c.TaskID, platform.TaskUpdate{Flux: &flux, Status: &c.Status}); err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  "failed to reschedule check",
+				Op:   "checks/update check",
+				Err:  err,
+			}
+		}
+	}
+
+	return s.CheckService.UpdateCheck(ctx, id, upd)
+}
+
+// DeleteCheck deletes the check and its task.
+func (s *Service) DeleteCheck(ctx context.Context, id platform.ID) error {
+	c, err := s.CheckService.FindCheckByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.CheckService.DeleteCheck(ctx, id); err != nil {
+		return err
+	}
+	return s.TaskService.DeleteTask(ctx, c.TaskID)
+}
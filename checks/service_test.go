@@ -0,0 +1,68 @@
+package checks_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/checks"
+	"github.com/influxdata/platform/inmem"
+	"github.com/influxdata/platform/mock"
+)
+
+func TestService(t *testing.T) {
+	tasks := make(map[platform.ID]*platform.Task)
+	ts := &mock.TaskService{
+		CreateTaskFn: func(ctx context.Context, task *platform.Task) error {
+			task.ID = platform.ID(len(tasks) + 100)
+			tasks[task.ID] = task
+			return nil
+		},
+		UpdateTaskFn: func(ctx context.Context, id platform.ID, upd platform.TaskUpdate) (*platform.Task, error) {
+			task := tasks[id]
+			task.Flux, task.Status = *upd.Flux, *upd.Status
+			return task, nil
+		},
+		DeleteTaskFn: func(ctx context.Context, id platform.ID) error {
+			delete(tasks, id)
+			return nil
+		},
+	}
+	s := checks.NewService(inmem.NewService(), ts)
+	ctx := context.Background()
+
+	c := &platform.Check{
+		OrganizationID: testOrgID,
+		Name:           "cpu",
+		Type:           platform.ThresholdCheck,
+		Query:          testQuery,
+		Every:          "1m",
+		Thresholds:     []platform.CheckThreshold{{Level: platform.CritLevel, Above: float64Ptr(90)}},
+	}
+	if err := s.CreateCheck(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+	task, ok := tasks[c.TaskID]
+	if !ok {
+		t.Fatal("expected a task to schedule the check")
+	}
+	if !strings.Contains(task.Flux, "every: 1m") || task.Status != platform.CheckActive {
+		t.Fatalf("unexpected task %+v", task)
+	}
+
+	every, status := "5m", platform.CheckInactive
+	if _, err := s.UpdateCheck(ctx, c.ID, platform.CheckUpdate{Every: &every, Status: &status}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(task.Flux, "every: 5m") || task.Status != platform.CheckInactive {
+		t.Fatalf("expected the task to be rescheduled, got %+v", task)
+	}
+
+	if err := s.DeleteCheck(ctx, c.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 0 {
+		t.Fatal("expected the check's task to be deleted")
+	}
+}
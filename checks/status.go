@@ -0,0 +1,103 @@
+package checks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/tsdb"
+)
+
+const (
+	statusMeasurement = "statuses"
+
+	checkIDTag   = "checkID"
+	checkNameTag = "checkName"
+	levelTag     = "level"
+
+	previousLevelField = "previousLevel"
+	messageField       = "message"
+	valueField         = "value"
+
+	// statusBucketID is the system bucket that statuses are written to, alongside task logs.
+	statusBucketID platform.ID = 10
+)
+
+// PointsWriter writes points to storage.
+// It matches storage.PointsWriter, without depending on the storage package.
+type PointsWriter interface {
+	WritePoints(points []models.Point) error
+}
+
+// Status is the level a check assigned to a series, recorded and sent to notification endpoints when the level changes.
+type Status struct {
+	CheckID       platform.ID         `json:"checkID"`
+	CheckName     string              `json:"checkName"`
+	Level         platform.CheckLevel `json:"level"`
+	PreviousLevel platform.CheckLevel `json:"previousLevel"`
+	// Tags identify the series, from the group key of the table the check's query returned for it.
+	Tags map[string]string `json:"tags,omitempty"`
+	// Value is the value that was checked, if the series had data.
+	Value   *float64  `json:"value,omitempty"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// seriesName describes the series of a status by its tags, in key order.
+func seriesName(tags map[string]string) string {
+	if len(tags) == 0 {
+		return "all series"
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + tags[k]
+	}
+	return strings.Join(pairs, ",")
+}
+
+func statusMessage(c *platform.Check, tags map[string]string, level platform.CheckLevel, value *float64) string {
+	msg := fmt.Sprintf("check %q is %s for %s", c.Name, level, seriesName(tags))
+	if value != nil {
+		msg += fmt.Sprintf(": value %g", *value)
+	}
+	return msg
+}
+
+// statusPoints returns the points that record statuses in the organization's system bucket.
+func statusPoints(orgID platform.ID, statuses []Status) ([]models.Point, error) {
+	pts := make([]models.Point, 0, len(statuses))
+	for _, s := range statuses {
+		tags := make(map[string]string, len(s.Tags)+3)
+		for k, v := range s.Tags {
+			tags[k] = v
+		}
+		tags[checkIDTag] = s.CheckID.String()
+		tags[checkNameTag] = s.CheckName
+		tags[levelTag] = string(s.Level)
+
+		fields := map[string]interface{}{
+			previousLevelField: string(s.PreviousLevel),
+			messageField:       s.Message,
+		}
+		if s.Value != nil {
+			fields[valueField] = *s.Value
+		}
+
+		pt, err := models.NewPoint(statusMeasurement, models.NewTags(tags), fields, s.Time)
+		if err != nil {
+			return nil, err
+		}
+		pts = append(pts, pt)
+	}
+
+	return tsdb.ExplodePoints(orgID, statusBucketID, pts)
+}
@@ -132,6 +132,7 @@ type BucketFindFlags struct {
 	id    string
 	org   string
 	orgID string
+	label string
 }
 
 var bucketFindFlags BucketFindFlags
@@ -147,6 +148,7 @@ func init() {
 	bucketFindCmd.Flags().StringVarP(&bucketFindFlags.id, "id", "i", "", "bucket ID")
 	bucketFindCmd.Flags().StringVarP(&bucketFindFlags.orgID, "org-id", "", "", "bucket organization ID")
 	bucketFindCmd.Flags().StringVarP(&bucketFindFlags.org, "org", "o", "", "bucket organization name")
+	bucketFindCmd.Flags().StringVarP(&bucketFindFlags.label, "label", "l", "", "only find buckets with this label")
 
 	bucketCmd.AddCommand(bucketFindCmd)
 }
@@ -191,6 +193,10 @@ func bucketFindF(cmd *cobra.Command, args []string) {
 		filter.Organization = &bucketFindFlags.org
 	}
 
+	if bucketFindFlags.label != "" {
+		filter.Label = &bucketFindFlags.label
+	}
+
 	buckets, _, err := s.FindBuckets(context.Background(), filter)
 	if err != nil {
 		fmt.Println(err)
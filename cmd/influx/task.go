@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/influxdata/flux/repl"
 	"github.com/influxdata/platform"
@@ -172,6 +173,7 @@ type TaskFindFlags struct {
 	user  string
 	id    string
 	orgID string
+	label string
 	limit int
 }
 
@@ -187,6 +189,7 @@ func init() {
 	taskFindCmd.Flags().StringVarP(&taskFindFlags.id, "id", "i", "", "task ID")
 	taskFindCmd.Flags().StringVarP(&taskFindFlags.user, "user-id", "n", "", "task owner ID")
 	taskFindCmd.Flags().StringVarP(&taskFindFlags.orgID, "org-id", "", "", "task organization ID")
+	taskFindCmd.Flags().StringVarP(&taskFindFlags.label, "label", "l", "", "only find tasks with this label")
 	taskFindCmd.Flags().IntVarP(&taskFindFlags.limit, "limit", "", platform.TaskDefaultPageSize, "the number of tasks to find")
 
 	taskCmd.AddCommand(taskFindCmd)
@@ -217,6 +220,10 @@ func taskFindF(cmd *cobra.Command, args []string) {
 		filter.Organization = id
 	}
 
+	if taskFindFlags.label != "" {
+		filter.Label = &taskFindFlags.label
+	}
+
 	if taskFindFlags.limit < 1 || taskFindFlags.limit > platform.TaskMaxPageSize {
 		fmt.Printf("limit must be between 1 and %d \n", platform.TaskMaxPageSize)
 		os.Exit(1)
@@ -272,8 +279,9 @@ func taskFindF(cmd *cobra.Command, args []string) {
 
 // taskUpdateFlags define the Update Command
 type TaskUpdateFlags struct {
-	id     string
-	status string
+	id          string
+	status      string
+	concurrency int64
 }
 
 var taskUpdateFlags TaskUpdateFlags
@@ -287,6 +295,7 @@ func init() {
 
 	taskUpdateCmd.Flags().StringVarP(&taskUpdateFlags.id, "id", "i", "", "task ID (required)")
 	taskUpdateCmd.Flags().StringVarP(&taskUpdateFlags.status, "status", "", "", "update task status")
+	taskUpdateCmd.Flags().Int64VarP(&taskUpdateFlags.concurrency, "concurrency", "", 0, "update how many runs of the task may execute at once, overriding the task's concurrency option")
 	taskUpdateCmd.MarkFlagRequired("id")
 
 	taskCmd.AddCommand(taskUpdateCmd)
@@ -308,6 +317,9 @@ func taskUpdateF(cmd *cobra.Command, args []string) {
 	if taskUpdateFlags.status != "" {
 		update.Status = &taskUpdateFlags.status
 	}
+	if taskUpdateFlags.concurrency != 0 {
+		update.Concurrency = &taskUpdateFlags.concurrency
+	}
 
 	if len(args) > 0 {
 		flux, err := repl.LoadQuery(args[0])
@@ -332,6 +344,7 @@ func taskUpdateF(cmd *cobra.Command, args []string) {
 		"Status",
 		"Every",
 		"Cron",
+		"Concurrency",
 	)
 	w.Write(map[string]interface{}{
 		"ID":           t.ID.String(),
@@ -340,6 +353,7 @@ func taskUpdateF(cmd *cobra.Command, args []string) {
 		"Status":       t.Status,
 		"Every":        t.Every,
 		"Cron":         t.Cron,
+		"Concurrency":  t.Concurrency,
 	})
 	w.Flush()
 }
@@ -414,6 +428,7 @@ type TaskLogFindFlags struct {
 	taskID string
 	runID  string
 	orgID  string
+	follow bool
 }
 
 var taskLogFindFlags TaskLogFindFlags
@@ -428,6 +443,7 @@ func init() {
 	taskLogFindCmd.Flags().StringVarP(&taskLogFindFlags.taskID, "task-id", "", "", "task id (required)")
 	taskLogFindCmd.Flags().StringVarP(&taskLogFindFlags.runID, "run-id", "", "", "run id")
 	taskLogFindCmd.Flags().StringVarP(&taskLogFindFlags.orgID, "org-id", "", "", "organization id")
+	taskLogFindCmd.Flags().BoolVarP(&taskLogFindFlags.follow, "follow", "f", false, "keep printing logs and run state changes as they happen")
 	taskLogFindCmd.MarkFlagRequired("task-id")
 
 	logCmd.AddCommand(taskLogFindCmd)
@@ -466,6 +482,11 @@ func taskLogFindF(cmd *cobra.Command, args []string) {
 	}
 
 	ctx := context.TODO()
+	if taskLogFindFlags.follow {
+		followTaskLogs(ctx, s, filter)
+		return
+	}
+
 	logs, _, err := s.FindLogs(ctx, filter)
 	if err != nil {
 		fmt.Println(err)
@@ -484,6 +505,33 @@ func taskLogFindF(cmd *cobra.Command, args []string) {
 	w.Flush()
 }
 
+// followTaskLogs prints the logs matching filter, and then each log and run state change as it happens.
+// When following a single run, it returns once the run has finished.
+func followTaskLogs(ctx context.Context, s *http.TaskService, filter platform.LogFilter) {
+	events, err := s.FollowLogs(ctx, filter)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	for e := range events {
+		var prefix string
+		if filter.Run == nil && e.RunID.Valid() {
+			prefix = fmt.Sprintf("[%s] ", e.RunID)
+		}
+
+		switch {
+		case e.Status != "":
+			fmt.Printf("%s%s: run %s\n", prefix, e.Time, e.Status)
+		case e.Time != "":
+			// Logs recorded before following began already include their time.
+			fmt.Printf("%s%s: %s\n", prefix, e.Time, e.Log)
+		default:
+			fmt.Printf("%s%s\n", prefix, e.Log)
+		}
+	}
+}
+
 // taskLogFindFlags define the Delete command
 type TaskRunFindFlags struct {
 	runID      string
@@ -548,7 +596,7 @@ func taskRunFindF(cmd *cobra.Command, args []string) {
 			fmt.Println(err)
 			os.Exit(1)
 		}
-		run, err := s.FindRunByID(context.Background(), *filter.Org, *id)
+		run, err := s.FindRunByID(context.Background(), *filter.Task, *id)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -571,21 +619,126 @@ func taskRunFindF(cmd *cobra.Command, args []string) {
 		"StartedAt",
 		"FinishedAt",
 		"RequestedAt",
+		"Try",
+		"QueuePosition",
 	)
 	for _, r := range runs {
 		w.Write(map[string]interface{}{
-			"ID":           r.ID,
-			"TaskID":       r.TaskID,
-			"Status":       r.Status,
-			"ScheduledFor": r.ScheduledFor,
-			"StartedAt":    r.StartedAt,
-			"FinishedAt":   r.FinishedAt,
-			"RequestedAt":  r.RequestedAt,
+			"ID":            r.ID,
+			"TaskID":        r.TaskID,
+			"Status":        r.Status,
+			"ScheduledFor":  r.ScheduledFor,
+			"StartedAt":     r.StartedAt,
+			"FinishedAt":    r.FinishedAt,
+			"RequestedAt":   r.RequestedAt,
+			"Try":           r.Try,
+			"QueuePosition": r.QueuePosition,
 		})
 	}
 	w.Flush()
 }
 
+type RunCancelFlags struct {
+	taskID, runID string
+}
+
+var runCancelFlags RunCancelFlags
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "cancel",
+		Short: "cancel a run in progress",
+		Run:   runCancelF,
+	}
+
+	cmd.Flags().StringVarP(&runCancelFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.Flags().StringVarP(&runCancelFlags.runID, "run-id", "r", "", "run id (required)")
+	cmd.MarkFlagRequired("task-id")
+	cmd.MarkFlagRequired("run-id")
+
+	runCmd.AddCommand(cmd)
+}
+
+func runCancelF(cmd *cobra.Command, args []string) {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var taskID, runID platform.ID
+	if err := taskID.DecodeFromString(runCancelFlags.taskID); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if err := runID.DecodeFromString(runCancelFlags.runID); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if err := s.CancelRun(context.Background(), taskID, runID); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Canceled task %s's run %s.\n", taskID, runID)
+}
+
+type RunForceFlags struct {
+	taskID       string
+	scheduledFor string
+}
+
+var runForceFlags RunForceFlags
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "force",
+		Short: "queue a run of a task",
+		Run:   runForceF,
+	}
+
+	cmd.Flags().StringVarP(&runForceFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.Flags().StringVarP(&runForceFlags.scheduledFor, "scheduled-for", "", "", "time to run the task for, in RFC3339 format (defaults to the current time)")
+	cmd.MarkFlagRequired("task-id")
+
+	runCmd.AddCommand(cmd)
+}
+
+func runForceF(cmd *cobra.Command, args []string) {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var taskID platform.ID
+	if err := taskID.DecodeFromString(runForceFlags.taskID); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	scheduledFor := time.Now()
+	if runForceFlags.scheduledFor != "" {
+		t, err := time.Parse(time.RFC3339, runForceFlags.scheduledFor)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		scheduledFor = t
+	}
+
+	run, err := s.ForceRun(context.Background(), taskID, scheduledFor.Unix())
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if run.QueuePosition > 0 {
+		fmt.Printf("Run of task %s for %s queued as run %s at position %d.\n", taskID, run.ScheduledFor, run.ID, run.QueuePosition)
+		return
+	}
+	fmt.Printf("Run of task %s for %s started as run %s.\n", taskID, run.ScheduledFor, run.ID)
+}
+
 type RunRetryFlags struct {
 	taskID, runID string
 }
@@ -632,3 +785,126 @@ func runRetryF(cmd *cobra.Command, args []string) {
 
 	fmt.Printf("Retry for task %s's run %s queued as run %s.\n", taskID, runID, newRun.ID)
 }
+
+// TaskValidateFlags define the Validate Command
+type TaskValidateFlags struct {
+	orgID   string
+	now     string
+	count   int
+	execute bool
+}
+
+var taskValidateFlags TaskValidateFlags
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "validate [query literal or @/path/to/query.flux]",
+		Short: "Show what a task would do, without creating it",
+		Args:  cobra.ExactArgs(1),
+		Run:   taskValidateF,
+	}
+
+	cmd.Flags().StringVarP(&taskValidateFlags.orgID, "org-id", "", "", "id of the organization that would own the task")
+	cmd.Flags().StringVarP(&taskValidateFlags.now, "now", "", "", "time to validate the task as of, in RFC3339 format (defaults to the current time)")
+	cmd.Flags().IntVarP(&taskValidateFlags.count, "count", "n", 0, "number of upcoming scheduled times to show")
+	cmd.Flags().BoolVarP(&taskValidateFlags.execute, "execute", "", false, "run the task once and show its results, without writing its output")
+	cmd.MarkFlagRequired("org-id")
+
+	taskCmd.AddCommand(cmd)
+}
+
+func taskValidateF(cmd *cobra.Command, args []string) {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	flux, err := repl.LoadQuery(args[0])
+	if err != nil {
+		fmt.Printf("error parsing flux script: %s\n", err)
+		os.Exit(1)
+	}
+
+	req := platform.TaskValidationRequest{
+		Flux:          flux,
+		Now:           taskValidateFlags.now,
+		ScheduleCount: taskValidateFlags.count,
+		Execute:       taskValidateFlags.execute,
+	}
+	if err := req.Organization.DecodeFromString(taskValidateFlags.orgID); err != nil {
+		fmt.Printf("error parsing organization id: %v\n", err)
+		os.Exit(1)
+	}
+
+	v, err := s.ValidateTask(context.Background(), req)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"Name",
+		"Every",
+		"Cron",
+		"Offset",
+		"Concurrency",
+		"Retry",
+	)
+	w.Write(map[string]interface{}{
+		"Name":        v.Name,
+		"Every":       v.Every,
+		"Cron":        v.Cron,
+		"Offset":      v.Offset,
+		"Concurrency": v.Concurrency,
+		"Retry":       v.Retry,
+	})
+	w.Flush()
+
+	fmt.Println("\nSchedule:")
+	for _, t := range v.Schedule {
+		fmt.Println(" ", t)
+	}
+
+	fmt.Println()
+	w = internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"Access",
+		"Bucket",
+		"ID",
+		"Organization",
+		"Permitted",
+	)
+	writeBuckets := func(access string, bs []platform.TaskValidationBucket) {
+		for _, b := range bs {
+			name := b.Name
+			if b.Error != "" {
+				name += " (" + b.Error + ")"
+			}
+			w.Write(map[string]interface{}{
+				"Access":       access,
+				"Bucket":       name,
+				"ID":           b.ID.String(),
+				"Organization": b.Organization,
+				"Permitted":    b.Permitted,
+			})
+		}
+	}
+	writeBuckets("read", v.ReadBuckets)
+	writeBuckets("write", v.WriteBuckets)
+	w.Flush()
+
+	fmt.Println("\nPlan:")
+	for _, n := range v.Plan {
+		if len(n.Predecessors) == 0 {
+			fmt.Printf("  %s (%s)\n", n.ID, n.Kind)
+			continue
+		}
+		fmt.Printf("  %s (%s) <- %v\n", n.ID, n.Kind, n.Predecessors)
+	}
+
+	if v.Results != "" {
+		fmt.Println("\nResults:")
+		fmt.Print(v.Results)
+	}
+}
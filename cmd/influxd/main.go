@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net"
@@ -16,7 +17,11 @@ import (
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/bolt"
+	"github.com/influxdata/platform/checks"
+	"github.com/influxdata/platform/chronograf"
+	"github.com/influxdata/platform/chronograf/canned"
 	"github.com/influxdata/platform/chronograf/server"
+	"github.com/influxdata/platform/dashboards"
 	"github.com/influxdata/platform/gather"
 	"github.com/influxdata/platform/http"
 	"github.com/influxdata/platform/internal/fs"
@@ -24,19 +29,24 @@ import (
 	"github.com/influxdata/platform/kit/prom"
 	"github.com/influxdata/platform/kit/signals"
 	influxlogger "github.com/influxdata/platform/logger"
+	"github.com/influxdata/platform/macros"
 	"github.com/influxdata/platform/nats"
 	"github.com/influxdata/platform/query"
 	_ "github.com/influxdata/platform/query/builtin"
 	pcontrol "github.com/influxdata/platform/query/control"
+	fstorage "github.com/influxdata/platform/query/functions/inputs/storage"
+	"github.com/influxdata/platform/replication"
 	"github.com/influxdata/platform/snowflake"
 	"github.com/influxdata/platform/source"
 	"github.com/influxdata/platform/storage"
 	"github.com/influxdata/platform/storage/readservice"
+	"github.com/influxdata/platform/storage/writequeue"
 	"github.com/influxdata/platform/task"
 	taskbackend "github.com/influxdata/platform/task/backend"
 	taskbolt "github.com/influxdata/platform/task/backend/bolt"
 	"github.com/influxdata/platform/task/backend/coordinator"
 	taskexecutor "github.com/influxdata/platform/task/backend/executor"
+	"github.com/influxdata/platform/telegraf"
 	_ "github.com/influxdata/platform/tsdb/tsi1"
 	_ "github.com/influxdata/platform/tsdb/tsm1"
 	pzap "github.com/influxdata/platform/zap"
@@ -44,6 +54,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 func main() {
@@ -77,20 +89,40 @@ type Main struct {
 	httpBindAddress string
 	boltPath        string
 	natsPath        string
+	natsPort        int
 	developerMode   bool
 	enginePath      string
+	replicationPath string
+	writeQueuePath  string
+	grpcBindAddress string
+	grpcTLSCert     string
+	grpcTLSKey      string
+	storageHosts    []string
+	storageToken    string
+	storageTLS      bool
+	storageTLSCA    string
+	taskLeaseOwner  string
+	taskLeaseTTL    time.Duration
 
 	boltClient *bolt.Client
 	engine     *storage.Engine
 
+	replicationService *replication.Service
+	writeQueue         *writequeue.Writer
+
 	queryController *pcontrol.Controller
 
 	httpPort   int
 	httpServer *nethttp.Server
 
+	grpcServer    *grpc.Server
+	storageReader fstorage.Reader
+
 	natsServer *nats.Server
 
-	scheduler *taskbackend.TickScheduler
+	taskStore       *taskbolt.Store
+	scheduler       *taskbackend.TickScheduler
+	taskCoordinator *coordinator.Coordinator
 
 	logger *zap.Logger
 
@@ -113,25 +145,58 @@ func (m *Main) URL() string {
 	return fmt.Sprintf("http://localhost:%d", m.httpPort)
 }
 
+// TaskStore returns the store of the tasks and their leases.
+func (m *Main) TaskStore() *taskbolt.Store {
+	return m.taskStore
+}
+
 // Shutdown shuts down the HTTP server and waits for all services to clean up.
 func (m *Main) Shutdown(ctx context.Context) {
 	m.cancel()
 	m.httpServer.Shutdown(ctx)
 
+	if m.grpcServer != nil {
+		m.logger.Info("Stopping", zap.String("service", "grpc"))
+		m.grpcServer.Stop()
+	}
+
 	m.logger.Info("Stopping", zap.String("service", "task"))
 	m.scheduler.Stop()
 
 	m.logger.Info("Stopping", zap.String("service", "nats"))
 	m.natsServer.Close()
 
+	m.logger.Info("Stopping", zap.String("service", "query"))
+	if err := m.queryController.Shutdown(ctx); err != nil {
+		m.logger.Info("Failed closing query service", zap.Error(err))
+	}
+
+	// Tasks, checks and queries write through the queue, so it is closed once
+	// the scheduler and the query controller have drained.
+	m.logger.Info("Stopping", zap.String("service", "write-queue"))
+	if err := m.writeQueue.Close(); err != nil {
+		m.logger.Error("failed to close write queue", zap.Error(err))
+	}
+
+	m.logger.Info("Stopping", zap.String("service", "replication"))
+	if err := m.replicationService.Close(); err != nil {
+		m.logger.Error("failed to close replication service", zap.Error(err))
+	}
+
+	// Release the task leases, so that other nodes claim the tasks right away.
+	// This also closes the task store, which shares the bolt database.
+	m.logger.Info("Stopping", zap.String("service", "task-coordinator"))
+	if err := m.taskCoordinator.Close(); err != nil {
+		m.logger.Info("failed to close task coordinator", zap.Error(err))
+	}
+
 	m.logger.Info("Stopping", zap.String("service", "bolt"))
 	if err := m.boltClient.Close(); err != nil {
 		m.logger.Info("failed closing bolt", zap.Error(err))
 	}
 
-	m.logger.Info("Stopping", zap.String("service", "query"))
-	if err := m.queryController.Shutdown(ctx); err != nil {
-		m.logger.Info("Failed closing query service", zap.Error(err))
+	if m.storageReader != nil {
+		m.storageReader.Close()
 	}
 
 	m.logger.Info("Stopping", zap.String("service", "storage-engine"))
@@ -185,12 +250,76 @@ func (m *Main) Run(ctx context.Context, args ...string) error {
 				Default: filepath.Join(dir, "nats"),
 				Desc:    "path to NATS queue for scraping tasks",
 			},
+			{
+				DestP:   &m.natsPort,
+				Flag:    "nats-port",
+				Default: nats.DefaultPort,
+				Desc:    "port of the NATS streaming server",
+			},
 			{
 				DestP:   &m.enginePath,
 				Flag:    "engine-path",
 				Default: filepath.Join(dir, "engine"),
 				Desc:    "path to persistent engine files",
 			},
+			{
+				DestP:   &m.replicationPath,
+				Flag:    "replication-path",
+				Default: filepath.Join(dir, "replication"),
+				Desc:    "path to the queues of points replicated to remote instances",
+			},
+			{
+				DestP:   &m.writeQueuePath,
+				Flag:    "write-queue-path",
+				Default: filepath.Join(dir, "writequeue"),
+				Desc:    "path to the queue of points written by tasks and checks",
+			},
+			{
+				DestP: &m.grpcBindAddress,
+				Flag:  "grpc-bind-address",
+				Desc:  "bind address for the gRPC storage read service, e.g. :8082; the service is disabled if empty",
+			},
+			{
+				DestP: &m.grpcTLSCert,
+				Flag:  "grpc-tls-cert",
+				Desc:  "TLS certificate file for the gRPC storage read service; the service uses plaintext if empty",
+			},
+			{
+				DestP: &m.grpcTLSKey,
+				Flag:  "grpc-tls-key",
+				Desc:  "TLS private key file for the certificate given by grpc-tls-cert",
+			},
+			{
+				DestP: &m.storageHosts,
+				Flag:  "storage-hosts",
+				Desc:  "addresses of the storage nodes to query over gRPC instead of the local engine",
+			},
+			{
+				DestP: &m.storageToken,
+				Flag:  "storage-token",
+				Desc:  "token authorizing queries of the storage nodes given by storage-hosts",
+			},
+			{
+				DestP:   &m.storageTLS,
+				Flag:    "storage-tls",
+				Default: false,
+				Desc:    "connect to the storage nodes given by storage-hosts over TLS, verifying them against the system roots",
+			},
+			{
+				DestP: &m.storageTLSCA,
+				Flag:  "storage-tls-ca",
+				Desc:  "CA certificate file to verify the storage nodes given by storage-hosts against; implies storage-tls",
+			},
+			{
+				DestP: &m.taskLeaseTTL,
+				Flag:  "task-lease-ttl",
+				Desc:  "lease tasks for this long, renewing the leases on a heartbeat, so that the nodes sharing the task store partition its tasks; tasks are not leased if 0",
+			},
+			{
+				DestP: &m.taskLeaseOwner,
+				Flag:  "task-lease-owner",
+				Desc:  "name identifying this node as the owner of task leases; defaults to the hostname",
+			},
 		},
 	}
 
@@ -251,12 +380,15 @@ func (m *Main) run(ctx context.Context) (err error) {
 		dashboardLogSvc  platform.DashboardOperationLogService    = m.boltClient
 		userLogSvc       platform.UserOperationLogService         = m.boltClient
 		bucketLogSvc     platform.BucketOperationLogService       = m.boltClient
+		bucketSchemaSvc  platform.BucketSchemaService             = m.boltClient
 		orgLogSvc        platform.OrganizationOperationLogService = m.boltClient
 		onboardingSvc    platform.OnboardingService               = m.boltClient
 		scraperTargetSvc platform.ScraperTargetStoreService       = m.boltClient
 		telegrafSvc      platform.TelegrafConfigStore             = m.boltClient
+		telegrafAgentSvc platform.TelegrafAgentService            = m.boltClient
 		userResourceSvc  platform.UserResourceMappingService      = m.boltClient
 		labelSvc         platform.LabelService                    = m.boltClient
+		endpointSvc      platform.NotificationEndpointService     = m.boltClient
 	)
 
 	chronografSvc, err := server.NewServiceV2(ctx, m.boltClient.DB())
@@ -265,10 +397,34 @@ func (m *Main) run(ctx context.Context) (err error) {
 		return err
 	}
 
-	var pointsWriter storage.PointsWriter
+	dashboardTemplateSvc := &dashboards.TemplateService{
+		DashboardService:           dashboardSvc,
+		ViewService:                viewSvc,
+		MacroService:               macroSvc,
+		LabelService:               labelSvc,
+		UserResourceMappingService: userResourceSvc,
+	}
+
+	telegrafAgentAuthSvc := &telegraf.AgentAuthorizationService{
+		TelegrafConfigStore:  telegrafSvc,
+		BucketService:        bucketSvc,
+		AuthorizationService: authSvc,
+	}
+
+	dashboardTemplates, err := dashboards.LayoutTemplates(ctx, &canned.BinLayoutsStore{Logger: &chronograf.NoopLogger{}})
+	if err != nil {
+		m.logger.Error("failed loading canned dashboard templates", zap.Error(err))
+		return err
+	}
+
+	var (
+		pointsWriter storage.PointsWriter
+		queuedWriter storage.PointsWriter // Used by internal writers, which cannot retry failed writes.
+	)
 	{
-		m.engine = storage.NewEngine(m.enginePath, storage.NewConfig(), storage.WithRetentionEnforcer(bucketSvc))
+		m.engine = storage.NewEngine(m.enginePath, storage.NewConfig(), storage.WithRetentionEnforcer(bucketSvc), storage.WithBucketSchemas(m.boltClient))
 		m.engine.WithLogger(m.logger)
+		bucketSchemaSvc = storage.NewBucketSchemaService(bucketSchemaSvc, m.engine)
 
 		if err := m.engine.Open(); err != nil {
 			m.logger.Error("failed to open engine", zap.Error(err))
@@ -277,7 +433,27 @@ func (m *Main) run(ctx context.Context) (err error) {
 		// The Engine's metrics must be registered after it opens.
 		reg.MustRegister(m.engine.PrometheusCollectors()...)
 
-		pointsWriter = m.engine
+		m.replicationService = replication.NewService(m.replicationPath, m.engine, m.boltClient, func(r *platform.ReplicationRule) platform.WriteService {
+			return &http.WriteService{Addr: r.RemoteURL, Token: r.RemoteToken}
+		})
+		m.replicationService.WithLogger(m.logger)
+		if err := m.replicationService.Open(); err != nil {
+			m.logger.Error("failed to open replication service", zap.Error(err))
+			return err
+		}
+		reg.MustRegister(m.replicationService.PrometheusCollectors()...)
+
+		// Points written by every writer are replicated to the remotes of their bucket.
+		pointsWriter = m.replicationService
+
+		m.writeQueue = writequeue.NewWriter(m.writeQueuePath, pointsWriter)
+		m.writeQueue.WithLogger(m.logger)
+		if err := m.writeQueue.Open(); err != nil {
+			m.logger.Error("failed to open write queue", zap.Error(err))
+			return err
+		}
+		reg.MustRegister(m.writeQueue.PrometheusCollectors()...)
+		queuedWriter = m.writeQueue
 
 		const (
 			concurrencyQuota = 10
@@ -291,9 +467,19 @@ func (m *Main) run(ctx context.Context) (err error) {
 			Logger:               m.logger.With(zap.String("service", "storage-reads")),
 		}
 
-		if err := readservice.AddControllerConfigDependencies(
-			&cc, m.engine, bucketSvc, orgSvc,
-		); err != nil {
+		// A query node given storage hosts reads from them rather than its own engine.
+		if len(m.storageHosts) > 0 {
+			creds, err := m.storageTransportCredentials()
+			if err != nil {
+				m.logger.Error("Failed to load storage TLS credentials", zap.Error(err))
+				return err
+			}
+			m.storageReader = readservice.NewRemoteReader(fstorage.NewStaticLookup(m.storageHosts), m.storageToken, creds)
+			err = readservice.AddControllerConfigDependenciesWithReader(&cc, m.storageReader, queuedWriter, bucketSvc, orgSvc)
+		} else {
+			err = readservice.AddControllerConfigDependencies(&cc, m.engine, queuedWriter, bucketSvc, orgSvc)
+		}
+		if err != nil {
 			m.logger.Error("Failed to configure query controller dependencies", zap.Error(err))
 			return err
 		}
@@ -303,35 +489,70 @@ func (m *Main) run(ctx context.Context) (err error) {
 	}
 
 	var storageQueryService query.ProxyQueryService = readservice.NewProxyQueryService(m.queryController)
-	var taskSvc platform.TaskService
+	macroEvaluationSvc := &macros.Evaluator{
+		MacroService:      macroSvc,
+		ProxyQueryService: storageQueryService,
+	}
+	var (
+		taskSvc         platform.TaskService
+		taskValidateSvc platform.TaskValidationService
+		runEvents       *taskbackend.RunEventHub
+		checkSvc        platform.CheckService
+		templateSvc     *task.TemplateService
+	)
 	{
 		boltStore, err := taskbolt.New(m.boltClient.DB(), "tasks")
 		if err != nil {
 			m.logger.Error("failed opening task bolt", zap.Error(err))
 			return err
 		}
+		m.taskStore = boltStore
 
-		executor := taskexecutor.NewAsyncQueryServiceExecutor(m.logger.With(zap.String("service", "task-executor")), m.queryController, boltStore)
+		queryService := query.QueryServiceBridge{AsyncQueryService: m.queryController}
 
-		lw := taskbackend.NewPointLogWriter(pointsWriter)
+		var executor taskbackend.Executor = taskexecutor.NewAsyncQueryServiceExecutor(m.logger.With(zap.String("service", "task-executor")), m.queryController, boltStore)
+		// Tasks that schedule checks evaluate the check rather than running their script.
+		evaluator := checks.NewEvaluator(m.logger.With(zap.String("service", "checks")), queryService, queuedWriter, endpointSvc)
+		executor = checks.NewExecutor(m.logger.With(zap.String("service", "check-executor")), executor, m.boltClient, evaluator)
+
+		runEvents = taskbackend.NewRunEventHub()
+		lw := runEvents.LogWriter(taskbackend.NewPointLogWriter(queuedWriter))
 		m.scheduler = taskbackend.NewScheduler(boltStore, executor, lw, time.Now().UTC().Unix(), taskbackend.WithTicker(ctx, 100*time.Millisecond), taskbackend.WithLogger(m.logger))
 		m.scheduler.Start(ctx)
 		reg.MustRegister(m.scheduler.PrometheusCollectors()...)
 
-		queryService := query.QueryServiceBridge{AsyncQueryService: m.queryController}
+		var coordOpts []coordinator.Option
+		if m.taskLeaseTTL > 0 {
+			owner := m.taskLeaseOwner
+			if owner == "" {
+				if owner, err = os.Hostname(); err != nil {
+					m.logger.Error("failed to determine task lease owner", zap.Error(err))
+					return err
+				}
+			}
+			m.logger.Info("Leasing tasks", zap.String("owner", owner), zap.Duration("ttl", m.taskLeaseTTL))
+			coordOpts = append(coordOpts, coordinator.WithLeases(boltStore, owner, m.taskLeaseTTL))
+		}
+		m.taskCoordinator = coordinator.New(m.logger.With(zap.String("service", "task-coordinator")), m.scheduler, boltStore, coordOpts...)
+
 		lr := taskbackend.NewQueryLogReader(queryService)
-		taskSvc = task.PlatformAdapter(coordinator.New(m.logger.With(zap.String("service", "task-coordinator")), m.scheduler, boltStore), lr, m.scheduler)
+		taskSvc = task.PlatformAdapter(m.taskCoordinator, lr, m.scheduler, task.WithLabelledResourceFinder(m.boltClient))
 		taskSvc = task.NewValidator(taskSvc, bucketSvc)
+		taskValidateSvc = task.NewValidationService(bucketSvc, queryService)
+
+		checkSvc = checks.NewService(m.boltClient, taskSvc)
+		templateSvc = task.NewTemplateService(m.boltClient, taskSvc, bucketSvc)
 	}
 
 	// NATS streaming server
-	m.natsServer = nats.NewServer(nats.Config{FilestoreDir: m.natsPath})
+	m.natsServer = nats.NewServer(nats.Config{FilestoreDir: m.natsPath, Port: m.natsPort})
 	if err := m.natsServer.Open(); err != nil {
 		m.logger.Error("failed to start nats streaming server", zap.Error(err))
 		return err
 	}
 
 	publisher := nats.NewAsyncPublisher("nats-publisher")
+	publisher.URL = m.natsServer.URL()
 	if err := publisher.Open(); err != nil {
 		m.logger.Error("failed to connect to streaming server", zap.Error(err))
 		return err
@@ -339,11 +560,26 @@ func (m *Main) run(ctx context.Context) (err error) {
 
 	// TODO(jm): this is an example of using a subscriber to consume from the channel. It should be removed.
 	subscriber := nats.NewQueueSubscriber("nats-subscriber")
+	subscriber.URL = m.natsServer.URL()
 	if err := subscriber.Open(); err != nil {
 		m.logger.Error("failed to connect to streaming server", zap.Error(err))
 		return err
 	}
 
+	// Scraped metrics are written through the write queue, so that they are
+	// retried rather than lost while the engine refuses writes.
+	if err := subscriber.Subscribe(gather.MetricsSubject, "", &gather.StorageHandler{
+		Logger: m.logger.With(zap.String("service", "scraper-storage")),
+		Storage: &gather.PointsWriterStorage{
+			OrganizationService: orgSvc,
+			BucketService:       bucketSvc,
+			PointsWriter:        queuedWriter,
+		},
+	}); err != nil {
+		m.logger.Error("failed to create scraper storage subscriber", zap.Error(err))
+		return err
+	}
+
 	scraperScheduler, err := gather.NewScheduler(10, m.logger, scraperTargetSvc, publisher, subscriber, 0, 0)
 	if err != nil {
 		m.logger.Error("failed to create scraper subscriber", zap.Error(err))
@@ -360,6 +596,46 @@ func (m *Main) run(ctx context.Context) (err error) {
 		logger.Info("Stopping")
 	}(m.logger)
 
+	// gRPC storage read service, served only when given a bind address.
+	if m.grpcBindAddress != "" {
+		grpcLogger := m.logger.With(zap.String("service", "grpc"))
+
+		var opts []grpc.ServerOption
+		if m.grpcTLSCert != "" || m.grpcTLSKey != "" {
+			creds, err := credentials.NewServerTLSFromFile(m.grpcTLSCert, m.grpcTLSKey)
+			if err != nil {
+				grpcLogger.Error("failed to load grpc TLS credentials", zap.Error(err))
+				return err
+			}
+			opts = append(opts, grpc.Creds(creds))
+		} else {
+			grpcLogger.Warn("Serving gRPC storage reads without TLS; tokens are sent in plaintext")
+		}
+
+		readServer := readservice.NewServer(m.engine, authSvc)
+		readServer.Logger = grpcLogger
+		m.grpcServer = grpc.NewServer(opts...)
+		readServer.Register(m.grpcServer)
+
+		grpcListener, err := net.Listen("tcp", m.grpcBindAddress)
+		if err != nil {
+			grpcLogger.Error("failed grpc listener", zap.Error(err))
+			grpcLogger.Info("Stopping")
+			return err
+		}
+
+		m.wg.Add(1)
+		go func(logger *zap.Logger) {
+			defer m.wg.Done()
+			logger.Info("Listening", zap.String("transport", "grpc"), zap.String("addr", m.grpcBindAddress))
+
+			if err := m.grpcServer.Serve(grpcListener); err != nil {
+				logger.Error("failed grpc service", zap.Error(err))
+			}
+			logger.Info("Stopping")
+		}(grpcLogger)
+	}
+
 	m.httpServer = &nethttp.Server{
 		Addr: m.httpBindAddress,
 	}
@@ -378,17 +654,30 @@ func (m *Main) run(ctx context.Context) (err error) {
 		LabelService:                    labelSvc,
 		DashboardService:                dashboardSvc,
 		DashboardOperationLogService:    dashboardLogSvc,
+		DashboardTemplateService:        dashboardTemplateSvc,
+		DashboardTemplates:              dashboardTemplates,
 		BucketOperationLogService:       bucketLogSvc,
+		BucketSchemaService:             bucketSchemaSvc,
 		UserOperationLogService:         userLogSvc,
 		OrganizationOperationLogService: orgLogSvc,
 		ViewService:                     viewSvc,
 		SourceService:                   sourceSvc,
 		MacroService:                    macroSvc,
+		MacroEvaluationService:          macroEvaluationSvc,
 		BasicAuthService:                basicAuthSvc,
 		OnboardingService:               onboardingSvc,
 		ProxyQueryService:               storageQueryService,
 		TaskService:                     taskSvc,
+		RunEventService:                 runEvents,
+		TaskValidationService:           taskValidateSvc,
+		CheckService:                    checkSvc,
+		TaskTemplateService:             templateSvc,
+		TaskTemplateInstanceService:     templateSvc,
+		NotificationEndpointService:     endpointSvc,
+		ReplicationRuleService:          m.replicationService.RuleService(),
 		TelegrafService:                 telegrafSvc,
+		TelegrafAgentService:            telegrafAgentSvc,
+		TelegrafAgentAuthService:        telegrafAgentAuthSvc,
 		ScraperTargetStoreService:       scraperTargetSvc,
 		ChronografService:               chronografSvc,
 	}
@@ -400,6 +689,7 @@ func (m *Main) run(ctx context.Context) (err error) {
 
 	h := http.NewHandlerFromRegistry("platform", reg)
 	h.Handler = platformHandler
+	h.ReadyHandler = http.NewReadyHandler(m.engine.WriteBackpressure)
 	h.Logger = httpLogger
 	h.Tracer = opentracing.GlobalTracer()
 
@@ -429,3 +719,21 @@ func (m *Main) run(ctx context.Context) (err error) {
 
 	return nil
 }
+
+// storageTransportCredentials returns the dial option securing the connections
+// to the storage hosts: TLS verified against storageTLSCA, or the system roots
+// if only storageTLS is set, and plaintext otherwise.
+func (m *Main) storageTransportCredentials() (grpc.DialOption, error) {
+	if m.storageTLSCA != "" {
+		creds, err := credentials.NewClientTLSFromFile(m.storageTLSCA, "")
+		if err != nil {
+			return nil, err
+		}
+		return grpc.WithTransportCredentials(creds), nil
+	}
+	if m.storageTLS {
+		return grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})), nil
+	}
+	m.logger.Warn("Reading from storage hosts without TLS; the storage token is sent in plaintext")
+	return grpc.WithInsecure(), nil
+}
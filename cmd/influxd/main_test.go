@@ -3,19 +3,26 @@ package main_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	nethttp "net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	bbolt "github.com/coreos/bbolt"
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/cmd/influxd"
 	"github.com/influxdata/platform/http"
+	"github.com/influxdata/platform/task/backend"
+	taskbolt "github.com/influxdata/platform/task/backend/bolt"
 )
 
 // Default context.
@@ -77,6 +84,127 @@ func TestMain_WriteAndQuery(t *testing.T) {
 	}
 }
 
+func TestMain_TaskLeases(t *testing.T) {
+	// Tasks are leased to the node, and the leases released on shutdown.
+	m := RunMainOrFail(t, ctx, "--task-lease-ttl", "1m", "--task-lease-owner", "node-a")
+	defer os.RemoveAll(m.Path)
+	m.SetupOrFail(t)
+
+	// The onboarding token may not create tasks.
+	auth := &platform.Authorization{
+		UserID: m.User.ID,
+		Permissions: []platform.Permission{
+			{Action: platform.CreateAction, Resource: platform.TaskResource(m.Org.ID)},
+			platform.ReadBucketPermission(m.Bucket.ID),
+			platform.WriteBucketPermission(m.Bucket.ID),
+		},
+	}
+	if err := (&http.AuthorizationService{Addr: m.URL(), Token: m.Auth.Token}).CreateAuthorization(ctx, auth); err != nil {
+		m.Main.Shutdown(ctx)
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	tsk := &platform.Task{
+		Organization: m.Org.ID,
+		Status:       "active",
+		Flux: `option task = {name: "leased", every: 1h}
+
+from(bucket: "BUCKET") |> range(start: -1h) |> to(bucket: "BUCKET", org: "ORG")`,
+	}
+	if err := (http.TaskService{Addr: m.URL(), Token: auth.Token}).CreateTask(ctx, tsk); err != nil {
+		m.Main.Shutdown(ctx)
+		t.Fatal(err)
+	}
+
+	lease, err := m.TaskStore().FindTaskLease(ctx, tsk.ID)
+	end := time.Now()
+	m.Main.Shutdown(ctx)
+	if err != nil {
+		t.Fatal(err)
+	} else if lease.Owner != "node-a" {
+		t.Fatalf("unexpected lease owner: %q", lease.Owner)
+	} else if lease.Expires < start.Add(time.Minute).Unix() || lease.Expires > end.Add(time.Minute).Unix() {
+		t.Fatalf("unexpected lease expiration: %d", lease.Expires)
+	}
+
+	// The bolt database is closed on shutdown, so reopen it to check the lease.
+	db, err := bbolt.Open(filepath.Join(m.Path, "influxd.bolt"), 0666, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	store, err := taskbolt.New(db, "tasks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.FindTaskLease(ctx, tsk.ID); err != backend.ErrTaskLeaseNotFound {
+		t.Fatalf("expected lease to be released, got %v", err)
+	}
+}
+
+func TestMain_Replication(t *testing.T) {
+	leader := RunMainOrFail(t, ctx)
+	leader.SetupOrFail(t)
+	defer leader.ShutdownOrFail(t, ctx)
+
+	follower := RunMainOrFail(t, ctx)
+	follower.SetupOrFail(t)
+	defer follower.ShutdownOrFail(t, ctx)
+
+	// Replicate the points written to the bucket of the leader to the bucket of the follower.
+	rule, err := json.Marshal(&platform.ReplicationRule{
+		OrganizationID: leader.Org.ID,
+		BucketID:       leader.Bucket.ID,
+		Name:           "follower",
+		RemoteURL:      follower.URL(),
+		RemoteToken:    follower.Auth.Token,
+		RemoteOrgID:    follower.Org.ID,
+		RemoteBucketID: follower.Bucket.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := nethttp.DefaultClient.Do(leader.MustNewHTTPRequest("POST", "/api/v2/replications", string(rule))); err != nil {
+		t.Fatal(err)
+	} else if err := resp.Body.Close(); err != nil {
+		t.Fatal(err)
+	} else if resp.StatusCode != nethttp.StatusCreated {
+		t.Fatalf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if resp, err := nethttp.DefaultClient.Do(leader.MustNewHTTPRequest("POST", fmt.Sprintf("/api/v2/write?org=%s&bucket=%s", leader.Org.ID, leader.Bucket.ID), `m,k=v f=100i 946684800000000000`)); err != nil {
+		t.Fatal(err)
+	} else if err := resp.Body.Close(); err != nil {
+		t.Fatal(err)
+	} else if resp.StatusCode != nethttp.StatusNoContent {
+		t.Fatalf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	// Query the follower until the replicated write arrives.
+	qs := `from(bucket:"BUCKET") |> range(start:2000-01-01T00:00:00Z,stop:2000-01-02T00:00:00Z)`
+	exp := `,result,table,_start,_stop,_time,_value,_field,_measurement,k` + "\r\n" +
+		`,result,table,2000-01-01T00:00:00Z,2000-01-02T00:00:00Z,2000-01-01T00:00:00Z,100,f,m,v` + "\r\n\r\n"
+
+	req := (http.QueryRequest{Query: qs, Org: follower.Org}).WithDefaults()
+	preq, err := req.ProxyRequest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		var buf bytes.Buffer
+		if _, err := follower.FluxService().Query(ctx, &buf, preq); err != nil {
+			t.Fatal(err)
+		} else if buf.String() == exp {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatal(cmp.Diff(buf.String(), exp))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
 // Main is a test wrapper for main.Main.
 type Main struct {
 	*main.Main
@@ -129,8 +257,12 @@ func RunMainOrFail(tb testing.TB, ctx context.Context, args ...string) *Main {
 func (m *Main) Run(ctx context.Context, args ...string) error {
 	args = append(args, "--bolt-path", filepath.Join(m.Path, "influxd.bolt"))
 	args = append(args, "--engine-path", filepath.Join(m.Path, "engine"))
+	args = append(args, "--replication-path", filepath.Join(m.Path, "replication"))
+	args = append(args, "--write-queue-path", filepath.Join(m.Path, "writequeue"))
 	args = append(args, "--nats-path", filepath.Join(m.Path, "nats"))
+	args = append(args, "--nats-port", strconv.Itoa(MustFreePort()))
 	args = append(args, "--http-bind-address", "127.0.0.1:0")
+	args = append(args, "--grpc-bind-address", "127.0.0.1:0")
 	args = append(args, "--log-level", "debug")
 	return m.Main.Run(ctx, args...)
 }
@@ -182,3 +314,13 @@ func (m *Main) MustNewHTTPRequest(method, rawurl, body string) *nethttp.Request
 	req.Header.Set("Authorization", "Token "+m.Auth.Token)
 	return req
 }
+
+// MustFreePort returns a port that is not in use. Panic on error.
+func MustFreePort() int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
@@ -103,6 +103,8 @@ type Cell struct {
 // DashboardFilter is a filter for dashboards.
 type DashboardFilter struct {
 	IDs []*ID
+	// Label restricts the dashboards to those with a label of this name.
+	Label *string
 }
 
 // DashboardUpdate is the patch structure for a dashboard.
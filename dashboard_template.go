@@ -0,0 +1,129 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+)
+
+// DashboardTemplateVersion is the version of the dashboard template document
+// produced by ExportDashboard.
+const DashboardTemplateVersion = "1"
+
+// DashboardTemplateService represents a service for moving dashboards
+// between installs as portable, self-contained documents.
+type DashboardTemplateService interface {
+	// ExportDashboard returns a template containing the dashboard with the
+	// provided ID, along with the views, macros and labels it references.
+	ExportDashboard(ctx context.Context, id ID) (*DashboardTemplate, error)
+
+	// ImportDashboard recreates the dashboard described by t with fresh
+	// identifiers and returns the new dashboard.
+	ImportDashboard(ctx context.Context, t *DashboardTemplate, opts ImportDashboardOptions) (*Dashboard, error)
+}
+
+// DashboardTemplate is a portable representation of a dashboard. It does not
+// reference any identifiers of the install it was exported from.
+type DashboardTemplate struct {
+	Meta        DashboardTemplateMeta    `json:"meta"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Cells       []*DashboardTemplateCell `json:"cells"`
+	Macros      []*Macro                 `json:"macros"`
+	Labels      []DashboardTemplateLabel `json:"labels"`
+}
+
+// DashboardTemplateMeta contains meta information about a dashboard template.
+type DashboardTemplateMeta struct {
+	Version string `json:"version"`
+}
+
+// DashboardTemplateCell is a dashboard cell along with its view.
+type DashboardTemplateCell struct {
+	X    int32 `json:"x"`
+	Y    int32 `json:"y"`
+	W    int32 `json:"w"`
+	H    int32 `json:"h"`
+	View *View `json:"view"`
+}
+
+// DashboardTemplateLabel is a label of the dashboard. Labels are referenced by
+// name; the properties are only applied to labels created by an import.
+type DashboardTemplateLabel struct {
+	Name       string            `json:"name"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// ImportDashboardOptions are options for importing a dashboard template.
+type ImportDashboardOptions struct {
+	// Owner is the user that will own the imported dashboard. If it is not
+	// valid no owner is recorded.
+	Owner ID
+
+	// OrganizationID is the organization whose labels are applied to the
+	// imported dashboard. It is required when the template has labels.
+	OrganizationID ID
+}
+
+// Valid returns an error if the dashboard template is invalid.
+func (t *DashboardTemplate) Valid() error {
+	if t.Meta.Version != DashboardTemplateVersion {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("unsupported dashboard template version %q", t.Meta.Version),
+		}
+	}
+
+	if t.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "dashboard template name is required",
+		}
+	}
+
+	for i, c := range t.Cells {
+		if c == nil {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("dashboard template cell %d is empty", i),
+			}
+		}
+		if c.View == nil {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("dashboard template cell %d is missing a view", i),
+			}
+		}
+	}
+
+	for i, m := range t.Macros {
+		if m == nil {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("dashboard template macro %d is empty", i),
+			}
+		}
+		if m.Arguments == nil {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("macro %q is missing arguments", m.Name),
+			}
+		}
+		if err := m.Valid(); err != nil {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("macro %q: %v", m.Name, err),
+			}
+		}
+	}
+
+	for i, l := range t.Labels {
+		if l.Name == "" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("dashboard template label %d is missing a name", i),
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package platform_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/platform"
+)
+
+func TestDashboardTemplateValid(t *testing.T) {
+	meta := platform.DashboardTemplateMeta{Version: platform.DashboardTemplateVersion}
+	view := &platform.View{
+		ViewContents: platform.ViewContents{Name: "md"},
+		Properties:   platform.MarkdownViewProperties{Type: "markdown", Note: "hi"},
+	}
+	macro := &platform.Macro{
+		Name:     "host",
+		Selected: []string{"a"},
+		Arguments: &platform.MacroArguments{
+			Type:   "constant",
+			Values: platform.MacroConstantValues{"a", "b"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template platform.DashboardTemplate
+		wantErr  bool
+	}{
+		{
+			name: "valid template",
+			template: platform.DashboardTemplate{
+				Meta:   meta,
+				Name:   "hello",
+				Cells:  []*platform.DashboardTemplateCell{{X: 1, Y: 2, W: 3, H: 4, View: view}},
+				Macros: []*platform.Macro{macro},
+				Labels: []platform.DashboardTemplateLabel{{Name: "prod", Properties: map[string]string{"color": "ffb3b3"}}},
+			},
+		},
+		{
+			name:     "unsupported version",
+			template: platform.DashboardTemplate{Meta: platform.DashboardTemplateMeta{Version: "999"}, Name: "hello"},
+			wantErr:  true,
+		},
+		{
+			name:     "template requires a name",
+			template: platform.DashboardTemplate{Meta: meta},
+			wantErr:  true,
+		},
+		{
+			name:     "empty cell",
+			template: platform.DashboardTemplate{Meta: meta, Name: "hello", Cells: []*platform.DashboardTemplateCell{nil}},
+			wantErr:  true,
+		},
+		{
+			name:     "cell without a view",
+			template: platform.DashboardTemplate{Meta: meta, Name: "hello", Cells: []*platform.DashboardTemplateCell{{X: 1}}},
+			wantErr:  true,
+		},
+		{
+			name:     "empty macro",
+			template: platform.DashboardTemplate{Meta: meta, Name: "hello", Macros: []*platform.Macro{nil}},
+			wantErr:  true,
+		},
+		{
+			name:     "macro without arguments",
+			template: platform.DashboardTemplate{Meta: meta, Name: "hello", Macros: []*platform.Macro{{Name: "host", Selected: []string{"a"}}}},
+			wantErr:  true,
+		},
+		{
+			name:     "label without a name",
+			template: platform.DashboardTemplate{Meta: meta, Name: "hello", Labels: []platform.DashboardTemplateLabel{{}}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.template.Valid()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Valid() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.EInvalid {
+				t.Fatalf("Valid() error code = %s, want %s", platform.ErrorCode(err), platform.EInvalid)
+			}
+		})
+	}
+}
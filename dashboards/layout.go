@@ -0,0 +1,117 @@
+package dashboards
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/chronograf"
+)
+
+// TemplateFromLayout converts a chronograf canned layout, such as those in
+// chronograf/canned, into a dashboard template. Layout queries are InfluxQL
+// and are kept verbatim, including their :db: and :rp: macro references.
+func TemplateFromLayout(l chronograf.Layout) *platform.DashboardTemplate {
+	t := &platform.DashboardTemplate{
+		Meta:        platform.DashboardTemplateMeta{Version: platform.DashboardTemplateVersion},
+		Name:        fmt.Sprintf("%s (%s)", l.Application, l.Measurement),
+		Description: fmt.Sprintf("Imported from the %s %s layout", l.Application, l.Measurement),
+		Cells:       make([]*platform.DashboardTemplateCell, 0, len(l.Cells)),
+		Macros:      []*platform.Macro{},
+		Labels:      []platform.DashboardTemplateLabel{{Name: l.Application}},
+	}
+
+	for _, c := range l.Cells {
+		t.Cells = append(t.Cells, &platform.DashboardTemplateCell{
+			X: c.X,
+			Y: c.Y,
+			W: c.W,
+			H: c.H,
+			View: &platform.View{
+				ViewContents: platform.ViewContents{Name: c.Name},
+				Properties:   layoutCellProperties(c),
+			},
+		})
+	}
+
+	return t
+}
+
+func layoutCellProperties(c chronograf.Cell) platform.ViewProperties {
+	queries := make([]platform.DashboardQuery, 0, len(c.Queries))
+	for _, q := range c.Queries {
+		queries = append(queries, platform.DashboardQuery{
+			Text:     q.Command,
+			Type:     "influxql",
+			EditMode: "advanced",
+			Name:     q.Label,
+		})
+	}
+
+	colors := make([]platform.ViewColor, 0, len(c.CellColors))
+	for _, cc := range c.CellColors {
+		// Unparseable values are left at zero, mirroring how the chronograf
+		// UI treats them.
+		v, _ := strconv.ParseFloat(cc.Value, 64)
+		colors = append(colors, platform.ViewColor{
+			ID:    cc.ID,
+			Type:  cc.Type,
+			Hex:   cc.Hex,
+			Name:  cc.Name,
+			Value: v,
+		})
+	}
+
+	if c.Type == "single-stat" {
+		return platform.SingleStatViewProperties{
+			Type:       "single-stat",
+			Queries:    queries,
+			ViewColors: colors,
+		}
+	}
+
+	axes := make(map[string]platform.Axis, len(c.Axes))
+	for k, a := range c.Axes {
+		axes[k] = platform.Axis{
+			Bounds: a.Bounds,
+			Label:  a.Label,
+			Prefix: a.Prefix,
+			Suffix: a.Suffix,
+			Base:   a.Base,
+			Scale:  a.Scale,
+		}
+	}
+
+	geom := "line"
+	switch c.Type {
+	case "line-stacked":
+		geom = "stacked"
+	case "line-stepplot":
+		geom = "step"
+	case "bar":
+		geom = "bar"
+	}
+
+	return platform.XYViewProperties{
+		Type:       "xy",
+		Queries:    queries,
+		Axes:       axes,
+		Geom:       geom,
+		ViewColors: colors,
+	}
+}
+
+// LayoutTemplates returns a dashboard template for every layout in store.
+func LayoutTemplates(ctx context.Context, store chronograf.LayoutsStore) ([]*platform.DashboardTemplate, error) {
+	layouts, err := store.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := make([]*platform.DashboardTemplate, 0, len(layouts))
+	for _, l := range layouts {
+		ts = append(ts, TemplateFromLayout(l))
+	}
+	return ts, nil
+}
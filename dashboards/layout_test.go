@@ -0,0 +1,100 @@
+package dashboards_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/chronograf"
+	"github.com/influxdata/platform/dashboards"
+)
+
+func TestTemplateFromLayout(t *testing.T) {
+	l := chronograf.Layout{
+		ID:          "0fa47984-825b-46f1-9ca5-0366e3281cc5",
+		Application: "system",
+		Measurement: "cpu",
+		Cells: []chronograf.Cell{
+			{
+				X: 0, Y: 0, W: 4, H: 4,
+				Name: "CPU Usage",
+				Queries: []chronograf.Query{
+					{
+						Command: `SELECT 100 - mean("usage_idle") AS "usage" FROM ":db:".":rp:"."cpu"`,
+						Label:   "% CPU time",
+					},
+				},
+				Type: "line-stacked",
+			},
+			{
+				X: 4, Y: 0, W: 2, H: 4,
+				Name: "Load",
+				Queries: []chronograf.Query{
+					{Command: `SELECT last("load1") FROM ":db:".":rp:"."system"`},
+				},
+				Type: "single-stat",
+				CellColors: []chronograf.CellColor{
+					{ID: "base", Type: "text", Hex: "#00C9FF", Name: "laser", Value: "-1000000000000000000"},
+				},
+			},
+		},
+	}
+
+	got := dashboards.TemplateFromLayout(l)
+	if err := got.Valid(); err != nil {
+		t.Fatalf("template from layout is invalid: %v", err)
+	}
+
+	want := &platform.DashboardTemplate{
+		Meta:        platform.DashboardTemplateMeta{Version: platform.DashboardTemplateVersion},
+		Name:        "system (cpu)",
+		Description: "Imported from the system cpu layout",
+		Macros:      []*platform.Macro{},
+		Labels:      []platform.DashboardTemplateLabel{{Name: "system"}},
+		Cells: []*platform.DashboardTemplateCell{
+			{
+				X: 0, Y: 0, W: 4, H: 4,
+				View: &platform.View{
+					ViewContents: platform.ViewContents{Name: "CPU Usage"},
+					Properties: platform.XYViewProperties{
+						Type: "xy",
+						Geom: "stacked",
+						Queries: []platform.DashboardQuery{
+							{
+								Text:     `SELECT 100 - mean("usage_idle") AS "usage" FROM ":db:".":rp:"."cpu"`,
+								Type:     "influxql",
+								EditMode: "advanced",
+								Name:     "% CPU time",
+							},
+						},
+						Axes:       map[string]platform.Axis{},
+						ViewColors: []platform.ViewColor{},
+					},
+				},
+			},
+			{
+				X: 4, Y: 0, W: 2, H: 4,
+				View: &platform.View{
+					ViewContents: platform.ViewContents{Name: "Load"},
+					Properties: platform.SingleStatViewProperties{
+						Type: "single-stat",
+						Queries: []platform.DashboardQuery{
+							{
+								Text:     `SELECT last("load1") FROM ":db:".":rp:"."system"`,
+								Type:     "influxql",
+								EditMode: "advanced",
+							},
+						},
+						ViewColors: []platform.ViewColor{
+							{ID: "base", Type: "text", Hex: "#00C9FF", Name: "laser", Value: -1e18},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected template -want/+got:\n%s", diff)
+	}
+}
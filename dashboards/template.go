@@ -0,0 +1,344 @@
+// Package dashboards provides services that operate across dashboards and
+// the views, macros and labels they reference.
+package dashboards
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.DashboardTemplateService = (*TemplateService)(nil)
+
+// TemplateService exports dashboards as portable templates and imports them
+// back through the underlying services.
+type TemplateService struct {
+	DashboardService           platform.DashboardService
+	ViewService                platform.ViewService
+	MacroService               platform.MacroService
+	LabelService               platform.LabelService
+	UserResourceMappingService platform.UserResourceMappingService
+}
+
+// ExportDashboard returns a template containing the dashboard with the
+// provided ID, the views of each of its cells, the macros referenced by the
+// queries of those views and the dashboard's labels.
+func (s *TemplateService) ExportDashboard(ctx context.Context, id platform.ID) (*platform.DashboardTemplate, error) {
+	d, err := s.DashboardService.FindDashboardByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &platform.DashboardTemplate{
+		Meta:        platform.DashboardTemplateMeta{Version: platform.DashboardTemplateVersion},
+		Name:        d.Name,
+		Description: d.Description,
+		Cells:       make([]*platform.DashboardTemplateCell, 0, len(d.Cells)),
+		Macros:      []*platform.Macro{},
+		Labels:      []platform.DashboardTemplateLabel{},
+	}
+
+	var refs []string
+	for _, c := range d.Cells {
+		v, err := s.ViewService.FindViewByID(ctx, c.ViewID)
+		if err != nil {
+			return nil, err
+		}
+		for _, q := range platform.ViewQueries(v.Properties) {
+			refs = append(refs, platform.MacroReferences(q.Text)...)
+		}
+
+		view := *v
+		view.ID = 0
+		t.Cells = append(t.Cells, &platform.DashboardTemplateCell{
+			X:    c.X,
+			Y:    c.Y,
+			W:    c.W,
+			H:    c.H,
+			View: &view,
+		})
+	}
+
+	macros, err := s.referencedMacros(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	t.Macros = macros
+
+	labels, err := s.LabelService.FindResourceLabels(ctx, platform.LabelMappingFilter{
+		ResourceID:   id,
+		ResourceType: platform.DashboardResourceType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range labels {
+		t.Labels = append(t.Labels, platform.DashboardTemplateLabel{
+			Name:       l.Name,
+			Properties: l.Properties,
+		})
+	}
+	sort.Slice(t.Labels, func(i, j int) bool { return t.Labels[i].Name < t.Labels[j].Name })
+
+	return t, nil
+}
+
+// referencedMacros returns copies of the macros named in refs, including the
+// macros that are in turn referenced by query macros. Names that do not match
+// a stored macro are ignored; they may refer to macros provided by the client.
+func (s *TemplateService) referencedMacros(ctx context.Context, refs []string) ([]*platform.Macro, error) {
+	ms, err := s.MacroService.FindMacros(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*platform.Macro, len(ms))
+	for _, m := range ms {
+		byName[m.Name] = m
+	}
+
+	macros := []*platform.Macro{}
+	seen := map[string]bool{}
+	for len(refs) > 0 {
+		name := refs[0]
+		refs = refs[1:]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		m, ok := byName[name]
+		if !ok {
+			continue
+		}
+
+		if m.Arguments != nil {
+			if q, ok := m.Arguments.Values.(platform.MacroQueryValues); ok {
+				refs = append(refs, platform.MacroReferences(q.Query)...)
+			}
+		}
+
+		macro := *m
+		macro.ID = 0
+		macros = append(macros, &macro)
+	}
+
+	sort.Slice(macros, func(i, j int) bool {
+		return macros[i].Name < macros[j].Name
+	})
+
+	return macros, nil
+}
+
+// ImportDashboard creates the views, macros and dashboard described by t.
+// Every created resource receives a new ID. Macros and labels are referenced
+// by name, so a macro or label of the template is only created when none
+// with the same name exists yet; a macro of the same name but a different
+// definition is a conflict. Labels are looked up in and created for
+// opts.OrganizationID. If the import fails, the resources it created are
+// removed again.
+func (s *TemplateService) ImportDashboard(ctx context.Context, t *platform.DashboardTemplate, opts platform.ImportDashboardOptions) (_ *platform.Dashboard, err error) {
+	if err := t.Valid(); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "dashboards.ImportDashboard",
+			Err:  err,
+		}
+	}
+
+	if len(t.Labels) > 0 && !opts.OrganizationID.Valid() {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "dashboards.ImportDashboard",
+			Msg:  "an organization is required to import a template with labels",
+		}
+	}
+
+	created := &importedResources{}
+	defer func() {
+		if err != nil {
+			s.removeImported(ctx, created)
+		}
+	}()
+
+	if err := s.importMacros(ctx, t.Macros, created); err != nil {
+		return nil, err
+	}
+
+	d := &platform.Dashboard{
+		Name:        t.Name,
+		Description: t.Description,
+	}
+	if err := s.DashboardService.CreateDashboard(ctx, d); err != nil {
+		return nil, err
+	}
+	created.dashboard = d.ID
+
+	for _, c := range t.Cells {
+		view := &platform.View{
+			ViewContents: platform.ViewContents{Name: c.View.Name},
+			Properties:   c.View.Properties,
+		}
+		if err := s.ViewService.CreateView(ctx, view); err != nil {
+			return nil, err
+		}
+		created.views = append(created.views, view.ID)
+
+		cell := &platform.Cell{
+			X:      c.X,
+			Y:      c.Y,
+			W:      c.W,
+			H:      c.H,
+			ViewID: view.ID,
+		}
+		if err := s.DashboardService.AddDashboardCell(ctx, d.ID, cell, platform.AddDashboardCellOptions{}); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, tl := range t.Labels {
+		l, err := s.findOrCreateLabel(ctx, opts.OrganizationID, tl, created)
+		if err != nil {
+			return nil, err
+		}
+
+		m := &platform.LabelMapping{
+			LabelID:      l.ID,
+			ResourceID:   d.ID,
+			ResourceType: platform.DashboardResourceType,
+		}
+		if err := s.LabelService.CreateLabelMapping(ctx, m); err != nil {
+			return nil, err
+		}
+		created.labelMappings = append(created.labelMappings, m)
+	}
+
+	if opts.Owner.Valid() {
+		m := &platform.UserResourceMapping{
+			ResourceID:   d.ID,
+			ResourceType: platform.DashboardResourceType,
+			UserID:       opts.Owner,
+			UserType:     platform.Owner,
+		}
+		if err := s.UserResourceMappingService.CreateUserResourceMapping(ctx, m); err != nil {
+			return nil, err
+		}
+		created.owner = opts.Owner
+	}
+
+	return s.DashboardService.FindDashboardByID(ctx, d.ID)
+}
+
+// importedResources are the resources created by an import so far.
+type importedResources struct {
+	macros        []platform.ID
+	dashboard     platform.ID
+	views         []platform.ID
+	labels        []platform.ID
+	labelMappings []*platform.LabelMapping
+	owner         platform.ID
+}
+
+// removeImported removes the resources created by a failed import. The
+// dashboard is removed before the views, as removing a dashboard may remove
+// the views of its cells. Errors are ignored, so that as much as possible is
+// removed.
+func (s *TemplateService) removeImported(ctx context.Context, r *importedResources) {
+	if r.owner.Valid() {
+		_ = s.UserResourceMappingService.DeleteUserResourceMapping(ctx, r.dashboard, r.owner)
+	}
+	for _, m := range r.labelMappings {
+		_ = s.LabelService.DeleteLabelMapping(ctx, m)
+	}
+	for _, id := range r.labels {
+		_ = s.LabelService.DeleteLabel(ctx, id)
+	}
+	if r.dashboard.Valid() {
+		_ = s.DashboardService.DeleteDashboard(ctx, r.dashboard)
+	}
+	for _, id := range r.views {
+		_ = s.ViewService.DeleteView(ctx, id)
+	}
+	for _, id := range r.macros {
+		_ = s.MacroService.DeleteMacro(ctx, id)
+	}
+}
+
+func (s *TemplateService) findOrCreateLabel(ctx context.Context, orgID platform.ID, tl platform.DashboardTemplateLabel, created *importedResources) (*platform.Label, error) {
+	ls, err := s.LabelService.FindLabels(ctx, platform.LabelFilter{
+		OrganizationID: &orgID,
+		Name:           tl.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(ls) > 0 {
+		return ls[0], nil
+	}
+
+	l := &platform.Label{
+		OrganizationID: orgID,
+		Name:           tl.Name,
+		Properties:     tl.Properties,
+	}
+	if err := s.LabelService.CreateLabel(ctx, l); err != nil {
+		return nil, err
+	}
+	created.labels = append(created.labels, l.ID)
+	return l, nil
+}
+
+// importMacros creates the macros that do not exist yet. It creates none if a
+// macro exists with the same name but different arguments.
+func (s *TemplateService) importMacros(ctx context.Context, macros []*platform.Macro, created *importedResources) error {
+	if len(macros) == 0 {
+		return nil
+	}
+
+	existing, err := s.MacroService.FindMacros(ctx)
+	if err != nil {
+		return err
+	}
+
+	byName := make(map[string]*platform.Macro, len(existing))
+	for _, m := range existing {
+		byName[m.Name] = m
+	}
+
+	var conflicts []string
+	for _, m := range macros {
+		if e, ok := byName[m.Name]; ok && !reflect.DeepEqual(e.Arguments, m.Arguments) {
+			conflicts = append(conflicts, fmt.Sprintf("%q", m.Name))
+		}
+	}
+	if len(conflicts) > 0 {
+		return &platform.Error{
+			Code: platform.EConflict,
+			Op:   "dashboards.ImportDashboard",
+			Msg:  fmt.Sprintf("macros %s already exist with different definitions", strings.Join(conflicts, ", ")),
+		}
+	}
+
+	for _, m := range macros {
+		if _, ok := byName[m.Name]; ok {
+			continue
+		}
+
+		macro := &platform.Macro{
+			Name:      m.Name,
+			Selected:  m.Selected,
+			Arguments: m.Arguments,
+		}
+		if err := s.MacroService.CreateMacro(ctx, macro); err != nil {
+			return fmt.Errorf("failed to create macro %q: %v", m.Name, err)
+		}
+		created.macros = append(created.macros, macro.ID)
+		byName[m.Name] = macro
+	}
+
+	return nil
+}
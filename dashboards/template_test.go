@@ -0,0 +1,301 @@
+package dashboards_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/dashboards"
+	"github.com/influxdata/platform/inmem"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func newTemplateService(s *inmem.Service) *dashboards.TemplateService {
+	return &dashboards.TemplateService{
+		DashboardService:           s,
+		ViewService:                s,
+		MacroService:               s,
+		LabelService:               s,
+		UserResourceMappingService: s,
+	}
+}
+
+func TestTemplateService_ExportImport(t *testing.T) {
+	ctx := context.Background()
+
+	src := inmem.NewService()
+
+	macros := []*platform.Macro{
+		{
+			Name:     "bucket",
+			Selected: []string{"telegraf"},
+			Arguments: &platform.MacroArguments{
+				Type:   "constant",
+				Values: platform.MacroConstantValues{"telegraf", "system"},
+			},
+		},
+		{
+			Name:     "host",
+			Selected: []string{"a"},
+			Arguments: &platform.MacroArguments{
+				Type: "query",
+				Values: platform.MacroQueryValues{
+					Query:    `from(bucket: v.bucket) |> range(start: -1h) |> keys()`,
+					Language: "flux",
+				},
+			},
+		},
+		{
+			Name:     "unused",
+			Selected: []string{"x"},
+			Arguments: &platform.MacroArguments{
+				Type:   "constant",
+				Values: platform.MacroConstantValues{"x"},
+			},
+		},
+	}
+	for _, m := range macros {
+		if err := src.CreateMacro(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	view := &platform.View{
+		ViewContents: platform.ViewContents{Name: "cpu"},
+		Properties: platform.XYViewProperties{
+			Type: "xy",
+			Geom: "line",
+			Queries: []platform.DashboardQuery{
+				{Text: `from(bucket: "telegraf") |> filter(fn: (r) => r.host == v.host)`, Type: "flux"},
+			},
+		},
+	}
+	if err := src.CreateView(ctx, view); err != nil {
+		t.Fatal(err)
+	}
+
+	d := &platform.Dashboard{Name: "hosts", Description: "all the hosts"}
+	if err := src.CreateDashboard(ctx, d); err != nil {
+		t.Fatal(err)
+	}
+	cell := &platform.Cell{X: 1, Y: 2, W: 3, H: 4, ViewID: view.ID}
+	if err := src.AddDashboardCell(ctx, d.ID, cell, platform.AddDashboardCellOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	orgID := platformtesting.MustIDBase16("020f755c3c082000")
+	l := &platform.Label{OrganizationID: orgID, Name: "prod", Properties: map[string]string{"color": "ffb3b3"}}
+	if err := src.CreateLabel(ctx, l); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.CreateLabelMapping(ctx, &platform.LabelMapping{
+		LabelID:      l.ID,
+		ResourceID:   d.ID,
+		ResourceType: platform.DashboardResourceType,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := newTemplateService(src).ExportDashboard(ctx, d.ID)
+	if err != nil {
+		t.Fatalf("unexpected error exporting dashboard: %v", err)
+	}
+
+	if got, want := len(tmpl.Macros), 2; got != want {
+		t.Fatalf("got %d macros, want %d", got, want)
+	}
+	if tmpl.Macros[0].Name != "bucket" || tmpl.Macros[1].Name != "host" {
+		t.Fatalf("unexpected macros exported: %q, %q", tmpl.Macros[0].Name, tmpl.Macros[1].Name)
+	}
+
+	dst := inmem.NewService()
+	owner := platformtesting.MustIDBase16("0c0c0c0c0c0c0c0c")
+
+	imported, err := newTemplateService(dst).ImportDashboard(ctx, tmpl, platform.ImportDashboardOptions{Owner: owner, OrganizationID: orgID})
+	if err != nil {
+		t.Fatalf("unexpected error importing dashboard: %v", err)
+	}
+
+	if imported.Name != d.Name || imported.Description != d.Description {
+		t.Errorf("unexpected imported dashboard %+v", imported)
+	}
+	if len(imported.Cells) != 1 {
+		t.Fatalf("got %d cells, want 1", len(imported.Cells))
+	}
+
+	v, err := dst.FindViewByID(ctx, imported.Cells[0].ViewID)
+	if err != nil {
+		t.Fatalf("imported view not found: %v", err)
+	}
+	if diff := cmp.Diff(view.Properties, v.Properties); diff != "" {
+		t.Errorf("imported view properties differ -want/+got:\n%s", diff)
+	}
+
+	ms, err := dst.FindMacros(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ms) != 2 {
+		t.Errorf("got %d macros after import, want 2", len(ms))
+	}
+
+	ls, err := dst.FindResourceLabels(ctx, platform.LabelMappingFilter{
+		ResourceID:   imported.ID,
+		ResourceType: platform.DashboardResourceType,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ls) != 1 || ls[0].Name != "prod" {
+		t.Errorf("unexpected labels after import: %v", ls)
+	} else if diff := cmp.Diff(l.Properties, ls[0].Properties); diff != "" {
+		t.Errorf("imported label properties differ -want/+got:\n%s", diff)
+	}
+
+	urms, _, err := dst.FindUserResourceMappings(ctx, platform.UserResourceMappingFilter{ResourceID: imported.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(urms) != 1 || urms[0].UserID != owner || urms[0].UserType != platform.Owner {
+		t.Errorf("unexpected user resource mappings after import: %v", urms)
+	}
+
+	// Importing the same template again reuses the macros and labels that now exist.
+	if _, err := newTemplateService(dst).ImportDashboard(ctx, tmpl, platform.ImportDashboardOptions{OrganizationID: orgID}); err != nil {
+		t.Fatalf("unexpected error importing dashboard twice: %v", err)
+	}
+	ms, err = dst.FindMacros(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ms) != 2 {
+		t.Errorf("got %d macros after second import, want 2", len(ms))
+	}
+	ls, err = dst.FindLabels(ctx, platform.LabelFilter{OrganizationID: &orgID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ls) != 1 {
+		t.Errorf("got %d labels after second import, want 1", len(ls))
+	}
+}
+
+func TestTemplateService_ImportLabelsRequireOrganization(t *testing.T) {
+	s := newTemplateService(inmem.NewService())
+	tmpl := &platform.DashboardTemplate{
+		Meta:   platform.DashboardTemplateMeta{Version: platform.DashboardTemplateVersion},
+		Name:   "x",
+		Labels: []platform.DashboardTemplateLabel{{Name: "prod"}},
+	}
+	_, err := s.ImportDashboard(context.Background(), tmpl, platform.ImportDashboardOptions{})
+	if platform.ErrorCode(err) != platform.EInvalid {
+		t.Fatalf("expected invalid error, got %v", err)
+	}
+}
+
+func TestTemplateService_ImportInvalid(t *testing.T) {
+	s := newTemplateService(inmem.NewService())
+	_, err := s.ImportDashboard(context.Background(), &platform.DashboardTemplate{Name: "x"}, platform.ImportDashboardOptions{})
+	if platform.ErrorCode(err) != platform.EInvalid {
+		t.Fatalf("expected invalid error, got %v", err)
+	}
+}
+
+// failingOwnerService fails to record the owner of a resource.
+type failingOwnerService struct {
+	platform.UserResourceMappingService
+}
+
+func (s failingOwnerService) CreateUserResourceMapping(context.Context, *platform.UserResourceMapping) error {
+	return &platform.Error{Code: platform.EInternal, Msg: "owner store unavailable"}
+}
+
+func TestTemplateService_ImportRollback(t *testing.T) {
+	ctx := context.Background()
+	orgID := platformtesting.MustIDBase16("020f755c3c082000")
+	tmpl := &platform.DashboardTemplate{
+		Meta: platform.DashboardTemplateMeta{Version: platform.DashboardTemplateVersion},
+		Name: "hosts",
+		Cells: []*platform.DashboardTemplateCell{
+			{W: 1, H: 1, View: &platform.View{
+				ViewContents: platform.ViewContents{Name: "cpu"},
+				Properties:   platform.EmptyViewProperties{},
+			}},
+		},
+		Macros: []*platform.Macro{
+			{
+				Name:      "bucket",
+				Selected:  []string{"telegraf"},
+				Arguments: &platform.MacroArguments{Type: "constant", Values: platform.MacroConstantValues{"telegraf"}},
+			},
+		},
+		Labels: []platform.DashboardTemplateLabel{{Name: "prod"}},
+	}
+
+	dst := inmem.NewService()
+	s := newTemplateService(dst)
+	s.UserResourceMappingService = failingOwnerService{dst}
+
+	_, err := s.ImportDashboard(ctx, tmpl, platform.ImportDashboardOptions{
+		Owner:          platformtesting.MustIDBase16("0c0c0c0c0c0c0c0c"),
+		OrganizationID: orgID,
+	})
+	if platform.ErrorCode(err) != platform.EInternal {
+		t.Fatalf("expected internal error, got %v", err)
+	}
+
+	if ds, _, err := dst.FindDashboards(ctx, platform.DashboardFilter{}, platform.FindOptions{}); err != nil {
+		t.Fatal(err)
+	} else if len(ds) != 0 {
+		t.Errorf("got %d dashboards after failed import, want 0", len(ds))
+	}
+	if vs, _, err := dst.FindViews(ctx, platform.ViewFilter{}); err != nil {
+		t.Fatal(err)
+	} else if len(vs) != 0 {
+		t.Errorf("got %d views after failed import, want 0", len(vs))
+	}
+	if ms, err := dst.FindMacros(ctx); err != nil {
+		t.Fatal(err)
+	} else if len(ms) != 0 {
+		t.Errorf("got %d macros after failed import, want 0", len(ms))
+	}
+	if ls, err := dst.FindLabels(ctx, platform.LabelFilter{OrganizationID: &orgID}); err != nil {
+		t.Fatal(err)
+	} else if len(ls) != 0 {
+		t.Errorf("got %d labels after failed import, want 0", len(ls))
+	}
+}
+
+func TestTemplateService_ImportMacroConflict(t *testing.T) {
+	ctx := context.Background()
+	dst := inmem.NewService()
+	if err := dst.CreateMacro(ctx, &platform.Macro{
+		Name:      "bucket",
+		Selected:  []string{"system"},
+		Arguments: &platform.MacroArguments{Type: "constant", Values: platform.MacroConstantValues{"system"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &platform.DashboardTemplate{
+		Meta: platform.DashboardTemplateMeta{Version: platform.DashboardTemplateVersion},
+		Name: "hosts",
+		Macros: []*platform.Macro{
+			{
+				Name:      "bucket",
+				Selected:  []string{"telegraf"},
+				Arguments: &platform.MacroArguments{Type: "constant", Values: platform.MacroConstantValues{"telegraf"}},
+			},
+		},
+	}
+	_, err := newTemplateService(dst).ImportDashboard(ctx, tmpl, platform.ImportDashboardOptions{})
+	if platform.ErrorCode(err) != platform.EConflict {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+
+	if ds, _, err := dst.FindDashboards(ctx, platform.DashboardFilter{}, platform.FindOptions{}); err != nil {
+		t.Fatal(err)
+	} else if len(ds) != 0 {
+		t.Errorf("got %d dashboards after conflicting import, want 0", len(ds))
+	}
+}
@@ -36,8 +36,13 @@ func (h *handler) Process(s nats.Subscription, m nats.Message) {
 	}
 
 	// send metrics to storage queue
+	mc := MetricsCollection{
+		OrgName:    req.OrgName,
+		BucketName: req.BucketName,
+		Metrics:    ms,
+	}
 	buf := new(bytes.Buffer)
-	if err := json.NewEncoder(buf).Encode(ms); err != nil {
+	if err := json.NewEncoder(buf).Encode(mc); err != nil {
 		h.Logger.Error("unable to marshal json", zap.Error(err))
 		return
 	}
@@ -13,6 +13,14 @@ type Metrics struct {
 	Type      MetricType             `json:"type"`
 }
 
+// MetricsCollection is the metrics gathered from a scraper target, along with
+// the names of the organization and bucket they are stored in.
+type MetricsCollection struct {
+	OrgName    string    `json:"org"`
+	BucketName string    `json:"bucket"`
+	Metrics    []Metrics `json:"metrics"`
+}
+
 // MetricType is prometheus metrics type.
 type MetricType int
 
@@ -191,10 +191,10 @@ type mockStorage struct {
 	Targets         []platform.ScraperTarget
 }
 
-func (s *mockStorage) Record(ms []Metrics) error {
+func (s *mockStorage) Record(mc MetricsCollection) error {
 	s.Lock()
 	defer s.Unlock()
-	for _, m := range ms {
+	for _, m := range mc.Metrics {
 		s.Metrics[m.Timestamp] = m
 	}
 	s.TotalGatherJobs <- struct{}{}
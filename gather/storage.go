@@ -1,17 +1,23 @@
 package gather
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
 	"github.com/influxdata/platform/nats"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb"
 	"go.uber.org/zap"
 )
 
 // Storage stores the metrics of a time based.
 type Storage interface {
 	//Subscriber nats.Subscriber
-	Record([]Metrics) error
+	Record(MetricsCollection) error
 }
 
 // StorageHandler implements nats.Handler interface.
@@ -23,14 +29,59 @@ type StorageHandler struct {
 // Process consumes job queue, and use storage to record.
 func (h *StorageHandler) Process(s nats.Subscription, m nats.Message) {
 	defer m.Ack()
-	ms := make([]Metrics, 0)
-	err := json.Unmarshal(m.Data(), &ms)
+	var mc MetricsCollection
+	err := json.Unmarshal(m.Data(), &mc)
 	if err != nil {
 		h.Logger.Error(fmt.Sprintf("storage handler process err: %v", err))
 		return
 	}
-	err = h.Storage.Record(ms)
+	err = h.Storage.Record(mc)
 	if err != nil {
 		h.Logger.Error(fmt.Sprintf("storage handler store err: %v", err))
 	}
 }
+
+// lookupTimeout is the maximum time allowed to look up the organization and
+// bucket of a collection of metrics.
+const lookupTimeout = 10 * time.Second
+
+// PointsWriterStorage is a Storage writing metrics as points to the bucket
+// named by their collection.
+type PointsWriterStorage struct {
+	OrganizationService platform.OrganizationService
+	BucketService       platform.BucketService
+	PointsWriter        storage.PointsWriter
+}
+
+// Record writes the metrics of mc to the bucket of mc.
+func (s *PointsWriterStorage) Record(mc MetricsCollection) error {
+	if len(mc.Metrics) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+	defer cancel()
+	org, err := s.OrganizationService.FindOrganization(ctx, platform.OrganizationFilter{Name: &mc.OrgName})
+	if err != nil {
+		return err
+	}
+	bucket, err := s.BucketService.FindBucket(ctx, platform.BucketFilter{OrganizationID: &org.ID, Name: &mc.BucketName})
+	if err != nil {
+		return err
+	}
+
+	points := make([]models.Point, 0, len(mc.Metrics))
+	for _, m := range mc.Metrics {
+		pt, err := models.NewPoint(m.Name, models.NewTags(m.Tags), m.Fields, time.Unix(0, m.Timestamp))
+		if err != nil {
+			return err
+		}
+		points = append(points, pt)
+	}
+
+	exploded, err := tsdb.ExplodePoints(org.ID, bucket.ID, points)
+	if err != nil {
+		return err
+	}
+	return s.PointsWriter.WritePoints(exploded)
+}
@@ -0,0 +1,96 @@
+package gather
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/inmem"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/storage/writequeue"
+	"github.com/influxdata/platform/tsdb"
+	"go.uber.org/zap"
+)
+
+func TestPointsWriterStorage_WriteQueue(t *testing.T) {
+	ctx := context.Background()
+	svc := inmem.NewService()
+	org := &platform.Organization{Name: "org"}
+	if err := svc.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+	bucket := &platform.Bucket{OrganizationID: org.ID, Name: "bucket"}
+	if err := svc.CreateBucket(ctx, bucket); err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "gather-writequeue-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	engine := &mock.PointsWriter{}
+	queue := writequeue.NewWriter(dir, engine)
+	if err := queue.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer queue.Close()
+
+	ts := httptest.NewServer(&mockHTTPHandler{
+		responseMap: map[string]string{
+			"/metrics": sampleRespSmall,
+		},
+	})
+	defer ts.Close()
+
+	publisher, subscriber := mock.NewNats()
+	logger := zap.NewNop()
+	if err := subscriber.Subscribe(MetricsSubject, "", &StorageHandler{
+		Logger: logger,
+		Storage: &PointsWriterStorage{
+			OrganizationService: svc,
+			BucketService:       svc,
+			PointsWriter:        queue,
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewScheduler(1, logger, svc, publisher, subscriber, time.Minute, time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := requestScrape(platform.ScraperTarget{
+		Type:       platform.PrometheusScraperType,
+		URL:        ts.URL + "/metrics",
+		OrgName:    org.Name,
+		BucketName: bucket.Name,
+	}, publisher); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	pt := engine.Next()
+	for pt == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("scraped metrics were not written through the write queue")
+		}
+		time.Sleep(10 * time.Millisecond)
+		pt = engine.Next()
+	}
+
+	var name [16]byte
+	copy(name[:], pt.Name())
+	if orgID, bucketID := tsdb.DecodeName(name); orgID != org.ID || bucketID != bucket.ID {
+		t.Errorf("point written to org %s bucket %s, expected org %s bucket %s", orgID, bucketID, org.ID, bucket.ID)
+	}
+	if got := string(pt.Tags().Get(tsdb.MeasurementTagKeyBytes)); got != "go_goroutines" {
+		t.Errorf("got measurement %q, expected go_goroutines", got)
+	}
+	if got := string(pt.Tags().Get(tsdb.FieldKeyTagKeyBytes)); got != "gauge" {
+		t.Errorf("got field %q, expected gauge", got)
+	}
+}
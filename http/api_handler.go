@@ -13,22 +13,28 @@ import (
 
 // APIHandler is a collection of all the service handlers.
 type APIHandler struct {
-	BucketHandler        *BucketHandler
-	UserHandler          *UserHandler
-	OrgHandler           *OrgHandler
-	AuthorizationHandler *AuthorizationHandler
-	DashboardHandler     *DashboardHandler
-	AssetHandler         *AssetHandler
-	ChronografHandler    *ChronografHandler
-	ViewHandler          *ViewHandler
-	SourceHandler        *SourceHandler
-	MacroHandler         *MacroHandler
-	TaskHandler          *TaskHandler
-	TelegrafHandler      *TelegrafHandler
-	QueryHandler         *FluxHandler
-	WriteHandler         *WriteHandler
-	SetupHandler         *SetupHandler
-	SessionHandler       *SessionHandler
+	BucketHandler               *BucketHandler
+	UserHandler                 *UserHandler
+	OrgHandler                  *OrgHandler
+	AuthorizationHandler        *AuthorizationHandler
+	DashboardHandler            *DashboardHandler
+	DashboardTemplateHandler    *DashboardTemplateHandler
+	AssetHandler                *AssetHandler
+	ChronografHandler           *ChronografHandler
+	ViewHandler                 *ViewHandler
+	LabelHandler                *LabelHandler
+	SourceHandler               *SourceHandler
+	MacroHandler                *MacroHandler
+	CheckHandler                *CheckHandler
+	TaskTemplateHandler         *TaskTemplateHandler
+	NotificationEndpointHandler *NotificationEndpointHandler
+	ReplicationRuleHandler      *ReplicationRuleHandler
+	TaskHandler                 *TaskHandler
+	TelegrafHandler             *TelegrafHandler
+	QueryHandler                *FluxHandler
+	WriteHandler                *WriteHandler
+	SetupHandler                *SetupHandler
+	SessionHandler              *SessionHandler
 }
 
 // APIBackend is all services and associated parameters required to construct
@@ -49,17 +55,30 @@ type APIBackend struct {
 	LabelService                    platform.LabelService
 	DashboardService                platform.DashboardService
 	DashboardOperationLogService    platform.DashboardOperationLogService
+	DashboardTemplateService        platform.DashboardTemplateService
+	DashboardTemplates              []*platform.DashboardTemplate
 	BucketOperationLogService       platform.BucketOperationLogService
+	BucketSchemaService             platform.BucketSchemaService
 	UserOperationLogService         platform.UserOperationLogService
 	OrganizationOperationLogService platform.OrganizationOperationLogService
 	ViewService                     platform.ViewService
 	SourceService                   platform.SourceService
 	MacroService                    platform.MacroService
+	MacroEvaluationService          platform.MacroEvaluationService
 	BasicAuthService                platform.BasicAuthService
 	OnboardingService               platform.OnboardingService
 	ProxyQueryService               query.ProxyQueryService
 	TaskService                     platform.TaskService
+	RunEventService                 platform.RunEventService
+	TaskValidationService           platform.TaskValidationService
+	CheckService                    platform.CheckService
+	TaskTemplateService             platform.TaskTemplateService
+	TaskTemplateInstanceService     platform.TaskTemplateInstanceService
+	NotificationEndpointService     platform.NotificationEndpointService
+	ReplicationRuleService          platform.ReplicationRuleService
 	TelegrafService                 platform.TelegrafConfigStore
+	TelegrafAgentService            platform.TelegrafAgentService
+	TelegrafAgentAuthService        platform.TelegrafAgentAuthorizationService
 	ScraperTargetStoreService       platform.ScraperTargetStoreService
 	ChronografService               *server.Service
 }
@@ -75,6 +94,7 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 	h.BucketHandler = NewBucketHandler(b.UserResourceMappingService, b.LabelService)
 	h.BucketHandler.BucketService = b.BucketService
 	h.BucketHandler.BucketOperationLogService = b.BucketOperationLogService
+	h.BucketHandler.BucketSchemaService = b.BucketSchemaService
 
 	h.OrgHandler = NewOrgHandler(b.UserResourceMappingService, b.LabelService)
 	h.OrgHandler.OrganizationService = b.OrganizationService
@@ -89,12 +109,22 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 	h.DashboardHandler = NewDashboardHandler(b.UserResourceMappingService, b.LabelService)
 	h.DashboardHandler.DashboardService = b.DashboardService
 	h.DashboardHandler.DashboardOperationLogService = b.DashboardOperationLogService
+	h.DashboardHandler.DashboardTemplateService = b.DashboardTemplateService
+
+	h.DashboardTemplateHandler = NewDashboardTemplateHandler()
+	h.DashboardTemplateHandler.DashboardTemplateService = b.DashboardTemplateService
+	h.DashboardTemplateHandler.Templates = b.DashboardTemplates
 
 	h.ViewHandler = NewViewHandler(b.UserResourceMappingService, b.LabelService)
 	h.ViewHandler.ViewService = b.ViewService
 
+	h.LabelHandler = NewLabelHandler()
+	h.LabelHandler.LabelService = b.LabelService
+
 	h.MacroHandler = NewMacroHandler()
 	h.MacroHandler.MacroService = b.MacroService
+	h.MacroHandler.MacroEvaluationService = b.MacroEvaluationService
+	h.MacroHandler.OrganizationService = b.OrganizationService
 
 	h.AuthorizationHandler = NewAuthorizationHandler()
 	h.AuthorizationHandler.AuthorizationService = b.AuthorizationService
@@ -110,15 +140,32 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 
 	h.TaskHandler = NewTaskHandler(b.UserResourceMappingService, b.LabelService, b.Logger)
 	h.TaskHandler.TaskService = b.TaskService
+	h.TaskHandler.RunEventService = b.RunEventService
+	h.TaskHandler.TaskValidationService = b.TaskValidationService
 	h.TaskHandler.AuthorizationService = b.AuthorizationService
 	h.TaskHandler.UserResourceMappingService = b.UserResourceMappingService
 
+	h.CheckHandler = NewCheckHandler()
+	h.CheckHandler.CheckService = b.CheckService
+
+	h.TaskTemplateHandler = NewTaskTemplateHandler()
+	h.TaskTemplateHandler.TaskTemplateService = b.TaskTemplateService
+	h.TaskTemplateHandler.TaskTemplateInstanceService = b.TaskTemplateInstanceService
+
+	h.NotificationEndpointHandler = NewNotificationEndpointHandler()
+	h.NotificationEndpointHandler.NotificationEndpointService = b.NotificationEndpointService
+
+	h.ReplicationRuleHandler = NewReplicationRuleHandler()
+	h.ReplicationRuleHandler.ReplicationRuleService = b.ReplicationRuleService
+
 	h.TelegrafHandler = NewTelegrafHandler(
 		b.Logger.With(zap.String("handler", "telegraf")),
 		b.UserResourceMappingService,
 		b.LabelService,
 		b.TelegrafService,
 	)
+	h.TelegrafHandler.TelegrafAgentService = b.TelegrafAgentService
+	h.TelegrafHandler.TelegrafAgentAuthorizationService = b.TelegrafAgentAuthService
 
 	h.WriteHandler = NewWriteHandler(b.PointsWriter)
 	h.WriteHandler.OrganizationService = b.OrganizationService
@@ -129,6 +176,7 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 	h.QueryHandler.OrganizationService = b.OrganizationService
 	h.QueryHandler.Logger = b.Logger.With(zap.String("handler", "query"))
 	h.QueryHandler.ProxyQueryService = b.ProxyQueryService
+	h.QueryHandler.MacroEvaluationService = b.MacroEvaluationService
 
 	h.ChronografHandler = NewChronografHandler(b.ChronografService)
 
@@ -136,21 +184,27 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 }
 
 var apiLinks = map[string]interface{}{
-	"signin":         "/api/v2/signin",
-	"signout":        "/api/v2/signout",
-	"setup":          "/api/v2/setup",
-	"sources":        "/api/v2/sources",
-	"dashboards":     "/api/v2/dashboards",
-	"views":          "/api/v2/views",
-	"write":          "/api/v2/write",
-	"orgs":           "/api/v2/orgs",
-	"authorizations": "/api/v2/authorizations",
-	"buckets":        "/api/v2/buckets",
-	"users":          "/api/v2/users",
-	"me":             "/api/v2/me",
-	"tasks":          "/api/v2/tasks",
-	"macros":         "/api/v2/macros",
-	"telegrafs":      "/api/v2/telegrafs",
+	"signin":                "/api/v2/signin",
+	"signout":               "/api/v2/signout",
+	"setup":                 "/api/v2/setup",
+	"sources":               "/api/v2/sources",
+	"dashboards":            "/api/v2/dashboards",
+	"dashboardTemplates":    "/api/v2/dashboardtemplates",
+	"views":                 "/api/v2/views",
+	"labels":                "/api/v2/labels",
+	"write":                 "/api/v2/write",
+	"orgs":                  "/api/v2/orgs",
+	"authorizations":        "/api/v2/authorizations",
+	"buckets":               "/api/v2/buckets",
+	"users":                 "/api/v2/users",
+	"me":                    "/api/v2/me",
+	"tasks":                 "/api/v2/tasks",
+	"macros":                "/api/v2/macros",
+	"checks":                "/api/v2/checks",
+	"taskTemplates":         "/api/v2/taskTemplates",
+	"notificationEndpoints": "/api/v2/notificationEndpoints",
+	"replications":          "/api/v2/replications",
+	"telegrafs":             "/api/v2/telegrafs",
 	"query": map[string]string{
 		"self":        "/api/v2/query",
 		"ast":         "/api/v2/query/ast",
@@ -239,6 +293,11 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/dashboardtemplates") {
+		h.DashboardTemplateHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/sources") {
 		h.SourceHandler.ServeHTTP(w, r)
 		return
@@ -259,11 +318,36 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/labels") {
+		h.LabelHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/macros") {
 		h.MacroHandler.ServeHTTP(w, r)
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/checks") {
+		h.CheckHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/taskTemplates") {
+		h.TaskTemplateHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/notificationEndpoints") {
+		h.NotificationEndpointHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/replications") {
+		h.ReplicationRuleHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/chronograf/") {
 		h.ChronografHandler.ServeHTTP(w, r)
 		return
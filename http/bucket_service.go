@@ -21,20 +21,22 @@ type BucketHandler struct {
 
 	BucketService              platform.BucketService
 	BucketOperationLogService  platform.BucketOperationLogService
+	BucketSchemaService        platform.BucketSchemaService
 	UserResourceMappingService platform.UserResourceMappingService
 	LabelService               platform.LabelService
 }
 
 const (
-	bucketsPath             = "/api/v2/buckets"
-	bucketsIDPath           = "/api/v2/buckets/:id"
-	bucketsIDLogPath        = "/api/v2/buckets/:id/log"
-	bucketsIDMembersPath    = "/api/v2/buckets/:id/members"
-	bucketsIDMembersIDPath  = "/api/v2/buckets/:id/members/:userID"
-	bucketsIDOwnersPath     = "/api/v2/buckets/:id/owners"
-	bucketsIDOwnersIDPath   = "/api/v2/buckets/:id/owners/:userID"
-	bucketsIDLabelsPath     = "/api/v2/buckets/:id/labels"
-	bucketsIDLabelsNamePath = "/api/v2/buckets/:id/labels/:name"
+	bucketsPath            = "/api/v2/buckets"
+	bucketsIDPath          = "/api/v2/buckets/:id"
+	bucketsIDLogPath       = "/api/v2/buckets/:id/log"
+	bucketsIDSchemaPath    = "/api/v2/buckets/:id/schema"
+	bucketsIDMembersPath   = "/api/v2/buckets/:id/members"
+	bucketsIDMembersIDPath = "/api/v2/buckets/:id/members/:userID"
+	bucketsIDOwnersPath    = "/api/v2/buckets/:id/owners"
+	bucketsIDOwnersIDPath  = "/api/v2/buckets/:id/owners/:userID"
+	bucketsIDLabelsPath    = "/api/v2/buckets/:id/labels"
+	bucketsIDLabelsIDPath  = "/api/v2/buckets/:id/labels/:lid"
 )
 
 // NewBucketHandler returns a new instance of BucketHandler.
@@ -51,6 +53,9 @@ func NewBucketHandler(mappingService platform.UserResourceMappingService, labelS
 	h.HandlerFunc("GET", bucketsIDLogPath, h.handleGetBucketLog)
 	h.HandlerFunc("PATCH", bucketsIDPath, h.handlePatchBucket)
 	h.HandlerFunc("DELETE", bucketsIDPath, h.handleDeleteBucket)
+	h.HandlerFunc("GET", bucketsIDSchemaPath, h.handleGetBucketSchema)
+	h.HandlerFunc("PUT", bucketsIDSchemaPath, h.handlePutBucketSchema)
+	h.HandlerFunc("DELETE", bucketsIDSchemaPath, h.handleDeleteBucketSchema)
 
 	h.HandlerFunc("POST", bucketsIDMembersPath, newPostMemberHandler(h.UserResourceMappingService, platform.BucketResourceType, platform.Member))
 	h.HandlerFunc("GET", bucketsIDMembersPath, newGetMembersHandler(h.UserResourceMappingService, platform.Member))
@@ -60,9 +65,9 @@ func NewBucketHandler(mappingService platform.UserResourceMappingService, labelS
 	h.HandlerFunc("GET", bucketsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, platform.Owner))
 	h.HandlerFunc("DELETE", bucketsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
 
-	h.HandlerFunc("GET", bucketsIDLabelsPath, newGetLabelsHandler(h.LabelService))
-	h.HandlerFunc("POST", bucketsIDLabelsPath, newPostLabelHandler(h.LabelService))
-	h.HandlerFunc("DELETE", bucketsIDLabelsNamePath, newDeleteLabelHandler(h.LabelService))
+	h.HandlerFunc("GET", bucketsIDLabelsPath, newGetLabelsHandler(h.LabelService, platform.BucketResourceType))
+	h.HandlerFunc("POST", bucketsIDLabelsPath, newPostLabelHandler(h.LabelService, platform.BucketResourceType))
+	h.HandlerFunc("DELETE", bucketsIDLabelsIDPath, newDeleteLabelHandler(h.LabelService, platform.BucketResourceType))
 
 	return h
 }
@@ -75,6 +80,9 @@ type bucket struct {
 	Name                string          `json:"name"`
 	RetentionPolicyName string          `json:"rp,omitempty"` // This to support v1 sources
 	RetentionRules      []retentionRule `json:"retentionRules"`
+	// ShardGroupDurationSeconds is the span of time of the data stored and
+	// expired together. Zero selects the default for the retention rules.
+	ShardGroupDurationSeconds int64 `json:"shardGroupDurationSeconds,omitempty"`
 }
 
 // retentionRule is the retention rule action for a bucket.
@@ -98,6 +106,11 @@ func (b *bucket) toPlatform() (*platform.Bucket, error) {
 		}
 	}
 
+	sgd, err := shardGroupDuration(b.ShardGroupDurationSeconds)
+	if err != nil {
+		return nil, err
+	}
+
 	return &platform.Bucket{
 		ID:                  b.ID,
 		OrganizationID:      b.OrganizationID,
@@ -105,9 +118,19 @@ func (b *bucket) toPlatform() (*platform.Bucket, error) {
 		Name:                b.Name,
 		RetentionPolicyName: b.RetentionPolicyName,
 		RetentionPeriod:     d,
+		ShardGroupDuration:  sgd,
 	}, nil
 }
 
+// shardGroupDuration returns the shard group duration of seconds, which must
+// not be negative.
+func shardGroupDuration(seconds int64) (time.Duration, error) {
+	if seconds < 0 {
+		return 0, errors.InvalidDataf("shard group duration seconds must be greater than or equal to zero")
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
 func newBucket(pb *platform.Bucket) *bucket {
 	if pb == nil {
 		return nil
@@ -123,19 +146,21 @@ func newBucket(pb *platform.Bucket) *bucket {
 	}
 
 	return &bucket{
-		ID:                  pb.ID,
-		OrganizationID:      pb.OrganizationID,
-		Organization:        pb.Organization,
-		Name:                pb.Name,
-		RetentionPolicyName: pb.RetentionPolicyName,
-		RetentionRules:      rules,
+		ID:                        pb.ID,
+		OrganizationID:            pb.OrganizationID,
+		Organization:              pb.Organization,
+		Name:                      pb.Name,
+		RetentionPolicyName:       pb.RetentionPolicyName,
+		RetentionRules:            rules,
+		ShardGroupDurationSeconds: int64(pb.ShardGroupDuration.Round(time.Second) / time.Second),
 	}
 }
 
 // bucketUpdate is used for serialization/deserialization with retention rules.
 type bucketUpdate struct {
-	Name           *string         `json:"name,omitempty"`
-	RetentionRules []retentionRule `json:"retentionRules,omitempty"`
+	Name                      *string         `json:"name,omitempty"`
+	RetentionRules            []retentionRule `json:"retentionRules,omitempty"`
+	ShardGroupDurationSeconds *int64          `json:"shardGroupDurationSeconds,omitempty"`
 }
 
 func (b *bucketUpdate) toPlatform() (*platform.BucketUpdate, error) {
@@ -152,10 +177,19 @@ func (b *bucketUpdate) toPlatform() (*platform.BucketUpdate, error) {
 		}
 	}
 
-	return &platform.BucketUpdate{
+	upd := &platform.BucketUpdate{
 		Name:            b.Name,
 		RetentionPeriod: &d,
-	}, nil
+	}
+
+	if b.ShardGroupDurationSeconds != nil {
+		sgd, err := shardGroupDuration(*b.ShardGroupDurationSeconds)
+		if err != nil {
+			return nil, err
+		}
+		upd.ShardGroupDuration = &sgd
+	}
+	return upd, nil
 }
 
 func newBucketUpdate(pb *platform.BucketUpdate) *bucketUpdate {
@@ -175,6 +209,11 @@ func newBucketUpdate(pb *platform.BucketUpdate) *bucketUpdate {
 			EverySeconds: d,
 		})
 	}
+
+	if pb.ShardGroupDuration != nil {
+		sgd := int64((*pb.ShardGroupDuration).Round(time.Second) / time.Second)
+		up.ShardGroupDurationSeconds = &sgd
+	}
 	return up
 }
 
@@ -402,6 +441,8 @@ func decodeGetBucketsRequest(ctx context.Context, r *http.Request) (*getBucketsR
 		req.filter.Name = &name
 	}
 
+	req.filter.Label = labelFilterParam(r)
+
 	return req, nil
 }
 
@@ -545,6 +586,9 @@ func (s *BucketService) FindBuckets(ctx context.Context, filter platform.BucketF
 	if filter.Name != nil {
 		query.Add("name", *filter.Name)
 	}
+	if filter.Label != nil {
+		query.Add("label", *filter.Label)
+	}
 
 	req, err := http.NewRequest("GET", u.String(), nil)
 	if err != nil {
@@ -771,3 +815,117 @@ func newBucketLogResponse(id platform.ID, es []*platform.OperationLogEntry) *ope
 		Log: log,
 	}
 }
+
+type bucketSchemaResponse struct {
+	Links map[string]string `json:"links"`
+	*platform.BucketSchema
+}
+
+func newBucketSchemaResponse(id platform.ID, s *platform.BucketSchema) *bucketSchemaResponse {
+	return &bucketSchemaResponse{
+		Links: map[string]string{
+			"self":   fmt.Sprintf("/api/v2/buckets/%s/schema", id),
+			"bucket": fmt.Sprintf("/api/v2/buckets/%s", id),
+		},
+		BucketSchema: s,
+	}
+}
+
+// handleGetBucketSchema is the HTTP handler for the GET /api/v2/buckets/:id/schema route.
+func (h *BucketHandler) handleGetBucketSchema(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetBucketRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	s, err := h.BucketSchemaService.FindBucketSchema(ctx, req.BucketID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	if s == nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  "bucket has no schema",
+			Op:   platform.OpFindBucketSchema,
+		}, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newBucketSchemaResponse(req.BucketID, s)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+type putBucketSchemaRequest struct {
+	BucketID platform.ID
+	Schema   *platform.BucketSchema
+}
+
+func decodePutBucketSchemaRequest(ctx context.Context, r *http.Request) (*putBucketSchemaRequest, error) {
+	req, err := decodeGetBucketRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &platform.BucketSchema{}
+	if err := json.NewDecoder(r.Body).Decode(s); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid bucket schema",
+			Op:   platform.OpSetBucketSchema,
+			Err:  err,
+		}
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &putBucketSchemaRequest{
+		BucketID: req.BucketID,
+		Schema:   s,
+	}, nil
+}
+
+// handlePutBucketSchema is the HTTP handler for the PUT /api/v2/buckets/:id/schema route.
+func (h *BucketHandler) handlePutBucketSchema(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePutBucketSchemaRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.BucketSchemaService.SetBucketSchema(ctx, req.BucketID, req.Schema); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newBucketSchemaResponse(req.BucketID, req.Schema)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handleDeleteBucketSchema is the HTTP handler for the DELETE /api/v2/buckets/:id/schema route.
+func (h *BucketHandler) handleDeleteBucketSchema(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetBucketRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.BucketSchemaService.SetBucketSchema(ctx, req.BucketID, nil); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
 
@@ -474,9 +475,10 @@ func TestService_handlePatchBucket(t *testing.T) {
 		BucketService platform.BucketService
 	}
 	type args struct {
-		id        string
-		name      string
-		retention time.Duration
+		id                 string
+		name               string
+		retention          time.Duration
+		shardGroupDuration time.Duration
 	}
 	type wants struct {
 		statusCode  int
@@ -537,6 +539,53 @@ func TestService_handlePatchBucket(t *testing.T) {
   "name": "example",
   "retentionRules": [{"type": "expire", "everySeconds": 2}]
 }
+`,
+			},
+		},
+		{
+			name: "update a bucket shard group duration",
+			fields: fields{
+				&mock.BucketService{
+					UpdateBucketFn: func(ctx context.Context, id platform.ID, upd platform.BucketUpdate) (*platform.Bucket, error) {
+						if id == platformtesting.MustIDBase16("020f755c3c082000") {
+							d := &platform.Bucket{
+								ID:              platformtesting.MustIDBase16("020f755c3c082000"),
+								Name:            "hello",
+								OrganizationID:  platformtesting.MustIDBase16("020f755c3c082000"),
+								RetentionPeriod: 2 * time.Hour,
+							}
+
+							if upd.ShardGroupDuration != nil {
+								d.ShardGroupDuration = *upd.ShardGroupDuration
+							}
+
+							return d, nil
+						}
+
+						return nil, fmt.Errorf("not found")
+					},
+				},
+			},
+			args: args{
+				id:                 "020f755c3c082000",
+				shardGroupDuration: 30 * time.Minute,
+			},
+			wants: wants{
+				statusCode:  http.StatusOK,
+				contentType: "application/json; charset=utf-8",
+				body: `
+{
+  "links": {
+    "org": "/api/v2/orgs/020f755c3c082000",
+    "self": "/api/v2/buckets/020f755c3c082000",
+    "log": "/api/v2/buckets/020f755c3c082000/log"
+  },
+  "id": "020f755c3c082000",
+  "organizationID": "020f755c3c082000",
+  "name": "hello",
+  "retentionRules": [{"type": "expire", "everySeconds": 7200}],
+  "shardGroupDurationSeconds": 1800
+}
 `,
 			},
 		},
@@ -720,6 +769,10 @@ func TestService_handlePatchBucket(t *testing.T) {
 				upd.RetentionPeriod = &tt.args.retention
 			}
 
+			if tt.args.shardGroupDuration != 0 {
+				upd.ShardGroupDuration = &tt.args.shardGroupDuration
+			}
+
 			b, err := json.Marshal(newBucketUpdate(&upd))
 			if err != nil {
 				t.Fatalf("failed to unmarshal bucket update: %v", err)
@@ -773,6 +826,16 @@ func initBucketService(f platformtesting.BucketFields, t *testing.T) (platform.B
 			t.Fatalf("failed to populate buckets")
 		}
 	}
+	for _, l := range f.Labels {
+		if err := svc.PutLabel(ctx, l); err != nil {
+			t.Fatalf("failed to populate labels: %v", err)
+		}
+	}
+	for _, m := range f.LabelMappings {
+		if err := svc.CreateLabelMapping(ctx, m); err != nil {
+			t.Fatalf("failed to populate label mappings: %v", err)
+		}
+	}
 
 	mappingService := mock.NewUserResourceMappingService()
 	labelService := mock.NewLabelService()
@@ -791,3 +854,132 @@ func initBucketService(f platformtesting.BucketFields, t *testing.T) (platform.B
 func TestBucketService(t *testing.T) {
 	platformtesting.BucketService(initBucketService, t)
 }
+
+func TestService_handleBucketSchema(t *testing.T) {
+	bucketID := platformtesting.MustIDBase16("020f755c3c082000")
+
+	type wants struct {
+		statusCode int
+		body       string
+		schema     *platform.BucketSchema
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		schema *platform.BucketSchema
+		wants  wants
+	}{
+		{
+			name:   "get a bucket schema",
+			method: "GET",
+			schema: &platform.BucketSchema{
+				Measurements: []platform.MeasurementSchema{
+					{
+						Name:   "cpu",
+						Tags:   []string{"host"},
+						Fields: []platform.FieldSchema{{Name: "usage", Type: platform.SchemaFieldFloat}},
+					},
+				},
+			},
+			wants: wants{
+				statusCode: http.StatusOK,
+				body: `
+		{
+		  "links": {
+		    "self": "/api/v2/buckets/020f755c3c082000/schema",
+		    "bucket": "/api/v2/buckets/020f755c3c082000"
+		  },
+		  "measurements": [
+		    {
+		      "name": "cpu",
+		      "tags": ["host"],
+		      "fields": [{"name": "usage", "type": "float"}]
+		    }
+		  ]
+		}
+		`,
+			},
+		},
+		{
+			name:   "get a bucket without a schema",
+			method: "GET",
+			wants: wants{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name:   "put a bucket schema",
+			method: "PUT",
+			body:   `{"measurements": [{"name": "cpu", "fields": [{"name": "usage", "type": "integer"}], "allowExtraColumns": true}]}`,
+			wants: wants{
+				statusCode: http.StatusOK,
+				schema: &platform.BucketSchema{
+					Measurements: []platform.MeasurementSchema{
+						{
+							Name:              "cpu",
+							Fields:            []platform.FieldSchema{{Name: "usage", Type: platform.SchemaFieldInteger}},
+							AllowExtraColumns: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "put an invalid bucket schema",
+			method: "PUT",
+			body:   `{"measurements": [{"name": "cpu", "fields": [{"name": "usage", "type": "decimal"}]}]}`,
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:   "delete a bucket schema",
+			method: "DELETE",
+			schema: &platform.BucketSchema{},
+			wants: wants{
+				statusCode: http.StatusNoContent,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := tt.schema
+			svc := &mock.BucketSchemaService{
+				FindBucketSchemaFn: func(ctx context.Context, id platform.ID) (*platform.BucketSchema, error) {
+					if id != bucketID {
+						return nil, &platform.Error{Code: platform.ENotFound, Msg: "bucket not found"}
+					}
+					return schema, nil
+				},
+				SetBucketSchemaFn: func(ctx context.Context, id platform.ID, s *platform.BucketSchema) error {
+					schema = s
+					return nil
+				},
+			}
+
+			h := NewBucketHandler(mock.NewUserResourceMappingService(), mock.NewLabelService())
+			h.BucketSchemaService = svc
+
+			r := httptest.NewRequest(tt.method, "http://any.url/api/v2/buckets/020f755c3c082000/schema", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+
+			if res.StatusCode != tt.wants.statusCode {
+				t.Errorf("%q. %s = %v, want %v: %s", tt.name, tt.method, res.StatusCode, tt.wants.statusCode, body)
+			}
+			if eq, _ := jsonEqual(string(body), tt.wants.body); tt.wants.body != "" && !eq {
+				t.Errorf("%q. %s = \n***%v***\n,\nwant\n***%v***", tt.name, tt.method, string(body), tt.wants.body)
+			}
+			if tt.wants.statusCode < 300 && tt.method != "GET" && !reflect.DeepEqual(schema, tt.wants.schema) {
+				t.Errorf("%q. %s set schema %+v, want %+v", tt.name, tt.method, schema, tt.wants.schema)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/platform"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	checksPath = "/api/v2/checks"
+)
+
+// CheckHandler is the handler for the check service
+type CheckHandler struct {
+	*httprouter.Router
+
+	CheckService platform.CheckService
+}
+
+// NewCheckHandler creates a new CheckHandler
+func NewCheckHandler() *CheckHandler {
+	h := &CheckHandler{
+		Router: httprouter.New(),
+	}
+
+	h.HandlerFunc("GET", checksPath, h.handleGetChecks)
+	h.HandlerFunc("POST", checksPath, h.handlePostCheck)
+	h.HandlerFunc("GET", checksPath+"/:id", h.handleGetCheck)
+	h.HandlerFunc("PATCH", checksPath+"/:id", h.handlePatchCheck)
+	h.HandlerFunc("DELETE", checksPath+"/:id", h.handleDeleteCheck)
+
+	return h
+}
+
+type checkLinks struct {
+	Self string `json:"self"`
+	Task string `json:"task,omitempty"`
+}
+
+type checkResponse struct {
+	*platform.Check
+	Links checkLinks `json:"links"`
+}
+
+func newCheckResponse(c *platform.Check) checkResponse {
+	resp := checkResponse{
+		Check: c,
+		Links: checkLinks{
+			Self: fmt.Sprintf("%s/%s", checksPath, c.ID),
+		},
+	}
+	if c.TaskID.Valid() {
+		resp.Links.Task = fmt.Sprintf("/api/v2/tasks/%s", c.TaskID)
+	}
+	return resp
+}
+
+type checksResponse struct {
+	Checks []checkResponse   `json:"checks"`
+	Links  map[string]string `json:"links"`
+}
+
+func newChecksResponse(cs []*platform.Check) checksResponse {
+	resp := checksResponse{
+		Checks: make([]checkResponse, 0, len(cs)),
+		Links: map[string]string{
+			"self": checksPath,
+		},
+	}
+	for _, c := range cs {
+		resp.Checks = append(resp.Checks, newCheckResponse(c))
+	}
+	return resp
+}
+
+// requestID returns the :id parameter of the request's route.
+func requestID(ctx context.Context) (platform.ID, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	urlID := params.ByName("id")
+	if urlID == "" {
+		return platform.InvalidID(), &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	id, err := platform.IDFromString(urlID)
+	if err != nil {
+		return platform.InvalidID(), &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
+	}
+	return *id, nil
+}
+
+// requestOrgIDFilter returns the orgID query parameter of the request, or nil if it is not set.
+func requestOrgIDFilter(r *http.Request) (*platform.ID, error) {
+	orgID := r.URL.Query().Get("orgID")
+	if orgID == "" {
+		return nil, nil
+	}
+	id, err := platform.IDFromString(orgID)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid orgID",
+			Err:  err,
+		}
+	}
+	return id, nil
+}
+
+func (h *CheckHandler) handleGetChecks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := requestOrgIDFilter(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	cs, err := h.CheckService.FindChecks(ctx, platform.CheckFilter{OrganizationID: orgID})
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newChecksResponse(cs)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *CheckHandler) handlePostCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	c := &platform.Check{}
+	if err := json.NewDecoder(r.Body).Decode(c); err != nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid json structure",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	if err := h.CheckService.CreateCheck(ctx, c); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newCheckResponse(c)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *CheckHandler) handleGetCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	c, err := h.CheckService.FindCheckByID(ctx, id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newCheckResponse(c)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *CheckHandler) handlePatchCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	var upd platform.CheckUpdate
+	if err := json.NewDecoder(r.Body).Decode(&upd); err != nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid json structure",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	c, err := h.CheckService.UpdateCheck(ctx, id, upd)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newCheckResponse(c)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *CheckHandler) handleDeleteCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.CheckService.DeleteCheck(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func TestCheckHandler(t *testing.T) {
+	above := 90.0
+	check := &platform.Check{
+		ID:             platformtesting.MustIDBase16("020f755c3c082000"),
+		OrganizationID: platformtesting.MustIDBase16("020f755c3c082001"),
+		Name:           "cpu",
+		Type:           platform.ThresholdCheck,
+		Status:         platform.CheckActive,
+		Query:          `from(bucket: "telegraf") |> range(start: -1m)`,
+		Every:          "1m",
+		Thresholds:     []platform.CheckThreshold{{Level: platform.CritLevel, Above: &above}},
+		TaskID:         platformtesting.MustIDBase16("020f755c3c082002"),
+	}
+	checkJSON := `{"id":"020f755c3c082000","orgID":"020f755c3c082001","name":"cpu","type":"threshold","status":"active","query":"from(bucket: \"telegraf\") |> range(start: -1m)","every":"1m","thresholds":[{"level":"crit","above":90}],"taskID":"020f755c3c082002","links":{"self":"/api/v2/checks/020f755c3c082000","task":"/api/v2/tasks/020f755c3c082002"}}`
+
+	cs := mock.NewCheckService()
+	cs.FindChecksFn = func(ctx context.Context, filter platform.CheckFilter) ([]*platform.Check, error) {
+		if filter.OrganizationID == nil || *filter.OrganizationID != check.OrganizationID {
+			t.Errorf("expected checks to be filtered by organization, got %v", filter.OrganizationID)
+		}
+		return []*platform.Check{check}, nil
+	}
+	cs.FindCheckByIDFn = func(ctx context.Context, id platform.ID) (*platform.Check, error) {
+		if id != check.ID {
+			return nil, &platform.Error{Code: platform.ENotFound, Msg: "check not found"}
+		}
+		return check, nil
+	}
+	cs.CreateCheckFn = func(ctx context.Context, c *platform.Check) error {
+		c.ID, c.TaskID, c.Status = check.ID, check.TaskID, platform.CheckActive
+		return nil
+	}
+
+	h := NewCheckHandler()
+	h.CheckService = cs
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "find checks of an organization",
+			method:     "GET",
+			path:       "/api/v2/checks?orgID=020f755c3c082001",
+			wantStatus: 200,
+			wantBody:   `{"checks":[` + checkJSON + `],"links":{"self":"/api/v2/checks"}}`,
+		},
+		{
+			name:       "find a check",
+			method:     "GET",
+			path:       "/api/v2/checks/020f755c3c082000",
+			wantStatus: 200,
+			wantBody:   checkJSON,
+		},
+		{
+			name:       "find a missing check",
+			method:     "GET",
+			path:       "/api/v2/checks/020f755c3c082003",
+			wantStatus: 404,
+		},
+		{
+			name:       "create a check",
+			method:     "POST",
+			path:       "/api/v2/checks",
+			body:       `{"orgID":"020f755c3c082001","name":"cpu","type":"threshold","query":"from(bucket: \"telegraf\") |> range(start: -1m)","every":"1m","thresholds":[{"level":"crit","above":90}]}`,
+			wantStatus: 201,
+			wantBody:   checkJSON,
+		},
+		{
+			name:       "create a check with malformed json",
+			method:     "POST",
+			path:       "/api/v2/checks",
+			body:       `{`,
+			wantStatus: 400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, res.StatusCode, body)
+			}
+			if tt.wantBody != "" {
+				if eq, _ := jsonEqual(string(body), tt.wantBody); !eq {
+					t.Errorf("unexpected body:\n%s\nwant:\n%s", body, tt.wantBody)
+				}
+			}
+		})
+	}
+}
@@ -21,22 +21,24 @@ type DashboardHandler struct {
 
 	DashboardService             platform.DashboardService
 	DashboardOperationLogService platform.DashboardOperationLogService
+	DashboardTemplateService     platform.DashboardTemplateService
 	UserResourceMappingService   platform.UserResourceMappingService
 	LabelService                 platform.LabelService
 }
 
 const (
-	dashboardsPath             = "/api/v2/dashboards"
-	dashboardsIDPath           = "/api/v2/dashboards/:id"
-	dashboardsIDCellsPath      = "/api/v2/dashboards/:id/cells"
-	dashboardsIDCellsIDPath    = "/api/v2/dashboards/:id/cells/:cellID"
-	dashboardsIDMembersPath    = "/api/v2/dashboards/:id/members"
-	dashboardsIDLogPath        = "/api/v2/dashboards/:id/log"
-	dashboardsIDMembersIDPath  = "/api/v2/dashboards/:id/members/:userID"
-	dashboardsIDOwnersPath     = "/api/v2/dashboards/:id/owners"
-	dashboardsIDOwnersIDPath   = "/api/v2/dashboards/:id/owners/:userID"
-	dashboardsIDLabelsPath     = "/api/v2/dashboards/:id/labels"
-	dashboardsIDLabelsNamePath = "/api/v2/dashboards/:id/labels/:name"
+	dashboardsPath            = "/api/v2/dashboards"
+	dashboardsIDPath          = "/api/v2/dashboards/:id"
+	dashboardsIDCellsPath     = "/api/v2/dashboards/:id/cells"
+	dashboardsIDCellsIDPath   = "/api/v2/dashboards/:id/cells/:cellID"
+	dashboardsIDMembersPath   = "/api/v2/dashboards/:id/members"
+	dashboardsIDLogPath       = "/api/v2/dashboards/:id/log"
+	dashboardsIDExportPath    = "/api/v2/dashboards/:id/export"
+	dashboardsIDMembersIDPath = "/api/v2/dashboards/:id/members/:userID"
+	dashboardsIDOwnersPath    = "/api/v2/dashboards/:id/owners"
+	dashboardsIDOwnersIDPath  = "/api/v2/dashboards/:id/owners/:userID"
+	dashboardsIDLabelsPath    = "/api/v2/dashboards/:id/labels"
+	dashboardsIDLabelsIDPath  = "/api/v2/dashboards/:id/labels/:lid"
 )
 
 // NewDashboardHandler returns a new instance of DashboardHandler.
@@ -51,6 +53,7 @@ func NewDashboardHandler(mappingService platform.UserResourceMappingService, lab
 	h.HandlerFunc("GET", dashboardsPath, h.handleGetDashboards)
 	h.HandlerFunc("GET", dashboardsIDPath, h.handleGetDashboard)
 	h.HandlerFunc("GET", dashboardsIDLogPath, h.handleGetDashboardLog)
+	h.HandlerFunc("GET", dashboardsIDExportPath, h.handleGetDashboardExport)
 	h.HandlerFunc("DELETE", dashboardsIDPath, h.handleDeleteDashboard)
 	h.HandlerFunc("PATCH", dashboardsIDPath, h.handlePatchDashboard)
 
@@ -67,9 +70,9 @@ func NewDashboardHandler(mappingService platform.UserResourceMappingService, lab
 	h.HandlerFunc("GET", dashboardsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, platform.Owner))
 	h.HandlerFunc("DELETE", dashboardsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
 
-	h.HandlerFunc("GET", dashboardsIDLabelsPath, newGetLabelsHandler(h.LabelService))
-	h.HandlerFunc("POST", dashboardsIDLabelsPath, newPostLabelHandler(h.LabelService))
-	h.HandlerFunc("DELETE", dashboardsIDLabelsNamePath, newDeleteLabelHandler(h.LabelService))
+	h.HandlerFunc("GET", dashboardsIDLabelsPath, newGetLabelsHandler(h.LabelService, platform.DashboardResourceType))
+	h.HandlerFunc("POST", dashboardsIDLabelsPath, newPostLabelHandler(h.LabelService, platform.DashboardResourceType))
+	h.HandlerFunc("DELETE", dashboardsIDLabelsIDPath, newDeleteLabelHandler(h.LabelService, platform.DashboardResourceType))
 
 	return h
 }
@@ -257,6 +260,8 @@ func decodeGetDashboardsRequest(ctx context.Context, r *http.Request) (*getDashb
 		}
 	}
 
+	req.filter.Label = labelFilterParam(r)
+
 	req.opts = platform.DefaultDashboardFindOptions
 
 	if sortBy := qp.Get("sortBy"); sortBy != "" {
@@ -817,6 +822,9 @@ func (s *DashboardService) FindDashboards(ctx context.Context, filter platform.D
 	for _, id := range filter.IDs {
 		qp.Add("id", id.String())
 	}
+	if filter.Label != nil {
+		qp.Add("label", *filter.Label)
+	}
 	url.RawQuery = qp.Encode()
 
 	req, err := http.NewRequest("GET", url.String(), nil)
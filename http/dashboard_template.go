@@ -0,0 +1,239 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	dashboardTemplatesPath       = "/api/v2/dashboardtemplates"
+	dashboardTemplatesImportPath = "/api/v2/dashboardtemplates/import"
+)
+
+// DashboardTemplateHandler is the handler for listing and importing dashboard templates.
+type DashboardTemplateHandler struct {
+	*httprouter.Router
+
+	DashboardTemplateService platform.DashboardTemplateService
+
+	// Templates are the built-in templates, such as the converted chronograf
+	// canned layouts, that are offered for import.
+	Templates []*platform.DashboardTemplate
+}
+
+// NewDashboardTemplateHandler returns a new instance of DashboardTemplateHandler.
+func NewDashboardTemplateHandler() *DashboardTemplateHandler {
+	h := &DashboardTemplateHandler{
+		Router: httprouter.New(),
+	}
+
+	h.HandlerFunc("GET", dashboardTemplatesPath, h.handleGetDashboardTemplates)
+	h.HandlerFunc("POST", dashboardTemplatesImportPath, h.handlePostDashboardTemplateImport)
+
+	return h
+}
+
+type dashboardTemplatesResponse struct {
+	Links     map[string]string             `json:"links"`
+	Templates []*platform.DashboardTemplate `json:"templates"`
+}
+
+// handleGetDashboardTemplates returns the built-in dashboard templates.
+func (h *DashboardTemplateHandler) handleGetDashboardTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	res := dashboardTemplatesResponse{
+		Links: map[string]string{
+			"self":   dashboardTemplatesPath,
+			"import": dashboardTemplatesImportPath,
+		},
+		Templates: h.Templates,
+	}
+	if res.Templates == nil {
+		res.Templates = []*platform.DashboardTemplate{}
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handlePostDashboardTemplateImport creates a new dashboard from a template.
+func (h *DashboardTemplateHandler) handlePostDashboardTemplateImport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePostDashboardTemplateImportRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	opts := platform.ImportDashboardOptions{
+		OrganizationID: req.OrganizationID,
+	}
+	if a, err := pcontext.GetAuthorizer(ctx); err == nil {
+		opts.Owner = a.GetUserID()
+	}
+
+	d, err := h.DashboardTemplateService.ImportDashboard(ctx, req.Template, opts)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newDashboardResponse(d)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+type postDashboardTemplateImportRequest struct {
+	Template       *platform.DashboardTemplate
+	OrganizationID platform.ID
+}
+
+func decodePostDashboardTemplateImportRequest(ctx context.Context, r *http.Request) (*postDashboardTemplateImportRequest, error) {
+	t := &platform.DashboardTemplate{}
+	if err := json.NewDecoder(r.Body).Decode(t); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "unable to decode dashboard template",
+			Err:  err,
+		}
+	}
+
+	if err := t.Valid(); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
+	}
+
+	req := &postDashboardTemplateImportRequest{
+		Template: t,
+	}
+
+	if orgID := r.URL.Query().Get(OrgID); orgID != "" {
+		id, err := platform.IDFromString(orgID)
+		if err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Err:  err,
+			}
+		}
+		req.OrganizationID = *id
+	}
+
+	return req, nil
+}
+
+// handleGetDashboardExport returns a dashboard as a portable template.
+func (h *DashboardHandler) handleGetDashboardExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetDashboardRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	t, err := h.DashboardTemplateService.ExportDashboard(ctx, req.DashboardID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, t); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+var _ platform.DashboardTemplateService = (*DashboardService)(nil)
+
+// ExportDashboard returns the dashboard with the provided ID as a portable template.
+func (s *DashboardService) ExportDashboard(ctx context.Context, id platform.ID) (*platform.DashboardTemplate, error) {
+	u, err := newURL(s.Addr, path.Join(dashboardIDPath(id), "export"))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	SetToken(s.Token, req)
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var t platform.DashboardTemplate
+	if err := json.NewDecoder(resp.Body).Decode(&t); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// ImportDashboard creates a new dashboard from a template. The dashboard is
+// owned by the user of the token used to import it; opts.Owner is ignored.
+func (s *DashboardService) ImportDashboard(ctx context.Context, t *platform.DashboardTemplate, opts platform.ImportDashboardOptions) (*platform.Dashboard, error) {
+	u, err := newURL(s.Addr, dashboardTemplatesImportPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.OrganizationID.Valid() {
+		qp := u.Query()
+		qp.Set(OrgID, opts.OrganizationID.String())
+		u.RawQuery = qp.Encode()
+	}
+
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var dr dashboardResponse
+	if err := json.NewDecoder(resp.Body).Decode(&dr); err != nil {
+		return nil, err
+	}
+
+	return dr.toPlatform(), nil
+}
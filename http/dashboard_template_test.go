@@ -0,0 +1,181 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/mock"
+	platformtesting "github.com/influxdata/platform/testing"
+	"github.com/julienschmidt/httprouter"
+)
+
+func TestService_handleGetDashboardExport(t *testing.T) {
+	svc := &mock.DashboardTemplateService{
+		ExportDashboardF: func(ctx context.Context, id platform.ID) (*platform.DashboardTemplate, error) {
+			if id != platformtesting.MustIDBase16("020f755c3c082000") {
+				return nil, platform.ErrDashboardNotFound
+			}
+			return &platform.DashboardTemplate{
+				Meta:        platform.DashboardTemplateMeta{Version: platform.DashboardTemplateVersion},
+				Name:        "hello",
+				Description: "oh hello there!",
+				Cells: []*platform.DashboardTemplateCell{
+					{
+						X: 1, Y: 2, W: 3, H: 4,
+						View: &platform.View{
+							ViewContents: platform.ViewContents{Name: "md"},
+							Properties:   platform.MarkdownViewProperties{Type: "markdown", Note: "hi"},
+						},
+					},
+				},
+				Macros: []*platform.Macro{},
+				Labels: []platform.DashboardTemplateLabel{{Name: "prod", Properties: map[string]string{"color": "ffb3b3"}}},
+			}, nil
+		},
+	}
+
+	h := NewDashboardHandler(mock.NewUserResourceMappingService(), mock.NewLabelService())
+	h.DashboardTemplateService = svc
+
+	r := httptest.NewRequest("GET", "http://any.url", nil)
+	r = r.WithContext(context.WithValue(
+		context.Background(),
+		httprouter.ParamsKey,
+		httprouter.Params{
+			{
+				Key:   "id",
+				Value: "020f755c3c082000",
+			},
+		}))
+	w := httptest.NewRecorder()
+
+	h.handleGetDashboardExport(w, r)
+
+	res := w.Result()
+	body, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("handleGetDashboardExport() = %v, want %v", res.StatusCode, http.StatusOK)
+	}
+
+	want := `
+{
+  "meta": {
+    "version": "1"
+  },
+  "name": "hello",
+  "description": "oh hello there!",
+  "cells": [
+    {
+      "x": 1,
+      "y": 2,
+      "w": 3,
+      "h": 4,
+      "view": {
+        "name": "md",
+        "properties": {
+          "shape": "chronograf-v2",
+          "type": "markdown",
+          "note": "hi"
+        }
+      }
+    }
+  ],
+  "macros": [],
+  "labels": [{"name": "prod", "properties": {"color": "ffb3b3"}}]
+}
+`
+	if eq, _ := jsonEqual(string(body), want); !eq {
+		t.Errorf("handleGetDashboardExport() = \n***%v***\n,\nwant\n***%v***", string(body), want)
+	}
+}
+
+func TestService_handlePostDashboardTemplateImport(t *testing.T) {
+	userID := platformtesting.MustIDBase16("6f626f7274697320")
+
+	tests := []struct {
+		name       string
+		body       string
+		statusCode int
+	}{
+		{
+			name: "import a template",
+			body: `
+{
+  "meta": {"version": "1"},
+  "name": "hello",
+  "cells": [
+    {"x": 1, "y": 2, "w": 3, "h": 4, "view": {"name": "md", "properties": {"shape": "chronograf-v2", "type": "markdown", "note": "hi"}}}
+  ]
+}
+`,
+			statusCode: http.StatusCreated,
+		},
+		{
+			name:       "unsupported version",
+			body:       `{"meta": {"version": "999"}, "name": "hello"}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "empty cell",
+			body:       `{"meta": {"version": "1"}, "name": "hello", "cells": [null]}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "cell without a view",
+			body:       `{"meta": {"version": "1"}, "name": "hello", "cells": [{"x": 1, "y": 2, "w": 3, "h": 4}]}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "empty macro",
+			body:       `{"meta": {"version": "1"}, "name": "hello", "macros": [null]}`,
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewDashboardTemplateHandler()
+			h.DashboardTemplateService = &mock.DashboardTemplateService{
+				ImportDashboardF: func(ctx context.Context, tmpl *platform.DashboardTemplate, opts platform.ImportDashboardOptions) (*platform.Dashboard, error) {
+					if opts.Owner != userID {
+						t.Errorf("unexpected owner %v", opts.Owner)
+					}
+					return &platform.Dashboard{
+						ID:   platformtesting.MustIDBase16("020f755c3c082000"),
+						Name: tmpl.Name,
+						Meta: platform.DashboardMeta{
+							CreatedAt: time.Date(2012, time.November, 10, 23, 0, 0, 0, time.UTC),
+						},
+						Cells: []*platform.Cell{
+							{
+								ID:     platformtesting.MustIDBase16("da7aba5e5d81e550"),
+								X:      1,
+								Y:      2,
+								W:      3,
+								H:      4,
+								ViewID: platformtesting.MustIDBase16("ba0bab707a11ed12"),
+							},
+						},
+					}, nil
+				},
+			}
+
+			r := httptest.NewRequest("POST", "http://any.url", bytes.NewBufferString(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{UserID: userID}))
+			w := httptest.NewRecorder()
+
+			h.handlePostDashboardTemplateImport(w, r)
+
+			if got := w.Result().StatusCode; got != tt.statusCode {
+				t.Errorf("handlePostDashboardTemplateImport() = %v, want %v", got, tt.statusCode)
+			}
+		})
+	}
+}
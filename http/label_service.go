@@ -1,212 +1,355 @@
-// responses from /labels should look like:
-// {
-//   labels: [
-//     "foo",
-//     "bar"
-//   ]
-// }
-//
-// this list (under key "labels") should be returned with any labelled resource that is requested via other endpoints
-
 package http
 
 import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
+	"net/url"
 	"path"
 
-	plat "github.com/influxdata/platform"
-	kerrors "github.com/influxdata/platform/kit/errors"
+	"github.com/influxdata/platform"
 	"github.com/julienschmidt/httprouter"
 )
 
-type LabelService struct {
-	Addr               string
-	Token              string
-	InsecureSkipVerify bool
-	BasePath           string
+const (
+	labelsPath   = "/api/v2/labels"
+	labelsIDPath = "/api/v2/labels/:id"
+)
+
+// LabelHandler represents an HTTP API handler for labels.
+type LabelHandler struct {
+	*httprouter.Router
+
+	LabelService platform.LabelService
+}
+
+// NewLabelHandler returns a new instance of LabelHandler.
+func NewLabelHandler() *LabelHandler {
+	h := &LabelHandler{
+		Router: httprouter.New(),
+	}
+
+	h.HandlerFunc("POST", labelsPath, h.handlePostLabel)
+	h.HandlerFunc("GET", labelsPath, h.handleGetLabels)
+	h.HandlerFunc("GET", labelsIDPath, h.handleGetLabel)
+	h.HandlerFunc("PATCH", labelsIDPath, h.handlePatchLabel)
+	h.HandlerFunc("DELETE", labelsIDPath, h.handleDeleteLabel)
+
+	return h
 }
 
 type labelResponse struct {
 	Links map[string]string `json:"links"`
-	Label string            `json:"label"`
+	Label platform.Label    `json:"label"`
 }
 
-// TODO: remove "dashboard" from this
-func newLabelResponse(l *plat.Label) *labelResponse {
+func newLabelResponse(l *platform.Label) *labelResponse {
 	return &labelResponse{
 		Links: map[string]string{
-			"resource": fmt.Sprintf("/api/v2/%ss/%s", "dashboard", l.ResourceID),
+			"self": labelIDPath(l.ID),
 		},
-		Label: l.Name,
+		Label: *l,
 	}
 }
 
 type labelsResponse struct {
 	Links  map[string]string `json:"links"`
-	Labels []string          `json:"labels"`
+	Labels []*platform.Label `json:"labels"`
+}
+
+func newLabelsResponse(self string, ls []*platform.Label) *labelsResponse {
+	if ls == nil {
+		ls = []*platform.Label{}
+	}
+
+	return &labelsResponse{
+		Links: map[string]string{
+			"self": self,
+		},
+		Labels: ls,
+	}
+}
+
+// handlePostLabel is the HTTP handler for the POST /api/v2/labels route.
+func (h *LabelHandler) handlePostLabel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	l := &platform.Label{}
+	if err := json.NewDecoder(r.Body).Decode(l); err != nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}, w)
+		return
+	}
+
+	if err := l.Validate(); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.LabelService.CreateLabel(ctx, l); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newLabelResponse(l)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handleGetLabels is the HTTP handler for the GET /api/v2/labels route.
+func (h *LabelHandler) handleGetLabels(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, err := decodeGetLabelsRequest(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	labels, err := h.LabelService.FindLabels(ctx, filter)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newLabelsResponse(labelsPath, labels)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
 }
 
-func (ls labelsResponse) ToPlatform() []*plat.Label {
-	labels := make([]*plat.Label, len(ls.Labels))
-	for i := range ls.Labels {
-		labels[i] = &plat.Label{
-			Name: ls.Labels[i],
+func decodeGetLabelsRequest(r *http.Request) (platform.LabelFilter, error) {
+	qp := r.URL.Query()
+	filter := platform.LabelFilter{
+		Name: qp.Get("name"),
+	}
+
+	if orgID := qp.Get(OrgID); orgID != "" {
+		id, err := platform.IDFromString(orgID)
+		if err != nil {
+			return filter, &platform.Error{
+				Code: platform.EInvalid,
+				Err:  err,
+			}
 		}
+		filter.OrganizationID = id
 	}
-	return labels
+
+	return filter, nil
 }
 
-func newLabelsResponse(opts plat.FindOptions, f plat.LabelFilter, ls []*plat.Label) *labelsResponse {
-	var labels []string
-	for _, l := range ls {
-		labels = append(labels, l.Name)
+// handleGetLabel is the HTTP handler for the GET /api/v2/labels/:id route.
+func (h *LabelHandler) handleGetLabel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := decodeIDParam(ctx, "id")
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	l, err := h.LabelService.FindLabelByID(ctx, id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
 	}
 
-	// TODO: Remove "dashboard" from this
-	return &labelsResponse{
-		Links: map[string]string{
-			"resource": fmt.Sprintf("/api/v2/%ss/%s", "dashboard", f.ResourceID),
-		},
-		Labels: labels,
+	if err := encodeResponse(ctx, w, http.StatusOK, newLabelResponse(l)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handlePatchLabel is the HTTP handler for the PATCH /api/v2/labels/:id route.
+func (h *LabelHandler) handlePatchLabel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := decodeIDParam(ctx, "id")
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	var upd platform.LabelUpdate
+	if err := json.NewDecoder(r.Body).Decode(&upd); err != nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}, w)
+		return
+	}
+
+	l, err := h.LabelService.UpdateLabel(ctx, id, upd)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newLabelResponse(l)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handleDeleteLabel is the HTTP handler for the DELETE /api/v2/labels/:id route.
+func (h *LabelHandler) handleDeleteLabel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := decodeIDParam(ctx, "id")
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.LabelService.DeleteLabel(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeIDParam decodes the ID in the named URL parameter.
+func decodeIDParam(ctx context.Context, name string) (platform.ID, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	s := params.ByName(name)
+	if s == "" {
+		return platform.InvalidID(), &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "url missing " + name,
+		}
+	}
+
+	id, err := platform.IDFromString(s)
+	if err != nil {
+		return platform.InvalidID(), &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
 	}
+
+	return *id, nil
 }
 
-// newGetLabelsHandler returns a handler func for a GET to /labels endpoints
-func newGetLabelsHandler(s plat.LabelService) http.HandlerFunc {
+// labelledResourceID decodes the ID of the resource that a resource label
+// route refers to. Task routes name it tid rather than id.
+func labelledResourceID(ctx context.Context) (platform.ID, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	if params.ByName("tid") != "" {
+		return decodeIDParam(ctx, "tid")
+	}
+	return decodeIDParam(ctx, "id")
+}
+
+// newGetLabelsHandler returns a handler func for a GET to the labels of a
+// resource of type rt.
+func newGetLabelsHandler(s platform.LabelService, rt platform.ResourceType) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		req, err := decodeGetLabelsRequest(ctx, r)
+		id, err := labelledResourceID(ctx)
 		if err != nil {
 			EncodeError(ctx, err, w)
 			return
 		}
 
-		opts := plat.FindOptions{}
-		labels, err := s.FindLabels(ctx, req.filter)
+		labels, err := s.FindResourceLabels(ctx, platform.LabelMappingFilter{
+			ResourceID:   id,
+			ResourceType: rt,
+		})
 		if err != nil {
 			EncodeError(ctx, err, w)
 			return
 		}
 
-		if err := encodeResponse(ctx, w, http.StatusOK, newLabelsResponse(opts, req.filter, labels)); err != nil {
+		if err := encodeResponse(ctx, w, http.StatusOK, newLabelsResponse(resourceLabelsPath(rt, id), labels)); err != nil {
 			EncodeError(ctx, err, w)
 			return
 		}
 	}
 }
 
-type getLabelsRequest struct {
-	filter plat.LabelFilter
-}
-
-func decodeGetLabelsRequest(ctx context.Context, r *http.Request) (*getLabelsRequest, error) {
-	qp := r.URL.Query()
-	req := &getLabelsRequest{}
-
-	params := httprouter.ParamsFromContext(ctx)
-	id := params.ByName("id")
-	if id == "" {
-		return nil, kerrors.InvalidDataf("url missing id")
-	}
-
-	var i plat.ID
-	if err := i.DecodeFromString(id); err != nil {
-		return nil, err
-	}
-	req.filter.ResourceID = i
-
-	if name := qp.Get("name"); name != "" {
-		req.filter.Name = name
-	}
-
-	return req, nil
+type postLabelMappingRequest struct {
+	LabelID platform.ID `json:"labelID"`
 }
 
-// newPostLabelHandler returns a handler func for a POST to /labels endpoints
-func newPostLabelHandler(s plat.LabelService) http.HandlerFunc {
+// newPostLabelHandler returns a handler func for a POST that applies a label
+// to a resource of type rt.
+func newPostLabelHandler(s platform.LabelService, rt platform.ResourceType) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		req, err := decodePostLabelRequest(ctx, r)
+		id, err := labelledResourceID(ctx)
 		if err != nil {
 			EncodeError(ctx, err, w)
 			return
 		}
 
-		label := &plat.Label{
-			ResourceID: req.ResourceID,
-			Name:       req.Name,
+		var req postLabelMappingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			EncodeError(ctx, &platform.Error{
+				Code: platform.EInvalid,
+				Err:  err,
+			}, w)
+			return
 		}
 
-		if err := label.Validate(); err != nil {
+		m := &platform.LabelMapping{
+			LabelID:      req.LabelID,
+			ResourceID:   id,
+			ResourceType: rt,
+		}
+		if err := m.Validate(); err != nil {
 			EncodeError(ctx, err, w)
 			return
 		}
 
-		if err := s.CreateLabel(ctx, label); err != nil {
+		if err := s.CreateLabelMapping(ctx, m); err != nil {
 			EncodeError(ctx, err, w)
 			return
 		}
 
-		if err := encodeResponse(ctx, w, http.StatusCreated, newLabelResponse(label)); err != nil {
+		l, err := s.FindLabelByID(ctx, m.LabelID)
+		if err != nil {
 			EncodeError(ctx, err, w)
 			return
 		}
-	}
-}
-
-type postLabelRequest struct {
-	ResourceID plat.ID
-	Name       string
-}
 
-func decodePostLabelRequest(ctx context.Context, r *http.Request) (*postLabelRequest, error) {
-	params := httprouter.ParamsFromContext(ctx)
-	id := params.ByName("id")
-	if id == "" {
-		return nil, kerrors.InvalidDataf("url missing id")
-	}
-
-	name := params.ByName("name")
-	if name == "" {
-		return nil, kerrors.InvalidDataf("url missing label name")
-	}
-
-	var rid plat.ID
-	if err := rid.DecodeFromString(id); err != nil {
-		return nil, err
-	}
-
-	label := &postLabelRequest{
-		ResourceID: rid,
-		Name:       name,
+		if err := encodeResponse(ctx, w, http.StatusCreated, newLabelResponse(l)); err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
 	}
-
-	return label, nil
 }
 
-// newDeleteLabelHandler returns a handler func for a DELETE to /labels endpoints
-func newDeleteLabelHandler(s plat.LabelService) http.HandlerFunc {
+// newDeleteLabelHandler returns a handler func for a DELETE that removes a
+// label from a resource of type rt.
+func newDeleteLabelHandler(s platform.LabelService, rt platform.ResourceType) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		req, err := decodeDeleteLabelRequest(ctx, r)
+		id, err := labelledResourceID(ctx)
 		if err != nil {
 			EncodeError(ctx, err, w)
 			return
 		}
 
-		label := plat.Label{
-			ResourceID: req.ResourceID,
-			Name:       req.Name,
+		labelID, err := decodeIDParam(ctx, "lid")
+		if err != nil {
+			EncodeError(ctx, err, w)
+			return
 		}
 
-		if err := s.DeleteLabel(ctx, label); err != nil {
+		m := &platform.LabelMapping{
+			LabelID:      labelID,
+			ResourceID:   id,
+			ResourceType: rt,
+		}
+		if err := s.DeleteLabelMapping(ctx, m); err != nil {
 			EncodeError(ctx, err, w)
 			return
 		}
@@ -215,76 +358,96 @@ func newDeleteLabelHandler(s plat.LabelService) http.HandlerFunc {
 	}
 }
 
-type deleteLabelRequest struct {
-	ResourceID plat.ID
-	Name       string
+func labelIDPath(id platform.ID) string {
+	return path.Join(labelsPath, id.String())
 }
 
-func decodeDeleteLabelRequest(ctx context.Context, r *http.Request) (*deleteLabelRequest, error) {
-	params := httprouter.ParamsFromContext(ctx)
-	id := params.ByName("id")
-	if id == "" {
-		return nil, kerrors.InvalidDataf("url missing resource id")
-	}
+// resourceLabelsPath returns the path of the labels of a resource.
+func resourceLabelsPath(rt platform.ResourceType, id platform.ID) string {
+	return path.Join("/api/v2", string(rt)+"s", id.String(), "labels")
+}
 
-	var rid plat.ID
-	if err := rid.DecodeFromString(id); err != nil {
-		return nil, err
-	}
+// LabelService connects to Influx via HTTP using tokens to manage labels.
+type LabelService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+var _ platform.LabelService = (*LabelService)(nil)
 
-	label := &deleteLabelRequest{}
-	if err := json.NewDecoder(r.Body).Decode(label); err != nil {
+// FindLabelByID returns a single label by ID.
+func (s *LabelService) FindLabelByID(ctx context.Context, id platform.ID) (*platform.Label, error) {
+	u, err := newURL(s.Addr, labelIDPath(id))
+	if err != nil {
 		return nil, err
 	}
 
-	if label.Name == "" {
-		return nil, kerrors.InvalidDataf("label name is missing")
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	label.ResourceID = rid
+	SetToken(s.Token, req)
 
-	return label, nil
+	return s.doLabel(u, req)
 }
 
-// FindLabels returns a slice of labels
-func (s *LabelService) FindLabels(ctx context.Context, filter plat.LabelFilter, opt ...plat.FindOptions) ([]*plat.Label, error) {
-	url, err := newURL(s.Addr, resourceIDPath(s.BasePath, filter.ResourceID))
-	if err != nil {
-		return nil, err
+// FindLabels returns a list of labels that match a filter.
+func (s *LabelService) FindLabels(ctx context.Context, filter platform.LabelFilter, opt ...platform.FindOptions) ([]*platform.Label, error) {
+	if filter.ID.Valid() {
+		l, err := s.FindLabelByID(ctx, filter.ID)
+		if err != nil {
+			return nil, err
+		}
+		return []*platform.Label{l}, nil
 	}
 
-	req, err := http.NewRequest("GET", url.String(), nil)
+	u, err := newURL(s.Addr, labelsPath)
 	if err != nil {
 		return nil, err
 	}
 
-	SetToken(s.Token, req)
-
-	hc := newClient(url.Scheme, s.InsecureSkipVerify)
+	qp := url.Values{}
+	if filter.Name != "" {
+		qp.Set("name", filter.Name)
+	}
+	if filter.OrganizationID != nil {
+		qp.Set(OrgID, filter.OrganizationID.String())
+	}
+	u.RawQuery = qp.Encode()
 
-	resp, err := hc.Do(req)
+	req, err := http.NewRequest("GET", u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
+	SetToken(s.Token, req)
+
+	return s.doLabels(u, req)
+}
 
-	if err := CheckError(resp); err != nil {
+// FindResourceLabels returns the labels applied to a resource.
+func (s *LabelService) FindResourceLabels(ctx context.Context, filter platform.LabelMappingFilter) ([]*platform.Label, error) {
+	u, err := newURL(s.Addr, resourceLabelsPath(filter.ResourceType, filter.ResourceID))
+	if err != nil {
 		return nil, err
 	}
 
-	var r labelsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
 		return nil, err
 	}
+	SetToken(s.Token, req)
 
-	rs := r.ToPlatform()
-	return rs, nil
+	return s.doLabels(u, req)
 }
 
-func (s *LabelService) CreateLabel(ctx context.Context, l *plat.Label) error {
+// CreateLabel creates a new label and sets l.ID with the new identifier.
+func (s *LabelService) CreateLabel(ctx context.Context, l *platform.Label) error {
 	if err := l.Validate(); err != nil {
 		return err
 	}
 
-	url, err := newURL(s.Addr, resourceIDPath(s.BasePath, l.ResourceID))
+	u, err := newURL(s.Addr, labelsPath)
 	if err != nil {
 		return err
 	}
@@ -294,52 +457,152 @@ func (s *LabelService) CreateLabel(ctx context.Context, l *plat.Label) error {
 		return err
 	}
 
-	req, err := http.NewRequest("POST", url.String(), bytes.NewReader(octets))
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(octets))
 	if err != nil {
 		return err
 	}
-
 	req.Header.Set("Content-Type", "application/json")
 	SetToken(s.Token, req)
 
-	hc := newClient(url.Scheme, s.InsecureSkipVerify)
+	created, err := s.doLabel(u, req)
+	if err != nil {
+		return err
+	}
 
-	resp, err := hc.Do(req)
+	*l = *created
+	return nil
+}
+
+// CreateLabelMapping applies a label to a resource.
+func (s *LabelService) CreateLabelMapping(ctx context.Context, m *platform.LabelMapping) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	u, err := newURL(s.Addr, resourceLabelsPath(m.ResourceType, m.ResourceID))
 	if err != nil {
 		return err
 	}
 
-	if err := CheckError(resp); err != nil {
+	octets, err := json.Marshal(postLabelMappingRequest{LabelID: m.LabelID})
+	if err != nil {
 		return err
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(l); err != nil {
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(octets))
+	if err != nil {
 		return err
 	}
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
 
-	return nil
+	_, err = s.doLabel(u, req)
+	return err
+}
+
+// UpdateLabel updates a label with a changeset.
+func (s *LabelService) UpdateLabel(ctx context.Context, id platform.ID, upd platform.LabelUpdate) (*platform.Label, error) {
+	u, err := newURL(s.Addr, labelIDPath(id))
+	if err != nil {
+		return nil, err
+	}
+
+	octets, err := json.Marshal(upd)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", u.String(), bytes.NewReader(octets))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	return s.doLabel(u, req)
 }
 
-func (s *LabelService) DeleteLabel(ctx context.Context, l plat.Label) error {
-	url, err := newURL(s.Addr, labelNamePath(s.BasePath, l.ResourceID, l.Name))
+// DeleteLabel deletes a label and removes it from every resource.
+func (s *LabelService) DeleteLabel(ctx context.Context, id platform.ID) error {
+	u, err := newURL(s.Addr, labelIDPath(id))
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("DELETE", url.String(), nil)
+	return s.doDelete(u)
+}
+
+// DeleteLabelMapping removes a label from a resource.
+func (s *LabelService) DeleteLabelMapping(ctx context.Context, m *platform.LabelMapping) error {
+	u, err := newURL(s.Addr, path.Join(resourceLabelsPath(m.ResourceType, m.ResourceID), m.LabelID.String()))
+	if err != nil {
+		return err
+	}
+
+	return s.doDelete(u)
+}
+
+func (s *LabelService) doLabel(u *url.URL, req *http.Request) (*platform.Label, error) {
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp, true); err != nil {
+		return nil, err
+	}
+
+	var lr labelResponse
+	if err := json.NewDecoder(resp.Body).Decode(&lr); err != nil {
+		return nil, err
+	}
+
+	return &lr.Label, nil
+}
+
+func (s *LabelService) doLabels(u *url.URL, req *http.Request) ([]*platform.Label, error) {
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp, true); err != nil {
+		return nil, err
+	}
+
+	var lr labelsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&lr); err != nil {
+		return nil, err
+	}
+
+	return lr.Labels, nil
+}
+
+func (s *LabelService) doDelete(u *url.URL) error {
+	req, err := http.NewRequest("DELETE", u.String(), nil)
 	if err != nil {
 		return err
 	}
 	SetToken(s.Token, req)
 
-	hc := newClient(url.Scheme, s.InsecureSkipVerify)
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
 	resp, err := hc.Do(req)
 	if err != nil {
 		return err
 	}
-	return CheckError(resp)
+	defer resp.Body.Close()
+
+	return CheckError(resp, true)
 }
 
-func labelNamePath(basePath string, resourceID plat.ID, name string) string {
-	return path.Join(basePath, resourceID.String(), "labels", name)
+// labelFilterParam returns the label name that a request filters resources by.
+func labelFilterParam(r *http.Request) *string {
+	if label := r.URL.Query().Get("label"); label != "" {
+		return &label
+	}
+	return nil
 }
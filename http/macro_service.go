@@ -9,6 +9,7 @@ import (
 	"path"
 
 	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
 	kerrors "github.com/influxdata/platform/kit/errors"
 	"github.com/julienschmidt/httprouter"
 )
@@ -21,7 +22,9 @@ const (
 type MacroHandler struct {
 	*httprouter.Router
 
-	MacroService platform.MacroService
+	MacroService           platform.MacroService
+	MacroEvaluationService platform.MacroEvaluationService
+	OrganizationService    platform.OrganizationService
 }
 
 // NewMacroHandler creates a new MacroHandler
@@ -36,6 +39,7 @@ func NewMacroHandler() *MacroHandler {
 	h.HandlerFunc("PATCH", "/api/v2/macros/:id", h.handlePatchMacro)
 	h.HandlerFunc("PUT", "/api/v2/macros/:id", h.handlePutMacro)
 	h.HandlerFunc("DELETE", "/api/v2/macros/:id", h.handleDeleteMacro)
+	h.HandlerFunc("GET", "/api/v2/macros/:id/values", h.handleGetMacroValues)
 
 	return h
 }
@@ -318,6 +322,93 @@ func (h *MacroHandler) handleDeleteMacro(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusNoContent)
 }
 
+type macroValuesResponse struct {
+	Values []string          `json:"values"`
+	Links  map[string]string `json:"links"`
+}
+
+func newMacroValuesResponse(id platform.ID, values []string) macroValuesResponse {
+	return macroValuesResponse{
+		Values: values,
+		Links: map[string]string{
+			"self":  fmt.Sprintf("/api/v2/macros/%s/values", id),
+			"macro": fmt.Sprintf("/api/v2/macros/%s", id),
+		},
+	}
+}
+
+// handleGetMacroValues evaluates a macro and returns the values it can take on.
+// Query macros are run in the organization given by the organization or
+// organizationID parameter under the caller's authorization. Any other query
+// parameter binds a value to the macro of the same name.
+func (h *MacroHandler) handleGetMacroValues(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	req, err := decodeGetMacroValuesRequest(ctx, r, h.OrganizationService)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if auth, ok := a.(*platform.Authorization); ok {
+		req.opts.Authorization = auth
+	}
+
+	values, err := h.MacroEvaluationService.EvaluateMacro(ctx, req.id, req.opts)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newMacroValuesResponse(req.id, values)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+type getMacroValuesRequest struct {
+	id   platform.ID
+	opts platform.MacroEvaluationOptions
+}
+
+func decodeGetMacroValuesRequest(ctx context.Context, r *http.Request, svc platform.OrganizationService) (*getMacroValuesRequest, error) {
+	id, err := requestMacroID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &getMacroValuesRequest{
+		id: id,
+		opts: platform.MacroEvaluationOptions{
+			Bindings: map[string]string{},
+		},
+	}
+
+	qp := r.URL.Query()
+	for k := range qp {
+		if k == OrgID || k == OrgName {
+			continue
+		}
+		req.opts.Bindings[k] = qp.Get(k)
+	}
+
+	if qp.Get(OrgID) != "" || qp.Get(OrgName) != "" {
+		o, err := queryOrganization(ctx, r, svc)
+		if err != nil {
+			return nil, err
+		}
+		req.opts.OrganizationID = o.ID
+	}
+
+	return req, nil
+}
+
 // MacroService is a macro service over HTTP to the influxdb server
 type MacroService struct {
 	Addr               string
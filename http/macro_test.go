@@ -5,9 +5,11 @@ import (
 	"context"
 	"io/ioutil"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
 	"github.com/influxdata/platform/inmem"
 	kerrors "github.com/influxdata/platform/kit/errors"
 	"github.com/influxdata/platform/mock"
@@ -541,3 +543,62 @@ func initMacroService(f platformtesting.MacroFields, t *testing.T) (platform.Mac
 func TestMacroService(t *testing.T) {
 	platformtesting.MacroService(initMacroService, t)
 }
+
+func TestMacroService_handleGetMacroValues(t *testing.T) {
+	orgID := platformtesting.MustIDBase16("020f755c3c082000")
+	authorization := &platform.Authorization{UserID: platformtesting.MustIDBase16("020f755c3c082001")}
+
+	h := NewMacroHandler()
+	h.OrganizationService = &mock.OrganizationService{
+		FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+			if filter.Name == nil || *filter.Name != "org" {
+				return nil, &platform.Error{Code: platform.ENotFound, Msg: "organization not found"}
+			}
+			return &platform.Organization{ID: orgID, Name: "org"}, nil
+		},
+	}
+	h.MacroEvaluationService = &mock.MacroEvaluationService{
+		EvaluateMacroF: func(ctx context.Context, id platform.ID, opts platform.MacroEvaluationOptions) ([]string, error) {
+			if id != platformtesting.MustIDBase16("75650d0a636f6d70") {
+				t.Errorf("unexpected macro id %v", id)
+			}
+			if opts.OrganizationID != orgID {
+				t.Errorf("unexpected organization id %v", opts.OrganizationID)
+			}
+			if opts.Authorization != authorization {
+				t.Errorf("unexpected authorization %v", opts.Authorization)
+			}
+			if want := map[string]string{"bucket": "telegraf"}; !reflect.DeepEqual(opts.Bindings, want) {
+				t.Errorf("bindings = %v, want %v", opts.Bindings, want)
+			}
+			return []string{"a", "b"}, nil
+		},
+	}
+
+	r := httptest.NewRequest("GET", "http://howdy.tld/api/v2/macros/75650d0a636f6d70/values?organization=org&bucket=telegraf", nil)
+	ctx := context.WithValue(
+		pcontext.SetAuthorizer(r.Context(), authorization),
+		httprouter.ParamsKey,
+		httprouter.Params{
+			{
+				Key:   "id",
+				Value: "75650d0a636f6d70",
+			},
+		})
+	r = r.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	h.handleGetMacroValues(w, r)
+
+	res := w.Result()
+	body, _ := ioutil.ReadAll(res.Body)
+
+	if res.StatusCode != 200 {
+		t.Errorf("got %v, want %v", res.StatusCode, 200)
+	}
+	want := `{"values":["a","b"],"links":{"macro":"/api/v2/macros/75650d0a636f6d70","self":"/api/v2/macros/75650d0a636f6d70/values"}}
+`
+	if string(body) != want {
+		t.Errorf("got = %v, want %v", string(body), want)
+	}
+}
@@ -0,0 +1,183 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/platform"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	notificationEndpointsPath = "/api/v2/notificationEndpoints"
+)
+
+// NotificationEndpointHandler is the handler for the notification endpoint service
+type NotificationEndpointHandler struct {
+	*httprouter.Router
+
+	NotificationEndpointService platform.NotificationEndpointService
+}
+
+// NewNotificationEndpointHandler creates a new NotificationEndpointHandler
+func NewNotificationEndpointHandler() *NotificationEndpointHandler {
+	h := &NotificationEndpointHandler{
+		Router: httprouter.New(),
+	}
+
+	h.HandlerFunc("GET", notificationEndpointsPath, h.handleGetNotificationEndpoints)
+	h.HandlerFunc("POST", notificationEndpointsPath, h.handlePostNotificationEndpoint)
+	h.HandlerFunc("GET", notificationEndpointsPath+"/:id", h.handleGetNotificationEndpoint)
+	h.HandlerFunc("PATCH", notificationEndpointsPath+"/:id", h.handlePatchNotificationEndpoint)
+	h.HandlerFunc("DELETE", notificationEndpointsPath+"/:id", h.handleDeleteNotificationEndpoint)
+
+	return h
+}
+
+// notificationEndpointResponse never includes the endpoint's secrets.
+type notificationEndpointResponse struct {
+	platform.NotificationEndpoint
+	Links map[string]string `json:"links"`
+}
+
+func newNotificationEndpointResponse(e *platform.NotificationEndpoint) notificationEndpointResponse {
+	return notificationEndpointResponse{
+		NotificationEndpoint: e.Redacted(),
+		Links: map[string]string{
+			"self": fmt.Sprintf("%s/%s", notificationEndpointsPath, e.ID),
+		},
+	}
+}
+
+type notificationEndpointsResponse struct {
+	NotificationEndpoints []notificationEndpointResponse `json:"notificationEndpoints"`
+	Links                 map[string]string              `json:"links"`
+}
+
+func newNotificationEndpointsResponse(es []*platform.NotificationEndpoint) notificationEndpointsResponse {
+	resp := notificationEndpointsResponse{
+		NotificationEndpoints: make([]notificationEndpointResponse, 0, len(es)),
+		Links: map[string]string{
+			"self": notificationEndpointsPath,
+		},
+	}
+	for _, e := range es {
+		resp.NotificationEndpoints = append(resp.NotificationEndpoints, newNotificationEndpointResponse(e))
+	}
+	return resp
+}
+
+func (h *NotificationEndpointHandler) handleGetNotificationEndpoints(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := requestOrgIDFilter(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	es, err := h.NotificationEndpointService.FindNotificationEndpoints(ctx, platform.NotificationEndpointFilter{OrganizationID: orgID})
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationEndpointsResponse(es)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *NotificationEndpointHandler) handlePostNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	e := &platform.NotificationEndpoint{}
+	if err := json.NewDecoder(r.Body).Decode(e); err != nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid json structure",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	if err := h.NotificationEndpointService.CreateNotificationEndpoint(ctx, e); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newNotificationEndpointResponse(e)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *NotificationEndpointHandler) handleGetNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	e, err := h.NotificationEndpointService.FindNotificationEndpointByID(ctx, id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationEndpointResponse(e)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *NotificationEndpointHandler) handlePatchNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	var upd platform.NotificationEndpointUpdate
+	if err := json.NewDecoder(r.Body).Decode(&upd); err != nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid json structure",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	e, err := h.NotificationEndpointService.UpdateNotificationEndpoint(ctx, id, upd)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationEndpointResponse(e)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *NotificationEndpointHandler) handleDeleteNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.NotificationEndpointService.DeleteNotificationEndpoint(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func TestNotificationEndpointHandler(t *testing.T) {
+	endpoint := &platform.NotificationEndpoint{
+		ID:             platformtesting.MustIDBase16("020f755c3c082000"),
+		OrganizationID: platformtesting.MustIDBase16("020f755c3c082001"),
+		Name:           "email",
+		Type:           platform.SMTPEndpoint,
+		SMTP: &platform.SMTPConfig{
+			Host:     "smtp.example.com",
+			Port:     587,
+			Username: "alerts",
+			Password: "hunter2",
+			From:     "alerts@example.com",
+			To:       []string{"ops@example.com"},
+		},
+	}
+	// Passwords and tokens are never returned.
+	endpointJSON := `{"id":"020f755c3c082000","orgID":"020f755c3c082001","name":"email","type":"smtp","smtp":{"host":"smtp.example.com","port":587,"username":"alerts","from":"alerts@example.com","to":["ops@example.com"]},"links":{"self":"/api/v2/notificationEndpoints/020f755c3c082000"}}`
+
+	es := mock.NewNotificationEndpointService()
+	es.FindNotificationEndpointsFn = func(ctx context.Context, filter platform.NotificationEndpointFilter) ([]*platform.NotificationEndpoint, error) {
+		return []*platform.NotificationEndpoint{endpoint}, nil
+	}
+	es.FindNotificationEndpointByIDFn = func(ctx context.Context, id platform.ID) (*platform.NotificationEndpoint, error) {
+		return endpoint, nil
+	}
+	es.UpdateNotificationEndpointFn = func(ctx context.Context, id platform.ID, upd platform.NotificationEndpointUpdate) (*platform.NotificationEndpoint, error) {
+		if upd.Name == nil || *upd.Name != "email" {
+			t.Errorf("unexpected update %+v", upd)
+		}
+		return endpoint, nil
+	}
+	es.DeleteNotificationEndpointFn = func(ctx context.Context, id platform.ID) error {
+		if id != endpoint.ID {
+			return &platform.Error{Code: platform.ENotFound, Msg: "notification endpoint not found"}
+		}
+		return nil
+	}
+
+	h := NewNotificationEndpointHandler()
+	h.NotificationEndpointService = es
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "find notification endpoints",
+			method:     "GET",
+			path:       "/api/v2/notificationEndpoints",
+			wantStatus: 200,
+			wantBody:   `{"notificationEndpoints":[` + endpointJSON + `],"links":{"self":"/api/v2/notificationEndpoints"}}`,
+		},
+		{
+			name:       "find a notification endpoint",
+			method:     "GET",
+			path:       "/api/v2/notificationEndpoints/020f755c3c082000",
+			wantStatus: 200,
+			wantBody:   endpointJSON,
+		},
+		{
+			name:       "find notification endpoints with an invalid orgID",
+			method:     "GET",
+			path:       "/api/v2/notificationEndpoints?orgID=nope",
+			wantStatus: 400,
+		},
+		{
+			name:       "update a notification endpoint",
+			method:     "PATCH",
+			path:       "/api/v2/notificationEndpoints/020f755c3c082000",
+			body:       `{"name":"email"}`,
+			wantStatus: 200,
+			wantBody:   endpointJSON,
+		},
+		{
+			name:       "delete a missing notification endpoint",
+			method:     "DELETE",
+			path:       "/api/v2/notificationEndpoints/020f755c3c082003",
+			wantStatus: 404,
+		},
+		{
+			name:       "delete a notification endpoint",
+			method:     "DELETE",
+			path:       "/api/v2/notificationEndpoints/020f755c3c082000",
+			wantStatus: 204,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, res.StatusCode, body)
+			}
+			if tt.wantBody != "" {
+				if eq, _ := jsonEqual(string(body), tt.wantBody); !eq {
+					t.Errorf("unexpected body:\n%s\nwant:\n%s", body, tt.wantBody)
+				}
+			}
+		})
+	}
+}
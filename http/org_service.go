@@ -38,7 +38,7 @@ const (
 	// TODO(desa): need a way to specify which secrets to delete. this should work for now
 	organizationsIDSecretsDeletePath = "/api/v2/orgs/:id/secrets/delete"
 	organizationsIDLabelsPath        = "/api/v2/orgs/:id/labels"
-	organizationsIDLabelsNamePath    = "/api/v2/orgs/:id/labels/:name"
+	organizationsIDLabelsIDPath      = "/api/v2/orgs/:id/labels/:lid"
 )
 
 // NewOrgHandler returns a new instance of OrgHandler.
@@ -70,9 +70,9 @@ func NewOrgHandler(mappingService platform.UserResourceMappingService,
 	// TODO(desa): need a way to specify which secrets to delete. this should work for now
 	h.HandlerFunc("POST", organizationsIDSecretsDeletePath, h.handleDeleteSecrets)
 
-	h.HandlerFunc("GET", organizationsIDLabelsPath, newGetLabelsHandler(h.LabelService))
-	h.HandlerFunc("POST", organizationsIDLabelsPath, newPostLabelHandler(h.LabelService))
-	h.HandlerFunc("DELETE", organizationsIDLabelsNamePath, newDeleteLabelHandler(h.LabelService))
+	h.HandlerFunc("GET", organizationsIDLabelsPath, newGetLabelsHandler(h.LabelService, platform.OrgResourceType))
+	h.HandlerFunc("POST", organizationsIDLabelsPath, newPostLabelHandler(h.LabelService, platform.OrgResourceType))
+	h.HandlerFunc("DELETE", organizationsIDLabelsIDPath, newDeleteLabelHandler(h.LabelService, platform.OrgResourceType))
 
 	return h
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/influxdata/flux/values"
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/kit/errors"
+	"github.com/influxdata/platform/macros"
 	"github.com/influxdata/platform/query"
 )
 
@@ -27,6 +28,10 @@ type QueryRequest struct {
 	Type    string       `json:"type"`
 	Dialect QueryDialect `json:"dialect"`
 
+	// Macros binds values to the macros referenced by Query. When set, every
+	// macro referenced by Query is available to it as a member of the v record.
+	Macros map[string]string `json:"macros,omitempty"`
+
 	Org *platform.Organization `json:"-"`
 }
 
@@ -67,6 +72,10 @@ func (r QueryRequest) Validate() error {
 		return fmt.Errorf(`unknown query type: %s`, r.Type)
 	}
 
+	if r.Macros != nil && r.Query == "" {
+		return errors.New(`macros can only be bound to a query`)
+	}
+
 	if len(r.Dialect.CommentPrefix) > 1 {
 		return fmt.Errorf("invalid dialect comment prefix: must be length 0 or 1")
 	}
@@ -135,8 +144,15 @@ func (r QueryRequest) proxyRequest(now func() time.Time) (*query.ProxyRequest, e
 	// Query is preferred over spec
 	var compiler flux.Compiler
 	if r.Query != "" {
+		q := r.Query
+		if r.Macros != nil {
+			var err error
+			if q, err = macros.BindFlux(q, r.Macros); err != nil {
+				return nil, err
+			}
+		}
 		compiler = lang.FluxCompiler{
-			Query: r.Query,
+			Query: q,
 		}
 	} else if r.AST != nil {
 		var err error
@@ -228,6 +244,35 @@ func decodeProxyQueryRequest(ctx context.Context, r *http.Request, auth platform
 		return nil, err
 	}
 
+	return newProxyQueryRequest(req, auth)
+}
+
+// bindQueryMacros binds a value to every macro that the request's query
+// references but that the request did not bind. Requests that do not bind
+// any macros are left untouched.
+func bindQueryMacros(ctx context.Context, req *QueryRequest, auth platform.Authorizer, svc platform.MacroEvaluationService) error {
+	if req.Macros == nil || svc == nil {
+		return nil
+	}
+
+	opts := platform.MacroEvaluationOptions{
+		OrganizationID: req.Org.ID,
+		Bindings:       req.Macros,
+	}
+	if a, ok := auth.(*platform.Authorization); ok {
+		opts.Authorization = a
+	}
+
+	bindings, err := svc.BindMacros(ctx, req.Query, opts)
+	if err != nil {
+		return err
+	}
+
+	req.Macros = bindings
+	return nil
+}
+
+func newProxyQueryRequest(req *QueryRequest, auth platform.Authorizer) (*query.ProxyRequest, error) {
 	pr, err := req.ProxyRequest()
 	if err != nil {
 		return nil, err
@@ -35,9 +35,10 @@ type FluxHandler struct {
 
 	Logger *zap.Logger
 
-	Now                 func() time.Time
-	OrganizationService platform.OrganizationService
-	ProxyQueryService   query.ProxyQueryService
+	Now                    func() time.Time
+	OrganizationService    platform.OrganizationService
+	MacroEvaluationService platform.MacroEvaluationService
+	ProxyQueryService      query.ProxyQueryService
 }
 
 // NewFluxHandler returns a new handler at /api/v2/query for flux queries.
@@ -66,7 +67,18 @@ func (h *FluxHandler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	req, err := decodeProxyQueryRequest(ctx, r, a, h.OrganizationService)
+	qr, err := decodeQueryRequest(ctx, r, h.OrganizationService)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := bindQueryMacros(ctx, qr, a, h.MacroEvaluationService); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	req, err := newProxyQueryRequest(qr, a)
 	if err != nil && err != platform.ErrAuthorizerNotSupported {
 		EncodeError(ctx, err, w)
 		return
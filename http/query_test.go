@@ -235,6 +235,7 @@ func TestQueryRequest_proxyRequest(t *testing.T) {
 		Query   string
 		Type    string
 		Dialect QueryDialect
+		Macros  map[string]string
 		org     *platform.Organization
 	}
 	tests := []struct {
@@ -304,6 +305,32 @@ func TestQueryRequest_proxyRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "query with macros",
+			fields: fields{
+				Query: "from(bucket: v.bucket)",
+				Type:  "flux",
+				Dialect: QueryDialect{
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+				},
+				Macros: map[string]string{"bucket": "telegraf"},
+				org:    &platform.Organization{},
+			},
+			want: &query.ProxyRequest{
+				Request: query.Request{
+					Compiler: lang.FluxCompiler{
+						Query: "v = {bucket: \"telegraf\"}\nfrom(bucket: v.bucket)",
+					},
+				},
+				Dialect: &csv.Dialect{
+					ResultEncoderConfig: csv.ResultEncoderConfig{
+						NoHeader:  false,
+						Delimiter: ',',
+					},
+				},
+			},
+		},
 		{
 			name: "valid spec",
 			fields: fields{
@@ -342,6 +369,7 @@ func TestQueryRequest_proxyRequest(t *testing.T) {
 				Query:   tt.fields.Query,
 				Type:    tt.fields.Type,
 				Dialect: tt.fields.Dialect,
+				Macros:  tt.fields.Macros,
 				Org:     tt.fields.org,
 			}
 			got, err := r.proxyRequest(tt.now)
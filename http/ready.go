@@ -11,18 +11,46 @@ import (
 
 var up = time.Now()
 
+// A ReadyCheck returns the reason a component of the service is degraded, or
+// an empty string if it is not.
+type ReadyCheck func() string
+
 // ReadyHandler is a default readiness handler. The default behaviour is always ready.
 func ReadyHandler(w http.ResponseWriter, r *http.Request) {
+	serveReady(w, nil)
+}
+
+// NewReadyHandler returns a readiness handler reporting the service as
+// degraded, along with the reasons, while any of checks reports a reason.
+// A degraded service is still ready to serve requests.
+func NewReadyHandler(checks ...ReadyCheck) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reasons []string
+		for _, check := range checks {
+			if reason := check(); reason != "" {
+				reasons = append(reasons, reason)
+			}
+		}
+		serveReady(w, reasons)
+	})
+}
+
+func serveReady(w http.ResponseWriter, degraded []string) {
 	w.WriteHeader(http.StatusOK)
 
 	var status = struct {
-		Status string        `json:"status"`
-		Start  time.Time     `json:"started"`
-		Up     toml.Duration `json:"up"`
+		Status   string        `json:"status"`
+		Start    time.Time     `json:"started"`
+		Up       toml.Duration `json:"up"`
+		Degraded []string      `json:"degraded,omitempty"`
 	}{
-		Status: "ready",
-		Start:  up,
-		Up:     toml.Duration(time.Since(up)),
+		Status:   "ready",
+		Start:    up,
+		Up:       toml.Duration(time.Since(up)),
+		Degraded: degraded,
+	}
+	if len(degraded) > 0 {
+		status.Status = "degraded"
 	}
 
 	enc := json.NewEncoder(w)
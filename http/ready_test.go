@@ -0,0 +1,43 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNewReadyHandler(t *testing.T) {
+	var reason string
+	h := NewReadyHandler(
+		func() string { return reason },
+		func() string { return "" },
+	)
+
+	serve := func() (status string, degraded []string) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", ReadyPath, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("unexpected status code %d", w.Code)
+		}
+
+		var body struct {
+			Status   string   `json:"status"`
+			Degraded []string `json:"degraded"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		return body.Status, body.Degraded
+	}
+
+	if status, degraded := serve(); status != "ready" || degraded != nil {
+		t.Errorf("got status %q, degraded %v, want ready", status, degraded)
+	}
+
+	reason = "cache is full"
+	if status, degraded := serve(); status != "degraded" || !reflect.DeepEqual(degraded, []string{reason}) {
+		t.Errorf("got status %q, degraded %v, want degraded by %q", status, degraded, reason)
+	}
+}
@@ -0,0 +1,183 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/platform"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	replicationsPath = "/api/v2/replications"
+)
+
+// ReplicationRuleHandler is the handler for the replication rule service
+type ReplicationRuleHandler struct {
+	*httprouter.Router
+
+	ReplicationRuleService platform.ReplicationRuleService
+}
+
+// NewReplicationRuleHandler creates a new ReplicationRuleHandler
+func NewReplicationRuleHandler() *ReplicationRuleHandler {
+	h := &ReplicationRuleHandler{
+		Router: httprouter.New(),
+	}
+
+	h.HandlerFunc("GET", replicationsPath, h.handleGetReplicationRules)
+	h.HandlerFunc("POST", replicationsPath, h.handlePostReplicationRule)
+	h.HandlerFunc("GET", replicationsPath+"/:id", h.handleGetReplicationRule)
+	h.HandlerFunc("PATCH", replicationsPath+"/:id", h.handlePatchReplicationRule)
+	h.HandlerFunc("DELETE", replicationsPath+"/:id", h.handleDeleteReplicationRule)
+
+	return h
+}
+
+// replicationRuleResponse never includes the remote token of the rule.
+type replicationRuleResponse struct {
+	platform.ReplicationRule
+	Links map[string]string `json:"links"`
+}
+
+func newReplicationRuleResponse(r *platform.ReplicationRule) replicationRuleResponse {
+	return replicationRuleResponse{
+		ReplicationRule: r.Redacted(),
+		Links: map[string]string{
+			"self": fmt.Sprintf("%s/%s", replicationsPath, r.ID),
+		},
+	}
+}
+
+type replicationRulesResponse struct {
+	ReplicationRules []replicationRuleResponse `json:"replications"`
+	Links            map[string]string         `json:"links"`
+}
+
+func newReplicationRulesResponse(rs []*platform.ReplicationRule) replicationRulesResponse {
+	resp := replicationRulesResponse{
+		ReplicationRules: make([]replicationRuleResponse, 0, len(rs)),
+		Links: map[string]string{
+			"self": replicationsPath,
+		},
+	}
+	for _, r := range rs {
+		resp.ReplicationRules = append(resp.ReplicationRules, newReplicationRuleResponse(r))
+	}
+	return resp
+}
+
+func (h *ReplicationRuleHandler) handleGetReplicationRules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := requestOrgIDFilter(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	rs, err := h.ReplicationRuleService.FindReplicationRules(ctx, platform.ReplicationRuleFilter{OrganizationID: orgID})
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newReplicationRulesResponse(rs)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *ReplicationRuleHandler) handlePostReplicationRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rule := &platform.ReplicationRule{}
+	if err := json.NewDecoder(r.Body).Decode(rule); err != nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid json structure",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	if err := h.ReplicationRuleService.CreateReplicationRule(ctx, rule); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newReplicationRuleResponse(rule)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *ReplicationRuleHandler) handleGetReplicationRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	rule, err := h.ReplicationRuleService.FindReplicationRuleByID(ctx, id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newReplicationRuleResponse(rule)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *ReplicationRuleHandler) handlePatchReplicationRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	var upd platform.ReplicationRuleUpdate
+	if err := json.NewDecoder(r.Body).Decode(&upd); err != nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid json structure",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	rule, err := h.ReplicationRuleService.UpdateReplicationRule(ctx, id, upd)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newReplicationRuleResponse(rule)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *ReplicationRuleHandler) handleDeleteReplicationRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.ReplicationRuleService.DeleteReplicationRule(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
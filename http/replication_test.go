@@ -0,0 +1,129 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func TestReplicationRuleHandler(t *testing.T) {
+	rule := &platform.ReplicationRule{
+		ID:             platformtesting.MustIDBase16("020f755c3c082000"),
+		OrganizationID: platformtesting.MustIDBase16("020f755c3c082001"),
+		BucketID:       platformtesting.MustIDBase16("020f755c3c082002"),
+		Name:           "standby",
+		RemoteURL:      "http://standby:9999",
+		RemoteToken:    "secret",
+		RemoteOrgID:    platformtesting.MustIDBase16("020f755c3c082010"),
+		RemoteBucketID: platformtesting.MustIDBase16("020f755c3c082020"),
+	}
+	// Remote tokens are never returned.
+	ruleJSON := `{"id":"020f755c3c082000","orgID":"020f755c3c082001","bucketID":"020f755c3c082002","name":"standby","remoteURL":"http://standby:9999","remoteOrgID":"020f755c3c082010","remoteBucketID":"020f755c3c082020","links":{"self":"/api/v2/replications/020f755c3c082000"}}`
+
+	rs := mock.NewReplicationRuleService()
+	rs.FindReplicationRulesFn = func(ctx context.Context, filter platform.ReplicationRuleFilter) ([]*platform.ReplicationRule, error) {
+		return []*platform.ReplicationRule{rule}, nil
+	}
+	rs.FindReplicationRuleByIDFn = func(ctx context.Context, id platform.ID) (*platform.ReplicationRule, error) {
+		return rule, nil
+	}
+	rs.CreateReplicationRuleFn = func(ctx context.Context, r *platform.ReplicationRule) error {
+		if r.RemoteToken != "secret" {
+			t.Errorf("got remote token %q, expected %q", r.RemoteToken, "secret")
+		}
+		r.ID = rule.ID
+		return nil
+	}
+	rs.UpdateReplicationRuleFn = func(ctx context.Context, id platform.ID, upd platform.ReplicationRuleUpdate) (*platform.ReplicationRule, error) {
+		if upd.RemoteURL == nil || *upd.RemoteURL != "http://standby:9999" {
+			t.Errorf("unexpected update %+v", upd)
+		}
+		return rule, nil
+	}
+	rs.DeleteReplicationRuleFn = func(ctx context.Context, id platform.ID) error {
+		if id != rule.ID {
+			return &platform.Error{Code: platform.ENotFound, Msg: "replication rule not found"}
+		}
+		return nil
+	}
+
+	h := NewReplicationRuleHandler()
+	h.ReplicationRuleService = rs
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "find replication rules",
+			method:     "GET",
+			path:       "/api/v2/replications",
+			wantStatus: 200,
+			wantBody:   `{"replications":[` + ruleJSON + `],"links":{"self":"/api/v2/replications"}}`,
+		},
+		{
+			name:       "find a replication rule",
+			method:     "GET",
+			path:       "/api/v2/replications/020f755c3c082000",
+			wantStatus: 200,
+			wantBody:   ruleJSON,
+		},
+		{
+			name:       "create a replication rule",
+			method:     "POST",
+			path:       "/api/v2/replications",
+			body:       `{"orgID":"020f755c3c082001","bucketID":"020f755c3c082002","name":"standby","remoteURL":"http://standby:9999","remoteToken":"secret","remoteOrgID":"020f755c3c082010","remoteBucketID":"020f755c3c082020"}`,
+			wantStatus: 201,
+			wantBody:   ruleJSON,
+		},
+		{
+			name:       "update a replication rule",
+			method:     "PATCH",
+			path:       "/api/v2/replications/020f755c3c082000",
+			body:       `{"remoteURL":"http://standby:9999"}`,
+			wantStatus: 200,
+			wantBody:   ruleJSON,
+		},
+		{
+			name:       "delete a missing replication rule",
+			method:     "DELETE",
+			path:       "/api/v2/replications/020f755c3c082003",
+			wantStatus: 404,
+		},
+		{
+			name:       "delete a replication rule",
+			method:     "DELETE",
+			path:       "/api/v2/replications/020f755c3c082000",
+			wantStatus: 204,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, res.StatusCode, body)
+			}
+			if tt.wantBody != "" {
+				if eq, _ := jsonEqual(string(body), tt.wantBody); !eq {
+					t.Errorf("unexpected body:\n%s\nwant:\n%s", body, tt.wantBody)
+				}
+			}
+		})
+	}
+}
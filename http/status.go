@@ -44,3 +44,10 @@ func (w *statusResponseWriter) statusCodeClass() string {
 	}
 	return class
 }
+
+// Flush sends any buffered data to the client, if the underlying ResponseWriter supports it.
+func (w *statusResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -1,15 +1,18 @@
 package http
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/influxdata/platform"
@@ -30,12 +33,19 @@ type TaskHandler struct {
 	OrganizationService        platform.OrganizationService
 	UserResourceMappingService platform.UserResourceMappingService
 	LabelService               platform.LabelService
+
+	// RunEventService, when set, lets clients follow task logs and run events as they happen.
+	RunEventService platform.RunEventService
+
+	TaskValidationService platform.TaskValidationService
 }
 
 const (
 	tasksPath              = "/api/v2/tasks"
+	tasksValidatePath      = "/api/v2/tasks/validate"
 	tasksIDPath            = "/api/v2/tasks/:tid"
 	tasksIDLogsPath        = "/api/v2/tasks/:tid/logs"
+	tasksIDEventsPath      = "/api/v2/tasks/:tid/events"
 	tasksIDMembersPath     = "/api/v2/tasks/:tid/members"
 	tasksIDMembersIDPath   = "/api/v2/tasks/:tid/members/:userID"
 	tasksIDOwnersPath      = "/api/v2/tasks/:tid/owners"
@@ -45,7 +55,10 @@ const (
 	tasksIDRunsIDLogsPath  = "/api/v2/tasks/:tid/runs/:rid/logs"
 	tasksIDRunsIDRetryPath = "/api/v2/tasks/:tid/runs/:rid/retry"
 	tasksIDLabelsPath      = "/api/v2/tasks/:tid/labels"
-	tasksIDLabelsNamePath  = "/api/v2/tasks/:tid/labels/:name"
+	tasksIDLabelsIDPath    = "/api/v2/tasks/:tid/labels/:lid"
+
+	tasksIDVersionsPath         = "/api/v2/tasks/:tid/versions"
+	tasksIDVersionsRollbackPath = "/api/v2/tasks/:tid/versions/:version/rollback"
 )
 
 // NewTaskHandler returns a new instance of TaskHandler.
@@ -67,6 +80,7 @@ func NewTaskHandler(mappingService platform.UserResourceMappingService, labelSer
 
 	h.HandlerFunc("GET", tasksIDLogsPath, h.handleGetLogs)
 	h.HandlerFunc("GET", tasksIDRunsIDLogsPath, h.handleGetLogs)
+	h.HandlerFunc("GET", tasksIDEventsPath, h.handleGetRunEvents)
 
 	h.HandlerFunc("POST", tasksIDMembersPath, newPostMemberHandler(h.UserResourceMappingService, platform.TaskResourceType, platform.Member))
 	h.HandlerFunc("GET", tasksIDMembersPath, newGetMembersHandler(h.UserResourceMappingService, platform.Member))
@@ -77,17 +91,36 @@ func NewTaskHandler(mappingService platform.UserResourceMappingService, labelSer
 	h.HandlerFunc("DELETE", tasksIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
 
 	h.HandlerFunc("GET", tasksIDRunsPath, h.handleGetRuns)
+	h.HandlerFunc("POST", tasksIDRunsPath, h.handleForceRun)
 	h.HandlerFunc("GET", tasksIDRunsIDPath, h.handleGetRun)
 	h.HandlerFunc("POST", tasksIDRunsIDRetryPath, h.handleRetryRun)
 	h.HandlerFunc("DELETE", tasksIDRunsIDPath, h.handleCancelRun)
 
-	h.HandlerFunc("GET", tasksIDLabelsPath, newGetLabelsHandler(h.LabelService))
-	h.HandlerFunc("POST", tasksIDLabelsPath, newPostLabelHandler(h.LabelService))
-	h.HandlerFunc("DELETE", tasksIDLabelsNamePath, newDeleteLabelHandler(h.LabelService))
+	h.HandlerFunc("GET", tasksIDVersionsPath, h.handleGetTaskVersions)
+	h.HandlerFunc("POST", tasksIDVersionsRollbackPath, h.handleRollbackTask)
+
+	h.HandlerFunc("GET", tasksIDLabelsPath, newGetLabelsHandler(h.LabelService, platform.TaskResourceType))
+	h.HandlerFunc("POST", tasksIDLabelsPath, newPostLabelHandler(h.LabelService, platform.TaskResourceType))
+	h.HandlerFunc("DELETE", tasksIDLabelsIDPath, newDeleteLabelHandler(h.LabelService, platform.TaskResourceType))
 
 	return h
 }
 
+// ServeHTTP serves the validate endpoint, whose path the router cannot tell apart from a task ID,
+// and routes every other request.
+func (h *TaskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == tasksValidatePath {
+		if r.Method != "POST" {
+			w.Header().Set("Allow", "POST")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		h.handleValidateTask(w, r)
+		return
+	}
+	h.Router.ServeHTTP(w, r)
+}
+
 type taskResponse struct {
 	Links map[string]string `json:"links"`
 	platform.Task
@@ -186,6 +219,41 @@ func newRunsResponse(rs []*platform.Run, taskID platform.ID) runsResponse {
 	return r
 }
 
+type taskVersionResponse struct {
+	Links map[string]string `json:"links"`
+	platform.TaskVersion
+}
+
+func newTaskVersionResponse(v platform.TaskVersion) taskVersionResponse {
+	return taskVersionResponse{
+		Links: map[string]string{
+			"task":     fmt.Sprintf("/api/v2/tasks/%s", v.TaskID),
+			"rollback": fmt.Sprintf("/api/v2/tasks/%s/versions/%d/rollback", v.TaskID, v.Version),
+		},
+		TaskVersion: v,
+	}
+}
+
+type taskVersionsResponse struct {
+	Links    map[string]string     `json:"links"`
+	Versions []taskVersionResponse `json:"versions"`
+}
+
+func newTaskVersionsResponse(vs []*platform.TaskVersion, taskID platform.ID) taskVersionsResponse {
+	r := taskVersionsResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/versions", taskID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", taskID),
+		},
+		Versions: make([]taskVersionResponse, len(vs)),
+	}
+
+	for i := range vs {
+		r.Versions[i] = newTaskVersionResponse(*vs[i])
+	}
+	return r
+}
+
 func (h *TaskHandler) handleGetTasks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -239,6 +307,8 @@ func decodeGetTasksRequest(ctx context.Context, r *http.Request) (*getTasksReque
 		req.filter.User = id
 	}
 
+	req.filter.Label = labelFilterParam(r)
+
 	if limit := qp.Get("limit"); limit != "" {
 		lim, err := strconv.Atoi(limit)
 		if err != nil {
@@ -452,6 +522,11 @@ func (h *TaskHandler) handleGetLogs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.follow {
+		h.followLogs(ctx, w, req.filter)
+		return
+	}
+
 	logs, _, err := h.TaskService.FindLogs(ctx, req.filter)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -466,6 +541,7 @@ func (h *TaskHandler) handleGetLogs(w http.ResponseWriter, r *http.Request) {
 
 type getLogsRequest struct {
 	filter platform.LogFilter
+	follow bool
 }
 
 func decodeGetLogsRequest(ctx context.Context, r *http.Request, orgs platform.OrganizationService) (*getLogsRequest, error) {
@@ -507,9 +583,208 @@ func decodeGetLogsRequest(ctx context.Context, r *http.Request, orgs platform.Or
 		req.filter.Run = id
 	}
 
+	if follow := qp.Get("follow"); follow != "" {
+		f, err := strconv.ParseBool(follow)
+		if err != nil {
+			return nil, kerrors.InvalidDataf("follow must be a boolean")
+		}
+		req.follow = f
+	}
+
+	return req, nil
+}
+
+// runEventKeepAliveInterval is how often a comment is sent on an idle run event stream,
+// so that proxies and clients do not time out the connection.
+const runEventKeepAliveInterval = 15 * time.Second
+
+var errRunEventsUnavailable = &platform.Error{
+	Code: platform.EUnavailable,
+	Msg:  "following task runs is not supported by this server",
+}
+
+// followLogs writes the logs already recorded for the task or run, followed by its run events as they happen,
+// as Server-Sent Events. When following a single run, the stream ends once the run has finished.
+func (h *TaskHandler) followLogs(ctx context.Context, w http.ResponseWriter, filter platform.LogFilter) {
+	if h.RunEventService == nil {
+		EncodeError(ctx, errRunEventsUnavailable, w)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Subscribe before reading the existing logs, so that nothing written in between is missed.
+	events, err := h.RunEventService.SubscribeRunEvents(ctx, platform.RunEventFilter{Task: *filter.Task, Run: filter.Run})
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	logs, _, err := h.TaskService.FindLogs(ctx, filter)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	var run *platform.Run
+	if filter.Run != nil {
+		run, err = h.TaskService.FindRunByID(ctx, *filter.Task, *filter.Run)
+		if err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+	}
+
+	s := newRunEventStream(w)
+	for _, l := range logs {
+		e := platform.RunEvent{TaskID: *filter.Task, Log: *l}
+		if filter.Run != nil {
+			e.RunID = *filter.Run
+		}
+		if err := s.send(e); err != nil {
+			return
+		}
+	}
+
+	if run != nil && runFinished(run.Status) {
+		// The run finished before we subscribed, so no further events will arrive for it.
+		s.send(platform.RunEvent{TaskID: run.TaskID, RunID: run.ID, Time: run.FinishedAt, Status: run.Status, Done: true})
+		return
+	}
+
+	s.stream(ctx, events, filter.Run != nil)
+}
+
+func (h *TaskHandler) handleGetRunEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetRunEventsRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if h.RunEventService == nil {
+		EncodeError(ctx, errRunEventsUnavailable, w)
+		return
+	}
+
+	if _, err := h.TaskService.FindTaskByID(ctx, req.filter.Task); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := h.RunEventService.SubscribeRunEvents(ctx, req.filter)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	newRunEventStream(w).stream(ctx, events, req.filter.Run != nil)
+}
+
+type getRunEventsRequest struct {
+	filter platform.RunEventFilter
+}
+
+func decodeGetRunEventsRequest(ctx context.Context, r *http.Request) (*getRunEventsRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("tid")
+	if id == "" {
+		return nil, kerrors.InvalidDataf("you must provide a task ID")
+	}
+
+	req := &getRunEventsRequest{}
+	if err := req.filter.Task.DecodeFromString(id); err != nil {
+		return nil, err
+	}
+
+	if runID := r.URL.Query().Get("runID"); runID != "" {
+		id, err := platform.IDFromString(runID)
+		if err != nil {
+			return nil, err
+		}
+		req.filter.Run = id
+	}
+
 	return req, nil
 }
 
+// runFinished reports whether a run with the given status will not change state again.
+func runFinished(status string) bool {
+	switch status {
+	case backend.RunSuccess.String(), backend.RunFail.String(), backend.RunCanceled.String():
+		return true
+	}
+	return false
+}
+
+// runEventStream writes run events to a client as Server-Sent Events, one JSON encoded platform.RunEvent per event.
+type runEventStream struct {
+	w http.ResponseWriter
+}
+
+func newRunEventStream(w http.ResponseWriter) *runEventStream {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	s := &runEventStream{w: w}
+	s.flush()
+	return s
+}
+
+func (s *runEventStream) send(e platform.RunEvent) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(s.w, "data: %s\n\n", b); err != nil {
+		return err
+	}
+	s.flush()
+	return nil
+}
+
+// stream sends events until the channel is closed or ctx is done,
+// or, if untilDone is set, until an event marks the end of the run.
+func (s *runEventStream) stream(ctx context.Context, events <-chan platform.RunEvent, untilDone bool) {
+	keepAlive := time.NewTicker(runEventKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := s.send(e); err != nil {
+				return
+			}
+			if untilDone && e.Done {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(s.w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			s.flush()
+		}
+	}
+}
+
+func (s *runEventStream) flush() {
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 func (h *TaskHandler) handleGetRuns(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -730,6 +1005,74 @@ func (h *TaskHandler) handleRetryRun(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *TaskHandler) handleForceRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeForceRunRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	run, err := h.TaskService.ForceRun(ctx, req.TaskID, req.ScheduledFor)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	if err := encodeResponse(ctx, w, http.StatusCreated, newRunResponse(*run)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+type forceRunRequest struct {
+	TaskID       platform.ID
+	ScheduledFor int64
+}
+
+func decodeForceRunRequest(ctx context.Context, r *http.Request) (*forceRunRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("tid")
+	if tid == "" {
+		return nil, kerrors.InvalidDataf("you must provide a task ID")
+	}
+
+	var ti platform.ID
+	if err := ti.DecodeFromString(tid); err != nil {
+		return nil, err
+	}
+
+	var body struct {
+		ScheduledFor string `json:"scheduledFor"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid json structure",
+			Err:  err,
+		}
+	}
+
+	// Without a time, the run is forced for now.
+	scheduledFor := time.Now().Unix()
+	if body.ScheduledFor != "" {
+		t, err := time.Parse(time.RFC3339, body.ScheduledFor)
+		if err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  "invalid scheduledFor",
+				Err:  err,
+			}
+		}
+		scheduledFor = t.Unix()
+	}
+
+	return &forceRunRequest{
+		TaskID:       ti,
+		ScheduledFor: scheduledFor,
+	}, nil
+}
+
 type retryRunRequest struct {
 	RunID, TaskID platform.ID
 }
@@ -759,6 +1102,139 @@ func decodeRetryRunRequest(ctx context.Context, r *http.Request) (*retryRunReque
 	}, nil
 }
 
+func (h *TaskHandler) handleValidateTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeValidateTaskRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	v, err := h.TaskValidationService.ValidateTask(ctx, *req)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, v); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func decodeValidateTaskRequest(ctx context.Context, r *http.Request) (*platform.TaskValidationRequest, error) {
+	req := &platform.TaskValidationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid json structure",
+			Err:  err,
+		}
+	}
+	if !req.Organization.Valid() {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "you must provide an orgID",
+		}
+	}
+	return req, nil
+}
+
+func (h *TaskHandler) handleGetTaskVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetTaskVersionsRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	versions, err := h.TaskService.FindTaskVersions(ctx, req.TaskID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newTaskVersionsResponse(versions, req.TaskID)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+type getTaskVersionsRequest struct {
+	TaskID platform.ID
+}
+
+func decodeGetTaskVersionsRequest(ctx context.Context, r *http.Request) (*getTaskVersionsRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("tid")
+	if tid == "" {
+		return nil, kerrors.InvalidDataf("you must provide a task ID")
+	}
+
+	var ti platform.ID
+	if err := ti.DecodeFromString(tid); err != nil {
+		return nil, err
+	}
+
+	return &getTaskVersionsRequest{
+		TaskID: ti,
+	}, nil
+}
+
+func (h *TaskHandler) handleRollbackTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeRollbackTaskRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	task, err := h.TaskService.RollbackTask(ctx, req.TaskID, req.Version)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newTaskResponse(*task)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+type rollbackTaskRequest struct {
+	TaskID  platform.ID
+	Version int
+}
+
+func decodeRollbackTaskRequest(ctx context.Context, r *http.Request) (*rollbackTaskRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("tid")
+	if tid == "" {
+		return nil, kerrors.InvalidDataf("you must provide a task ID")
+	}
+	version := params.ByName("version")
+	if version == "" {
+		return nil, kerrors.InvalidDataf("you must provide a version")
+	}
+
+	var ti platform.ID
+	if err := ti.DecodeFromString(tid); err != nil {
+		return nil, err
+	}
+	v, err := strconv.Atoi(version)
+	if err != nil || v < 1 {
+		return nil, kerrors.InvalidDataf("version must be a positive integer")
+	}
+
+	return &rollbackTaskRequest{
+		TaskID:  ti,
+		Version: v,
+	}, nil
+}
+
 // TaskService connects to Influx via HTTP using tokens to manage tasks.
 type TaskService struct {
 	Addr               string
@@ -821,6 +1297,9 @@ func (t TaskService) FindTasks(ctx context.Context, filter platform.TaskFilter)
 	if filter.User != nil {
 		val.Add("user", filter.User.String())
 	}
+	if filter.Label != nil {
+		val.Add("label", *filter.Label)
+	}
 	if filter.Limit != 0 {
 		val.Add("limit", strconv.Itoa(filter.Limit))
 	}
@@ -1012,6 +1491,100 @@ func (t TaskService) FindLogs(ctx context.Context, filter platform.LogFilter) ([
 	return logs, len(logs), nil
 }
 
+// FollowLogs returns the logs of a task, or of a single run when filter.Run is set, followed by the run events as they happen.
+// The channel is closed when ctx is done or, when following a single run, once the run has finished.
+func (t TaskService) FollowLogs(ctx context.Context, filter platform.LogFilter) (<-chan platform.RunEvent, error) {
+	if filter.Task == nil {
+		return nil, errors.New("task ID required")
+	}
+
+	var urlPath string
+	if filter.Run == nil {
+		urlPath = path.Join(taskIDPath(*filter.Task), "logs")
+	} else {
+		urlPath = path.Join(taskIDRunIDPath(*filter.Task, *filter.Run), "logs")
+	}
+
+	u, err := newURL(t.Addr, urlPath)
+	if err != nil {
+		return nil, err
+	}
+	val := url.Values{}
+	val.Set("follow", "true")
+	if filter.Org != nil {
+		val.Set("orgID", filter.Org.String())
+	}
+	u.RawQuery = val.Encode()
+
+	return t.streamRunEvents(ctx, u)
+}
+
+// SubscribeRunEvents delivers the run events matching filter as they happen, until ctx is done.
+func (t TaskService) SubscribeRunEvents(ctx context.Context, filter platform.RunEventFilter) (<-chan platform.RunEvent, error) {
+	u, err := newURL(t.Addr, path.Join(taskIDPath(filter.Task), "events"))
+	if err != nil {
+		return nil, err
+	}
+	if filter.Run != nil {
+		val := url.Values{}
+		val.Set("runID", filter.Run.String())
+		u.RawQuery = val.Encode()
+	}
+
+	return t.streamRunEvents(ctx, u)
+}
+
+// streamRunEvents delivers the run events read from the Server-Sent Events stream at u.
+func (t TaskService) streamRunEvents(ctx context.Context, u *url.URL) (<-chan platform.RunEvent, error) {
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(t.Token, req)
+	req = req.WithContext(ctx)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CheckError(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	events := make(chan platform.RunEvent)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+
+		const dataPrefix = "data: "
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, dataPrefix) {
+				// Blank lines separate events, and lines starting with a colon are keep-alive comments.
+				continue
+			}
+
+			var e platform.RunEvent
+			if err := json.Unmarshal([]byte(line[len(dataPrefix):]), &e); err != nil {
+				return
+			}
+
+			select {
+			case events <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
 // FindRuns returns a list of runs that match a filter and the total count of returned runs.
 func (t TaskService) FindRuns(ctx context.Context, filter platform.RunFilter) ([]*platform.Run, int, error) {
 	if filter.Task == nil {
@@ -1147,6 +1720,162 @@ func (t TaskService) RetryRun(ctx context.Context, taskID, runID platform.ID) (*
 	return &rs.Run, nil
 }
 
+// ForceRun queues a run of the task for the given Unix timestamp.
+func (t TaskService) ForceRun(ctx context.Context, taskID platform.ID, scheduledFor int64) (*platform.Run, error) {
+	u, err := newURL(t.Addr, taskIDRunsPath(taskID))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"scheduledFor": time.Unix(scheduledFor, 0).UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		// RetryAlreadyQueuedError is part of the contract of queueing a run for a time that is already queued.
+		if e := backend.ParseRetryAlreadyQueuedError(err.Error()); e != nil {
+			return nil, *e
+		}
+		return nil, err
+	}
+
+	rs := &runResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(rs); err != nil {
+		return nil, err
+	}
+	return &rs.Run, nil
+}
+
+// ValidateTask returns what the task script would do, without creating the task.
+func (t TaskService) ValidateTask(ctx context.Context, vr platform.TaskValidationRequest) (*platform.TaskValidation, error) {
+	u, err := newURL(t.Addr, tasksValidatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(vr)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var v platform.TaskValidation
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// FindTaskVersions returns the script versions of a task, oldest first.
+func (t TaskService) FindTaskVersions(ctx context.Context, taskID platform.ID) ([]*platform.TaskVersion, error) {
+	u, err := newURL(t.Addr, taskIDVersionsPath(taskID))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var vs taskVersionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&vs); err != nil {
+		return nil, err
+	}
+
+	versions := make([]*platform.TaskVersion, len(vs.Versions))
+	for i := range vs.Versions {
+		versions[i] = &vs.Versions[i].TaskVersion
+	}
+	return versions, nil
+}
+
+// RollbackTask updates a task's script to that of the given version, creating a new version.
+func (t TaskService) RollbackTask(ctx context.Context, taskID platform.ID, version int) (*platform.Task, error) {
+	p := path.Join(taskIDVersionsPath(taskID), strconv.Itoa(version), "rollback")
+	u, err := newURL(t.Addr, p)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var tr taskResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return nil, err
+	}
+	return &tr.Task, nil
+}
+
 func cancelPath(taskID, runID platform.ID) string {
 	return path.Join(taskID.String(), runID.String())
 }
@@ -1190,3 +1919,7 @@ func taskIDRunsPath(id platform.ID) string {
 func taskIDRunIDPath(taskID, runID platform.ID) string {
 	return path.Join(tasksPath, taskID.String(), "runs", runID.String())
 }
+
+func taskIDVersionsPath(id platform.ID) string {
+	return path.Join(tasksPath, id.String(), "versions")
+}
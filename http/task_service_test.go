@@ -9,12 +9,14 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/influxdata/platform"
 	pcontext "github.com/influxdata/platform/context"
 	"github.com/influxdata/platform/logger"
 	"github.com/influxdata/platform/mock"
 	_ "github.com/influxdata/platform/query/builtin"
+	"github.com/influxdata/platform/task/backend"
 	"github.com/julienschmidt/httprouter"
 )
 
@@ -294,6 +296,68 @@ func TestTaskHandler_handleGetRun(t *testing.T) {
   "finishedAt": "2018-12-01T17:00:13.155645Z",
   "requestedAt": "2018-12-01T17:00:13Z",
   "log": ""
+}`,
+			},
+		},
+		{
+			name: "get a run with statistics",
+			fields: fields{
+				taskService: &mock.TaskService{
+					FindRunByIDFn: func(ctx context.Context, taskID platform.ID, runID platform.ID) (*platform.Run, error) {
+						run := platform.Run{
+							ID:           runID,
+							TaskID:       taskID,
+							Status:       "success",
+							ScheduledFor: "2018-12-01T17:00:13Z",
+							StartedAt:    "2018-12-01T17:00:03.155645Z",
+							FinishedAt:   "2018-12-01T17:00:13.155645Z",
+							Statistics: &platform.RunStatistics{
+								TotalDuration:   10000000,
+								QueueDuration:   1000000,
+								CompileDuration: 2000000,
+								PlanDuration:    3000000,
+								ExecuteDuration: 4000000,
+								ValuesRead:      500,
+								BytesRead:       4000,
+								PointsWritten:   10,
+							},
+						}
+						return &run, nil
+					},
+				},
+			},
+			args: args{
+				taskID: 1,
+				runID:  2,
+			},
+			wants: wants{
+				statusCode:  http.StatusOK,
+				contentType: "application/json; charset=utf-8",
+				body: `
+{
+  "links": {
+    "self": "/api/v2/tasks/0000000000000001/runs/0000000000000002",
+    "task": "/api/v2/tasks/0000000000000001",
+    "retry": "/api/v2/tasks/0000000000000001/runs/0000000000000002/retry",
+    "logs": "/api/v2/tasks/0000000000000001/runs/0000000000000002/logs"
+  },
+  "id": "0000000000000002",
+  "taskID": "0000000000000001",
+  "status": "success",
+  "scheduledFor": "2018-12-01T17:00:13Z",
+  "startedAt": "2018-12-01T17:00:03.155645Z",
+  "finishedAt": "2018-12-01T17:00:13.155645Z",
+  "log": "",
+  "statistics": {
+    "totalDuration": 10000000,
+    "queueDuration": 1000000,
+    "compileDuration": 2000000,
+    "planDuration": 3000000,
+    "executeDuration": 4000000,
+    "valuesRead": 500,
+    "bytesRead": 4000,
+    "pointsWritten": 10
+  }
 }`,
 			},
 		},
@@ -444,3 +508,131 @@ func TestTaskHandler_handleGetRuns(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskService_FollowLogs(t *testing.T) {
+	taskID, runID := platform.ID(1), platform.ID(2)
+	runStatus := "started"
+
+	hub := backend.NewRunEventHub()
+	h := NewTaskHandler(mock.NewUserResourceMappingService(), mock.NewLabelService(), logger.New(os.Stdout))
+	h.RunEventService = hub
+	h.TaskService = &mock.TaskService{
+		FindLogsFn: func(ctx context.Context, filter platform.LogFilter) ([]*platform.Log, int, error) {
+			l := platform.Log("2018-12-01T17:00:03Z: earlier log")
+			return []*platform.Log{&l}, 1, nil
+		},
+		FindRunByIDFn: func(ctx context.Context, tid, rid platform.ID) (*platform.Run, error) {
+			return &platform.Run{ID: rid, TaskID: tid, Status: runStatus, FinishedAt: "2018-12-01T17:00:13Z"}, nil
+		},
+	}
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := TaskService{Addr: server.URL}
+	events, err := client.FollowLogs(ctx, platform.LogFilter{Task: &taskID, Run: &runID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Receiving the earlier log means the server has subscribed to run events.
+	if e := <-events; e.Log != "2018-12-01T17:00:03Z: earlier log" || e.RunID != runID {
+		t.Fatalf("unexpected first event: %#v", e)
+	}
+
+	lw := hub.LogWriter(backend.NopLogWriter{})
+	base := backend.RunLogBase{Task: &backend.StoreTask{ID: taskID}, RunID: runID}
+	now := time.Date(2018, 12, 1, 17, 0, 5, 0, time.UTC)
+	if err := lw.AddRunLog(ctx, base, now, "live log"); err != nil {
+		t.Fatal(err)
+	}
+	if err := lw.UpdateRunState(ctx, base, now, backend.RunSuccess); err != nil {
+		t.Fatal(err)
+	}
+
+	if e := <-events; e.Log != "live log" || e.Time != "2018-12-01T17:00:05Z" {
+		t.Fatalf("unexpected log event: %#v", e)
+	}
+	if e := <-events; e.Status != "success" || !e.Done {
+		t.Fatalf("unexpected state event: %#v", e)
+	}
+	if e, ok := <-events; ok {
+		t.Fatalf("expected stream to end after run finished, got %#v", e)
+	}
+
+	// Following a run that has already finished returns its logs and final state.
+	runStatus = "failed"
+	events, err = client.FollowLogs(ctx, platform.LogFilter{Task: &taskID, Run: &runID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []platform.RunEvent
+	for e := range events {
+		got = append(got, e)
+	}
+	if len(got) != 2 || got[1].Status != "failed" || !got[1].Done || got[1].Time != "2018-12-01T17:00:13Z" {
+		t.Fatalf("unexpected events for finished run: %#v", got)
+	}
+
+	// Without a RunEventService, following is unavailable.
+	h.RunEventService = nil
+	if _, err := client.FollowLogs(ctx, platform.LogFilter{Task: &taskID}); err == nil {
+		t.Fatal("expected error following logs without a run event service")
+	}
+}
+
+type taskValidationService func(context.Context, platform.TaskValidationRequest) (*platform.TaskValidation, error)
+
+func (f taskValidationService) ValidateTask(ctx context.Context, req platform.TaskValidationRequest) (*platform.TaskValidation, error) {
+	return f(ctx, req)
+}
+
+func TestTaskService_ValidateTask(t *testing.T) {
+	want := &platform.TaskValidation{
+		Name:         "downsample",
+		Every:        "1h0m0s",
+		Concurrency:  1,
+		Retry:        1,
+		Schedule:     []string{"2018-11-01T11:00:00Z"},
+		ReadBuckets:  []platform.TaskValidationBucket{{ID: 10, Name: "src", Permitted: true}},
+		WriteBuckets: []platform.TaskValidationBucket{},
+		Plan:         []platform.TaskValidationPlanNode{{ID: "from0", Kind: "from"}},
+	}
+
+	h := NewTaskHandler(mock.NewUserResourceMappingService(), mock.NewLabelService(), logger.New(os.Stdout))
+	h.TaskValidationService = taskValidationService(func(ctx context.Context, req platform.TaskValidationRequest) (*platform.TaskValidation, error) {
+		if req.Organization != 1 || req.Flux != "script" || !req.Execute {
+			t.Errorf("unexpected request %+v", req)
+		}
+		return want, nil
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	s := TaskService{Addr: server.URL}
+	got, err := s.ValidateTask(context.Background(), platform.TaskValidationRequest{Organization: 1, Flux: "script", Execute: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	if !bytes.Equal(gotJSON, wantJSON) {
+		t.Fatalf("unexpected validation:\n%s\nwant:\n%s", gotJSON, wantJSON)
+	}
+
+	// The validate path is not a task ID.
+	resp, err := http.Get(server.URL + tasksValidatePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("expected GET of the validate path to not be allowed, got %d", resp.StatusCode)
+	}
+
+	if _, err := s.ValidateTask(context.Background(), platform.TaskValidationRequest{Flux: "script"}); err == nil {
+		t.Fatal("expected an error when the organization is missing")
+	}
+}
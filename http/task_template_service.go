@@ -0,0 +1,282 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/platform"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	taskTemplatesPath = "/api/v2/taskTemplates"
+)
+
+// TaskTemplateHandler is the handler for the task template service
+type TaskTemplateHandler struct {
+	*httprouter.Router
+
+	TaskTemplateService         platform.TaskTemplateService
+	TaskTemplateInstanceService platform.TaskTemplateInstanceService
+}
+
+// NewTaskTemplateHandler creates a new TaskTemplateHandler
+func NewTaskTemplateHandler() *TaskTemplateHandler {
+	h := &TaskTemplateHandler{
+		Router: httprouter.New(),
+	}
+
+	h.HandlerFunc("GET", taskTemplatesPath, h.handleGetTaskTemplates)
+	h.HandlerFunc("POST", taskTemplatesPath, h.handlePostTaskTemplate)
+	h.HandlerFunc("GET", taskTemplatesPath+"/:id", h.handleGetTaskTemplate)
+	h.HandlerFunc("PATCH", taskTemplatesPath+"/:id", h.handlePatchTaskTemplate)
+	h.HandlerFunc("DELETE", taskTemplatesPath+"/:id", h.handleDeleteTaskTemplate)
+	h.HandlerFunc("POST", taskTemplatesPath+"/:id/instances", h.handlePostTaskTemplateInstances)
+
+	return h
+}
+
+type taskTemplateLinks struct {
+	Self      string `json:"self"`
+	Instances string `json:"instances"`
+}
+
+type taskTemplateResponse struct {
+	*platform.TaskTemplate
+	Links taskTemplateLinks `json:"links"`
+	// Tasks are the tasks updated by a rollout of the template.
+	Tasks []taskResponse `json:"tasks,omitempty"`
+}
+
+func newTaskTemplateResponse(t *platform.TaskTemplate) taskTemplateResponse {
+	return taskTemplateResponse{
+		TaskTemplate: t,
+		Links: taskTemplateLinks{
+			Self:      fmt.Sprintf("%s/%s", taskTemplatesPath, t.ID),
+			Instances: fmt.Sprintf("%s/%s/instances", taskTemplatesPath, t.ID),
+		},
+	}
+}
+
+type taskTemplatesResponse struct {
+	TaskTemplates []taskTemplateResponse `json:"taskTemplates"`
+	Links         map[string]string      `json:"links"`
+}
+
+func newTaskTemplatesResponse(ts []*platform.TaskTemplate) taskTemplatesResponse {
+	resp := taskTemplatesResponse{
+		TaskTemplates: make([]taskTemplateResponse, 0, len(ts)),
+		Links: map[string]string{
+			"self": taskTemplatesPath,
+		},
+	}
+	for _, t := range ts {
+		resp.TaskTemplates = append(resp.TaskTemplates, newTaskTemplateResponse(t))
+	}
+	return resp
+}
+
+type taskTemplateInstancesResponse struct {
+	Tasks []taskResponse `json:"tasks"`
+}
+
+func newTaskResponses(ts []*platform.Task) []taskResponse {
+	rs := make([]taskResponse, 0, len(ts))
+	for _, t := range ts {
+		rs = append(rs, newTaskResponse(*t))
+	}
+	return rs
+}
+
+func (h *TaskTemplateHandler) handleGetTaskTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := requestOrgIDFilter(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	filter := platform.TaskTemplateFilter{OrganizationID: orgID}
+	if taskID := r.URL.Query().Get("taskID"); taskID != "" {
+		id, err := platform.IDFromString(taskID)
+		if err != nil {
+			EncodeError(ctx, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  "invalid taskID",
+				Err:  err,
+			}, w)
+			return
+		}
+		filter.TaskID = id
+	}
+
+	ts, err := h.TaskTemplateService.FindTaskTemplates(ctx, filter)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newTaskTemplatesResponse(ts)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *TaskTemplateHandler) handlePostTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	t := &platform.TaskTemplate{}
+	if err := json.NewDecoder(r.Body).Decode(t); err != nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid json structure",
+			Err:  err,
+		}, w)
+		return
+	}
+	// Templates are linked to their tasks by instantiating them, never by the request.
+	t.Instances = nil
+
+	if err := h.TaskTemplateService.CreateTaskTemplate(ctx, t); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newTaskTemplateResponse(t)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *TaskTemplateHandler) handleGetTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	t, err := h.TaskTemplateService.FindTaskTemplateByID(ctx, id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newTaskTemplateResponse(t)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handlePatchTaskTemplate updates a task template.
+// With the rollout query parameter set to true, the tasks instantiated from the template are then updated to it.
+func (h *TaskTemplateHandler) handlePatchTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	var rollout bool
+	switch r.URL.Query().Get("rollout") {
+	case "", "false":
+	case "true":
+		rollout = true
+	default:
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "rollout must be true or false",
+		}, w)
+		return
+	}
+
+	var upd platform.TaskTemplateUpdate
+	if err := json.NewDecoder(r.Body).Decode(&upd); err != nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid json structure",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	t, err := h.TaskTemplateService.UpdateTaskTemplate(ctx, id, upd)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	resp := newTaskTemplateResponse(t)
+	if rollout {
+		ts, err := h.TaskTemplateInstanceService.RolloutTaskTemplate(ctx, id)
+		if err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+		resp.Tasks = newTaskResponses(ts)
+
+		// A rollout unlinks the instances whose task was deleted.
+		if t, err = h.TaskTemplateService.FindTaskTemplateByID(ctx, id); err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+		resp.TaskTemplate = t
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, resp); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *TaskTemplateHandler) handleDeleteTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.TaskTemplateService.DeleteTaskTemplate(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *TaskTemplateHandler) handlePostTaskTemplateInstances(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	var inst platform.TaskTemplateInstantiation
+	if err := json.NewDecoder(r.Body).Decode(&inst); err != nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid json structure",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	ts, err := h.TaskTemplateInstanceService.InstantiateTaskTemplate(ctx, id, inst)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, taskTemplateInstancesResponse{Tasks: newTaskResponses(ts)}); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
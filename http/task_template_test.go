@@ -0,0 +1,155 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func TestTaskTemplateHandler(t *testing.T) {
+	template := &platform.TaskTemplate{
+		ID:             platformtesting.MustIDBase16("020f755c3c082000"),
+		OrganizationID: platformtesting.MustIDBase16("020f755c3c082001"),
+		Name:           "cpu",
+		Flux:           `option task = {name: params.host, every: 1m}`,
+		Params:         []platform.TaskTemplateParam{{Name: "host", Type: platform.TaskTemplateStringParam}},
+	}
+	templateJSON := `{"id":"020f755c3c082000","orgID":"020f755c3c082001","name":"cpu","flux":"option task = {name: params.host, every: 1m}","params":[{"name":"host","type":"string"}],"links":{"self":"/api/v2/taskTemplates/020f755c3c082000","instances":"/api/v2/taskTemplates/020f755c3c082000/instances"}}`
+	task := &platform.Task{
+		ID:           platformtesting.MustIDBase16("020f755c3c082002"),
+		Organization: template.OrganizationID,
+		Name:         "a",
+		Status:       "active",
+		Flux:         "params = {host: \"a\"}\n\n" + template.Flux,
+	}
+
+	ts := mock.NewTaskTemplateService()
+	ts.FindTaskTemplatesFn = func(ctx context.Context, filter platform.TaskTemplateFilter) ([]*platform.TaskTemplate, error) {
+		if filter.TaskID == nil || *filter.TaskID != task.ID {
+			t.Errorf("expected task templates to be filtered by task, got %v", filter.TaskID)
+		}
+		return []*platform.TaskTemplate{template}, nil
+	}
+	ts.FindTaskTemplateByIDFn = func(ctx context.Context, id platform.ID) (*platform.TaskTemplate, error) {
+		if id != template.ID {
+			return nil, &platform.Error{Code: platform.ENotFound, Msg: "task template not found"}
+		}
+		return template, nil
+	}
+	ts.CreateTaskTemplateFn = func(ctx context.Context, tt *platform.TaskTemplate) error {
+		if tt.Instances != nil {
+			t.Error("expected instances of a new task template to be ignored")
+		}
+		tt.ID = template.ID
+		return nil
+	}
+	ts.UpdateTaskTemplateFn = func(ctx context.Context, id platform.ID, upd platform.TaskTemplateUpdate) (*platform.TaskTemplate, error) {
+		return template, nil
+	}
+	rolledOut := false
+	ts.RolloutTaskTemplateFn = func(ctx context.Context, id platform.ID) ([]*platform.Task, error) {
+		rolledOut = true
+		return []*platform.Task{task}, nil
+	}
+	ts.InstantiateTaskTemplateFn = func(ctx context.Context, id platform.ID, inst platform.TaskTemplateInstantiation) ([]*platform.Task, error) {
+		if len(inst.Instances) != 1 || inst.Instances[0]["host"] != "a" {
+			t.Errorf("unexpected instantiation %+v", inst)
+		}
+		return []*platform.Task{task}, nil
+	}
+
+	h := NewTaskTemplateHandler()
+	h.TaskTemplateService = ts
+	h.TaskTemplateInstanceService = ts
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		body        string
+		wantStatus  int
+		wantBody    string
+		wantRollout bool
+	}{
+		{
+			name:       "find the task template of a task",
+			method:     "GET",
+			path:       "/api/v2/taskTemplates?taskID=020f755c3c082002",
+			wantStatus: 200,
+			wantBody:   `{"taskTemplates":[` + templateJSON + `],"links":{"self":"/api/v2/taskTemplates"}}`,
+		},
+		{
+			name:       "find a missing task template",
+			method:     "GET",
+			path:       "/api/v2/taskTemplates/020f755c3c082003",
+			wantStatus: 404,
+		},
+		{
+			name:       "create a task template",
+			method:     "POST",
+			path:       "/api/v2/taskTemplates",
+			body:       `{"orgID":"020f755c3c082001","name":"cpu","flux":"option task = {name: params.host, every: 1m}","params":[{"name":"host","type":"string"}],"instances":[{"taskID":"020f755c3c082002"}]}`,
+			wantStatus: 201,
+			wantBody:   templateJSON,
+		},
+		{
+			name:       "update a task template",
+			method:     "PATCH",
+			path:       "/api/v2/taskTemplates/020f755c3c082000",
+			body:       `{"name":"cpu"}`,
+			wantStatus: 200,
+			wantBody:   templateJSON,
+		},
+		{
+			name:        "update a task template and roll it out",
+			method:      "PATCH",
+			path:        "/api/v2/taskTemplates/020f755c3c082000?rollout=true",
+			body:        `{"name":"cpu"}`,
+			wantStatus:  200,
+			wantRollout: true,
+		},
+		{
+			name:       "update with an invalid rollout",
+			method:     "PATCH",
+			path:       "/api/v2/taskTemplates/020f755c3c082000?rollout=yes",
+			body:       `{"name":"cpu"}`,
+			wantStatus: 400,
+		},
+		{
+			name:       "instantiate a task template",
+			method:     "POST",
+			path:       "/api/v2/taskTemplates/020f755c3c082000/instances",
+			body:       `{"instances":[{"host":"a"}]}`,
+			wantStatus: 201,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rolledOut = false
+			r := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, res.StatusCode, body)
+			}
+			if tt.wantBody != "" {
+				if eq, _ := jsonEqual(string(body), tt.wantBody); !eq {
+					t.Errorf("unexpected body:\n%s\nwant:\n%s", body, tt.wantBody)
+				}
+			}
+			if rolledOut != tt.wantRollout {
+				t.Errorf("expected rollout %v, got %v", tt.wantRollout, rolledOut)
+			}
+		})
+	}
+}
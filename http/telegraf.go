@@ -2,8 +2,10 @@ package http
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"mime"
 	"net/http"
 	"strings"
 	"time"
@@ -23,16 +25,27 @@ type TelegrafHandler struct {
 	TelegrafService            platform.TelegrafConfigStore
 	UserResourceMappingService platform.UserResourceMappingService
 	LabelService               platform.LabelService
+
+	TelegrafAgentService              platform.TelegrafAgentService
+	TelegrafAgentAuthorizationService platform.TelegrafAgentAuthorizationService
+
+	// AgentStaleAfter is how long after its last check-in an agent is
+	// reported as stale, platform.DefaultTelegrafAgentStaleAfter if zero.
+	AgentStaleAfter time.Duration
 }
 
 const (
-	telegrafsPath             = "/api/v2/telegrafs"
-	telegrafsIDPath           = "/api/v2/telegrafs/:id"
-	telegrafsIDMembersIDPath  = "/api/v2/telegrafs/:id/members/:userID"
-	telegrafsIDOwnersPath     = "/api/v2/telegrafs/:id/owners"
-	telegrafsIDOwnersIDPath   = "/api/v2/telegrafs/:id/owners/:userID"
-	telegrafsIDLabelsPath     = "/api/v2/telegrafs/:id/labels"
-	telegrafsIDLabelsNamePath = "/api/v2/telegrafs/:id/labels/:name"
+	telegrafsPath            = "/api/v2/telegrafs"
+	telegrafsIDPath          = "/api/v2/telegrafs/:id"
+	telegrafsIDMembersIDPath = "/api/v2/telegrafs/:id/members/:userID"
+	telegrafsIDOwnersPath    = "/api/v2/telegrafs/:id/owners"
+	telegrafsIDOwnersIDPath  = "/api/v2/telegrafs/:id/owners/:userID"
+	telegrafsIDLabelsPath    = "/api/v2/telegrafs/:id/labels"
+	telegrafsIDLabelsIDPath  = "/api/v2/telegrafs/:id/labels/:lid"
+
+	telegrafsIDConfigPath        = "/api/v2/telegrafs/:id/config"
+	telegrafsIDAgentsPath        = "/api/v2/telegrafs/:id/agents"
+	telegrafsIDAuthorizationPath = "/api/v2/telegrafs/:id/authorization"
 )
 
 // NewTelegrafHandler returns a new instance of TelegrafHandler.
@@ -64,20 +77,26 @@ func NewTelegrafHandler(
 	h.HandlerFunc("GET", telegrafsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, platform.Owner))
 	h.HandlerFunc("DELETE", telegrafsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
 
-	h.HandlerFunc("GET", telegrafsIDLabelsPath, newGetLabelsHandler(h.LabelService))
-	h.HandlerFunc("POST", telegrafsIDLabelsPath, newPostLabelHandler(h.LabelService))
-	h.HandlerFunc("DELETE", telegrafsIDLabelsNamePath, newDeleteLabelHandler(h.LabelService))
+	h.HandlerFunc("GET", telegrafsIDLabelsPath, newGetLabelsHandler(h.LabelService, platform.TelegrafResourceType))
+	h.HandlerFunc("POST", telegrafsIDLabelsPath, newPostLabelHandler(h.LabelService, platform.TelegrafResourceType))
+	h.HandlerFunc("DELETE", telegrafsIDLabelsIDPath, newDeleteLabelHandler(h.LabelService, platform.TelegrafResourceType))
+
+	h.HandlerFunc("GET", telegrafsIDConfigPath, h.handleGetTelegrafAgentConfig)
+	h.HandlerFunc("GET", telegrafsIDAgentsPath, h.handleGetTelegrafAgents)
+	h.HandlerFunc("POST", telegrafsIDAuthorizationPath, h.handlePostTelegrafAgentAuthorization)
 
 	return h
 }
 
-type link struct {
-	Self string `json:"self"`
+type telegrafLinks struct {
+	Self   string `json:"self"`
+	Config string `json:"config"`
+	Agents string `json:"agents"`
 }
 
 type telegrafResponse struct {
 	*platform.TelegrafConfig
-	Links link `json:"links"`
+	Links telegrafLinks `json:"links"`
 }
 
 type telegrafResponses struct {
@@ -87,8 +106,10 @@ type telegrafResponses struct {
 func newTelegrafResponse(tc *platform.TelegrafConfig) telegrafResponse {
 	return telegrafResponse{
 		TelegrafConfig: tc,
-		Links: link{
-			Self: fmt.Sprintf("/api/v2/telegrafs/%s", tc.ID.String()),
+		Links: telegrafLinks{
+			Self:   fmt.Sprintf("/api/v2/telegrafs/%s", tc.ID.String()),
+			Config: fmt.Sprintf("/api/v2/telegrafs/%s/config", tc.ID.String()),
+			Agents: fmt.Sprintf("/api/v2/telegrafs/%s/agents", tc.ID.String()),
 		},
 	}
 }
@@ -124,7 +145,10 @@ func (h *TelegrafHandler) handleGetTelegrafs(w http.ResponseWriter, r *http.Requ
 		EncodeError(ctx, err, w)
 		return
 	}
-	tcs, _, err := h.TelegrafService.FindTelegrafConfigs(ctx, *filter)
+	tcs, _, err := h.TelegrafService.FindTelegrafConfigs(ctx, platform.TelegrafConfigFilter{
+		UserResourceMappingFilter: *filter,
+		Label:                     labelFilterParam(r),
+	})
 	if err != nil {
 		if err == platform.ErrViewNotFound {
 			err = errors.New(err.Error(), errors.NotFound)
@@ -198,10 +222,38 @@ func decodeUserResourceMappingFilter(ctx context.Context, r *http.Request) (*pla
 
 func decodePostTelegrafRequest(ctx context.Context, r *http.Request) (*platform.TelegrafConfig, error) {
 	tc := new(platform.TelegrafConfig)
+	if isTOMLContent(r) {
+		return decodeTelegrafTOML(r, tc)
+	}
 	err := json.NewDecoder(r.Body).Decode(tc)
 	return tc, err
 }
 
+func isTOMLContent(r *http.Request) bool {
+	mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mt == "application/toml"
+}
+
+// decodeTelegrafTOML decodes a telegraf.conf. As the file has no name for the
+// config, it is taken from the name query parameter.
+func decodeTelegrafTOML(r *http.Request, tc *platform.TelegrafConfig) (*platform.TelegrafConfig, error) {
+	if err := tc.DecodeTOML(r.Body); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "unable to decode telegraf toml",
+			Err:  err,
+		}
+	}
+	tc.Name = r.URL.Query().Get("name")
+	if tc.Name == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "name is required to import a telegraf toml config",
+		}
+	}
+	return tc, nil
+}
+
 func decodePutTelegrafRequest(ctx context.Context, r *http.Request) (*platform.TelegrafConfig, error) {
 	tc := new(platform.TelegrafConfig)
 	if err := json.NewDecoder(r.Body).Decode(tc); err != nil {
@@ -221,6 +273,8 @@ func decodePutTelegrafRequest(ctx context.Context, r *http.Request) (*platform.T
 }
 
 // handlePostTelegraf is the HTTP handler for the POST /api/v2/telegrafs route.
+// The config is either json or, with a Content-Type of application/toml, a
+// telegraf.conf.
 func (h *TelegrafHandler) handlePostTelegraf(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	tc, err := decodePostTelegrafRequest(ctx, r)
@@ -293,3 +347,162 @@ func (h *TelegrafHandler) handleDeleteTelegraf(w http.ResponseWriter, r *http.Re
 		return
 	}
 }
+
+// telegrafConfigETag is the version of a rendered telegraf config.
+func telegrafConfigETag(toml string) string {
+	sum := sha256.Sum256([]byte(toml))
+	return fmt.Sprintf(`"%x"`, sum[:16])
+}
+
+// etagMatch returns true if the If-None-Match header matches etag.
+func etagMatch(ifNoneMatch, etag string) bool {
+	for _, t := range strings.Split(ifNoneMatch, ",") {
+		t = strings.TrimSpace(t)
+		if t == "*" || strings.TrimPrefix(t, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// telegrafAgentVersion is the version query parameter or the version in a
+// telegraf User-Agent, exp "Telegraf/1.9.0".
+func telegrafAgentVersion(r *http.Request) string {
+	if v := r.URL.Query().Get("version"); v != "" {
+		return v
+	}
+	ua := strings.Fields(r.Header.Get("User-Agent"))
+	if len(ua) > 0 && strings.HasPrefix(ua[0], "Telegraf/") {
+		return strings.TrimPrefix(ua[0], "Telegraf/")
+	}
+	return ""
+}
+
+// handleGetTelegrafAgentConfig is the HTTP handler for the GET
+// /api/v2/telegrafs/:id/config route agents poll for their config. The
+// config is versioned by its ETag; a request with a matching If-None-Match
+// header is answered with 304 Not Modified. Agents that identify themselves
+// with the hostname query parameter are checked in.
+func (h *TelegrafHandler) handleGetTelegrafAgentConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeGetTelegrafRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	tc, err := h.TelegrafService.FindTelegrafConfigByID(ctx, id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if hostname := r.URL.Query().Get("hostname"); hostname != "" && h.TelegrafAgentService != nil {
+		a := &platform.TelegrafAgent{
+			TelegrafConfigID: tc.ID,
+			Hostname:         hostname,
+			Version:          telegrafAgentVersion(r),
+			LastSeen:         time.Now().UTC(),
+		}
+		if err := h.TelegrafAgentService.CheckInTelegrafAgent(ctx, a); err != nil {
+			h.Logger.Info("failed to check in telegraf agent", zap.String("hostname", hostname), zap.Error(err))
+		}
+	}
+
+	toml := tc.TOML()
+	etag := telegrafConfigETag(toml)
+	w.Header().Set("ETag", etag)
+	if etagMatch(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/toml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(toml))
+}
+
+type telegrafAgentResponse struct {
+	*platform.TelegrafAgent
+	Stale bool `json:"stale"`
+}
+
+type telegrafAgentsResponse struct {
+	Links  map[string]string       `json:"links"`
+	Agents []telegrafAgentResponse `json:"agents"`
+}
+
+// handleGetTelegrafAgents is the HTTP handler for the GET
+// /api/v2/telegrafs/:id/agents route. With stale=true only the agents that
+// have not checked in recently are returned.
+func (h *TelegrafHandler) handleGetTelegrafAgents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeGetTelegrafRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	onlyStale := r.URL.Query().Get("stale") == "true"
+
+	as, err := h.TelegrafAgentService.FindTelegrafAgents(ctx, platform.TelegrafAgentFilter{
+		TelegrafConfigID: &id,
+	})
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	staleAfter := h.AgentStaleAfter
+	if staleAfter <= 0 {
+		staleAfter = platform.DefaultTelegrafAgentStaleAfter
+	}
+	now := time.Now()
+	res := telegrafAgentsResponse{
+		Links: map[string]string{
+			"self":     fmt.Sprintf("/api/v2/telegrafs/%s/agents", id),
+			"telegraf": fmt.Sprintf("/api/v2/telegrafs/%s", id),
+		},
+		Agents: []telegrafAgentResponse{},
+	}
+	for _, a := range as {
+		stale := a.Stale(now, staleAfter)
+		if onlyStale && !stale {
+			continue
+		}
+		res.Agents = append(res.Agents, telegrafAgentResponse{
+			TelegrafAgent: a,
+			Stale:         stale,
+		})
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handlePostTelegrafAgentAuthorization is the HTTP handler for the POST
+// /api/v2/telegrafs/:id/authorization route. It mints the token the agents of
+// the config use, replacing the token minted before.
+func (h *TelegrafHandler) handlePostTelegrafAgentAuthorization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeGetTelegrafRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	a, err := h.TelegrafAgentAuthorizationService.CreateTelegrafAgentAuthorization(ctx, id, auth.GetUserID())
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newAuthResponse(a)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
@@ -0,0 +1,183 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/inmem"
+	"github.com/influxdata/platform/telegraf/plugins"
+	"github.com/influxdata/platform/telegraf/plugins/inputs"
+	"go.uber.org/zap"
+)
+
+func TestDecodePostTelegrafRequest_TOML(t *testing.T) {
+	conf := `[agent]
+  interval = "10s"
+
+[[inputs.cpu]]
+
+[[outputs.kafka]]
+  brokers = ["localhost:9092"]
+`
+	r := httptest.NewRequest("POST", "/api/v2/telegrafs?name=tc1", strings.NewReader(conf))
+	r.Header.Set("Content-Type", "application/toml; charset=utf-8")
+
+	tc, err := decodePostTelegrafRequest(context.Background(), r)
+	if err != nil {
+		t.Fatalf("unexpected error decoding telegraf toml: %v", err)
+	}
+	if tc.Name != "tc1" {
+		t.Errorf("expected name tc1, got %q", tc.Name)
+	}
+	if tc.Agent.Interval != 10000 {
+		t.Errorf("expected interval of 10000ms, got %d", tc.Agent.Interval)
+	}
+	if len(tc.Plugins) != 2 {
+		t.Fatalf("expected 2 plugins, got %d", len(tc.Plugins))
+	}
+	if _, ok := tc.Plugins[0].Config.(*inputs.CPUStats); !ok {
+		t.Errorf("expected cpu input plugin, got %T", tc.Plugins[0].Config)
+	}
+	if p, ok := tc.Plugins[1].Config.(*plugins.Raw); !ok || p.PluginName() != "kafka" {
+		t.Errorf("expected raw kafka output plugin, got %#v", tc.Plugins[1].Config)
+	}
+}
+
+func TestDecodePostTelegrafRequest_TOMLRequiresName(t *testing.T) {
+	r := httptest.NewRequest("POST", "/api/v2/telegrafs", strings.NewReader("[agent]\n  interval = \"10s\"\n"))
+	r.Header.Set("Content-Type", "application/toml")
+
+	if _, err := decodePostTelegrafRequest(context.Background(), r); platform.ErrorCode(err) != platform.EInvalid {
+		t.Fatalf("expected invalid error, got %v", err)
+	}
+}
+
+func TestTelegrafHandler_handleGetTelegrafAgentConfig(t *testing.T) {
+	ctx := context.Background()
+	s := inmem.NewService()
+	tc := &platform.TelegrafConfig{
+		Name:    "tc1",
+		Plugins: []platform.TelegrafPlugin{{Config: &inputs.CPUStats{}}},
+	}
+	if err := s.CreateTelegrafConfig(ctx, tc, platform.ID(1), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewTelegrafHandler(zap.NewNop(), s, s, s)
+	h.TelegrafAgentService = s
+	path := "/api/v2/telegrafs/" + tc.ID.String() + "/config"
+
+	r := httptest.NewRequest("GET", path+"?hostname=host1", nil)
+	r.Header.Set("User-Agent", "Telegraf/1.9.0")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w.Body.String() != tc.TOML() {
+		t.Errorf("expected config toml, got %q", w.Body.String())
+	}
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag")
+	}
+
+	r = httptest.NewRequest("GET", path, nil)
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("expected status 304 for unchanged config, got %d", w.Code)
+	}
+
+	tc.Plugins = append(tc.Plugins, platform.TelegrafPlugin{Config: &inputs.MemStats{}})
+	if _, err := s.UpdateTelegrafConfig(ctx, tc.ID, tc, platform.ID(1), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200 for changed config, got %d", w.Code)
+	}
+
+	as, err := s.FindTelegrafAgents(ctx, platform.TelegrafAgentFilter{TelegrafConfigID: &tc.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(as) != 1 || as[0].Hostname != "host1" || as[0].Version != "1.9.0" {
+		t.Errorf("expected agent host1 at version 1.9.0 to be checked in, got %v", as)
+	}
+}
+
+func TestTelegrafHandler_handleGetTelegrafAgents(t *testing.T) {
+	ctx := context.Background()
+	s := inmem.NewService()
+	id := platform.ID(1)
+	agents := []*platform.TelegrafAgent{
+		{TelegrafConfigID: id, Hostname: "host1", LastSeen: time.Now()},
+		{TelegrafConfigID: id, Hostname: "host2", LastSeen: time.Now().Add(-time.Hour)},
+		{TelegrafConfigID: platform.ID(2), Hostname: "host3", LastSeen: time.Now()},
+	}
+	for _, a := range agents {
+		if err := s.CheckInTelegrafAgent(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	h := NewTelegrafHandler(zap.NewNop(), s, s, s)
+	h.TelegrafAgentService = s
+
+	tests := []struct {
+		name  string
+		query string
+		want  map[string]bool
+	}{
+		{
+			name: "all agents",
+			want: map[string]bool{"host1": false, "host2": true},
+		},
+		{
+			name:  "stale agents",
+			query: "?stale=true",
+			want:  map[string]bool{"host2": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v2/telegrafs/"+id.String()+"/agents"+tt.query, nil)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var res struct {
+				Agents []struct {
+					Hostname string `json:"hostname"`
+					Stale    bool   `json:"stale"`
+				} `json:"agents"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatal(err)
+			}
+			got := map[string]bool{}
+			for _, a := range res.Agents {
+				got[a.Hostname] = a.Stale
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected agents %v, got %v", tt.want, got)
+			}
+			for host, stale := range tt.want {
+				if s, ok := got[host]; !ok || s != stale {
+					t.Errorf("expected agent %s with stale=%v, got %v", host, stale, got)
+				}
+			}
+		})
+	}
+}
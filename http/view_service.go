@@ -21,14 +21,14 @@ type ViewHandler struct {
 }
 
 const (
-	viewsPath             = "/api/v2/views"
-	viewsIDPath           = "/api/v2/views/:id"
-	viewsIDMembersPath    = "/api/v2/views/:id/members"
-	viewsIDMembersIDPath  = "/api/v2/views/:id/members/:userID"
-	viewsIDOwnersPath     = "/api/v2/views/:id/owners"
-	viewsIDOwnersIDPath   = "/api/v2/views/:id/owners/:userID"
-	viewsIDLabelsPath     = "/api/v2/views/:id/labels"
-	viewsIDLabelsNamePath = "/api/v2/views/:id/labels/:name"
+	viewsPath            = "/api/v2/views"
+	viewsIDPath          = "/api/v2/views/:id"
+	viewsIDMembersPath   = "/api/v2/views/:id/members"
+	viewsIDMembersIDPath = "/api/v2/views/:id/members/:userID"
+	viewsIDOwnersPath    = "/api/v2/views/:id/owners"
+	viewsIDOwnersIDPath  = "/api/v2/views/:id/owners/:userID"
+	viewsIDLabelsPath    = "/api/v2/views/:id/labels"
+	viewsIDLabelsIDPath  = "/api/v2/views/:id/labels/:lid"
 )
 
 // NewViewHandler returns a new instance of ViewHandler.
@@ -54,9 +54,9 @@ func NewViewHandler(mappingService platform.UserResourceMappingService, labelSer
 	h.HandlerFunc("GET", viewsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, platform.Owner))
 	h.HandlerFunc("DELETE", viewsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
 
-	h.HandlerFunc("GET", viewsIDLabelsPath, newGetLabelsHandler(h.LabelService))
-	h.HandlerFunc("POST", viewsIDLabelsPath, newPostLabelHandler(h.LabelService))
-	h.HandlerFunc("DELETE", viewsIDLabelsNamePath, newDeleteLabelHandler(h.LabelService))
+	h.HandlerFunc("GET", viewsIDLabelsPath, newGetLabelsHandler(h.LabelService, platform.ViewResourceType))
+	h.HandlerFunc("POST", viewsIDLabelsPath, newPostLabelHandler(h.LabelService, platform.ViewResourceType))
+	h.HandlerFunc("DELETE", viewsIDLabelsIDPath, newDeleteLabelHandler(h.LabelService, platform.ViewResourceType))
 
 	return h
 }
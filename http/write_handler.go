@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/influxdata/platform"
@@ -29,10 +30,17 @@ type WriteHandler struct {
 	OrganizationService platform.OrganizationService
 
 	PointsWriter storage.PointsWriter
+
+	// RetryAfter is the delay suggested to clients whose writes are rejected
+	// because storage is unavailable.
+	RetryAfter time.Duration
 }
 
 const (
 	writePath = "/api/v2/write"
+
+	// DefaultWriteRetryAfter is the default RetryAfter of a WriteHandler.
+	DefaultWriteRetryAfter = 10 * time.Second
 )
 
 // NewWriteHandler creates a new handler at /api/v2/write to receive line protocol.
@@ -41,6 +49,7 @@ func NewWriteHandler(writer storage.PointsWriter) *WriteHandler {
 		Router:       httprouter.New(),
 		Logger:       zap.NewNop(),
 		PointsWriter: writer,
+		RetryAfter:   DefaultWriteRetryAfter,
 	}
 
 	h.HandlerFunc("POST", writePath, h.handleWrite)
@@ -157,6 +166,12 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.PointsWriter.WritePoints(exploded); err != nil {
+		if platform.ErrorCode(err) == platform.EUnavailable {
+			// Ask clients to back off rather than retry immediately.
+			w.Header().Set("Retry-After", strconv.Itoa(int(h.RetryAfter/time.Second)))
+			EncodeError(ctx, err, w)
+			return
+		}
 		EncodeError(ctx, errors.BadRequestError(err.Error()), w)
 		return
 	}
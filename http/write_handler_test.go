@@ -9,8 +9,11 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/mock"
 )
 
 func TestWriteService_Write(t *testing.T) {
@@ -69,3 +72,40 @@ func TestWriteService_Write(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteHandler_handleWrite_Unavailable(t *testing.T) {
+	org := &platform.Organization{ID: 1, Name: "org"}
+	bucket := &platform.Bucket{ID: 2, OrganizationID: org.ID, Name: "bucket"}
+
+	writer := &mock.PointsWriter{}
+	writer.ForceError(&platform.Error{Code: platform.EUnavailable, Msg: "engine is overloaded"})
+
+	h := NewWriteHandler(writer)
+	h.RetryAfter = 30 * time.Second
+	h.OrganizationService = &mock.OrganizationService{
+		FindOrganizationByIDF: func(ctx context.Context, id platform.ID) (*platform.Organization, error) {
+			return org, nil
+		},
+	}
+	bucketService := mock.NewBucketService()
+	bucketService.FindBucketFn = func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+		return bucket, nil
+	}
+	h.BucketService = bucketService
+
+	r := httptest.NewRequest("POST", "/api/v2/write?org=0000000000000001&bucket=0000000000000002", strings.NewReader("m,t1=v1 f1=2"))
+	r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{
+		Status:      platform.Active,
+		Permissions: []platform.Permission{platform.WriteBucketPermission(bucket.ID)},
+	}))
+	w := httptest.NewRecorder()
+
+	h.handleWrite(w, r)
+
+	if got, want := w.Code, http.StatusServiceUnavailable; got != want {
+		t.Fatalf("handleWrite() status = %v, want %v: %s", got, want, w.Body.String())
+	}
+	if got, want := w.Header().Get("Retry-After"), "30"; got != want {
+		t.Errorf("handleWrite() Retry-After = %q, want %q", got, want)
+	}
+}
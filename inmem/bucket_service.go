@@ -134,7 +134,7 @@ func (s *Service) FindBucket(ctx context.Context, filter platform.BucketFilter)
 
 func (s *Service) findBuckets(ctx context.Context, filter platform.BucketFilter, opt ...platform.FindOptions) ([]*platform.Bucket, *platform.Error) {
 	// filter by bucket id
-	if filter.ID != nil {
+	if filter.ID != nil && filter.Label == nil {
 		b, err := s.FindBucketByID(ctx, *filter.ID)
 		if err != nil {
 			return nil, &platform.Error{
@@ -173,6 +173,25 @@ func (s *Service) findBuckets(ctx context.Context, filter platform.BucketFilter,
 		}
 	}
 
+	if filter.ID != nil {
+		filterFunc = func(b *platform.Bucket) bool {
+			return b.ID == *filter.ID
+		}
+	}
+
+	if filter.Label != nil {
+		labelled, err := s.FindLabelledResources(ctx, platform.BucketResourceType, *filter.Label)
+		if err != nil {
+			return nil, &platform.Error{
+				Err: err,
+			}
+		}
+		fn := filterFunc
+		filterFunc = func(b *platform.Bucket) bool {
+			return fn(b) && labelled.Has(b.ID, b.OrganizationID)
+		}
+	}
+
 	bs, err := s.filterBuckets(ctx, filterFunc)
 	if err != nil {
 		return nil, &platform.Error{
@@ -249,6 +268,10 @@ func (s *Service) UpdateBucket(ctx context.Context, id platform.ID, upd platform
 		b.RetentionPeriod = *upd.RetentionPeriod
 	}
 
+	if upd.ShardGroupDuration != nil {
+		b.ShardGroupDuration = *upd.ShardGroupDuration
+	}
+
 	s.bucketKV.Store(b.ID.String(), b)
 
 	return b, nil
@@ -263,7 +286,7 @@ func (s *Service) DeleteBucket(ctx context.Context, id platform.ID) error {
 		}
 	}
 	s.bucketKV.Delete(id.String())
-	return s.deleteLabel(ctx, platform.LabelFilter{ResourceID: id})
+	return s.deleteResourceLabelMappings(ctx, id)
 }
 
 // DeleteOrganizationBuckets removes all the buckets for a given org
@@ -22,6 +22,16 @@ func initBucketService(f platformtesting.BucketFields, t *testing.T) (platform.B
 			t.Fatalf("failed to populate buckets")
 		}
 	}
+	for _, l := range f.Labels {
+		if err := s.PutLabel(ctx, l); err != nil {
+			t.Fatalf("failed to populate labels: %v", err)
+		}
+	}
+	for _, m := range f.LabelMappings {
+		if err := s.CreateLabelMapping(ctx, m); err != nil {
+			t.Fatalf("failed to populate label mappings: %v", err)
+		}
+	}
 	return s, OpPrefix, func() {}
 }
 
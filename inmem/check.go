@@ -0,0 +1,114 @@
+package inmem
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.CheckService = (*Service)(nil)
+
+func (s *Service) loadCheck(id platform.ID) (*platform.Check, error) {
+	i, ok := s.checkKV.Load(id.String())
+	if !ok {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  fmt.Sprintf("check with ID %v not found", id),
+		}
+	}
+
+	c := i.(platform.Check)
+	return &c, nil
+}
+
+// FindCheckByID returns a single check by ID.
+func (s *Service) FindCheckByID(ctx context.Context, id platform.ID) (*platform.Check, error) {
+	c, err := s.loadCheck(id)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "inmem/find check by id",
+			Err:  err,
+		}
+	}
+	return c, nil
+}
+
+// FindChecks returns the checks that match filter.
+func (s *Service) FindChecks(ctx context.Context, filter platform.CheckFilter) ([]*platform.Check, error) {
+	cs := []*platform.Check{}
+	s.checkKV.Range(func(k, v interface{}) bool {
+		c := v.(platform.Check)
+		if filter.OrganizationID != nil && c.OrganizationID != *filter.OrganizationID {
+			return true
+		}
+		if filter.TaskID != nil && c.TaskID != *filter.TaskID {
+			return true
+		}
+		cs = append(cs, &c)
+		return true
+	})
+	sort.Slice(cs, func(i, j int) bool {
+		return cs[i].ID < cs[j].ID
+	})
+	return cs, nil
+}
+
+// CreateCheck creates a new check and sets c.ID with the new identifier.
+func (s *Service) CreateCheck(ctx context.Context, c *platform.Check) error {
+	if c.Status == "" {
+		c.Status = platform.CheckActive
+	}
+	if err := c.Valid(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "inmem/create check",
+			Err:  err,
+		}
+	}
+	c.ID = s.IDGenerator.ID()
+	return s.PutCheck(ctx, c)
+}
+
+// PutCheck will put a check without setting an ID.
+func (s *Service) PutCheck(ctx context.Context, c *platform.Check) error {
+	s.checkKV.Store(c.ID.String(), *c)
+	return nil
+}
+
+// UpdateCheck updates a single check with a changeset and returns the updated check.
+func (s *Service) UpdateCheck(ctx context.Context, id platform.ID, upd platform.CheckUpdate) (*platform.Check, error) {
+	op := "inmem/update check"
+	c, err := s.loadCheck(id)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   op,
+			Err:  err,
+		}
+	}
+	upd.Apply(c)
+	if err := c.Valid(); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Err:  err,
+		}
+	}
+	return c, s.PutCheck(ctx, c)
+}
+
+// DeleteCheck removes a check by ID.
+func (s *Service) DeleteCheck(ctx context.Context, id platform.ID) error {
+	if _, err := s.loadCheck(id); err != nil {
+		return &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "inmem/delete check",
+			Err:  err,
+		}
+	}
+	s.checkKV.Delete(id.String())
+	return nil
+}
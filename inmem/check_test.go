@@ -0,0 +1,27 @@
+package inmem
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initCheckService(f platformtesting.CheckFields, t *testing.T) (platform.CheckService, func()) {
+	s := NewService()
+	if f.IDGenerator != nil {
+		s.IDGenerator = f.IDGenerator
+	}
+	ctx := context.Background()
+	for _, v := range f.Checks {
+		if err := s.PutCheck(ctx, v); err != nil {
+			t.Fatalf("failed to populate checks")
+		}
+	}
+	return s, func() {}
+}
+
+func TestCheckService(t *testing.T) {
+	platformtesting.CheckService(initCheckService, t)
+}
@@ -43,7 +43,7 @@ func filterDashboardFn(filter platform.DashboardFilter) func(d *platform.Dashboa
 
 // FindDashboards implements platform.DashboardService interface.
 func (s *Service) FindDashboards(ctx context.Context, filter platform.DashboardFilter, opts platform.FindOptions) ([]*platform.Dashboard, int, error) {
-	if len(filter.IDs) == 1 {
+	if len(filter.IDs) == 1 && filter.Label == nil {
 		d, err := s.FindDashboardByID(ctx, *filter.IDs[0])
 		if err != nil {
 			return nil, 0, err
@@ -52,6 +52,15 @@ func (s *Service) FindDashboards(ctx context.Context, filter platform.DashboardF
 		return []*platform.Dashboard{d}, 1, nil
 	}
 
+	var labelled platform.LabelledResources
+	if filter.Label != nil {
+		ids, err := s.FindLabelledResources(ctx, platform.DashboardResourceType, *filter.Label)
+		if err != nil {
+			return nil, 0, err
+		}
+		labelled = ids
+	}
+
 	var ds []*platform.Dashboard
 	var err error
 	filterF := filterDashboardFn(filter)
@@ -61,7 +70,7 @@ func (s *Service) FindDashboards(ctx context.Context, filter platform.DashboardF
 			return false
 		}
 
-		if filterF(d) {
+		if filterF(d) && (labelled == nil || labelled[d.ID] != nil) {
 			ds = append(ds, d)
 		}
 		return true
@@ -119,7 +128,7 @@ func (s *Service) DeleteDashboard(ctx context.Context, id platform.ID) error {
 		return err
 	}
 	s.dashboardKV.Delete(id.String())
-	return s.deleteLabel(ctx, platform.LabelFilter{ResourceID: id})
+	return s.deleteResourceLabelMappings(ctx, id)
 }
 
 // AddDashboardCell adds a new cell to the dashboard.
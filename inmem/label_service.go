@@ -8,26 +8,52 @@ import (
 	"github.com/influxdata/platform"
 )
 
-func encodeLabelKey(resourceID platform.ID, name string) string {
-	return path.Join(resourceID.String(), name)
+var _ platform.LabelService = (*Service)(nil)
+
+func encodeLabelMappingKey(m *platform.LabelMapping) string {
+	return path.Join(m.ResourceID.String(), m.LabelID.String())
 }
 
-func (s *Service) loadLabel(ctx context.Context, resourceID platform.ID, name string) (*platform.Label, error) {
-	i, ok := s.labelKV.Load(encodeLabelKey(resourceID, name))
+func (s *Service) loadLabel(ctx context.Context, id platform.ID) (*platform.Label, *platform.Error) {
+	i, ok := s.labelKV.Load(id.String())
 	if !ok {
-		return nil, platform.ErrLabelNotFound
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  fmt.Sprintf("label with ID %v not found", id),
+		}
 	}
 
 	l, ok := i.(platform.Label)
 	if !ok {
-		return nil, fmt.Errorf("type %T is not a label", i)
+		return nil, &platform.Error{
+			Code: platform.EInternal,
+			Msg:  fmt.Sprintf("type %T is not a label", i),
+		}
 	}
 
-	return &l, nil
+	return copyLabel(&l), nil
 }
 
-func (s *Service) FindLabelBy(ctx context.Context, resourceID platform.ID, name string) (*platform.Label, error) {
-	return s.loadLabel(ctx, resourceID, name)
+// copyLabel returns a copy of l that does not share its properties.
+func copyLabel(l *platform.Label) *platform.Label {
+	c := *l
+	if l.Properties != nil {
+		c.Properties = make(map[string]string, len(l.Properties))
+		for k, v := range l.Properties {
+			c.Properties[k] = v
+		}
+	}
+	return &c
+}
+
+// FindLabelByID finds a label by its ID.
+func (s *Service) FindLabelByID(ctx context.Context, id platform.ID) (*platform.Label, error) {
+	l, pErr := s.loadLabel(ctx, id)
+	if pErr != nil {
+		pErr.Op = OpPrefix + "find label by id"
+		return nil, pErr
+	}
+	return l, nil
 }
 
 func (s *Service) forEachLabel(ctx context.Context, fn func(m *platform.Label) bool) error {
@@ -38,7 +64,21 @@ func (s *Service) forEachLabel(ctx context.Context, fn func(m *platform.Label) b
 			err = fmt.Errorf("type %T is not a label", v)
 			return false
 		}
-		return fn(&l)
+		return fn(copyLabel(&l))
+	})
+
+	return err
+}
+
+func (s *Service) forEachLabelMapping(ctx context.Context, fn func(m *platform.LabelMapping) bool) error {
+	var err error
+	s.labelMappingKV.Range(func(k, v interface{}) bool {
+		m, ok := v.(platform.LabelMapping)
+		if !ok {
+			err = fmt.Errorf("type %T is not a label mapping", v)
+			return false
+		}
+		return fn(&m)
 	})
 
 	return err
@@ -60,9 +100,10 @@ func (s *Service) filterLabels(ctx context.Context, fn func(m *platform.Label) b
 	return labels, nil
 }
 
+// FindLabels returns a list of labels that match a filter.
 func (s *Service) FindLabels(ctx context.Context, filter platform.LabelFilter, opt ...platform.FindOptions) ([]*platform.Label, error) {
-	if filter.ResourceID.Valid() && filter.Name != "" {
-		l, err := s.FindLabelBy(ctx, filter.ResourceID, filter.Name)
+	if filter.ID.Valid() {
+		l, err := s.FindLabelByID(ctx, filter.ID)
 		if err != nil {
 			return nil, err
 		}
@@ -70,51 +111,218 @@ func (s *Service) FindLabels(ctx context.Context, filter platform.LabelFilter, o
 	}
 
 	filterFunc := func(label *platform.Label) bool {
-		return (!filter.ResourceID.Valid() || (filter.ResourceID == label.ResourceID)) &&
-			(filter.Name == "" || (filter.Name == label.Name))
+		return (filter.OrganizationID == nil || *filter.OrganizationID == label.OrganizationID) &&
+			(filter.Name == "" || filter.Name == label.Name)
 	}
 
-	labels, err := s.filterLabels(ctx, filterFunc)
+	return s.filterLabels(ctx, filterFunc)
+}
+
+// FindResourceLabels returns the labels applied to a resource.
+func (s *Service) FindResourceLabels(ctx context.Context, filter platform.LabelMappingFilter) ([]*platform.Label, error) {
+	var ids []platform.ID
+	err := s.forEachLabelMapping(ctx, func(m *platform.LabelMapping) bool {
+		if m.ResourceID == filter.ResourceID && (filter.ResourceType == "" || filter.ResourceType == m.ResourceType) {
+			ids = append(ids, m.LabelID)
+		}
+		return true
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	labels := []*platform.Label{}
+	for _, id := range ids {
+		l, err := s.FindLabelByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, l)
+	}
+
 	return labels, nil
 }
 
-func (s *Service) CreateLabel(ctx context.Context, l *platform.Label) error {
-	label, _ := s.FindLabelBy(ctx, l.ResourceID, l.Name)
-	if label != nil {
-		return fmt.Errorf("label %s already exists", l.Name)
+// FindLabelledResources returns the resources of type rt that have a label
+// named name.
+func (s *Service) FindLabelledResources(ctx context.Context, rt platform.ResourceType, name string) (platform.LabelledResources, error) {
+	labels, err := s.FindLabels(ctx, platform.LabelFilter{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := make(map[platform.ID]platform.ID, len(labels))
+	for _, l := range labels {
+		orgs[l.ID] = l.OrganizationID
+	}
+
+	labelled := platform.LabelledResources{}
+	err = s.forEachLabelMapping(ctx, func(m *platform.LabelMapping) bool {
+		if orgID, ok := orgs[m.LabelID]; ok && m.ResourceType == rt {
+			labelled[m.ResourceID] = append(labelled[m.ResourceID], orgID)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return labelled, nil
+}
+
+// uniqueLabelName returns an error if another label of the organization of
+// l has the same name.
+func (s *Service) uniqueLabelName(ctx context.Context, l *platform.Label) error {
+	ls, err := s.FindLabels(ctx, platform.LabelFilter{
+		OrganizationID: &l.OrganizationID,
+		Name:           l.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, existing := range ls {
+		if existing.ID != l.ID {
+			return &platform.Error{
+				Code: platform.EConflict,
+				Msg:  fmt.Sprintf("label %s already exists", l.Name),
+			}
+		}
 	}
 
-	s.labelKV.Store(encodeLabelKey(l.ResourceID, l.Name), *l)
 	return nil
 }
 
+// CreateLabel creates a new label.
+func (s *Service) CreateLabel(ctx context.Context, l *platform.Label) error {
+	op := OpPrefix + "create label"
+	if err := l.Validate(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Err:  err,
+		}
+	}
+
+	if err := s.uniqueLabelName(ctx, l); err != nil {
+		return &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   op,
+			Err:  err,
+		}
+	}
+
+	l.ID = s.IDGenerator.ID()
+	return s.PutLabel(ctx, l)
+}
+
+// PutLabel stores a label with its current ID.
 func (s *Service) PutLabel(ctx context.Context, l *platform.Label) error {
-	s.labelKV.Store(encodeLabelKey(l.ResourceID, l.Name), *l)
+	s.labelKV.Store(l.ID.String(), *copyLabel(l))
 	return nil
 }
 
-func (s *Service) DeleteLabel(ctx context.Context, l platform.Label) error {
-	label, err := s.FindLabelBy(ctx, l.ResourceID, l.Name)
-	if label == nil && err != nil {
-		return err
+// CreateLabelMapping applies a label to a resource.
+func (s *Service) CreateLabelMapping(ctx context.Context, m *platform.LabelMapping) error {
+	op := OpPrefix + "create label mapping"
+	if err := m.Validate(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Err:  err,
+		}
+	}
+
+	if _, pErr := s.loadLabel(ctx, m.LabelID); pErr != nil {
+		pErr.Op = op
+		return pErr
+	}
+
+	if _, loaded := s.labelMappingKV.LoadOrStore(encodeLabelMappingKey(m), *m); loaded {
+		return &platform.Error{
+			Code: platform.EConflict,
+			Op:   op,
+			Msg:  "label is already applied to the resource",
+		}
 	}
 
-	s.labelKV.Delete(encodeLabelKey(l.ResourceID, l.Name))
 	return nil
 }
 
-func (s *Service) deleteLabel(ctx context.Context, filter platform.LabelFilter) error {
-	labels, err := s.FindLabels(ctx, filter)
-	if labels == nil && err != nil {
+// UpdateLabel updates a label with a changeset.
+func (s *Service) UpdateLabel(ctx context.Context, id platform.ID, upd platform.LabelUpdate) (*platform.Label, error) {
+	op := OpPrefix + "update label"
+	l, pErr := s.loadLabel(ctx, id)
+	if pErr != nil {
+		pErr.Op = op
+		return nil, pErr
+	}
+
+	if err := upd.Apply(l); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Err:  err,
+		}
+	}
+
+	if err := s.uniqueLabelName(ctx, l); err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   op,
+			Err:  err,
+		}
+	}
+
+	if err := s.PutLabel(ctx, l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// DeleteLabel deletes a label and removes it from every resource.
+func (s *Service) DeleteLabel(ctx context.Context, id platform.ID) error {
+	if _, pErr := s.loadLabel(ctx, id); pErr != nil {
+		pErr.Op = OpPrefix + "delete label"
+		return pErr
+	}
+
+	err := s.forEachLabelMapping(ctx, func(m *platform.LabelMapping) bool {
+		if m.LabelID == id {
+			s.labelMappingKV.Delete(encodeLabelMappingKey(m))
+		}
+		return true
+	})
+	if err != nil {
 		return err
 	}
-	for _, l := range labels {
-		s.labelKV.Delete(encodeLabelKey(l.ResourceID, l.Name))
+
+	s.labelKV.Delete(id.String())
+	return nil
+}
+
+// DeleteLabelMapping removes a label from a resource.
+func (s *Service) DeleteLabelMapping(ctx context.Context, m *platform.LabelMapping) error {
+	key := encodeLabelMappingKey(m)
+	if _, ok := s.labelMappingKV.Load(key); !ok {
+		return &platform.Error{
+			Code: platform.ENotFound,
+			Op:   OpPrefix + "delete label mapping",
+			Msg:  "label is not applied to the resource",
+		}
 	}
 
+	s.labelMappingKV.Delete(key)
 	return nil
 }
+
+// deleteResourceLabelMappings removes every label from a resource.
+func (s *Service) deleteResourceLabelMappings(ctx context.Context, resourceID platform.ID) error {
+	return s.forEachLabelMapping(ctx, func(m *platform.LabelMapping) bool {
+		if m.ResourceID == resourceID {
+			s.labelMappingKV.Delete(encodeLabelMappingKey(m))
+		}
+		return true
+	})
+}
@@ -10,10 +10,16 @@ import (
 
 func initLabelService(f platformtesting.LabelFields, t *testing.T) (platform.LabelService, func()) {
 	s := NewService()
+	s.IDGenerator = f.IDGenerator
 	ctx := context.TODO()
-	for _, m := range f.Labels {
-		if err := s.CreateLabel(ctx, m); err != nil {
-			t.Fatalf("failed to populate labels")
+	for _, l := range f.Labels {
+		if err := s.PutLabel(ctx, l); err != nil {
+			t.Fatalf("failed to populate labels: %v", err)
+		}
+	}
+	for _, m := range f.Mappings {
+		if err := s.CreateLabelMapping(ctx, m); err != nil {
+			t.Fatalf("failed to populate label mappings: %v", err)
 		}
 	}
 
@@ -0,0 +1,108 @@
+package inmem
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.NotificationEndpointService = (*Service)(nil)
+
+func (s *Service) loadNotificationEndpoint(id platform.ID) (*platform.NotificationEndpoint, error) {
+	i, ok := s.notificationEndpointKV.Load(id.String())
+	if !ok {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  fmt.Sprintf("notification endpoint with ID %v not found", id),
+		}
+	}
+
+	e := i.(platform.NotificationEndpoint)
+	return &e, nil
+}
+
+// FindNotificationEndpointByID returns a single notification endpoint by ID.
+func (s *Service) FindNotificationEndpointByID(ctx context.Context, id platform.ID) (*platform.NotificationEndpoint, error) {
+	e, err := s.loadNotificationEndpoint(id)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "inmem/find notification endpoint by id",
+			Err:  err,
+		}
+	}
+	return e, nil
+}
+
+// FindNotificationEndpoints returns the notification endpoints that match filter.
+func (s *Service) FindNotificationEndpoints(ctx context.Context, filter platform.NotificationEndpointFilter) ([]*platform.NotificationEndpoint, error) {
+	es := []*platform.NotificationEndpoint{}
+	s.notificationEndpointKV.Range(func(k, v interface{}) bool {
+		e := v.(platform.NotificationEndpoint)
+		if filter.OrganizationID != nil && e.OrganizationID != *filter.OrganizationID {
+			return true
+		}
+		es = append(es, &e)
+		return true
+	})
+	sort.Slice(es, func(i, j int) bool {
+		return es[i].ID < es[j].ID
+	})
+	return es, nil
+}
+
+// CreateNotificationEndpoint creates a new notification endpoint and sets e.ID with the new identifier.
+func (s *Service) CreateNotificationEndpoint(ctx context.Context, e *platform.NotificationEndpoint) error {
+	if err := e.Valid(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "inmem/create notification endpoint",
+			Err:  err,
+		}
+	}
+	e.ID = s.IDGenerator.ID()
+	return s.PutNotificationEndpoint(ctx, e)
+}
+
+// PutNotificationEndpoint will put a notification endpoint without setting an ID.
+func (s *Service) PutNotificationEndpoint(ctx context.Context, e *platform.NotificationEndpoint) error {
+	s.notificationEndpointKV.Store(e.ID.String(), *e)
+	return nil
+}
+
+// UpdateNotificationEndpoint updates a single notification endpoint with a changeset and returns the updated endpoint.
+func (s *Service) UpdateNotificationEndpoint(ctx context.Context, id platform.ID, upd platform.NotificationEndpointUpdate) (*platform.NotificationEndpoint, error) {
+	op := "inmem/update notification endpoint"
+	e, err := s.loadNotificationEndpoint(id)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   op,
+			Err:  err,
+		}
+	}
+	upd.Apply(e)
+	if err := e.Valid(); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Err:  err,
+		}
+	}
+	return e, s.PutNotificationEndpoint(ctx, e)
+}
+
+// DeleteNotificationEndpoint removes a notification endpoint by ID.
+func (s *Service) DeleteNotificationEndpoint(ctx context.Context, id platform.ID) error {
+	if _, err := s.loadNotificationEndpoint(id); err != nil {
+		return &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "inmem/delete notification endpoint",
+			Err:  err,
+		}
+	}
+	s.notificationEndpointKV.Delete(id.String())
+	return nil
+}
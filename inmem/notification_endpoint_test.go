@@ -0,0 +1,27 @@
+package inmem
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initNotificationEndpointService(f platformtesting.NotificationEndpointFields, t *testing.T) (platform.NotificationEndpointService, func()) {
+	s := NewService()
+	if f.IDGenerator != nil {
+		s.IDGenerator = f.IDGenerator
+	}
+	ctx := context.Background()
+	for _, v := range f.NotificationEndpoints {
+		if err := s.PutNotificationEndpoint(ctx, v); err != nil {
+			t.Fatalf("failed to populate notification endpoints")
+		}
+	}
+	return s, func() {}
+}
+
+func TestNotificationEndpointService(t *testing.T) {
+	platformtesting.NotificationEndpointService(initNotificationEndpointService, t)
+}
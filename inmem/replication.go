@@ -0,0 +1,111 @@
+package inmem
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.ReplicationRuleService = (*Service)(nil)
+
+func (s *Service) loadReplicationRule(id platform.ID) (*platform.ReplicationRule, error) {
+	i, ok := s.replicationRuleKV.Load(id.String())
+	if !ok {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  fmt.Sprintf("replication rule with ID %v not found", id),
+		}
+	}
+
+	r := i.(platform.ReplicationRule)
+	return &r, nil
+}
+
+// FindReplicationRuleByID returns a single replication rule by ID.
+func (s *Service) FindReplicationRuleByID(ctx context.Context, id platform.ID) (*platform.ReplicationRule, error) {
+	r, err := s.loadReplicationRule(id)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "inmem/find replication rule by id",
+			Err:  err,
+		}
+	}
+	return r, nil
+}
+
+// FindReplicationRules returns the replication rules that match filter.
+func (s *Service) FindReplicationRules(ctx context.Context, filter platform.ReplicationRuleFilter) ([]*platform.ReplicationRule, error) {
+	rs := []*platform.ReplicationRule{}
+	s.replicationRuleKV.Range(func(k, v interface{}) bool {
+		r := v.(platform.ReplicationRule)
+		if filter.OrganizationID != nil && r.OrganizationID != *filter.OrganizationID {
+			return true
+		}
+		if filter.BucketID != nil && r.BucketID != *filter.BucketID {
+			return true
+		}
+		rs = append(rs, &r)
+		return true
+	})
+	sort.Slice(rs, func(i, j int) bool {
+		return rs[i].ID < rs[j].ID
+	})
+	return rs, nil
+}
+
+// CreateReplicationRule creates a new replication rule and sets r.ID with the new identifier.
+func (s *Service) CreateReplicationRule(ctx context.Context, r *platform.ReplicationRule) error {
+	if err := r.Valid(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "inmem/create replication rule",
+			Err:  err,
+		}
+	}
+	r.ID = s.IDGenerator.ID()
+	return s.PutReplicationRule(ctx, r)
+}
+
+// PutReplicationRule will put a replication rule without setting an ID.
+func (s *Service) PutReplicationRule(ctx context.Context, r *platform.ReplicationRule) error {
+	s.replicationRuleKV.Store(r.ID.String(), *r)
+	return nil
+}
+
+// UpdateReplicationRule updates a single replication rule with a changeset and returns the updated endpoint.
+func (s *Service) UpdateReplicationRule(ctx context.Context, id platform.ID, upd platform.ReplicationRuleUpdate) (*platform.ReplicationRule, error) {
+	op := "inmem/update replication rule"
+	r, err := s.loadReplicationRule(id)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   op,
+			Err:  err,
+		}
+	}
+	upd.Apply(r)
+	if err := r.Valid(); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Err:  err,
+		}
+	}
+	return r, s.PutReplicationRule(ctx, r)
+}
+
+// DeleteReplicationRule removes a replication rule by ID.
+func (s *Service) DeleteReplicationRule(ctx context.Context, id platform.ID) error {
+	if _, err := s.loadReplicationRule(id); err != nil {
+		return &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "inmem/delete replication rule",
+			Err:  err,
+		}
+	}
+	s.replicationRuleKV.Delete(id.String())
+	return nil
+}
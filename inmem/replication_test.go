@@ -0,0 +1,27 @@
+package inmem
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initReplicationRuleService(f platformtesting.ReplicationRuleFields, t *testing.T) (platform.ReplicationRuleService, func()) {
+	s := NewService()
+	if f.IDGenerator != nil {
+		s.IDGenerator = f.IDGenerator
+	}
+	ctx := context.Background()
+	for _, v := range f.ReplicationRules {
+		if err := s.PutReplicationRule(ctx, v); err != nil {
+			t.Fatalf("failed to populate replication rules")
+		}
+	}
+	return s, func() {}
+}
+
+func TestReplicationRuleService(t *testing.T) {
+	platformtesting.ReplicationRuleService(initReplicationRuleService, t)
+}
@@ -14,20 +14,26 @@ const OpPrefix = "inmem/"
 
 // Service implements various top level services.
 type Service struct {
-	authorizationKV       sync.Map
-	organizationKV        sync.Map
-	bucketKV              sync.Map
-	userKV                sync.Map
-	dashboardKV           sync.Map
-	viewKV                sync.Map
-	macroKV               sync.Map
-	dbrpMappingKV         sync.Map
-	userResourceMappingKV sync.Map
-	labelKV               sync.Map
-	scraperTargetKV       sync.Map
-	telegrafConfigKV      sync.Map
-	onboardingKV          sync.Map
-	basicAuthKV           sync.Map
+	authorizationKV        sync.Map
+	organizationKV         sync.Map
+	bucketKV               sync.Map
+	userKV                 sync.Map
+	dashboardKV            sync.Map
+	viewKV                 sync.Map
+	macroKV                sync.Map
+	dbrpMappingKV          sync.Map
+	userResourceMappingKV  sync.Map
+	labelKV                sync.Map
+	labelMappingKV         sync.Map
+	scraperTargetKV        sync.Map
+	telegrafConfigKV       sync.Map
+	telegrafAgentKV        sync.Map
+	checkKV                sync.Map
+	notificationEndpointKV sync.Map
+	replicationRuleKV      sync.Map
+	taskTemplateKV         sync.Map
+	onboardingKV           sync.Map
+	basicAuthKV            sync.Map
 
 	TokenGenerator platform.TokenGenerator
 	IDGenerator    platform.IDGenerator
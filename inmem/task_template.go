@@ -0,0 +1,120 @@
+package inmem
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.TaskTemplateService = (*Service)(nil)
+
+func (s *Service) loadTaskTemplate(id platform.ID) (*platform.TaskTemplate, error) {
+	i, ok := s.taskTemplateKV.Load(id.String())
+	if !ok {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  fmt.Sprintf("task template with ID %v not found", id),
+		}
+	}
+
+	t := i.(platform.TaskTemplate)
+	return &t, nil
+}
+
+// FindTaskTemplateByID returns a single task template by ID.
+func (s *Service) FindTaskTemplateByID(ctx context.Context, id platform.ID) (*platform.TaskTemplate, error) {
+	t, err := s.loadTaskTemplate(id)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "inmem/find task template by id",
+			Err:  err,
+		}
+	}
+	return t, nil
+}
+
+// FindTaskTemplates returns the task templates that match filter.
+func (s *Service) FindTaskTemplates(ctx context.Context, filter platform.TaskTemplateFilter) ([]*platform.TaskTemplate, error) {
+	ts := []*platform.TaskTemplate{}
+	s.taskTemplateKV.Range(func(k, v interface{}) bool {
+		t := v.(platform.TaskTemplate)
+		if filter.OrganizationID != nil && t.OrganizationID != *filter.OrganizationID {
+			return true
+		}
+		if filter.TaskID != nil && !hasTaskTemplateInstance(&t, *filter.TaskID) {
+			return true
+		}
+		ts = append(ts, &t)
+		return true
+	})
+	sort.Slice(ts, func(i, j int) bool {
+		return ts[i].ID < ts[j].ID
+	})
+	return ts, nil
+}
+
+func hasTaskTemplateInstance(t *platform.TaskTemplate, taskID platform.ID) bool {
+	for _, inst := range t.Instances {
+		if inst.TaskID == taskID {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateTaskTemplate creates a new task template and sets t.ID with the new identifier.
+func (s *Service) CreateTaskTemplate(ctx context.Context, t *platform.TaskTemplate) error {
+	if err := t.Valid(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "inmem/create task template",
+			Err:  err,
+		}
+	}
+	t.ID = s.IDGenerator.ID()
+	return s.PutTaskTemplate(ctx, t)
+}
+
+// PutTaskTemplate will put a task template without setting an ID.
+func (s *Service) PutTaskTemplate(ctx context.Context, t *platform.TaskTemplate) error {
+	s.taskTemplateKV.Store(t.ID.String(), *t)
+	return nil
+}
+
+// UpdateTaskTemplate updates a single task template with a changeset and returns the updated template.
+func (s *Service) UpdateTaskTemplate(ctx context.Context, id platform.ID, upd platform.TaskTemplateUpdate) (*platform.TaskTemplate, error) {
+	op := "inmem/update task template"
+	t, err := s.loadTaskTemplate(id)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   op,
+			Err:  err,
+		}
+	}
+	upd.Apply(t)
+	if err := t.Valid(); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Err:  err,
+		}
+	}
+	return t, s.PutTaskTemplate(ctx, t)
+}
+
+// DeleteTaskTemplate removes a task template by ID.
+func (s *Service) DeleteTaskTemplate(ctx context.Context, id platform.ID) error {
+	if _, err := s.loadTaskTemplate(id); err != nil {
+		return &platform.Error{
+			Code: platform.ErrorCode(err),
+			Op:   "inmem/delete task template",
+			Err:  err,
+		}
+	}
+	s.taskTemplateKV.Delete(id.String())
+	return nil
+}
@@ -0,0 +1,27 @@
+package inmem
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initTaskTemplateService(f platformtesting.TaskTemplateFields, t *testing.T) (platform.TaskTemplateService, func()) {
+	s := NewService()
+	if f.IDGenerator != nil {
+		s.IDGenerator = f.IDGenerator
+	}
+	ctx := context.Background()
+	for _, v := range f.TaskTemplates {
+		if err := s.PutTaskTemplate(ctx, v); err != nil {
+			t.Fatalf("failed to populate task templates")
+		}
+	}
+	return s, func() {}
+}
+
+func TestTaskTemplateService(t *testing.T) {
+	platformtesting.TaskTemplateService(initTaskTemplateService, t)
+}
@@ -44,7 +44,7 @@ func (s *Service) findTelegrafConfigByID(ctx context.Context, id platform.ID) (*
 // FindTelegrafConfig returns the first telegraf config that matches filter.
 func (s *Service) FindTelegrafConfig(ctx context.Context, filter platform.UserResourceMappingFilter) (*platform.TelegrafConfig, error) {
 	op := "inmem/find telegraf config"
-	tcs, n, err := s.FindTelegrafConfigs(ctx, filter)
+	tcs, n, err := s.FindTelegrafConfigs(ctx, platform.TelegrafConfigFilter{UserResourceMappingFilter: filter})
 	if err != nil {
 		return nil, err
 	}
@@ -57,9 +57,9 @@ func (s *Service) FindTelegrafConfig(ctx context.Context, filter platform.UserRe
 	}
 }
 
-func (s *Service) findTelegrafConfigs(ctx context.Context, filter platform.UserResourceMappingFilter, opt ...platform.FindOptions) ([]*platform.TelegrafConfig, int, *platform.Error) {
+func (s *Service) findTelegrafConfigs(ctx context.Context, filter platform.TelegrafConfigFilter, opt ...platform.FindOptions) ([]*platform.TelegrafConfig, int, *platform.Error) {
 	tcs := make([]*platform.TelegrafConfig, 0)
-	m, _, err := s.FindUserResourceMappings(ctx, filter)
+	m, _, err := s.FindUserResourceMappings(ctx, filter.UserResourceMappingFilter)
 	if err != nil {
 		return nil, 0, &platform.Error{
 			Err: err,
@@ -68,7 +68,18 @@ func (s *Service) findTelegrafConfigs(ctx context.Context, filter platform.UserR
 	if len(m) == 0 {
 		return tcs, 0, nil
 	}
+	var labelled platform.LabelledResources
+	if filter.Label != nil {
+		if labelled, err = s.FindLabelledResources(ctx, platform.TelegrafResourceType, *filter.Label); err != nil {
+			return nil, 0, &platform.Error{
+				Err: err,
+			}
+		}
+	}
 	for _, item := range m {
+		if labelled != nil && labelled[item.ResourceID] == nil {
+			continue
+		}
 		tc, err := s.findTelegrafConfigByID(ctx, item.ResourceID)
 		if err != nil {
 			return nil, 0, &platform.Error{
@@ -78,7 +89,7 @@ func (s *Service) findTelegrafConfigs(ctx context.Context, filter platform.UserR
 		}
 		tcs = append(tcs, tc)
 	}
-	if len(tcs) == 0 {
+	if len(tcs) == 0 && labelled == nil {
 		return nil, 0, &platform.Error{
 			Msg: "inconsistent user resource mapping and telegraf config",
 		}
@@ -88,7 +99,7 @@ func (s *Service) findTelegrafConfigs(ctx context.Context, filter platform.UserR
 
 // FindTelegrafConfigs returns a list of telegraf configs that match filter and the total count of matching telegraf configs.
 // Additional options provide pagination & sorting.
-func (s *Service) FindTelegrafConfigs(ctx context.Context, filter platform.UserResourceMappingFilter, opt ...platform.FindOptions) (tcs []*platform.TelegrafConfig, n int, err error) {
+func (s *Service) FindTelegrafConfigs(ctx context.Context, filter platform.TelegrafConfigFilter, opt ...platform.FindOptions) (tcs []*platform.TelegrafConfig, n int, err error) {
 	op := "inmem/find telegraf configs"
 	var pErr *platform.Error
 	tcs, n, pErr = s.findTelegrafConfigs(ctx, filter)
@@ -174,6 +185,14 @@ func (s *Service) DeleteTelegrafConfig(ctx context.Context, id platform.ID) erro
 		return pErr
 	}
 	s.telegrafConfigKV.Delete(id)
+	s.deleteTelegrafAgents(ctx, id)
+
+	if err := s.deleteResourceLabelMappings(ctx, id); err != nil {
+		return &platform.Error{
+			Op:  op,
+			Err: err,
+		}
+	}
 
 	err = s.deleteUserResourceMapping(ctx, platform.UserResourceMappingFilter{
 		ResourceID:   id,
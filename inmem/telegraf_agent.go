@@ -0,0 +1,57 @@
+package inmem
+
+import (
+	"context"
+	"sort"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.TelegrafAgentService = (*Service)(nil)
+
+func telegrafAgentKey(id platform.ID, hostname string) string {
+	return id.String() + "/" + hostname
+}
+
+// FindTelegrafAgents returns the telegraf agents that match filter.
+func (s *Service) FindTelegrafAgents(ctx context.Context, filter platform.TelegrafAgentFilter) ([]*platform.TelegrafAgent, error) {
+	as := []*platform.TelegrafAgent{}
+	s.telegrafAgentKV.Range(func(k, v interface{}) bool {
+		a := v.(platform.TelegrafAgent)
+		if filter.TelegrafConfigID != nil && a.TelegrafConfigID != *filter.TelegrafConfigID {
+			return true
+		}
+		if filter.Hostname != nil && a.Hostname != *filter.Hostname {
+			return true
+		}
+		as = append(as, &a)
+		return true
+	})
+	sort.Slice(as, func(i, j int) bool {
+		return telegrafAgentKey(as[i].TelegrafConfigID, as[i].Hostname) < telegrafAgentKey(as[j].TelegrafConfigID, as[j].Hostname)
+	})
+	return as, nil
+}
+
+// CheckInTelegrafAgent records that an agent fetched its config, adding the
+// agent if it has not checked in before.
+func (s *Service) CheckInTelegrafAgent(ctx context.Context, a *platform.TelegrafAgent) error {
+	if err := a.Valid(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "inmem/check in telegraf agent",
+			Err:  err,
+		}
+	}
+	s.telegrafAgentKV.Store(telegrafAgentKey(a.TelegrafConfigID, a.Hostname), *a)
+	return nil
+}
+
+func (s *Service) deleteTelegrafAgents(ctx context.Context, id platform.ID) {
+	s.telegrafAgentKV.Range(func(k, v interface{}) bool {
+		if a := v.(platform.TelegrafAgent); a.TelegrafConfigID == id {
+			s.telegrafAgentKV.Delete(k)
+		}
+		return true
+	})
+}
@@ -0,0 +1,24 @@
+package inmem
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initTelegrafAgentService(f platformtesting.TelegrafAgentFields, t *testing.T) (platform.TelegrafAgentService, func()) {
+	s := NewService()
+	ctx := context.Background()
+	for _, a := range f.TelegrafAgents {
+		if err := s.CheckInTelegrafAgent(ctx, a); err != nil {
+			t.Fatalf("failed to populate telegraf agents")
+		}
+	}
+	return s, func() {}
+}
+
+func TestTelegrafAgentService(t *testing.T) {
+	platformtesting.TelegrafAgentService(initTelegrafAgentService, t)
+}
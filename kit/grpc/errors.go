@@ -25,6 +25,8 @@ func ToStatus(err *platform.Error) (*status.Status, error) {
 		c = codes.InvalidArgument
 	case platform.EUnavailable:
 		c = codes.Unavailable
+	case platform.EForbidden:
+		c = codes.PermissionDenied
 	}
 
 	buf, jerr := json.Marshal(err)
@@ -66,6 +66,17 @@ func TestToStatus(t *testing.T) {
 			wantCode:    codes.Unavailable,
 			wantMessage: `{"code":"unavailable","msg":"howdy","op":"kit/grpc","err":"error"}`,
 		},
+		{
+			name: "encode forbidden error",
+			err: &platform.Error{
+				Err:  fmt.Errorf("error"),
+				Op:   "kit/grpc",
+				Code: platform.EForbidden,
+				Msg:  "howdy",
+			},
+			wantCode:    codes.PermissionDenied,
+			wantMessage: `{"code":"forbidden","msg":"howdy","op":"kit/grpc","err":"error"}`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
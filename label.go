@@ -1,46 +1,161 @@
-// Resource types that can have labels:
-// Dashboards, Users, Tokens, Buckets
-
 package platform
 
 import (
 	"context"
-	"errors"
 )
 
 // ErrLabelNotFound is the error for a missing Label.
 const ErrLabelNotFound = ChronografError("label not found")
 
+// LabelService represents a service for managing labels and the resources
+// they are applied to.
 type LabelService interface {
-	// FindLabels returns a list of labels that match a filter
+	// FindLabelByID returns a single label by ID.
+	FindLabelByID(ctx context.Context, id ID) (*Label, error)
+
+	// FindLabels returns a list of labels that match a filter.
 	FindLabels(ctx context.Context, filter LabelFilter, opt ...FindOptions) ([]*Label, error)
 
-	// CreateLabel creates a new label
+	// FindResourceLabels returns a list of labels applied to a resource.
+	FindResourceLabels(ctx context.Context, filter LabelMappingFilter) ([]*Label, error)
+
+	// CreateLabel creates a new label.
 	CreateLabel(ctx context.Context, l *Label) error
 
-	// DeleteLabel deletes a label
-	DeleteLabel(ctx context.Context, l Label) error
+	// CreateLabelMapping applies a label to a resource.
+	CreateLabelMapping(ctx context.Context, m *LabelMapping) error
+
+	// UpdateLabel updates a label with a changeset.
+	UpdateLabel(ctx context.Context, id ID, upd LabelUpdate) (*Label, error)
+
+	// DeleteLabel deletes a label and removes it from every resource.
+	DeleteLabel(ctx context.Context, id ID) error
+
+	// DeleteLabelMapping removes a label from a resource.
+	DeleteLabelMapping(ctx context.Context, m *LabelMapping) error
 }
 
+// Label is a tag owned by an organization that can be applied to any
+// resource of that organization.
 type Label struct {
-	ResourceID ID     `json:"resource_id"`
-	Name       string `json:"name"`
+	ID             ID                `json:"id,omitempty"`
+	OrganizationID ID                `json:"orgID"`
+	Name           string            `json:"name"`
+	Properties     map[string]string `json:"properties,omitempty"`
 }
 
 // Validate returns an error if the label is invalid.
 func (l *Label) Validate() error {
-	if !l.ResourceID.Valid() {
-		return errors.New("resourceID is required")
+	if l.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "label name is required",
+		}
 	}
 
-	if l.Name == "" {
-		return errors.New("label name is required")
+	if !l.OrganizationID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "organization ID is required",
+		}
 	}
 
 	return nil
 }
 
+// LabelMapping applies a label to a resource.
+type LabelMapping struct {
+	LabelID      ID           `json:"labelID"`
+	ResourceID   ID           `json:"resourceID"`
+	ResourceType ResourceType `json:"resourceType"`
+}
+
+// Validate returns an error if the mapping is invalid.
+func (m *LabelMapping) Validate() error {
+	if !m.LabelID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "label ID is required",
+		}
+	}
+
+	if !m.ResourceID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "resource ID is required",
+		}
+	}
+
+	switch m.ResourceType {
+	case DashboardResourceType, BucketResourceType, TaskResourceType, OrgResourceType, ViewResourceType, TelegrafResourceType:
+	default:
+		return &Error{
+			Code: EInvalid,
+			Msg:  "a valid resource type is required",
+		}
+	}
+
+	return nil
+}
+
+// LabelUpdate is the changeset for a label. Properties are merged into the
+// label's properties, and a property set to the empty string is removed.
+type LabelUpdate struct {
+	Name       *string           `json:"name,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// Apply applies an update to a label.
+func (u LabelUpdate) Apply(l *Label) error {
+	if u.Name != nil {
+		if *u.Name == "" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "label name is required",
+			}
+		}
+		l.Name = *u.Name
+	}
+
+	if len(u.Properties) > 0 && l.Properties == nil {
+		l.Properties = make(map[string]string, len(u.Properties))
+	}
+	for k, v := range u.Properties {
+		if v == "" {
+			delete(l.Properties, k)
+			continue
+		}
+		l.Properties[k] = v
+	}
+
+	return nil
+}
+
+// LabelFilter represents a set of filters that restrict the returned labels.
 type LabelFilter struct {
-	ResourceID ID
-	Name       string
+	ID             ID
+	OrganizationID *ID
+	Name           string
+}
+
+// LabelMappingFilter restricts the labels returned to those applied to a
+// resource.
+type LabelMappingFilter struct {
+	ResourceID   ID
+	ResourceType ResourceType
+}
+
+// LabelledResources maps the IDs of resources to the IDs of the
+// organizations of the labels they have.
+type LabelledResources map[ID][]ID
+
+// Has returns true if the resource with id has a label of the organization
+// with orgID.
+func (r LabelledResources) Has(id, orgID ID) bool {
+	for _, o := range r[id] {
+		if o == orgID {
+			return true
+		}
+	}
+	return false
 }
@@ -9,8 +9,8 @@ import (
 
 func TestLabelValidate(t *testing.T) {
 	type fields struct {
-		ResourceID platform.ID
-		Name       string
+		OrganizationID platform.ID
+		Name           string
 	}
 	tests := []struct {
 		name    string
@@ -20,12 +20,12 @@ func TestLabelValidate(t *testing.T) {
 		{
 			name: "valid label",
 			fields: fields{
-				ResourceID: platformtesting.MustIDBase16("020f755c3c082000"),
-				Name:       "iot",
+				OrganizationID: platformtesting.MustIDBase16("020f755c3c082000"),
+				Name:           "iot",
 			},
 		},
 		{
-			name: "label requires a resourceid",
+			name: "label requires an organization",
 			fields: fields{
 				Name: "iot",
 			},
@@ -34,7 +34,7 @@ func TestLabelValidate(t *testing.T) {
 		{
 			name: "label requires a name",
 			fields: fields{
-				ResourceID: platformtesting.MustIDBase16("020f755c3c082000"),
+				OrganizationID: platformtesting.MustIDBase16("020f755c3c082000"),
 			},
 			wantErr: true,
 		},
@@ -42,8 +42,8 @@ func TestLabelValidate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m := platform.Label{
-				ResourceID: tt.fields.ResourceID,
-				Name:       tt.fields.Name,
+				OrganizationID: tt.fields.OrganizationID,
+				Name:           tt.fields.Name,
 			}
 			if err := m.Validate(); (err != nil) != tt.wantErr {
 				t.Errorf("Label.Validate() error = %v, wantErr %v", err, tt.wantErr)
@@ -51,3 +51,52 @@ func TestLabelValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestLabelMappingValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping platform.LabelMapping
+		wantErr bool
+	}{
+		{
+			name: "valid mapping",
+			mapping: platform.LabelMapping{
+				LabelID:      platformtesting.MustIDBase16("020f755c3c082000"),
+				ResourceID:   platformtesting.MustIDBase16("020f755c3c082001"),
+				ResourceType: platform.DashboardResourceType,
+			},
+		},
+		{
+			name: "mapping requires a label",
+			mapping: platform.LabelMapping{
+				ResourceID:   platformtesting.MustIDBase16("020f755c3c082001"),
+				ResourceType: platform.DashboardResourceType,
+			},
+			wantErr: true,
+		},
+		{
+			name: "mapping requires a resource",
+			mapping: platform.LabelMapping{
+				LabelID:      platformtesting.MustIDBase16("020f755c3c082000"),
+				ResourceType: platform.DashboardResourceType,
+			},
+			wantErr: true,
+		},
+		{
+			name: "mapping requires a known resource type",
+			mapping: platform.LabelMapping{
+				LabelID:      platformtesting.MustIDBase16("020f755c3c082000"),
+				ResourceID:   platformtesting.MustIDBase16("020f755c3c082001"),
+				ResourceType: platform.ResourceType("widget"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.mapping.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("LabelMapping.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
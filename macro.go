@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 )
 
 // MacroService describes a service for managing Macros
@@ -27,6 +28,29 @@ type MacroService interface {
 	DeleteMacro(ctx context.Context, id ID) error
 }
 
+// MacroEvaluationService describes a service for expanding macros into the
+// values they can take on.
+type MacroEvaluationService interface {
+	// EvaluateMacro returns the values that the macro with the provided ID
+	// can take on. Query macros are evaluated by running their query.
+	EvaluateMacro(ctx context.Context, id ID, opts MacroEvaluationOptions) ([]string, error)
+
+	// BindMacros returns a value for every macro that query references,
+	// directly or through the queries of other macros. Macros already present
+	// in opts.Bindings keep the provided value.
+	BindMacros(ctx context.Context, query string, opts MacroEvaluationOptions) (map[string]string, error)
+}
+
+// MacroEvaluationOptions are the options used when evaluating macros.
+type MacroEvaluationOptions struct {
+	// Authorization is the authorization that query macros are run under.
+	Authorization *Authorization
+	// OrganizationID is the organization that query macros are run in.
+	OrganizationID ID
+	// Bindings are values for macros that have already been chosen.
+	Bindings map[string]string
+}
+
 // A Macro describes a keyword that can be expanded into several possible
 // values when used in an InfluxQL or Flux query
 type Macro struct {
@@ -186,3 +210,27 @@ func (a *MacroArguments) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// macroReferencePattern matches references to macros in query text. Flux
+// queries reference a macro as a member of the `v` record (v.name) and
+// InfluxQL queries wrap the macro name in colons (:name:).
+var macroReferencePattern = regexp.MustCompile(`\bv\.([A-Za-z_][A-Za-z0-9_]*)|:([A-Za-z_][A-Za-z0-9_]*):`)
+
+// MacroReferences returns the unique names of the macros referenced in query,
+// in the order in which they first appear.
+func MacroReferences(query string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, m := range macroReferencePattern.FindAllStringSubmatch(query, -1) {
+		name := m[1]
+		if name == "" {
+			name = m[2]
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
@@ -112,3 +112,34 @@ func TestMacro_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestMacroReferences(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "flux",
+			query: `from(bucket: v.bucket) |> range(start: v.timeRangeStart) |> filter(fn: (r) => r.host == v.bucket)`,
+			want:  []string{"bucket", "timeRangeStart"},
+		},
+		{
+			name:  "influxql",
+			query: `SELECT mean("usage_idle") FROM ":db:".":rp:"."cpu" WHERE host = ':host:'`,
+			want:  []string{"db", "rp", "host"},
+		},
+		{
+			name:  "no references",
+			query: `from(bucket: "telegraf") |> range(start: -1h) |> filter(fn: (r) => r.cpu == "cpu-total")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := platform.MacroReferences(tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MacroReferences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Package macros evaluates macros and binds their values into queries.
+package macros
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var fluxStringEscaper = strings.NewReplacer(
+	"\\", `\\`,
+	"\"", `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+)
+
+// BindFlux returns query with a `v` record holding bindings prepended to it,
+// so that the query can reference each macro as v.name. The query is returned
+// unchanged when there are no bindings.
+func BindFlux(query string, bindings map[string]string) (string, error) {
+	if len(bindings) == 0 {
+		return query, nil
+	}
+
+	names, err := bindingNames(bindings)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("v = {")
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(name)
+		b.WriteString(`: "`)
+		b.WriteString(fluxStringEscaper.Replace(bindings[name]))
+		b.WriteString(`"`)
+	}
+	b.WriteString("}\n")
+	b.WriteString(query)
+	return b.String(), nil
+}
+
+// BindInfluxQL returns query with every :name: reference replaced by the
+// value bound to name. References to macros without a binding are left as is.
+func BindInfluxQL(query string, bindings map[string]string) (string, error) {
+	names, err := bindingNames(bindings)
+	if err != nil {
+		return "", err
+	}
+
+	oldnew := make([]string, 0, 2*len(names))
+	for _, name := range names {
+		oldnew = append(oldnew, ":"+name+":", bindings[name])
+	}
+	return strings.NewReplacer(oldnew...).Replace(query), nil
+}
+
+// bindingNames returns the sorted names of bindings, or an error if any of
+// them cannot be referenced from a query.
+func bindingNames(bindings map[string]string) ([]string, error) {
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		if !identifierPattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid macro name %q", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
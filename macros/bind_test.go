@@ -0,0 +1,70 @@
+package macros_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/platform/macros"
+)
+
+func TestBindFlux(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		bindings map[string]string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:  "no bindings",
+			query: `from(bucket: "telegraf")`,
+			want:  `from(bucket: "telegraf")`,
+		},
+		{
+			name:     "sorted bindings",
+			query:    `from(bucket: v.bucket) |> filter(fn: (r) => r.host == v.host)`,
+			bindings: map[string]string{"host": "a", "bucket": "telegraf"},
+			want: `v = {bucket: "telegraf", host: "a"}
+from(bucket: v.bucket) |> filter(fn: (r) => r.host == v.host)`,
+		},
+		{
+			name:     "escaped values",
+			query:    `from(bucket: v.bucket)`,
+			bindings: map[string]string{"bucket": "a \"quoted\"\\\nbucket"},
+			want: `v = {bucket: "a \"quoted\"\\\nbucket"}
+from(bucket: v.bucket)`,
+		},
+		{
+			name:     "invalid name",
+			query:    `from(bucket: v.bucket)`,
+			bindings: map[string]string{"not a name": "x"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := macros.BindFlux(tt.query, tt.bindings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BindFlux() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("BindFlux() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBindInfluxQL(t *testing.T) {
+	got, err := macros.BindInfluxQL(`SELECT mean("usage_idle") FROM ":db:".":rp:"."cpu" WHERE host = ':host:'`, map[string]string{
+		"db":   "telegraf",
+		"rp":   "autogen",
+		"host": "a",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `SELECT mean("usage_idle") FROM "telegraf"."autogen"."cpu" WHERE host = 'a'`; got != want {
+		t.Errorf("BindInfluxQL() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,357 @@
+package macros
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/influxql"
+)
+
+var _ platform.MacroEvaluationService = (*Evaluator)(nil)
+
+// Evaluator evaluates macros, running the queries of query macros through a
+// ProxyQueryService.
+type Evaluator struct {
+	MacroService      platform.MacroService
+	ProxyQueryService query.ProxyQueryService
+
+	// DBRPMappingService is used to compile InfluxQL query macros. When it
+	// is nil, InfluxQL query macros cannot be evaluated.
+	DBRPMappingService platform.DBRPMappingService
+}
+
+// EvaluateMacro returns the values that the macro with the provided ID can
+// take on. Constant macros return their values, map macros return their keys
+// and query macros return the _value column of every table of the query
+// result. Macros referenced by the query of a query macro are bound first.
+func (e *Evaluator) EvaluateMacro(ctx context.Context, id platform.ID, opts platform.MacroEvaluationOptions) ([]string, error) {
+	m, err := e.MacroService.FindMacroByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	byName, err := e.macrosByName(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bindings := copyBindings(opts.Bindings)
+	if q, ok := queryValues(m); ok {
+		// A macro cannot be bound while evaluating its own values.
+		delete(bindings, m.Name)
+		if err := e.bind(ctx, platform.MacroReferences(q.Query), byName, bindings, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	return e.values(ctx, m, bindings, opts)
+}
+
+// BindMacros returns a value for every macro referenced by q. Macros are
+// bound in dependency order, so that the query of a query macro only runs
+// once every macro it references has a value. A query macro binds its first
+// selected value when the query returns it and its first value otherwise.
+func (e *Evaluator) BindMacros(ctx context.Context, q string, opts platform.MacroEvaluationOptions) (map[string]string, error) {
+	byName, err := e.macrosByName(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bindings := copyBindings(opts.Bindings)
+	if err := e.bind(ctx, platform.MacroReferences(q), byName, bindings, opts); err != nil {
+		return nil, err
+	}
+	return bindings, nil
+}
+
+func (e *Evaluator) bind(ctx context.Context, refs []string, byName map[string]*platform.Macro, bindings map[string]string, opts platform.MacroEvaluationOptions) error {
+	order, err := dependencyOrder(refs, byName, bindings)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range order {
+		vs, err := e.values(ctx, m, bindings, opts)
+		if err != nil {
+			return err
+		}
+
+		v, err := selectValue(m, vs)
+		if err != nil {
+			return err
+		}
+		bindings[m.Name] = v
+	}
+
+	return nil
+}
+
+// dependencyOrder returns the unbound macros that refs depend on, ordered
+// so that every macro comes after the macros its query references.
+func dependencyOrder(refs []string, byName map[string]*platform.Macro, bindings map[string]string) ([]*platform.Macro, error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	var order []*platform.Macro
+	state := map[string]int{}
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		if _, ok := bindings[name]; ok {
+			return nil
+		}
+
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("macro dependency cycle: %v", append(path, name)),
+			}
+		}
+
+		m, ok := byName[name]
+		if !ok {
+			return &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("macro %q is referenced but not defined", name),
+			}
+		}
+
+		state[name] = visiting
+		if q, ok := queryValues(m); ok {
+			for _, dep := range platform.MacroReferences(q.Query) {
+				if err := visit(dep, append(path, name)); err != nil {
+					return err
+				}
+			}
+		}
+		state[name] = visited
+
+		order = append(order, m)
+		return nil
+	}
+
+	for _, name := range refs {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// values returns the values that m can take on, given bindings for the
+// macros that its query references.
+func (e *Evaluator) values(ctx context.Context, m *platform.Macro, bindings map[string]string, opts platform.MacroEvaluationOptions) ([]string, error) {
+	if m.Arguments == nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("macro %q has no arguments", m.Name),
+		}
+	}
+
+	switch vs := m.Arguments.Values.(type) {
+	case platform.MacroConstantValues:
+		return []string(vs), nil
+	case platform.MacroMapValues:
+		keys := make([]string, 0, len(vs))
+		for k := range vs {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return keys, nil
+	case platform.MacroQueryValues:
+		return e.runQuery(ctx, vs, bindings, opts)
+	}
+
+	return nil, &platform.Error{
+		Code: platform.EInvalid,
+		Msg:  fmt.Sprintf("macro %q has unknown arguments type %q", m.Name, m.Arguments.Type),
+	}
+}
+
+func (e *Evaluator) runQuery(ctx context.Context, q platform.MacroQueryValues, bindings map[string]string, opts platform.MacroEvaluationOptions) ([]string, error) {
+	if !opts.OrganizationID.Valid() {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "an organization is required to evaluate query macros",
+		}
+	}
+
+	var compiler flux.Compiler
+	switch q.Language {
+	case "flux":
+		text, err := BindFlux(q.Query, bindings)
+		if err != nil {
+			return nil, err
+		}
+		compiler = lang.FluxCompiler{Query: text}
+	case "influxql":
+		if e.DBRPMappingService == nil {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  "influxql query macros are not supported",
+			}
+		}
+		text, err := BindInfluxQL(q.Query, bindings)
+		if err != nil {
+			return nil, err
+		}
+		c := influxql.NewCompiler(e.DBRPMappingService)
+		c.Query = text
+		compiler = c
+	default:
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("unknown macro query language %q", q.Language),
+		}
+	}
+
+	req := &query.ProxyRequest{
+		Request: query.Request{
+			Authorization:  opts.Authorization,
+			OrganizationID: opts.OrganizationID,
+			Compiler:       compiler,
+		},
+		Dialect: csv.DefaultDialect(),
+	}
+
+	var buf bytes.Buffer
+	if _, err := e.ProxyQueryService.Query(ctx, &buf, req); err != nil {
+		return nil, err
+	}
+
+	return decodeValues(buf.Bytes())
+}
+
+// decodeValues returns the unique values of the _value column of every table
+// in the annotated CSV results, in the order in which they appear.
+func decodeValues(b []byte) ([]string, error) {
+	results, err := csv.NewMultiResultDecoder(csv.ResultDecoderConfig{}).Decode(ioutil.NopCloser(bytes.NewReader(b)))
+	if err != nil {
+		return nil, err
+	}
+	defer results.Release()
+
+	vs := []string{}
+	seen := map[string]bool{}
+	for results.More() {
+		err := results.Next().Tables().Do(func(tbl flux.Table) error {
+			j := execute.ColIdx(execute.DefaultValueColLabel, tbl.Cols())
+			if j < 0 {
+				return nil
+			}
+			return tbl.Do(func(cr flux.ColReader) error {
+				for i := 0; i < cr.Len(); i++ {
+					v := valueString(cr, i, j)
+					if !seen[v] {
+						seen[v] = true
+						vs = append(vs, v)
+					}
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := results.Err(); err != nil {
+		return nil, err
+	}
+
+	return vs, nil
+}
+
+func valueString(cr flux.ColReader, i, j int) string {
+	switch cr.Cols()[j].Type {
+	case flux.TString:
+		return cr.Strings(j)[i]
+	case flux.TInt:
+		return strconv.FormatInt(cr.Ints(j)[i], 10)
+	case flux.TUInt:
+		return strconv.FormatUint(cr.UInts(j)[i], 10)
+	case flux.TFloat:
+		return strconv.FormatFloat(cr.Floats(j)[i], 'f', -1, 64)
+	case flux.TBool:
+		return strconv.FormatBool(cr.Bools(j)[i])
+	case flux.TTime:
+		return cr.Times(j)[i].Time().Format(time.RFC3339Nano)
+	}
+	return ""
+}
+
+// selectValue returns the value bound for m when the user has not chosen one.
+func selectValue(m *platform.Macro, vs []string) (string, error) {
+	if mv, ok := m.Arguments.Values.(platform.MacroMapValues); ok {
+		for _, s := range m.Selected {
+			if v, ok := mv[s]; ok {
+				return v, nil
+			}
+		}
+		if len(vs) > 0 {
+			return mv[vs[0]], nil
+		}
+	} else {
+		for _, s := range m.Selected {
+			for _, v := range vs {
+				if s == v {
+					return v, nil
+				}
+			}
+		}
+		if len(vs) > 0 {
+			return vs[0], nil
+		}
+	}
+
+	return "", &platform.Error{
+		Code: platform.EInvalid,
+		Msg:  fmt.Sprintf("macro %q has no values", m.Name),
+	}
+}
+
+func (e *Evaluator) macrosByName(ctx context.Context) (map[string]*platform.Macro, error) {
+	ms, err := e.MacroService.FindMacros(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*platform.Macro, len(ms))
+	for _, m := range ms {
+		byName[m.Name] = m
+	}
+	return byName, nil
+}
+
+func queryValues(m *platform.Macro) (platform.MacroQueryValues, bool) {
+	if m.Arguments == nil {
+		return platform.MacroQueryValues{}, false
+	}
+	q, ok := m.Arguments.Values.(platform.MacroQueryValues)
+	return q, ok
+}
+
+func copyBindings(bindings map[string]string) map[string]string {
+	c := make(map[string]string, len(bindings))
+	for k, v := range bindings {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,206 @@
+package macros_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/inmem"
+	"github.com/influxdata/platform/macros"
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/mock"
+)
+
+var orgID = platform.ID(1)
+
+// valuesCSV returns annotated CSV with a single table whose _value column
+// holds vs.
+func valuesCSV(vs ...string) string {
+	var b strings.Builder
+	b.WriteString("#datatype,string,long,string\r\n")
+	b.WriteString("#group,false,false,false\r\n")
+	b.WriteString("#default,_result,,\r\n")
+	b.WriteString(",result,table,_value\r\n")
+	for _, v := range vs {
+		fmt.Fprintf(&b, ",,0,%s\r\n", v)
+	}
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+// newEvaluator returns an Evaluator over ms whose queries return the values
+// in results for the query text they end with.
+func newEvaluator(t *testing.T, ms []*platform.Macro, results map[string][]string) (*macros.Evaluator, *[]string) {
+	t.Helper()
+
+	svc := inmem.NewService()
+	for _, m := range ms {
+		if err := svc.CreateMacro(context.Background(), m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var queries []string
+	return &macros.Evaluator{
+		MacroService: svc,
+		ProxyQueryService: &mock.ProxyQueryService{
+			QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (int64, error) {
+				if req.Request.OrganizationID != orgID {
+					return 0, fmt.Errorf("unexpected organization %v", req.Request.OrganizationID)
+				}
+				q := req.Request.Compiler.(lang.FluxCompiler).Query
+				queries = append(queries, q)
+				for suffix, vs := range results {
+					if strings.HasSuffix(q, suffix) {
+						n, err := io.WriteString(w, valuesCSV(vs...))
+						return int64(n), err
+					}
+				}
+				return 0, fmt.Errorf("unexpected query %q", q)
+			},
+		},
+	}, &queries
+}
+
+func queryMacro(name, q string) *platform.Macro {
+	return &platform.Macro{
+		Name: name,
+		Arguments: &platform.MacroArguments{
+			Type:   "query",
+			Values: platform.MacroQueryValues{Query: q, Language: "flux"},
+		},
+	}
+}
+
+func TestEvaluator_EvaluateMacro(t *testing.T) {
+	constant := &platform.Macro{
+		Name: "bucket",
+		Arguments: &platform.MacroArguments{
+			Type:   "constant",
+			Values: platform.MacroConstantValues{"telegraf", "system"},
+		},
+	}
+	mapped := &platform.Macro{
+		Name: "region",
+		Arguments: &platform.MacroArguments{
+			Type:   "map",
+			Values: platform.MacroMapValues{"west": "us-west", "east": "us-east"},
+		},
+		Selected: []string{"west"},
+	}
+	hosts := queryMacro("host", `hosts(bucket: v.bucket, region: v.region)`)
+
+	e, queries := newEvaluator(t, []*platform.Macro{constant, mapped, hosts}, map[string][]string{
+		`hosts(bucket: v.bucket, region: v.region)`: {"a", "b", "a"},
+	})
+
+	ctx := context.Background()
+	opts := platform.MacroEvaluationOptions{OrganizationID: orgID}
+
+	got, err := e.EvaluateMacro(ctx, constant.ID, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"telegraf", "system"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("constant values = %v, want %v", got, want)
+	}
+
+	got, err = e.EvaluateMacro(ctx, mapped.ID, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"east", "west"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("map values = %v, want %v", got, want)
+	}
+
+	opts.Bindings = map[string]string{"bucket": "system"}
+	got, err = e.EvaluateMacro(ctx, hosts.ID, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("query values = %v, want %v", got, want)
+	}
+
+	want := []string{`v = {bucket: "system", region: "us-west"}
+hosts(bucket: v.bucket, region: v.region)`}
+	if !reflect.DeepEqual(*queries, want) {
+		t.Errorf("queries = %q, want %q", *queries, want)
+	}
+}
+
+func TestEvaluator_BindMacros(t *testing.T) {
+	ms := []*platform.Macro{
+		queryMacro("host", `hosts(bucket: v.bucket)`),
+		queryMacro("bucket", `buckets()`),
+	}
+	ms[0].Selected = []string{"b"}
+
+	e, queries := newEvaluator(t, ms, map[string][]string{
+		`hosts(bucket: v.bucket)`: {"a", "b"},
+		`buckets()`:               {"telegraf", "system"},
+	})
+
+	got, err := e.BindMacros(context.Background(), `from(bucket: v.bucket) |> filter(fn: (r) => r.host == v.host)`, platform.MacroEvaluationOptions{
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"bucket": "telegraf", "host": "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("BindMacros() = %v, want %v", got, want)
+	}
+
+	want := []string{
+		`buckets()`,
+		`v = {bucket: "telegraf"}
+hosts(bucket: v.bucket)`,
+	}
+	if !reflect.DeepEqual(*queries, want) {
+		t.Errorf("queries = %q, want %q", *queries, want)
+	}
+}
+
+func TestEvaluator_BindMacros_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		macros []*platform.Macro
+		query  string
+		opts   platform.MacroEvaluationOptions
+	}{
+		{
+			name: "dependency cycle",
+			macros: []*platform.Macro{
+				queryMacro("a", `a(x: v.b)`),
+				queryMacro("b", `b(x: v.a)`),
+			},
+			query: `from(bucket: v.a)`,
+			opts:  platform.MacroEvaluationOptions{OrganizationID: orgID},
+		},
+		{
+			name:  "undefined macro",
+			query: `from(bucket: v.missing)`,
+			opts:  platform.MacroEvaluationOptions{OrganizationID: orgID},
+		},
+		{
+			name:   "missing organization",
+			macros: []*platform.Macro{queryMacro("bucket", `buckets()`)},
+			query:  `from(bucket: v.bucket)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _ := newEvaluator(t, tt.macros, nil)
+			_, err := e.BindMacros(context.Background(), tt.query, tt.opts)
+			if platform.ErrorCode(err) != platform.EInvalid {
+				t.Errorf("BindMacros() error = %v, want code %q", err, platform.EInvalid)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.BucketSchemaService = (*BucketSchemaService)(nil)
+
+// BucketSchemaService is a mock implementation of platform.BucketSchemaService.
+type BucketSchemaService struct {
+	FindBucketSchemaFn func(context.Context, platform.ID) (*platform.BucketSchema, error)
+	SetBucketSchemaFn  func(context.Context, platform.ID, *platform.BucketSchema) error
+}
+
+// NewBucketSchemaService returns a mock BucketSchemaService where its methods
+// will return zero values.
+func NewBucketSchemaService() *BucketSchemaService {
+	return &BucketSchemaService{
+		FindBucketSchemaFn: func(context.Context, platform.ID) (*platform.BucketSchema, error) { return nil, nil },
+		SetBucketSchemaFn:  func(context.Context, platform.ID, *platform.BucketSchema) error { return nil },
+	}
+}
+
+// FindBucketSchema returns the schema of a bucket.
+func (s *BucketSchemaService) FindBucketSchema(ctx context.Context, id platform.ID) (*platform.BucketSchema, error) {
+	return s.FindBucketSchemaFn(ctx, id)
+}
+
+// SetBucketSchema sets the schema of a bucket.
+func (s *BucketSchemaService) SetBucketSchema(ctx context.Context, id platform.ID, schema *platform.BucketSchema) error {
+	return s.SetBucketSchemaFn(ctx, id, schema)
+}
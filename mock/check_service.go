@@ -0,0 +1,55 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.CheckService = (*CheckService)(nil)
+
+// CheckService is a mock implementation of platform.CheckService.
+type CheckService struct {
+	FindCheckByIDFn func(context.Context, platform.ID) (*platform.Check, error)
+	FindChecksFn    func(context.Context, platform.CheckFilter) ([]*platform.Check, error)
+	CreateCheckFn   func(context.Context, *platform.Check) error
+	UpdateCheckFn   func(context.Context, platform.ID, platform.CheckUpdate) (*platform.Check, error)
+	DeleteCheckFn   func(context.Context, platform.ID) error
+}
+
+// NewCheckService returns a mock CheckService where its methods will return
+// zero values.
+func NewCheckService() *CheckService {
+	return &CheckService{
+		FindCheckByIDFn: func(context.Context, platform.ID) (*platform.Check, error) { return nil, nil },
+		FindChecksFn:    func(context.Context, platform.CheckFilter) ([]*platform.Check, error) { return nil, nil },
+		CreateCheckFn:   func(context.Context, *platform.Check) error { return nil },
+		UpdateCheckFn:   func(context.Context, platform.ID, platform.CheckUpdate) (*platform.Check, error) { return nil, nil },
+		DeleteCheckFn:   func(context.Context, platform.ID) error { return nil },
+	}
+}
+
+// FindCheckByID returns a single check by ID.
+func (s *CheckService) FindCheckByID(ctx context.Context, id platform.ID) (*platform.Check, error) {
+	return s.FindCheckByIDFn(ctx, id)
+}
+
+// FindChecks returns all checks that match filter.
+func (s *CheckService) FindChecks(ctx context.Context, filter platform.CheckFilter) ([]*platform.Check, error) {
+	return s.FindChecksFn(ctx, filter)
+}
+
+// CreateCheck creates a new check and sets c.ID with the new identifier.
+func (s *CheckService) CreateCheck(ctx context.Context, c *platform.Check) error {
+	return s.CreateCheckFn(ctx, c)
+}
+
+// UpdateCheck updates a single check with changeset.
+func (s *CheckService) UpdateCheck(ctx context.Context, id platform.ID, upd platform.CheckUpdate) (*platform.Check, error) {
+	return s.UpdateCheckFn(ctx, id, upd)
+}
+
+// DeleteCheck removes a check by ID.
+func (s *CheckService) DeleteCheck(ctx context.Context, id platform.ID) error {
+	return s.DeleteCheckFn(ctx, id)
+}
@@ -0,0 +1,22 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.DashboardTemplateService = &DashboardTemplateService{}
+
+type DashboardTemplateService struct {
+	ExportDashboardF func(context.Context, platform.ID) (*platform.DashboardTemplate, error)
+	ImportDashboardF func(context.Context, *platform.DashboardTemplate, platform.ImportDashboardOptions) (*platform.Dashboard, error)
+}
+
+func (s *DashboardTemplateService) ExportDashboard(ctx context.Context, id platform.ID) (*platform.DashboardTemplate, error) {
+	return s.ExportDashboardF(ctx, id)
+}
+
+func (s *DashboardTemplateService) ImportDashboard(ctx context.Context, t *platform.DashboardTemplate, opts platform.ImportDashboardOptions) (*platform.Dashboard, error) {
+	return s.ImportDashboardF(ctx, t, opts)
+}
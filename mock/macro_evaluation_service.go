@@ -0,0 +1,22 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.MacroEvaluationService = &MacroEvaluationService{}
+
+type MacroEvaluationService struct {
+	EvaluateMacroF func(context.Context, platform.ID, platform.MacroEvaluationOptions) ([]string, error)
+	BindMacrosF    func(context.Context, string, platform.MacroEvaluationOptions) (map[string]string, error)
+}
+
+func (s *MacroEvaluationService) EvaluateMacro(ctx context.Context, id platform.ID, opts platform.MacroEvaluationOptions) ([]string, error) {
+	return s.EvaluateMacroF(ctx, id, opts)
+}
+
+func (s *MacroEvaluationService) BindMacros(ctx context.Context, query string, opts platform.MacroEvaluationOptions) (map[string]string, error) {
+	return s.BindMacrosF(ctx, query, opts)
+}
@@ -0,0 +1,59 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.NotificationEndpointService = (*NotificationEndpointService)(nil)
+
+// NotificationEndpointService is a mock implementation of platform.NotificationEndpointService.
+type NotificationEndpointService struct {
+	FindNotificationEndpointByIDFn func(context.Context, platform.ID) (*platform.NotificationEndpoint, error)
+	FindNotificationEndpointsFn    func(context.Context, platform.NotificationEndpointFilter) ([]*platform.NotificationEndpoint, error)
+	CreateNotificationEndpointFn   func(context.Context, *platform.NotificationEndpoint) error
+	UpdateNotificationEndpointFn   func(context.Context, platform.ID, platform.NotificationEndpointUpdate) (*platform.NotificationEndpoint, error)
+	DeleteNotificationEndpointFn   func(context.Context, platform.ID) error
+}
+
+// NewNotificationEndpointService returns a mock NotificationEndpointService where its methods will return
+// zero values.
+func NewNotificationEndpointService() *NotificationEndpointService {
+	return &NotificationEndpointService{
+		FindNotificationEndpointByIDFn: func(context.Context, platform.ID) (*platform.NotificationEndpoint, error) { return nil, nil },
+		FindNotificationEndpointsFn: func(context.Context, platform.NotificationEndpointFilter) ([]*platform.NotificationEndpoint, error) {
+			return nil, nil
+		},
+		CreateNotificationEndpointFn: func(context.Context, *platform.NotificationEndpoint) error { return nil },
+		UpdateNotificationEndpointFn: func(context.Context, platform.ID, platform.NotificationEndpointUpdate) (*platform.NotificationEndpoint, error) {
+			return nil, nil
+		},
+		DeleteNotificationEndpointFn: func(context.Context, platform.ID) error { return nil },
+	}
+}
+
+// FindNotificationEndpointByID returns a single notification endpoint by ID.
+func (s *NotificationEndpointService) FindNotificationEndpointByID(ctx context.Context, id platform.ID) (*platform.NotificationEndpoint, error) {
+	return s.FindNotificationEndpointByIDFn(ctx, id)
+}
+
+// FindNotificationEndpoints returns all notification endpoints that match filter.
+func (s *NotificationEndpointService) FindNotificationEndpoints(ctx context.Context, filter platform.NotificationEndpointFilter) ([]*platform.NotificationEndpoint, error) {
+	return s.FindNotificationEndpointsFn(ctx, filter)
+}
+
+// CreateNotificationEndpoint creates a new notification endpoint and sets e.ID with the new identifier.
+func (s *NotificationEndpointService) CreateNotificationEndpoint(ctx context.Context, e *platform.NotificationEndpoint) error {
+	return s.CreateNotificationEndpointFn(ctx, e)
+}
+
+// UpdateNotificationEndpoint updates a single notification endpoint with changeset.
+func (s *NotificationEndpointService) UpdateNotificationEndpoint(ctx context.Context, id platform.ID, upd platform.NotificationEndpointUpdate) (*platform.NotificationEndpoint, error) {
+	return s.UpdateNotificationEndpointFn(ctx, id, upd)
+}
+
+// DeleteNotificationEndpoint removes a notification endpoint by ID.
+func (s *NotificationEndpointService) DeleteNotificationEndpoint(ctx context.Context, id platform.ID) error {
+	return s.DeleteNotificationEndpointFn(ctx, id)
+}
@@ -0,0 +1,59 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.ReplicationRuleService = (*ReplicationRuleService)(nil)
+
+// ReplicationRuleService is a mock implementation of platform.ReplicationRuleService.
+type ReplicationRuleService struct {
+	FindReplicationRuleByIDFn func(context.Context, platform.ID) (*platform.ReplicationRule, error)
+	FindReplicationRulesFn    func(context.Context, platform.ReplicationRuleFilter) ([]*platform.ReplicationRule, error)
+	CreateReplicationRuleFn   func(context.Context, *platform.ReplicationRule) error
+	UpdateReplicationRuleFn   func(context.Context, platform.ID, platform.ReplicationRuleUpdate) (*platform.ReplicationRule, error)
+	DeleteReplicationRuleFn   func(context.Context, platform.ID) error
+}
+
+// NewReplicationRuleService returns a mock ReplicationRuleService where its methods will return
+// zero values.
+func NewReplicationRuleService() *ReplicationRuleService {
+	return &ReplicationRuleService{
+		FindReplicationRuleByIDFn: func(context.Context, platform.ID) (*platform.ReplicationRule, error) { return nil, nil },
+		FindReplicationRulesFn: func(context.Context, platform.ReplicationRuleFilter) ([]*platform.ReplicationRule, error) {
+			return nil, nil
+		},
+		CreateReplicationRuleFn: func(context.Context, *platform.ReplicationRule) error { return nil },
+		UpdateReplicationRuleFn: func(context.Context, platform.ID, platform.ReplicationRuleUpdate) (*platform.ReplicationRule, error) {
+			return nil, nil
+		},
+		DeleteReplicationRuleFn: func(context.Context, platform.ID) error { return nil },
+	}
+}
+
+// FindReplicationRuleByID returns a single replication rule by ID.
+func (s *ReplicationRuleService) FindReplicationRuleByID(ctx context.Context, id platform.ID) (*platform.ReplicationRule, error) {
+	return s.FindReplicationRuleByIDFn(ctx, id)
+}
+
+// FindReplicationRules returns all replication rules that match filter.
+func (s *ReplicationRuleService) FindReplicationRules(ctx context.Context, filter platform.ReplicationRuleFilter) ([]*platform.ReplicationRule, error) {
+	return s.FindReplicationRulesFn(ctx, filter)
+}
+
+// CreateReplicationRule creates a new replication rule and sets r.ID with the new identifier.
+func (s *ReplicationRuleService) CreateReplicationRule(ctx context.Context, r *platform.ReplicationRule) error {
+	return s.CreateReplicationRuleFn(ctx, r)
+}
+
+// UpdateReplicationRule updates a single replication rule with changeset.
+func (s *ReplicationRuleService) UpdateReplicationRule(ctx context.Context, id platform.ID, upd platform.ReplicationRuleUpdate) (*platform.ReplicationRule, error) {
+	return s.UpdateReplicationRuleFn(ctx, id, upd)
+}
+
+// DeleteReplicationRule removes a replication rule by ID.
+func (s *ReplicationRuleService) DeleteReplicationRule(ctx context.Context, id platform.ID) error {
+	return s.DeleteReplicationRuleFn(ctx, id)
+}
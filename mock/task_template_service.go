@@ -0,0 +1,77 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.TaskTemplateService = (*TaskTemplateService)(nil)
+var _ platform.TaskTemplateInstanceService = (*TaskTemplateService)(nil)
+
+// TaskTemplateService is a mock implementation of platform.TaskTemplateService
+// and platform.TaskTemplateInstanceService.
+type TaskTemplateService struct {
+	FindTaskTemplateByIDFn    func(context.Context, platform.ID) (*platform.TaskTemplate, error)
+	FindTaskTemplatesFn       func(context.Context, platform.TaskTemplateFilter) ([]*platform.TaskTemplate, error)
+	CreateTaskTemplateFn      func(context.Context, *platform.TaskTemplate) error
+	UpdateTaskTemplateFn      func(context.Context, platform.ID, platform.TaskTemplateUpdate) (*platform.TaskTemplate, error)
+	DeleteTaskTemplateFn      func(context.Context, platform.ID) error
+	InstantiateTaskTemplateFn func(context.Context, platform.ID, platform.TaskTemplateInstantiation) ([]*platform.Task, error)
+	RolloutTaskTemplateFn     func(context.Context, platform.ID) ([]*platform.Task, error)
+}
+
+// NewTaskTemplateService returns a mock TaskTemplateService where its methods will return
+// zero values.
+func NewTaskTemplateService() *TaskTemplateService {
+	return &TaskTemplateService{
+		FindTaskTemplateByIDFn: func(context.Context, platform.ID) (*platform.TaskTemplate, error) { return nil, nil },
+		FindTaskTemplatesFn: func(context.Context, platform.TaskTemplateFilter) ([]*platform.TaskTemplate, error) {
+			return nil, nil
+		},
+		CreateTaskTemplateFn: func(context.Context, *platform.TaskTemplate) error { return nil },
+		UpdateTaskTemplateFn: func(context.Context, platform.ID, platform.TaskTemplateUpdate) (*platform.TaskTemplate, error) {
+			return nil, nil
+		},
+		DeleteTaskTemplateFn: func(context.Context, platform.ID) error { return nil },
+		InstantiateTaskTemplateFn: func(context.Context, platform.ID, platform.TaskTemplateInstantiation) ([]*platform.Task, error) {
+			return nil, nil
+		},
+		RolloutTaskTemplateFn: func(context.Context, platform.ID) ([]*platform.Task, error) { return nil, nil },
+	}
+}
+
+// FindTaskTemplateByID returns a single task template by ID.
+func (s *TaskTemplateService) FindTaskTemplateByID(ctx context.Context, id platform.ID) (*platform.TaskTemplate, error) {
+	return s.FindTaskTemplateByIDFn(ctx, id)
+}
+
+// FindTaskTemplates returns all task templates that match filter.
+func (s *TaskTemplateService) FindTaskTemplates(ctx context.Context, filter platform.TaskTemplateFilter) ([]*platform.TaskTemplate, error) {
+	return s.FindTaskTemplatesFn(ctx, filter)
+}
+
+// CreateTaskTemplate creates a new task template and sets t.ID with the new identifier.
+func (s *TaskTemplateService) CreateTaskTemplate(ctx context.Context, t *platform.TaskTemplate) error {
+	return s.CreateTaskTemplateFn(ctx, t)
+}
+
+// UpdateTaskTemplate updates a single task template with changeset.
+func (s *TaskTemplateService) UpdateTaskTemplate(ctx context.Context, id platform.ID, upd platform.TaskTemplateUpdate) (*platform.TaskTemplate, error) {
+	return s.UpdateTaskTemplateFn(ctx, id, upd)
+}
+
+// DeleteTaskTemplate removes a task template by ID.
+func (s *TaskTemplateService) DeleteTaskTemplate(ctx context.Context, id platform.ID) error {
+	return s.DeleteTaskTemplateFn(ctx, id)
+}
+
+// InstantiateTaskTemplate creates tasks from a task template.
+func (s *TaskTemplateService) InstantiateTaskTemplate(ctx context.Context, id platform.ID, inst platform.TaskTemplateInstantiation) ([]*platform.Task, error) {
+	return s.InstantiateTaskTemplateFn(ctx, id, inst)
+}
+
+// RolloutTaskTemplate updates the tasks instantiated from a task template.
+func (s *TaskTemplateService) RolloutTaskTemplate(ctx context.Context, id platform.ID) ([]*platform.Task, error) {
+	return s.RolloutTaskTemplateFn(ctx, id)
+}
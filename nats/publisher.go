@@ -16,7 +16,9 @@ type Publisher interface {
 type AsyncPublisher struct {
 	ClientID   string
 	Connection stan.Conn
-	Logger     *zap.Logger
+	// URL of the server. Defaults to stan.DefaultNatsURL.
+	URL    string
+	Logger *zap.Logger
 }
 
 func NewAsyncPublisher(clientID string) *AsyncPublisher {
@@ -25,7 +27,7 @@ func NewAsyncPublisher(clientID string) *AsyncPublisher {
 
 // Open creates and maintains a connection to NATS server
 func (p *AsyncPublisher) Open() error {
-	sc, err := stan.Connect(ServerName, p.ClientID)
+	sc, err := stan.Connect(ServerName, p.ClientID, natsURL(p.URL))
 	if err != nil {
 		return err
 	}
@@ -52,3 +54,12 @@ func (p *AsyncPublisher) Publish(subject string, r io.Reader) error {
 	_, err = p.Connection.PublishAsync(subject, data, ah)
 	return err
 }
+
+// natsURL returns the option connecting to the server at url, or at the
+// default URL if url is empty.
+func natsURL(url string) stan.Option {
+	if url == "" {
+		url = stan.DefaultNatsURL
+	}
+	return stan.NatsURL(url)
+}
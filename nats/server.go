@@ -2,6 +2,7 @@ package nats
 
 import (
 	"errors"
+	"fmt"
 
 	stand "github.com/nats-io/nats-streaming-server/server"
 	"github.com/nats-io/nats-streaming-server/stores"
@@ -23,7 +24,10 @@ func (s *Server) Open() error {
 	opts.StoreType = stores.TypeFile
 	opts.ID = ServerName
 	opts.FilestoreDir = s.config.FilestoreDir
-	server, err := stand.RunServerWithOpts(opts, nil)
+
+	natsOpts := stand.NewNATSOptions()
+	natsOpts.Port = s.config.port()
+	server, err := stand.RunServerWithOpts(opts, natsOpts)
 	if err != nil {
 		return err
 	}
@@ -33,6 +37,11 @@ func (s *Server) Open() error {
 	return nil
 }
 
+// URL returns the URL clients connect to the server with.
+func (s *Server) URL() string {
+	return fmt.Sprintf("nats://localhost:%d", s.config.port())
+}
+
 // Close stops the embedded NATS server.
 func (s *Server) Close() {
 	s.Server.Shutdown()
@@ -42,6 +51,19 @@ func (s *Server) Close() {
 type Config struct {
 	// The directory where nats persists message information
 	FilestoreDir string
+
+	// The port the server listens on. Defaults to DefaultPort.
+	Port int
+}
+
+// DefaultPort is the default port of the NATS streaming server.
+const DefaultPort = 4222
+
+func (c Config) port() int {
+	if c.Port == 0 {
+		return DefaultPort
+	}
+	return c.Port
 }
 
 // NewServer creates and returns a new server struct from the provided config
@@ -12,6 +12,8 @@ type Subscriber interface {
 type QueueSubscriber struct {
 	ClientID   string
 	Connection stan.Conn
+	// URL of the server. Defaults to stan.DefaultNatsURL.
+	URL string
 }
 
 func NewQueueSubscriber(clientID string) *QueueSubscriber {
@@ -20,7 +22,7 @@ func NewQueueSubscriber(clientID string) *QueueSubscriber {
 
 // Open creates and maintains a connection to NATS server
 func (s *QueueSubscriber) Open() error {
-	sc, err := stan.Connect(ServerName, s.ClientID)
+	sc, err := stan.Connect(ServerName, s.ClientID, natsURL(s.URL))
 	if err != nil {
 		return err
 	}
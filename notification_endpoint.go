@@ -0,0 +1,170 @@
+package platform
+
+import (
+	"context"
+	"net/url"
+)
+
+// NotificationEndpointType is the kind of service a notification endpoint sends to.
+type NotificationEndpointType string
+
+const (
+	// HTTPEndpoint posts the JSON encoded notification to URL.
+	HTTPEndpoint NotificationEndpointType = "http"
+	// SlackEndpoint posts a message to a Slack compatible incoming webhook at URL.
+	SlackEndpoint NotificationEndpointType = "slack"
+	// PagerDutyEndpoint sends events to a PagerDuty compatible events API, using Token as the routing key.
+	PagerDutyEndpoint NotificationEndpointType = "pagerduty"
+	// SMTPEndpoint sends email through an SMTP server.
+	SMTPEndpoint NotificationEndpointType = "smtp"
+)
+
+// DefaultPagerDutyURL is the events API that PagerDuty endpoints send to when they have no URL.
+const DefaultPagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+
+// NotificationEndpoint is somewhere that check status changes are sent.
+type NotificationEndpoint struct {
+	ID             ID                       `json:"id,omitempty"`
+	OrganizationID ID                       `json:"orgID"`
+	Name           string                   `json:"name"`
+	Type           NotificationEndpointType `json:"type"`
+
+	// URL is the webhook URL of http and slack endpoints, and the events API URL of pagerduty endpoints.
+	URL string `json:"url,omitempty"`
+	// Headers are added to the requests of http endpoints.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Token is sent as a bearer token by http endpoints, and is the routing key of pagerduty endpoints.
+	Token string `json:"token,omitempty"`
+
+	// SMTP configures smtp endpoints.
+	SMTP *SMTPConfig `json:"smtp,omitempty"`
+}
+
+// SMTPConfig is how an smtp notification endpoint sends email.
+type SMTPConfig struct {
+	Host     string   `json:"host"`
+	Port     int      `json:"port"`
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+}
+
+// Redacted returns a copy of the endpoint without its token and SMTP password.
+func (e NotificationEndpoint) Redacted() NotificationEndpoint {
+	e.Token = ""
+	if e.SMTP != nil {
+		smtp := *e.SMTP
+		smtp.Password = ""
+		e.SMTP = &smtp
+	}
+	return e
+}
+
+// Valid returns an error if notifications cannot be sent to the endpoint.
+func (e *NotificationEndpoint) Valid() error {
+	if !e.OrganizationID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "notification endpoint requires an organization",
+		}
+	}
+	if e.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "notification endpoint name is required",
+		}
+	}
+
+	switch e.Type {
+	case HTTPEndpoint, SlackEndpoint:
+		if _, err := url.ParseRequestURI(e.URL); err != nil {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "notification endpoint requires a valid url",
+				Err:  err,
+			}
+		}
+	case PagerDutyEndpoint:
+		if e.Token == "" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "pagerduty endpoint requires a routing key token",
+			}
+		}
+		if e.URL != "" {
+			if _, err := url.ParseRequestURI(e.URL); err != nil {
+				return &Error{
+					Code: EInvalid,
+					Msg:  "notification endpoint requires a valid url",
+					Err:  err,
+				}
+			}
+		}
+	case SMTPEndpoint:
+		if e.SMTP == nil || e.SMTP.Host == "" || e.SMTP.Port <= 0 || e.SMTP.From == "" || len(e.SMTP.To) == 0 {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "smtp endpoint requires a host, port, from address and at least one to address",
+			}
+		}
+	default:
+		return &Error{
+			Code: EInvalid,
+			Msg:  "notification endpoint type must be http, slack, pagerduty or smtp",
+		}
+	}
+	return nil
+}
+
+// NotificationEndpointUpdate is a set of changes to a notification endpoint. Nil fields are left unchanged.
+type NotificationEndpointUpdate struct {
+	Name    *string            `json:"name,omitempty"`
+	URL     *string            `json:"url,omitempty"`
+	Headers *map[string]string `json:"headers,omitempty"`
+	Token   *string            `json:"token,omitempty"`
+	SMTP    *SMTPConfig        `json:"smtp,omitempty"`
+}
+
+// Apply applies the non-nil fields of the update to e.
+func (u NotificationEndpointUpdate) Apply(e *NotificationEndpoint) {
+	if u.Name != nil {
+		e.Name = *u.Name
+	}
+	if u.URL != nil {
+		e.URL = *u.URL
+	}
+	if u.Headers != nil {
+		e.Headers = *u.Headers
+	}
+	if u.Token != nil {
+		e.Token = *u.Token
+	}
+	if u.SMTP != nil {
+		smtp := *u.SMTP
+		e.SMTP = &smtp
+	}
+}
+
+// NotificationEndpointFilter represents a set of filters that restrict the returned notification endpoints.
+type NotificationEndpointFilter struct {
+	OrganizationID *ID
+}
+
+// NotificationEndpointService manages notification endpoints.
+type NotificationEndpointService interface {
+	// FindNotificationEndpointByID returns a single notification endpoint by ID.
+	FindNotificationEndpointByID(ctx context.Context, id ID) (*NotificationEndpoint, error)
+
+	// FindNotificationEndpoints returns the notification endpoints that match filter.
+	FindNotificationEndpoints(ctx context.Context, filter NotificationEndpointFilter) ([]*NotificationEndpoint, error)
+
+	// CreateNotificationEndpoint creates a new notification endpoint and sets e.ID with the new identifier.
+	CreateNotificationEndpoint(ctx context.Context, e *NotificationEndpoint) error
+
+	// UpdateNotificationEndpoint updates a single notification endpoint with a changeset and returns the updated endpoint.
+	UpdateNotificationEndpoint(ctx context.Context, id ID, upd NotificationEndpointUpdate) (*NotificationEndpoint, error)
+
+	// DeleteNotificationEndpoint removes a notification endpoint by ID.
+	DeleteNotificationEndpoint(ctx context.Context, id ID) error
+}
@@ -0,0 +1,480 @@
+// Package durablequeue provides a FIFO queue of byte slices stored on disk.
+//
+// The entries of a queue are appended to segment files in the directory of
+// the queue. Each entry is stored as its length and CRC-32 checksum followed
+// by its data, and is synced to disk before Append returns. The position of
+// the first unconsumed entry is recorded in a head file as entries are
+// consumed, and segment files are removed once all of their entries have been
+// consumed. The head file is not synced, so entries consumed shortly before a
+// crash may be returned again: entries are delivered at least once. When a
+// queue is opened, a partially written entry at the end of the last segment,
+// left by a crash, is truncated.
+package durablequeue
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/influxdata/platform/pkg/file"
+)
+
+const (
+	// DefaultMaxSize is the default maximum size in bytes of the entries of a queue.
+	DefaultMaxSize = 1 << 30
+
+	// DefaultMaxSegmentSize is the default maximum size of a segment file.
+	DefaultMaxSegmentSize = 10 << 20
+
+	segmentExt   = ".seg"
+	headFileName = "head"
+
+	entryHeaderSize = 8 // The length and checksum of an entry.
+	headFileSize    = 16
+)
+
+var (
+	// ErrQueueFull is returned when appending an entry would grow the queue
+	// beyond its maximum size.
+	ErrQueueFull = errors.New("queue is full")
+
+	// ErrQueueClosed is returned when using a closed queue.
+	ErrQueueClosed = errors.New("queue is closed")
+
+	// ErrEntryTooLarge is returned when appending an entry larger than the
+	// maximum size of the queue.
+	ErrEntryTooLarge = errors.New("entry is larger than the maximum size of the queue")
+)
+
+// Queue is a FIFO queue of byte slices stored in a directory. A Queue is safe
+// for concurrent use, though entries are expected to be consumed by a single
+// reader calling Peek and Advance.
+type Queue struct {
+	// MaxSize is the maximum size in bytes of the unconsumed entries of the
+	// queue, including their headers.
+	MaxSize int64
+
+	// MaxSegmentSize is the size above which a new segment file is started.
+	MaxSegmentSize int64
+
+	dir string
+
+	mu       sync.Mutex
+	segments []*segment // Segments from head to tail.
+	headOff  int64      // Offset of the first unconsumed entry of segments[0].
+	n        int        // Number of unconsumed entries.
+	size     int64      // Size of the unconsumed entries.
+	tail     *os.File   // Open for appending to the last segment.
+	head     *os.File   // Open for reading the first segment.
+	closed   bool
+
+	appended chan struct{}
+}
+
+type segment struct {
+	id   uint64
+	path string
+	size int64
+}
+
+// NewQueue returns a new queue stored in dir. The queue must be opened before use.
+func NewQueue(dir string) *Queue {
+	return &Queue{
+		MaxSize:        DefaultMaxSize,
+		MaxSegmentSize: DefaultMaxSegmentSize,
+		dir:            dir,
+		appended:       make(chan struct{}, 1),
+	}
+}
+
+// Dir returns the directory of the queue.
+func (q *Queue) Dir() string { return q.dir }
+
+// Open opens the queue, creating its directory if it does not exist.
+func (q *Queue) Open() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err := os.MkdirAll(q.dir, 0777); err != nil {
+		return err
+	}
+
+	segments, err := q.loadSegments()
+	if err != nil {
+		return err
+	}
+	headID, headOff, err := q.readHead()
+	if err != nil {
+		return err
+	}
+
+	// Remove the segments that were consumed before the queue was closed.
+	for len(segments) > 0 && segments[0].id < headID {
+		if err := os.Remove(segments[0].path); err != nil {
+			return err
+		}
+		segments = segments[1:]
+	}
+	if len(segments) == 0 || segments[0].id != headID {
+		headOff = 0
+	}
+
+	if len(segments) == 0 {
+		s, err := q.createSegment(headID)
+		if err != nil {
+			return err
+		}
+		segments = append(segments, s)
+	}
+	q.segments = segments
+	q.headOff = headOff
+	q.n, q.size = 0, 0
+
+	// Count the unconsumed entries, truncating a partially written entry at the
+	// end of the last segment.
+	for i, s := range segments {
+		off := int64(0)
+		if i == 0 {
+			off = headOff
+		}
+		n, end, err := scanSegment(s.path, off)
+		if err != nil {
+			return err
+		}
+		if end != s.size {
+			if i != len(segments)-1 {
+				return fmt.Errorf("durablequeue: corrupt segment %s at offset %d", s.path, end)
+			}
+			if err := os.Truncate(s.path, end); err != nil {
+				return err
+			}
+			s.size = end
+		}
+		q.n += n
+		q.size += end - off
+	}
+
+	tail := q.segments[len(q.segments)-1]
+	if q.tail, err = os.OpenFile(tail.path, os.O_WRONLY|os.O_APPEND, 0666); err != nil {
+		return err
+	}
+	if q.head, err = os.Open(q.segments[0].path); err != nil {
+		q.tail.Close()
+		return err
+	}
+	q.closed = false
+	return nil
+}
+
+// Close closes the queue. Unconsumed entries remain on disk.
+func (q *Queue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || q.tail == nil {
+		return nil
+	}
+	q.closed = true
+
+	err := q.tail.Close()
+	if e := q.head.Close(); e != nil && err == nil {
+		err = e
+	}
+	return err
+}
+
+// Len returns the number of unconsumed entries.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.n
+}
+
+// Size returns the size in bytes of the unconsumed entries, including their headers.
+func (q *Queue) Size() int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// Appended returns a channel that receives a value after entries are appended.
+// Values are not queued, so a receiver should consume all entries after
+// receiving a value.
+func (q *Queue) Appended() <-chan struct{} {
+	return q.appended
+}
+
+// Append appends b to the queue. The entry is synced to disk when Append
+// returns. ErrQueueFull is returned if the queue has no room for b.
+func (q *Queue) Append(b []byte) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || q.tail == nil {
+		return ErrQueueClosed
+	}
+
+	n := int64(entryHeaderSize + len(b))
+	if n > q.MaxSize {
+		return ErrEntryTooLarge
+	} else if q.size+n > q.MaxSize {
+		return ErrQueueFull
+	}
+
+	tail := q.segments[len(q.segments)-1]
+	if tail.size > 0 && tail.size+n > q.MaxSegmentSize {
+		if err := q.roll(); err != nil {
+			return err
+		}
+		tail = q.segments[len(q.segments)-1]
+	}
+
+	buf := make([]byte, n)
+	binary.BigEndian.PutUint32(buf[0:4], uint32(len(b)))
+	binary.BigEndian.PutUint32(buf[4:8], crc32.ChecksumIEEE(b))
+	copy(buf[entryHeaderSize:], b)
+
+	if _, err := q.tail.Write(buf); err != nil {
+		// Drop what may have been written of the entry so the segment stays valid.
+		os.Truncate(tail.path, tail.size)
+		return err
+	}
+	if err := q.tail.Sync(); err != nil {
+		os.Truncate(tail.path, tail.size)
+		return err
+	}
+	tail.size += n
+	q.n++
+	q.size += n
+
+	select {
+	case q.appended <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Peek returns the first unconsumed entry, or io.EOF if the queue is empty.
+// The entry is not consumed until Advance is called.
+func (q *Queue) Peek() ([]byte, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || q.tail == nil {
+		return nil, ErrQueueClosed
+	}
+
+	if err := q.skipConsumedSegment(); err != nil {
+		return nil, err
+	}
+	if q.n == 0 {
+		return nil, io.EOF
+	}
+
+	b, _, err := readEntry(q.head, q.headOff, q.segments[0].size)
+	if err != nil {
+		return nil, fmt.Errorf("durablequeue: reading %s at offset %d: %v", q.segments[0].path, q.headOff, err)
+	}
+	return b, nil
+}
+
+// Advance consumes the first unconsumed entry.
+func (q *Queue) Advance() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || q.tail == nil {
+		return ErrQueueClosed
+	}
+
+	if err := q.skipConsumedSegment(); err != nil {
+		return err
+	}
+	if q.n == 0 {
+		return io.EOF
+	}
+
+	var hdr [entryHeaderSize]byte
+	if _, err := q.head.ReadAt(hdr[:], q.headOff); err != nil {
+		return err
+	}
+	n := int64(entryHeaderSize + binary.BigEndian.Uint32(hdr[0:4]))
+
+	q.headOff += n
+	q.n--
+	q.size -= n
+	if err := q.writeHead(); err != nil {
+		return err
+	}
+	return q.skipConsumedSegment()
+}
+
+// skipConsumedSegment removes the head segment if all of its entries have
+// been consumed and it is not the tail segment.
+func (q *Queue) skipConsumedSegment() error {
+	for len(q.segments) > 1 && q.headOff >= q.segments[0].size {
+		next, err := os.Open(q.segments[1].path)
+		if err != nil {
+			return err
+		}
+		q.head.Close()
+		q.head = next
+
+		consumed := q.segments[0]
+		q.segments = q.segments[1:]
+		q.headOff = 0
+		if err := q.writeHead(); err != nil {
+			return err
+		}
+		if err := os.Remove(consumed.path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// roll starts a new tail segment.
+func (q *Queue) roll() error {
+	s, err := q.createSegment(q.segments[len(q.segments)-1].id + 1)
+	if err != nil {
+		return err
+	}
+	tail, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+	if err := q.tail.Close(); err != nil {
+		tail.Close()
+		return err
+	}
+	q.tail = tail
+	q.segments = append(q.segments, s)
+	return nil
+}
+
+func (q *Queue) createSegment(id uint64) (*segment, error) {
+	s := &segment{id: id, path: filepath.Join(q.dir, fmt.Sprintf("%016d%s", id, segmentExt))}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	return s, file.SyncDir(q.dir)
+}
+
+// loadSegments returns the segments of the queue directory ordered by id.
+func (q *Queue) loadSegments() ([]*segment, error) {
+	fis, err := ioutil.ReadDir(q.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []*segment
+	for _, fi := range fis {
+		name := fi.Name()
+		if fi.IsDir() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, &segment{
+			id:   id,
+			path: filepath.Join(q.dir, name),
+			size: fi.Size(),
+		})
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].id < segments[j].id })
+	return segments, nil
+}
+
+// readHead returns the segment id and offset of the first unconsumed entry,
+// or the start of the first possible segment if the head file does not exist.
+func (q *Queue) readHead() (uint64, int64, error) {
+	b, err := ioutil.ReadFile(filepath.Join(q.dir, headFileName))
+	if os.IsNotExist(err) {
+		return 1, 0, nil
+	} else if err != nil {
+		return 0, 0, err
+	} else if len(b) != headFileSize {
+		return 0, 0, fmt.Errorf("durablequeue: invalid head file in %s", q.dir)
+	}
+	return binary.BigEndian.Uint64(b[0:8]), int64(binary.BigEndian.Uint64(b[8:16])), nil
+}
+
+// writeHead records the position of the first unconsumed entry.
+func (q *Queue) writeHead() error {
+	var b [headFileSize]byte
+	binary.BigEndian.PutUint64(b[0:8], q.segments[0].id)
+	binary.BigEndian.PutUint64(b[8:16], uint64(q.headOff))
+
+	path := filepath.Join(q.dir, headFileName)
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b[:], 0666); err != nil {
+		return err
+	}
+	return file.RenameFile(tmp, path)
+}
+
+// scanSegment returns the number of valid entries of the segment at path from
+// off, and the offset of the end of the last valid entry.
+func scanSegment(path string, off int64) (int, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	n := 0
+	for {
+		_, next, err := readEntry(f, off, fi.Size())
+		if err != nil {
+			// The segment ends at the first entry that cannot be read.
+			return n, off, nil
+		}
+		n++
+		off = next
+	}
+}
+
+// readEntry returns the data of the entry of f at off and the offset of the
+// next entry. The entry must end before size.
+func readEntry(f *os.File, off, size int64) ([]byte, int64, error) {
+	var hdr [entryHeaderSize]byte
+	if _, err := f.ReadAt(hdr[:], off); err != nil {
+		return nil, 0, err
+	}
+
+	n := int64(binary.BigEndian.Uint32(hdr[0:4]))
+	if off+entryHeaderSize+n > size {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	b := make([]byte, n)
+	if _, err := f.ReadAt(b, off+entryHeaderSize); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, 0, err
+	}
+	if crc32.ChecksumIEEE(b) != binary.BigEndian.Uint32(hdr[4:8]) {
+		return nil, 0, errors.New("checksum mismatch")
+	}
+	return b, off + int64(len(b)) + entryHeaderSize, nil
+}
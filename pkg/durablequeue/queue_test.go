@@ -0,0 +1,197 @@
+package durablequeue_test
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/influxdata/platform/pkg/durablequeue"
+)
+
+func TestQueue_AppendPeekAdvance(t *testing.T) {
+	q := MustOpenQueue(t)
+	defer q.Remove()
+
+	if _, err := q.Peek(); err != io.EOF {
+		t.Fatalf("got error %v, expected io.EOF", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := q.Append([]byte(fmt.Sprintf("entry %d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, exp := q.Len(), 3; got != exp {
+		t.Fatalf("got %d entries, expected %d", got, exp)
+	}
+
+	for i := 0; i < 3; i++ {
+		b, err := q.Peek()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, exp := string(b), fmt.Sprintf("entry %d", i); got != exp {
+			t.Fatalf("got %q, expected %q", got, exp)
+		}
+		if err := q.Advance(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := q.Peek(); err != io.EOF {
+		t.Fatalf("got error %v, expected io.EOF", err)
+	}
+	if got := q.Size(); got != 0 {
+		t.Fatalf("got size %d, expected 0", got)
+	}
+}
+
+func TestQueue_Reopen(t *testing.T) {
+	q := MustOpenQueue(t)
+	defer q.Remove()
+	q.MaxSegmentSize = 32
+
+	for i := 0; i < 10; i++ {
+		if err := q.Append([]byte(fmt.Sprintf("entry %d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 4; i++ {
+		if err := q.Advance(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := q.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Consumed segments are removed.
+	segments, err := filepath.Glob(filepath.Join(q.Dir(), "*.seg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := len(segments), 3; got != exp {
+		t.Fatalf("got %d segments, expected %d", got, exp)
+	}
+
+	if err := q.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := q.Len(), 6; got != exp {
+		t.Fatalf("got %d entries, expected %d", got, exp)
+	}
+	for i := 4; i < 10; i++ {
+		b, err := q.Peek()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, exp := string(b), fmt.Sprintf("entry %d", i); got != exp {
+			t.Fatalf("got %q, expected %q", got, exp)
+		}
+		if err := q.Advance(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestQueue_TruncatesPartialEntry(t *testing.T) {
+	q := MustOpenQueue(t)
+	defer q.Remove()
+
+	if err := q.Append([]byte("complete")); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a crash while appending an entry.
+	segments, err := filepath.Glob(filepath.Join(q.Dir(), "*.seg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(segments[len(segments)-1], os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte{0, 0, 0, 100, 1, 2, 3, 4, 'p', 'a', 'r'}); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	if err := q.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := q.Len(), 1; got != exp {
+		t.Fatalf("got %d entries, expected %d", got, exp)
+	}
+	if err := q.Append([]byte("next")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, exp := range []string{"complete", "next"} {
+		b, err := q.Peek()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != exp {
+			t.Fatalf("got %q, expected %q", b, exp)
+		}
+		if err := q.Advance(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestQueue_Full(t *testing.T) {
+	q := MustOpenQueue(t)
+	defer q.Remove()
+	q.MaxSize = 40
+
+	if err := q.Append(make([]byte, 100)); err != durablequeue.ErrEntryTooLarge {
+		t.Fatalf("got error %v, expected %v", err, durablequeue.ErrEntryTooLarge)
+	}
+	if err := q.Append(make([]byte, 20)); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Append(make([]byte, 20)); err != durablequeue.ErrQueueFull {
+		t.Fatalf("got error %v, expected %v", err, durablequeue.ErrQueueFull)
+	}
+
+	// Consuming entries makes room for new ones.
+	if err := q.Advance(); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Append(make([]byte, 20)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Queue is a test wrapper for durablequeue.Queue.
+type Queue struct {
+	*durablequeue.Queue
+}
+
+// MustOpenQueue returns an open queue in a temporary directory.
+func MustOpenQueue(tb testing.TB) *Queue {
+	tb.Helper()
+	dir, err := ioutil.TempDir("", "durablequeue")
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	q := durablequeue.NewQueue(dir)
+	if err := q.Open(); err != nil {
+		tb.Fatal(err)
+	}
+	return &Queue{Queue: q}
+}
+
+// Remove closes the queue and removes its directory.
+func (q *Queue) Remove() error {
+	defer os.RemoveAll(q.Dir())
+	return q.Close()
+}
@@ -27,7 +27,17 @@ func createFromSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execu
 		return nil, errors.New("nil bounds passed to from")
 	}
 
-	if spec.WindowSet {
+	var windowEvery, windowOffset execute.Duration
+	if spec.WindowSet && spec.AggregateSet {
+		// The aggregate of every window is read at once, so storage is read over the whole range.
+		windowEvery = execute.Duration(spec.Window.Every)
+		if !spec.Window.Start.IsZero() {
+			start := a.ResolveTime(spec.Window.Start)
+			windowOffset = execute.Duration(start - start.Truncate(windowEvery))
+		}
+	}
+
+	if spec.WindowSet && !spec.AggregateSet {
 		w = execute.Window{
 			Every:  execute.Duration(spec.Window.Every),
 			Period: execute.Duration(spec.Window.Period),
@@ -82,6 +92,9 @@ func createFromSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execu
 			GroupMode:       storage.ToGroupMode(spec.GroupMode),
 			GroupKeys:       spec.GroupKeys,
 			AggregateMethod: spec.AggregateMethod,
+			WindowEvery:     windowEvery,
+			WindowOffset:    windowOffset,
+			Allocator:       a.Allocator(),
 		},
 		*bounds,
 		w,
@@ -0,0 +1,352 @@
+package inputs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/functions/inputs/storage"
+	"github.com/pkg/errors"
+)
+
+const (
+	TagKeysKind      = "tagKeys"
+	TagValuesKind    = "tagValues"
+	MeasurementsKind = "measurements"
+	FieldKeysKind    = "fieldKeys"
+
+	// measurementKey and fieldKey are the tags whose values are the
+	// measurement and field names of a bucket.
+	measurementKey = "_measurement"
+	fieldKey       = "_field"
+)
+
+func init() {
+	schemaParameters := func(extra map[string]semantic.PolyType) map[string]semantic.PolyType {
+		params := map[string]semantic.PolyType{
+			"bucket":   semantic.String,
+			"bucketID": semantic.String,
+			"start":    semantic.Tvar(1),
+			"stop":     semantic.Tvar(2),
+			"predicate": semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+				Parameters: map[string]semantic.PolyType{
+					"r": semantic.Tvar(3),
+				},
+				Required: semantic.LabelSet{"r"},
+				Return:   semantic.Bool,
+			}),
+		}
+		for k, v := range extra {
+			params[k] = v
+		}
+		return params
+	}
+
+	flux.RegisterFunction(TagKeysKind, createTagKeysOpSpec, semantic.FunctionPolySignature{
+		Parameters: schemaParameters(nil),
+		Return:     flux.TableObjectType,
+	})
+	flux.RegisterFunction(TagValuesKind, createTagValuesOpSpec, semantic.FunctionPolySignature{
+		Parameters: schemaParameters(map[string]semantic.PolyType{"tag": semantic.String}),
+		Required:   semantic.LabelSet{"tag"},
+		Return:     flux.TableObjectType,
+	})
+	flux.RegisterFunction(MeasurementsKind, createTagValuesOpSpecOf(measurementKey), semantic.FunctionPolySignature{
+		Parameters: schemaParameters(nil),
+		Return:     flux.TableObjectType,
+	})
+	flux.RegisterFunction(FieldKeysKind, createTagValuesOpSpecOf(fieldKey), semantic.FunctionPolySignature{
+		Parameters: schemaParameters(nil),
+		Return:     flux.TableObjectType,
+	})
+
+	flux.RegisterOpSpec(TagKeysKind, newTagKeysOp)
+	flux.RegisterOpSpec(TagValuesKind, newTagValuesOp)
+	plan.RegisterProcedureSpec(TagKeysKind, newTagKeysProcedure, TagKeysKind)
+	plan.RegisterProcedureSpec(TagValuesKind, newTagValuesProcedure, TagValuesKind)
+	execute.RegisterSource(TagKeysKind, createTagKeysSource)
+	execute.RegisterSource(TagValuesKind, createTagValuesSource)
+}
+
+// SchemaOpSpec holds the arguments common to the schema functions. When Start
+// and Stop are zero, the schema of all the data of the bucket is returned.
+type SchemaOpSpec struct {
+	Bucket    string                       `json:"bucket,omitempty"`
+	BucketID  string                       `json:"bucketID,omitempty"`
+	Start     flux.Time                    `json:"start"`
+	Stop      flux.Time                    `json:"stop"`
+	Predicate *semantic.FunctionExpression `json:"predicate,omitempty"`
+}
+
+func (s *SchemaOpSpec) readArgs(args flux.Arguments) error {
+	if bucket, ok, err := args.GetString("bucket"); err != nil {
+		return err
+	} else if ok {
+		s.Bucket = bucket
+	}
+
+	if bucketID, ok, err := args.GetString("bucketID"); err != nil {
+		return err
+	} else if ok {
+		s.BucketID = bucketID
+	}
+
+	if s.Bucket == "" && s.BucketID == "" {
+		return errors.New("must specify one of bucket or bucketID")
+	}
+	if s.Bucket != "" && s.BucketID != "" {
+		return errors.New("must specify only one of bucket or bucketID")
+	}
+
+	if start, ok, err := args.GetTime("start"); err != nil {
+		return err
+	} else if ok {
+		s.Start = start
+		s.Stop = flux.Now
+	}
+
+	if stop, ok, err := args.GetTime("stop"); err != nil {
+		return err
+	} else if ok {
+		if s.Start.IsZero() {
+			return errors.New("stop requires start")
+		}
+		s.Stop = stop
+	}
+
+	if f, ok, err := args.GetFunction("predicate"); err != nil {
+		return err
+	} else if ok {
+		fn, err := interpreter.ResolveFunction(f)
+		if err != nil {
+			return err
+		}
+		s.Predicate = fn
+	}
+	return nil
+}
+
+func (s *SchemaOpSpec) copy() SchemaOpSpec {
+	ns := *s
+	if s.Predicate != nil {
+		ns.Predicate = s.Predicate.Copy().(*semantic.FunctionExpression)
+	}
+	return ns
+}
+
+// TagKeysOpSpec is the operation spec of tagKeys, returning the tag keys of a bucket.
+type TagKeysOpSpec struct {
+	SchemaOpSpec
+}
+
+func createTagKeysOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	spec := new(TagKeysOpSpec)
+	if err := spec.readArgs(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func newTagKeysOp() flux.OperationSpec {
+	return new(TagKeysOpSpec)
+}
+
+func (s *TagKeysOpSpec) Kind() flux.OperationKind {
+	return TagKeysKind
+}
+
+// TagValuesOpSpec is the operation spec of tagValues, returning the values of
+// a tag of a bucket, and of measurements and fieldKeys, returning the values
+// of the _measurement and _field tags.
+type TagValuesOpSpec struct {
+	SchemaOpSpec
+	Tag string `json:"tag"`
+}
+
+func createTagValuesOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	tag, err := args.GetRequiredString("tag")
+	if err != nil {
+		return nil, err
+	}
+	return createTagValuesOpSpecOf(tag)(args, a)
+}
+
+// createTagValuesOpSpecOf returns a function creating a TagValuesOpSpec for the values of tag.
+func createTagValuesOpSpecOf(tag string) flux.CreateOperationSpec {
+	return func(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+		spec := &TagValuesOpSpec{Tag: tag}
+		if err := spec.readArgs(args); err != nil {
+			return nil, err
+		}
+		return spec, nil
+	}
+}
+
+func newTagValuesOp() flux.OperationSpec {
+	return new(TagValuesOpSpec)
+}
+
+func (s *TagValuesOpSpec) Kind() flux.OperationKind {
+	return TagValuesKind
+}
+
+type TagKeysProcedureSpec struct {
+	plan.DefaultCost
+	SchemaOpSpec
+}
+
+func newTagKeysProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*TagKeysOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &TagKeysProcedureSpec{SchemaOpSpec: spec.copy()}, nil
+}
+
+func (s *TagKeysProcedureSpec) Kind() plan.ProcedureKind {
+	return TagKeysKind
+}
+
+func (s *TagKeysProcedureSpec) Copy() plan.ProcedureSpec {
+	return &TagKeysProcedureSpec{SchemaOpSpec: s.copy()}
+}
+
+type TagValuesProcedureSpec struct {
+	plan.DefaultCost
+	SchemaOpSpec
+	Tag string
+}
+
+func newTagValuesProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*TagValuesOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &TagValuesProcedureSpec{SchemaOpSpec: spec.copy(), Tag: spec.Tag}, nil
+}
+
+func (s *TagValuesProcedureSpec) Kind() plan.ProcedureKind {
+	return TagValuesKind
+}
+
+func (s *TagValuesProcedureSpec) Copy() plan.ProcedureSpec {
+	return &TagValuesProcedureSpec{SchemaOpSpec: s.copy(), Tag: s.Tag}
+}
+
+func createTagKeysSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := prSpec.(*TagKeysProcedureSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", prSpec)
+	}
+	return createSchemaSource(&spec.SchemaOpSpec, "", dsid, a)
+}
+
+func createTagValuesSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := prSpec.(*TagValuesProcedureSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", prSpec)
+	}
+	return createSchemaSource(&spec.SchemaOpSpec, spec.Tag, dsid, a)
+}
+
+// createSchemaSource creates a source returning the tag keys of the bucket of
+// spec if tag is empty, and the values of tag otherwise.
+func createSchemaSource(spec *SchemaOpSpec, tag string, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	// the schema functions read the bucket with the dependencies of from.
+	deps := a.Dependencies()[inputs.FromKind].(storage.Dependencies)
+	if deps.SchemaReader == nil {
+		return nil, errors.New("schema functions are not supported by the storage reader")
+	}
+
+	req := query.RequestFromContext(a.Context())
+	if req == nil {
+		return nil, errors.New("missing request on context")
+	}
+	orgID := req.OrganizationID
+
+	var bucketID platform.ID
+	switch {
+	case spec.Bucket != "":
+		b, ok := deps.BucketLookup.Lookup(orgID, spec.Bucket)
+		if !ok {
+			return nil, fmt.Errorf("could not find bucket %q", spec.Bucket)
+		}
+		bucketID = b
+	case len(spec.BucketID) != 0:
+		if err := bucketID.DecodeFromString(spec.BucketID); err != nil {
+			return nil, err
+		}
+	}
+
+	ss := storage.SchemaSpec{
+		OrganizationID: orgID,
+		BucketID:       bucketID,
+		TagKey:         tag,
+		Predicate:      spec.Predicate,
+		AllTime:        spec.Start.IsZero(),
+	}
+	if !ss.AllTime {
+		ss.Start = a.ResolveTime(spec.Start)
+		ss.Stop = a.ResolveTime(spec.Stop)
+	}
+
+	sd := &SchemaDecoder{
+		ctx:    a.Context(),
+		reader: deps.SchemaReader,
+		spec:   ss,
+		alloc:  a.Allocator(),
+	}
+	return inputs.CreateSourceFromDecoder(sd, dsid, a)
+}
+
+// SchemaDecoder decodes the tag keys or tag values of a schema request to a
+// table with a single _value column.
+type SchemaDecoder struct {
+	ctx    context.Context
+	reader storage.SchemaReader
+	spec   storage.SchemaSpec
+	values []string
+	alloc  *memory.Allocator
+}
+
+func (sd *SchemaDecoder) Connect() error {
+	return nil
+}
+
+func (sd *SchemaDecoder) Fetch() (bool, error) {
+	var err error
+	if sd.spec.TagKey == "" {
+		sd.values, err = sd.reader.TagKeys(sd.ctx, sd.spec)
+	} else {
+		sd.values, err = sd.reader.TagValues(sd.ctx, sd.spec)
+	}
+	return false, err
+}
+
+func (sd *SchemaDecoder) Decode() (flux.Table, error) {
+	gk := execute.NewGroupKey(nil, nil)
+	b := execute.NewColListTableBuilder(gk, sd.alloc)
+
+	if _, err := b.AddCol(flux.ColMeta{
+		Label: execute.DefaultValueColLabel,
+		Type:  flux.TString,
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, v := range sd.values {
+		if err := b.AppendValue(0, values.NewString(v)); err != nil {
+			return nil, err
+		}
+	}
+	return b.Table()
+}
@@ -0,0 +1,104 @@
+package inputs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/platform/query/functions/inputs"
+)
+
+func TestSchema_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name:    "tagKeys no bucket",
+			Raw:     `tagKeys()`,
+			WantErr: true,
+		},
+		{
+			Name:    "tagValues no tag",
+			Raw:     `tagValues(bucket:"telegraf")`,
+			WantErr: true,
+		},
+		{
+			Name:    "tagKeys stop without start",
+			Raw:     `tagKeys(bucket:"telegraf", stop:-1h)`,
+			WantErr: true,
+		},
+		{
+			Name: "tagKeys",
+			Raw:  `tagKeys(bucket:"telegraf")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "tagKeys0",
+						Spec: &inputs.TagKeysOpSpec{
+							SchemaOpSpec: inputs.SchemaOpSpec{Bucket: "telegraf"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "tagValues with time range",
+			Raw:  `tagValues(bucketID:"aaaabbbbccccdddd", tag:"host", start:-4h, stop:-2h)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "tagValues0",
+						Spec: &inputs.TagValuesOpSpec{
+							SchemaOpSpec: inputs.SchemaOpSpec{
+								BucketID: "aaaabbbbccccdddd",
+								Start:    flux.Time{Relative: -4 * time.Hour, IsRelative: true},
+								Stop:     flux.Time{Relative: -2 * time.Hour, IsRelative: true},
+							},
+							Tag: "host",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "measurements",
+			Raw:  `measurements(bucket:"telegraf", start:-1h)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "tagValues0",
+						Spec: &inputs.TagValuesOpSpec{
+							SchemaOpSpec: inputs.SchemaOpSpec{
+								Bucket: "telegraf",
+								Start:  flux.Time{Relative: -1 * time.Hour, IsRelative: true},
+								Stop:   flux.Now,
+							},
+							Tag: "_measurement",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "fieldKeys",
+			Raw:  `fieldKeys(bucket:"telegraf")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "tagValues0",
+						Spec: &inputs.TagValuesOpSpec{
+							SchemaOpSpec: inputs.SchemaOpSpec{Bucket: "telegraf"},
+							Tag:          "_field",
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"math"
+
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/functions/transformations"
+	"github.com/influxdata/flux/plan"
+)
+
+func init() {
+	plan.RegisterPhysicalRules(
+		PushDownWindowAggregateRule{AggregateKind: transformations.MinKind},
+		PushDownWindowAggregateRule{AggregateKind: transformations.MaxKind},
+		PushDownWindowAggregateRule{AggregateKind: transformations.FirstKind},
+		PushDownWindowAggregateRule{AggregateKind: transformations.LastKind},
+		PushDownWindowAggregateRule{AggregateKind: transformations.MeanKind},
+		PushDownWindowAggregateRule{AggregateKind: transformations.SumKind},
+		PushDownWindowAggregateRule{AggregateKind: transformations.CountKind},
+	)
+}
+
+// PushDownWindowAggregateRule pushes a `window` followed by an aggregate or selector into a `from`,
+// so that storage computes one point for each window of each series instead of reading every point.
+type PushDownWindowAggregateRule struct {
+	AggregateKind plan.ProcedureKind
+}
+
+// Name returns the name of the rule
+func (rule PushDownWindowAggregateRule) Name() string {
+	return "PushDownWindowAggregateRule_" + string(rule.AggregateKind)
+}
+
+// Pattern returns the pattern that matches `from -> window -> aggregate`
+func (rule PushDownWindowAggregateRule) Pattern() plan.Pattern {
+	return plan.Pat(rule.AggregateKind, plan.Pat(transformations.WindowKind, plan.Pat(inputs.FromKind)))
+}
+
+// Rewrite merges a `from -> window -> aggregate` into a `from` that reads the aggregate of each window.
+func (rule PushDownWindowAggregateRule) Rewrite(aggNode plan.PlanNode) (plan.PlanNode, bool, error) {
+	windowNode := aggNode.Predecessors()[0]
+	fromNode := windowNode.Predecessors()[0]
+	windowSpec := windowNode.ProcedureSpec().(*transformations.WindowProcedureSpec)
+	fromSpec := fromNode.ProcedureSpec().(*inputs.FromProcedureSpec)
+
+	if !fromSpec.BoundsSet ||
+		fromSpec.LimitSet ||
+		fromSpec.DescendingSet ||
+		fromSpec.WindowSet ||
+		fromSpec.GroupingSet ||
+		fromSpec.AggregateSet ||
+		!canPushDownWindow(windowSpec) ||
+		!canPushDownAggregate(aggNode.ProcedureSpec()) {
+		return aggNode, false, nil
+	}
+
+	newFromSpec := fromSpec.Copy().(*inputs.FromProcedureSpec)
+	newFromSpec.WindowSet = true
+	newFromSpec.Window = windowSpec.Window
+	newFromSpec.AggregateSet = true
+	newFromSpec.AggregateMethod = string(rule.AggregateKind)
+
+	merged, err := plan.MergePhysicalPlanNodes(windowNode, fromNode, newFromSpec)
+	if err != nil {
+		return nil, false, err
+	}
+	aggNode.ClearPredecessors()
+	aggNode.AddPredecessors(merged)
+	merged.AddSuccessors(aggNode)
+
+	merged, err = plan.MergePhysicalPlanNodes(aggNode, merged, newFromSpec)
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, true, nil
+}
+
+// canPushDownWindow reports whether storage can produce the windows of spec:
+// adjacent windows of a finite width that are only created for the times that have points.
+func canPushDownWindow(spec *transformations.WindowProcedureSpec) bool {
+	return spec.Window.Every > 0 &&
+		spec.Window.Every != math.MaxInt64 &&
+		spec.Window.Period == spec.Window.Every &&
+		spec.Window.Round == 0 &&
+		spec.TimeColumn == execute.DefaultTimeColLabel &&
+		spec.StartColumn == execute.DefaultStartColLabel &&
+		spec.StopColumn == execute.DefaultStopColLabel &&
+		!spec.CreateEmpty
+}
+
+// canPushDownAggregate reports whether spec only aggregates the value column.
+func canPushDownAggregate(spec plan.ProcedureSpec) bool {
+	var selector *execute.SelectorConfig
+	var aggregate *execute.AggregateConfig
+	switch spec := spec.(type) {
+	case *transformations.MinProcedureSpec:
+		selector = &spec.SelectorConfig
+	case *transformations.MaxProcedureSpec:
+		selector = &spec.SelectorConfig
+	case *transformations.FirstProcedureSpec:
+		selector = &spec.SelectorConfig
+	case *transformations.LastProcedureSpec:
+		selector = &spec.SelectorConfig
+	case *transformations.MeanProcedureSpec:
+		aggregate = &spec.AggregateConfig
+	case *transformations.SumProcedureSpec:
+		aggregate = &spec.AggregateConfig
+	case *transformations.CountProcedureSpec:
+		aggregate = &spec.AggregateConfig
+	default:
+		return false
+	}
+
+	if selector != nil {
+		return selector.Column == execute.DefaultValueColLabel
+	}
+	return len(aggregate.Columns) == 1 && aggregate.Columns[0] == execute.DefaultValueColLabel
+}
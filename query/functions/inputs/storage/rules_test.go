@@ -0,0 +1,145 @@
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/functions/transformations"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/plantest"
+	"github.com/influxdata/platform/query/functions/inputs/storage"
+)
+
+func TestPushDownWindowAggregateRule(t *testing.T) {
+	bounds := flux.Bounds{
+		Start: flux.Time{Absolute: time.Unix(0, 0)},
+		Stop:  flux.Time{Absolute: time.Unix(3600, 0)},
+	}
+	from := &inputs.FromProcedureSpec{
+		Bucket:    "telegraf",
+		BoundsSet: true,
+		Bounds:    bounds,
+	}
+	window := func(every time.Duration, createEmpty bool) *transformations.WindowProcedureSpec {
+		return &transformations.WindowProcedureSpec{
+			Window: plan.WindowSpec{
+				Every:  flux.Duration(every),
+				Period: flux.Duration(every),
+			},
+			TimeColumn:  execute.DefaultTimeColLabel,
+			StartColumn: execute.DefaultStartColLabel,
+			StopColumn:  execute.DefaultStopColLabel,
+			CreateEmpty: createEmpty,
+		}
+	}
+	max := &transformations.MaxProcedureSpec{
+		SelectorConfig: execute.SelectorConfig{Column: execute.DefaultValueColLabel},
+	}
+	mean := &transformations.MeanProcedureSpec{
+		AggregateConfig: execute.DefaultAggregateConfig,
+	}
+	maxHost := &transformations.MaxProcedureSpec{
+		SelectorConfig: execute.SelectorConfig{Column: "host"},
+	}
+	unbounded := &inputs.FromProcedureSpec{Bucket: "telegraf"}
+	// unchanged returns the plan of from -> window -> agg.
+	// The expected plan is built from the specs rather than copied,
+	// as copying a window spec drops its columns.
+	unchanged := func(from *inputs.FromProcedureSpec, window *transformations.WindowProcedureSpec, agg plan.PhysicalProcedureSpec) *plantest.PlanSpec {
+		return &plantest.PlanSpec{
+			Nodes: []plan.PlanNode{
+				plan.CreatePhysicalNode("from", from),
+				plan.CreatePhysicalNode("window", window),
+				plan.CreatePhysicalNode("agg", agg),
+			},
+			Edges: [][2]int{{0, 1}, {1, 2}},
+		}
+	}
+	windowAggregate := func(method string) *inputs.FromProcedureSpec {
+		spec := from.Copy().(*inputs.FromProcedureSpec)
+		spec.WindowSet = true
+		spec.Window = window(time.Minute, false).Window
+		spec.AggregateSet = true
+		spec.AggregateMethod = method
+		return spec
+	}
+
+	rules := []plan.Rule{
+		storage.PushDownWindowAggregateRule{AggregateKind: transformations.MaxKind},
+		storage.PushDownWindowAggregateRule{AggregateKind: transformations.MeanKind},
+	}
+
+	tests := []plantest.RuleTestCase{
+		{
+			Name: "window max",
+			// from -> window -> max  =>  from
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plan.CreatePhysicalNode("from", from),
+					plan.CreatePhysicalNode("window", window(time.Minute, false)),
+					plan.CreatePhysicalNode("max", max),
+				},
+				Edges: [][2]int{{0, 1}, {1, 2}},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plan.CreatePhysicalNode("merged_from_window_max", windowAggregate("max")),
+				},
+			},
+		},
+		{
+			Name: "window mean with successor",
+			// from -> window -> mean -> count  =>  from -> count
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plan.CreatePhysicalNode("from", from),
+					plan.CreatePhysicalNode("window", window(time.Minute, false)),
+					plan.CreatePhysicalNode("mean", mean),
+					plan.CreatePhysicalNode("count", &transformations.CountProcedureSpec{}),
+				},
+				Edges: [][2]int{{0, 1}, {1, 2}, {2, 3}},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plan.CreatePhysicalNode("merged_from_window_mean", windowAggregate("mean")),
+					plan.CreatePhysicalNode("count", &transformations.CountProcedureSpec{}),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+		},
+		{
+			Name: "window with empty windows",
+			// from -> window(createEmpty: true) -> max
+			Rules:  rules,
+			Before: unchanged(from, window(time.Minute, true), max),
+			After:  unchanged(from, window(time.Minute, true), max),
+		},
+		{
+			Name: "max of another column",
+			// from -> window -> max(column: "host")
+			Rules:  rules,
+			Before: unchanged(from, window(time.Minute, false), maxHost),
+			After:  unchanged(from, window(time.Minute, false), maxHost),
+		},
+		{
+			Name: "unbounded from",
+			// from -> window -> max
+			Rules:  rules,
+			Before: unchanged(unbounded, window(time.Minute, false), max),
+			After:  unchanged(unbounded, window(time.Minute, false), max),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.RuleTestHelper(t, &tc)
+		})
+	}
+}
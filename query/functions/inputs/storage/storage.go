@@ -10,6 +10,7 @@ import (
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/functions"
+	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/platform"
 	"github.com/pkg/errors"
@@ -32,6 +33,10 @@ type Dependencies struct {
 	Reader             Reader
 	BucketLookup       BucketLookup
 	OrganizationLookup OrganizationLookup
+
+	// SchemaReader answers the schema sources, such as tagKeys and tagValues.
+	// It is optional; the schema sources fail without it.
+	SchemaReader SchemaReader
 }
 
 func (d Dependencies) Validate() error {
@@ -211,6 +216,11 @@ type ReadSpec struct {
 	Descending   bool
 
 	AggregateMethod string
+	// WindowEvery is the width of the windows AggregateMethod is applied to.
+	// When it is zero, the aggregate is applied to the whole time range of the read.
+	WindowEvery execute.Duration
+	// WindowOffset shifts the window boundaries from multiples of WindowEvery.
+	WindowOffset execute.Duration
 
 	// OrderByTime indicates that series reads should produce all
 	// series for a time before producing any series for a larger time.
@@ -227,9 +237,38 @@ type ReadSpec struct {
 
 	Database        string // required by InfluxDB OSS
 	RetentionPolicy string // required by InfluxDB OSS
+
+	// Allocator accounts the memory of the tables the reader builds itself,
+	// exp for windowed aggregates, against the limit of the query.
+	// The memory is not limited when it is nil.
+	Allocator *memory.Allocator
 }
 
 type Reader interface {
 	Read(ctx context.Context, rs ReadSpec, start, stop execute.Time) (flux.TableIterator, error)
 	Close()
 }
+
+// SchemaSpec describes a request for the tag keys or the values of a tag key of a bucket.
+type SchemaSpec struct {
+	OrganizationID platform.ID
+	BucketID       platform.ID
+
+	// TagKey is the key whose values are requested by TagValues.
+	TagKey    string
+	Predicate *semantic.FunctionExpression
+
+	// AllTime requests the schema of all the data of the bucket,
+	// in which case Start and Stop are ignored.
+	AllTime     bool
+	Start, Stop execute.Time
+}
+
+// SchemaReader answers schema requests from the index of the storage engine
+// without reading any points.
+type SchemaReader interface {
+	// TagKeys returns the sorted tag keys of the series matching spec.
+	TagKeys(ctx context.Context, spec SchemaSpec) ([]string, error)
+	// TagValues returns the sorted values of spec.TagKey of the series matching spec.
+	TagValues(ctx context.Context, spec SchemaSpec) ([]string, error)
+}
@@ -0,0 +1,117 @@
+package platform
+
+import (
+	"context"
+	"net/url"
+)
+
+// ReplicationRule forwards the points written to a bucket to a bucket of a
+// remote instance.
+type ReplicationRule struct {
+	ID             ID     `json:"id,omitempty"`
+	OrganizationID ID     `json:"orgID"`
+	BucketID       ID     `json:"bucketID"`
+	Name           string `json:"name"`
+
+	// RemoteURL is the address of the remote instance.
+	RemoteURL string `json:"remoteURL"`
+	// RemoteToken authorizes writes to the remote bucket.
+	RemoteToken string `json:"remoteToken,omitempty"`
+	// RemoteOrgID and RemoteBucketID identify the remote bucket.
+	RemoteOrgID    ID `json:"remoteOrgID"`
+	RemoteBucketID ID `json:"remoteBucketID"`
+}
+
+// Redacted returns a copy of the rule without its remote token.
+func (r ReplicationRule) Redacted() ReplicationRule {
+	r.RemoteToken = ""
+	return r
+}
+
+// Valid returns an error if points cannot be replicated with the rule.
+func (r *ReplicationRule) Valid() error {
+	if !r.OrganizationID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "replication rule requires an organization",
+		}
+	}
+	if !r.BucketID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "replication rule requires a bucket",
+		}
+	}
+	if r.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "replication rule name is required",
+		}
+	}
+	if u, err := url.ParseRequestURI(r.RemoteURL); err != nil || u.Host == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "replication rule requires a valid remote url",
+			Err:  err,
+		}
+	}
+	if !r.RemoteOrgID.Valid() || !r.RemoteBucketID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "replication rule requires a remote organization and bucket",
+		}
+	}
+	return nil
+}
+
+// ReplicationRuleUpdate is a set of changes to a replication rule. Nil fields are left unchanged.
+type ReplicationRuleUpdate struct {
+	Name           *string `json:"name,omitempty"`
+	RemoteURL      *string `json:"remoteURL,omitempty"`
+	RemoteToken    *string `json:"remoteToken,omitempty"`
+	RemoteOrgID    *ID     `json:"remoteOrgID,omitempty"`
+	RemoteBucketID *ID     `json:"remoteBucketID,omitempty"`
+}
+
+// Apply applies the non-nil fields of the update to r.
+func (u ReplicationRuleUpdate) Apply(r *ReplicationRule) {
+	if u.Name != nil {
+		r.Name = *u.Name
+	}
+	if u.RemoteURL != nil {
+		r.RemoteURL = *u.RemoteURL
+	}
+	if u.RemoteToken != nil {
+		r.RemoteToken = *u.RemoteToken
+	}
+	if u.RemoteOrgID != nil {
+		r.RemoteOrgID = *u.RemoteOrgID
+	}
+	if u.RemoteBucketID != nil {
+		r.RemoteBucketID = *u.RemoteBucketID
+	}
+}
+
+// ReplicationRuleFilter represents a set of filters that restrict the returned replication rules.
+type ReplicationRuleFilter struct {
+	OrganizationID *ID
+	BucketID       *ID
+}
+
+// ReplicationRuleService manages replication rules.
+type ReplicationRuleService interface {
+	// FindReplicationRuleByID returns a single replication rule by ID.
+	FindReplicationRuleByID(ctx context.Context, id ID) (*ReplicationRule, error)
+
+	// FindReplicationRules returns the replication rules that match filter.
+	FindReplicationRules(ctx context.Context, filter ReplicationRuleFilter) ([]*ReplicationRule, error)
+
+	// CreateReplicationRule creates a new replication rule and sets r.ID with the new identifier.
+	CreateReplicationRule(ctx context.Context, r *ReplicationRule) error
+
+	// UpdateReplicationRule updates a single replication rule with a changeset and returns the updated rule.
+	UpdateReplicationRule(ctx context.Context, id ID, upd ReplicationRuleUpdate) (*ReplicationRule, error)
+
+	// DeleteReplicationRule removes a replication rule by ID.
+	DeleteReplicationRule(ctx context.Context, id ID) error
+}
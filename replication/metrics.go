@@ -0,0 +1,114 @@
+package replication
+
+import (
+	"github.com/influxdata/platform"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// namespace is the leading part of all published metrics for the replication service.
+const namespace = "replication"
+
+const ruleLabel = "rule_id"
+
+// metrics are the metrics of the replication of each rule.
+type metrics struct {
+	QueueBytes   *prometheus.GaugeVec
+	QueueEntries *prometheus.GaugeVec
+	Lag          *prometheus.GaugeVec
+	Batches      *prometheus.CounterVec
+	Failures     *prometheus.CounterVec
+	Dropped      *prometheus.CounterVec
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		QueueBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "queue_bytes",
+			Help:      "Size of the points queued for replication.",
+		}, []string{ruleLabel}),
+
+		QueueEntries: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "queue_batches",
+			Help:      "Number of batches of points queued for replication.",
+		}, []string{ruleLabel}),
+
+		Lag: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "lag_seconds",
+			Help:      "Time since the oldest batch of points queued for replication was written.",
+		}, []string{ruleLabel}),
+
+		Batches: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "batches_total",
+			Help:      "Number of batches of points written to the remote.",
+		}, []string{ruleLabel}),
+
+		Failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "write_failures_total",
+			Help:      "Number of failed writes to the remote that were retried.",
+		}, []string{ruleLabel}),
+
+		Dropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "dropped_batches_total",
+			Help:      "Number of batches of points that were not replicated.",
+		}, []string{ruleLabel, "reason"}),
+	}
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (m *metrics) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.QueueBytes,
+		m.QueueEntries,
+		m.Lag,
+		m.Batches,
+		m.Failures,
+		m.Dropped,
+	}
+}
+
+// ruleMetrics are the metrics of the replication of a single rule.
+type ruleMetrics struct {
+	id           string
+	QueueBytes   prometheus.Gauge
+	QueueEntries prometheus.Gauge
+	Lag          prometheus.Gauge
+	Batches      prometheus.Counter
+	Failures     prometheus.Counter
+	Dropped      *prometheus.CounterVec
+}
+
+func (m *metrics) ruleMetrics(id platform.ID) *ruleMetrics {
+	labels := prometheus.Labels{ruleLabel: id.String()}
+	return &ruleMetrics{
+		id:           id.String(),
+		QueueBytes:   m.QueueBytes.With(labels),
+		QueueEntries: m.QueueEntries.With(labels),
+		Lag:          m.Lag.With(labels),
+		Batches:      m.Batches.With(labels),
+		Failures:     m.Failures.With(labels),
+		Dropped:      m.Dropped,
+	}
+}
+
+func (m *ruleMetrics) dropped(reason string) prometheus.Counter {
+	return m.Dropped.WithLabelValues(m.id, reason)
+}
+
+// deleteRuleMetrics removes the metrics of the rule with id.
+func (m *metrics) deleteRuleMetrics(id platform.ID) {
+	labels := prometheus.Labels{ruleLabel: id.String()}
+	m.QueueBytes.Delete(labels)
+	m.QueueEntries.Delete(labels)
+	m.Lag.Delete(labels)
+	m.Batches.Delete(labels)
+	m.Failures.Delete(labels)
+	for _, reason := range []string{"queue_full", "invalid", "rejected"} {
+		m.Dropped.DeleteLabelValues(id.String(), reason)
+	}
+}
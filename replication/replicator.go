@@ -0,0 +1,151 @@
+package replication
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
+	"github.com/influxdata/platform/pkg/durablequeue"
+	"go.uber.org/zap"
+)
+
+// replicator writes the points of the queue of a rule to the remote of the rule.
+type replicator struct {
+	rule    platform.ReplicationRule
+	queue   *durablequeue.Queue
+	writer  platform.WriteService
+	logger  *zap.Logger
+	metrics *ruleMetrics
+
+	timeout    time.Duration
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// start starts writing the points of the queue to the remote.
+func (r *replicator) start() {
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.run()
+	}()
+}
+
+// stop stops writing to the remote, canceling a write in progress, and
+// closes the queue.
+func (r *replicator) stop() error {
+	if r.cancel != nil {
+		r.cancel()
+		r.wg.Wait()
+	}
+	return r.queue.Close()
+}
+
+// enqueue appends an entry to the queue.
+func (r *replicator) enqueue(entry []byte) {
+	if err := r.queue.Append(entry); err != nil {
+		r.logger.Warn("Failed to queue points for replication", zap.Error(err))
+		r.metrics.dropped("queue_full").Inc()
+	}
+	r.metrics.QueueBytes.Set(float64(r.queue.Size()))
+	r.metrics.QueueEntries.Set(float64(r.queue.Len()))
+}
+
+func (r *replicator) run() {
+	backoff := r.minBackoff
+	for {
+		err := r.replicateNext()
+		if err == io.EOF {
+			r.metrics.Lag.Set(0)
+			select {
+			case <-r.queue.Appended():
+				continue
+			case <-r.ctx.Done():
+				return
+			}
+		}
+
+		if err != nil {
+			select {
+			case <-time.After(backoff):
+			case <-r.ctx.Done():
+				return
+			}
+			if backoff *= 2; backoff > r.maxBackoff {
+				backoff = r.maxBackoff
+			}
+			continue
+		}
+		backoff = r.minBackoff
+	}
+}
+
+// replicateNext writes the first entry of the queue to the remote and removes
+// it from the queue. io.EOF is returned if the queue is empty.
+func (r *replicator) replicateNext() error {
+	b, err := r.queue.Peek()
+	if err == io.EOF {
+		return err
+	} else if err != nil {
+		r.logger.Error("Failed to read replication queue", zap.Error(err))
+		return err
+	}
+
+	written, data, err := decodeEntry(b)
+	if err != nil {
+		r.logger.Error("Dropping invalid replication queue entry", zap.Error(err))
+		r.metrics.dropped("invalid").Inc()
+		return r.advance()
+	}
+	r.metrics.Lag.Set(time.Since(written).Seconds())
+
+	ctx, cancel := context.WithTimeout(r.ctx, r.timeout)
+	defer cancel()
+	if err := r.writer.Write(ctx, r.rule.RemoteOrgID, r.rule.RemoteBucketID, bytes.NewReader(data)); err != nil {
+		if r.ctx.Err() != nil {
+			return err
+		}
+		if !isRejected(err) {
+			r.logger.Info("Failed to replicate points; retrying", zap.Error(err))
+			r.metrics.Failures.Inc()
+			return err
+		}
+		// Retrying points rejected by the remote would stop replication.
+		r.logger.Error("Remote rejected replicated points; dropping them", zap.Error(err))
+		r.metrics.dropped("rejected").Inc()
+		return r.advance()
+	}
+
+	r.metrics.Batches.Inc()
+	return r.advance()
+}
+
+func (r *replicator) advance() error {
+	err := r.queue.Advance()
+	r.metrics.QueueBytes.Set(float64(r.queue.Size()))
+	r.metrics.QueueEntries.Set(float64(r.queue.Len()))
+	return err
+}
+
+// isRejected returns true if err reports that the remote will never accept
+// the points written, such as points with a type conflicting with the data
+// of the remote bucket.
+func isRejected(err error) bool {
+	switch e := err.(type) {
+	case *kerrors.Error:
+		return e.Code == http.StatusBadRequest || e.Code == http.StatusRequestEntityTooLarge
+	case kerrors.Error:
+		return e.Code == http.StatusBadRequest || e.Code == http.StatusRequestEntityTooLarge
+	}
+	return platform.ErrorCode(err) == platform.EInvalid
+}
@@ -0,0 +1,54 @@
+package replication
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+	"go.uber.org/zap"
+)
+
+var _ platform.ReplicationRuleService = (*ruleService)(nil)
+
+// RuleService returns the replication rule service of s, applying changes
+// to the rules as soon as they are made rather than at the next refresh.
+func (s *Service) RuleService() platform.ReplicationRuleService {
+	return &ruleService{ReplicationRuleService: s.rules, s: s}
+}
+
+type ruleService struct {
+	platform.ReplicationRuleService
+	s *Service
+}
+
+func (rs *ruleService) CreateReplicationRule(ctx context.Context, r *platform.ReplicationRule) error {
+	if err := rs.ReplicationRuleService.CreateReplicationRule(ctx, r); err != nil {
+		return err
+	}
+	rs.refresh()
+	return nil
+}
+
+func (rs *ruleService) UpdateReplicationRule(ctx context.Context, id platform.ID, upd platform.ReplicationRuleUpdate) (*platform.ReplicationRule, error) {
+	r, err := rs.ReplicationRuleService.UpdateReplicationRule(ctx, id, upd)
+	if err != nil {
+		return nil, err
+	}
+	rs.refresh()
+	return r, nil
+}
+
+func (rs *ruleService) DeleteReplicationRule(ctx context.Context, id platform.ID) error {
+	if err := rs.ReplicationRuleService.DeleteReplicationRule(ctx, id); err != nil {
+		return err
+	}
+	rs.refresh()
+	return nil
+}
+
+// refresh applies the rules. A failure is logged as the rules are applied
+// again at the next refresh.
+func (rs *ruleService) refresh() {
+	if err := rs.s.Refresh(); err != nil {
+		rs.s.Logger.Info("Failed to refresh replication rules", zap.Error(err))
+	}
+}
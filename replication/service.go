@@ -0,0 +1,444 @@
+// Package replication forwards the points written to buckets to buckets of
+// remote instances.
+//
+// A Service sits in front of the storage engine. Every batch of points the
+// engine accepts is appended, per bucket, to an on-disk queue of each
+// replication rule of the bucket, and the queue of each rule is written to
+// its remote bucket in order, retrying with backoff while the remote is
+// unavailable.
+package replication
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/pkg/durablequeue"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultRefreshInterval is the default interval at which the replication
+	// rules are reloaded.
+	DefaultRefreshInterval = 30 * time.Second
+
+	// DefaultMinRetryBackoff and DefaultMaxRetryBackoff bound the delay
+	// between attempts to write to an unavailable remote.
+	DefaultMinRetryBackoff = time.Second
+	DefaultMaxRetryBackoff = time.Minute
+
+	// DefaultWriteTimeout is the default timeout of a write to a remote.
+	DefaultWriteTimeout = 30 * time.Second
+
+	// ruleServiceTimeout bounds the time taken to load the replication rules.
+	ruleServiceTimeout = 10 * time.Second
+)
+
+// A WriteServiceFunc returns the service writing to the remote of a rule.
+type WriteServiceFunc func(rule *platform.ReplicationRule) platform.WriteService
+
+// Service is a storage.PointsWriter writing points to another PointsWriter
+// and replicating the points that were written according to the replication
+// rules of their bucket.
+type Service struct {
+	Logger *zap.Logger
+
+	// RefreshInterval is the interval at which the replication rules are reloaded.
+	RefreshInterval time.Duration
+
+	// MaxQueueSize is the maximum size in bytes of the queue of a rule.
+	// Points written while the queue of a rule is full are not replicated.
+	MaxQueueSize int64
+
+	// MinRetryBackoff and MaxRetryBackoff bound the delay between attempts
+	// to write to an unavailable remote.
+	MinRetryBackoff time.Duration
+	MaxRetryBackoff time.Duration
+
+	// WriteTimeout is the timeout of a write to a remote.
+	WriteTimeout time.Duration
+
+	dir             string
+	writer          storage.PointsWriter
+	rules           platform.ReplicationRuleService
+	newWriteService WriteServiceFunc
+	metrics         *metrics
+
+	mu          sync.RWMutex
+	replicators map[platform.ID]*replicator
+	byName      map[string][]*replicator // By the encoded org and bucket of their rule.
+
+	refreshMu sync.Mutex
+	closing   chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewService returns a new Service writing points to w and replicating them
+// according to the rules of rs. The queues of the rules are stored in dir.
+func NewService(dir string, w storage.PointsWriter, rs platform.ReplicationRuleService, fn WriteServiceFunc) *Service {
+	return &Service{
+		Logger:          zap.NewNop(),
+		RefreshInterval: DefaultRefreshInterval,
+		MaxQueueSize:    durablequeue.DefaultMaxSize,
+		MinRetryBackoff: DefaultMinRetryBackoff,
+		MaxRetryBackoff: DefaultMaxRetryBackoff,
+		WriteTimeout:    DefaultWriteTimeout,
+		dir:             dir,
+		writer:          w,
+		rules:           rs,
+		newWriteService: fn,
+		metrics:         newMetrics(),
+		replicators:     make(map[platform.ID]*replicator),
+		byName:          make(map[string][]*replicator),
+	}
+}
+
+// WithLogger sets the logger of the service.
+func (s *Service) WithLogger(log *zap.Logger) {
+	s.Logger = log.With(zap.String("service", "replication"))
+}
+
+// PrometheusCollectors returns the metrics of the service.
+func (s *Service) PrometheusCollectors() []prometheus.Collector {
+	return s.metrics.PrometheusCollectors()
+}
+
+// Open loads the replication rules and starts replicating the points of the
+// queues of the rules.
+func (s *Service) Open() error {
+	if err := os.MkdirAll(s.dir, 0777); err != nil {
+		return err
+	}
+
+	s.closing = make(chan struct{})
+	if err := s.Refresh(); err != nil {
+		return err
+	}
+	if err := s.removeOrphanedQueues(); err != nil {
+		return err
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.refreshEvery(s.RefreshInterval)
+	}()
+	return nil
+}
+
+// Close stops replicating. Points that were not replicated remain queued
+// until the service is opened again.
+func (s *Service) Close() error {
+	if s.closing == nil {
+		return nil
+	}
+	close(s.closing)
+	s.wg.Wait()
+
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+	for id, r := range s.replicators {
+		if e := r.stop(); e != nil && err == nil {
+			err = e
+		}
+		delete(s.replicators, id)
+	}
+	s.byName = make(map[string][]*replicator)
+	s.closing = nil
+	return err
+}
+
+// WritePoints writes points and queues the points that were written for
+// replication. Points that cannot be queued are logged and counted, but do
+// not fail the write.
+func (s *Service) WritePoints(points []models.Point) error {
+	err := s.writer.WritePoints(points)
+	if err != nil {
+		pwe, ok := err.(tsdb.PartialWriteError)
+		if !ok {
+			return err
+		}
+		points = withoutKeys(points, pwe.DroppedKeys)
+	}
+	s.replicate(points)
+	return err
+}
+
+// replicate appends the points of each bucket to the queues of the rules of the bucket.
+func (s *Service) replicate(points []models.Point) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.byName) == 0 {
+		return
+	}
+
+	now := time.Now()
+	for len(points) > 0 {
+		name := points[0].Name()
+		n := 1
+		for n < len(points) && bytes.Equal(points[n].Name(), name) {
+			n++
+		}
+		batch := points[:n]
+		points = points[n:]
+
+		rs := s.byName[string(name)]
+		if len(rs) == 0 {
+			continue
+		}
+
+		entry, err := encodeEntry(now, batch)
+		if err != nil {
+			s.Logger.Error("Failed to encode points for replication", zap.Error(err))
+			continue
+		}
+		for _, r := range rs {
+			r.enqueue(entry)
+		}
+	}
+}
+
+// Refresh reloads the replication rules, starting to replicate the points of
+// new rules and removing the queues of deleted rules.
+func (s *Service) Refresh() error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	if s.closing == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ruleServiceTimeout)
+	defer cancel()
+	rules, err := s.rules.FindReplicationRules(ctx, platform.ReplicationRuleFilter{})
+	if err != nil {
+		return err
+	}
+
+	// Replicators are created outside of the lock as opening their queues
+	// reads them from disk.
+	current := make(map[platform.ID]bool, len(rules))
+	started := make(map[platform.ID]*replicator)
+	for _, rule := range rules {
+		current[rule.ID] = true
+
+		s.mu.RLock()
+		r := s.replicators[rule.ID]
+		s.mu.RUnlock()
+		if r != nil && r.rule == *rule {
+			continue
+		}
+		if r != nil {
+			// The rule changed; the points it queued are sent to its new remote.
+			if err := s.stopReplicator(rule.ID, false); err != nil {
+				s.Logger.Error("Failed to stop replication", zap.Stringer("rule_id", rule.ID), zap.Error(err))
+			}
+		}
+
+		r, err := s.newReplicator(*rule)
+		if err != nil {
+			s.Logger.Error("Failed to start replication", zap.Stringer("rule_id", rule.ID), zap.Error(err))
+			continue
+		}
+		started[rule.ID] = r
+	}
+
+	s.mu.RLock()
+	var deleted []platform.ID
+	for id := range s.replicators {
+		if !current[id] {
+			deleted = append(deleted, id)
+		}
+	}
+	s.mu.RUnlock()
+	for _, id := range deleted {
+		if err := s.stopReplicator(id, true); err != nil {
+			s.Logger.Error("Failed to remove replication", zap.Stringer("rule_id", id), zap.Error(err))
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, r := range started {
+		s.replicators[id] = r
+		r.start()
+	}
+	s.indexReplicators()
+	return nil
+}
+
+// refreshEvery reloads the replication rules every interval until the service closes.
+func (s *Service) refreshEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Refresh(); err != nil {
+				s.Logger.Info("Failed to refresh replication rules", zap.Error(err))
+			}
+		case <-s.closing:
+			return
+		}
+	}
+}
+
+func (s *Service) newReplicator(rule platform.ReplicationRule) (*replicator, error) {
+	q := durablequeue.NewQueue(filepath.Join(s.dir, rule.ID.String()))
+	q.MaxSize = s.MaxQueueSize
+	if err := q.Open(); err != nil {
+		return nil, err
+	}
+
+	return &replicator{
+		rule:       rule,
+		queue:      q,
+		writer:     s.newWriteService(&rule),
+		logger:     s.Logger.With(zap.Stringer("rule_id", rule.ID)),
+		metrics:    s.metrics.ruleMetrics(rule.ID),
+		timeout:    s.WriteTimeout,
+		minBackoff: s.MinRetryBackoff,
+		maxBackoff: s.MaxRetryBackoff,
+	}, nil
+}
+
+// stopReplicator stops the replicator of the rule with id, removing its queue if remove is true.
+func (s *Service) stopReplicator(id platform.ID, remove bool) error {
+	s.mu.Lock()
+	r := s.replicators[id]
+	delete(s.replicators, id)
+	s.indexReplicators()
+	s.mu.Unlock()
+
+	if r == nil {
+		return nil
+	}
+	err := r.stop()
+	if remove {
+		s.metrics.deleteRuleMetrics(id)
+		if e := os.RemoveAll(r.queue.Dir()); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// indexReplicators indexes the replicators by the bucket of their rule. s.mu
+// must be held.
+func (s *Service) indexReplicators() {
+	s.byName = make(map[string][]*replicator, len(s.replicators))
+	for _, r := range s.replicators {
+		name := tsdb.EncodeName(r.rule.OrganizationID, r.rule.BucketID)
+		s.byName[string(name[:])] = append(s.byName[string(name[:])], r)
+	}
+}
+
+// removeOrphanedQueues removes the queues of rules that were deleted while
+// the service was closed.
+func (s *Service) removeOrphanedQueues() error {
+	fis, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, fi := range fis {
+		var id platform.ID
+		if !fi.IsDir() || id.DecodeFromString(fi.Name()) != nil {
+			continue
+		}
+		if _, ok := s.replicators[id]; ok {
+			continue
+		}
+		s.Logger.Info("Removing queue of deleted replication rule", zap.Stringer("rule_id", id))
+		if err := os.RemoveAll(filepath.Join(s.dir, fi.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withoutKeys returns the points whose keys are not in the sorted keys.
+func withoutKeys(points []models.Point, keys [][]byte) []models.Point {
+	if len(keys) == 0 {
+		return points
+	}
+
+	out := make([]models.Point, 0, len(points))
+	for _, pt := range points {
+		key := pt.Key()
+		i := sort.Search(len(keys), func(i int) bool { return bytes.Compare(keys[i], key) >= 0 })
+		if i < len(keys) && bytes.Equal(keys[i], key) {
+			continue
+		}
+		out = append(out, pt)
+	}
+	return out
+}
+
+// An entry of a queue is the time the points were written, followed by the
+// points in line protocol with their measurement names and field keys restored.
+const entryTimeSize = 8
+
+var errInvalidEntry = errors.New("invalid replication queue entry")
+
+func encodeEntry(t time.Time, points []models.Point) ([]byte, error) {
+	buf := make([]byte, entryTimeSize, entryTimeSize+64*len(points))
+	binary.BigEndian.PutUint64(buf, uint64(t.UnixNano()))
+	for _, pt := range points {
+		pt, err := implodePoint(pt)
+		if err != nil {
+			return nil, err
+		}
+		buf = pt.AppendString(buf)
+		buf = append(buf, '\n')
+	}
+	return buf, nil
+}
+
+func decodeEntry(b []byte) (time.Time, []byte, error) {
+	if len(b) < entryTimeSize {
+		return time.Time{}, nil, errInvalidEntry
+	}
+	return time.Unix(0, int64(binary.BigEndian.Uint64(b))), b[entryTimeSize:], nil
+}
+
+// implodePoint returns the point written with the exploded point pt.
+func implodePoint(pt models.Point) (models.Point, error) {
+	var name []byte
+	tags := make(models.Tags, 0, len(pt.Tags()))
+	for _, t := range pt.Tags() {
+		switch {
+		case bytes.Equal(t.Key, tsdb.MeasurementTagKeyBytes):
+			name = t.Value
+		case bytes.Equal(t.Key, tsdb.FieldKeyTagKeyBytes):
+		default:
+			tags = append(tags, t)
+		}
+	}
+
+	fields, err := pt.Fields()
+	if err != nil {
+		return nil, err
+	}
+	return models.NewPoint(string(name), tags, fields, pt.Time())
+}
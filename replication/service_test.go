@@ -0,0 +1,298 @@
+package replication_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/inmem"
+	kerrors "github.com/influxdata/platform/kit/errors"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/replication"
+	"github.com/influxdata/platform/tsdb"
+)
+
+var (
+	orgID          = platform.ID(1)
+	bucketID       = platform.ID(2)
+	otherBucketID  = platform.ID(3)
+	remoteOrgID    = platform.ID(10)
+	remoteBucketID = platform.ID(20)
+)
+
+func TestService_Replicate(t *testing.T) {
+	remote := NewRemote()
+	s := MustOpenService(t, remote)
+	defer s.Remove()
+
+	if err := s.WritePoints(MustExplode(bucketID, "cpu,host=a value=1 1", "cpu,host=a value=2 2")); err != nil {
+		t.Fatal(err)
+	}
+	// Points of buckets without a rule are not replicated.
+	if err := s.WritePoints(MustExplode(otherBucketID, "cpu,host=a value=3 3")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.WritePoints(MustExplode(bucketID, "mem,host=a free=4i,used=5i 4")); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []string{
+		"cpu,host=a value=1 1\ncpu,host=a value=2 2\n",
+		"mem,host=a free=4i 4\nmem,host=a used=5i 4\n",
+	}
+	if got := remote.Wait(t, len(exp)); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got writes %q, expected %q", got, exp)
+	}
+	if got, exp := len(s.engine.Points), 5; got != exp {
+		t.Fatalf("got %d points written to the engine, expected %d", got, exp)
+	}
+}
+
+func TestService_RetryAndReopen(t *testing.T) {
+	remote := NewRemote()
+	remote.Fail(errors.New("connection refused"))
+
+	s := MustOpenService(t, remote)
+	defer s.Remove()
+
+	for _, line := range []string{"cpu value=1 1", "cpu value=2 2"} {
+		if err := s.WritePoints(MustExplode(bucketID, line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	remote.WaitAttempts(t, 2)
+
+	// Points queued while the remote is unavailable survive a restart.
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	remote.Fail(nil)
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []string{"cpu value=1 1\n", "cpu value=2 2\n"}
+	if got := remote.Wait(t, len(exp)); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got writes %q, expected %q", got, exp)
+	}
+}
+
+func TestService_Rejected(t *testing.T) {
+	remote := NewRemote()
+	remote.Fail(&kerrors.Error{Code: http.StatusBadRequest, Err: "field type conflict"})
+
+	s := MustOpenService(t, remote)
+	defer s.Remove()
+
+	if err := s.WritePoints(MustExplode(bucketID, "cpu value=1 1")); err != nil {
+		t.Fatal(err)
+	}
+	remote.WaitAttempts(t, 1)
+
+	// Rejected points are dropped rather than blocking the points after them.
+	remote.Fail(nil)
+	if err := s.WritePoints(MustExplode(bucketID, "cpu value=2 2")); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []string{"cpu value=2 2\n"}
+	if got := remote.Wait(t, len(exp)); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got writes %q, expected %q", got, exp)
+	}
+}
+
+func TestService_PartialWrite(t *testing.T) {
+	remote := NewRemote()
+	s := MustOpenService(t, remote)
+	defer s.Remove()
+
+	points := MustExplode(bucketID, "cpu value=1 1", "mem value=2 2")
+	pwe := tsdb.PartialWriteError{Reason: "dropped", Dropped: 1, DroppedKeys: [][]byte{points[0].Key()}}
+	s.engine.ForceError(pwe)
+
+	if err := s.WritePoints(points); !reflect.DeepEqual(err, pwe) {
+		t.Fatalf("got error %v, expected %v", err, pwe)
+	}
+
+	// Only the points that were written are replicated.
+	exp := []string{"mem value=2 2\n"}
+	if got := remote.Wait(t, len(exp)); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got writes %q, expected %q", got, exp)
+	}
+}
+
+func TestService_DeleteRule(t *testing.T) {
+	remote := NewRemote()
+	remote.Fail(errors.New("connection refused"))
+
+	s := MustOpenService(t, remote)
+	defer s.Remove()
+
+	if err := s.WritePoints(MustExplode(bucketID, "cpu value=1 1")); err != nil {
+		t.Fatal(err)
+	}
+	remote.WaitAttempts(t, 1)
+
+	if err := s.RuleService().DeleteReplicationRule(context.Background(), s.rule.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// The queue of a deleted rule is removed.
+	fis, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fis) != 0 {
+		t.Fatalf("got %d queues, expected none", len(fis))
+	}
+}
+
+// Service is a test wrapper for replication.Service.
+type Service struct {
+	*replication.Service
+	dir    string
+	engine *mock.PointsWriter
+	rule   *platform.ReplicationRule
+}
+
+// MustOpenService returns an open service replicating the points of bucketID to remote.
+func MustOpenService(tb testing.TB, remote *Remote) *Service {
+	tb.Helper()
+
+	dir, err := ioutil.TempDir("", "replication")
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	rules := inmem.NewService()
+	rule := &platform.ReplicationRule{
+		OrganizationID: orgID,
+		BucketID:       bucketID,
+		Name:           "standby",
+		RemoteURL:      "http://standby:9999",
+		RemoteOrgID:    remoteOrgID,
+		RemoteBucketID: remoteBucketID,
+	}
+	if err := rules.CreateReplicationRule(context.Background(), rule); err != nil {
+		tb.Fatal(err)
+	}
+
+	engine := &mock.PointsWriter{}
+	s := replication.NewService(dir, engine, rules, func(r *platform.ReplicationRule) platform.WriteService {
+		return &mock.WriteService{
+			WriteF: func(ctx context.Context, org, bucket platform.ID, r io.Reader) error {
+				if org != remoteOrgID || bucket != remoteBucketID {
+					tb.Errorf("got write to %s/%s, expected %s/%s", org, bucket, remoteOrgID, remoteBucketID)
+				}
+				return remote.Write(r)
+			},
+		}
+	})
+	s.MinRetryBackoff = time.Millisecond
+	s.MaxRetryBackoff = 10 * time.Millisecond
+	if err := s.Open(); err != nil {
+		tb.Fatal(err)
+	}
+	return &Service{Service: s, dir: dir, engine: engine, rule: rule}
+}
+
+// Remove closes the service and removes its queues.
+func (s *Service) Remove() error {
+	defer os.RemoveAll(s.dir)
+	return s.Close()
+}
+
+// Remote records the writes replicated to a remote.
+type Remote struct {
+	mu       sync.Mutex
+	err      error
+	writes   []string
+	attempts int
+}
+
+func NewRemote() *Remote {
+	return &Remote{}
+}
+
+// Fail makes writes to the remote fail with err.
+func (r *Remote) Fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.err = err
+}
+
+func (r *Remote) Write(in io.Reader) error {
+	b, err := ioutil.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts++
+	if r.err != nil {
+		return r.err
+	}
+	r.writes = append(r.writes, string(b))
+	return nil
+}
+
+// Wait waits for n writes to the remote and returns them.
+func (r *Remote) Wait(tb testing.TB, n int) []string {
+	tb.Helper()
+	var writes []string
+	r.wait(tb, func() bool {
+		writes = append([]string(nil), r.writes...)
+		return len(writes) >= n
+	})
+	return writes
+}
+
+// WaitAttempts waits for n attempts to write to the remote.
+func (r *Remote) WaitAttempts(tb testing.TB, n int) {
+	tb.Helper()
+	r.wait(tb, func() bool { return r.attempts >= n })
+}
+
+func (r *Remote) wait(tb testing.TB, fn func() bool) {
+	tb.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		r.mu.Lock()
+		done := fn()
+		r.mu.Unlock()
+		if done {
+			return
+		} else if time.Now().After(deadline) {
+			tb.Fatal("timed out waiting for the remote")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// MustExplode parses lines of line protocol and explodes them into bucket.
+func MustExplode(bucket platform.ID, lines ...string) []models.Point {
+	var points []models.Point
+	for _, line := range lines {
+		pts, err := models.ParsePointsString(line)
+		if err != nil {
+			panic(err)
+		}
+		points = append(points, pts...)
+	}
+
+	exploded, err := tsdb.ExplodePoints(orgID, bucket, points)
+	if err != nil {
+		panic(err)
+	}
+	return exploded
+}
@@ -0,0 +1,205 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/tsdb"
+)
+
+// bucketSchemaTTL is how long the schema of a bucket is cached by the engine.
+// Schemas set through a BucketSchemaService wrapping the engine are enforced
+// immediately; other changes once the cached schema expires.
+const bucketSchemaTTL = 10 * time.Second
+
+// A BucketSchemaFinder is responsible for providing access to the schemas of
+// buckets.
+type BucketSchemaFinder interface {
+	FindBucketSchema(context.Context, platform.ID) (*platform.BucketSchema, error)
+}
+
+// bucketSchemas caches the schemas of buckets for checking writes.
+type bucketSchemas struct {
+	finder BucketSchemaFinder
+	now    func() time.Time
+
+	mu      sync.RWMutex
+	schemas map[platform.ID]cachedBucketSchema
+	gen     uint64 // Incremented by invalidate, so that lookups racing it don't cache stale schemas.
+}
+
+type cachedBucketSchema struct {
+	schema  *bucketSchema
+	expires time.Time
+}
+
+func newBucketSchemas(finder BucketSchemaFinder) *bucketSchemas {
+	return &bucketSchemas{
+		finder:  finder,
+		now:     time.Now,
+		schemas: make(map[platform.ID]cachedBucketSchema),
+	}
+}
+
+// lookup returns the schema of the bucket of name, or nil if the bucket has
+// no schema or name is not the name of a bucket.
+func (s *bucketSchemas) lookup(name []byte) (*bucketSchema, error) {
+	if len(name) != platform.IDLength {
+		return nil, nil
+	}
+	var n [16]byte
+	copy(n[:], name)
+	_, bucketID := tsdb.DecodeName(n)
+
+	now := s.now()
+	s.mu.RLock()
+	cached, ok := s.schemas[bucketID]
+	gen := s.gen
+	s.mu.RUnlock()
+	if ok && now.Before(cached.expires) {
+		return cached.schema, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), bucketAPITimeout)
+	defer cancel()
+	ps, err := s.finder.FindBucketSchema(ctx, bucketID)
+	if err != nil && platform.ErrorCode(err) != platform.ENotFound {
+		return nil, err
+	}
+
+	schema := newBucketSchema(bucketID, ps)
+	s.mu.Lock()
+	if s.gen == gen {
+		s.schemas[bucketID] = cachedBucketSchema{schema: schema, expires: now.Add(bucketSchemaTTL)}
+	}
+	s.mu.Unlock()
+	return schema, nil
+}
+
+// invalidate drops the cached schema of the bucket, so that the next write to
+// it looks up its current schema.
+func (s *bucketSchemas) invalidate(bucketID platform.ID) {
+	s.mu.Lock()
+	delete(s.schemas, bucketID)
+	s.gen++
+	s.mu.Unlock()
+}
+
+// BucketSchemaService wraps a platform.BucketSchemaService to make an engine
+// enforce the schemas set through it without waiting for its cache to expire.
+type BucketSchemaService struct {
+	platform.BucketSchemaService
+	engine *Engine
+}
+
+// NewBucketSchemaService returns a BucketSchemaService setting schemas with s
+// and enforcing them on e.
+func NewBucketSchemaService(s platform.BucketSchemaService, e *Engine) *BucketSchemaService {
+	return &BucketSchemaService{
+		BucketSchemaService: s,
+		engine:              e,
+	}
+}
+
+// SetBucketSchema sets the schema of a bucket and drops the engine's cached copy.
+func (s *BucketSchemaService) SetBucketSchema(ctx context.Context, id platform.ID, schema *platform.BucketSchema) error {
+	if err := s.BucketSchemaService.SetBucketSchema(ctx, id, schema); err != nil {
+		return err
+	}
+	s.engine.InvalidateBucketSchema(id)
+	return nil
+}
+
+// bucketSchema is the form of a platform.BucketSchema used to check writes.
+type bucketSchema struct {
+	bucketID     platform.ID
+	measurements map[string]*measurementSchema
+}
+
+type measurementSchema struct {
+	tags       map[string]struct{}
+	fields     map[string]platform.SchemaFieldType
+	allowExtra bool
+}
+
+// newBucketSchema returns the schema of ps, or nil if ps is nil.
+func newBucketSchema(bucketID platform.ID, ps *platform.BucketSchema) *bucketSchema {
+	if ps == nil {
+		return nil
+	}
+
+	s := &bucketSchema{
+		bucketID:     bucketID,
+		measurements: make(map[string]*measurementSchema, len(ps.Measurements)),
+	}
+	for _, pm := range ps.Measurements {
+		m := &measurementSchema{
+			tags:       make(map[string]struct{}, len(pm.Tags)),
+			fields:     make(map[string]platform.SchemaFieldType, len(pm.Fields)),
+			allowExtra: pm.AllowExtraColumns,
+		}
+		for _, tag := range pm.Tags {
+			m.tags[tag] = struct{}{}
+		}
+		for _, f := range pm.Fields {
+			m.fields[f.Name] = f.Type
+		}
+		s.measurements[pm.Name] = m
+	}
+	return s
+}
+
+// check returns the reason a series of the bucket with tags and values of type
+// typ does not conform to the schema, or an empty string if it does.
+func (s *bucketSchema) check(tags models.Tags, typ models.FieldType) string {
+	measurement := string(tags.Get(tsdb.MeasurementTagKeyBytes))
+	m, ok := s.measurements[measurement]
+	if !ok {
+		return fmt.Sprintf("schema violation: measurement %q is not in the schema of bucket %s", measurement, s.bucketID)
+	}
+
+	field := string(tags.Get(tsdb.FieldKeyTagKeyBytes))
+	if want, ok := m.fields[field]; ok {
+		if got := schemaFieldType(typ); got != want {
+			return fmt.Sprintf("schema violation: field %q on measurement %q is type %s, the schema requires %s", field, measurement, got, want)
+		}
+	} else if !m.allowExtra {
+		return fmt.Sprintf("schema violation: field %q is not in the schema of measurement %q", field, measurement)
+	}
+
+	if m.allowExtra {
+		return ""
+	}
+	for _, t := range tags {
+		key := string(t.Key)
+		if key == tsdb.MeasurementTagKey || key == tsdb.FieldKeyTagKey {
+			continue
+		}
+		if _, ok := m.tags[key]; !ok {
+			return fmt.Sprintf("schema violation: tag %q is not in the schema of measurement %q", key, measurement)
+		}
+	}
+	return ""
+}
+
+// schemaFieldType returns the schema field type of values of type typ.
+func schemaFieldType(typ models.FieldType) platform.SchemaFieldType {
+	switch typ {
+	case models.Float:
+		return platform.SchemaFieldFloat
+	case models.Integer:
+		return platform.SchemaFieldInteger
+	case models.Unsigned:
+		return platform.SchemaFieldUnsigned
+	case models.String:
+		return platform.SchemaFieldString
+	case models.Boolean:
+		return platform.SchemaFieldBoolean
+	default:
+		return platform.SchemaFieldType(typ.String())
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"time"
 
 	"github.com/influxdata/influxql"
+	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/logger"
 	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/pkg/bytesutil"
 	"github.com/influxdata/platform/tsdb"
 	"github.com/influxdata/platform/tsdb/tsi1"
 	"github.com/influxdata/platform/tsdb/tsm1"
@@ -21,6 +23,9 @@ import (
 // Static objects to prevent small allocs.
 var timeBytes = []byte("time")
 
+// reclaimBatchSize is the number of series looked up at once by ReclaimSeries.
+const reclaimBatchSize = 10000
+
 // ErrEngineClosed is returned when a caller attempts to use the engine while
 // it's closed.
 var ErrEngineClosed = errors.New("engine is closed")
@@ -37,6 +42,8 @@ type Engine struct {
 	sfile             *tsdb.SeriesFile
 	engine            *tsm1.Engine
 	wal               *tsm1.WAL
+	partitioner       *bucketPartitioner
+	schemas           *bucketSchemas
 	retentionEnforcer *retentionEnforcer
 
 	defaultMetricLabels prometheus.Labels
@@ -85,6 +92,14 @@ func WithRetentionEnforcer(finder BucketFinder) Option {
 	}
 }
 
+// WithBucketSchemas makes the engine drop the points written to buckets with a
+// schema that do not conform to it. The schemas are found with finder.
+func WithBucketSchemas(finder BucketSchemaFinder) Option {
+	return func(e *Engine) {
+		e.schemas = newBucketSchemas(finder)
+	}
+}
+
 // WithFileStoreObserver makes the engine have the provided file store observer.
 func WithFileStoreObserver(obs tsm1.FileStoreObserver) Option {
 	return func(e *Engine) {
@@ -107,6 +122,7 @@ func NewEngine(path string, c Config, options ...Option) *Engine {
 		path:                path,
 		defaultMetricLabels: prometheus.Labels{},
 		logger:              zap.NewNop(),
+		partitioner:         newBucketPartitioner(),
 	}
 
 	// Initialize series file.
@@ -128,7 +144,8 @@ func NewEngine(path string, c Config, options ...Option) *Engine {
 	// Initialise Engine
 	e.engine = tsm1.NewEngine(c.GetEnginePath(path), e.index, c.Engine,
 		tsm1.WithWAL(wal),
-		tsm1.WithTraceLogging(c.TraceLoggingEnabled))
+		tsm1.WithTraceLogging(c.TraceLoggingEnabled),
+		tsm1.WithPartitioner(e.partitioner))
 
 	// Apply options.
 	for _, option := range options {
@@ -298,10 +315,17 @@ func (e *Engine) CreateCursorIterator(ctx context.Context) (tsdb.CursorIterator,
 //
 // The Engine expects all points to have been correctly validated by the caller.
 // WritePoints will however determine if there are any field type conflicts, and
-// return an appropriate error in that case.
+// return an appropriate error in that case. Points that do not conform to the
+// schema of their bucket are dropped. When the cache of the engine stays full
+// for longer than its max write wait, an EUnavailable error is returned.
 func (e *Engine) WritePoints(points []models.Point) error {
 	collection := tsdb.NewSeriesCollection(points)
 
+	var (
+		schema     *bucketSchema
+		schemaName []byte // The name of the bucket of schema.
+	)
+
 	j := 0
 	for iter := collection.Iterator(); iter.Next(); {
 		tags := iter.Tags()
@@ -336,6 +360,28 @@ func (e *Engine) WritePoints(points []models.Point) error {
 			continue
 		}
 
+		// Drop any series that does not conform to the schema of its bucket.
+		if e.schemas != nil {
+			if schemaName == nil || !bytes.Equal(schemaName, iter.Name()) {
+				var err error
+				if schema, err = e.schemas.lookup(iter.Name()); err != nil {
+					return err
+				}
+				schemaName = iter.Name()
+			}
+
+			if schema != nil {
+				if reason := schema.check(tags, iter.Type()); reason != "" {
+					if collection.Reason == "" {
+						collection.Reason = reason
+					}
+					collection.Dropped++
+					collection.DroppedKeys = append(collection.DroppedKeys, iter.Key())
+					continue
+				}
+			}
+		}
+
 		collection.Copy(j, iter.Index())
 		j++
 	}
@@ -359,6 +405,13 @@ func (e *Engine) WritePoints(points []models.Point) error {
 
 	// Write the points to the cache and WAL.
 	if err := e.engine.WritePoints(collection.Points); err != nil {
+		if tsm1.IsCacheFull(err) {
+			return &platform.Error{
+				Code: platform.EUnavailable,
+				Msg:  "engine is overloaded, retry the write later",
+				Err:  err,
+			}
+		}
 		return err
 	}
 	return collection.PartialWriteError()
@@ -375,6 +428,113 @@ func (e *Engine) DeleteSeriesRangeWithPredicate(itr tsdb.SeriesIterator, fn func
 	return e.engine.DeleteSeriesRangeWithPredicate(itr, fn)
 }
 
+// WriteBackpressure returns the reason writes to the engine are being held
+// back, or an empty string if they are admitted freely.
+func (e *Engine) WriteBackpressure() string {
+	if e.engine.Cache.Full() {
+		return "storage engine cache is full, writes are waiting for snapshots"
+	}
+	return ""
+}
+
+// InvalidateBucketSchema drops the cached schema of a bucket, so that writes to
+// it are checked against its current schema.
+func (e *Engine) InvalidateBucketSchema(bucketID platform.ID) {
+	if e.schemas != nil {
+		e.schemas.invalidate(bucketID)
+	}
+}
+
+// SetShardGroupDurations sets the shard group durations of buckets. The TSM
+// files of the engine hold the data of a single shard group of a bucket.
+func (e *Engine) SetShardGroupDurations(durations map[platform.ID]time.Duration) {
+	e.partitioner.setShardGroupDurations(durations)
+}
+
+// DropFiles removes the TSM files for which fn returns true, without writing
+// tombstones or updating the index.
+func (e *Engine) DropFiles(fn func(tsm1.FileStat) bool) ([]string, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return nil, ErrEngineClosed
+	}
+	return e.engine.DropFiles(fn)
+}
+
+// ReclaimSeries removes the series without values in the cache or TSM files,
+// such as the series of expired data, from the index and the series file, and
+// then compacts the series file. It returns the number of series removed.
+//
+// Writes are held back while the series are removed, so that a series written
+// again is indexed anew.
+func (e *Engine) ReclaimSeries() (int, error) {
+	e.mu.RLock()
+	if e.closing == nil {
+		e.mu.RUnlock()
+		return 0, ErrEngineClosed
+	}
+	ids := e.index.SeriesIDSet().Slice()
+	e.mu.RUnlock()
+
+	var n int
+	batch := make([][]byte, 0, reclaimBatchSize)
+	for i, id := range ids {
+		key := e.sfile.SeriesKey(tsdb.NewSeriesID(id))
+		if key != nil {
+			name, tags := tsdb.ParseSeriesKey(key)
+			batch = append(batch, models.MakeKey(name, tags))
+		}
+
+		if len(batch) < reclaimBatchSize && i < len(ids)-1 {
+			continue
+		}
+
+		m, err := e.reclaimSeries(batch)
+		n += m
+		if err != nil {
+			return n, err
+		}
+		batch = batch[:0]
+	}
+
+	if n == 0 {
+		return 0, nil
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return n, ErrEngineClosed
+	}
+	return n, e.sfile.Compact()
+}
+
+// reclaimSeries removes the series of seriesKeys without values. The series
+// are first looked up without holding back writes, and only those found
+// without values are checked again while writes are held back.
+func (e *Engine) reclaimSeries(seriesKeys [][]byte) (int, error) {
+	bytesutil.Sort(seriesKeys)
+
+	e.mu.RLock()
+	if e.closing == nil {
+		e.mu.RUnlock()
+		return 0, ErrEngineClosed
+	}
+	candidates, err := e.engine.SeriesWithoutValues(seriesKeys)
+	e.mu.RUnlock()
+	if err != nil || len(candidates) == 0 {
+		return 0, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closing == nil {
+		return 0, ErrEngineClosed
+	}
+	return e.engine.ReclaimSeries(candidates)
+}
+
 // SeriesCardinality returns the number of series in the engine.
 func (e *Engine) SeriesCardinality() int64 {
 	e.mu.RLock()
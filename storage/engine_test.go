@@ -1,12 +1,14 @@
 package storage_test
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
 	"github.com/influxdata/platform/models"
 	"github.com/influxdata/platform/storage"
 	"github.com/influxdata/platform/tsdb"
@@ -149,6 +151,130 @@ func TestEngine_WriteAddNewField(t *testing.T) {
 	}
 }
 
+func TestEngine_WriteBucketSchema(t *testing.T) {
+	finder := bucketSchemaFinder(func(ctx context.Context, id platform.ID) (*platform.BucketSchema, error) {
+		return &platform.BucketSchema{
+			Measurements: []platform.MeasurementSchema{
+				{
+					Name:   "cpu",
+					Tags:   []string{"host"},
+					Fields: []platform.FieldSchema{{Name: "value", Type: platform.SchemaFieldFloat}},
+				},
+				{
+					Name:              "mem",
+					Fields:            []platform.FieldSchema{{Name: "used", Type: platform.SchemaFieldInteger}},
+					AllowExtraColumns: true,
+				},
+			},
+		}, nil
+	})
+
+	engine := NewEngine(storage.NewConfig(), storage.WithBucketSchemas(finder))
+	defer engine.Close()
+	engine.MustOpen()
+
+	tests := []struct {
+		name   string
+		point  models.Point
+		reason string
+	}{
+		{
+			name:  "conforming",
+			point: models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "a"}), map[string]interface{}{"value": 1.0}, time.Unix(1, 0)),
+		},
+		{
+			name:   "unknown measurement",
+			point:  models.MustNewPoint("disk", models.NewTags(map[string]string{"host": "a"}), map[string]interface{}{"value": 1.0}, time.Unix(1, 0)),
+			reason: `schema violation: measurement "disk" is not in the schema of bucket 3232323232323232`,
+		},
+		{
+			name:   "unknown tag",
+			point:  models.MustNewPoint("cpu", models.NewTags(map[string]string{"region": "west"}), map[string]interface{}{"value": 1.0}, time.Unix(1, 0)),
+			reason: `schema violation: tag "region" is not in the schema of measurement "cpu"`,
+		},
+		{
+			name:   "unknown field",
+			point:  models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "a"}), map[string]interface{}{"idle": 1.0}, time.Unix(1, 0)),
+			reason: `schema violation: field "idle" is not in the schema of measurement "cpu"`,
+		},
+		{
+			name:   "wrong field type",
+			point:  models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "a"}), map[string]interface{}{"value": int64(1)}, time.Unix(1, 0)),
+			reason: `schema violation: field "value" on measurement "cpu" is type integer, the schema requires float`,
+		},
+		{
+			name:  "extra columns",
+			point: models.MustNewPoint("mem", models.NewTags(map[string]string{"host": "a"}), map[string]interface{}{"used": int64(1), "free": 1.0}, time.Unix(1, 0)),
+		},
+		{
+			name:   "wrong field type with extra columns",
+			point:  models.MustNewPoint("mem", models.NewTags(map[string]string{"host": "a"}), map[string]interface{}{"used": 1.0}, time.Unix(1, 0)),
+			reason: `schema violation: field "used" on measurement "mem" is type float, the schema requires integer`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := engine.Write1xPoints([]models.Point{tt.point})
+			if tt.reason == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			pwe, ok := err.(tsdb.PartialWriteError)
+			if !ok {
+				t.Fatalf("got error %v, expected a partial write error", err)
+			}
+			if pwe.Reason != tt.reason {
+				t.Fatalf("got reason %q, expected %q", pwe.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestEngine_SetBucketSchema(t *testing.T) {
+	var schema *platform.BucketSchema
+	svc := mock.NewBucketSchemaService()
+	svc.FindBucketSchemaFn = func(context.Context, platform.ID) (*platform.BucketSchema, error) {
+		return schema, nil
+	}
+	svc.SetBucketSchemaFn = func(_ context.Context, _ platform.ID, s *platform.BucketSchema) error {
+		schema = s
+		return nil
+	}
+
+	engine := NewEngine(storage.NewConfig(), storage.WithBucketSchemas(svc))
+	defer engine.Close()
+	engine.MustOpen()
+
+	pt := models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "a"}), map[string]interface{}{"value": 1.0}, time.Unix(1, 0))
+	if err := engine.Write1xPoints([]models.Point{pt}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The schema set through the wrapping service applies to the next write,
+	// although the engine has cached that the bucket has no schema.
+	schemaSvc := storage.NewBucketSchemaService(svc, engine.Engine)
+	if err := schemaSvc.SetBucketSchema(context.Background(), platform.ID(0x3232323232323232), &platform.BucketSchema{
+		Measurements: []platform.MeasurementSchema{{Name: "mem"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := engine.Write1xPoints([]models.Point{pt})
+	if _, ok := err.(tsdb.PartialWriteError); !ok {
+		t.Fatalf("got error %v, expected a partial write error", err)
+	}
+}
+
+type bucketSchemaFinder func(context.Context, platform.ID) (*platform.BucketSchema, error)
+
+func (f bucketSchemaFinder) FindBucketSchema(ctx context.Context, id platform.ID) (*platform.BucketSchema, error) {
+	return f(ctx, id)
+}
+
 // Ensures that when a shard is closed, it removes any series meta-data
 // from the index.
 func TestEngineClose_RemoveIndex(t *testing.T) {
@@ -207,10 +333,10 @@ type Engine struct {
 }
 
 // NewEngine create a new wrapper around a storage engine.
-func NewEngine(c storage.Config) *Engine {
+func NewEngine(c storage.Config, options ...storage.Option) *Engine {
 	path, _ := ioutil.TempDir("", "storage_engine_test")
 
-	engine := storage.NewEngine(path, c)
+	engine := storage.NewEngine(path, c, options...)
 	return &Engine{
 		path:   path,
 		Engine: engine,
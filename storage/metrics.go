@@ -18,6 +18,9 @@ type retentionMetrics struct {
 	CheckDuration *prometheus.HistogramVec
 	Unprocessable *prometheus.CounterVec
 	Series        *prometheus.CounterVec
+	Files         *prometheus.CounterVec
+
+	ReclaimedSeries *prometheus.CounterVec
 }
 
 func newRetentionMetrics(labels prometheus.Labels) *retentionMetrics {
@@ -59,6 +62,20 @@ func newRetentionMetrics(labels prometheus.Labels) *retentionMetrics {
 			Name:      "series_total",
 			Help:      "Number of series that a delete was applied to.",
 		}, names),
+
+		Files: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: retentionSubsystem,
+			Name:      "files_total",
+			Help:      "Number of expired TSM files that were removed whole.",
+		}, names),
+
+		ReclaimedSeries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: retentionSubsystem,
+			Name:      "reclaimed_series_total",
+			Help:      "Number of series without data that were removed from the index and series file.",
+		}, names),
 	}
 }
 
@@ -78,5 +95,7 @@ func (rm *retentionMetrics) PrometheusCollectors() []prometheus.Collector {
 		rm.CheckDuration,
 		rm.Unprocessable,
 		rm.Series,
+		rm.Files,
+		rm.ReclaimedSeries,
 	}
 }
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/tsdb"
+	"github.com/influxdata/platform/tsdb/tsm1"
+)
+
+// bucketPartitioner partitions the TSM files of the engine by bucket and by
+// the shard group duration of each bucket, so that the files of expired shard
+// groups can be removed whole.
+type bucketPartitioner struct {
+	mu        sync.RWMutex
+	durations map[platform.ID]time.Duration
+}
+
+func newBucketPartitioner() *bucketPartitioner {
+	return &bucketPartitioner{durations: make(map[platform.ID]time.Duration)}
+}
+
+// setShardGroupDurations replaces the shard group durations of the buckets.
+// Buckets without a duration use the default of an infinite retention period.
+func (p *bucketPartitioner) setShardGroupDurations(durations map[platform.ID]time.Duration) {
+	p.mu.Lock()
+	p.durations = durations
+	p.mu.Unlock()
+}
+
+// Partition returns the shard group of the bucket of key containing t.
+func (p *bucketPartitioner) Partition(key []byte, t int64) tsm1.Partition {
+	name := models.ParseName(key)
+	d := int64(p.shardGroupDuration(name))
+
+	min := t - t%d
+	if min > t {
+		if min < math.MinInt64+d {
+			min = math.MinInt64
+		} else {
+			min -= d
+		}
+	}
+	max := min + d - 1
+	if max < min {
+		max = math.MaxInt64
+	}
+	return tsm1.Partition{Group: string(name), Min: min, Max: max}
+}
+
+func (p *bucketPartitioner) shardGroupDuration(name []byte) time.Duration {
+	if len(name) == platform.IDLength {
+		var n [16]byte
+		copy(n[:], name)
+		_, bucketID := tsdb.DecodeName(n)
+
+		p.mu.RLock()
+		d, ok := p.durations[bucketID]
+		p.mu.RUnlock()
+		if ok && d > 0 {
+			return d
+		}
+	}
+	return platform.DefaultShardGroupDuration(platform.InfiniteRetention)
+}
+
+// fileBucket returns the bucket of the TSM file of stat, and false if the file
+// holds data of more than one bucket.
+func fileBucket(stat tsm1.FileStat) (platform.ID, bool) {
+	name := models.ParseName(stat.MinKey)
+	if len(name) != platform.IDLength || string(name) != string(models.ParseName(stat.MaxKey)) {
+		return 0, false
+	}
+
+	var n [16]byte
+	copy(n[:], name)
+	_, bucketID := tsdb.DecodeName(n)
+	return bucketID, true
+}
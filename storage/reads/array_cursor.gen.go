@@ -9,6 +9,7 @@ package reads
 import (
 	"errors"
 
+	"github.com/influxdata/platform/storage/reads/datatypes"
 	"github.com/influxdata/platform/tsdb/cursors"
 )
 
@@ -169,6 +170,28 @@ func (c *floatMultiShardArrayCursor) nextArrayCursor() bool {
 	return ok
 }
 
+// PeekBlockStats returns the statistics of the next block of the current shard, when its
+// points are not filtered and all of them are within the limit of the cursor.
+func (c *floatMultiShardArrayCursor) PeekBlockStats() (cursors.FloatBlockStats, bool) {
+	cur, ok := c.FloatArrayCursor.(cursors.FloatBlockStatsCursor)
+	if !ok {
+		return cursors.FloatBlockStats{}, false
+	}
+	s, ok := cur.PeekBlockStats()
+	if !ok || c.count+s.Count > c.limit {
+		return cursors.FloatBlockStats{}, false
+	}
+	return s, true
+}
+
+// SkipBlock moves past the block returned by PeekBlockStats.
+func (c *floatMultiShardArrayCursor) SkipBlock() {
+	cur := c.FloatArrayCursor.(cursors.FloatBlockStatsCursor)
+	s, _ := cur.PeekBlockStats()
+	c.count += s.Count
+	cur.SkipBlock()
+}
+
 type floatArraySumCursor struct {
 	cursors.FloatArrayCursor
 	ts  [1]int64
@@ -186,26 +209,45 @@ func newFloatArraySumCursor(cur cursors.FloatArrayCursor) *floatArraySumCursor {
 func (c floatArraySumCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
 
 func (c floatArraySumCursor) Next() *cursors.FloatArray {
-	a := c.FloatArrayCursor.Next()
-	if len(a.Timestamps) == 0 {
-		return a
-	}
+	stats, _ := c.FloatArrayCursor.(cursors.FloatBlockStatsCursor)
 
-	ts := a.Timestamps[0]
+	var ts int64
 	var acc float64
+	var n int64
 
 	for {
-		for _, v := range a.Values {
-			acc += v
+		// sum whole blocks from their statistics when possible
+		if stats != nil {
+			if s, ok := stats.PeekBlockStats(); ok {
+				stats.SkipBlock()
+				if n == 0 {
+					ts = s.FirstTime
+				}
+				acc += s.Sum
+				n += s.Count
+				continue
+			}
 		}
-		a = c.FloatArrayCursor.Next()
+
+		a := c.FloatArrayCursor.Next()
 		if len(a.Timestamps) == 0 {
+			if n == 0 {
+				return a
+			}
 			c.ts[0] = ts
 			c.vs[0] = acc
 			c.res.Timestamps = c.ts[:]
 			c.res.Values = c.vs[:]
 			return c.res
 		}
+
+		if n == 0 {
+			ts = a.Timestamps[0]
+		}
+		for _, v := range a.Values {
+			acc += v
+		}
+		n += int64(a.Len())
 	}
 }
 
@@ -218,23 +260,373 @@ func (c *integerFloatCountArrayCursor) Stats() cursors.CursorStats {
 }
 
 func (c *integerFloatCountArrayCursor) Next() *cursors.IntegerArray {
-	a := c.FloatArrayCursor.Next()
-	if len(a.Timestamps) == 0 {
-		return &cursors.IntegerArray{}
-	}
+	stats, _ := c.FloatArrayCursor.(cursors.FloatBlockStatsCursor)
 
-	ts := a.Timestamps[0]
+	var ts int64
 	var acc int64
 	for {
-		acc += int64(len(a.Timestamps))
-		a = c.FloatArrayCursor.Next()
+		// count whole blocks from their statistics when possible
+		if stats != nil {
+			if s, ok := stats.PeekBlockStats(); ok {
+				stats.SkipBlock()
+				if acc == 0 {
+					ts = s.FirstTime
+				}
+				acc += s.Count
+				continue
+			}
+		}
+
+		a := c.FloatArrayCursor.Next()
 		if len(a.Timestamps) == 0 {
+			if acc == 0 {
+				return &cursors.IntegerArray{}
+			}
 			res := cursors.NewIntegerArrayLen(1)
 			res.Timestamps[0] = ts
 			res.Values[0] = acc
 			return res
 		}
+
+		if acc == 0 {
+			ts = a.Timestamps[0]
+		}
+		acc += int64(len(a.Timestamps))
+	}
+}
+
+// floatWindowAggregateArrayCursor selects or sums the values of each window of the cursor.
+type floatWindowAggregateArrayCursor struct {
+	cursors.FloatArrayCursor
+	agg    datatypes.Aggregate_AggregateType
+	window aggregateWindow
+	a      *cursors.FloatArray
+	i      int
+	res    *cursors.FloatArray
+
+	// stats is set when the sum, min or max of whole blocks may be read from their statistics.
+	stats cursors.FloatBlockStatsCursor
+}
+
+func newFloatWindowAggregateArrayCursor(cur cursors.FloatArrayCursor, agg datatypes.Aggregate_AggregateType, window aggregateWindow) *floatWindowAggregateArrayCursor {
+	c := &floatWindowAggregateArrayCursor{
+		FloatArrayCursor: cur,
+		agg:              agg,
+		window:           window,
+		a:                &cursors.FloatArray{},
+		res:              cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+	switch agg {
+	case datatypes.AggregateTypeSum, datatypes.AggregateTypeMin, datatypes.AggregateTypeMax:
+		c.stats, _ = cur.(cursors.FloatBlockStatsCursor)
+	}
+	return c
+}
+
+func (c *floatWindowAggregateArrayCursor) Stats() cursors.CursorStats {
+	return c.FloatArrayCursor.Stats()
+}
+
+func (c *floatWindowAggregateArrayCursor) Next() *cursors.FloatArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for len(c.res.Timestamps) < MaxPointsPerBlock {
+		var start, stop, ts int64
+		var v float64
+		if s, ok := c.peekBlockStats(); ok {
+			c.stats.SkipBlock()
+			start, stop = c.window.bounds(s.FirstTime)
+			ts, v = c.blockAggregate(s)
+		} else {
+			if c.i >= c.a.Len() {
+				c.a = c.FloatArrayCursor.Next()
+				c.i = 0
+				if c.a.Len() == 0 {
+					break
+				}
+			}
+
+			start, stop = c.window.bounds(c.a.Timestamps[c.i])
+			ts, v = c.a.Timestamps[c.i], c.a.Values[c.i]
+			c.i++
+		}
+	WINDOW:
+		for {
+			for ; c.i < c.a.Len(); c.i++ {
+				t := c.a.Timestamps[c.i]
+				if t < start || t >= stop {
+					break WINDOW
+				}
+				switch c.agg {
+				case datatypes.AggregateTypeSum:
+					v += c.a.Values[c.i]
+				case datatypes.AggregateTypeMin:
+					if c.a.Values[c.i] < v {
+						ts, v = t, c.a.Values[c.i]
+					}
+				case datatypes.AggregateTypeMax:
+					if c.a.Values[c.i] > v {
+						ts, v = t, c.a.Values[c.i]
+					}
+				case datatypes.AggregateTypeLast:
+					ts, v = t, c.a.Values[c.i]
+				}
+			}
+			if s, ok := c.peekBlockStats(); ok {
+				if s.FirstTime >= stop {
+					// the block starts the next window
+					break WINDOW
+				}
+				c.stats.SkipBlock()
+				bts, bv := c.blockAggregate(s)
+				switch c.agg {
+				case datatypes.AggregateTypeSum:
+					v += bv
+				case datatypes.AggregateTypeMin:
+					if bv < v {
+						ts, v = bts, bv
+					}
+				case datatypes.AggregateTypeMax:
+					if bv > v {
+						ts, v = bts, bv
+					}
+				}
+				continue
+			}
+			c.a = c.FloatArrayCursor.Next()
+			c.i = 0
+			if c.a.Len() == 0 {
+				break
+			}
+		}
+
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, v)
+	}
+
+	return c.res
+}
+
+// peekBlockStats returns the statistics of the next block of the cursor, when no points
+// are left to aggregate before the block and it lies within a single window.
+func (c *floatWindowAggregateArrayCursor) peekBlockStats() (cursors.FloatBlockStats, bool) {
+	if c.stats == nil || c.i < c.a.Len() {
+		return cursors.FloatBlockStats{}, false
+	}
+	s, ok := c.stats.PeekBlockStats()
+	if !ok {
+		return s, false
+	}
+	_, stop := c.window.bounds(s.FirstTime)
+	return s, s.LastTime < stop
+}
+
+// blockAggregate returns the time and value of the aggregate of a block.
+func (c *floatWindowAggregateArrayCursor) blockAggregate(s cursors.FloatBlockStats) (int64, float64) {
+	switch c.agg {
+	case datatypes.AggregateTypeMin:
+		return s.MinTime, s.Min
+	case datatypes.AggregateTypeMax:
+		return s.MaxTime, s.Max
+	default:
+		return s.FirstTime, s.Sum
+	}
+}
+
+// floatFloatWindowMeanArrayCursor averages the values of each window of the cursor.
+type floatFloatWindowMeanArrayCursor struct {
+	cursors.FloatArrayCursor
+	window aggregateWindow
+	a      *cursors.FloatArray
+	i      int
+	res    *cursors.FloatArray
+
+	// stats is set when the sum and count of whole blocks may be read from their statistics.
+	stats cursors.FloatBlockStatsCursor
+}
+
+func newFloatFloatWindowMeanArrayCursor(cur cursors.FloatArrayCursor, window aggregateWindow) *floatFloatWindowMeanArrayCursor {
+	c := &floatFloatWindowMeanArrayCursor{
+		FloatArrayCursor: cur,
+		window:           window,
+		a:                &cursors.FloatArray{},
+		res:              cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+	c.stats, _ = cur.(cursors.FloatBlockStatsCursor)
+	return c
+}
+
+func (c *floatFloatWindowMeanArrayCursor) Stats() cursors.CursorStats {
+	return c.FloatArrayCursor.Stats()
+}
+
+func (c *floatFloatWindowMeanArrayCursor) Next() *cursors.FloatArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for len(c.res.Timestamps) < MaxPointsPerBlock {
+		var start, stop, ts int64
+		var sum float64
+		var n int64
+		if s, ok := c.peekBlockStats(); ok {
+			c.stats.SkipBlock()
+			start, stop = c.window.bounds(s.FirstTime)
+			ts, sum, n = s.FirstTime, float64(s.Sum), s.Count
+		} else {
+			if c.i >= c.a.Len() {
+				c.a = c.FloatArrayCursor.Next()
+				c.i = 0
+				if c.a.Len() == 0 {
+					break
+				}
+			}
+
+			start, stop = c.window.bounds(c.a.Timestamps[c.i])
+			ts = c.a.Timestamps[c.i]
+		}
+	WINDOW:
+		for {
+			for ; c.i < c.a.Len(); c.i++ {
+				t := c.a.Timestamps[c.i]
+				if t < start || t >= stop {
+					break WINDOW
+				}
+				sum += float64(c.a.Values[c.i])
+				n++
+			}
+			if s, ok := c.peekBlockStats(); ok {
+				if s.FirstTime >= stop {
+					// the block starts the next window
+					break WINDOW
+				}
+				c.stats.SkipBlock()
+				sum += float64(s.Sum)
+				n += s.Count
+				continue
+			}
+			c.a = c.FloatArrayCursor.Next()
+			c.i = 0
+			if c.a.Len() == 0 {
+				break
+			}
+		}
+
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, sum/float64(n))
+	}
+
+	return c.res
+}
+
+// peekBlockStats returns the statistics of the next block of the cursor, when no points
+// are left to average before the block and it lies within a single window.
+func (c *floatFloatWindowMeanArrayCursor) peekBlockStats() (cursors.FloatBlockStats, bool) {
+	if c.stats == nil || c.i < c.a.Len() {
+		return cursors.FloatBlockStats{}, false
+	}
+	s, ok := c.stats.PeekBlockStats()
+	if !ok {
+		return s, false
 	}
+	_, stop := c.window.bounds(s.FirstTime)
+	return s, s.LastTime < stop
+}
+
+// integerFloatWindowCountArrayCursor counts the values of each window of the cursor.
+type integerFloatWindowCountArrayCursor struct {
+	cursors.FloatArrayCursor
+	window aggregateWindow
+	a      *cursors.FloatArray
+	i      int
+	res    *cursors.IntegerArray
+
+	// stats is set when the count of whole blocks may be read from their statistics.
+	stats cursors.FloatBlockStatsCursor
+}
+
+func newIntegerFloatWindowCountArrayCursor(cur cursors.FloatArrayCursor, window aggregateWindow) *integerFloatWindowCountArrayCursor {
+	c := &integerFloatWindowCountArrayCursor{
+		FloatArrayCursor: cur,
+		window:           window,
+		a:                &cursors.FloatArray{},
+		res:              cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+	c.stats, _ = cur.(cursors.FloatBlockStatsCursor)
+	return c
+}
+
+func (c *integerFloatWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.FloatArrayCursor.Stats()
+}
+
+func (c *integerFloatWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for len(c.res.Timestamps) < MaxPointsPerBlock {
+		var start, stop, ts int64
+		var n int64
+		if s, ok := c.peekBlockStats(); ok {
+			c.stats.SkipBlock()
+			start, stop = c.window.bounds(s.FirstTime)
+			ts, n = s.FirstTime, s.Count
+		} else {
+			if c.i >= c.a.Len() {
+				c.a = c.FloatArrayCursor.Next()
+				c.i = 0
+				if c.a.Len() == 0 {
+					break
+				}
+			}
+
+			start, stop = c.window.bounds(c.a.Timestamps[c.i])
+			ts = c.a.Timestamps[c.i]
+		}
+	WINDOW:
+		for {
+			for ; c.i < c.a.Len(); c.i++ {
+				t := c.a.Timestamps[c.i]
+				if t < start || t >= stop {
+					break WINDOW
+				}
+				n++
+			}
+			if s, ok := c.peekBlockStats(); ok {
+				if s.FirstTime >= stop {
+					// the block starts the next window
+					break WINDOW
+				}
+				c.stats.SkipBlock()
+				n += s.Count
+				continue
+			}
+			c.a = c.FloatArrayCursor.Next()
+			c.i = 0
+			if c.a.Len() == 0 {
+				break
+			}
+		}
+
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, n)
+	}
+
+	return c.res
+}
+
+// peekBlockStats returns the statistics of the next block of the cursor, when no points
+// are left to count before the block and it lies within a single window.
+func (c *integerFloatWindowCountArrayCursor) peekBlockStats() (cursors.FloatBlockStats, bool) {
+	if c.stats == nil || c.i < c.a.Len() {
+		return cursors.FloatBlockStats{}, false
+	}
+	s, ok := c.stats.PeekBlockStats()
+	if !ok {
+		return s, false
+	}
+	_, stop := c.window.bounds(s.FirstTime)
+	return s, s.LastTime < stop
 }
 
 type floatEmptyArrayCursor struct {
@@ -398,6 +790,28 @@ func (c *integerMultiShardArrayCursor) nextArrayCursor() bool {
 	return ok
 }
 
+// PeekBlockStats returns the statistics of the next block of the current shard, when its
+// points are not filtered and all of them are within the limit of the cursor.
+func (c *integerMultiShardArrayCursor) PeekBlockStats() (cursors.IntegerBlockStats, bool) {
+	cur, ok := c.IntegerArrayCursor.(cursors.IntegerBlockStatsCursor)
+	if !ok {
+		return cursors.IntegerBlockStats{}, false
+	}
+	s, ok := cur.PeekBlockStats()
+	if !ok || c.count+s.Count > c.limit {
+		return cursors.IntegerBlockStats{}, false
+	}
+	return s, true
+}
+
+// SkipBlock moves past the block returned by PeekBlockStats.
+func (c *integerMultiShardArrayCursor) SkipBlock() {
+	cur := c.IntegerArrayCursor.(cursors.IntegerBlockStatsCursor)
+	s, _ := cur.PeekBlockStats()
+	c.count += s.Count
+	cur.SkipBlock()
+}
+
 type integerArraySumCursor struct {
 	cursors.IntegerArrayCursor
 	ts  [1]int64
@@ -415,26 +829,45 @@ func newIntegerArraySumCursor(cur cursors.IntegerArrayCursor) *integerArraySumCu
 func (c integerArraySumCursor) Stats() cursors.CursorStats { return c.IntegerArrayCursor.Stats() }
 
 func (c integerArraySumCursor) Next() *cursors.IntegerArray {
-	a := c.IntegerArrayCursor.Next()
-	if len(a.Timestamps) == 0 {
-		return a
-	}
+	stats, _ := c.IntegerArrayCursor.(cursors.IntegerBlockStatsCursor)
 
-	ts := a.Timestamps[0]
+	var ts int64
 	var acc int64
+	var n int64
 
 	for {
-		for _, v := range a.Values {
-			acc += v
+		// sum whole blocks from their statistics when possible
+		if stats != nil {
+			if s, ok := stats.PeekBlockStats(); ok {
+				stats.SkipBlock()
+				if n == 0 {
+					ts = s.FirstTime
+				}
+				acc += s.Sum
+				n += s.Count
+				continue
+			}
 		}
-		a = c.IntegerArrayCursor.Next()
+
+		a := c.IntegerArrayCursor.Next()
 		if len(a.Timestamps) == 0 {
+			if n == 0 {
+				return a
+			}
 			c.ts[0] = ts
 			c.vs[0] = acc
 			c.res.Timestamps = c.ts[:]
 			c.res.Values = c.vs[:]
 			return c.res
 		}
+
+		if n == 0 {
+			ts = a.Timestamps[0]
+		}
+		for _, v := range a.Values {
+			acc += v
+		}
+		n += int64(a.Len())
 	}
 }
 
@@ -447,23 +880,373 @@ func (c *integerIntegerCountArrayCursor) Stats() cursors.CursorStats {
 }
 
 func (c *integerIntegerCountArrayCursor) Next() *cursors.IntegerArray {
-	a := c.IntegerArrayCursor.Next()
-	if len(a.Timestamps) == 0 {
-		return &cursors.IntegerArray{}
-	}
+	stats, _ := c.IntegerArrayCursor.(cursors.IntegerBlockStatsCursor)
 
-	ts := a.Timestamps[0]
+	var ts int64
 	var acc int64
 	for {
-		acc += int64(len(a.Timestamps))
-		a = c.IntegerArrayCursor.Next()
+		// count whole blocks from their statistics when possible
+		if stats != nil {
+			if s, ok := stats.PeekBlockStats(); ok {
+				stats.SkipBlock()
+				if acc == 0 {
+					ts = s.FirstTime
+				}
+				acc += s.Count
+				continue
+			}
+		}
+
+		a := c.IntegerArrayCursor.Next()
 		if len(a.Timestamps) == 0 {
+			if acc == 0 {
+				return &cursors.IntegerArray{}
+			}
 			res := cursors.NewIntegerArrayLen(1)
 			res.Timestamps[0] = ts
 			res.Values[0] = acc
 			return res
 		}
+
+		if acc == 0 {
+			ts = a.Timestamps[0]
+		}
+		acc += int64(len(a.Timestamps))
+	}
+}
+
+// integerWindowAggregateArrayCursor selects or sums the values of each window of the cursor.
+type integerWindowAggregateArrayCursor struct {
+	cursors.IntegerArrayCursor
+	agg    datatypes.Aggregate_AggregateType
+	window aggregateWindow
+	a      *cursors.IntegerArray
+	i      int
+	res    *cursors.IntegerArray
+
+	// stats is set when the sum, min or max of whole blocks may be read from their statistics.
+	stats cursors.IntegerBlockStatsCursor
+}
+
+func newIntegerWindowAggregateArrayCursor(cur cursors.IntegerArrayCursor, agg datatypes.Aggregate_AggregateType, window aggregateWindow) *integerWindowAggregateArrayCursor {
+	c := &integerWindowAggregateArrayCursor{
+		IntegerArrayCursor: cur,
+		agg:                agg,
+		window:             window,
+		a:                  &cursors.IntegerArray{},
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+	switch agg {
+	case datatypes.AggregateTypeSum, datatypes.AggregateTypeMin, datatypes.AggregateTypeMax:
+		c.stats, _ = cur.(cursors.IntegerBlockStatsCursor)
+	}
+	return c
+}
+
+func (c *integerWindowAggregateArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowAggregateArrayCursor) Next() *cursors.IntegerArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for len(c.res.Timestamps) < MaxPointsPerBlock {
+		var start, stop, ts int64
+		var v int64
+		if s, ok := c.peekBlockStats(); ok {
+			c.stats.SkipBlock()
+			start, stop = c.window.bounds(s.FirstTime)
+			ts, v = c.blockAggregate(s)
+		} else {
+			if c.i >= c.a.Len() {
+				c.a = c.IntegerArrayCursor.Next()
+				c.i = 0
+				if c.a.Len() == 0 {
+					break
+				}
+			}
+
+			start, stop = c.window.bounds(c.a.Timestamps[c.i])
+			ts, v = c.a.Timestamps[c.i], c.a.Values[c.i]
+			c.i++
+		}
+	WINDOW:
+		for {
+			for ; c.i < c.a.Len(); c.i++ {
+				t := c.a.Timestamps[c.i]
+				if t < start || t >= stop {
+					break WINDOW
+				}
+				switch c.agg {
+				case datatypes.AggregateTypeSum:
+					v += c.a.Values[c.i]
+				case datatypes.AggregateTypeMin:
+					if c.a.Values[c.i] < v {
+						ts, v = t, c.a.Values[c.i]
+					}
+				case datatypes.AggregateTypeMax:
+					if c.a.Values[c.i] > v {
+						ts, v = t, c.a.Values[c.i]
+					}
+				case datatypes.AggregateTypeLast:
+					ts, v = t, c.a.Values[c.i]
+				}
+			}
+			if s, ok := c.peekBlockStats(); ok {
+				if s.FirstTime >= stop {
+					// the block starts the next window
+					break WINDOW
+				}
+				c.stats.SkipBlock()
+				bts, bv := c.blockAggregate(s)
+				switch c.agg {
+				case datatypes.AggregateTypeSum:
+					v += bv
+				case datatypes.AggregateTypeMin:
+					if bv < v {
+						ts, v = bts, bv
+					}
+				case datatypes.AggregateTypeMax:
+					if bv > v {
+						ts, v = bts, bv
+					}
+				}
+				continue
+			}
+			c.a = c.IntegerArrayCursor.Next()
+			c.i = 0
+			if c.a.Len() == 0 {
+				break
+			}
+		}
+
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, v)
+	}
+
+	return c.res
+}
+
+// peekBlockStats returns the statistics of the next block of the cursor, when no points
+// are left to aggregate before the block and it lies within a single window.
+func (c *integerWindowAggregateArrayCursor) peekBlockStats() (cursors.IntegerBlockStats, bool) {
+	if c.stats == nil || c.i < c.a.Len() {
+		return cursors.IntegerBlockStats{}, false
+	}
+	s, ok := c.stats.PeekBlockStats()
+	if !ok {
+		return s, false
+	}
+	_, stop := c.window.bounds(s.FirstTime)
+	return s, s.LastTime < stop
+}
+
+// blockAggregate returns the time and value of the aggregate of a block.
+func (c *integerWindowAggregateArrayCursor) blockAggregate(s cursors.IntegerBlockStats) (int64, int64) {
+	switch c.agg {
+	case datatypes.AggregateTypeMin:
+		return s.MinTime, s.Min
+	case datatypes.AggregateTypeMax:
+		return s.MaxTime, s.Max
+	default:
+		return s.FirstTime, s.Sum
+	}
+}
+
+// floatIntegerWindowMeanArrayCursor averages the values of each window of the cursor.
+type floatIntegerWindowMeanArrayCursor struct {
+	cursors.IntegerArrayCursor
+	window aggregateWindow
+	a      *cursors.IntegerArray
+	i      int
+	res    *cursors.FloatArray
+
+	// stats is set when the sum and count of whole blocks may be read from their statistics.
+	stats cursors.IntegerBlockStatsCursor
+}
+
+func newFloatIntegerWindowMeanArrayCursor(cur cursors.IntegerArrayCursor, window aggregateWindow) *floatIntegerWindowMeanArrayCursor {
+	c := &floatIntegerWindowMeanArrayCursor{
+		IntegerArrayCursor: cur,
+		window:             window,
+		a:                  &cursors.IntegerArray{},
+		res:                cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+	c.stats, _ = cur.(cursors.IntegerBlockStatsCursor)
+	return c
+}
+
+func (c *floatIntegerWindowMeanArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *floatIntegerWindowMeanArrayCursor) Next() *cursors.FloatArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for len(c.res.Timestamps) < MaxPointsPerBlock {
+		var start, stop, ts int64
+		var sum float64
+		var n int64
+		if s, ok := c.peekBlockStats(); ok {
+			c.stats.SkipBlock()
+			start, stop = c.window.bounds(s.FirstTime)
+			ts, sum, n = s.FirstTime, float64(s.Sum), s.Count
+		} else {
+			if c.i >= c.a.Len() {
+				c.a = c.IntegerArrayCursor.Next()
+				c.i = 0
+				if c.a.Len() == 0 {
+					break
+				}
+			}
+
+			start, stop = c.window.bounds(c.a.Timestamps[c.i])
+			ts = c.a.Timestamps[c.i]
+		}
+	WINDOW:
+		for {
+			for ; c.i < c.a.Len(); c.i++ {
+				t := c.a.Timestamps[c.i]
+				if t < start || t >= stop {
+					break WINDOW
+				}
+				sum += float64(c.a.Values[c.i])
+				n++
+			}
+			if s, ok := c.peekBlockStats(); ok {
+				if s.FirstTime >= stop {
+					// the block starts the next window
+					break WINDOW
+				}
+				c.stats.SkipBlock()
+				sum += float64(s.Sum)
+				n += s.Count
+				continue
+			}
+			c.a = c.IntegerArrayCursor.Next()
+			c.i = 0
+			if c.a.Len() == 0 {
+				break
+			}
+		}
+
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, sum/float64(n))
+	}
+
+	return c.res
+}
+
+// peekBlockStats returns the statistics of the next block of the cursor, when no points
+// are left to average before the block and it lies within a single window.
+func (c *floatIntegerWindowMeanArrayCursor) peekBlockStats() (cursors.IntegerBlockStats, bool) {
+	if c.stats == nil || c.i < c.a.Len() {
+		return cursors.IntegerBlockStats{}, false
+	}
+	s, ok := c.stats.PeekBlockStats()
+	if !ok {
+		return s, false
+	}
+	_, stop := c.window.bounds(s.FirstTime)
+	return s, s.LastTime < stop
+}
+
+// integerIntegerWindowCountArrayCursor counts the values of each window of the cursor.
+type integerIntegerWindowCountArrayCursor struct {
+	cursors.IntegerArrayCursor
+	window aggregateWindow
+	a      *cursors.IntegerArray
+	i      int
+	res    *cursors.IntegerArray
+
+	// stats is set when the count of whole blocks may be read from their statistics.
+	stats cursors.IntegerBlockStatsCursor
+}
+
+func newIntegerIntegerWindowCountArrayCursor(cur cursors.IntegerArrayCursor, window aggregateWindow) *integerIntegerWindowCountArrayCursor {
+	c := &integerIntegerWindowCountArrayCursor{
+		IntegerArrayCursor: cur,
+		window:             window,
+		a:                  &cursors.IntegerArray{},
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+	c.stats, _ = cur.(cursors.IntegerBlockStatsCursor)
+	return c
+}
+
+func (c *integerIntegerWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerIntegerWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for len(c.res.Timestamps) < MaxPointsPerBlock {
+		var start, stop, ts int64
+		var n int64
+		if s, ok := c.peekBlockStats(); ok {
+			c.stats.SkipBlock()
+			start, stop = c.window.bounds(s.FirstTime)
+			ts, n = s.FirstTime, s.Count
+		} else {
+			if c.i >= c.a.Len() {
+				c.a = c.IntegerArrayCursor.Next()
+				c.i = 0
+				if c.a.Len() == 0 {
+					break
+				}
+			}
+
+			start, stop = c.window.bounds(c.a.Timestamps[c.i])
+			ts = c.a.Timestamps[c.i]
+		}
+	WINDOW:
+		for {
+			for ; c.i < c.a.Len(); c.i++ {
+				t := c.a.Timestamps[c.i]
+				if t < start || t >= stop {
+					break WINDOW
+				}
+				n++
+			}
+			if s, ok := c.peekBlockStats(); ok {
+				if s.FirstTime >= stop {
+					// the block starts the next window
+					break WINDOW
+				}
+				c.stats.SkipBlock()
+				n += s.Count
+				continue
+			}
+			c.a = c.IntegerArrayCursor.Next()
+			c.i = 0
+			if c.a.Len() == 0 {
+				break
+			}
+		}
+
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, n)
+	}
+
+	return c.res
+}
+
+// peekBlockStats returns the statistics of the next block of the cursor, when no points
+// are left to count before the block and it lies within a single window.
+func (c *integerIntegerWindowCountArrayCursor) peekBlockStats() (cursors.IntegerBlockStats, bool) {
+	if c.stats == nil || c.i < c.a.Len() {
+		return cursors.IntegerBlockStats{}, false
 	}
+	s, ok := c.stats.PeekBlockStats()
+	if !ok {
+		return s, false
+	}
+	_, stop := c.window.bounds(s.FirstTime)
+	return s, s.LastTime < stop
 }
 
 type integerEmptyArrayCursor struct {
@@ -627,6 +1410,28 @@ func (c *unsignedMultiShardArrayCursor) nextArrayCursor() bool {
 	return ok
 }
 
+// PeekBlockStats returns the statistics of the next block of the current shard, when its
+// points are not filtered and all of them are within the limit of the cursor.
+func (c *unsignedMultiShardArrayCursor) PeekBlockStats() (cursors.UnsignedBlockStats, bool) {
+	cur, ok := c.UnsignedArrayCursor.(cursors.UnsignedBlockStatsCursor)
+	if !ok {
+		return cursors.UnsignedBlockStats{}, false
+	}
+	s, ok := cur.PeekBlockStats()
+	if !ok || c.count+s.Count > c.limit {
+		return cursors.UnsignedBlockStats{}, false
+	}
+	return s, true
+}
+
+// SkipBlock moves past the block returned by PeekBlockStats.
+func (c *unsignedMultiShardArrayCursor) SkipBlock() {
+	cur := c.UnsignedArrayCursor.(cursors.UnsignedBlockStatsCursor)
+	s, _ := cur.PeekBlockStats()
+	c.count += s.Count
+	cur.SkipBlock()
+}
+
 type unsignedArraySumCursor struct {
 	cursors.UnsignedArrayCursor
 	ts  [1]int64
@@ -644,26 +1449,45 @@ func newUnsignedArraySumCursor(cur cursors.UnsignedArrayCursor) *unsignedArraySu
 func (c unsignedArraySumCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
 
 func (c unsignedArraySumCursor) Next() *cursors.UnsignedArray {
-	a := c.UnsignedArrayCursor.Next()
-	if len(a.Timestamps) == 0 {
-		return a
-	}
+	stats, _ := c.UnsignedArrayCursor.(cursors.UnsignedBlockStatsCursor)
 
-	ts := a.Timestamps[0]
+	var ts int64
 	var acc uint64
+	var n int64
 
 	for {
-		for _, v := range a.Values {
-			acc += v
+		// sum whole blocks from their statistics when possible
+		if stats != nil {
+			if s, ok := stats.PeekBlockStats(); ok {
+				stats.SkipBlock()
+				if n == 0 {
+					ts = s.FirstTime
+				}
+				acc += s.Sum
+				n += s.Count
+				continue
+			}
 		}
-		a = c.UnsignedArrayCursor.Next()
+
+		a := c.UnsignedArrayCursor.Next()
 		if len(a.Timestamps) == 0 {
+			if n == 0 {
+				return a
+			}
 			c.ts[0] = ts
 			c.vs[0] = acc
 			c.res.Timestamps = c.ts[:]
 			c.res.Values = c.vs[:]
 			return c.res
 		}
+
+		if n == 0 {
+			ts = a.Timestamps[0]
+		}
+		for _, v := range a.Values {
+			acc += v
+		}
+		n += int64(a.Len())
 	}
 }
 
@@ -676,23 +1500,373 @@ func (c *integerUnsignedCountArrayCursor) Stats() cursors.CursorStats {
 }
 
 func (c *integerUnsignedCountArrayCursor) Next() *cursors.IntegerArray {
-	a := c.UnsignedArrayCursor.Next()
-	if len(a.Timestamps) == 0 {
-		return &cursors.IntegerArray{}
-	}
+	stats, _ := c.UnsignedArrayCursor.(cursors.UnsignedBlockStatsCursor)
 
-	ts := a.Timestamps[0]
+	var ts int64
 	var acc int64
 	for {
-		acc += int64(len(a.Timestamps))
-		a = c.UnsignedArrayCursor.Next()
+		// count whole blocks from their statistics when possible
+		if stats != nil {
+			if s, ok := stats.PeekBlockStats(); ok {
+				stats.SkipBlock()
+				if acc == 0 {
+					ts = s.FirstTime
+				}
+				acc += s.Count
+				continue
+			}
+		}
+
+		a := c.UnsignedArrayCursor.Next()
 		if len(a.Timestamps) == 0 {
+			if acc == 0 {
+				return &cursors.IntegerArray{}
+			}
 			res := cursors.NewIntegerArrayLen(1)
 			res.Timestamps[0] = ts
 			res.Values[0] = acc
 			return res
 		}
+
+		if acc == 0 {
+			ts = a.Timestamps[0]
+		}
+		acc += int64(len(a.Timestamps))
+	}
+}
+
+// unsignedWindowAggregateArrayCursor selects or sums the values of each window of the cursor.
+type unsignedWindowAggregateArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	agg    datatypes.Aggregate_AggregateType
+	window aggregateWindow
+	a      *cursors.UnsignedArray
+	i      int
+	res    *cursors.UnsignedArray
+
+	// stats is set when the sum, min or max of whole blocks may be read from their statistics.
+	stats cursors.UnsignedBlockStatsCursor
+}
+
+func newUnsignedWindowAggregateArrayCursor(cur cursors.UnsignedArrayCursor, agg datatypes.Aggregate_AggregateType, window aggregateWindow) *unsignedWindowAggregateArrayCursor {
+	c := &unsignedWindowAggregateArrayCursor{
+		UnsignedArrayCursor: cur,
+		agg:                 agg,
+		window:              window,
+		a:                   &cursors.UnsignedArray{},
+		res:                 cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+	}
+	switch agg {
+	case datatypes.AggregateTypeSum, datatypes.AggregateTypeMin, datatypes.AggregateTypeMax:
+		c.stats, _ = cur.(cursors.UnsignedBlockStatsCursor)
+	}
+	return c
+}
+
+func (c *unsignedWindowAggregateArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *unsignedWindowAggregateArrayCursor) Next() *cursors.UnsignedArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for len(c.res.Timestamps) < MaxPointsPerBlock {
+		var start, stop, ts int64
+		var v uint64
+		if s, ok := c.peekBlockStats(); ok {
+			c.stats.SkipBlock()
+			start, stop = c.window.bounds(s.FirstTime)
+			ts, v = c.blockAggregate(s)
+		} else {
+			if c.i >= c.a.Len() {
+				c.a = c.UnsignedArrayCursor.Next()
+				c.i = 0
+				if c.a.Len() == 0 {
+					break
+				}
+			}
+
+			start, stop = c.window.bounds(c.a.Timestamps[c.i])
+			ts, v = c.a.Timestamps[c.i], c.a.Values[c.i]
+			c.i++
+		}
+	WINDOW:
+		for {
+			for ; c.i < c.a.Len(); c.i++ {
+				t := c.a.Timestamps[c.i]
+				if t < start || t >= stop {
+					break WINDOW
+				}
+				switch c.agg {
+				case datatypes.AggregateTypeSum:
+					v += c.a.Values[c.i]
+				case datatypes.AggregateTypeMin:
+					if c.a.Values[c.i] < v {
+						ts, v = t, c.a.Values[c.i]
+					}
+				case datatypes.AggregateTypeMax:
+					if c.a.Values[c.i] > v {
+						ts, v = t, c.a.Values[c.i]
+					}
+				case datatypes.AggregateTypeLast:
+					ts, v = t, c.a.Values[c.i]
+				}
+			}
+			if s, ok := c.peekBlockStats(); ok {
+				if s.FirstTime >= stop {
+					// the block starts the next window
+					break WINDOW
+				}
+				c.stats.SkipBlock()
+				bts, bv := c.blockAggregate(s)
+				switch c.agg {
+				case datatypes.AggregateTypeSum:
+					v += bv
+				case datatypes.AggregateTypeMin:
+					if bv < v {
+						ts, v = bts, bv
+					}
+				case datatypes.AggregateTypeMax:
+					if bv > v {
+						ts, v = bts, bv
+					}
+				}
+				continue
+			}
+			c.a = c.UnsignedArrayCursor.Next()
+			c.i = 0
+			if c.a.Len() == 0 {
+				break
+			}
+		}
+
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, v)
+	}
+
+	return c.res
+}
+
+// peekBlockStats returns the statistics of the next block of the cursor, when no points
+// are left to aggregate before the block and it lies within a single window.
+func (c *unsignedWindowAggregateArrayCursor) peekBlockStats() (cursors.UnsignedBlockStats, bool) {
+	if c.stats == nil || c.i < c.a.Len() {
+		return cursors.UnsignedBlockStats{}, false
+	}
+	s, ok := c.stats.PeekBlockStats()
+	if !ok {
+		return s, false
+	}
+	_, stop := c.window.bounds(s.FirstTime)
+	return s, s.LastTime < stop
+}
+
+// blockAggregate returns the time and value of the aggregate of a block.
+func (c *unsignedWindowAggregateArrayCursor) blockAggregate(s cursors.UnsignedBlockStats) (int64, uint64) {
+	switch c.agg {
+	case datatypes.AggregateTypeMin:
+		return s.MinTime, s.Min
+	case datatypes.AggregateTypeMax:
+		return s.MaxTime, s.Max
+	default:
+		return s.FirstTime, s.Sum
+	}
+}
+
+// floatUnsignedWindowMeanArrayCursor averages the values of each window of the cursor.
+type floatUnsignedWindowMeanArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	window aggregateWindow
+	a      *cursors.UnsignedArray
+	i      int
+	res    *cursors.FloatArray
+
+	// stats is set when the sum and count of whole blocks may be read from their statistics.
+	stats cursors.UnsignedBlockStatsCursor
+}
+
+func newFloatUnsignedWindowMeanArrayCursor(cur cursors.UnsignedArrayCursor, window aggregateWindow) *floatUnsignedWindowMeanArrayCursor {
+	c := &floatUnsignedWindowMeanArrayCursor{
+		UnsignedArrayCursor: cur,
+		window:              window,
+		a:                   &cursors.UnsignedArray{},
+		res:                 cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+	c.stats, _ = cur.(cursors.UnsignedBlockStatsCursor)
+	return c
+}
+
+func (c *floatUnsignedWindowMeanArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *floatUnsignedWindowMeanArrayCursor) Next() *cursors.FloatArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for len(c.res.Timestamps) < MaxPointsPerBlock {
+		var start, stop, ts int64
+		var sum float64
+		var n int64
+		if s, ok := c.peekBlockStats(); ok {
+			c.stats.SkipBlock()
+			start, stop = c.window.bounds(s.FirstTime)
+			ts, sum, n = s.FirstTime, float64(s.Sum), s.Count
+		} else {
+			if c.i >= c.a.Len() {
+				c.a = c.UnsignedArrayCursor.Next()
+				c.i = 0
+				if c.a.Len() == 0 {
+					break
+				}
+			}
+
+			start, stop = c.window.bounds(c.a.Timestamps[c.i])
+			ts = c.a.Timestamps[c.i]
+		}
+	WINDOW:
+		for {
+			for ; c.i < c.a.Len(); c.i++ {
+				t := c.a.Timestamps[c.i]
+				if t < start || t >= stop {
+					break WINDOW
+				}
+				sum += float64(c.a.Values[c.i])
+				n++
+			}
+			if s, ok := c.peekBlockStats(); ok {
+				if s.FirstTime >= stop {
+					// the block starts the next window
+					break WINDOW
+				}
+				c.stats.SkipBlock()
+				sum += float64(s.Sum)
+				n += s.Count
+				continue
+			}
+			c.a = c.UnsignedArrayCursor.Next()
+			c.i = 0
+			if c.a.Len() == 0 {
+				break
+			}
+		}
+
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, sum/float64(n))
+	}
+
+	return c.res
+}
+
+// peekBlockStats returns the statistics of the next block of the cursor, when no points
+// are left to average before the block and it lies within a single window.
+func (c *floatUnsignedWindowMeanArrayCursor) peekBlockStats() (cursors.UnsignedBlockStats, bool) {
+	if c.stats == nil || c.i < c.a.Len() {
+		return cursors.UnsignedBlockStats{}, false
+	}
+	s, ok := c.stats.PeekBlockStats()
+	if !ok {
+		return s, false
+	}
+	_, stop := c.window.bounds(s.FirstTime)
+	return s, s.LastTime < stop
+}
+
+// integerUnsignedWindowCountArrayCursor counts the values of each window of the cursor.
+type integerUnsignedWindowCountArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	window aggregateWindow
+	a      *cursors.UnsignedArray
+	i      int
+	res    *cursors.IntegerArray
+
+	// stats is set when the count of whole blocks may be read from their statistics.
+	stats cursors.UnsignedBlockStatsCursor
+}
+
+func newIntegerUnsignedWindowCountArrayCursor(cur cursors.UnsignedArrayCursor, window aggregateWindow) *integerUnsignedWindowCountArrayCursor {
+	c := &integerUnsignedWindowCountArrayCursor{
+		UnsignedArrayCursor: cur,
+		window:              window,
+		a:                   &cursors.UnsignedArray{},
+		res:                 cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+	c.stats, _ = cur.(cursors.UnsignedBlockStatsCursor)
+	return c
+}
+
+func (c *integerUnsignedWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *integerUnsignedWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for len(c.res.Timestamps) < MaxPointsPerBlock {
+		var start, stop, ts int64
+		var n int64
+		if s, ok := c.peekBlockStats(); ok {
+			c.stats.SkipBlock()
+			start, stop = c.window.bounds(s.FirstTime)
+			ts, n = s.FirstTime, s.Count
+		} else {
+			if c.i >= c.a.Len() {
+				c.a = c.UnsignedArrayCursor.Next()
+				c.i = 0
+				if c.a.Len() == 0 {
+					break
+				}
+			}
+
+			start, stop = c.window.bounds(c.a.Timestamps[c.i])
+			ts = c.a.Timestamps[c.i]
+		}
+	WINDOW:
+		for {
+			for ; c.i < c.a.Len(); c.i++ {
+				t := c.a.Timestamps[c.i]
+				if t < start || t >= stop {
+					break WINDOW
+				}
+				n++
+			}
+			if s, ok := c.peekBlockStats(); ok {
+				if s.FirstTime >= stop {
+					// the block starts the next window
+					break WINDOW
+				}
+				c.stats.SkipBlock()
+				n += s.Count
+				continue
+			}
+			c.a = c.UnsignedArrayCursor.Next()
+			c.i = 0
+			if c.a.Len() == 0 {
+				break
+			}
+		}
+
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, n)
+	}
+
+	return c.res
+}
+
+// peekBlockStats returns the statistics of the next block of the cursor, when no points
+// are left to count before the block and it lies within a single window.
+func (c *integerUnsignedWindowCountArrayCursor) peekBlockStats() (cursors.UnsignedBlockStats, bool) {
+	if c.stats == nil || c.i < c.a.Len() {
+		return cursors.UnsignedBlockStats{}, false
+	}
+	s, ok := c.stats.PeekBlockStats()
+	if !ok {
+		return s, false
 	}
+	_, stop := c.window.bounds(s.FirstTime)
+	return s, s.LastTime < stop
 }
 
 type unsignedEmptyArrayCursor struct {
@@ -865,23 +2039,157 @@ func (c *integerStringCountArrayCursor) Stats() cursors.CursorStats {
 }
 
 func (c *integerStringCountArrayCursor) Next() *cursors.IntegerArray {
-	a := c.StringArrayCursor.Next()
-	if len(a.Timestamps) == 0 {
-		return &cursors.IntegerArray{}
-	}
-
-	ts := a.Timestamps[0]
+	var ts int64
 	var acc int64
 	for {
-		acc += int64(len(a.Timestamps))
-		a = c.StringArrayCursor.Next()
+		a := c.StringArrayCursor.Next()
 		if len(a.Timestamps) == 0 {
+			if acc == 0 {
+				return &cursors.IntegerArray{}
+			}
 			res := cursors.NewIntegerArrayLen(1)
 			res.Timestamps[0] = ts
 			res.Values[0] = acc
 			return res
 		}
+
+		if acc == 0 {
+			ts = a.Timestamps[0]
+		}
+		acc += int64(len(a.Timestamps))
+	}
+}
+
+// stringWindowAggregateArrayCursor selects or sums the values of each window of the cursor.
+type stringWindowAggregateArrayCursor struct {
+	cursors.StringArrayCursor
+	agg    datatypes.Aggregate_AggregateType
+	window aggregateWindow
+	a      *cursors.StringArray
+	i      int
+	res    *cursors.StringArray
+}
+
+func newStringWindowAggregateArrayCursor(cur cursors.StringArrayCursor, agg datatypes.Aggregate_AggregateType, window aggregateWindow) *stringWindowAggregateArrayCursor {
+	c := &stringWindowAggregateArrayCursor{
+		StringArrayCursor: cur,
+		agg:               agg,
+		window:            window,
+		a:                 &cursors.StringArray{},
+		res:               cursors.NewStringArrayLen(MaxPointsPerBlock),
+	}
+	return c
+}
+
+func (c *stringWindowAggregateArrayCursor) Stats() cursors.CursorStats {
+	return c.StringArrayCursor.Stats()
+}
+
+func (c *stringWindowAggregateArrayCursor) Next() *cursors.StringArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for len(c.res.Timestamps) < MaxPointsPerBlock {
+		var start, stop, ts int64
+		var v string
+		if c.i >= c.a.Len() {
+			c.a = c.StringArrayCursor.Next()
+			c.i = 0
+			if c.a.Len() == 0 {
+				break
+			}
+		}
+
+		start, stop = c.window.bounds(c.a.Timestamps[c.i])
+		ts, v = c.a.Timestamps[c.i], c.a.Values[c.i]
+		c.i++
+	WINDOW:
+		for {
+			for ; c.i < c.a.Len(); c.i++ {
+				t := c.a.Timestamps[c.i]
+				if t < start || t >= stop {
+					break WINDOW
+				}
+				switch c.agg {
+				case datatypes.AggregateTypeLast:
+					ts, v = t, c.a.Values[c.i]
+				}
+			}
+			c.a = c.StringArrayCursor.Next()
+			c.i = 0
+			if c.a.Len() == 0 {
+				break
+			}
+		}
+
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, v)
+	}
+
+	return c.res
+}
+
+// integerStringWindowCountArrayCursor counts the values of each window of the cursor.
+type integerStringWindowCountArrayCursor struct {
+	cursors.StringArrayCursor
+	window aggregateWindow
+	a      *cursors.StringArray
+	i      int
+	res    *cursors.IntegerArray
+}
+
+func newIntegerStringWindowCountArrayCursor(cur cursors.StringArrayCursor, window aggregateWindow) *integerStringWindowCountArrayCursor {
+	c := &integerStringWindowCountArrayCursor{
+		StringArrayCursor: cur,
+		window:            window,
+		a:                 &cursors.StringArray{},
+		res:               cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+	return c
+}
+
+func (c *integerStringWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.StringArrayCursor.Stats()
+}
+
+func (c *integerStringWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for len(c.res.Timestamps) < MaxPointsPerBlock {
+		var start, stop, ts int64
+		var n int64
+		if c.i >= c.a.Len() {
+			c.a = c.StringArrayCursor.Next()
+			c.i = 0
+			if c.a.Len() == 0 {
+				break
+			}
+		}
+
+		start, stop = c.window.bounds(c.a.Timestamps[c.i])
+		ts = c.a.Timestamps[c.i]
+	WINDOW:
+		for {
+			for ; c.i < c.a.Len(); c.i++ {
+				t := c.a.Timestamps[c.i]
+				if t < start || t >= stop {
+					break WINDOW
+				}
+				n++
+			}
+			c.a = c.StringArrayCursor.Next()
+			c.i = 0
+			if c.a.Len() == 0 {
+				break
+			}
+		}
+
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, n)
 	}
+
+	return c.res
 }
 
 type stringEmptyArrayCursor struct {
@@ -1054,23 +2362,157 @@ func (c *integerBooleanCountArrayCursor) Stats() cursors.CursorStats {
 }
 
 func (c *integerBooleanCountArrayCursor) Next() *cursors.IntegerArray {
-	a := c.BooleanArrayCursor.Next()
-	if len(a.Timestamps) == 0 {
-		return &cursors.IntegerArray{}
-	}
-
-	ts := a.Timestamps[0]
+	var ts int64
 	var acc int64
 	for {
-		acc += int64(len(a.Timestamps))
-		a = c.BooleanArrayCursor.Next()
+		a := c.BooleanArrayCursor.Next()
 		if len(a.Timestamps) == 0 {
+			if acc == 0 {
+				return &cursors.IntegerArray{}
+			}
 			res := cursors.NewIntegerArrayLen(1)
 			res.Timestamps[0] = ts
 			res.Values[0] = acc
 			return res
 		}
+
+		if acc == 0 {
+			ts = a.Timestamps[0]
+		}
+		acc += int64(len(a.Timestamps))
+	}
+}
+
+// booleanWindowAggregateArrayCursor selects or sums the values of each window of the cursor.
+type booleanWindowAggregateArrayCursor struct {
+	cursors.BooleanArrayCursor
+	agg    datatypes.Aggregate_AggregateType
+	window aggregateWindow
+	a      *cursors.BooleanArray
+	i      int
+	res    *cursors.BooleanArray
+}
+
+func newBooleanWindowAggregateArrayCursor(cur cursors.BooleanArrayCursor, agg datatypes.Aggregate_AggregateType, window aggregateWindow) *booleanWindowAggregateArrayCursor {
+	c := &booleanWindowAggregateArrayCursor{
+		BooleanArrayCursor: cur,
+		agg:                agg,
+		window:             window,
+		a:                  &cursors.BooleanArray{},
+		res:                cursors.NewBooleanArrayLen(MaxPointsPerBlock),
+	}
+	return c
+}
+
+func (c *booleanWindowAggregateArrayCursor) Stats() cursors.CursorStats {
+	return c.BooleanArrayCursor.Stats()
+}
+
+func (c *booleanWindowAggregateArrayCursor) Next() *cursors.BooleanArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for len(c.res.Timestamps) < MaxPointsPerBlock {
+		var start, stop, ts int64
+		var v bool
+		if c.i >= c.a.Len() {
+			c.a = c.BooleanArrayCursor.Next()
+			c.i = 0
+			if c.a.Len() == 0 {
+				break
+			}
+		}
+
+		start, stop = c.window.bounds(c.a.Timestamps[c.i])
+		ts, v = c.a.Timestamps[c.i], c.a.Values[c.i]
+		c.i++
+	WINDOW:
+		for {
+			for ; c.i < c.a.Len(); c.i++ {
+				t := c.a.Timestamps[c.i]
+				if t < start || t >= stop {
+					break WINDOW
+				}
+				switch c.agg {
+				case datatypes.AggregateTypeLast:
+					ts, v = t, c.a.Values[c.i]
+				}
+			}
+			c.a = c.BooleanArrayCursor.Next()
+			c.i = 0
+			if c.a.Len() == 0 {
+				break
+			}
+		}
+
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, v)
+	}
+
+	return c.res
+}
+
+// integerBooleanWindowCountArrayCursor counts the values of each window of the cursor.
+type integerBooleanWindowCountArrayCursor struct {
+	cursors.BooleanArrayCursor
+	window aggregateWindow
+	a      *cursors.BooleanArray
+	i      int
+	res    *cursors.IntegerArray
+}
+
+func newIntegerBooleanWindowCountArrayCursor(cur cursors.BooleanArrayCursor, window aggregateWindow) *integerBooleanWindowCountArrayCursor {
+	c := &integerBooleanWindowCountArrayCursor{
+		BooleanArrayCursor: cur,
+		window:             window,
+		a:                  &cursors.BooleanArray{},
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+	return c
+}
+
+func (c *integerBooleanWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.BooleanArrayCursor.Stats()
+}
+
+func (c *integerBooleanWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for len(c.res.Timestamps) < MaxPointsPerBlock {
+		var start, stop, ts int64
+		var n int64
+		if c.i >= c.a.Len() {
+			c.a = c.BooleanArrayCursor.Next()
+			c.i = 0
+			if c.a.Len() == 0 {
+				break
+			}
+		}
+
+		start, stop = c.window.bounds(c.a.Timestamps[c.i])
+		ts = c.a.Timestamps[c.i]
+	WINDOW:
+		for {
+			for ; c.i < c.a.Len(); c.i++ {
+				t := c.a.Timestamps[c.i]
+				if t < start || t >= stop {
+					break WINDOW
+				}
+				n++
+			}
+			c.a = c.BooleanArrayCursor.Next()
+			c.i = 0
+			if c.a.Len() == 0 {
+				break
+			}
+		}
+
+		c.res.Timestamps = append(c.res.Timestamps, ts)
+		c.res.Values = append(c.res.Values, n)
 	}
+
+	return c.res
 }
 
 type booleanEmptyArrayCursor struct {
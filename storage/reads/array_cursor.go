@@ -3,6 +3,7 @@ package reads
 import (
 	"context"
 	"fmt"
+	"math"
 
 	"github.com/influxdata/platform/storage/reads/datatypes"
 	"github.com/influxdata/platform/tsdb/cursors"
@@ -21,17 +22,106 @@ func newAggregateArrayCursor(ctx context.Context, agg *datatypes.Aggregate, curs
 		return nil
 	}
 
+	window := aggregateWindow{every: agg.Every, offset: agg.Offset}
 	switch agg.Type {
 	case datatypes.AggregateTypeSum:
-		return newSumArrayCursor(cursor)
+		if window.every == 0 {
+			return newSumArrayCursor(cursor)
+		}
+		return newWindowAggregateArrayCursor(cursor, agg.Type, window)
 	case datatypes.AggregateTypeCount:
-		return newCountArrayCursor(cursor)
+		if window.every == 0 {
+			return newCountArrayCursor(cursor)
+		}
+		return newWindowCountArrayCursor(cursor, window)
+	case datatypes.AggregateTypeMin, datatypes.AggregateTypeMax, datatypes.AggregateTypeFirst, datatypes.AggregateTypeLast:
+		return newWindowAggregateArrayCursor(cursor, agg.Type, window)
+	case datatypes.AggregateTypeMean:
+		return newWindowMeanArrayCursor(cursor, window)
 	default:
 		// TODO(sgc): should be validated higher up
 		panic("invalid aggregate")
 	}
 }
 
+// aggregateWindow splits time into the windows an aggregate is computed over.
+// An every of 0 is a single window spanning all time.
+type aggregateWindow struct {
+	every  int64
+	offset int64
+}
+
+// bounds returns the start and stop of the window that holds t.
+// The stop is exclusive.
+func (w aggregateWindow) bounds(t int64) (start, stop int64) {
+	if w.every == 0 {
+		return math.MinInt64, math.MaxInt64
+	}
+	r := (t - w.offset) % w.every
+	if r < 0 {
+		r += w.every
+	}
+	start = t - r
+	return start, start + w.every
+}
+
+// newWindowAggregateArrayCursor returns a cursor that selects the min, max, first or last value,
+// or sums the values, of each window. Only numeric values can be summed or compared.
+func newWindowAggregateArrayCursor(cur cursors.Cursor, agg datatypes.Aggregate_AggregateType, window aggregateWindow) cursors.Cursor {
+	numeric := agg == datatypes.AggregateTypeSum || agg == datatypes.AggregateTypeMin || agg == datatypes.AggregateTypeMax
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatWindowAggregateArrayCursor(cur, agg, window)
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowAggregateArrayCursor(cur, agg, window)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowAggregateArrayCursor(cur, agg, window)
+	case cursors.StringArrayCursor:
+		if numeric {
+			return nil
+		}
+		return newStringWindowAggregateArrayCursor(cur, agg, window)
+	case cursors.BooleanArrayCursor:
+		if numeric {
+			return nil
+		}
+		return newBooleanWindowAggregateArrayCursor(cur, agg, window)
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+}
+
+func newWindowMeanArrayCursor(cur cursors.Cursor, window aggregateWindow) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatFloatWindowMeanArrayCursor(cur, window)
+	case cursors.IntegerArrayCursor:
+		return newFloatIntegerWindowMeanArrayCursor(cur, window)
+	case cursors.UnsignedArrayCursor:
+		return newFloatUnsignedWindowMeanArrayCursor(cur, window)
+	default:
+		// TODO(sgc): propagate an error instead?
+		return nil
+	}
+}
+
+func newWindowCountArrayCursor(cur cursors.Cursor, window aggregateWindow) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newIntegerFloatWindowCountArrayCursor(cur, window)
+	case cursors.IntegerArrayCursor:
+		return newIntegerIntegerWindowCountArrayCursor(cur, window)
+	case cursors.UnsignedArrayCursor:
+		return newIntegerUnsignedWindowCountArrayCursor(cur, window)
+	case cursors.StringArrayCursor:
+		return newIntegerStringWindowCountArrayCursor(cur, window)
+	case cursors.BooleanArrayCursor:
+		return newIntegerBooleanWindowCountArrayCursor(cur, window)
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+}
+
 func newSumArrayCursor(cur cursors.Cursor) cursors.Cursor {
 	switch cur := cur.(type) {
 	case cursors.FloatArrayCursor:
@@ -0,0 +1,267 @@
+package reads
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform/storage/reads/datatypes"
+	"github.com/influxdata/platform/tsdb/cursors"
+)
+
+// floatArrayCursor returns each of its arrays in turn.
+type floatArrayCursor struct {
+	arrays []*cursors.FloatArray
+}
+
+func (c *floatArrayCursor) Close()                     {}
+func (c *floatArrayCursor) Err() error                 { return nil }
+func (c *floatArrayCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+func (c *floatArrayCursor) Next() *cursors.FloatArray {
+	if len(c.arrays) == 0 {
+		return &cursors.FloatArray{}
+	}
+	a := c.arrays[0]
+	c.arrays = c.arrays[1:]
+	return a
+}
+
+func newFloatArrayCursor() *floatArrayCursor {
+	// Windows of 10 hold 1, 2 and 3 points; the second window spans two arrays.
+	return &floatArrayCursor{arrays: []*cursors.FloatArray{
+		{Timestamps: []int64{3, 12}, Values: []float64{4, 1}},
+		{Timestamps: []int64{15, 21, 22, 29}, Values: []float64{7, 3, 9, 3}},
+	}}
+}
+
+func TestAggregateWindow_Bounds(t *testing.T) {
+	tests := []struct {
+		window      aggregateWindow
+		t           int64
+		start, stop int64
+	}{
+		{window: aggregateWindow{every: 10}, t: 0, start: 0, stop: 10},
+		{window: aggregateWindow{every: 10}, t: 19, start: 10, stop: 20},
+		{window: aggregateWindow{every: 10, offset: 5}, t: 19, start: 15, stop: 25},
+		{window: aggregateWindow{every: 10, offset: 5}, t: 14, start: 5, stop: 15},
+		{window: aggregateWindow{every: 10}, t: -1, start: -10, stop: 0},
+	}
+	for _, tt := range tests {
+		start, stop := tt.window.bounds(tt.t)
+		if start != tt.start || stop != tt.stop {
+			t.Errorf("%+v bounds(%d): got [%d, %d), want [%d, %d)", tt.window, tt.t, start, stop, tt.start, tt.stop)
+		}
+	}
+}
+
+func TestNewAggregateArrayCursor_Window(t *testing.T) {
+	tests := []struct {
+		agg  datatypes.Aggregate_AggregateType
+		want interface{}
+	}{
+		{
+			agg:  datatypes.AggregateTypeMin,
+			want: &cursors.FloatArray{Timestamps: []int64{3, 12, 21}, Values: []float64{4, 1, 3}},
+		},
+		{
+			agg:  datatypes.AggregateTypeMax,
+			want: &cursors.FloatArray{Timestamps: []int64{3, 15, 22}, Values: []float64{4, 7, 9}},
+		},
+		{
+			agg:  datatypes.AggregateTypeFirst,
+			want: &cursors.FloatArray{Timestamps: []int64{3, 12, 21}, Values: []float64{4, 1, 3}},
+		},
+		{
+			agg:  datatypes.AggregateTypeLast,
+			want: &cursors.FloatArray{Timestamps: []int64{3, 15, 29}, Values: []float64{4, 7, 3}},
+		},
+		{
+			agg:  datatypes.AggregateTypeSum,
+			want: &cursors.FloatArray{Timestamps: []int64{3, 12, 21}, Values: []float64{4, 8, 15}},
+		},
+		{
+			agg:  datatypes.AggregateTypeMean,
+			want: &cursors.FloatArray{Timestamps: []int64{3, 12, 21}, Values: []float64{4, 4, 5}},
+		},
+		{
+			agg:  datatypes.AggregateTypeCount,
+			want: &cursors.IntegerArray{Timestamps: []int64{3, 12, 21}, Values: []int64{1, 2, 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.agg.String(), func(t *testing.T) {
+			agg := &datatypes.Aggregate{Type: tt.agg, Every: 10}
+			var got interface{}
+			var more int
+			switch cur := newAggregateArrayCursor(context.Background(), agg, newFloatArrayCursor()).(type) {
+			case cursors.FloatArrayCursor:
+				a := cur.Next()
+				got = &cursors.FloatArray{Timestamps: append([]int64{}, a.Timestamps...), Values: append([]float64{}, a.Values...)}
+				more = cur.Next().Len()
+			case cursors.IntegerArrayCursor:
+				a := cur.Next()
+				got = &cursors.IntegerArray{Timestamps: append([]int64{}, a.Timestamps...), Values: append([]int64{}, a.Values...)}
+				more = cur.Next().Len()
+			default:
+				t.Fatalf("unexpected cursor %T", cur)
+			}
+			if !cmp.Equal(got, tt.want) {
+				t.Errorf("unexpected result -want/+got:\n%s", cmp.Diff(tt.want, got))
+			}
+			if more != 0 {
+				t.Errorf("expected a single array, got another with %d points", more)
+			}
+		})
+	}
+}
+
+func TestNewAggregateArrayCursor_WholeRange(t *testing.T) {
+	cur := newAggregateArrayCursor(context.Background(), &datatypes.Aggregate{Type: datatypes.AggregateTypeMax}, newFloatArrayCursor())
+	got := cur.(cursors.FloatArrayCursor).Next()
+	want := &cursors.FloatArray{Timestamps: []int64{22}, Values: []float64{9}}
+	if !cmp.Equal(got, want) {
+		t.Errorf("unexpected result -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+// floatBlockStatsCursor returns each of its arrays in turn, summarizing those
+// that have statistics when asked to.
+type floatBlockStatsCursor struct {
+	floatArrayCursor
+	stats   []bool
+	skipped int
+}
+
+func (c *floatBlockStatsCursor) Next() *cursors.FloatArray {
+	if len(c.stats) > 0 {
+		c.stats = c.stats[1:]
+	}
+	return c.floatArrayCursor.Next()
+}
+
+func (c *floatBlockStatsCursor) PeekBlockStats() (cursors.FloatBlockStats, bool) {
+	if len(c.arrays) == 0 || !c.stats[0] {
+		return cursors.FloatBlockStats{}, false
+	}
+	a := c.arrays[0]
+	s := cursors.FloatBlockStats{
+		FirstTime: a.MinTime(),
+		LastTime:  a.MaxTime(),
+		Count:     int64(a.Len()),
+		Min:       a.Values[0],
+		MinTime:   a.Timestamps[0],
+		Max:       a.Values[0],
+		MaxTime:   a.Timestamps[0],
+	}
+	for i, v := range a.Values {
+		s.Sum += v
+		if v < s.Min {
+			s.Min, s.MinTime = v, a.Timestamps[i]
+		}
+		if v > s.Max {
+			s.Max, s.MaxTime = v, a.Timestamps[i]
+		}
+	}
+	return s, true
+}
+
+func (c *floatBlockStatsCursor) SkipBlock() {
+	c.arrays, c.stats = c.arrays[1:], c.stats[1:]
+	c.skipped++
+}
+
+func newFloatBlockStatsCursor() *floatBlockStatsCursor {
+	// Windows of 10 hold the points at 3; 12, 15 and 18; 21, 22 and 29; 31; and 45.
+	// The blocks at 12-15 and 21-22 start a window, the block at 29 ends one,
+	// and the block at 31-45 spans two windows.
+	return &floatBlockStatsCursor{
+		floatArrayCursor: floatArrayCursor{arrays: []*cursors.FloatArray{
+			{Timestamps: []int64{3}, Values: []float64{4}},
+			{Timestamps: []int64{12, 15}, Values: []float64{1, 7}},
+			{Timestamps: []int64{18}, Values: []float64{2}},
+			{Timestamps: []int64{21, 22}, Values: []float64{3, 9}},
+			{Timestamps: []int64{29}, Values: []float64{3}},
+			{Timestamps: []int64{31, 45}, Values: []float64{5, 1}},
+		}},
+		stats: []bool{false, true, false, true, true, true},
+	}
+}
+
+func TestNewAggregateArrayCursor_BlockStats(t *testing.T) {
+	tests := []struct {
+		agg     datatypes.Aggregate_AggregateType
+		every   int64
+		want    interface{}
+		skipped int
+	}{
+		{
+			agg:     datatypes.AggregateTypeSum,
+			want:    &cursors.FloatArray{Timestamps: []int64{3}, Values: []float64{35}},
+			skipped: 4,
+		},
+		{
+			agg:     datatypes.AggregateTypeCount,
+			want:    &cursors.IntegerArray{Timestamps: []int64{3}, Values: []int64{9}},
+			skipped: 4,
+		},
+		{
+			agg:     datatypes.AggregateTypeSum,
+			every:   10,
+			want:    &cursors.FloatArray{Timestamps: []int64{3, 12, 21, 31, 45}, Values: []float64{4, 10, 15, 5, 1}},
+			skipped: 3,
+		},
+		{
+			agg:     datatypes.AggregateTypeMin,
+			every:   10,
+			want:    &cursors.FloatArray{Timestamps: []int64{3, 12, 21, 31, 45}, Values: []float64{4, 1, 3, 5, 1}},
+			skipped: 3,
+		},
+		{
+			agg:     datatypes.AggregateTypeMax,
+			every:   10,
+			want:    &cursors.FloatArray{Timestamps: []int64{3, 15, 22, 31, 45}, Values: []float64{4, 7, 9, 5, 1}},
+			skipped: 3,
+		},
+		{
+			agg:     datatypes.AggregateTypeMean,
+			every:   10,
+			want:    &cursors.FloatArray{Timestamps: []int64{3, 12, 21, 31, 45}, Values: []float64{4, 10.0 / 3, 5, 5, 1}},
+			skipped: 3,
+		},
+		{
+			agg:     datatypes.AggregateTypeCount,
+			every:   10,
+			want:    &cursors.IntegerArray{Timestamps: []int64{3, 12, 21, 31, 45}, Values: []int64{1, 3, 3, 1, 1}},
+			skipped: 3,
+		},
+		{
+			// selecting the last value cannot use statistics
+			agg:   datatypes.AggregateTypeLast,
+			every: 10,
+			want:  &cursors.FloatArray{Timestamps: []int64{3, 18, 29, 31, 45}, Values: []float64{4, 2, 3, 5, 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s every %d", tt.agg, tt.every), func(t *testing.T) {
+			cur := newFloatBlockStatsCursor()
+			agg := &datatypes.Aggregate{Type: tt.agg, Every: tt.every}
+			var got interface{}
+			switch c := newAggregateArrayCursor(context.Background(), agg, cur).(type) {
+			case cursors.FloatArrayCursor:
+				got = c.Next()
+			case cursors.IntegerArrayCursor:
+				got = c.Next()
+			default:
+				t.Fatalf("unexpected cursor %T", c)
+			}
+			if !cmp.Equal(got, tt.want) {
+				t.Errorf("unexpected result -want/+got:\n%s", cmp.Diff(tt.want, got))
+			}
+			if cur.skipped != tt.skipped {
+				t.Errorf("unexpected blocks skipped: got %d, want %d", cur.skipped, tt.skipped)
+			}
+		})
+	}
+}
@@ -98,17 +98,32 @@ const (
 	AggregateTypeNone  Aggregate_AggregateType = 0
 	AggregateTypeSum   Aggregate_AggregateType = 1
 	AggregateTypeCount Aggregate_AggregateType = 2
+	AggregateTypeMin   Aggregate_AggregateType = 3
+	AggregateTypeMax   Aggregate_AggregateType = 4
+	AggregateTypeFirst Aggregate_AggregateType = 5
+	AggregateTypeLast  Aggregate_AggregateType = 6
+	AggregateTypeMean  Aggregate_AggregateType = 7
 )
 
 var Aggregate_AggregateType_name = map[int32]string{
 	0: "NONE",
 	1: "SUM",
 	2: "COUNT",
+	3: "MIN",
+	4: "MAX",
+	5: "FIRST",
+	6: "LAST",
+	7: "MEAN",
 }
 var Aggregate_AggregateType_value = map[string]int32{
 	"NONE":  0,
 	"SUM":   1,
 	"COUNT": 2,
+	"MIN":   3,
+	"MAX":   4,
+	"FIRST": 5,
+	"LAST":  6,
+	"MEAN":  7,
 }
 
 func (x Aggregate_AggregateType) String() string {
@@ -238,9 +253,14 @@ func (m *ReadRequest) XXX_DiscardUnknown() {
 var xxx_messageInfo_ReadRequest proto.InternalMessageInfo
 
 type Aggregate struct {
-	Type                 Aggregate_AggregateType `protobuf:"varint,1,opt,name=type,proto3,enum=influxdata.platform.storage.Aggregate_AggregateType" json:"type,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	Type Aggregate_AggregateType `protobuf:"varint,1,opt,name=type,proto3,enum=influxdata.platform.storage.Aggregate_AggregateType" json:"type,omitempty"`
+	// Every is the width of the windows, in nanoseconds, that each series is aggregated over.
+	// Specify 0 to aggregate each series over the entire time range.
+	Every int64 `protobuf:"varint,2,opt,name=every,proto3" json:"every,omitempty"`
+	// Offset shifts the boundaries of the windows, in nanoseconds.
+	Offset               int64    `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Aggregate) Reset()         { *m = Aggregate{} }
@@ -1066,6 +1086,144 @@ func (m *TimestampRange) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_TimestampRange proto.InternalMessageInfo
 
+// Request message for Storage.TagKeys.
+type TagKeysRequest struct {
+	ReadSource *types.Any `protobuf:"bytes,1,opt,name=read_source,json=readSource" json:"read_source,omitempty"`
+	// TimestampRange limits the keys to series with points in the range,
+	// unless Hints includes HintSchemaAllTime.
+	TimestampRange TimestampRange `protobuf:"bytes,2,opt,name=timestamp_range,json=timestampRange" json:"timestamp_range"`
+	Predicate      *Predicate     `protobuf:"bytes,3,opt,name=predicate" json:"predicate,omitempty"`
+	// Hints is a bitwise OR of ReadRequest.HintFlags.
+	Hints HintFlags `protobuf:"fixed32,4,opt,name=hints,proto3,casttype=HintFlags" json:"hints,omitempty"`
+	// Trace contains opaque data if a trace is active.
+	Trace                map[string]string `protobuf:"bytes,5,rep,name=trace" json:"trace,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TagKeysRequest) Reset()         { *m = TagKeysRequest{} }
+func (m *TagKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*TagKeysRequest) ProtoMessage()    {}
+func (*TagKeysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{7}
+}
+func (m *TagKeysRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TagKeysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TagKeysRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *TagKeysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TagKeysRequest.Merge(dst, src)
+}
+func (m *TagKeysRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *TagKeysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TagKeysRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TagKeysRequest proto.InternalMessageInfo
+
+// Request message for Storage.TagValues.
+type TagValuesRequest struct {
+	ReadSource *types.Any `protobuf:"bytes,1,opt,name=read_source,json=readSource" json:"read_source,omitempty"`
+	// TimestampRange limits the values to series with points in the range,
+	// unless Hints includes HintSchemaAllTime.
+	TimestampRange TimestampRange `protobuf:"bytes,2,opt,name=timestamp_range,json=timestampRange" json:"timestamp_range"`
+	Predicate      *Predicate     `protobuf:"bytes,3,opt,name=predicate" json:"predicate,omitempty"`
+	// Hints is a bitwise OR of ReadRequest.HintFlags.
+	Hints HintFlags `protobuf:"fixed32,4,opt,name=hints,proto3,casttype=HintFlags" json:"hints,omitempty"`
+	// Trace contains opaque data if a trace is active.
+	Trace map[string]string `protobuf:"bytes,5,rep,name=trace" json:"trace,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// TagKey is the key whose values are returned.
+	TagKey               string   `protobuf:"bytes,6,opt,name=tag_key,json=tagKey,proto3" json:"tag_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TagValuesRequest) Reset()         { *m = TagValuesRequest{} }
+func (m *TagValuesRequest) String() string { return proto.CompactTextString(m) }
+func (*TagValuesRequest) ProtoMessage()    {}
+func (*TagValuesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{8}
+}
+func (m *TagValuesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TagValuesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TagValuesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *TagValuesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TagValuesRequest.Merge(dst, src)
+}
+func (m *TagValuesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *TagValuesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TagValuesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TagValuesRequest proto.InternalMessageInfo
+
+// Response message for Storage.TagKeys and Storage.TagValues.
+type StringValuesResponse struct {
+	Values               [][]byte `protobuf:"bytes,1,rep,name=values" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StringValuesResponse) Reset()         { *m = StringValuesResponse{} }
+func (m *StringValuesResponse) String() string { return proto.CompactTextString(m) }
+func (*StringValuesResponse) ProtoMessage()    {}
+func (*StringValuesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_storage_common_01b6ac29b3fb8162, []int{9}
+}
+func (m *StringValuesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StringValuesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StringValuesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *StringValuesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StringValuesResponse.Merge(dst, src)
+}
+func (m *StringValuesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *StringValuesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StringValuesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StringValuesResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*ReadRequest)(nil), "influxdata.platform.storage.ReadRequest")
 	proto.RegisterMapType((map[string]string)(nil), "influxdata.platform.storage.ReadRequest.TraceEntry")
@@ -1084,6 +1242,11 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "influxdata.platform.storage.CapabilitiesResponse.CapsEntry")
 	proto.RegisterType((*HintsResponse)(nil), "influxdata.platform.storage.HintsResponse")
 	proto.RegisterType((*TimestampRange)(nil), "influxdata.platform.storage.TimestampRange")
+	proto.RegisterType((*TagKeysRequest)(nil), "influxdata.platform.storage.TagKeysRequest")
+	proto.RegisterMapType((map[string]string)(nil), "influxdata.platform.storage.TagKeysRequest.TraceEntry")
+	proto.RegisterType((*TagValuesRequest)(nil), "influxdata.platform.storage.TagValuesRequest")
+	proto.RegisterMapType((map[string]string)(nil), "influxdata.platform.storage.TagValuesRequest.TraceEntry")
+	proto.RegisterType((*StringValuesResponse)(nil), "influxdata.platform.storage.StringValuesResponse")
 	proto.RegisterEnum("influxdata.platform.storage.ReadRequest_Group", ReadRequest_Group_name, ReadRequest_Group_value)
 	proto.RegisterEnum("influxdata.platform.storage.ReadRequest_HintFlags", ReadRequest_HintFlags_name, ReadRequest_HintFlags_value)
 	proto.RegisterEnum("influxdata.platform.storage.Aggregate_AggregateType", Aggregate_AggregateType_name, Aggregate_AggregateType_value)
@@ -1107,6 +1270,10 @@ type StorageClient interface {
 	// Capabilities returns a map of keys and values identifying the capabilities supported by the storage engine
 	Capabilities(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*CapabilitiesResponse, error)
 	Hints(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*HintsResponse, error)
+	// TagKeys returns the sorted tag keys of the series matching the given TagKeysRequest.
+	TagKeys(ctx context.Context, in *TagKeysRequest, opts ...grpc.CallOption) (Storage_TagKeysClient, error)
+	// TagValues returns the sorted values of a tag key of the series matching the given TagValuesRequest.
+	TagValues(ctx context.Context, in *TagValuesRequest, opts ...grpc.CallOption) (Storage_TagValuesClient, error)
 }
 
 type storageClient struct {
@@ -1167,6 +1334,70 @@ func (c *storageClient) Hints(ctx context.Context, in *types.Empty, opts ...grpc
 	return out, nil
 }
 
+func (c *storageClient) TagKeys(ctx context.Context, in *TagKeysRequest, opts ...grpc.CallOption) (Storage_TagKeysClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[1], "/influxdata.platform.storage.Storage/TagKeys", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageTagKeysClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Storage_TagKeysClient interface {
+	Recv() (*StringValuesResponse, error)
+	grpc.ClientStream
+}
+
+type storageTagKeysClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageTagKeysClient) Recv() (*StringValuesResponse, error) {
+	m := new(StringValuesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageClient) TagValues(ctx context.Context, in *TagValuesRequest, opts ...grpc.CallOption) (Storage_TagValuesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[2], "/influxdata.platform.storage.Storage/TagValues", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageTagValuesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Storage_TagValuesClient interface {
+	Recv() (*StringValuesResponse, error)
+	grpc.ClientStream
+}
+
+type storageTagValuesClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageTagValuesClient) Recv() (*StringValuesResponse, error) {
+	m := new(StringValuesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Storage service
 
 type StorageServer interface {
@@ -1175,6 +1406,10 @@ type StorageServer interface {
 	// Capabilities returns a map of keys and values identifying the capabilities supported by the storage engine
 	Capabilities(context.Context, *types.Empty) (*CapabilitiesResponse, error)
 	Hints(context.Context, *types.Empty) (*HintsResponse, error)
+	// TagKeys returns the sorted tag keys of the series matching the given TagKeysRequest.
+	TagKeys(*TagKeysRequest, Storage_TagKeysServer) error
+	// TagValues returns the sorted values of a tag key of the series matching the given TagValuesRequest.
+	TagValues(*TagValuesRequest, Storage_TagValuesServer) error
 }
 
 func RegisterStorageServer(s *grpc.Server, srv StorageServer) {
@@ -1238,6 +1473,48 @@ func _Storage_Hints_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Storage_TagKeys_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TagKeysRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServer).TagKeys(m, &storageTagKeysServer{stream})
+}
+
+type Storage_TagKeysServer interface {
+	Send(*StringValuesResponse) error
+	grpc.ServerStream
+}
+
+type storageTagKeysServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageTagKeysServer) Send(m *StringValuesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Storage_TagValues_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TagValuesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServer).TagValues(m, &storageTagValuesServer{stream})
+}
+
+type Storage_TagValuesServer interface {
+	Send(*StringValuesResponse) error
+	grpc.ServerStream
+}
+
+type storageTagValuesServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageTagValuesServer) Send(m *StringValuesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Storage_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "influxdata.platform.storage.Storage",
 	HandlerType: (*StorageServer)(nil),
@@ -1257,6 +1534,16 @@ var _Storage_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Storage_Read_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "TagKeys",
+			Handler:       _Storage_TagKeys_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TagValues",
+			Handler:       _Storage_TagValues_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "storage_common.proto",
 }
@@ -1405,6 +1692,16 @@ func (m *Aggregate) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintStorageCommon(dAtA, i, uint64(m.Type))
 	}
+	if m.Every != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.Every))
+	}
+	if m.Offset != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.Offset))
+	}
 	return i, nil
 }
 
@@ -1949,73 +2246,249 @@ func (m *TimestampRange) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func encodeVarintStorageCommon(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *TagKeysRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *ReadRequest) Size() (n int) {
+
+func (m *TagKeysRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.TimestampRange.Size()
-	n += 1 + l + sovStorageCommon(uint64(l))
-	if m.Descending {
-		n += 2
-	}
-	if len(m.GroupKeys) > 0 {
-		for _, s := range m.GroupKeys {
-			l = len(s)
-			n += 1 + l + sovStorageCommon(uint64(l))
+	if m.ReadSource != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.ReadSource.Size()))
+		n18, err := m.ReadSource.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n18
 	}
-	if m.Predicate != nil {
-		l = m.Predicate.Size()
-		n += 1 + l + sovStorageCommon(uint64(l))
-	}
-	if m.SeriesLimit != 0 {
-		n += 1 + sovStorageCommon(uint64(m.SeriesLimit))
-	}
-	if m.SeriesOffset != 0 {
-		n += 1 + sovStorageCommon(uint64(m.SeriesOffset))
-	}
-	if m.PointsLimit != 0 {
-		n += 1 + sovStorageCommon(uint64(m.PointsLimit))
-	}
-	if m.Aggregate != nil {
-		l = m.Aggregate.Size()
-		n += 1 + l + sovStorageCommon(uint64(l))
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintStorageCommon(dAtA, i, uint64(m.TimestampRange.Size()))
+	n19, err := m.TimestampRange.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if len(m.Trace) > 0 {
-		for k, v := range m.Trace {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovStorageCommon(uint64(len(k))) + 1 + len(v) + sovStorageCommon(uint64(len(v)))
-			n += mapEntrySize + 1 + sovStorageCommon(uint64(mapEntrySize))
+	i += n19
+	if m.Predicate != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.Predicate.Size()))
+		n20, err := m.Predicate.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-	}
-	if m.Group != 0 {
-		n += 1 + sovStorageCommon(uint64(m.Group))
+		i += n20
 	}
 	if m.Hints != 0 {
-		n += 5
+		dAtA[i] = 0x25
+		i++
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.Hints))
+		i += 4
 	}
-	if m.ReadSource != nil {
-		l = m.ReadSource.Size()
-		n += 1 + l + sovStorageCommon(uint64(l))
+	if len(m.Trace) > 0 {
+		for k, _ := range m.Trace {
+			dAtA[i] = 0x2a
+			i++
+			v := m.Trace[k]
+			mapSize := 1 + len(k) + sovStorageCommon(uint64(len(k))) + 1 + len(v) + sovStorageCommon(uint64(len(v)))
+			i = encodeVarintStorageCommon(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintStorageCommon(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintStorageCommon(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
 	}
-	return n
+	return i, nil
 }
 
-func (m *Aggregate) Size() (n int) {
+func (m *TagValuesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TagValuesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ReadSource != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.ReadSource.Size()))
+		n21, err := m.ReadSource.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n21
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintStorageCommon(dAtA, i, uint64(m.TimestampRange.Size()))
+	n22, err := m.TimestampRange.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n22
+	if m.Predicate != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.Predicate.Size()))
+		n23, err := m.Predicate.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n23
+	}
+	if m.Hints != 0 {
+		dAtA[i] = 0x25
+		i++
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.Hints))
+		i += 4
+	}
+	if len(m.Trace) > 0 {
+		for k, _ := range m.Trace {
+			dAtA[i] = 0x2a
+			i++
+			v := m.Trace[k]
+			mapSize := 1 + len(k) + sovStorageCommon(uint64(len(k))) + 1 + len(v) + sovStorageCommon(uint64(len(v)))
+			i = encodeVarintStorageCommon(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintStorageCommon(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintStorageCommon(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if len(m.TagKey) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(len(m.TagKey)))
+		i += copy(dAtA[i:], m.TagKey)
+	}
+	return i, nil
+}
+
+func (m *StringValuesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StringValuesResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		for _, b := range m.Values {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintStorageCommon(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	return i, nil
+}
+
+func encodeVarintStorageCommon(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *ReadRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.TimestampRange.Size()
+	n += 1 + l + sovStorageCommon(uint64(l))
+	if m.Descending {
+		n += 2
+	}
+	if len(m.GroupKeys) > 0 {
+		for _, s := range m.GroupKeys {
+			l = len(s)
+			n += 1 + l + sovStorageCommon(uint64(l))
+		}
+	}
+	if m.Predicate != nil {
+		l = m.Predicate.Size()
+		n += 1 + l + sovStorageCommon(uint64(l))
+	}
+	if m.SeriesLimit != 0 {
+		n += 1 + sovStorageCommon(uint64(m.SeriesLimit))
+	}
+	if m.SeriesOffset != 0 {
+		n += 1 + sovStorageCommon(uint64(m.SeriesOffset))
+	}
+	if m.PointsLimit != 0 {
+		n += 1 + sovStorageCommon(uint64(m.PointsLimit))
+	}
+	if m.Aggregate != nil {
+		l = m.Aggregate.Size()
+		n += 1 + l + sovStorageCommon(uint64(l))
+	}
+	if len(m.Trace) > 0 {
+		for k, v := range m.Trace {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovStorageCommon(uint64(len(k))) + 1 + len(v) + sovStorageCommon(uint64(len(v)))
+			n += mapEntrySize + 1 + sovStorageCommon(uint64(mapEntrySize))
+		}
+	}
+	if m.Group != 0 {
+		n += 1 + sovStorageCommon(uint64(m.Group))
+	}
+	if m.Hints != 0 {
+		n += 5
+	}
+	if m.ReadSource != nil {
+		l = m.ReadSource.Size()
+		n += 1 + l + sovStorageCommon(uint64(l))
+	}
+	return n
+}
+
+func (m *Aggregate) Size() (n int) {
 	var l int
 	_ = l
 	if m.Type != 0 {
 		n += 1 + sovStorageCommon(uint64(m.Type))
 	}
+	if m.Every != 0 {
+		n += 1 + sovStorageCommon(uint64(m.Every))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovStorageCommon(uint64(m.Offset))
+	}
 	return n
 }
 
@@ -2253,6 +2726,76 @@ func (m *TimestampRange) Size() (n int) {
 	return n
 }
 
+func (m *TagKeysRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.ReadSource != nil {
+		l = m.ReadSource.Size()
+		n += 1 + l + sovStorageCommon(uint64(l))
+	}
+	l = m.TimestampRange.Size()
+	n += 1 + l + sovStorageCommon(uint64(l))
+	if m.Predicate != nil {
+		l = m.Predicate.Size()
+		n += 1 + l + sovStorageCommon(uint64(l))
+	}
+	if m.Hints != 0 {
+		n += 5
+	}
+	if len(m.Trace) > 0 {
+		for k, v := range m.Trace {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovStorageCommon(uint64(len(k))) + 1 + len(v) + sovStorageCommon(uint64(len(v)))
+			n += mapEntrySize + 1 + sovStorageCommon(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *TagValuesRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.ReadSource != nil {
+		l = m.ReadSource.Size()
+		n += 1 + l + sovStorageCommon(uint64(l))
+	}
+	l = m.TimestampRange.Size()
+	n += 1 + l + sovStorageCommon(uint64(l))
+	if m.Predicate != nil {
+		l = m.Predicate.Size()
+		n += 1 + l + sovStorageCommon(uint64(l))
+	}
+	if m.Hints != 0 {
+		n += 5
+	}
+	if len(m.Trace) > 0 {
+		for k, v := range m.Trace {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovStorageCommon(uint64(len(k))) + 1 + len(v) + sovStorageCommon(uint64(len(v)))
+			n += mapEntrySize + 1 + sovStorageCommon(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.TagKey)
+	if l > 0 {
+		n += 1 + l + sovStorageCommon(uint64(l))
+	}
+	return n
+}
+
+func (m *StringValuesResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		for _, b := range m.Values {
+			l = len(b)
+			n += 1 + l + sovStorageCommon(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovStorageCommon(x uint64) (n int) {
 	for {
 		n++
@@ -2746,6 +3289,44 @@ func (m *Aggregate) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Every", wireType)
+			}
+			m.Every = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Every |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStorageCommon(dAtA[iNdEx:])
@@ -4479,6 +5060,662 @@ func (m *TimestampRange) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *TagKeysRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorageCommon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TagKeysRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TagKeysRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadSource", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReadSource == nil {
+				m.ReadSource = &types.Any{}
+			}
+			if err := m.ReadSource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimestampRange", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.TimestampRange.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Predicate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Predicate == nil {
+				m.Predicate = &Predicate{}
+			}
+			if err := m.Predicate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hints", wireType)
+			}
+			m.Hints = 0
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hints = HintFlags(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trace", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Trace == nil {
+				m.Trace = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowStorageCommon
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowStorageCommon
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthStorageCommon
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowStorageCommon
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthStorageCommon
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipStorageCommon(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthStorageCommon
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Trace[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorageCommon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TagValuesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorageCommon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TagValuesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TagValuesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadSource", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReadSource == nil {
+				m.ReadSource = &types.Any{}
+			}
+			if err := m.ReadSource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimestampRange", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.TimestampRange.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Predicate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Predicate == nil {
+				m.Predicate = &Predicate{}
+			}
+			if err := m.Predicate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hints", wireType)
+			}
+			m.Hints = 0
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hints = HintFlags(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trace", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Trace == nil {
+				m.Trace = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowStorageCommon
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowStorageCommon
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthStorageCommon
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowStorageCommon
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthStorageCommon
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipStorageCommon(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthStorageCommon
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Trace[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TagKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TagKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorageCommon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StringValuesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorageCommon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StringValuesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StringValuesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Values = append(m.Values, make([]byte, postIndex-iNdEx))
+			copy(m.Values[len(m.Values)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorageCommon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipStorageCommon(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -4589,102 +5826,116 @@ func init() {
 }
 
 var fileDescriptor_storage_common_01b6ac29b3fb8162 = []byte{
-	// 1539 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x57, 0x5f, 0x8f, 0x2a, 0x49,
-	0x15, 0xa7, 0xf9, 0xcf, 0xe1, 0xcf, 0xf4, 0xad, 0xc5, 0x09, 0xdb, 0xd7, 0x85, 0x5e, 0x62, 0x36,
-	0xa8, 0x2b, 0xa3, 0xec, 0x6e, 0xbc, 0xb9, 0xea, 0x03, 0xcc, 0x70, 0x07, 0x9c, 0x19, 0x98, 0x14,
-	0x8c, 0x71, 0x4d, 0x0c, 0xa9, 0x81, 0xa2, 0xb7, 0xb3, 0x4d, 0x77, 0xdb, 0xdd, 0x6c, 0x86, 0xc4,
-	0x77, 0x37, 0x3c, 0x5d, 0x5f, 0x4d, 0x48, 0x4c, 0x7c, 0xf4, 0xdd, 0xcf, 0x70, 0xdf, 0xf4, 0x13,
-	0x10, 0xc5, 0x0f, 0x61, 0xe2, 0x93, 0xa9, 0xaa, 0x6e, 0x68, 0x66, 0xae, 0x13, 0x78, 0xab, 0x3a,
-	0x7f, 0x7e, 0xbf, 0x53, 0xa7, 0xcf, 0x39, 0x55, 0x0d, 0x45, 0xd7, 0xb3, 0x1c, 0xa2, 0xd1, 0xd1,
-	0xd8, 0x9a, 0xcd, 0x2c, 0xb3, 0x6e, 0x3b, 0x96, 0x67, 0xa1, 0x97, 0xba, 0x39, 0x35, 0xe6, 0x0f,
-	0x13, 0xe2, 0x91, 0xba, 0x6d, 0x10, 0x6f, 0x6a, 0x39, 0xb3, 0xba, 0x6f, 0xa9, 0x14, 0x35, 0x4b,
-	0xb3, 0xb8, 0xdd, 0x19, 0x5b, 0x09, 0x17, 0xe5, 0xa5, 0x66, 0x59, 0x9a, 0x41, 0xcf, 0xf8, 0xee,
-	0x7e, 0x3e, 0x3d, 0xa3, 0x33, 0xdb, 0x5b, 0xf8, 0xca, 0x0f, 0x1f, 0x2b, 0x89, 0x19, 0xa8, 0x4e,
-	0x6c, 0x87, 0x4e, 0xf4, 0x31, 0xf1, 0xa8, 0x10, 0x54, 0xff, 0x93, 0x86, 0x2c, 0xa6, 0x64, 0x82,
-	0xe9, 0xef, 0xe6, 0xd4, 0xf5, 0x90, 0x01, 0x27, 0x9e, 0x3e, 0xa3, 0xae, 0x47, 0x66, 0xf6, 0xc8,
-	0x21, 0xa6, 0x46, 0x4b, 0x51, 0x55, 0xaa, 0x65, 0x1b, 0x3f, 0xac, 0x3f, 0x13, 0x65, 0x7d, 0x18,
-	0xf8, 0x60, 0xe6, 0xd2, 0x3a, 0x7d, 0xb7, 0xae, 0x44, 0x36, 0xeb, 0x4a, 0x61, 0x5f, 0x8e, 0x0b,
-	0xde, 0xde, 0x1e, 0x95, 0x01, 0x26, 0xd4, 0x1d, 0x53, 0x73, 0xa2, 0x9b, 0x5a, 0x29, 0xa6, 0x4a,
-	0xb5, 0x34, 0x0e, 0x49, 0xd0, 0xa7, 0x00, 0x9a, 0x63, 0xcd, 0xed, 0xd1, 0xd7, 0x74, 0xe1, 0x96,
-	0xe2, 0x6a, 0xac, 0x96, 0x69, 0xe5, 0x37, 0xeb, 0x4a, 0xe6, 0x92, 0x49, 0xaf, 0xe8, 0xc2, 0xc5,
-	0x19, 0x2d, 0x58, 0xa2, 0x0b, 0xc8, 0x6c, 0x8f, 0x57, 0x4a, 0xf0, 0xa8, 0x3f, 0x79, 0x36, 0xea,
-	0xdb, 0xc0, 0x1a, 0xef, 0x1c, 0x51, 0x03, 0x72, 0x2e, 0x75, 0x74, 0xea, 0x8e, 0x0c, 0x7d, 0xa6,
-	0x7b, 0xa5, 0xa4, 0x2a, 0xd5, 0x62, 0xad, 0x93, 0xcd, 0xba, 0x92, 0x1d, 0x70, 0xf9, 0x35, 0x13,
-	0xe3, 0xac, 0xbb, 0xdb, 0xa0, 0x2f, 0x20, 0xef, 0xfb, 0x58, 0xd3, 0xa9, 0x4b, 0xbd, 0x52, 0x8a,
-	0x3b, 0xc9, 0x9b, 0x75, 0x25, 0x27, 0x9c, 0xfa, 0x5c, 0x8e, 0x7d, 0x68, 0xb1, 0x63, 0x54, 0xb6,
-	0xa5, 0x9b, 0x5e, 0x40, 0x95, 0xde, 0x51, 0xdd, 0x72, 0xb9, 0x4f, 0x65, 0xef, 0x36, 0xec, 0x90,
-	0x44, 0xd3, 0x1c, 0xaa, 0xb1, 0x43, 0x66, 0x0e, 0x38, 0x64, 0x33, 0xb0, 0xc6, 0x3b, 0x47, 0x34,
-	0x84, 0x84, 0xe7, 0x90, 0x31, 0x2d, 0x81, 0x1a, 0xab, 0x65, 0x1b, 0x9f, 0x3d, 0x8b, 0x10, 0xaa,
-	0x8f, 0xfa, 0x90, 0x79, 0xb5, 0x4d, 0xcf, 0x59, 0xb4, 0x32, 0x9b, 0x75, 0x25, 0xc1, 0xf7, 0x58,
-	0x80, 0xa1, 0x0b, 0x48, 0xf0, 0xaf, 0x51, 0xca, 0xaa, 0x52, 0xad, 0xd0, 0xa8, 0x1f, 0x8c, 0xca,
-	0x3f, 0x27, 0x16, 0xce, 0xe8, 0x53, 0x48, 0x7c, 0xc5, 0xce, 0x5b, 0xca, 0xa9, 0x52, 0x2d, 0xd5,
-	0x3a, 0x65, 0x34, 0x1d, 0x26, 0xf8, 0xef, 0xba, 0x92, 0x61, 0x8b, 0x37, 0x06, 0xd1, 0x5c, 0x2c,
-	0x8c, 0x50, 0x1b, 0xb2, 0x0e, 0x25, 0x93, 0x91, 0x6b, 0xcd, 0x9d, 0x31, 0x2d, 0xe5, 0x79, 0x46,
-	0x8a, 0x75, 0xd1, 0x02, 0xf5, 0xa0, 0x05, 0xea, 0x4d, 0x73, 0xd1, 0x2a, 0x6c, 0xd6, 0x15, 0x60,
-	0xb4, 0x03, 0x6e, 0x8b, 0xc1, 0xd9, 0xae, 0x95, 0x57, 0x00, 0xbb, 0xa3, 0x21, 0x19, 0x62, 0x5f,
-	0xd3, 0x45, 0x49, 0x52, 0xa5, 0x5a, 0x06, 0xb3, 0x25, 0x2a, 0x42, 0xe2, 0x1b, 0x62, 0xcc, 0x45,
-	0x37, 0x64, 0xb0, 0xd8, 0xbc, 0x8e, 0xbe, 0x92, 0xaa, 0x7f, 0x90, 0x20, 0xc1, 0xe3, 0x47, 0x1f,
-	0x01, 0x5c, 0xe2, 0xfe, 0xdd, 0xed, 0xa8, 0xd7, 0xef, 0xb5, 0xe5, 0x88, 0x92, 0x5f, 0xae, 0x54,
-	0x51, 0xa9, 0x3d, 0xcb, 0xa4, 0xe8, 0x25, 0x64, 0x84, 0xba, 0x79, 0x7d, 0x2d, 0x4b, 0x4a, 0x6e,
-	0xb9, 0x52, 0xd3, 0x5c, 0xdb, 0x34, 0x0c, 0xf4, 0x21, 0xa4, 0x85, 0xb2, 0xf5, 0xa5, 0x1c, 0x55,
-	0xb2, 0xcb, 0x95, 0x9a, 0xe2, 0xba, 0xd6, 0x02, 0x7d, 0x0c, 0x39, 0xa1, 0x6a, 0xff, 0xfa, 0xbc,
-	0x7d, 0x3b, 0x94, 0x63, 0xca, 0xc9, 0x72, 0xa5, 0x66, 0xb9, 0xba, 0xfd, 0x30, 0xa6, 0xb6, 0xa7,
-	0xc4, 0xbf, 0xfd, 0x4b, 0x39, 0x52, 0xfd, 0xab, 0x04, 0xbb, 0xfc, 0x30, 0xba, 0x4e, 0xb7, 0x37,
-	0x0c, 0x82, 0xe1, 0x74, 0x4c, 0xcb, 0x63, 0xf9, 0x1e, 0x14, 0x7c, 0xe5, 0xe8, 0xb6, 0xdf, 0xed,
-	0x0d, 0x07, 0xb2, 0xa4, 0xc8, 0xcb, 0x95, 0x9a, 0x13, 0x16, 0xa2, 0xfa, 0xc2, 0x56, 0x83, 0x36,
-	0xee, 0xb6, 0x07, 0x72, 0x34, 0x6c, 0x25, 0x2a, 0x1b, 0x9d, 0x41, 0x91, 0x5b, 0x0d, 0xce, 0x3b,
-	0xed, 0x9b, 0x26, 0x3b, 0xdd, 0x68, 0xd8, 0xbd, 0x69, 0xcb, 0x71, 0xe5, 0x3b, 0xcb, 0x95, 0xfa,
-	0x82, 0xd9, 0x0e, 0xc6, 0x5f, 0xd1, 0x19, 0x69, 0x1a, 0x06, 0x9b, 0x07, 0x7e, 0xb4, 0x7f, 0x97,
-	0x20, 0xb3, 0xad, 0x4d, 0xd4, 0x81, 0xb8, 0xb7, 0xb0, 0x29, 0x4f, 0x79, 0xa1, 0xf1, 0xf9, 0x61,
-	0x15, 0xbd, 0x5b, 0x0d, 0x17, 0x36, 0xc5, 0x1c, 0xa1, 0xfa, 0x00, 0xf9, 0x3d, 0x31, 0xaa, 0x40,
-	0xdc, 0xcf, 0x01, 0x8f, 0x67, 0x4f, 0xc9, 0x93, 0xf1, 0x11, 0xc4, 0x06, 0x77, 0x37, 0xb2, 0xa4,
-	0x14, 0x97, 0x2b, 0x55, 0xde, 0xd3, 0x0f, 0xe6, 0x33, 0xf4, 0x31, 0x24, 0xce, 0xfb, 0x77, 0xbd,
-	0xa1, 0x1c, 0x55, 0x4e, 0x97, 0x2b, 0x15, 0xed, 0x19, 0x9c, 0x5b, 0x73, 0x33, 0xc8, 0xff, 0x8f,
-	0x20, 0x36, 0x24, 0x5a, 0xb8, 0x78, 0x72, 0xef, 0x29, 0x9e, 0x9c, 0x5f, 0x3c, 0xd5, 0x3f, 0x16,
-	0x20, 0x27, 0x9a, 0xc0, 0xb5, 0x2d, 0xd3, 0xa5, 0xe8, 0x06, 0x92, 0x53, 0x87, 0xcc, 0xa8, 0x5b,
-	0x92, 0x78, 0x57, 0x9e, 0x1d, 0xd0, 0x3f, 0xc2, 0xb5, 0xfe, 0x86, 0xf9, 0xb5, 0xe2, 0x6c, 0xec,
-	0x62, 0x1f, 0x44, 0xf9, 0x36, 0x09, 0x09, 0x2e, 0x47, 0x7d, 0x48, 0x8a, 0xb9, 0xc3, 0x83, 0xca,
-	0x36, 0xbe, 0x38, 0x1c, 0x58, 0x7c, 0x63, 0x0e, 0xd3, 0x89, 0x60, 0x1f, 0x06, 0xd9, 0x90, 0x9b,
-	0x1a, 0x16, 0xf1, 0x46, 0x62, 0x32, 0xf9, 0x57, 0xc4, 0xeb, 0x23, 0xe2, 0x65, 0xde, 0xa2, 0xca,
-	0x44, 0xe8, 0x7c, 0xe8, 0x85, 0xa4, 0x9d, 0x08, 0xce, 0x4e, 0x77, 0x5b, 0xf4, 0x00, 0x05, 0xdd,
-	0xf4, 0xa8, 0x46, 0x9d, 0x80, 0x33, 0xc6, 0x39, 0x7f, 0x7e, 0x38, 0x67, 0x57, 0xf8, 0x87, 0x59,
-	0x5f, 0x6c, 0xd6, 0x95, 0xfc, 0x9e, 0xbc, 0x13, 0xc1, 0x79, 0x3d, 0x2c, 0x40, 0xbf, 0x87, 0x93,
-	0xb9, 0xe9, 0xea, 0x9a, 0x49, 0x27, 0x01, 0x75, 0x9c, 0x53, 0xff, 0xe2, 0x70, 0xea, 0x3b, 0x1f,
-	0x20, 0xcc, 0x8d, 0xd8, 0xfd, 0xb8, 0xaf, 0xe8, 0x44, 0x70, 0x61, 0xbe, 0x27, 0x61, 0xe7, 0xbe,
-	0xb7, 0x2c, 0x83, 0x12, 0x33, 0x20, 0x4f, 0x1c, 0x7b, 0xee, 0x96, 0xf0, 0x7f, 0x72, 0xee, 0x3d,
-	0x39, 0x3b, 0xf7, 0x7d, 0x58, 0x80, 0x3c, 0xc8, 0xbb, 0x9e, 0xa3, 0x9b, 0x5a, 0x40, 0x9c, 0xe4,
-	0xc4, 0x3f, 0x3b, 0xa2, 0x76, 0xb8, 0x7b, 0x98, 0x57, 0x5c, 0x88, 0x21, 0x71, 0x27, 0x82, 0x73,
-	0x6e, 0x68, 0x8f, 0xae, 0x83, 0x2b, 0x24, 0xc5, 0xd9, 0x3e, 0x3f, 0x9c, 0x8d, 0xcf, 0xc3, 0xa0,
-	0x50, 0x05, 0x48, 0x2b, 0x09, 0x71, 0xe6, 0xa9, 0x3c, 0x00, 0xec, 0xd4, 0xe8, 0x13, 0x48, 0x7b,
-	0x44, 0x13, 0x6f, 0x0a, 0xd6, 0x69, 0xb9, 0x56, 0x76, 0xb3, 0xae, 0xa4, 0x86, 0x44, 0xe3, 0x2f,
-	0x8a, 0x94, 0x27, 0x16, 0xa8, 0x05, 0xc8, 0x26, 0x8e, 0xa7, 0x7b, 0xba, 0x65, 0x32, 0xeb, 0xd1,
-	0x37, 0xc4, 0x60, 0xb5, 0xce, 0x3c, 0x8a, 0x9b, 0x75, 0x45, 0xbe, 0x0d, 0xb4, 0x57, 0x74, 0xf1,
-	0x2b, 0x62, 0xb8, 0x58, 0xb6, 0x1f, 0x49, 0x94, 0x3f, 0x49, 0x90, 0x0d, 0xf5, 0x10, 0x7a, 0x0d,
-	0x71, 0x8f, 0x68, 0x41, 0x87, 0xab, 0xcf, 0x3f, 0xaa, 0x88, 0xe6, 0xb7, 0x34, 0xf7, 0x41, 0x7d,
-	0xc8, 0x30, 0xc3, 0x11, 0x1f, 0x94, 0x51, 0x3e, 0x28, 0x1b, 0x87, 0xe7, 0xe7, 0x82, 0x78, 0x84,
-	0x8f, 0xc9, 0xf4, 0xc4, 0x5f, 0x29, 0xbf, 0x04, 0xf9, 0x71, 0x23, 0xb2, 0x27, 0xd9, 0xf6, 0x91,
-	0x26, 0xc2, 0x94, 0x71, 0x48, 0x82, 0x4e, 0x21, 0xc9, 0xc7, 0x97, 0x48, 0x84, 0x84, 0xfd, 0x9d,
-	0x72, 0x0d, 0xe8, 0x69, 0x83, 0x1d, 0x89, 0x16, 0xdb, 0xa2, 0xdd, 0xc0, 0x07, 0xef, 0xe9, 0x99,
-	0x23, 0xe1, 0xe2, 0xe1, 0xe0, 0x9e, 0x76, 0xc1, 0x91, 0x68, 0xe9, 0x2d, 0xda, 0x15, 0xbc, 0x78,
-	0x52, 0xda, 0x47, 0x82, 0x65, 0x02, 0xb0, 0xea, 0x00, 0x32, 0x1c, 0xc0, 0xbf, 0xaa, 0x92, 0xfe,
-	0x45, 0x1b, 0x51, 0x3e, 0x58, 0xae, 0xd4, 0x93, 0xad, 0xca, 0xbf, 0x6b, 0x2b, 0x90, 0xdc, 0xde,
-	0xd7, 0xfb, 0x06, 0x22, 0x16, 0xff, 0x26, 0xfa, 0x9b, 0x04, 0xe9, 0xe0, 0x7b, 0xa3, 0xef, 0x42,
-	0xe2, 0xcd, 0x75, 0xbf, 0x39, 0x94, 0x23, 0xca, 0x8b, 0xe5, 0x4a, 0xcd, 0x07, 0x0a, 0xfe, 0xe9,
-	0x91, 0x0a, 0xa9, 0x6e, 0x6f, 0xd8, 0xbe, 0x6c, 0xe3, 0x00, 0x32, 0xd0, 0xfb, 0x9f, 0x13, 0x55,
-	0x21, 0x7d, 0xd7, 0x1b, 0x74, 0x2f, 0x7b, 0xed, 0x0b, 0x39, 0x2a, 0xee, 0xc8, 0xc0, 0x24, 0xf8,
-	0x46, 0x0c, 0xa5, 0xd5, 0xef, 0x5f, 0xb7, 0x9b, 0x3d, 0x39, 0xb6, 0x8f, 0xe2, 0xe7, 0x1d, 0x95,
-	0x21, 0x39, 0x18, 0xe2, 0x6e, 0xef, 0x52, 0x8e, 0x2b, 0x68, 0xb9, 0x52, 0x0b, 0x81, 0x81, 0x48,
-	0xa5, 0x1f, 0xf8, 0x9f, 0x25, 0x28, 0x9e, 0x13, 0x9b, 0xdc, 0xeb, 0x86, 0xee, 0xe9, 0xd4, 0xdd,
-	0xde, 0x8d, 0x7d, 0x88, 0x8f, 0x89, 0x1d, 0xf4, 0xcd, 0xf3, 0x43, 0xe8, 0x7d, 0x00, 0x4c, 0xe8,
-	0xf2, 0xc7, 0x1d, 0xe6, 0x40, 0xca, 0x4f, 0x21, 0xb3, 0x15, 0x1d, 0xf5, 0xde, 0x3b, 0x81, 0x3c,
-	0x7f, 0x8d, 0x06, 0xc8, 0xd5, 0x57, 0xf0, 0xe8, 0x37, 0x87, 0x39, 0xbb, 0x1e, 0x71, 0x3c, 0x0e,
-	0x18, 0xc3, 0x62, 0xc3, 0x48, 0xa8, 0x39, 0xe1, 0x80, 0x31, 0xcc, 0x96, 0x8d, 0xb7, 0x51, 0x48,
-	0x0d, 0x44, 0xd0, 0xe8, 0xb7, 0x10, 0x67, 0xed, 0x8a, 0x6a, 0x87, 0x3e, 0x9a, 0x95, 0xef, 0x1f,
-	0xdc, 0xfb, 0x3f, 0x96, 0xd0, 0x97, 0x90, 0x0b, 0xa7, 0x05, 0x9d, 0x3e, 0x79, 0x21, 0xb7, 0xd9,
-	0x1f, 0xa4, 0xf2, 0x93, 0xa3, 0x33, 0x8b, 0xae, 0x40, 0x3c, 0xcf, 0xff, 0x2f, 0xe6, 0x0f, 0x9e,
-	0xc5, 0xdc, 0x4b, 0x66, 0xab, 0xf2, 0xee, 0x5f, 0xe5, 0xc8, 0xbb, 0x4d, 0x59, 0xfa, 0xc7, 0xa6,
-	0x2c, 0xfd, 0x73, 0x53, 0x96, 0xde, 0xfe, 0xbb, 0x1c, 0xf9, 0x0d, 0x9f, 0x7b, 0x6c, 0xec, 0xb9,
-	0xf7, 0x49, 0x0e, 0xfe, 0xd9, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0x62, 0x34, 0xad, 0x1c, 0x4b,
-	0x0f, 0x00, 0x00,
+	// 1774 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0xcd, 0x6f, 0x23, 0x49,
+	0x15, 0x77, 0xa7, 0xfd, 0xf9, 0xfc, 0x91, 0x9e, 0x5a, 0x13, 0x79, 0x7b, 0xd8, 0xb8, 0xd7, 0xa0,
+	0x95, 0x61, 0x77, 0x9d, 0x25, 0xbb, 0x0b, 0xa3, 0x01, 0x0e, 0x76, 0xc6, 0x89, 0x4d, 0xfc, 0x11,
+	0x95, 0x9d, 0x65, 0x07, 0x09, 0x59, 0x95, 0xb8, 0xd2, 0xdb, 0x1a, 0xbb, 0xdb, 0x74, 0xb7, 0x47,
+	0xb1, 0xc4, 0x9d, 0x95, 0x4f, 0x70, 0x05, 0x59, 0x02, 0x71, 0xe4, 0xce, 0xdf, 0x30, 0x07, 0x0e,
+	0xfc, 0x05, 0x16, 0x98, 0x3b, 0x37, 0x84, 0xc4, 0x09, 0x55, 0x55, 0xb7, 0xdd, 0x4e, 0x4c, 0xc6,
+	0x9e, 0x95, 0x38, 0xed, 0xad, 0xeb, 0x7d, 0xfc, 0xde, 0xab, 0x7a, 0x1f, 0xf5, 0xaa, 0x21, 0xeb,
+	0xb8, 0x96, 0x4d, 0x74, 0xda, 0xbb, 0xb6, 0x86, 0x43, 0xcb, 0x2c, 0x8d, 0x6c, 0xcb, 0xb5, 0xd0,
+	0x63, 0xc3, 0xbc, 0x19, 0x8c, 0x6f, 0xfb, 0xc4, 0x25, 0xa5, 0xd1, 0x80, 0xb8, 0x37, 0x96, 0x3d,
+	0x2c, 0x79, 0x92, 0x6a, 0x56, 0xb7, 0x74, 0x8b, 0xcb, 0x1d, 0xb1, 0x2f, 0xa1, 0xa2, 0x3e, 0xd6,
+	0x2d, 0x4b, 0x1f, 0xd0, 0x23, 0xbe, 0xba, 0x1a, 0xdf, 0x1c, 0xd1, 0xe1, 0xc8, 0x9d, 0x78, 0xcc,
+	0xb7, 0xef, 0x32, 0x89, 0xe9, 0xb3, 0xf6, 0x47, 0x36, 0xed, 0x1b, 0xd7, 0xc4, 0xa5, 0x82, 0x50,
+	0xf8, 0x77, 0x1c, 0x92, 0x98, 0x92, 0x3e, 0xa6, 0xbf, 0x18, 0x53, 0xc7, 0x45, 0x03, 0xd8, 0x77,
+	0x8d, 0x21, 0x75, 0x5c, 0x32, 0x1c, 0xf5, 0x6c, 0x62, 0xea, 0x34, 0xb7, 0xa7, 0x49, 0xc5, 0xe4,
+	0xf1, 0xfb, 0xa5, 0x07, 0xbc, 0x2c, 0x75, 0x7d, 0x1d, 0xcc, 0x54, 0x2a, 0x07, 0xaf, 0xe6, 0xf9,
+	0xd0, 0x62, 0x9e, 0xcf, 0xac, 0xd3, 0x71, 0xc6, 0x5d, 0x5b, 0xa3, 0x43, 0x80, 0x3e, 0x75, 0xae,
+	0xa9, 0xd9, 0x37, 0x4c, 0x3d, 0x27, 0x6b, 0x52, 0x31, 0x8e, 0x03, 0x14, 0xf4, 0x01, 0x80, 0x6e,
+	0x5b, 0xe3, 0x51, 0xef, 0x05, 0x9d, 0x38, 0xb9, 0xb0, 0x26, 0x17, 0x13, 0x95, 0xf4, 0x62, 0x9e,
+	0x4f, 0x9c, 0x31, 0xea, 0x39, 0x9d, 0x38, 0x38, 0xa1, 0xfb, 0x9f, 0xe8, 0x19, 0x24, 0x96, 0xdb,
+	0xcb, 0x45, 0xb8, 0xd7, 0xef, 0x3d, 0xe8, 0xf5, 0x85, 0x2f, 0x8d, 0x57, 0x8a, 0xe8, 0x18, 0x52,
+	0x0e, 0xb5, 0x0d, 0xea, 0xf4, 0x06, 0xc6, 0xd0, 0x70, 0x73, 0x51, 0x4d, 0x2a, 0xca, 0x95, 0xfd,
+	0xc5, 0x3c, 0x9f, 0xec, 0x70, 0x7a, 0x83, 0x91, 0x71, 0xd2, 0x59, 0x2d, 0xd0, 0xa7, 0x90, 0xf6,
+	0x74, 0xac, 0x9b, 0x1b, 0x87, 0xba, 0xb9, 0x18, 0x57, 0x52, 0x16, 0xf3, 0x7c, 0x4a, 0x28, 0xb5,
+	0x39, 0x1d, 0xa7, 0x9c, 0xc0, 0x8a, 0x99, 0x1a, 0x59, 0x86, 0xe9, 0xfa, 0xa6, 0xe2, 0x2b, 0x53,
+	0x17, 0x9c, 0xee, 0x99, 0x1a, 0xad, 0x16, 0x6c, 0x93, 0x44, 0xd7, 0x6d, 0xaa, 0xb3, 0x4d, 0x26,
+	0xb6, 0xd8, 0x64, 0xd9, 0x97, 0xc6, 0x2b, 0x45, 0xd4, 0x85, 0x88, 0x6b, 0x93, 0x6b, 0x9a, 0x03,
+	0x4d, 0x2e, 0x26, 0x8f, 0x3f, 0x7e, 0x10, 0x21, 0x90, 0x1f, 0xa5, 0x2e, 0xd3, 0xaa, 0x9a, 0xae,
+	0x3d, 0xa9, 0x24, 0x16, 0xf3, 0x7c, 0x84, 0xaf, 0xb1, 0x00, 0x43, 0xcf, 0x20, 0xc2, 0xa3, 0x91,
+	0x4b, 0x6a, 0x52, 0x31, 0x73, 0x5c, 0xda, 0x1a, 0x95, 0x87, 0x13, 0x0b, 0x65, 0xf4, 0x01, 0x44,
+	0xbe, 0x60, 0xfb, 0xcd, 0xa5, 0x34, 0xa9, 0x18, 0xab, 0x1c, 0x30, 0x33, 0x35, 0x46, 0xf8, 0xcf,
+	0x3c, 0x9f, 0x60, 0x1f, 0xa7, 0x03, 0xa2, 0x3b, 0x58, 0x08, 0xa1, 0x2a, 0x24, 0x6d, 0x4a, 0xfa,
+	0x3d, 0xc7, 0x1a, 0xdb, 0xd7, 0x34, 0x97, 0xe6, 0x27, 0x92, 0x2d, 0x89, 0x12, 0x28, 0xf9, 0x25,
+	0x50, 0x2a, 0x9b, 0x93, 0x4a, 0x66, 0x31, 0xcf, 0x03, 0x33, 0xdb, 0xe1, 0xb2, 0x18, 0xec, 0xe5,
+	0xb7, 0xfa, 0x04, 0x60, 0xb5, 0x35, 0xa4, 0x80, 0xfc, 0x82, 0x4e, 0x72, 0x92, 0x26, 0x15, 0x13,
+	0x98, 0x7d, 0xa2, 0x2c, 0x44, 0x5e, 0x92, 0xc1, 0x58, 0x54, 0x43, 0x02, 0x8b, 0xc5, 0xd3, 0xbd,
+	0x27, 0x52, 0xe1, 0x57, 0x12, 0x44, 0xb8, 0xff, 0xe8, 0x1d, 0x80, 0x33, 0xdc, 0xbe, 0xbc, 0xe8,
+	0xb5, 0xda, 0xad, 0xaa, 0x12, 0x52, 0xd3, 0xd3, 0x99, 0x26, 0x32, 0xb5, 0x65, 0x99, 0x14, 0x3d,
+	0x86, 0x84, 0x60, 0x97, 0x1b, 0x0d, 0x45, 0x52, 0x53, 0xd3, 0x99, 0x16, 0xe7, 0xdc, 0xf2, 0x60,
+	0x80, 0xde, 0x86, 0xb8, 0x60, 0x56, 0x9e, 0x2b, 0x7b, 0x6a, 0x72, 0x3a, 0xd3, 0x62, 0x9c, 0x57,
+	0x99, 0xa0, 0x77, 0x21, 0x25, 0x58, 0xd5, 0xcf, 0x4f, 0xaa, 0x17, 0x5d, 0x45, 0x56, 0xf7, 0xa7,
+	0x33, 0x2d, 0xc9, 0xd9, 0xd5, 0xdb, 0x6b, 0x3a, 0x72, 0xd5, 0xf0, 0x97, 0x7f, 0x3c, 0x0c, 0x15,
+	0xfe, 0x24, 0xc1, 0xea, 0x7c, 0x98, 0xb9, 0x5a, 0xbd, 0xd5, 0xf5, 0x9d, 0xe1, 0xe6, 0x18, 0x97,
+	0xfb, 0xf2, 0x6d, 0xc8, 0x78, 0xcc, 0xde, 0x45, 0xbb, 0xde, 0xea, 0x76, 0x14, 0x49, 0x55, 0xa6,
+	0x33, 0x2d, 0x25, 0x24, 0x44, 0xf6, 0x05, 0xa5, 0x3a, 0x55, 0x5c, 0xaf, 0x76, 0x94, 0xbd, 0xa0,
+	0x94, 0xc8, 0x6c, 0x74, 0x04, 0x59, 0x2e, 0xd5, 0x39, 0xa9, 0x55, 0x9b, 0x65, 0xb6, 0xbb, 0x5e,
+	0xb7, 0xde, 0xac, 0x2a, 0x61, 0xf5, 0x1b, 0xd3, 0x99, 0xf6, 0x88, 0xc9, 0x76, 0xae, 0xbf, 0xa0,
+	0x43, 0x52, 0x1e, 0x0c, 0x58, 0x3f, 0xf0, 0xbc, 0xfd, 0x83, 0x0c, 0x89, 0x65, 0x6e, 0xa2, 0x1a,
+	0x84, 0xdd, 0xc9, 0x88, 0xf2, 0x23, 0xcf, 0x1c, 0x7f, 0xb2, 0x5d, 0x46, 0xaf, 0xbe, 0xba, 0x93,
+	0x11, 0xc5, 0x1c, 0x81, 0x45, 0x8a, 0xbe, 0xa4, 0xf6, 0x84, 0x47, 0x4a, 0xc6, 0x62, 0x81, 0x0e,
+	0x20, 0xea, 0x95, 0xa6, 0xcc, 0xc9, 0xde, 0xaa, 0xf0, 0xbb, 0x3d, 0x48, 0xaf, 0xa1, 0xa0, 0x3c,
+	0x84, 0xbd, 0x23, 0xe3, 0xee, 0xaf, 0x31, 0xf9, 0xd9, 0xbd, 0x03, 0x72, 0xe7, 0xb2, 0xa9, 0x48,
+	0x6a, 0x76, 0x3a, 0xd3, 0x94, 0x35, 0x7e, 0x67, 0x3c, 0x44, 0xef, 0x42, 0xe4, 0xa4, 0x7d, 0xd9,
+	0xea, 0x2a, 0x7b, 0xea, 0xc1, 0x74, 0xa6, 0xa1, 0x35, 0x81, 0x13, 0x6b, 0x6c, 0xba, 0x0c, 0xa1,
+	0x59, 0x6f, 0x29, 0xf2, 0x06, 0x84, 0xa6, 0x61, 0x72, 0x76, 0xf9, 0x73, 0x25, 0xbc, 0x89, 0x4d,
+	0x6e, 0x99, 0x81, 0xd3, 0x3a, 0xee, 0x74, 0x95, 0xc8, 0x06, 0x03, 0xa7, 0x86, 0xed, 0xb8, 0x6c,
+	0x0f, 0x8d, 0x72, 0xa7, 0xab, 0x44, 0x37, 0xec, 0xa1, 0x41, 0x84, 0x40, 0xb3, 0x5a, 0x6e, 0x29,
+	0xb1, 0x0d, 0x02, 0x4d, 0x4a, 0x4c, 0x2f, 0x46, 0x1f, 0x82, 0xdc, 0x25, 0x7a, 0xb0, 0x1c, 0x52,
+	0x1b, 0xca, 0x21, 0xe5, 0x95, 0x43, 0xe1, 0x37, 0x19, 0x48, 0x89, 0xb2, 0x76, 0x46, 0x96, 0xe9,
+	0x50, 0xd4, 0x84, 0xe8, 0x8d, 0x4d, 0x86, 0xd4, 0xc9, 0x49, 0xbc, 0xcf, 0x1c, 0x6d, 0xd1, 0x11,
+	0x84, 0x6a, 0xe9, 0x94, 0xe9, 0x55, 0xc2, 0xec, 0x22, 0xc1, 0x1e, 0x88, 0xfa, 0x65, 0x14, 0x22,
+	0x9c, 0x8e, 0xda, 0x10, 0x15, 0x9d, 0x94, 0x3b, 0x95, 0x3c, 0xfe, 0x74, 0x7b, 0x60, 0x91, 0xb5,
+	0x1c, 0xa6, 0x16, 0xc2, 0x1e, 0x0c, 0x1a, 0x41, 0xea, 0x66, 0x60, 0x11, 0xb7, 0x27, 0x7a, 0xad,
+	0x77, 0xe9, 0x3d, 0xdd, 0xc1, 0x5f, 0xa6, 0x2d, 0xea, 0x46, 0xb8, 0xce, 0xdb, 0x78, 0x80, 0x5a,
+	0x0b, 0xe1, 0xe4, 0xcd, 0x6a, 0x89, 0x6e, 0x21, 0x63, 0x98, 0x2e, 0xd5, 0xa9, 0xed, 0xdb, 0x94,
+	0xb9, 0xcd, 0x1f, 0x6d, 0x6f, 0xb3, 0x2e, 0xf4, 0x83, 0x56, 0x1f, 0x2d, 0xe6, 0xf9, 0xf4, 0x1a,
+	0xbd, 0x16, 0xc2, 0x69, 0x23, 0x48, 0x40, 0xbf, 0x84, 0xfd, 0xb1, 0xe9, 0x18, 0xba, 0x49, 0xfb,
+	0xbe, 0xe9, 0x30, 0x37, 0xfd, 0xe3, 0xed, 0x4d, 0x5f, 0x7a, 0x00, 0x41, 0xdb, 0x88, 0xdd, 0xf8,
+	0xeb, 0x8c, 0x5a, 0x08, 0x67, 0xc6, 0x6b, 0x14, 0xb6, 0xef, 0x2b, 0xcb, 0x1a, 0x50, 0x62, 0xfa,
+	0xc6, 0x23, 0xbb, 0xee, 0xbb, 0x22, 0xf4, 0xef, 0xed, 0x7b, 0x8d, 0xce, 0xf6, 0x7d, 0x15, 0x24,
+	0x20, 0x17, 0xd2, 0x8e, 0x6b, 0x1b, 0xa6, 0xee, 0x1b, 0x8e, 0x72, 0xc3, 0x3f, 0xdc, 0x21, 0x77,
+	0xb8, 0x7a, 0xd0, 0xae, 0xb8, 0xe2, 0x03, 0xe4, 0x5a, 0x08, 0xa7, 0x9c, 0xc0, 0x1a, 0x35, 0xfc,
+	0x4b, 0x31, 0xc6, 0xad, 0x7d, 0xb2, 0xbd, 0x35, 0xde, 0xe1, 0xfd, 0x44, 0x15, 0x20, 0x95, 0x28,
+	0x84, 0x99, 0xa6, 0x7a, 0x0b, 0xb0, 0x62, 0xa3, 0xf7, 0x20, 0xee, 0x12, 0x5d, 0x4c, 0x49, 0xac,
+	0xd2, 0x52, 0x95, 0xe4, 0x62, 0x9e, 0x8f, 0x75, 0x89, 0xce, 0x67, 0xa4, 0x98, 0x2b, 0x3e, 0x50,
+	0x05, 0xd0, 0x88, 0xd8, 0xae, 0xe1, 0x1a, 0x96, 0xc9, 0xa4, 0x7b, 0x2f, 0xc9, 0x80, 0xe5, 0x3a,
+	0xd3, 0xc8, 0x2e, 0xe6, 0x79, 0xe5, 0xc2, 0xe7, 0x9e, 0xd3, 0xc9, 0x67, 0x64, 0xe0, 0x60, 0x65,
+	0x74, 0x87, 0xa2, 0xfe, 0x56, 0x82, 0x64, 0xa0, 0x86, 0xd0, 0x53, 0x08, 0xbb, 0x44, 0xf7, 0x2b,
+	0x5c, 0x7b, 0x78, 0x4c, 0x24, 0xba, 0x57, 0xd2, 0x5c, 0x07, 0xb5, 0x21, 0xc1, 0x04, 0x7b, 0xbc,
+	0xf5, 0xef, 0xf1, 0xd6, 0x7f, 0xbc, 0xfd, 0xf9, 0x3c, 0x23, 0x2e, 0xe1, 0x8d, 0x3f, 0xde, 0xf7,
+	0xbe, 0xd4, 0x9f, 0x80, 0x72, 0xb7, 0x10, 0xd9, 0x90, 0xb9, 0x1c, 0x3b, 0x85, 0x9b, 0x0a, 0x0e,
+	0x50, 0xd8, 0xd5, 0xc0, 0xdb, 0x97, 0x38, 0x08, 0x09, 0x7b, 0x2b, 0xb5, 0x01, 0xe8, 0x7e, 0x81,
+	0xed, 0x88, 0x26, 0x2f, 0xd1, 0x9a, 0xf0, 0xd6, 0x86, 0x9a, 0xd9, 0x11, 0x2e, 0x1c, 0x74, 0xee,
+	0x7e, 0x15, 0xec, 0x88, 0x16, 0x5f, 0xa2, 0x9d, 0xc3, 0xa3, 0x7b, 0xa9, 0xbd, 0x23, 0x58, 0xc2,
+	0x07, 0x2b, 0x74, 0x20, 0xc1, 0x01, 0xbc, 0xdb, 0x34, 0xea, 0x8d, 0x0e, 0x21, 0xf5, 0xad, 0xe9,
+	0x4c, 0xdb, 0x5f, 0xb2, 0xbc, 0xe9, 0x21, 0x0f, 0xd1, 0xe5, 0x04, 0xb2, 0x2e, 0x20, 0x7c, 0xf1,
+	0x6e, 0xa2, 0x3f, 0x4b, 0x10, 0xf7, 0xe3, 0x8d, 0xbe, 0x09, 0x91, 0xd3, 0x46, 0xbb, 0xdc, 0x55,
+	0x42, 0xea, 0xa3, 0xe9, 0x4c, 0x4b, 0xfb, 0x0c, 0x1e, 0x7a, 0xa4, 0x41, 0xac, 0xde, 0xea, 0x56,
+	0xcf, 0xaa, 0xd8, 0x87, 0xf4, 0xf9, 0x5e, 0x38, 0x51, 0x01, 0xe2, 0x97, 0xad, 0x4e, 0xfd, 0xac,
+	0x55, 0x7d, 0xa6, 0xec, 0x89, 0x5b, 0xd6, 0x17, 0xf1, 0x63, 0xc4, 0x50, 0x2a, 0xed, 0x76, 0x83,
+	0x5d, 0x92, 0xf2, 0x3a, 0x8a, 0x77, 0xee, 0xe8, 0x10, 0xa2, 0x9d, 0x2e, 0xae, 0xb7, 0xce, 0x94,
+	0xb0, 0x8a, 0xa6, 0x33, 0x2d, 0xe3, 0x0b, 0x88, 0xa3, 0xf4, 0x1c, 0xff, 0xbd, 0x04, 0xd9, 0x13,
+	0x32, 0x22, 0x57, 0xc6, 0xc0, 0x70, 0x0d, 0xea, 0x2c, 0xef, 0xc6, 0x36, 0x84, 0xaf, 0xc9, 0xc8,
+	0xaf, 0x9b, 0x87, 0x9b, 0xd0, 0x26, 0x00, 0x46, 0x74, 0xf8, 0xb8, 0x8a, 0x39, 0x90, 0xfa, 0x03,
+	0x48, 0x2c, 0x49, 0x3b, 0x4d, 0xb0, 0xfb, 0x90, 0xe6, 0xf3, 0xb5, 0x8f, 0x5c, 0x78, 0x02, 0x77,
+	0x1e, 0x6e, 0x4c, 0xd9, 0x71, 0x89, 0xed, 0x72, 0x40, 0x19, 0x8b, 0x05, 0x33, 0x42, 0xcd, 0xbe,
+	0x37, 0x68, 0xb1, 0xcf, 0xc2, 0x5f, 0x64, 0xc8, 0xf8, 0x5d, 0xc7, 0x7b, 0x51, 0xde, 0x19, 0xd0,
+	0xa5, 0x37, 0x1b, 0xd0, 0xff, 0xcf, 0x0f, 0xd3, 0xb5, 0xa7, 0xa4, 0xfc, 0xa6, 0x4f, 0xc9, 0xe5,
+	0x4b, 0x26, 0xbc, 0xcd, 0x4b, 0xe6, 0xa7, 0xfe, 0x9b, 0x2c, 0xc2, 0x33, 0xe2, 0xfb, 0xaf, 0xeb,
+	0xa4, 0x81, 0x43, 0x7e, 0xf0, 0x59, 0xf6, 0x15, 0xde, 0x36, 0xff, 0x92, 0x41, 0xe9, 0x12, 0xfd,
+	0x33, 0x46, 0xf8, 0x3a, 0xa0, 0xbb, 0x06, 0xf4, 0xf9, 0x7a, 0x40, 0x9f, 0xbc, 0x2e, 0xa0, 0x6b,
+	0xc7, 0xfc, 0xf0, 0x4b, 0xfb, 0x5b, 0x10, 0xf3, 0x2e, 0x7c, 0x3e, 0xc4, 0x24, 0x2a, 0xb0, 0x98,
+	0xe7, 0xa3, 0x22, 0x29, 0x70, 0x54, 0x5c, 0xf7, 0x5f, 0x21, 0xee, 0x25, 0xc8, 0x8a, 0x26, 0xe6,
+	0xbb, 0xe4, 0xf5, 0xac, 0x55, 0xcb, 0xe7, 0x53, 0x86, 0xdf, 0xf2, 0x8f, 0xff, 0x29, 0x43, 0xac,
+	0x23, 0x36, 0x82, 0x7e, 0x0e, 0x61, 0x16, 0x71, 0x54, 0xdc, 0xf6, 0xf5, 0xaf, 0x7e, 0x67, 0xeb,
+	0x2b, 0xff, 0x23, 0x09, 0x3d, 0x87, 0x54, 0xb0, 0x1b, 0xa2, 0x83, 0x7b, 0x89, 0x57, 0x65, 0xbf,
+	0xc2, 0xd4, 0xef, 0xed, 0xdc, 0x50, 0xd1, 0x39, 0x88, 0x60, 0xfe, 0x4f, 0xcc, 0xef, 0x3e, 0x88,
+	0xb9, 0xd6, 0x43, 0xd1, 0x0b, 0xf0, 0xc7, 0x2f, 0xf4, 0xfe, 0x0e, 0x95, 0xfc, 0x1a, 0xbf, 0x37,
+	0x45, 0xe5, 0x23, 0x09, 0x59, 0x90, 0x58, 0xe6, 0x0f, 0xfa, 0x70, 0xa7, 0x3c, 0x7b, 0x23, 0x83,
+	0x95, 0xfc, 0xab, 0xbf, 0x1f, 0x86, 0x5e, 0x2d, 0x0e, 0xa5, 0xbf, 0x2e, 0x0e, 0xa5, 0xbf, 0x2d,
+	0x0e, 0xa5, 0x5f, 0xff, 0xe3, 0x30, 0xf4, 0x33, 0x3e, 0xcc, 0xb1, 0x59, 0xce, 0xb9, 0x8a, 0xf2,
+	0xa3, 0xfb, 0xf8, 0xbf, 0x03, 0x00, 0xa8, 0x9e, 0xdd, 0xbe, 0xf2, 0x14, 0x00, 0x00,
 }
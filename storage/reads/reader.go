@@ -3,12 +3,14 @@ package reads
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/gogo/protobuf/types"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/values"
 	"github.com/influxdata/platform/models"
 	fstorage "github.com/influxdata/platform/query/functions/inputs/storage"
@@ -92,7 +94,16 @@ func (bi *tableIterator) Do(f func(flux.Table) error) error {
 	if agg, err := determineAggregateMethod(bi.readSpec.AggregateMethod); err != nil {
 		return err
 	} else if agg != datatypes.AggregateTypeNone {
-		req.Aggregate = &datatypes.Aggregate{Type: agg}
+		req.Aggregate = &datatypes.Aggregate{
+			Type:   agg,
+			Every:  int64(bi.readSpec.WindowEvery),
+			Offset: int64(bi.readSpec.WindowOffset),
+		}
+	}
+
+	windowed := req.Aggregate != nil && req.Aggregate.Every > 0
+	if windowed && (req.Group != datatypes.GroupAll || req.Hints.NoPoints()) {
+		return errors.New("window aggregates can only be read per series")
 	}
 
 	switch {
@@ -124,6 +135,9 @@ func (bi *tableIterator) Do(f func(flux.Table) error) error {
 		if req.Hints.NoPoints() {
 			return bi.handleReadNoPoints(f, rs)
 		}
+		if windowed {
+			return bi.handleWindowAggregateRead(f, rs, req.Aggregate)
+		}
 		return bi.handleRead(f, rs)
 	}
 }
@@ -198,6 +212,53 @@ READ:
 	return rs.Err()
 }
 
+// handleWindowAggregateRead produces a table for each window of each series,
+// holding the single point the window was aggregated to.
+// The tables are those produced by windowing the series and then applying the aggregate.
+func (bi *tableIterator) handleWindowAggregateRead(f func(flux.Table) error, rs ResultSet, agg *datatypes.Aggregate) error {
+	defer rs.Close()
+
+	alloc := bi.readSpec.Allocator
+	if alloc == nil {
+		alloc = &memory.Allocator{}
+	}
+	w := windowTables{
+		f:      f,
+		bounds: bi.bounds,
+		window: aggregateWindow{every: agg.Every, offset: agg.Offset},
+		// Selectors keep the time of the point they select, aggregates drop it.
+		selector: agg.Type == datatypes.AggregateTypeMin || agg.Type == datatypes.AggregateTypeMax ||
+			agg.Type == datatypes.AggregateTypeFirst || agg.Type == datatypes.AggregateTypeLast,
+		readSpec: &bi.readSpec,
+		alloc:    alloc,
+	}
+
+	for rs.Next() {
+		if err := bi.ctx.Err(); err != nil {
+			return err
+		}
+
+		cur := rs.Cursor()
+		if cur == nil {
+			// no data for series key + field combination
+			continue
+		}
+
+		w.tags = rs.Tags()
+		err := w.do(cur)
+		cs := cur.Stats()
+		bi.stats = bi.stats.Add(flux.Statistics{
+			ScannedValues: cs.ScannedValues,
+			ScannedBytes:  cs.ScannedBytes,
+		})
+		cur.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return rs.Err()
+}
+
 func (bi *tableIterator) handleReadNoPoints(f func(flux.Table) error, rs ResultSet) error {
 	// these resources must be closed if not nil on return
 	var table storageTable
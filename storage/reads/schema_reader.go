@@ -0,0 +1,72 @@
+package reads
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/types"
+	fstorage "github.com/influxdata/platform/query/functions/inputs/storage"
+	"github.com/influxdata/platform/storage/reads/datatypes"
+)
+
+type schemaReader struct {
+	s SchemaStore
+}
+
+// NewSchemaReader returns a SchemaReader that answers schema requests from s.
+func NewSchemaReader(s SchemaStore) fstorage.SchemaReader {
+	return &schemaReader{s: s}
+}
+
+func (r *schemaReader) TagKeys(ctx context.Context, spec fstorage.SchemaSpec) ([]string, error) {
+	req, err := r.newTagKeysRequest(spec)
+	if err != nil {
+		return nil, err
+	}
+	return r.s.TagKeys(ctx, req)
+}
+
+func (r *schemaReader) TagValues(ctx context.Context, spec fstorage.SchemaSpec) ([]string, error) {
+	kreq, err := r.newTagKeysRequest(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &datatypes.TagValuesRequest{
+		ReadSource:     kreq.ReadSource,
+		TimestampRange: kreq.TimestampRange,
+		Predicate:      kreq.Predicate,
+		Hints:          kreq.Hints,
+		TagKey:         spec.TagKey,
+	}
+	return r.s.TagValues(ctx, req)
+}
+
+// newTagKeysRequest returns the request for the tag keys of spec.
+func (r *schemaReader) newTagKeysRequest(spec fstorage.SchemaSpec) (*datatypes.TagKeysRequest, error) {
+	src, err := r.s.GetSource(fstorage.ReadSpec{
+		OrganizationID: spec.OrganizationID,
+		BucketID:       spec.BucketID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var req datatypes.TagKeysRequest
+	if req.ReadSource, err = types.MarshalAny(src); err != nil {
+		return nil, err
+	}
+
+	if spec.Predicate != nil {
+		if req.Predicate, err = toStoragePredicate(spec.Predicate); err != nil {
+			return nil, err
+		}
+	}
+
+	if spec.AllTime {
+		req.Hints.SetHintSchemaAllTime()
+	} else {
+		req.TimestampRange.Start = int64(spec.Start)
+		req.TimestampRange.End = int64(spec.Stop)
+	}
+	return &req, nil
+}
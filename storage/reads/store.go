@@ -79,3 +79,10 @@ type Store interface {
 	GroupRead(ctx context.Context, req *datatypes.ReadRequest) (GroupResultSet, error)
 	GetSource(rs fstorage.ReadSpec) (proto.Message, error)
 }
+
+// SchemaStore answers schema requests from the index of a Store.
+type SchemaStore interface {
+	TagKeys(ctx context.Context, req *datatypes.TagKeysRequest) ([]string, error)
+	TagValues(ctx context.Context, req *datatypes.TagValuesRequest) ([]string, error)
+	GetSource(rs fstorage.ReadSpec) (proto.Message, error)
+}
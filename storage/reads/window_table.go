@@ -0,0 +1,203 @@
+package reads
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/platform/models"
+	fstorage "github.com/influxdata/platform/query/functions/inputs/storage"
+	"github.com/influxdata/platform/tsdb/cursors"
+)
+
+// windowTables produces a table for every window of a window aggregate
+// cursor. The bounds of a window are part of the group key, so the points of
+// a series are split into a table per run of points in the same window.
+type windowTables struct {
+	f        func(flux.Table) error
+	bounds   execute.Bounds
+	window   aggregateWindow
+	selector bool
+	readSpec *fstorage.ReadSpec
+	alloc    *memory.Allocator
+
+	// tags of the current series.
+	tags models.Tags
+
+	// Columns of the tables of the current series, set by init.
+	// The group key of a window is keyCols with keyValues, whose
+	// _start and _stop values are the bounds of the window.
+	cols      []flux.ColMeta
+	tagValues []string // value of each tag column of cols
+	valueCol  int
+	keyCols   []flux.ColMeta
+	keyValues []values.Value
+
+	// Table of the current window.
+	b           *execute.ColListTableBuilder
+	start, stop execute.Time
+}
+
+func (w *windowTables) do(cur cursors.Cursor) error {
+	switch cur := cur.(type) {
+	case cursors.IntegerArrayCursor:
+		w.init(flux.TInt)
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			for i, ts := range a.Timestamps {
+				if err := w.appendRow(ts); err != nil {
+					return err
+				} else if err := w.b.AppendInt(w.valueCol, a.Values[i]); err != nil {
+					return err
+				}
+			}
+		}
+	case cursors.FloatArrayCursor:
+		w.init(flux.TFloat)
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			for i, ts := range a.Timestamps {
+				if err := w.appendRow(ts); err != nil {
+					return err
+				} else if err := w.b.AppendFloat(w.valueCol, a.Values[i]); err != nil {
+					return err
+				}
+			}
+		}
+	case cursors.UnsignedArrayCursor:
+		w.init(flux.TUInt)
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			for i, ts := range a.Timestamps {
+				if err := w.appendRow(ts); err != nil {
+					return err
+				} else if err := w.b.AppendUInt(w.valueCol, a.Values[i]); err != nil {
+					return err
+				}
+			}
+		}
+	case cursors.BooleanArrayCursor:
+		w.init(flux.TBool)
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			for i, ts := range a.Timestamps {
+				if err := w.appendRow(ts); err != nil {
+					return err
+				} else if err := w.b.AppendBool(w.valueCol, a.Values[i]); err != nil {
+					return err
+				}
+			}
+		}
+	case cursors.StringArrayCursor:
+		w.init(flux.TString)
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			for i, ts := range a.Timestamps {
+				if err := w.appendRow(ts); err != nil {
+					return err
+				} else if err := w.b.AppendString(w.valueCol, a.Values[i]); err != nil {
+					return err
+				}
+			}
+		}
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+	return w.flush()
+}
+
+// init determines the columns and group key of the tables of the current series.
+// The columns of a selector's table are those of a series table;
+// an aggregate's table has the group key columns followed by the value.
+func (w *windowTables) init(typ flux.ColType) {
+	w.b = nil
+
+	key := groupKeyForSeries(w.tags, w.readSpec, w.bounds)
+	w.keyCols = key.Cols()
+	w.keyValues = key.Values()
+
+	if w.selector {
+		w.cols, _ = determineTableColsForSeries(w.tags, typ)
+	} else {
+		w.cols = append(w.keyCols[:len(w.keyCols):len(w.keyCols)], flux.ColMeta{Label: execute.DefaultValueColLabel, Type: typ})
+	}
+
+	w.tagValues = make([]string, len(w.cols))
+	for j, c := range w.cols {
+		switch c.Label {
+		case execute.DefaultStartColLabel, execute.DefaultStopColLabel, execute.DefaultTimeColLabel:
+		case execute.DefaultValueColLabel:
+			w.valueCol = j
+		default:
+			w.tagValues[j] = string(w.tags.Get([]byte(c.Label)))
+		}
+	}
+}
+
+// appendRow appends the columns other than the value of the point at ts to
+// the table of its window, first passing f the table of the previous window
+// when the window changes.
+func (w *windowTables) appendRow(ts int64) error {
+	start, stop := w.window.bounds(ts)
+	bounds := execute.Bounds{Start: execute.Time(start), Stop: execute.Time(stop)}
+	if bounds.Start < w.bounds.Start {
+		bounds.Start = w.bounds.Start
+	}
+	if bounds.Stop > w.bounds.Stop {
+		bounds.Stop = w.bounds.Stop
+	}
+
+	if w.b == nil || bounds.Start != w.start || bounds.Stop != w.stop {
+		if err := w.flush(); err != nil {
+			return err
+		} else if err := w.newTable(bounds); err != nil {
+			return err
+		}
+	}
+
+	for j, c := range w.cols {
+		var err error
+		switch c.Label {
+		case execute.DefaultStartColLabel:
+			err = w.b.AppendTime(j, w.start)
+		case execute.DefaultStopColLabel:
+			err = w.b.AppendTime(j, w.stop)
+		case execute.DefaultTimeColLabel:
+			err = w.b.AppendTime(j, execute.Time(ts))
+		case execute.DefaultValueColLabel:
+		default:
+			err = w.b.AppendString(j, w.tagValues[j])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newTable starts the table of the window with bounds.
+func (w *windowTables) newTable(bounds execute.Bounds) error {
+	// groupKeyForSeries puts _start and _stop first.
+	vs := make([]values.Value, len(w.keyValues))
+	copy(vs, w.keyValues)
+	vs[0], vs[1] = values.NewTime(bounds.Start), values.NewTime(bounds.Stop)
+
+	w.b = execute.NewColListTableBuilder(execute.NewGroupKey(w.keyCols, vs), w.alloc)
+	w.start, w.stop = bounds.Start, bounds.Stop
+	for _, c := range w.cols {
+		if _, err := w.b.AddCol(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flush passes f the table of the current window, if any.
+func (w *windowTables) flush() error {
+	if w.b == nil {
+		return nil
+	}
+	tbl, err := w.b.Table()
+	w.b = nil
+	if err != nil {
+		return err
+	}
+	return w.f(tbl)
+}
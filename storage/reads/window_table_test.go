@@ -0,0 +1,114 @@
+package reads
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/platform/models"
+	fstorage "github.com/influxdata/platform/query/functions/inputs/storage"
+	"github.com/influxdata/platform/tsdb/cursors"
+)
+
+func TestWindowTables(t *testing.T) {
+	type table struct {
+		start, stop execute.Time
+		rows        int
+	}
+	var got []table
+	w := windowTables{
+		f: func(tbl flux.Table) error {
+			key := tbl.Key()
+			return tbl.Do(func(cr flux.ColReader) error {
+				got = append(got, table{
+					start: key.ValueTime(0),
+					stop:  key.ValueTime(1),
+					rows:  cr.Len(),
+				})
+				return nil
+			})
+		},
+		bounds:   execute.Bounds{Start: 0, Stop: 25},
+		window:   aggregateWindow{every: 10},
+		readSpec: &fstorage.ReadSpec{},
+		alloc:    &memory.Allocator{},
+		tags:     models.NewTags(map[string]string{"_m": "cpu", "_f": "value", "host": "a"}),
+	}
+	if err := w.do(newFloatArrayCursor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The points of a window are in one table, even across arrays.
+	exp := []table{
+		{start: 0, stop: 10, rows: 1},
+		{start: 10, stop: 20, rows: 2},
+		{start: 20, stop: 25, rows: 3},
+	}
+	if diff := cmp.Diff(exp, got, cmp.AllowUnexported(table{})); diff != "" {
+		t.Fatalf("unexpected tables -want/+got:\n%s", diff)
+	}
+}
+
+func TestWindowTables_AllocatorLimit(t *testing.T) {
+	limit := int64(64)
+	alloc := &memory.Allocator{Limit: &limit}
+	w := windowTables{
+		f: func(tbl flux.Table) error {
+			return tbl.Do(func(flux.ColReader) error { return nil })
+		},
+		bounds:   execute.Bounds{Start: 0, Stop: 100},
+		window:   aggregateWindow{every: 10},
+		readSpec: &fstorage.ReadSpec{},
+		alloc:    alloc,
+		tags:     models.NewTags(map[string]string{"_m": "cpu", "_f": "value", "host": "a"}),
+	}
+	// The allocator of a query panics when its limit is exceeded, which
+	// aborts the query.
+	defer func() {
+		if _, ok := recover().(memory.LimitExceededError); !ok {
+			t.Fatal("expected the memory limit of the allocator to be exceeded")
+		}
+	}()
+	_ = w.do(newFloatArrayCursor())
+}
+
+func BenchmarkWindowTables(b *testing.B) {
+	// A series aggregated to a point per window.
+	const n = 10000
+	a := &cursors.FloatArray{Timestamps: make([]int64, n), Values: make([]float64, n)}
+	for i := range a.Timestamps {
+		a.Timestamps[i] = int64(i) * 10
+		a.Values[i] = float64(i)
+	}
+
+	for _, selector := range []bool{false, true} {
+		name := "aggregate"
+		if selector {
+			name = "selector"
+		}
+		b.Run(name, func(b *testing.B) {
+			w := windowTables{
+				f: func(tbl flux.Table) error {
+					return tbl.Do(func(flux.ColReader) error { return nil })
+				},
+				bounds:   execute.Bounds{Start: 0, Stop: n * 10},
+				window:   aggregateWindow{every: 10},
+				selector: selector,
+				readSpec: &fstorage.ReadSpec{},
+				alloc:    &memory.Allocator{},
+				tags:     models.NewTags(map[string]string{"_m": "cpu", "_f": "value", "host": "a", "region": "west"}),
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := w.do(&floatArrayCursor{arrays: []*cursors.FloatArray{a}}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,327 @@
+package readservice
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/influxdata/platform"
+	kitgrpc "github.com/influxdata/platform/kit/grpc"
+	fstorage "github.com/influxdata/platform/query/functions/inputs/storage"
+	"github.com/influxdata/platform/storage/reads"
+	"github.com/influxdata/platform/storage/reads/datatypes"
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// remoteReader reads from the storage nodes of a remoteStore and closes
+// its connections when it is closed. It also implements fstorage.SchemaReader.
+type remoteReader struct {
+	fstorage.Reader
+	fstorage.SchemaReader
+	store *remoteStore
+}
+
+// NewRemoteReader returns a Reader that reads from every storage node of lookup
+// over gRPC and merges their results. Reads are authorized with token.
+// The returned Reader is also a fstorage.SchemaReader.
+func NewRemoteReader(lookup fstorage.HostLookup, token string, opts ...grpc.DialOption) fstorage.Reader {
+	s := newRemoteStore(lookup, token, opts...)
+	return &remoteReader{
+		Reader:       reads.NewReader(s),
+		SchemaReader: reads.NewSchemaReader(s),
+		store:        s,
+	}
+}
+
+func (r *remoteReader) Close() {
+	r.Reader.Close()
+	r.store.Close()
+}
+
+// remoteStore is a reads.Store that reads from the Storage gRPC service of
+// the hosts of a HostLookup.
+type remoteStore struct {
+	lookup fstorage.HostLookup
+	token  string
+	opts   []grpc.DialOption
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func newRemoteStore(lookup fstorage.HostLookup, token string, opts ...grpc.DialOption) *remoteStore {
+	return &remoteStore{
+		lookup: lookup,
+		token:  token,
+		opts:   opts,
+		conns:  make(map[string]*grpc.ClientConn),
+	}
+}
+
+// clients returns a client for each of the current hosts of the lookup,
+// dialing hosts that were added and closing the connections to hosts that were removed.
+func (s *remoteStore) clients() ([]datatypes.StorageClient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hosts := s.lookup.Hosts()
+	current := make(map[string]bool, len(hosts))
+	clients := make([]datatypes.StorageClient, 0, len(hosts))
+	for _, host := range hosts {
+		current[host] = true
+		conn, ok := s.conns[host]
+		if !ok {
+			var err error
+			if conn, err = grpc.Dial(host, s.opts...); err != nil {
+				return nil, &platform.Error{
+					Code: platform.EUnavailable,
+					Op:   "readservice/remoteStore.clients",
+					Msg:  "failed to dial storage host " + host,
+					Err:  err,
+				}
+			}
+			s.conns[host] = conn
+		}
+		clients = append(clients, datatypes.NewStorageClient(conn))
+	}
+
+	for host, conn := range s.conns {
+		if !current[host] {
+			conn.Close()
+			delete(s.conns, host)
+		}
+	}
+
+	if len(clients) == 0 {
+		return nil, &platform.Error{
+			Code: platform.EUnavailable,
+			Op:   "readservice/remoteStore.clients",
+			Msg:  "no storage hosts",
+		}
+	}
+	return clients, nil
+}
+
+// Close closes the connections to all hosts.
+func (s *remoteStore) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for host, conn := range s.conns {
+		conn.Close()
+		delete(s.conns, host)
+	}
+}
+
+// read sends req to every host and returns their response streams. The
+// streams remain open until cancel is called.
+func (s *remoteStore) read(ctx context.Context, req *datatypes.ReadRequest) (streams []reads.StreamReader, cancel func(), err error) {
+	clients, err := s.clients()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req.Trace = injectTrace(ctx)
+	ctx, cancel = context.WithCancel(ctx)
+	ctx = s.outgoingContext(ctx)
+	for _, c := range clients {
+		stream, err := c.Read(ctx, req)
+		if err != nil {
+			cancel()
+			return nil, nil, fromStatusError(err)
+		}
+		streams = append(streams, &statusStreamReader{stream: stream})
+	}
+	return streams, cancel, nil
+}
+
+func (s *remoteStore) Read(ctx context.Context, req *datatypes.ReadRequest) (reads.ResultSet, error) {
+	streams, cancel, err := s.read(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(streams) > 1 && (req.SeriesLimit > 0 || req.SeriesOffset > 0) {
+		cancel()
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "readservice/remoteStore.Read",
+			Msg:  "series limit and offset are not supported when reading from multiple storage hosts",
+		}
+	}
+
+	results := make([]reads.ResultSet, 0, len(streams))
+	for _, stream := range streams {
+		results = append(results, reads.NewResultSetStreamReader(stream))
+	}
+	return &remoteResultSet{ResultSet: reads.NewMergedResultSet(results), cancel: cancel}, nil
+}
+
+func (s *remoteStore) GroupRead(ctx context.Context, req *datatypes.ReadRequest) (reads.GroupResultSet, error) {
+	streams, cancel, err := s.read(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]reads.GroupResultSet, 0, len(streams))
+	for _, stream := range streams {
+		results = append(results, reads.NewGroupResultSetStreamReader(stream))
+	}
+
+	var rs reads.GroupResultSet
+	if req.Group == datatypes.GroupNone {
+		rs = reads.NewGroupNoneMergedGroupResultSet(results)
+	} else {
+		rs = reads.NewGroupByMergedGroupResultSet(results)
+	}
+	return &remoteGroupResultSet{GroupResultSet: rs, cancel: cancel}, nil
+}
+
+// TagKeys returns the merged tag keys of every host.
+func (s *remoteStore) TagKeys(ctx context.Context, req *datatypes.TagKeysRequest) ([]string, error) {
+	req.Trace = injectTrace(ctx)
+	return s.stringValues(ctx, func(ctx context.Context, c datatypes.StorageClient) (stringValuesClient, error) {
+		return c.TagKeys(ctx, req)
+	})
+}
+
+// TagValues returns the merged tag values of every host.
+func (s *remoteStore) TagValues(ctx context.Context, req *datatypes.TagValuesRequest) ([]string, error) {
+	req.Trace = injectTrace(ctx)
+	return s.stringValues(ctx, func(ctx context.Context, c datatypes.StorageClient) (stringValuesClient, error) {
+		return c.TagValues(ctx, req)
+	})
+}
+
+// stringValuesClient is the client stream of a schema request.
+type stringValuesClient interface {
+	Recv() (*datatypes.StringValuesResponse, error)
+}
+
+// stringValues sends a schema request to every host with call and returns
+// the sorted, distinct values of their responses.
+func (s *remoteStore) stringValues(ctx context.Context, call func(context.Context, datatypes.StorageClient) (stringValuesClient, error)) ([]string, error) {
+	clients, err := s.clients()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ctx = s.outgoingContext(ctx)
+
+	var merged []string
+	for _, c := range clients {
+		stream, err := call(ctx, c)
+		if err != nil {
+			return nil, fromStatusError(err)
+		}
+
+		var values []string
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, fromStatusError(err)
+			}
+			for _, v := range res.Values {
+				values = append(values, string(v))
+			}
+		}
+		merged = mergeSortedStrings(merged, values)
+	}
+	return merged, nil
+}
+
+// mergeSortedStrings returns the sorted, distinct values of the sorted slices a and b.
+func mergeSortedStrings(a, b []string) []string {
+	if len(a) == 0 {
+		return b
+	} else if len(b) == 0 {
+		return a
+	}
+
+	out := make([]string, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		switch {
+		case a[0] < b[0]:
+			out, a = append(out, a[0]), a[1:]
+		case a[0] > b[0]:
+			out, b = append(out, b[0]), b[1:]
+		default:
+			out, a, b = append(out, a[0]), a[1:], b[1:]
+		}
+	}
+	out = append(out, a...)
+	return append(out, b...)
+}
+
+func (s *remoteStore) GetSource(rs fstorage.ReadSpec) (proto.Message, error) {
+	return newReadSource(rs), nil
+}
+
+// outgoingContext returns ctx with the token of s added to its outgoing metadata.
+func (s *remoteStore) outgoingContext(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, authorizationKey, tokenScheme+s.token)
+}
+
+// injectTrace returns the trace of the span of ctx, if any, to be carried by a request.
+func injectTrace(ctx context.Context) map[string]string {
+	span := opentracing.SpanFromContext(ctx)
+	if span == nil {
+		return nil
+	}
+
+	trace := make(map[string]string)
+	span.Tracer().Inject(span.Context(), opentracing.TextMap, opentracing.TextMapCarrier(trace))
+	return trace
+}
+
+// statusStreamReader decodes the gRPC status errors of a response stream to platform.Errors.
+type statusStreamReader struct {
+	stream datatypes.Storage_ReadClient
+}
+
+func (r *statusStreamReader) Recv() (*datatypes.ReadResponse, error) {
+	res, err := r.stream.Recv()
+	if err != nil && err != io.EOF {
+		return nil, fromStatusError(err)
+	}
+	return res, err
+}
+
+// fromStatusError converts a gRPC status error to the platform.Error it encodes.
+func fromStatusError(err error) error {
+	if st, ok := status.FromError(err); ok {
+		if perr := kitgrpc.FromStatus(st); perr != nil {
+			return perr
+		}
+	}
+	return err
+}
+
+// remoteResultSet cancels the response streams of a ResultSet when it is closed.
+type remoteResultSet struct {
+	reads.ResultSet
+	cancel func()
+}
+
+func (r *remoteResultSet) Close() {
+	r.ResultSet.Close()
+	r.cancel()
+}
+
+// remoteGroupResultSet cancels the response streams of a GroupResultSet when it is closed.
+type remoteGroupResultSet struct {
+	reads.GroupResultSet
+	cancel func()
+}
+
+func (r *remoteGroupResultSet) Close() {
+	r.GroupResultSet.Close()
+	r.cancel()
+}
@@ -0,0 +1,87 @@
+package readservice
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/influxdata/influxql"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/storage/reads"
+	"github.com/influxdata/platform/storage/reads/datatypes"
+	"github.com/influxdata/platform/tsdb"
+)
+
+// TagKeys returns the sorted tag keys of the series of the bucket of req that
+// match its predicate and time range. The measurement and field keys are
+// returned as _measurement and _field.
+func (s *store) TagKeys(ctx context.Context, req *datatypes.TagKeysRequest) ([]string, error) {
+	source, cond, start, end, err := schemaRequest(req.ReadSource, req.Predicate, req.TimestampRange, req.Hints)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.engine.TagKeys(ctx, platform.ID(source.OrganizationID), platform.ID(source.BucketID), start, end, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, k := range keys {
+		switch k {
+		case tsdb.MeasurementTagKey:
+			keys[i] = measurementKey
+		case tsdb.FieldKeyTagKey:
+			keys[i] = fieldKey
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// TagValues returns the sorted values of the tag key of req of the series of
+// its bucket that match its predicate and time range. The measurement and
+// field names are the values of the _measurement and _field keys.
+func (s *store) TagValues(ctx context.Context, req *datatypes.TagValuesRequest) ([]string, error) {
+	source, cond, start, end, err := schemaRequest(req.ReadSource, req.Predicate, req.TimestampRange, req.Hints)
+	if err != nil {
+		return nil, err
+	}
+
+	key := req.TagKey
+	switch key {
+	case measurementKey:
+		key = tsdb.MeasurementTagKey
+	case fieldKey:
+		key = tsdb.FieldKeyTagKey
+	}
+	return s.engine.TagValues(ctx, platform.ID(source.OrganizationID), platform.ID(source.BucketID), key, start, end, cond)
+}
+
+// schemaRequest decodes the fields common to the schema requests. The time
+// range spans all time when HintSchemaAllTime is set, and defaults to the
+// minimum and maximum times otherwise, as it does for reads.
+func schemaRequest(src *types.Any, predicate *datatypes.Predicate, tr datatypes.TimestampRange, hints datatypes.HintFlags) (source *readSource, cond influxql.Expr, start, end int64, err error) {
+	if source, err = unmarshalReadSource(src); err != nil {
+		return nil, nil, 0, 0, err
+	}
+
+	if root := predicate.GetRoot(); root != nil {
+		if cond, err = reads.NodeToExpr(root, nil); err != nil {
+			return nil, nil, 0, 0, err
+		}
+		if reads.HasFieldValueKey(cond) {
+			return nil, nil, 0, 0, errors.New("schema predicates cannot reference _value")
+		}
+	}
+
+	start, end = tr.Start, tr.End
+	if hints.HintSchemaAllTime() || start == 0 {
+		start = models.MinNanoTime
+	}
+	if hints.HintSchemaAllTime() || end == 0 {
+		end = models.MaxNanoTime
+	}
+	return source, cond, start, end, nil
+}
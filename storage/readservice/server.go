@@ -0,0 +1,246 @@
+package readservice
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/influxdata/platform"
+	kitgrpc "github.com/influxdata/platform/kit/grpc"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/storage/reads"
+	"github.com/influxdata/platform/storage/reads/datatypes"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// authorizationKey is the gRPC metadata key holding the token of a request.
+	authorizationKey = "authorization"
+
+	// tokenScheme prefixes the token in the authorization metadata, as it
+	// does in the Authorization header of the HTTP API.
+	tokenScheme = "Token "
+
+	// stringValuesBatchSize is the maximum number of values sent in each
+	// StringValuesResponse of a schema request.
+	stringValuesBatchSize = 1000
+)
+
+// Server implements the Storage gRPC service, answering reads from an engine
+// for remote query nodes.
+type Server struct {
+	store                reads.Store
+	schema               reads.SchemaStore
+	AuthorizationService platform.AuthorizationService
+	Logger               *zap.Logger
+}
+
+// NewServer returns a Server that reads from engine, authorizing each request
+// with a token from authSvc.
+func NewServer(engine *storage.Engine, authSvc platform.AuthorizationService) *Server {
+	store := newStore(engine)
+	return &Server{
+		store:                store,
+		schema:               store,
+		AuthorizationService: authSvc,
+		Logger:               zap.NewNop(),
+	}
+}
+
+// Register registers the Storage service of s with the gRPC server gs.
+func (s *Server) Register(gs *grpc.Server) {
+	datatypes.RegisterStorageServer(gs, s)
+}
+
+// Read streams the results of req, grouped as requested, to stream.
+func (s *Server) Read(req *datatypes.ReadRequest, stream datatypes.Storage_ReadServer) error {
+	span, ctx := startServerSpan(stream.Context(), "storage.Read", req.Trace)
+	defer span.Finish()
+
+	if err := s.authorize(ctx, req.ReadSource); err != nil {
+		return statusError(err)
+	}
+
+	w := reads.NewResponseWriter(stream, req.Hints)
+	switch req.Group {
+	case datatypes.GroupAll:
+		rs, err := s.store.Read(ctx, req)
+		if err != nil {
+			return statusError(err)
+		}
+		if rs == nil {
+			return nil
+		}
+		defer rs.Close()
+		if err := w.WriteResultSet(rs); err != nil {
+			return statusError(err)
+		}
+		if err := rs.Err(); err != nil {
+			return statusError(err)
+		}
+
+	default:
+		rs, err := s.store.GroupRead(ctx, req)
+		if err != nil {
+			return statusError(err)
+		}
+		if rs == nil {
+			return nil
+		}
+		defer rs.Close()
+		if err := w.WriteGroupResultSet(rs); err != nil {
+			return statusError(err)
+		}
+		if err := rs.Err(); err != nil {
+			return statusError(err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Err(); err != nil {
+		s.Logger.Info("Failed writing read response", zap.Error(err))
+		return statusError(err)
+	}
+	return nil
+}
+
+// TagKeys streams the tag keys of the bucket of req to stream.
+func (s *Server) TagKeys(req *datatypes.TagKeysRequest, stream datatypes.Storage_TagKeysServer) error {
+	span, ctx := startServerSpan(stream.Context(), "storage.TagKeys", req.Trace)
+	defer span.Finish()
+
+	if err := s.authorize(ctx, req.ReadSource); err != nil {
+		return statusError(err)
+	}
+
+	keys, err := s.schema.TagKeys(ctx, req)
+	if err != nil {
+		return statusError(err)
+	}
+	return sendStringValues(stream, keys)
+}
+
+// TagValues streams the values of the tag key of req to stream.
+func (s *Server) TagValues(req *datatypes.TagValuesRequest, stream datatypes.Storage_TagValuesServer) error {
+	span, ctx := startServerSpan(stream.Context(), "storage.TagValues", req.Trace)
+	defer span.Finish()
+
+	if err := s.authorize(ctx, req.ReadSource); err != nil {
+		return statusError(err)
+	}
+
+	values, err := s.schema.TagValues(ctx, req)
+	if err != nil {
+		return statusError(err)
+	}
+	return sendStringValues(stream, values)
+}
+
+// stringValuesStream is the server stream of a schema request.
+type stringValuesStream interface {
+	Send(*datatypes.StringValuesResponse) error
+}
+
+// sendStringValues sends values to stream in batches.
+func sendStringValues(stream stringValuesStream, values []string) error {
+	for len(values) > 0 {
+		n := len(values)
+		if n > stringValuesBatchSize {
+			n = stringValuesBatchSize
+		}
+
+		res := &datatypes.StringValuesResponse{Values: make([][]byte, n)}
+		for i, v := range values[:n] {
+			res.Values[i] = []byte(v)
+		}
+		if err := stream.Send(res); err != nil {
+			return statusError(err)
+		}
+		values = values[n:]
+	}
+	return nil
+}
+
+// startServerSpan starts a span for a request, referring to the remote query
+// of trace if available. If the request carries no trace, a root span is created.
+func startServerSpan(ctx context.Context, operationName string, trace map[string]string) (opentracing.Span, context.Context) {
+	tracer := opentracing.GlobalTracer()
+	wireContext, _ := tracer.Extract(opentracing.TextMap, opentracing.TextMapCarrier(trace))
+	span := tracer.StartSpan(operationName, ext.RPCServerOption(wireContext))
+	return span, opentracing.ContextWithSpan(ctx, span)
+}
+
+// authorize verifies the token of the request grants read access to the bucket of its source.
+func (s *Server) authorize(ctx context.Context, src *types.Any) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var token string
+	if v := md[authorizationKey]; len(v) > 0 && strings.HasPrefix(v[0], tokenScheme) {
+		token = strings.TrimPrefix(v[0], tokenScheme)
+	}
+	if token == "" {
+		return &platform.Error{
+			Code: platform.EForbidden,
+			Op:   "readservice/authorize",
+			Msg:  "token required",
+		}
+	}
+
+	source, err := unmarshalReadSource(src)
+	if err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "readservice/authorize",
+			Err:  err,
+		}
+	}
+
+	a, err := s.AuthorizationService.FindAuthorizationByToken(ctx, token)
+	if err != nil {
+		return &platform.Error{
+			Code: platform.EForbidden,
+			Op:   "readservice/authorize",
+			Msg:  "invalid token",
+			Err:  err,
+		}
+	}
+
+	if !a.Allowed(platform.ReadBucketPermission(platform.ID(source.BucketID))) {
+		return &platform.Error{
+			Code: platform.EForbidden,
+			Op:   "readservice/authorize",
+			Msg:  "insufficient permissions to read bucket",
+		}
+	}
+	return nil
+}
+
+// Capabilities returns the capabilities of the storage engine, of which there are none.
+func (s *Server) Capabilities(context.Context, *types.Empty) (*datatypes.CapabilitiesResponse, error) {
+	return &datatypes.CapabilitiesResponse{}, nil
+}
+
+// Hints returns the hints of the storage engine, of which there are none.
+func (s *Server) Hints(context.Context, *types.Empty) (*datatypes.HintsResponse, error) {
+	return &datatypes.HintsResponse{}, nil
+}
+
+// statusError converts err to a gRPC status error that the client decodes back to a platform.Error.
+func statusError(err error) error {
+	perr, ok := err.(*platform.Error)
+	if !ok {
+		perr = &platform.Error{
+			Code: platform.EInternal,
+			Err:  err,
+		}
+	}
+
+	st, serr := kitgrpc.ToStatus(perr)
+	if serr != nil {
+		return serr
+	}
+	return st.Err()
+}
@@ -0,0 +1,263 @@
+package readservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/models"
+	fstorage "github.com/influxdata/platform/query/functions/inputs/storage"
+	"github.com/influxdata/platform/storage/reads"
+	"github.com/influxdata/platform/storage/reads/datatypes"
+	"github.com/influxdata/platform/tsdb/cursors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	testToken  = "secret"
+	testBucket = platform.ID(1)
+)
+
+// floatSeries is a series of float points served by a seriesStore.
+type floatSeries struct {
+	tags   models.Tags
+	points *cursors.FloatArray
+}
+
+// seriesStore answers reads with its series, in order.
+type seriesStore struct {
+	series []floatSeries
+}
+
+func (s *seriesStore) Read(ctx context.Context, req *datatypes.ReadRequest) (reads.ResultSet, error) {
+	return &seriesResultSet{series: s.series, i: -1}, nil
+}
+
+func (s *seriesStore) GroupRead(ctx context.Context, req *datatypes.ReadRequest) (reads.GroupResultSet, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *seriesStore) GetSource(rs fstorage.ReadSpec) (proto.Message, error) {
+	return newReadSource(rs), nil
+}
+
+type seriesResultSet struct {
+	series []floatSeries
+	i      int
+}
+
+func (r *seriesResultSet) Next() bool                 { r.i++; return r.i < len(r.series) }
+func (r *seriesResultSet) Cursor() cursors.Cursor     { return &floatCursor{a: r.series[r.i].points} }
+func (r *seriesResultSet) Tags() models.Tags          { return r.series[r.i].tags }
+func (r *seriesResultSet) Close()                     {}
+func (r *seriesResultSet) Err() error                 { return nil }
+func (r *seriesResultSet) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+// schemaSeriesStore is a seriesStore that answers schema requests with its
+// tag keys and the values of the tag key requested.
+type schemaSeriesStore struct {
+	seriesStore
+	keys   []string
+	values map[string][]string
+}
+
+func (s *schemaSeriesStore) TagKeys(ctx context.Context, req *datatypes.TagKeysRequest) ([]string, error) {
+	return s.keys, nil
+}
+
+func (s *schemaSeriesStore) TagValues(ctx context.Context, req *datatypes.TagValuesRequest) ([]string, error) {
+	return s.values[req.TagKey], nil
+}
+
+// floatCursor returns its points once.
+type floatCursor struct {
+	a *cursors.FloatArray
+}
+
+func (c *floatCursor) Close()                     {}
+func (c *floatCursor) Err() error                 { return nil }
+func (c *floatCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+func (c *floatCursor) Next() *cursors.FloatArray {
+	a := c.a
+	c.a = &cursors.FloatArray{}
+	return a
+}
+
+// newTestServers serves each store from a Server and returns a dial option
+// connecting to the server of a store by its index as the host.
+func newTestServers(t *testing.T, stores ...reads.Store) (grpc.DialOption, []string, func()) {
+	authSvc := mock.NewAuthorizationService()
+	authSvc.FindAuthorizationByTokenFn = func(ctx context.Context, token string) (*platform.Authorization, error) {
+		if token != testToken {
+			return nil, &platform.Error{Code: platform.ENotFound, Msg: "authorization not found"}
+		}
+		return &platform.Authorization{
+			Status:      platform.Active,
+			Permissions: []platform.Permission{platform.ReadBucketPermission(testBucket)},
+		}, nil
+	}
+
+	var (
+		hosts     []string
+		listeners = make(map[string]*bufconn.Listener)
+		servers   []*grpc.Server
+	)
+	for i, store := range stores {
+		host := fmt.Sprintf("storage%d", i)
+		ln := bufconn.Listen(1 << 20)
+		gs := grpc.NewServer()
+		schema, _ := store.(reads.SchemaStore)
+		(&Server{store: store, schema: schema, AuthorizationService: authSvc}).Register(gs)
+		go gs.Serve(ln)
+
+		hosts = append(hosts, host)
+		listeners[host] = ln
+		servers = append(servers, gs)
+	}
+
+	dialer := grpc.WithDialer(func(host string, timeout time.Duration) (net.Conn, error) {
+		return listeners[host].Dial()
+	})
+	return dialer, hosts, func() {
+		for _, gs := range servers {
+			gs.Stop()
+		}
+	}
+}
+
+func newTestReadRequest(t *testing.T, bucketID platform.ID) *datatypes.ReadRequest {
+	any, err := types.MarshalAny(newReadSource(fstorage.ReadSpec{BucketID: bucketID}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &datatypes.ReadRequest{ReadSource: any, Group: datatypes.GroupAll}
+}
+
+func TestRemoteStore_Read(t *testing.T) {
+	dialer, hosts, cleanup := newTestServers(t,
+		&seriesStore{series: []floatSeries{
+			{tags: models.NewTags(map[string]string{"host": "a"}), points: &cursors.FloatArray{Timestamps: []int64{1, 2}, Values: []float64{1, 2}}},
+			{tags: models.NewTags(map[string]string{"host": "c"}), points: &cursors.FloatArray{Timestamps: []int64{3}, Values: []float64{3}}},
+		}},
+		&seriesStore{series: []floatSeries{
+			{tags: models.NewTags(map[string]string{"host": "b"}), points: &cursors.FloatArray{Timestamps: []int64{4}, Values: []float64{4}}},
+		}},
+	)
+	defer cleanup()
+
+	s := newRemoteStore(fstorage.NewStaticLookup(hosts), testToken, dialer, grpc.WithInsecure())
+	defer s.Close()
+
+	rs, err := s.Read(context.Background(), newTestReadRequest(t, testBucket))
+	if err != nil {
+		t.Fatalf("unexpected error reading: %v", err)
+	}
+	defer rs.Close()
+
+	var got []string
+	var values []float64
+	for rs.Next() {
+		got = append(got, string(rs.Tags().Get([]byte("host"))))
+		cur := rs.Cursor().(cursors.FloatArrayCursor)
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			values = append(values, a.Values...)
+		}
+		cur.Close()
+	}
+	if err := rs.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"a", "b", "c"}; !cmp.Equal(got, want) {
+		t.Errorf("unexpected series -want/+got:\n%s", cmp.Diff(want, got))
+	}
+	if want := []float64{1, 2, 4, 3}; !cmp.Equal(values, want) {
+		t.Errorf("unexpected values -want/+got:\n%s", cmp.Diff(want, values))
+	}
+}
+
+func TestRemoteStore_Read_Forbidden(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		bucket platform.ID
+	}{
+		{name: "invalid token", token: "wrong", bucket: testBucket},
+		{name: "missing token", bucket: testBucket},
+		{name: "other bucket", token: testToken, bucket: platform.ID(2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialer, hosts, cleanup := newTestServers(t, &seriesStore{})
+			defer cleanup()
+
+			s := newRemoteStore(fstorage.NewStaticLookup(hosts), tt.token, dialer, grpc.WithInsecure())
+			defer s.Close()
+
+			rs, err := s.Read(context.Background(), newTestReadRequest(t, tt.bucket))
+			if err != nil {
+				t.Fatalf("unexpected error reading: %v", err)
+			}
+			defer rs.Close()
+
+			if rs.Next() {
+				t.Fatal("expected no series")
+			}
+			if code := platform.ErrorCode(rs.Err()); code != platform.EForbidden {
+				t.Errorf("unexpected error code: got %q, want %q (%v)", code, platform.EForbidden, rs.Err())
+			}
+		})
+	}
+}
+
+func TestRemoteStore_TagKeysAndValues(t *testing.T) {
+	dialer, hosts, cleanup := newTestServers(t,
+		&schemaSeriesStore{
+			keys:   []string{"_field", "_measurement", "host"},
+			values: map[string][]string{"host": {"a", "c"}},
+		},
+		&schemaSeriesStore{
+			keys:   []string{"_field", "_measurement", "host", "region"},
+			values: map[string][]string{"host": {"b", "c", "d"}},
+		},
+	)
+	defer cleanup()
+
+	s := newRemoteStore(fstorage.NewStaticLookup(hosts), testToken, dialer, grpc.WithInsecure())
+	defer s.Close()
+
+	r := reads.NewSchemaReader(s)
+	spec := fstorage.SchemaSpec{BucketID: testBucket, AllTime: true}
+
+	keys, err := r.TagKeys(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("unexpected error reading tag keys: %v", err)
+	}
+	if want := []string{"_field", "_measurement", "host", "region"}; !cmp.Equal(keys, want) {
+		t.Errorf("unexpected tag keys -want/+got:\n%s", cmp.Diff(want, keys))
+	}
+
+	spec.TagKey = "host"
+	values, err := r.TagValues(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("unexpected error reading tag values: %v", err)
+	}
+	if want := []string{"a", "b", "c", "d"}; !cmp.Equal(values, want) {
+		t.Errorf("unexpected tag values -want/+got:\n%s", cmp.Diff(want, values))
+	}
+
+	spec.BucketID = platform.ID(2)
+	if _, err := r.TagKeys(context.Background(), spec); platform.ErrorCode(err) != platform.EForbidden {
+		t.Errorf("unexpected error reading tag keys of other bucket: got %v, want %q", err, platform.EForbidden)
+	}
+}
@@ -23,19 +23,47 @@ func NewProxyQueryService(queryController *pcontrol.Controller) query.ProxyQuery
 }
 
 // AddControllerConfigDependencies sets up the dependencies on cc
-// such that "from" and "to" flux functions will work correctly.
+// such that "from" reads from engine and "to" writes to writer.
 func AddControllerConfigDependencies(
 	cc *control.Config,
 	engine *storage.Engine,
+	writer storage.PointsWriter,
+	bucketSvc platform.BucketService,
+	orgSvc platform.OrganizationService,
+) error {
+	store := newStore(engine)
+	reader := &localReader{
+		Reader:       reads.NewReader(store),
+		SchemaReader: reads.NewSchemaReader(store),
+	}
+	return AddControllerConfigDependenciesWithReader(cc, reader, writer, bucketSvc, orgSvc)
+}
+
+// localReader reads from the engine of a store.
+type localReader struct {
+	fstorage.Reader
+	fstorage.SchemaReader
+}
+
+// AddControllerConfigDependenciesWithReader sets up the dependencies on cc
+// such that "from" reads using reader, such as one returned by NewRemoteReader,
+// and "to" writes to writer. If reader is also a fstorage.SchemaReader, it answers
+// the schema functions, such as "tagKeys" and "tagValues".
+func AddControllerConfigDependenciesWithReader(
+	cc *control.Config,
+	reader fstorage.Reader,
+	writer storage.PointsWriter,
 	bucketSvc platform.BucketService,
 	orgSvc platform.OrganizationService,
 ) error {
 	bucketLookupSvc := query.FromBucketService(bucketSvc)
 	orgLookupSvc := query.FromOrganizationService(orgSvc)
+	schemaReader, _ := reader.(fstorage.SchemaReader)
 	err := inputs.InjectFromDependencies(cc.ExecutorDependencies, fstorage.Dependencies{
-		Reader:             reads.NewReader(newStore(engine)),
+		Reader:             reader,
 		BucketLookup:       bucketLookupSvc,
 		OrganizationLookup: orgLookupSvc,
+		SchemaReader:       schemaReader,
 	})
 	if err != nil {
 		return err
@@ -48,6 +76,6 @@ func AddControllerConfigDependencies(
 	return outputs.InjectToDependencies(cc.ExecutorDependencies, outputs.ToDependencies{
 		BucketLookup:       bucketLookupSvc,
 		OrganizationLookup: orgLookupSvc,
-		PointsWriter:       engine,
+		PointsWriter:       writer,
 	})
 }
@@ -114,19 +114,29 @@ func (r *readSource) String() string          { return "readSource{}" }
 func (r *readSource) ProtoMessage()           {}
 
 func (s *store) GetSource(rs fstorage.ReadSpec) (proto.Message, error) {
+	return newReadSource(rs), nil
+}
+
+// newReadSource returns the source of the bucket read by rs.
+func newReadSource(rs fstorage.ReadSpec) *readSource {
 	return &readSource{
 		BucketID:       uint64(rs.BucketID),
 		OrganizationID: uint64(rs.OrganizationID),
-	}, nil
+	}
 }
 
 func getReadSource(req *datatypes.ReadRequest) (*readSource, error) {
-	if req.ReadSource == nil {
+	return unmarshalReadSource(req.ReadSource)
+}
+
+// unmarshalReadSource decodes the readSource of a request.
+func unmarshalReadSource(any *types.Any) (*readSource, error) {
+	if any == nil {
 		return nil, errors.New("missing read source")
 	}
 
 	var source readSource
-	if err := types.UnmarshalAny(req.ReadSource, &source); err != nil {
+	if err := types.UnmarshalAny(any, &source); err != nil {
 		return nil, err
 	}
 	return &source, nil
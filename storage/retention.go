@@ -13,6 +13,7 @@ import (
 	"github.com/influxdata/platform/logger"
 	"github.com/influxdata/platform/models"
 	"github.com/influxdata/platform/tsdb"
+	"github.com/influxdata/platform/tsdb/tsm1"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
@@ -28,6 +29,19 @@ type Deleter interface {
 	DeleteSeriesRangeWithPredicate(tsdb.SeriesIterator, func([]byte, models.Tags) (int64, int64, bool)) error
 }
 
+// A FileDropper implementation is capable of partitioning the data of a storage
+// engine by the shard groups of buckets, and of removing whole files of data.
+type FileDropper interface {
+	SetShardGroupDurations(map[platform.ID]time.Duration)
+	DropFiles(func(tsm1.FileStat) bool) ([]string, error)
+}
+
+// A SeriesReclaimer implementation is capable of removing the series without
+// data from the index of a storage engine.
+type SeriesReclaimer interface {
+	ReclaimSeries() (int, error)
+}
+
 // A BucketFinder is responsible for providing access to buckets via a filter.
 type BucketFinder interface {
 	FindBuckets(context.Context, platform.BucketFilter, ...platform.FindOptions) ([]*platform.Bucket, int, error)
@@ -74,28 +88,76 @@ func (s *retentionEnforcer) WithLogger(l *zap.Logger) {
 
 // run periodically expires (deletes) all data that's fallen outside of the
 // retention period for the associated bucket.
+//
+// When the engine is a FileDropper, the files of expired shard groups are
+// removed whole first, so that only files at the boundary of the retention
+// period are rewritten by deletes. When the engine is a SeriesReclaimer, the
+// series left without data are then removed from its index.
 func (s *retentionEnforcer) run() {
 	log, logEnd := logger.NewOperation(s.logger, "Data retention check", "data_retention_check")
 	defer logEnd()
 
-	rpByBucketID, err := s.getRetentionPeriodPerBucket()
+	buckets, err := s.findBuckets()
 	if err != nil {
 		log.Error("Unable to determine bucket:RP mapping", zap.Error(err))
 		return
 	}
+	rpByBucketID := retentionPeriodPerBucket(buckets)
 
 	now := time.Now().UTC()
 	labels := s.metrics.Labels()
 	labels["status"] = "ok"
 
+	if fd, ok := s.Engine.(FileDropper); ok {
+		fd.SetShardGroupDurations(shardGroupDurationPerBucket(buckets))
+		if err := s.expireFiles(fd, rpByBucketID, now); err != nil {
+			log.Error("File removal not successful", zap.Error(err))
+			labels["status"] = "error"
+		}
+	}
+
 	if err := s.expireData(rpByBucketID, now); err != nil {
 		log.Error("Deletion not successful", zap.Error(err))
 		labels["status"] = "error"
 	}
+
+	if sr, ok := s.Engine.(SeriesReclaimer); ok {
+		if err := s.reclaimSeries(sr); err != nil {
+			log.Error("Series reclamation not successful", zap.Error(err))
+			labels["status"] = "error"
+		}
+	}
 	s.metrics.CheckDuration.With(labels).Observe(time.Since(now).Seconds())
 	s.metrics.Checks.With(labels).Inc()
 }
 
+// expireFiles removes the files holding only data of a single bucket in the
+// provided map that falls outside the bucket's indicated retention period.
+func (s *retentionEnforcer) expireFiles(fd FileDropper, rpByBucketID map[platform.ID]time.Duration, now time.Time) error {
+	_, logEnd := logger.NewOperation(s.logger, "File removal", "file_removal")
+	defer logEnd()
+
+	files, err := fd.DropFiles(func(stat tsm1.FileStat) bool {
+		bucketID, ok := fileBucket(stat)
+		if !ok {
+			return false
+		}
+
+		retentionPeriod := rpByBucketID[bucketID]
+		if retentionPeriod == 0 {
+			return false
+		}
+		return stat.MaxTime <= now.Add(-retentionPeriod).UnixNano()
+	})
+
+	if s.metrics != nil {
+		labels := s.metrics.Labels()
+		labels["status"] = "ok"
+		s.metrics.Files.With(labels).Add(float64(len(files)))
+	}
+	return err
+}
+
 // expireData runs a delete operation on the storage engine.
 //
 // Any series data that (1) belongs to a bucket in the provided map and
@@ -171,20 +233,47 @@ func (s *retentionEnforcer) expireData(rpByBucketID map[platform.ID]time.Duratio
 	return s.Engine.DeleteSeriesRangeWithPredicate(newSeriesIteratorAdapter(cur), fn)
 }
 
-// getRetentionPeriodPerBucket returns a map of (bucket ID -> retention period)
-// for all buckets.
-func (s *retentionEnforcer) getRetentionPeriodPerBucket() (map[platform.ID]time.Duration, error) {
+// reclaimSeries removes the series without data from the index of the engine.
+func (s *retentionEnforcer) reclaimSeries(sr SeriesReclaimer) error {
+	_, logEnd := logger.NewOperation(s.logger, "Series reclamation", "series_reclamation")
+	defer logEnd()
+
+	n, err := sr.ReclaimSeries()
+
+	if s.metrics != nil {
+		labels := s.metrics.Labels()
+		labels["status"] = "ok"
+		s.metrics.ReclaimedSeries.With(labels).Add(float64(n))
+	}
+	return err
+}
+
+// findBuckets returns all buckets.
+func (s *retentionEnforcer) findBuckets() ([]*platform.Bucket, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), bucketAPITimeout)
 	defer cancel()
 	buckets, _, err := s.BucketService.FindBuckets(ctx, platform.BucketFilter{})
-	if err != nil {
-		return nil, err
-	}
+	return buckets, err
+}
+
+// retentionPeriodPerBucket returns a map of (bucket ID -> retention period)
+// for buckets.
+func retentionPeriodPerBucket(buckets []*platform.Bucket) map[platform.ID]time.Duration {
 	rpByBucketID := make(map[platform.ID]time.Duration, len(buckets))
 	for _, bucket := range buckets {
 		rpByBucketID[bucket.ID] = bucket.RetentionPeriod
 	}
-	return rpByBucketID, nil
+	return rpByBucketID
+}
+
+// shardGroupDurationPerBucket returns a map of (bucket ID -> shard group
+// duration) for buckets.
+func shardGroupDurationPerBucket(buckets []*platform.Bucket) map[platform.ID]time.Duration {
+	sgdByBucketID := make(map[platform.ID]time.Duration, len(buckets))
+	for _, bucket := range buckets {
+		sgdByBucketID[bucket.ID] = bucket.EffectiveShardGroupDuration()
+	}
+	return sgdByBucketID
 }
 
 // PrometheusCollectors satisfies the prom.PrometheusCollector interface.
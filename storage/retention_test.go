@@ -1,10 +1,14 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io/ioutil"
 	"math"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -110,6 +114,214 @@ func TestService_expireData(t *testing.T) {
 	})
 }
 
+func TestService_expireFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "storage-retention-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	engine := NewEngine(dir, NewConfig())
+	if err := engine.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Close()
+
+	org, bucket := platform.ID(0x3131313131313131), platform.ID(0x3232323232323232)
+	engine.SetShardGroupDurations(map[platform.ID]time.Duration{bucket: time.Hour})
+
+	now := time.Date(2018, 4, 10, 12, 30, 0, 0, time.UTC)
+	cutoff := now.Add(-24 * time.Hour)
+	pts, err := tsdb.ExplodePoints(org, bucket, []models.Point{
+		models.MustNewPoint("cpu", nil, models.Fields{"value": 1.0}, now.Add(-48*time.Hour)),      // Expired shard group.
+		models.MustNewPoint("cpu", nil, models.Fields{"value": 2.0}, cutoff.Add(-10*time.Minute)), // Boundary shard group.
+		models.MustNewPoint("cpu", nil, models.Fields{"value": 3.0}, cutoff.Add(10*time.Minute)),
+		models.MustNewPoint("cpu", nil, models.Fields{"value": 4.0}, now.Add(-time.Hour)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.WritePoints(pts); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.engine.WriteSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := len(engine.engine.FileStore.Stats()), 3; got != exp {
+		t.Fatalf("got %d files, expected %d", got, exp)
+	}
+
+	service := newRetentionEnforcer(engine, NewTestBucketFinder())
+	rpByBucketID := map[platform.ID]time.Duration{bucket: 24 * time.Hour}
+	if err := service.expireFiles(engine, rpByBucketID, now); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := engine.engine.FileStore.Stats()
+	if got, exp := len(stats), 2; got != exp {
+		t.Fatalf("got %d files, expected %d", got, exp)
+	}
+	for _, stat := range stats {
+		if stat.MaxTime <= cutoff.UnixNano() {
+			t.Errorf("expired file %s was not removed", stat.Path)
+		}
+	}
+
+	// Files of buckets without a retention period are kept.
+	if err := service.expireFiles(engine, map[platform.ID]time.Duration{bucket: 0}, now.Add(48*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := len(engine.engine.FileStore.Stats()), 2; got != exp {
+		t.Fatalf("got %d files, expected %d", got, exp)
+	}
+}
+
+func TestService_reclaimSeries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "storage-retention-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	engine := NewEngine(dir, NewConfig())
+	if err := engine.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Close()
+
+	org, bucket := platform.ID(0x3131313131313131), platform.ID(0x3232323232323232)
+	engine.SetShardGroupDurations(map[platform.ID]time.Duration{bucket: time.Hour})
+
+	now := time.Date(2018, 4, 10, 12, 30, 0, 0, time.UTC)
+	write := func(pts ...models.Point) {
+		t.Helper()
+		exploded, err := tsdb.ExplodePoints(org, bucket, pts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := engine.WritePoints(exploded); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "a"}), models.Fields{"value": 1.0}, now.Add(-48*time.Hour)),
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "b"}), models.Fields{"value": 2.0}, now.Add(-time.Hour)),
+	)
+	if err := engine.engine.WriteSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+
+	service := newRetentionEnforcer(engine, NewTestBucketFinder())
+	if err := service.expireFiles(engine, map[platform.ID]time.Duration{bucket: 24 * time.Hour}, now); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := engine.SeriesCardinality(), int64(2); got != exp {
+		t.Fatalf("got %d series, expected %d", got, exp)
+	}
+
+	// The series of host a has no data left.
+	if err := service.reclaimSeries(engine); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := engine.SeriesCardinality(), int64(1); got != exp {
+		t.Fatalf("got %d series, expected %d", got, exp)
+	}
+
+	// A reclaimed series is indexed again when it is written.
+	write(models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "a"}), models.Fields{"value": 3.0}, now))
+	if got, exp := engine.SeriesCardinality(), int64(2); got != exp {
+		t.Fatalf("got %d series, expected %d", got, exp)
+	}
+	if n, err := engine.ReclaimSeries(); err != nil || n != 0 {
+		t.Fatalf("unexpected reclaim: %d, %v", n, err)
+	}
+}
+
+func TestEngine_ReclaimSeries_Segments(t *testing.T) {
+	dir, err := ioutil.TempDir("", "storage-retention-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := NewConfig()
+	config.WAL.Enabled = false
+	engine := NewEngine(dir, config)
+	if err := engine.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Close()
+
+	org, bucket := platform.ID(0x3131313131313131), platform.ID(0x3232323232323232)
+	engine.SetShardGroupDurations(map[platform.ID]time.Duration{bucket: time.Hour})
+
+	// segmentsSize returns the size of the series file segments.
+	segmentsSize := func() int64 {
+		t.Helper()
+		var n int64
+		if err := filepath.Walk(engine.sfile.Path(), func(path string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			} else if !fi.IsDir() && tsdb.IsValidSeriesSegmentFilename(fi.Name()) {
+				n += fi.Size()
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	// Write enough series with large keys to fill the first segment of the
+	// partitions.
+	now := time.Date(2018, 4, 10, 12, 30, 0, 0, time.UTC)
+	pts := make([]models.Point, 0, 1536)
+	for i := 0; i < cap(pts); i++ {
+		host := fmt.Sprintf("%04d%s", i, bytes.Repeat([]byte("a"), 32<<10))
+		pts = append(pts, models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": host}), models.Fields{"value": 1.0}, now.Add(-48*time.Hour)))
+	}
+	exploded, err := tsdb.ExplodePoints(org, bucket, pts)
+	if err != nil {
+		t.Fatal(err)
+	} else if err := engine.WritePoints(exploded); err != nil {
+		t.Fatal(err)
+	} else if err := engine.engine.WriteSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+
+	service := newRetentionEnforcer(engine, NewTestBucketFinder())
+	if err := service.expireFiles(engine, map[platform.ID]time.Duration{bucket: 24 * time.Hour}, now); err != nil {
+		t.Fatal(err)
+	}
+
+	before := segmentsSize()
+	if n, err := engine.ReclaimSeries(); err != nil {
+		t.Fatal(err)
+	} else if n != len(pts) {
+		t.Fatalf("got %d series reclaimed, expected %d", n, len(pts))
+	}
+
+	// The first segments only hold deleted series and are removed.
+	if after := segmentsSize(); after >= before {
+		t.Fatalf("got segments of %d bytes, expected less than %d bytes", after, before)
+	}
+	if got, exp := engine.SeriesCardinality(), int64(0); got != exp {
+		t.Fatalf("got %d series, expected %d", got, exp)
+	}
+
+	// The series file is still usable after the segments are removed.
+	exploded, err = tsdb.ExplodePoints(org, bucket, pts[:1])
+	if err != nil {
+		t.Fatal(err)
+	} else if err := engine.WritePoints(exploded); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := engine.SeriesCardinality(), int64(1); got != exp {
+		t.Fatalf("got %d series, expected %d", got, exp)
+	}
+}
+
 // genMeasurementName generates a random measurement name or panics.
 func genMeasurementName() []byte {
 	b := make([]byte, 16)
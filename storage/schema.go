@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/influxdata/influxql"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/tsdb"
+	"github.com/influxdata/platform/tsdb/tsm1"
+)
+
+// TagKeys returns the sorted, distinct tag keys of the series of a bucket that
+// match predicate and have values between start and end, inclusive. The keys
+// include the measurement and field keys of the series.
+//
+// Schema requests are answered from the index and the TSM index, without
+// reading any points. A time range spanning models.MinNanoTime to
+// models.MaxNanoTime skips the TSM index.
+func (e *Engine) TagKeys(ctx context.Context, orgID, bucketID platform.ID, start, end int64, predicate influxql.Expr) ([]string, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return nil, ErrEngineClosed
+	}
+
+	name := tsdb.EncodeName(orgID, bucketID)
+	if predicate == nil && isAllTime(start, end) {
+		return e.indexTagKeys(name[:])
+	}
+
+	keys := make(map[string]struct{})
+	err := e.forEachSeries(name[:], start, end, predicate, func(tags models.Tags) {
+		for _, t := range tags {
+			keys[string(t.Key)] = struct{}{}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sortedKeys(keys), nil
+}
+
+// TagValues returns the sorted, distinct values of tagKey of the series of a
+// bucket that match predicate and have values between start and end, inclusive.
+// The measurement and field names of the bucket are the values of the
+// tsdb.MeasurementTagKey and tsdb.FieldKeyTagKey keys.
+func (e *Engine) TagValues(ctx context.Context, orgID, bucketID platform.ID, tagKey string, start, end int64, predicate influxql.Expr) ([]string, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return nil, ErrEngineClosed
+	}
+
+	name := tsdb.EncodeName(orgID, bucketID)
+	if predicate == nil && isAllTime(start, end) {
+		return e.indexTagValues(name[:], []byte(tagKey))
+	}
+
+	key := []byte(tagKey)
+	values := make(map[string]struct{})
+	err := e.forEachSeries(name[:], start, end, predicate, func(tags models.Tags) {
+		if v := tags.Get(key); v != nil {
+			values[string(v)] = struct{}{}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sortedKeys(values), nil
+}
+
+// forEachSeries calls fn with the tags of each series of the measurement name
+// that matches predicate and has values between start and end.
+func (e *Engine) forEachSeries(name []byte, start, end int64, predicate influxql.Expr, fn func(tags models.Tags)) error {
+	mi := tsdb.NewMeasurementSliceIterator([][]byte{name})
+	cur, err := newSeriesCursor(SeriesCursorRequest{Measurements: mi}, e.index, predicate)
+	if err != nil {
+		return err
+	}
+	defer cur.Close()
+
+	allTime := isAllTime(start, end)
+	for {
+		row, err := cur.Next()
+		if err != nil {
+			return err
+		} else if row == nil {
+			return nil
+		}
+
+		if !allTime {
+			field := string(row.Tags.Get(tsdb.FieldKeyTagKeyBytes))
+			key := tsm1.SeriesFieldKeyBytes(string(models.MakeKey(row.Name, row.Tags)), field)
+			if !e.engine.HasValuesInRange(key, start, end) {
+				continue
+			}
+		}
+		fn(row.Tags)
+	}
+}
+
+// indexTagKeys returns the tag keys of the measurement name that have series.
+func (e *Engine) indexTagKeys(name []byte) ([]string, error) {
+	itr, err := e.index.TagKeyIterator(name)
+	if err != nil {
+		return nil, err
+	} else if itr == nil {
+		return nil, nil
+	}
+	defer itr.Close()
+
+	var keys []string
+	for {
+		key, err := itr.Next()
+		if err != nil {
+			return nil, err
+		} else if key == nil {
+			return keys, nil
+		}
+
+		ok, err := hasSeries(e.index.TagKeySeriesIDIterator(name, key))
+		if err != nil {
+			return nil, err
+		} else if ok {
+			keys = append(keys, string(key))
+		}
+	}
+}
+
+// indexTagValues returns the values of key in the measurement name that have series.
+func (e *Engine) indexTagValues(name, key []byte) ([]string, error) {
+	itr, err := e.index.TagValueIterator(name, key)
+	if err != nil {
+		return nil, err
+	} else if itr == nil {
+		return nil, nil
+	}
+	defer itr.Close()
+
+	var values []string
+	for {
+		value, err := itr.Next()
+		if err != nil {
+			return nil, err
+		} else if value == nil {
+			return values, nil
+		}
+
+		ok, err := hasSeries(e.index.TagValueSeriesIDIterator(name, key, value))
+		if err != nil {
+			return nil, err
+		} else if ok {
+			values = append(values, string(value))
+		}
+	}
+}
+
+// hasSeries returns true if itr returns at least one series, closing it.
+func hasSeries(itr tsdb.SeriesIDIterator, err error) (bool, error) {
+	if err != nil || itr == nil {
+		return false, err
+	}
+	defer itr.Close()
+
+	elem, err := itr.Next()
+	if err != nil {
+		return false, err
+	}
+	return !elem.SeriesID.IsZero(), nil
+}
+
+// isAllTime returns true if start and end span all representable times.
+func isAllTime(start, end int64) bool {
+	return start <= models.MinNanoTime && end >= models.MaxNanoTime
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	a := make([]string, 0, len(m))
+	for k := range m {
+		a = append(a, k)
+	}
+	sort.Strings(a)
+	return a
+}
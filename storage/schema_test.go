@@ -0,0 +1,89 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxql"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/tsdb"
+)
+
+func TestEngine_TagKeysAndValues(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	pts := []models.Point{
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "a"}), map[string]interface{}{"value": 1.0}, time.Unix(1, 0)),
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "b", "region": "west"}), map[string]interface{}{"value": 2.0}, time.Unix(10, 0)),
+		models.MustNewPoint("mem", models.NewTags(map[string]string{"host": "c"}), map[string]interface{}{"free": 3.0}, time.Unix(20, 0)),
+		models.MustNewPoint("mem", models.NewTags(map[string]string{"host": "d"}), map[string]interface{}{"free": 4.0}, time.Unix(30, 0)),
+	}
+	if err := engine.Write1xPoints(pts); err != nil {
+		t.Fatal(err)
+	}
+
+	org, _ := platform.IDFromString("3131313131313131")
+	bucket, _ := platform.IDFromString("3232323232323232")
+
+	sec := func(s int64) int64 { return time.Unix(s, 0).UnixNano() }
+	pred := func(s string) influxql.Expr {
+		if s == "" {
+			return nil
+		}
+		return influxql.MustParseExpr(s)
+	}
+
+	keysTests := []struct {
+		name       string
+		start, end int64
+		predicate  string
+		want       []string
+	}{
+		{name: "all", start: models.MinNanoTime, end: models.MaxNanoTime, want: []string{"_f", "_m", "host", "region"}},
+		{name: "time range", start: sec(0), end: sec(5), want: []string{"_f", "_m", "host"}},
+		{name: "predicate", start: models.MinNanoTime, end: models.MaxNanoTime, predicate: `_m = 'mem'`, want: []string{"_f", "_m", "host"}},
+		{name: "no series", start: sec(40), end: sec(50), want: []string{}},
+	}
+	for _, tt := range keysTests {
+		t.Run("TagKeys/"+tt.name, func(t *testing.T) {
+			got, err := engine.TagKeys(context.Background(), *org, *bucket, tt.start, tt.end, pred(tt.predicate))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, tt.want) {
+				t.Errorf("unexpected tag keys -want/+got:\n%s", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+
+	valuesTests := []struct {
+		name       string
+		key        string
+		start, end int64
+		predicate  string
+		want       []string
+	}{
+		{name: "measurements", key: tsdb.MeasurementTagKey, start: models.MinNanoTime, end: models.MaxNanoTime, want: []string{"cpu", "mem"}},
+		{name: "measurements in range", key: tsdb.MeasurementTagKey, start: sec(15), end: sec(35), want: []string{"mem"}},
+		{name: "fields", key: tsdb.FieldKeyTagKey, start: models.MinNanoTime, end: models.MaxNanoTime, predicate: `host = 'c'`, want: []string{"free"}},
+		{name: "hosts of measurement", key: "host", start: models.MinNanoTime, end: models.MaxNanoTime, predicate: `_m = 'cpu'`, want: []string{"a", "b"}},
+		{name: "hosts in range", key: "host", start: sec(25), end: sec(35), want: []string{"d"}},
+		{name: "unknown key", key: "dc", start: models.MinNanoTime, end: models.MaxNanoTime},
+	}
+	for _, tt := range valuesTests {
+		t.Run("TagValues/"+tt.name, func(t *testing.T) {
+			got, err := engine.TagValues(context.Background(), *org, *bucket, tt.key, tt.start, tt.end, pred(tt.predicate))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, tt.want) {
+				t.Errorf("unexpected tag values -want/+got:\n%s", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package writequeue
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// The metrics of the write queue are published under storage_write_queue_*.
+const (
+	namespace = "storage"
+	subsystem = "write_queue"
+)
+
+type metrics struct {
+	QueueBytes       prometheus.Gauge
+	QueueBatches     prometheus.Gauge
+	Batches          prometheus.Counter
+	Failures         prometheus.Counter
+	InvalidBatches   prometheus.Counter
+	DeadLetterPoints prometheus.Counter
+	DeadLetterBytes  prometheus.Gauge
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		QueueBytes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "bytes",
+			Help:      "Size of the points queued to be written.",
+		}),
+
+		QueueBatches: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "batches",
+			Help:      "Number of batches of points queued to be written.",
+		}),
+
+		Batches: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "written_batches_total",
+			Help:      "Number of queued batches of points written.",
+		}),
+
+		Failures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "write_failures_total",
+			Help:      "Number of failed writes of queued points that were retried.",
+		}),
+
+		InvalidBatches: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "invalid_batches_total",
+			Help:      "Number of queued batches of points dropped because they could not be read.",
+		}),
+
+		DeadLetterPoints: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "dead_letter_points_total",
+			Help:      "Number of queued points rejected by the storage engine.",
+		}),
+
+		DeadLetterBytes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "dead_letter_bytes",
+			Help:      "Size of the points in the dead letter queue.",
+		}),
+	}
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (m *metrics) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.QueueBytes,
+		m.QueueBatches,
+		m.Batches,
+		m.Failures,
+		m.InvalidBatches,
+		m.DeadLetterPoints,
+		m.DeadLetterBytes,
+	}
+}
@@ -0,0 +1,404 @@
+// Package writequeue queues the points written to a storage.PointsWriter on
+// disk.
+//
+// A Writer appends every batch of points written to it to an on-disk queue
+// and returns. The queue is written to the underlying PointsWriter in order,
+// retrying with backoff while the writes fail, and is replayed from where it
+// stopped when the Writer is opened again. Points the PointsWriter rejects,
+// such as points whose field type conflicts with the existing data, are
+// moved to a dead letter queue rather than blocking the points behind them.
+package writequeue
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/pkg/durablequeue"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultMaxDeadLetterSize is the default maximum size in bytes of the
+	// dead letter queue.
+	DefaultMaxDeadLetterSize = 100 << 20
+
+	// DefaultMinRetryBackoff and DefaultMaxRetryBackoff bound the delay
+	// between attempts to write points that failed to be written.
+	DefaultMinRetryBackoff = 100 * time.Millisecond
+	DefaultMaxRetryBackoff = 10 * time.Second
+
+	queueDir      = "queue"
+	deadLetterDir = "deadletter"
+)
+
+// Writer is a storage.PointsWriter queuing points on disk before writing them
+// to another PointsWriter.
+type Writer struct {
+	Logger *zap.Logger
+
+	// MaxSize is the maximum size in bytes of the queued points. Writes are
+	// refused with an EUnavailable error while the queue is full.
+	MaxSize int64
+
+	// MaxDeadLetterSize is the maximum size in bytes of the dead letter
+	// queue. Points rejected while it is full are logged and dropped.
+	MaxDeadLetterSize int64
+
+	// MinRetryBackoff and MaxRetryBackoff bound the delay between attempts
+	// to write points that failed to be written.
+	MinRetryBackoff time.Duration
+	MaxRetryBackoff time.Duration
+
+	writer  storage.PointsWriter
+	metrics *metrics
+
+	queue       *durablequeue.Queue
+	deadLetters *durablequeue.Queue
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWriter returns a new Writer queuing points in dir before writing them to w.
+func NewWriter(dir string, w storage.PointsWriter) *Writer {
+	return &Writer{
+		Logger:            zap.NewNop(),
+		MaxSize:           durablequeue.DefaultMaxSize,
+		MaxDeadLetterSize: DefaultMaxDeadLetterSize,
+		MinRetryBackoff:   DefaultMinRetryBackoff,
+		MaxRetryBackoff:   DefaultMaxRetryBackoff,
+		writer:            w,
+		metrics:           newMetrics(),
+		queue:             durablequeue.NewQueue(filepath.Join(dir, queueDir)),
+		deadLetters:       durablequeue.NewQueue(filepath.Join(dir, deadLetterDir)),
+	}
+}
+
+// WithLogger sets the logger of the writer.
+func (w *Writer) WithLogger(log *zap.Logger) {
+	w.Logger = log.With(zap.String("service", "write-queue"))
+}
+
+// PrometheusCollectors returns the metrics of the writer.
+func (w *Writer) PrometheusCollectors() []prometheus.Collector {
+	return w.metrics.PrometheusCollectors()
+}
+
+// Open opens the queues and starts writing the queued points, beginning
+// with the points left queued when the writer was closed.
+func (w *Writer) Open() error {
+	w.queue.MaxSize = w.MaxSize
+	if err := w.queue.Open(); err != nil {
+		return err
+	}
+	w.deadLetters.MaxSize = w.MaxDeadLetterSize
+	if err := w.deadLetters.Open(); err != nil {
+		w.queue.Close()
+		return err
+	}
+	w.updateQueueMetrics()
+
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.run()
+	}()
+	return nil
+}
+
+// Close stops writing the queued points, waiting for a write in progress,
+// and closes the queues. Points that were not written remain queued until
+// the writer is opened again.
+func (w *Writer) Close() error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+	w.wg.Wait()
+	w.cancel = nil
+
+	err := w.queue.Close()
+	if e := w.deadLetters.Close(); e != nil && err == nil {
+		err = e
+	}
+	return err
+}
+
+// WritePoints queues points to be written. The points are on disk when
+// WritePoints returns.
+func (w *Writer) WritePoints(points []models.Point) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	b, err := encodePoints(points)
+	if err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "unable to queue points",
+			Err:  err,
+		}
+	}
+
+	if err := w.queue.Append(b); err != nil {
+		if err == durablequeue.ErrQueueFull {
+			return &platform.Error{
+				Code: platform.EUnavailable,
+				Msg:  "write queue is full, retry the write later",
+				Err:  err,
+			}
+		} else if err == durablequeue.ErrEntryTooLarge {
+			return &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  "points are larger than the write queue",
+				Err:  err,
+			}
+		}
+		return err
+	}
+	w.updateQueueMetrics()
+	return nil
+}
+
+func (w *Writer) run() {
+	backoff := w.MinRetryBackoff
+	for {
+		err := w.writeNext()
+		if err == io.EOF {
+			select {
+			case <-w.queue.Appended():
+				continue
+			case <-w.ctx.Done():
+				return
+			}
+		}
+
+		if err != nil {
+			select {
+			case <-time.After(backoff):
+			case <-w.ctx.Done():
+				return
+			}
+			if backoff *= 2; backoff > w.MaxRetryBackoff {
+				backoff = w.MaxRetryBackoff
+			}
+			continue
+		}
+		backoff = w.MinRetryBackoff
+	}
+}
+
+// writeNext writes the first batch of points of the queue and removes it
+// from the queue. io.EOF is returned if the queue is empty.
+func (w *Writer) writeNext() error {
+	b, err := w.queue.Peek()
+	if err == io.EOF {
+		return err
+	} else if err != nil {
+		w.Logger.Error("Failed to read write queue", zap.Error(err))
+		return err
+	}
+
+	points, err := decodePoints(b)
+	if err != nil {
+		w.Logger.Error("Dropping invalid write queue entry", zap.Error(err))
+		w.metrics.InvalidBatches.Inc()
+		return w.advance()
+	}
+
+	if err := w.write(points); err != nil {
+		return err
+	}
+	w.metrics.Batches.Inc()
+	return w.advance()
+}
+
+// write writes points, moving the points that are rejected to the dead
+// letter queue. An error is returned if the points should be written again.
+func (w *Writer) write(points []models.Point) error {
+	err := w.writer.WritePoints(points)
+	if err == nil {
+		return nil
+	}
+
+	if pwe, ok := err.(tsdb.PartialWriteError); ok {
+		w.deadLetter(withKeys(points, pwe.DroppedKeys), pwe.Reason)
+		return nil
+	}
+
+	if !isRejected(err) {
+		if w.ctx.Err() == nil {
+			w.Logger.Info("Failed to write queued points; retrying", zap.Error(err))
+			w.metrics.Failures.Inc()
+		}
+		return err
+	}
+
+	if len(points) == 1 {
+		w.deadLetter(points, err.Error())
+		return nil
+	}
+
+	// The whole batch fails when some of its points are rejected, so find
+	// them by writing the points one at a time. Points are written again
+	// with the same timestamp, which is idempotent.
+	for i := range points {
+		if err := w.write(points[i : i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deadLetter appends points to the dead letter queue.
+func (w *Writer) deadLetter(points []models.Point, reason string) {
+	if len(points) == 0 {
+		return
+	}
+	w.Logger.Warn("Points rejected by the storage engine; moving them to the dead letter queue",
+		zap.Int("points", len(points)), zap.String("reason", reason))
+	w.metrics.DeadLetterPoints.Add(float64(len(points)))
+
+	b, err := encodeDeadLetter(&DeadLetter{Reason: reason, Points: points})
+	if err == nil {
+		err = w.deadLetters.Append(b)
+	}
+	if err != nil {
+		w.Logger.Error("Failed to append to the dead letter queue; dropping points",
+			zap.Int("points", len(points)), zap.Error(err))
+	}
+	w.updateQueueMetrics()
+}
+
+func (w *Writer) advance() error {
+	err := w.queue.Advance()
+	w.updateQueueMetrics()
+	return err
+}
+
+func (w *Writer) updateQueueMetrics() {
+	w.metrics.QueueBytes.Set(float64(w.queue.Size()))
+	w.metrics.QueueBatches.Set(float64(w.queue.Len()))
+	w.metrics.DeadLetterBytes.Set(float64(w.deadLetters.Size()))
+}
+
+// DeadLetter is a batch of points rejected by the storage engine.
+type DeadLetter struct {
+	Reason string
+	Points []models.Point
+}
+
+// NextDeadLetter removes the oldest batch of points from the dead letter
+// queue and returns it. io.EOF is returned if the dead letter queue is empty.
+func (w *Writer) NextDeadLetter() (*DeadLetter, error) {
+	b, err := w.deadLetters.Peek()
+	if err != nil {
+		return nil, err
+	}
+	dl, err := decodeDeadLetter(b)
+	if err := w.deadLetters.Advance(); err != nil {
+		return nil, err
+	}
+	w.updateQueueMetrics()
+	return dl, err
+}
+
+// isRejected returns true if err reports that the points written will never
+// be accepted.
+func isRejected(err error) bool {
+	return err == tsdb.ErrFieldTypeConflict || platform.ErrorCode(err) == platform.EInvalid
+}
+
+// withKeys returns the points of points with a key in the sorted keys.
+func withKeys(points []models.Point, keys [][]byte) []models.Point {
+	var out []models.Point
+	for _, pt := range points {
+		key := pt.Key()
+		i := sort.Search(len(keys), func(i int) bool { return bytes.Compare(keys[i], key) >= 0 })
+		if i < len(keys) && bytes.Equal(keys[i], key) {
+			out = append(out, pt)
+		}
+	}
+	return out
+}
+
+// An entry of the queue is the binary encoding of its points, each preceded
+// by its length. An entry of the dead letter queue is the reason the points
+// were rejected, preceded by its length, followed by the points.
+var errInvalidEntry = errors.New("invalid write queue entry")
+
+func encodePoints(points []models.Point) ([]byte, error) {
+	return appendPoints(nil, points)
+}
+
+func appendPoints(buf []byte, points []models.Point) ([]byte, error) {
+	var n [4]byte
+	for _, pt := range points {
+		b, err := pt.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		binary.BigEndian.PutUint32(n[:], uint32(len(b)))
+		buf = append(buf, n[:]...)
+		buf = append(buf, b...)
+	}
+	return buf, nil
+}
+
+func decodePoints(b []byte) ([]models.Point, error) {
+	var points []models.Point
+	for len(b) > 0 {
+		if len(b) < 4 {
+			return nil, errInvalidEntry
+		}
+		n := binary.BigEndian.Uint32(b)
+		b = b[4:]
+		if uint64(len(b)) < uint64(n) {
+			return nil, errInvalidEntry
+		}
+		pt, err := models.NewPointFromBytes(b[:n])
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, pt)
+		b = b[n:]
+	}
+	return points, nil
+}
+
+func encodeDeadLetter(dl *DeadLetter) ([]byte, error) {
+	buf := make([]byte, 4, 4+len(dl.Reason))
+	binary.BigEndian.PutUint32(buf, uint32(len(dl.Reason)))
+	buf = append(buf, dl.Reason...)
+	return appendPoints(buf, dl.Points)
+}
+
+func decodeDeadLetter(b []byte) (*DeadLetter, error) {
+	if len(b) < 4 {
+		return nil, errInvalidEntry
+	}
+	n := binary.BigEndian.Uint32(b)
+	b = b[4:]
+	if uint64(len(b)) < uint64(n) {
+		return nil, errInvalidEntry
+	}
+	points, err := decodePoints(b[n:])
+	if err != nil {
+		return nil, err
+	}
+	return &DeadLetter{Reason: string(b[:n]), Points: points}, nil
+}
@@ -0,0 +1,304 @@
+package writequeue_test
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/storage/writequeue"
+	"github.com/influxdata/platform/tsdb"
+)
+
+func TestWriter_WritePoints(t *testing.T) {
+	engine := NewEngine()
+	w := MustOpenWriter(t, engine)
+	defer w.Remove()
+
+	for _, line := range []string{"cpu value=1 1", "cpu value=2 2\nmem value=3 3"} {
+		if err := w.WritePoints(MustParsePoints(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	exp := []string{"cpu value=1 1", "cpu value=2 2", "mem value=3 3"}
+	if got := engine.Wait(t, len(exp)); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got points %q, expected %q", got, exp)
+	}
+}
+
+func TestWriter_RetryAndReopen(t *testing.T) {
+	engine := NewEngine()
+	engine.Fail(func(models.Point) error { return errors.New("engine is closed") })
+
+	w := MustOpenWriter(t, engine)
+	defer w.Remove()
+
+	for _, line := range []string{"cpu value=1 1", "cpu value=2 2"} {
+		if err := w.WritePoints(MustParsePoints(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	engine.WaitAttempts(t, 2)
+
+	// Points queued while the engine fails are written in order after a restart.
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	engine.Fail(nil)
+	if err := w.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []string{"cpu value=1 1", "cpu value=2 2"}
+	if got := engine.Wait(t, len(exp)); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got points %q, expected %q", got, exp)
+	}
+}
+
+func TestWriter_DeadLetter(t *testing.T) {
+	engine := NewEngine()
+	engine.Fail(func(p models.Point) error {
+		if string(p.Name()) == "conflict" {
+			return tsdb.ErrFieldTypeConflict
+		}
+		return nil
+	})
+
+	w := MustOpenWriter(t, engine)
+	defer w.Remove()
+
+	// Rejected points are moved to the dead letter queue and the other
+	// points of their batch are written.
+	if err := w.WritePoints(MustParsePoints("cpu value=1 1\nconflict value=2 2\nmem value=3 3")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WritePoints(MustParsePoints("cpu value=4 4")); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []string{"cpu value=1 1", "mem value=3 3", "cpu value=4 4"}
+	if got := engine.Wait(t, len(exp)); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got points %q, expected %q", got, exp)
+	}
+
+	dl, err := w.NextDeadLetter()
+	if err != nil {
+		t.Fatal(err)
+	} else if got, exp := dl.Reason, tsdb.ErrFieldTypeConflict.Error(); got != exp {
+		t.Fatalf("got reason %q, expected %q", got, exp)
+	} else if got, exp := PointStrings(dl.Points), []string{"conflict value=2 2"}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got dead letter points %q, expected %q", got, exp)
+	}
+	if _, err := w.NextDeadLetter(); err != io.EOF {
+		t.Fatalf("got error %v, expected io.EOF", err)
+	}
+}
+
+func TestWriter_PartialWrite(t *testing.T) {
+	engine := NewEngine()
+	engine.Partial(func(p models.Point) bool { return string(p.Name()) == "mem" })
+
+	w := MustOpenWriter(t, engine)
+	defer w.Remove()
+
+	if err := w.WritePoints(MustParsePoints("cpu value=1 1\nmem value=2 2")); err != nil {
+		t.Fatal(err)
+	}
+	engine.Wait(t, 1)
+
+	dl := MustNextDeadLetter(t, w)
+	if got, exp := dl.Reason, "schema violation"; got != exp {
+		t.Fatalf("got reason %q, expected %q", got, exp)
+	} else if got, exp := PointStrings(dl.Points), []string{"mem value=2 2"}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got dead letter points %q, expected %q", got, exp)
+	}
+}
+
+func TestWriter_Full(t *testing.T) {
+	engine := NewEngine()
+	engine.Fail(func(models.Point) error { return errors.New("engine is closed") })
+
+	w := NewWriter(t, engine)
+	w.MaxSize = 100
+	if err := w.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Remove()
+
+	if err := w.WritePoints(MustParsePoints("cpu value=1 1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WritePoints(MustParsePoints("cpu value=2 2\ncpu value=3 3")); platform.ErrorCode(err) != platform.EUnavailable {
+		t.Fatalf("got error %v, expected %s", err, platform.EUnavailable)
+	}
+}
+
+// Writer is a test wrapper for writequeue.Writer.
+type Writer struct {
+	*writequeue.Writer
+	dir string
+}
+
+// NewWriter returns a writer queuing points in a temporary directory.
+func NewWriter(tb testing.TB, engine *Engine) *Writer {
+	tb.Helper()
+
+	dir, err := ioutil.TempDir("", "writequeue")
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	w := writequeue.NewWriter(dir, engine)
+	w.MinRetryBackoff = time.Millisecond
+	w.MaxRetryBackoff = 10 * time.Millisecond
+	return &Writer{Writer: w, dir: dir}
+}
+
+// MustOpenWriter returns an open writer. Fail on error.
+func MustOpenWriter(tb testing.TB, engine *Engine) *Writer {
+	tb.Helper()
+	w := NewWriter(tb, engine)
+	if err := w.Open(); err != nil {
+		tb.Fatal(err)
+	}
+	return w
+}
+
+// Remove closes the writer and removes its queues.
+func (w *Writer) Remove() error {
+	defer os.RemoveAll(w.dir)
+	return w.Close()
+}
+
+// MustNextDeadLetter waits for a dead letter and returns it. Fail on timeout.
+func MustNextDeadLetter(tb testing.TB, w *Writer) *writequeue.DeadLetter {
+	tb.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		dl, err := w.NextDeadLetter()
+		if err == nil {
+			return dl
+		} else if err != io.EOF {
+			tb.Fatal(err)
+		} else if time.Now().After(deadline) {
+			tb.Fatal("timed out waiting for a dead letter")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// Engine records the points written to it.
+type Engine struct {
+	mu       sync.Mutex
+	fail     func(models.Point) error
+	partial  func(models.Point) bool
+	points   []string
+	attempts int
+}
+
+func NewEngine() *Engine {
+	return &Engine{}
+}
+
+// Fail makes writes of batches with a point for which fn returns an error
+// fail with that error.
+func (e *Engine) Fail(fn func(models.Point) error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.fail = fn
+}
+
+// Partial makes the engine drop the points for which fn returns true.
+func (e *Engine) Partial(fn func(models.Point) bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.partial = fn
+}
+
+func (e *Engine) WritePoints(points []models.Point) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.attempts++
+	if e.fail != nil {
+		for _, p := range points {
+			if err := e.fail(p); err != nil {
+				return err
+			}
+		}
+	}
+
+	var pwe tsdb.PartialWriteError
+	for _, p := range points {
+		if e.partial != nil && e.partial(p) {
+			pwe.Reason = "schema violation"
+			pwe.Dropped++
+			pwe.DroppedKeys = append(pwe.DroppedKeys, p.Key())
+			continue
+		}
+		e.points = append(e.points, p.String())
+	}
+	if pwe.Dropped > 0 {
+		return pwe
+	}
+	return nil
+}
+
+// Wait waits for n points to be written and returns them.
+func (e *Engine) Wait(tb testing.TB, n int) []string {
+	tb.Helper()
+	var points []string
+	e.wait(tb, func() bool {
+		points = append([]string(nil), e.points...)
+		return len(points) >= n
+	})
+	return points
+}
+
+// WaitAttempts waits for n attempts to write points.
+func (e *Engine) WaitAttempts(tb testing.TB, n int) {
+	tb.Helper()
+	e.wait(tb, func() bool { return e.attempts >= n })
+}
+
+func (e *Engine) wait(tb testing.TB, fn func() bool) {
+	tb.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		e.mu.Lock()
+		done := fn()
+		e.mu.Unlock()
+		if done {
+			return
+		} else if time.Now().After(deadline) {
+			tb.Fatal("timed out waiting for the engine")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// MustParsePoints parses lines of line protocol. Panic on error.
+func MustParsePoints(lines string) []models.Point {
+	points, err := models.ParsePointsString(lines)
+	if err != nil {
+		panic(err)
+	}
+	return points
+}
+
+// PointStrings returns the line protocol of points.
+func PointStrings(points []models.Point) []string {
+	a := make([]string, len(points))
+	for i, p := range points {
+		a[i] = strings.TrimSpace(p.String())
+	}
+	return a
+}
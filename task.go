@@ -2,6 +2,7 @@ package platform
 
 import (
 	"context"
+	"time"
 )
 
 const (
@@ -21,6 +22,8 @@ type Task struct {
 	Cron            string `json:"cron,omitempty"`
 	Offset          string `json:"offset,omitempty"`
 	LatestCompleted string `json:"latest_completed,omitempty"`
+	// Concurrency is how many runs of the task may execute at once.
+	Concurrency int64 `json:"concurrency,omitempty"`
 }
 
 // Run is a record created when a run of a task is scheduled.
@@ -33,6 +36,60 @@ type Run struct {
 	FinishedAt   string `json:"finishedAt,omitempty"`
 	RequestedAt  string `json:"requestedAt,omitempty"`
 	Log          Log    `json:"log"`
+
+	// ScriptVersion is the version of the task's script that the run executed.
+	// It is zero for runs of tasks created before script versions were recorded.
+	ScriptVersion int `json:"scriptVersion,omitempty"`
+
+	// Statistics is set once a run has executed its query.
+	Statistics *RunStatistics `json:"statistics,omitempty"`
+
+	// Try is the attempt number of a run in progress, starting at 1.
+	Try int `json:"try,omitempty"`
+
+	// QueuePosition is the position, starting at 1, of a manually requested run waiting in its task's queue.
+	// It is zero for runs that are not queued.
+	QueuePosition int `json:"queuePosition,omitempty"`
+}
+
+// RunStatistics describes how a run's query executed.
+// Durations are reported in nanoseconds.
+type RunStatistics struct {
+	// TotalDuration is the total time spent on the run's query.
+	TotalDuration time.Duration `json:"totalDuration"`
+	// QueueDuration is the time the query spent waiting to be executed.
+	QueueDuration time.Duration `json:"queueDuration"`
+	// CompileDuration is the time spent compiling the query.
+	CompileDuration time.Duration `json:"compileDuration"`
+	// PlanDuration is the time spent planning the query.
+	PlanDuration time.Duration `json:"planDuration"`
+	// ExecuteDuration is the time spent executing the query.
+	ExecuteDuration time.Duration `json:"executeDuration"`
+
+	// ValuesRead is the number of values scanned from storage.
+	ValuesRead int64 `json:"valuesRead"`
+	// BytesRead is the number of uncompressed bytes scanned from storage.
+	BytesRead int64 `json:"bytesRead"`
+	// PointsWritten is the number of points written by to().
+	PointsWritten int64 `json:"pointsWritten"`
+}
+
+// TaskVersion is an immutable record of a task's script,
+// created when the task is created and each time its script is updated.
+type TaskVersion struct {
+	TaskID  ID     `json:"taskID"`
+	Version int    `json:"version"`
+	Flux    string `json:"flux"`
+
+	// The task options parsed from Flux.
+	Name   string `json:"name"`
+	Every  string `json:"every,omitempty"`
+	Cron   string `json:"cron,omitempty"`
+	Offset string `json:"offset,omitempty"`
+
+	// AuthorID is the user who created this version, if known.
+	AuthorID  ID     `json:"authorID,omitempty"`
+	CreatedAt string `json:"createdAt"`
 }
 
 // Log represents a link to a log resource
@@ -70,12 +127,25 @@ type TaskService interface {
 
 	// RetryRun creates and returns a new run (which is a retry of another run).
 	RetryRun(ctx context.Context, taskID, runID ID) (*Run, error)
+
+	// ForceRun queues a run of the task for the given Unix timestamp, and returns the queued run.
+	ForceRun(ctx context.Context, taskID ID, scheduledFor int64) (*Run, error)
+
+	// FindTaskVersions returns the script versions of a task, oldest first.
+	FindTaskVersions(ctx context.Context, taskID ID) ([]*TaskVersion, error)
+
+	// RollbackTask updates a task's script to that of the given version, creating a new version.
+	RollbackTask(ctx context.Context, taskID ID, version int) (*Task, error)
 }
 
 // TaskUpdate represents updates to a task
 type TaskUpdate struct {
 	Flux   *string `json:"flux,omitempty"`
 	Status *string `json:"status,omitempty"`
+
+	// Concurrency changes how many runs of the task may execute at once,
+	// overriding the concurrency option of the task's script.
+	Concurrency *int64 `json:"concurrency,omitempty"`
 }
 
 // TaskFilter represents a set of filters that restrict the returned results
@@ -83,7 +153,9 @@ type TaskFilter struct {
 	After        *ID
 	Organization *ID
 	User         *ID
-	Limit        int
+	// Label restricts the tasks to those with a label of this name.
+	Label *string
+	Limit int
 }
 
 // RunFilter represents a set of filters that restrict the returned results
@@ -102,3 +174,95 @@ type LogFilter struct {
 	Task *ID
 	Run  *ID
 }
+
+// RunEvent is a change to a task run: either a state transition or a log line added to the run.
+type RunEvent struct {
+	TaskID ID     `json:"taskID"`
+	RunID  ID     `json:"runID"`
+	Time   string `json:"time,omitempty"` // RFC3339Nano
+
+	// Status is the run's new status, set when the event is a state transition.
+	Status string `json:"status,omitempty"`
+
+	// Log is set when the event adds a log line to the run.
+	Log Log `json:"log,omitempty"`
+
+	// Done is set when the run has reached a final state; no more events follow for the run.
+	Done bool `json:"done,omitempty"`
+}
+
+// RunEventFilter restricts the run events delivered to a subscriber.
+type RunEventFilter struct {
+	Task ID
+	// Run restricts the events to a single run of Task, when set.
+	Run *ID
+}
+
+// RunEventService delivers task run events as they happen.
+type RunEventService interface {
+	// SubscribeRunEvents delivers the run events matching filter on the returned channel,
+	// until ctx is canceled, at which point the channel is closed.
+	SubscribeRunEvents(ctx context.Context, filter RunEventFilter) (<-chan RunEvent, error)
+}
+
+// TaskValidationRequest asks what a task script would do, without creating the task.
+type TaskValidationRequest struct {
+	Organization ID     `json:"orgID"`
+	Flux         string `json:"flux"`
+
+	// Now is the time the script is compiled and executed as of, in RFC3339 format. It defaults to the current time.
+	Now string `json:"now,omitempty"`
+	// ScheduleCount is how many upcoming scheduled times to return. It defaults to 5.
+	ScheduleCount int `json:"scheduleCount,omitempty"`
+	// Execute runs the script once as of Now and returns its results.
+	// Output that the script would write with to() is returned instead of being written.
+	Execute bool `json:"execute,omitempty"`
+}
+
+// TaskValidation describes what a task script would do.
+type TaskValidation struct {
+	// The task options parsed from Flux.
+	Name        string `json:"name"`
+	Every       string `json:"every,omitempty"`
+	Cron        string `json:"cron,omitempty"`
+	Offset      string `json:"offset,omitempty"`
+	Concurrency int64  `json:"concurrency"`
+	Retry       int64  `json:"retry"`
+
+	// Schedule is the times of the next scheduled runs after Now, in RFC3339 format.
+	// Each run executes Offset after its scheduled time.
+	Schedule []string `json:"schedule"`
+
+	ReadBuckets  []TaskValidationBucket `json:"readBuckets"`
+	WriteBuckets []TaskValidationBucket `json:"writeBuckets"`
+
+	// Plan is the physical query plan of the script.
+	Plan []TaskValidationPlanNode `json:"plan"`
+
+	// Results are the results of executing the script, as annotated CSV, when execution was requested.
+	Results string `json:"results,omitempty"`
+}
+
+// TaskValidationBucket is a bucket a task script reads or writes.
+type TaskValidationBucket struct {
+	ID           ID     `json:"id,omitempty"`
+	Name         string `json:"name"`
+	Organization string `json:"organization,omitempty"`
+	// Permitted reports whether the requester's authorization allows the access.
+	Permitted bool `json:"permitted"`
+	// Error explains why the bucket could not be found, if it could not.
+	Error string `json:"error,omitempty"`
+}
+
+// TaskValidationPlanNode is a node of a task script's query plan.
+type TaskValidationPlanNode struct {
+	ID           string   `json:"id"`
+	Kind         string   `json:"kind"`
+	Predecessors []string `json:"predecessors,omitempty"`
+}
+
+// TaskValidationService validates task scripts.
+type TaskValidationService interface {
+	// ValidateTask returns what the task script would do, or an error if it is not a valid task.
+	ValidateTask(ctx context.Context, req TaskValidationRequest) (*TaskValidation, error)
+}
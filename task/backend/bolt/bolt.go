@@ -9,6 +9,8 @@
 //    bucket(/tasks/v1/user_by_task_id) key(:task_id) -> The user ID (stored as encoded string) associated with given task.
 //    buket(/tasks/v1/name_by_task_id) key(:task_id) -> The user-supplied name of the script.
 //    bucket(/tasks/v1/run_ids) -> Counter for run IDs
+//    bucket(/tasks/v1/leases) key(:task_id) -> Expiration of the task's lease as a big-endian int64, followed by the lease owner.
+//    bucket(/tasks/v1/versions).bucket(:task_id) key(:version) -> JSON encoded script version of the task; versions are big-endian uint32s.
 //    bucket(/tasks/v1/orgs).bucket(:org_id) key(:task_id) -> Empty content; presence of :task_id allows for lookup from org to tasks.
 //    bucket(/tasks/v1/users).bucket(:user_id) key(:task_id) -> Empty content; presence of :task_id allows for lookup from user to tasks.
 // Note that task IDs are stored big-endian uint64s for sorting purposes,
@@ -60,6 +62,8 @@ var (
 	userByTaskID = []byte(basePath + "user_by_task_id")
 	nameByTaskID = []byte(basePath + "name_by_task_id")
 	runIDs       = []byte(basePath + "run_ids")
+	leasesPath   = []byte(basePath + "leases")
+	versionsPath = []byte(basePath + "versions")
 )
 
 // New gives us a new Store based on "github.com/coreos/bbolt"
@@ -79,7 +83,7 @@ func New(db *bolt.DB, rootBucket string) (*Store, error) {
 		for _, b := range [][]byte{
 			tasksPath, orgsPath, usersPath, taskMetaPath,
 			orgByTaskID, userByTaskID,
-			nameByTaskID, runIDs,
+			nameByTaskID, runIDs, leasesPath, versionsPath,
 		} {
 			_, err := root.CreateBucketIfNotExists(b)
 			if err != nil {
@@ -168,6 +172,11 @@ func (s *Store) CreateTask(ctx context.Context, req backend.CreateTaskRequest) (
 			return err
 		}
 
+		// first script version
+		if err := putTaskVersion(b, encodedID, backend.NewStoreTaskVersion(id, 1, req.Script, o, req.User)); err != nil {
+			return err
+		}
+
 		stm := backend.NewStoreTaskMeta(req, o)
 		stmBytes, err := stm.Marshal()
 		if err != nil {
@@ -206,7 +215,17 @@ func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (
 		}
 		res.OldScript = string(v)
 
+		var userID, orgID platform.ID
+		if err := userID.Decode(b.Bucket(userByTaskID).Get(encodedID)); err != nil {
+			return err
+		}
+
+		if err := orgID.Decode(b.Bucket(orgByTaskID).Get(encodedID)); err != nil {
+			return err
+		}
+
 		newScript := req.Script
+		version := latestTaskVersion(b, encodedID)
 		if req.Script == "" {
 			// Need to build op from existing script.
 			op, err = options.FromScript(string(v))
@@ -221,15 +240,24 @@ func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (
 			if err := b.Bucket(nameByTaskID).Put(encodedID, []byte(op.Name)); err != nil {
 				return err
 			}
-		}
-
-		var userID, orgID platform.ID
-		if err := userID.Decode(b.Bucket(userByTaskID).Get(encodedID)); err != nil {
-			return err
-		}
-
-		if err := orgID.Decode(b.Bucket(orgByTaskID).Get(encodedID)); err != nil {
-			return err
+			if req.Script != res.OldScript {
+				if version == 0 {
+					// The task was created before its versions were recorded,
+					// keep its original script as the first version.
+					oldOp, err := options.FromScript(res.OldScript)
+					if err != nil {
+						return err
+					}
+					version++
+					if err := putTaskVersion(b, encodedID, backend.NewStoreTaskVersion(req.ID, version, res.OldScript, oldOp, userID)); err != nil {
+						return err
+					}
+				}
+				version++
+				if err := putTaskVersion(b, encodedID, backend.NewStoreTaskVersion(req.ID, version, req.Script, op, req.User)); err != nil {
+					return err
+				}
+			}
 		}
 
 		stmBytes := b.Bucket(taskMetaPath).Get(encodedID)
@@ -241,8 +269,13 @@ func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (
 			return err
 		}
 		res.OldStatus = backend.TaskStatus(stm.Status)
-		if req.Status != "" {
-			stm.Status = string(req.Status)
+		if req.Status != "" || req.MaxConcurrency != 0 {
+			if req.Status != "" {
+				stm.Status = string(req.Status)
+			}
+			if req.MaxConcurrency != 0 {
+				stm.MaxConcurrency = req.MaxConcurrency
+			}
 			stmBytes, err = stm.Marshal()
 			if err != nil {
 				return err
@@ -254,11 +287,12 @@ func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (
 		res.NewMeta = stm
 
 		res.NewTask = backend.StoreTask{
-			ID:     req.ID,
-			Org:    orgID,
-			User:   userID,
-			Name:   op.Name,
-			Script: newScript,
+			ID:      req.ID,
+			Org:     orgID,
+			User:    userID,
+			Name:    op.Name,
+			Script:  newScript,
+			Version: version,
 		}
 
 		return nil
@@ -311,6 +345,25 @@ func (s *Store) ListTasks(ctx context.Context, params backend.TaskSearchParams)
 		} else {
 			c = b.Bucket(tasksPath).Cursor()
 		}
+		appendID := func(k []byte) error {
+			var nID platform.ID
+			if err := nID.Decode(k); err != nil {
+				return err
+			}
+			if params.Match != nil {
+				orgID := params.Org
+				if !orgID.Valid() {
+					if err := orgID.Decode(b.Bucket(orgByTaskID).Get(k)); err != nil {
+						return err
+					}
+				}
+				if !params.Match(nID, orgID) {
+					return nil
+				}
+			}
+			taskIDs = append(taskIDs, nID)
+			return nil
+		}
 		if params.After.Valid() {
 			encodedAfter, err := params.After.Encode()
 			if err != nil {
@@ -318,19 +371,15 @@ func (s *Store) ListTasks(ctx context.Context, params backend.TaskSearchParams)
 			}
 			c.Seek(encodedAfter)
 			for k, _ := c.Next(); k != nil && len(taskIDs) < lim; k, _ = c.Next() {
-				var nID platform.ID
-				if err := nID.Decode(k); err != nil {
+				if err := appendID(k); err != nil {
 					return err
 				}
-				taskIDs = append(taskIDs, nID)
 			}
 		} else {
 			for k, _ := c.First(); k != nil && len(taskIDs) < lim; k, _ = c.Next() {
-				var nID platform.ID
-				if err := nID.Decode(k); err != nil {
+				if err := appendID(k); err != nil {
 					return err
 				}
-				taskIDs = append(taskIDs, nID)
 			}
 		}
 
@@ -349,6 +398,7 @@ func (s *Store) ListTasks(ctx context.Context, params backend.TaskSearchParams)
 				tasks[i].Task.ID = taskIDs[i]
 				tasks[i].Task.Script = string(b.Bucket(tasksPath).Get(encodedID))
 				tasks[i].Task.Name = string(b.Bucket(nameByTaskID).Get(encodedID))
+				tasks[i].Task.Version = latestTaskVersion(b, encodedID)
 			}
 		}
 		if params.Org.Valid() {
@@ -447,6 +497,7 @@ func (s *Store) ListTasks(ctx context.Context, params backend.TaskSearchParams)
 func (s *Store) FindTaskByID(ctx context.Context, id platform.ID) (*backend.StoreTask, error) {
 	var userID, orgID platform.ID
 	var script, name string
+	var version uint32
 	encodedID, err := id.Encode()
 	if err != nil {
 		return nil, err
@@ -468,6 +519,7 @@ func (s *Store) FindTaskByID(ctx context.Context, id platform.ID) (*backend.Stor
 		}
 
 		name = string(b.Bucket(nameByTaskID).Get(encodedID))
+		version = latestTaskVersion(b, encodedID)
 		return nil
 	})
 	if err != nil {
@@ -475,11 +527,12 @@ func (s *Store) FindTaskByID(ctx context.Context, id platform.ID) (*backend.Stor
 	}
 
 	return &backend.StoreTask{
-		ID:     id,
-		Org:    orgID,
-		User:   userID,
-		Name:   name,
-		Script: script,
+		ID:      id,
+		Org:     orgID,
+		User:    userID,
+		Name:    name,
+		Script:  script,
+		Version: version,
 	}, err
 }
 
@@ -508,6 +561,7 @@ func (s *Store) FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*back
 	var stmBytes []byte
 	var userID, orgID platform.ID
 	var script, name string
+	var version uint32
 	encodedID, err := id.Encode()
 	if err != nil {
 		return nil, nil, err
@@ -532,6 +586,7 @@ func (s *Store) FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*back
 		}
 
 		name = string(b.Bucket(nameByTaskID).Get(encodedID))
+		version = latestTaskVersion(b, encodedID)
 		return nil
 	})
 	if err != nil {
@@ -544,11 +599,12 @@ func (s *Store) FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*back
 	}
 
 	return &backend.StoreTask{
-		ID:     id,
-		Org:    orgID,
-		User:   userID,
-		Name:   name,
-		Script: script,
+		ID:      id,
+		Org:     orgID,
+		User:    userID,
+		Name:    name,
+		Script:  script,
+		Version: version,
 	}, &stm, nil
 }
 
@@ -569,6 +625,12 @@ func (s *Store) DeleteTask(ctx context.Context, id platform.ID) (deleted bool, e
 		if err := b.Bucket(tasksPath).Delete(encodedID); err != nil {
 			return err
 		}
+		if err := b.Bucket(leasesPath).Delete(encodedID); err != nil {
+			return err
+		}
+		if err := deleteTaskVersions(b, encodedID); err != nil {
+			return err
+		}
 		user := b.Bucket(userByTaskID).Get(encodedID)
 		if len(user) > 0 {
 			if err := b.Bucket(usersPath).Bucket(user).Delete(encodedID); err != nil {
@@ -642,6 +704,15 @@ func (s *Store) CreateNextRun(ctx context.Context, taskID platform.ID, now int64
 
 // FinishRun removes runID from the list of running tasks and if its `now` is later then last completed update it.
 func (s *Store) FinishRun(ctx context.Context, taskID, runID platform.ID) error {
+	return s.finishRun(taskID, runID, (*backend.StoreTaskMeta).FinishRun)
+}
+
+// SucceedRun finishes runID and if its `now` is later then last succeeded update it.
+func (s *Store) SucceedRun(ctx context.Context, taskID, runID platform.ID) error {
+	return s.finishRun(taskID, runID, (*backend.StoreTaskMeta).SucceedRun)
+}
+
+func (s *Store) finishRun(taskID, runID platform.ID, finish func(*backend.StoreTaskMeta, platform.ID) bool) error {
 	encodedID, err := taskID.Encode()
 	if err != nil {
 		return err
@@ -654,7 +725,7 @@ func (s *Store) FinishRun(ctx context.Context, taskID, runID platform.ID) error
 		if err := stm.Unmarshal(stmBytes); err != nil {
 			return err
 		}
-		if !stm.FinishRun(runID) {
+		if !finish(&stm, runID) {
 			return ErrRunNotFound
 		}
 
@@ -667,6 +738,42 @@ func (s *Store) FinishRun(ctx context.Context, taskID, runID platform.ID) error
 	})
 }
 
+// IncrementRunTry increments the try of the running run with runID.
+func (s *Store) IncrementRunTry(ctx context.Context, taskID, runID platform.ID) (uint32, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return 0, err
+	}
+
+	var try uint32
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		stmBytes := b.Bucket(taskMetaPath).Get(encodedID)
+		if stmBytes == nil {
+			return backend.ErrTaskNotFound
+		}
+		var stm backend.StoreTaskMeta
+		if err := stm.Unmarshal(stmBytes); err != nil {
+			return err
+		}
+		var ok bool
+		try, ok = stm.IncrementRunTry(runID)
+		if !ok {
+			return ErrRunNotFound
+		}
+
+		stmBytes, err := stm.Marshal()
+		if err != nil {
+			return err
+		}
+
+		return tx.Bucket(s.bucket).Bucket(taskMetaPath).Put(encodedID, stmBytes)
+	}); err != nil {
+		return 0, err
+	}
+	return try, nil
+}
+
 func (s *Store) ManuallyRunTimeRange(_ context.Context, taskID platform.ID, start, end, requestedAt int64) (*backend.StoreTaskMetaManualRun, error) {
 	encodedID, err := taskID.Encode()
 	if err != nil {
@@ -744,6 +851,12 @@ func (s *Store) DeleteUser(ctx context.Context, id platform.ID) error {
 			if err := b.Bucket(nameByTaskID).Delete(k); err != nil {
 				return err
 			}
+			if err := b.Bucket(leasesPath).Delete(k); err != nil {
+				return err
+			}
+			if err := deleteTaskVersions(b, k); err != nil {
+				return err
+			}
 
 			org := b.Bucket(orgByTaskID).Get(k)
 			if len(org) > 0 {
@@ -808,6 +921,12 @@ func (s *Store) DeleteOrg(ctx context.Context, id platform.ID) error {
 			if err := b.Bucket(nameByTaskID).Delete(k); err != nil {
 				return err
 			}
+			if err := b.Bucket(leasesPath).Delete(k); err != nil {
+				return err
+			}
+			if err := deleteTaskVersions(b, k); err != nil {
+				return err
+			}
 			user := b.Bucket(userByTaskID).Get(k)
 			if len(user) > 0 {
 				ub := b.Bucket(usersPath).Bucket(user)
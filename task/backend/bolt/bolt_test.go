@@ -1,6 +1,7 @@
 package bolt_test
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -49,3 +50,69 @@ func TestBoltStore(t *testing.T) {
 		},
 	)(t)
 }
+
+func TestBoltStore_UpdateTaskWithoutVersions(t *testing.T) {
+	f, err := ioutil.TempFile("", "influx_bolt_task_store_test")
+	if err != nil {
+		t.Fatalf("failed to create tempfile for test db %v\n", err)
+	}
+	defer os.Remove(f.Name())
+	db, err := bolt.Open(f.Name(), os.ModeTemporary, nil)
+	if err != nil {
+		t.Fatalf("failed to open bolt db for test db %v\n", err)
+	}
+	s, err := boltstore.New(db, "testbucket")
+	if err != nil {
+		t.Fatalf("failed to create new bolt store %v\n", err)
+	}
+	defer s.Close()
+
+	const script = `option task = {
+		name: "a task",
+		every: 1m,
+	}
+from(bucket:"test") |> range(start:-1h)`
+	const script2 = `option task = {
+		name: "a task2",
+		every: 2m,
+	}
+from(bucket:"test") |> range(start:-2h)`
+
+	ctx := context.Background()
+	id, err := s.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Drop the recorded versions, as for a task created before they were.
+	if err := db.Update(func(tx *bolt.Tx) error {
+		encodedID, err := id.Encode()
+		if err != nil {
+			return err
+		}
+		return tx.Bucket([]byte("testbucket")).Bucket([]byte("/tasks/v1/versions")).DeleteBucket(encodedID)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := s.UpdateTask(ctx, backend.UpdateTaskRequest{ID: id, Script: script2, User: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.NewTask.Version != 2 {
+		t.Fatalf("expected updated task at version 2, got %d", res.NewTask.Version)
+	}
+
+	versions, err := s.ListTaskVersions(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %d", len(versions))
+	}
+	if v := versions[0]; v.Version != 1 || v.Script != script || v.Author != 2 || v.Options.Name != "a task" {
+		t.Fatalf("expected the original script as version 1, got %#v", v)
+	}
+	if v := versions[1]; v.Version != 2 || v.Script != script2 || v.Author != 3 {
+		t.Fatalf("expected the new script as version 2, got %#v", v)
+	}
+}
@@ -0,0 +1,107 @@
+package bolt
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+)
+
+var _ backend.LeaseStore = (*Store)(nil)
+
+func encodeLease(l backend.TaskLease) []byte {
+	buf := make([]byte, 8+len(l.Owner))
+	binary.BigEndian.PutUint64(buf, uint64(l.Expires))
+	copy(buf[8:], l.Owner)
+	return buf
+}
+
+func decodeLease(taskID platform.ID, buf []byte) (backend.TaskLease, error) {
+	if len(buf) < 8 {
+		return backend.TaskLease{}, errors.New("invalid task lease")
+	}
+	return backend.TaskLease{
+		TaskID:  taskID,
+		Owner:   string(buf[8:]),
+		Expires: int64(binary.BigEndian.Uint64(buf)),
+	}, nil
+}
+
+// AcquireTaskLease leases the task to owner until expires, unless another owner holds an unexpired lease.
+func (s *Store) AcquireTaskLease(ctx context.Context, taskID platform.ID, owner string, now, expires int64) error {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(taskMetaPath).Get(encodedID) == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		if v := b.Bucket(leasesPath).Get(encodedID); v != nil {
+			l, err := decodeLease(taskID, v)
+			if err != nil {
+				return err
+			}
+			if l.Owner != owner && !l.Expired(now) {
+				return backend.ErrTaskLeased
+			}
+		}
+
+		return b.Bucket(leasesPath).Put(encodedID, encodeLease(backend.TaskLease{
+			TaskID:  taskID,
+			Owner:   owner,
+			Expires: expires,
+		}))
+	})
+}
+
+// ReleaseTaskLease removes the lease on the task, if it is held by owner.
+func (s *Store) ReleaseTaskLease(ctx context.Context, taskID platform.ID, owner string) error {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket).Bucket(leasesPath)
+		v := b.Get(encodedID)
+		if v == nil {
+			return backend.ErrTaskLeaseNotFound
+		}
+		l, err := decodeLease(taskID, v)
+		if err != nil {
+			return err
+		}
+		if l.Owner != owner {
+			return backend.ErrTaskLeased
+		}
+		return b.Delete(encodedID)
+	})
+}
+
+// FindTaskLease returns the lease on the task.
+func (s *Store) FindTaskLease(ctx context.Context, taskID platform.ID) (*backend.TaskLease, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	var l backend.TaskLease
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(s.bucket).Bucket(leasesPath).Get(encodedID)
+		if v == nil {
+			return backend.ErrTaskLeaseNotFound
+		}
+		l, err = decodeLease(taskID, v)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
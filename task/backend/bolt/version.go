@@ -0,0 +1,146 @@
+package bolt
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/options"
+)
+
+// taskVersion is the JSON encoding of a backend.StoreTaskVersion.
+// The task ID and version are stored in the bucket and key, respectively.
+type taskVersion struct {
+	Script    string          `json:"script"`
+	Options   options.Options `json:"options"`
+	Author    uint64          `json:"author,omitempty"`
+	CreatedAt int64           `json:"createdAt"`
+}
+
+func encodeVersionKey(version uint32) []byte {
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, version)
+	return buf
+}
+
+func decodeTaskVersion(taskID platform.ID, k, v []byte) (backend.StoreTaskVersion, error) {
+	var tv taskVersion
+	if err := json.Unmarshal(v, &tv); err != nil {
+		return backend.StoreTaskVersion{}, err
+	}
+	return backend.StoreTaskVersion{
+		TaskID:    taskID,
+		Version:   binary.BigEndian.Uint32(k),
+		Script:    tv.Script,
+		Options:   tv.Options,
+		Author:    platform.ID(tv.Author),
+		CreatedAt: tv.CreatedAt,
+	}, nil
+}
+
+// putTaskVersion records v in the versions bucket b.
+func putTaskVersion(b *bolt.Bucket, encodedID []byte, v backend.StoreTaskVersion) error {
+	vb, err := b.Bucket(versionsPath).CreateBucketIfNotExists(encodedID)
+	if err != nil {
+		return err
+	}
+	buf, err := json.Marshal(taskVersion{
+		Script:    v.Script,
+		Options:   v.Options,
+		Author:    uint64(v.Author),
+		CreatedAt: v.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	return vb.Put(encodeVersionKey(v.Version), buf)
+}
+
+// latestTaskVersion returns the most recent script version of the task, or 0 if the task has no recorded versions.
+func latestTaskVersion(b *bolt.Bucket, encodedID []byte) uint32 {
+	vb := b.Bucket(versionsPath).Bucket(encodedID)
+	if vb == nil {
+		return 0
+	}
+	k, _ := vb.Cursor().Last()
+	if k == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(k)
+}
+
+// deleteTaskVersions removes every script version of the task.
+func deleteTaskVersions(b *bolt.Bucket, encodedID []byte) error {
+	if b.Bucket(versionsPath).Bucket(encodedID) == nil {
+		return nil
+	}
+	return b.Bucket(versionsPath).DeleteBucket(encodedID)
+}
+
+// ListTaskVersions returns every recorded script version of the task, oldest first.
+func (s *Store) ListTaskVersions(ctx context.Context, taskID platform.ID) ([]backend.StoreTaskVersion, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []backend.StoreTaskVersion
+	err = s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(tasksPath).Get(encodedID) == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		vb := b.Bucket(versionsPath).Bucket(encodedID)
+		if vb == nil {
+			return nil
+		}
+		return vb.ForEach(func(k, v []byte) error {
+			tv, err := decodeTaskVersion(taskID, k, v)
+			if err != nil {
+				return err
+			}
+			versions = append(versions, tv)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// FindTaskVersion returns the given script version of the task.
+func (s *Store) FindTaskVersion(ctx context.Context, taskID platform.ID, version uint32) (*backend.StoreTaskVersion, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	var tv backend.StoreTaskVersion
+	err = s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(tasksPath).Get(encodedID) == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		vb := b.Bucket(versionsPath).Bucket(encodedID)
+		if vb == nil {
+			return backend.ErrTaskVersionNotFound
+		}
+		k := encodeVersionKey(version)
+		v := vb.Get(k)
+		if v == nil {
+			return backend.ErrTaskVersionNotFound
+		}
+		tv, err = decodeTaskVersion(taskID, k, v)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tv, nil
+}
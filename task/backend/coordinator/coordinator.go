@@ -3,6 +3,8 @@ package coordinator
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/task/backend"
@@ -16,6 +18,18 @@ type Coordinator struct {
 	sch    backend.Scheduler
 
 	limit int
+
+	// Set by WithLeases. Without leases, the coordinator claims every task in the store.
+	leases    backend.LeaseStore
+	owner     string
+	leaseTTL  time.Duration
+	heartbeat time.Duration
+
+	mu     sync.Mutex
+	leased map[platform.ID]string // ID of each task claimed under a lease -> script claimed in the scheduler.
+
+	cancel context.CancelFunc
+	done   chan struct{}
 }
 
 type Option func(*Coordinator)
@@ -26,23 +40,226 @@ func WithLimit(i int) Option {
 	}
 }
 
+// WithLeases shares the store with the coordinators of other nodes.
+// A task is only claimed in the scheduler while owner holds its lease, which lasts for ttl.
+// Leases are renewed on a heartbeat, and the tasks of an owner that stops renewing its leases,
+// including their currently running runs, are claimed by other owners once the leases expire.
+func WithLeases(ls backend.LeaseStore, owner string, ttl time.Duration) Option {
+	return func(c *Coordinator) {
+		c.leases = ls
+		c.owner = owner
+		c.leaseTTL = ttl
+		if c.heartbeat == 0 {
+			c.heartbeat = ttl / 3
+		}
+	}
+}
+
+// WithHeartbeat sets how often leases are renewed and unleased tasks claimed.
+// It defaults to a third of the lease TTL.
+func WithHeartbeat(d time.Duration) Option {
+	return func(c *Coordinator) {
+		c.heartbeat = d
+	}
+}
+
 func New(logger *zap.Logger, scheduler backend.Scheduler, st backend.Store, opts ...Option) *Coordinator {
 	c := &Coordinator{
 		logger: logger,
 		sch:    scheduler,
 		Store:  st,
 		limit:  1000,
+		leased: make(map[platform.ID]string),
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
-	go c.claimExistingTasks()
+	if c.leases == nil {
+		go c.claimExistingTasks()
+		return c
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+	go c.renewLeases(ctx)
 
 	return c
 }
 
+// Close stops renewing leases and releases the leases held by c, so that other owners may claim the tasks right away.
+// It then closes the underlying store.
+func (c *Coordinator) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+
+		c.mu.Lock()
+		for id := range c.leased {
+			if err := c.leases.ReleaseTaskLease(context.Background(), id, c.owner); err != nil && err != backend.ErrTaskLeaseNotFound {
+				c.logger.Info("failed to release task lease", zap.Stringer("task_id", id), zap.Error(err))
+			}
+			delete(c.leased, id)
+		}
+		c.mu.Unlock()
+	}
+
+	return c.Store.Close()
+}
+
+// renewLeases runs the lease heartbeat until ctx is canceled.
+func (c *Coordinator) renewLeases(ctx context.Context) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		c.Heartbeat(ctx, time.Now())
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Heartbeat renews the leases held by c and claims the active tasks whose lease is available.
+// Tasks whose lease was lost, that were disabled, or that were deleted are released from the scheduler,
+// and tasks updated through another coordinator are updated in the scheduler.
+// Heartbeat is called periodically when c uses leases; it is exported so tests can control the time.
+func (c *Coordinator) Heartbeat(ctx context.Context, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := now.Add(c.leaseTTL).Unix()
+
+	seen := make(map[platform.ID]bool, len(c.leased))
+	tasks, err := c.Store.ListTasks(ctx, backend.TaskSearchParams{})
+	for err == nil && len(tasks) > 0 {
+		for _, task := range tasks {
+			t := task // Copy to avoid mistaken closure around task value.
+			seen[t.Task.ID] = true
+			c.heartbeatTask(ctx, &t, now.Unix(), expires)
+		}
+		tasks, err = c.Store.ListTasks(ctx, backend.TaskSearchParams{
+			After: tasks[len(tasks)-1].Task.ID,
+		})
+	}
+	if err != nil {
+		// Without a full listing, we can't tell which tasks were deleted.
+		c.logger.Error("failed to list tasks", zap.Error(err))
+		return
+	}
+
+	for id := range c.leased {
+		if !seen[id] {
+			// Deleted through another coordinator.
+			c.releaseLeasedTask(ctx, id)
+		}
+	}
+}
+
+// heartbeatTask renews or acquires the lease for a single task. c.mu must be held.
+func (c *Coordinator) heartbeatTask(ctx context.Context, t *backend.StoreTaskWithMeta, now, expires int64) {
+	id := t.Task.ID
+	script, owned := c.leased[id]
+
+	if t.Meta.Status != string(backend.TaskActive) {
+		if owned {
+			c.releaseLeasedTask(ctx, id)
+		}
+		return
+	}
+
+	if err := c.leases.AcquireTaskLease(ctx, id, c.owner, now, expires); err != nil {
+		if err != backend.ErrTaskLeased {
+			c.logger.Info("failed to acquire task lease", zap.Stringer("task_id", id), zap.Error(err))
+			return
+		}
+		if owned {
+			// Another owner took over after our lease expired; stop executing the task here.
+			c.logger.Info("lost task lease", zap.Stringer("task_id", id))
+			if err := c.sch.ReleaseTask(id); err != nil && err != backend.ErrTaskNotClaimed {
+				c.logger.Info("failed to release task", zap.Stringer("task_id", id), zap.Error(err))
+			}
+			delete(c.leased, id)
+		}
+		return
+	}
+
+	switch {
+	case !owned:
+		if err := c.sch.ClaimTask(&t.Task, &t.Meta); err != nil && err != backend.ErrTaskAlreadyClaimed {
+			c.logger.Error("failed claim task", zap.Stringer("task_id", id), zap.Error(err))
+			if err := c.leases.ReleaseTaskLease(ctx, id, c.owner); err != nil {
+				c.logger.Info("failed to release task lease", zap.Stringer("task_id", id), zap.Error(err))
+			}
+			return
+		}
+		c.leased[id] = t.Task.Script
+	case script != t.Task.Script:
+		if err := c.sch.UpdateTask(&t.Task, &t.Meta); err != nil {
+			c.logger.Error("failed update task", zap.Stringer("task_id", id), zap.Error(err))
+			return
+		}
+		c.leased[id] = t.Task.Script
+	}
+}
+
+// releaseLeasedTask releases a task from the scheduler and gives up its lease. c.mu must be held.
+func (c *Coordinator) releaseLeasedTask(ctx context.Context, id platform.ID) {
+	if err := c.sch.ReleaseTask(id); err != nil && err != backend.ErrTaskNotClaimed {
+		c.logger.Info("failed to release task", zap.Stringer("task_id", id), zap.Error(err))
+	}
+	if err := c.leases.ReleaseTaskLease(ctx, id, c.owner); err != nil && err != backend.ErrTaskLeaseNotFound {
+		c.logger.Info("failed to release task lease", zap.Stringer("task_id", id), zap.Error(err))
+	}
+	delete(c.leased, id)
+}
+
+// claim claims the task in the scheduler, first acquiring its lease if c uses leases.
+func (c *Coordinator) claim(ctx context.Context, task *backend.StoreTask, meta *backend.StoreTaskMeta) error {
+	if c.leases == nil {
+		return c.sch.ClaimTask(task, meta)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if err := c.leases.AcquireTaskLease(ctx, task.ID, c.owner, now.Unix(), now.Add(c.leaseTTL).Unix()); err != nil {
+		return err
+	}
+	if err := c.sch.ClaimTask(task, meta); err != nil {
+		if err != backend.ErrTaskAlreadyClaimed {
+			c.leases.ReleaseTaskLease(ctx, task.ID, c.owner)
+		}
+		return err
+	}
+	c.leased[task.ID] = task.Script
+	return nil
+}
+
+// release releases the task from the scheduler, and gives up its lease if c uses leases.
+func (c *Coordinator) release(ctx context.Context, id platform.ID) error {
+	if c.leases == nil {
+		return c.sch.ReleaseTask(id)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.leased[id]; !ok {
+		return backend.ErrTaskNotClaimed
+	}
+	c.releaseLeasedTask(ctx, id)
+	return nil
+}
+
 // claimExistingTasks is called on startup to claim all tasks in the store.
 func (c *Coordinator) claimExistingTasks() {
 	tasks, err := c.Store.ListTasks(context.Background(), backend.TaskSearchParams{})
@@ -80,7 +297,7 @@ func (c *Coordinator) CreateTask(ctx context.Context, req backend.CreateTaskRequ
 		return id, err
 	}
 
-	if err := c.sch.ClaimTask(task, meta); err != nil {
+	if err := c.claim(ctx, task, meta); err != nil {
 		_, delErr := c.Store.DeleteTask(ctx, id)
 		if delErr != nil {
 			return id, fmt.Errorf("schedule task failed: %s\n\tcleanup also failed: %s", err, delErr)
@@ -104,18 +321,19 @@ func (c *Coordinator) UpdateTask(ctx context.Context, req backend.UpdateTaskRequ
 
 	// If disabling the task, do so before modifying the script.
 	if req.Status == backend.TaskInactive && res.OldStatus != backend.TaskInactive {
-		if err := c.sch.ReleaseTask(req.ID); err != nil && err != backend.ErrTaskNotClaimed {
+		if err := c.release(ctx, req.ID); err != nil && err != backend.ErrTaskNotClaimed {
 			return res, err
 		}
 	}
 
-	if err := c.sch.UpdateTask(task, meta); err != nil && err != backend.ErrTaskNotClaimed {
+	// A task leased by another coordinator is updated by its heartbeat.
+	if err := c.updateClaimed(task, meta); err != nil && err != backend.ErrTaskNotClaimed {
 		return res, err
 	}
 
 	// If enabling the task, claim it after modifying the script.
 	if req.Status == backend.TaskActive {
-		if err := c.sch.ClaimTask(task, meta); err != nil && err != backend.ErrTaskAlreadyClaimed {
+		if err := c.claim(ctx, task, meta); err != nil && err != backend.ErrTaskAlreadyClaimed && err != backend.ErrTaskLeased {
 			return res, err
 		}
 	}
@@ -123,8 +341,27 @@ func (c *Coordinator) UpdateTask(ctx context.Context, req backend.UpdateTaskRequ
 	return res, nil
 }
 
+// updateClaimed updates the task in the scheduler, if c has claimed it.
+func (c *Coordinator) updateClaimed(task *backend.StoreTask, meta *backend.StoreTaskMeta) error {
+	if c.leases == nil {
+		return c.sch.UpdateTask(task, meta)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.leased[task.ID]; !ok {
+		return backend.ErrTaskNotClaimed
+	}
+	if err := c.sch.UpdateTask(task, meta); err != nil {
+		return err
+	}
+	c.leased[task.ID] = task.Script
+	return nil
+}
+
 func (c *Coordinator) DeleteTask(ctx context.Context, id platform.ID) (deleted bool, err error) {
-	if err := c.sch.ReleaseTask(id); err != nil && err != backend.ErrTaskNotClaimed {
+	if err := c.release(ctx, id); err != nil && err != backend.ErrTaskNotClaimed {
 		return false, err
 	}
 
@@ -140,7 +377,7 @@ func (c *Coordinator) DeleteOrg(ctx context.Context, orgID platform.ID) error {
 	}
 
 	for _, orgTask := range orgTasks {
-		if err := c.sch.ReleaseTask(orgTask.Task.ID); err != nil {
+		if err := c.release(ctx, orgTask.Task.ID); err != nil && (c.leases == nil || err != backend.ErrTaskNotClaimed) {
 			return err
 		}
 	}
@@ -157,7 +394,7 @@ func (c *Coordinator) DeleteUser(ctx context.Context, userID platform.ID) error
 	}
 
 	for _, userTask := range userTasks {
-		if err := c.sch.ReleaseTask(userTask.Task.ID); err != nil {
+		if err := c.release(ctx, userTask.Task.ID); err != nil && (c.leases == nil || err != backend.ErrTaskNotClaimed) {
 			return err
 		}
 	}
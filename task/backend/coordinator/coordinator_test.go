@@ -192,3 +192,154 @@ func TestCoordinator_ClaimExistingTasks(t *testing.T) {
 		}
 	}
 }
+
+func TestCoordinator_LeasesPartitionTasks(t *testing.T) {
+	st := backend.NewInMemStore()
+	ls := st.(backend.LeaseStore)
+	ctx := context.Background()
+
+	const numTasks = 4
+	createdIDs := make([]platform.ID, numTasks)
+	for i := range createdIDs {
+		id, err := st.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+		createdIDs[i] = id
+	}
+
+	schedA, schedB := mock.NewScheduler(), mock.NewScheduler()
+	coordA := coordinator.New(zaptest.NewLogger(t), schedA, st, coordinator.WithLeases(ls, "a", time.Minute), coordinator.WithHeartbeat(time.Hour))
+	coordB := coordinator.New(zaptest.NewLogger(t), schedB, st, coordinator.WithLeases(ls, "b", time.Minute), coordinator.WithHeartbeat(time.Hour))
+
+	now := time.Now()
+	coordA.Heartbeat(ctx, now)
+	coordB.Heartbeat(ctx, now)
+	for _, id := range createdIDs {
+		if (schedA.TaskFor(id) == nil) == (schedB.TaskFor(id) == nil) {
+			t.Fatalf("expected task %s to be claimed by exactly one scheduler", id)
+		}
+	}
+
+	// Only b keeps renewing its leases; it takes over a's tasks once their leases expire.
+	later := now.Add(2 * time.Minute)
+	coordB.Heartbeat(ctx, later)
+	coordA.Heartbeat(ctx, later)
+	for _, id := range createdIDs {
+		if schedB.TaskFor(id) == nil {
+			t.Fatalf("expected task %s to be claimed by b after a's lease expired", id)
+		}
+		if schedA.TaskFor(id) != nil {
+			t.Fatalf("expected task %s to be released by a after losing its lease", id)
+		}
+		l, err := ls.FindTaskLease(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if l.Owner != "b" {
+			t.Fatalf("expected task %s to be leased by b, got %s", id, l.Owner)
+		}
+	}
+
+	// Closing b releases its leases, so a claims the tasks without waiting for them to expire.
+	if err := coordB.Close(); err != nil {
+		t.Fatal(err)
+	}
+	coordA.Heartbeat(ctx, later)
+	for _, id := range createdIDs {
+		if schedA.TaskFor(id) == nil {
+			t.Fatalf("expected task %s to be claimed by a after b closed", id)
+		}
+	}
+}
+
+func TestCoordinator_LeasesUpdateAndDelete(t *testing.T) {
+	st := backend.NewInMemStore()
+	ls := st.(backend.LeaseStore)
+	ctx := context.Background()
+
+	schedA, schedB := mock.NewScheduler(), mock.NewScheduler()
+	coordA := coordinator.New(zaptest.NewLogger(t), schedA, st, coordinator.WithLeases(ls, "a", time.Minute), coordinator.WithHeartbeat(time.Hour))
+	coordB := coordinator.New(zaptest.NewLogger(t), schedB, st, coordinator.WithLeases(ls, "b", time.Minute), coordinator.WithHeartbeat(time.Hour))
+
+	id, err := coordA.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedA.TaskFor(id) == nil {
+		t.Fatal("expected created task to be claimed by its coordinator")
+	}
+
+	// An update through b is picked up by a's heartbeat, without b claiming the task.
+	newScript := `option task = {name: "a task",cron: "1 * * * *"} from(bucket:"test") |> range(start:-2h)`
+	if _, err := coordB.UpdateTask(ctx, backend.UpdateTaskRequest{ID: id, Script: newScript}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := coordB.UpdateTask(ctx, backend.UpdateTaskRequest{ID: id, Status: backend.TaskActive}); err != nil {
+		t.Fatal(err)
+	}
+	if schedB.TaskFor(id) != nil {
+		t.Fatal("expected b not to claim a task leased by a")
+	}
+	coordA.Heartbeat(ctx, time.Now())
+	if task := schedA.TaskFor(id); task == nil || task.Script != newScript {
+		t.Fatalf("expected a to update the task script, got %#v", task)
+	}
+
+	// Deleting through b releases the task from a on its next heartbeat.
+	if _, err := coordB.DeleteTask(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	coordA.Heartbeat(ctx, time.Now())
+	if schedA.TaskFor(id) != nil {
+		t.Fatal("expected a to release the deleted task")
+	}
+}
+
+func TestCoordinator_LeaseTakeoverResumesRuns(t *testing.T) {
+	st := backend.NewInMemStore()
+	ls := st.(backend.LeaseStore)
+	ctx := context.Background()
+
+	execA, execB := mock.NewExecutor(), mock.NewExecutor()
+	schedA := backend.NewScheduler(st, execA, backend.NopLogWriter{}, 5, backend.WithLogger(zaptest.NewLogger(t)))
+	schedA.Start(ctx)
+	defer schedA.Stop()
+	schedB := backend.NewScheduler(st, execB, backend.NopLogWriter{}, 5, backend.WithLogger(zaptest.NewLogger(t)))
+	schedB.Start(ctx)
+	defer schedB.Stop()
+
+	coordA := coordinator.New(zaptest.NewLogger(t), schedA, st, coordinator.WithLeases(ls, "a", time.Minute), coordinator.WithHeartbeat(time.Hour))
+	id, err := coordA.CreateTask(ctx, backend.CreateTaskRequest{
+		Org:           1,
+		User:          2,
+		Script:        `option task = {name: "a task", every: 1s} from(bucket:"test") |> range(start:-1h)`,
+		ScheduleAfter: 5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schedA.Tick(8)
+	running, err := execA.PollForNumberRunning(id, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runID := running[0].Run().RunID
+
+	// a stops renewing its lease without finishing its run, as if its node crashed.
+	coordB := coordinator.New(zaptest.NewLogger(t), schedB, st, coordinator.WithLeases(ls, "b", time.Minute), coordinator.WithHeartbeat(time.Hour))
+	coordB.Heartbeat(ctx, time.Now())
+	if _, err := execB.PollForNumberRunning(id, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	coordB.Heartbeat(ctx, time.Now().Add(2*time.Minute))
+	running, err = execB.PollForNumberRunning(id, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := running[0].Run().RunID; got != runID {
+		t.Fatalf("expected b to resume run %s, got %s", runID, got)
+	}
+}
@@ -0,0 +1,76 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/options"
+)
+
+// ValidateDependencies returns an error if a task in orgID, whose ID is taskID, can't depend on the upstream tasks dependsOn.
+// Every upstream task must exist in the same organization,
+// and no upstream task may depend on the task, directly or indirectly.
+//
+// taskID is not valid for a task that hasn't been created yet.
+func ValidateDependencies(ctx context.Context, s Store, taskID, orgID platform.ID, dependsOn []platform.ID) error {
+	visited := make(map[platform.ID]bool)
+
+	// visit walks the upstream tasks of the task with the given ID, as found along path.
+	var visit func(path []platform.ID, upstreams []platform.ID) error
+	visit = func(path []platform.ID, upstreams []platform.ID) error {
+		for _, id := range upstreams {
+			if id == taskID {
+				return &platform.Error{
+					Code: platform.EInvalid,
+					Msg:  fmt.Sprintf("task dependencies would form a cycle: %s", cycleString(append(path, id))),
+				}
+			}
+			if visited[id] {
+				continue
+			}
+			visited[id] = true
+
+			t, err := s.FindTaskByID(ctx, id)
+			if err != nil {
+				if err == ErrTaskNotFound {
+					return &platform.Error{
+						Code: platform.EInvalid,
+						Msg:  fmt.Sprintf("upstream task %s not found", id),
+					}
+				}
+				return err
+			}
+			if t.Org != orgID {
+				return &platform.Error{
+					Code: platform.EInvalid,
+					Msg:  fmt.Sprintf("upstream task %s belongs to another organization", id),
+				}
+			}
+
+			// A stored task whose options can't be read has no upstream tasks to follow.
+			opts, err := options.FromScript(t.Script)
+			if err != nil {
+				continue
+			}
+			if err := visit(append(path, id), opts.DependsOn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return visit([]platform.ID{taskID}, dependsOn)
+}
+
+// cycleString formats the task IDs of a dependency cycle, from downstream to upstream.
+func cycleString(ids []platform.ID) string {
+	s := ""
+	for i, id := range ids {
+		if i > 0 {
+			s += " -> "
+		}
+		s += id.String()
+	}
+	return s
+}
@@ -0,0 +1,69 @@
+package backend_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/influxdata/platform"
+	_ "github.com/influxdata/platform/query/builtin"
+	"github.com/influxdata/platform/task/backend"
+)
+
+func dependentScript(name string, dependsOn ...platform.ID) string {
+	ids := ""
+	for i, id := range dependsOn {
+		if i > 0 {
+			ids += ", "
+		}
+		ids += fmt.Sprintf("%q", id.String())
+	}
+	return fmt.Sprintf(`option task = {name: %q, every: 1h, dependsOn: [%s]}
+from(bucket: "b") |> range(start: -1h)`, name, ids)
+}
+
+func TestValidateDependencies(t *testing.T) {
+	ctx := context.Background()
+	s := backend.NewInMemStore()
+	org := platform.ID(1)
+
+	create := func(script string, org platform.ID) platform.ID {
+		t.Helper()
+		id, err := s.CreateTask(ctx, backend.CreateTaskRequest{Org: org, User: 2, Script: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+
+	a := create(`option task = {name: "a", every: 1h}
+from(bucket: "b") |> range(start: -1h)`, org)
+	b := create(dependentScript("b", a), org)
+	c := create(dependentScript("c", b), org)
+	other := create(`option task = {name: "other", every: 1h}
+from(bucket: "b") |> range(start: -1h)`, 3)
+
+	// A new task may depend on existing tasks in its organization.
+	if err := backend.ValidateDependencies(ctx, s, platform.InvalidID(), org, []platform.ID{a, c}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		taskID    platform.ID
+		dependsOn []platform.ID
+	}{
+		{name: "self", taskID: a, dependsOn: []platform.ID{a}},
+		{name: "direct cycle", taskID: a, dependsOn: []platform.ID{b}},
+		{name: "indirect cycle", taskID: a, dependsOn: []platform.ID{c}},
+		{name: "missing upstream", taskID: platform.InvalidID(), dependsOn: []platform.ID{99}},
+		{name: "other organization", taskID: platform.InvalidID(), dependsOn: []platform.ID{other}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := backend.ValidateDependencies(ctx, s, tc.taskID, org, tc.dependsOn)
+			if platform.ErrorCode(err) != platform.EInvalid {
+				t.Fatalf("expected invalid dependencies, got %v", err)
+			}
+		})
+	}
+}
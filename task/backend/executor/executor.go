@@ -8,9 +8,13 @@ import (
 	"time"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/functions/transformations"
 	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/logger"
 	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/functions/outputs"
 	"github.com/influxdata/platform/task/backend"
 	"go.uber.org/zap"
 )
@@ -146,19 +150,28 @@ func (p *syncRunPromise) doQuery(wg *sync.WaitGroup) {
 		p.finish(nil, err)
 		return
 	}
-	defer it.Release()
 
 	// Drain the result iterator.
+	var rs resultStats
+	written := toResults(spec)
 	for it.More() {
 		// Consume the full iterator so that we don't leak outstanding iterators.
 		res := it.Next()
-		if err := exhaustResultIterators(res); err != nil {
+		if err := rs.exhaust(res, written[res.Name()]); err != nil {
 			p.logger.Info("Error exhausting result iterator", zap.Error(err), zap.String("name", res.Name()))
 		}
 	}
 
 	// Is it okay to assume it.Err will be set if the query context is canceled?
-	p.finish(&runResult{err: it.Err()}, nil)
+	// An error while running the query may be transient, unlike a failure to compile it, so it may be retried.
+	err = it.Err()
+
+	// The query's statistics are only complete once the iterator is released.
+	it.Release()
+	if s, ok := it.(flux.Statisticser); ok {
+		rs.query = s.Statistics()
+	}
+	p.finish(&runResult{err: err, retryable: err != nil, stats: rs.runStatistics()}, nil)
 }
 
 func (p *syncRunPromise) cancelOnContextDone(wg *sync.WaitGroup) {
@@ -212,7 +225,7 @@ func (e *asyncQueryServiceExecutor) Execute(ctx context.Context, run backend.Que
 		return nil, err
 	}
 
-	return newAsyncRunPromise(run, q, e), nil
+	return newAsyncRunPromise(run, q, toResults(spec), e), nil
 }
 
 func (e *asyncQueryServiceExecutor) Wait() {
@@ -224,6 +237,8 @@ type asyncRunPromise struct {
 	qr backend.QueuedRun
 	q  flux.Query
 
+	written map[string]bool // Names of the results produced by to().
+
 	logger *zap.Logger
 	logEnd func()
 
@@ -235,14 +250,15 @@ type asyncRunPromise struct {
 
 var _ backend.RunPromise = (*asyncRunPromise)(nil)
 
-func newAsyncRunPromise(qr backend.QueuedRun, q flux.Query, e *asyncQueryServiceExecutor) *asyncRunPromise {
+func newAsyncRunPromise(qr backend.QueuedRun, q flux.Query, written map[string]bool, e *asyncQueryServiceExecutor) *asyncRunPromise {
 	opLogger := e.logger.With(zap.Stringer("task_id", qr.TaskID), zap.Stringer("run_id", qr.RunID))
 	log, logEnd := logger.NewOperation(opLogger, "Executing task", "execute")
 
 	p := &asyncRunPromise{
-		qr:    qr,
-		q:     q,
-		ready: make(chan struct{}),
+		qr:      qr,
+		q:       q,
+		written: written,
+		ready:   make(chan struct{}),
 
 		logger: log,
 		logEnd: logEnd,
@@ -287,28 +303,42 @@ func (p *asyncRunPromise) followQuery(wg *sync.WaitGroup) {
 	case results, ok := <-p.q.Ready():
 		if !ok {
 			// Something went wrong with the flux. Set the error in the run result.
-			rr := &runResult{err: p.q.Err()}
+			// An error while running the query may be transient, so it may be retried.
+			p.q.Done()
+			rs := resultStats{query: p.q.Statistics()}
+			rr := &runResult{err: p.q.Err(), retryable: true, stats: rs.runStatistics()}
 			p.finish(rr, nil)
 			return
 		}
 
 		// Exhaust the results so we don't leave unfinished iterators around.
-		var wg sync.WaitGroup
+		var (
+			wg sync.WaitGroup
+			mu sync.Mutex
+			rs resultStats
+		)
 		wg.Add(len(results))
 		for _, res := range results {
 			r := res
 			go func() {
 				defer wg.Done()
-				if err := exhaustResultIterators(r); err != nil {
+				var s resultStats
+				if err := s.exhaust(r, p.written[r.Name()]); err != nil {
 					p.logger.Info("Error exhausting result iterator", zap.Error(err), zap.String("name", r.Name()))
 				}
+				mu.Lock()
+				rs.add(s)
+				mu.Unlock()
 			}()
 		}
 		wg.Wait()
 
+		// The query's statistics are only complete once it is done.
+		p.q.Done()
+		rs.query = p.q.Statistics()
+
 		// Otherwise, query was successful.
-		// TODO(mr): collect query statistics, once RunResult interface supports them?
-		p.finish(new(runResult), nil)
+		p.finish(&runResult{stats: rs.runStatistics()}, nil)
 	}
 }
 
@@ -332,18 +362,88 @@ func (p *asyncRunPromise) finish(res *runResult, err error) {
 type runResult struct {
 	err       error
 	retryable bool
+	stats     platform.RunStatistics
 }
 
 var _ backend.RunResult = (*runResult)(nil)
 
-func (rr *runResult) Err() error        { return rr.err }
-func (rr *runResult) IsRetryable() bool { return rr.retryable }
+func (rr *runResult) Err() error                         { return rr.err }
+func (rr *runResult) IsRetryable() bool                  { return rr.retryable }
+func (rr *runResult) Statistics() platform.RunStatistics { return rr.stats }
+
+// resultStats accumulates the statistics of a query while its results are exhausted.
+type resultStats struct {
+	// query holds the statistics reported by the query itself.
+	query flux.Statistics
+	// tables holds the sum of the statistics reported by the result tables.
+	tables flux.Statistics
+	// pointsWritten is the number of rows of the results produced by to().
+	pointsWritten int64
+}
 
-// exhaustResultIterators drains all the iterators from a flux query Result.
-func exhaustResultIterators(res flux.Result) error {
+// exhaust drains all the iterators from a flux query Result,
+// recording the statistics of each table.
+// The rows of res are counted as written points if written is set.
+func (rs *resultStats) exhaust(res flux.Result, written bool) error {
 	return res.Tables().Do(func(tbl flux.Table) error {
-		return tbl.Do(func(flux.ColReader) error {
+		err := tbl.Do(func(cr flux.ColReader) error {
+			if written {
+				rs.pointsWritten += int64(cr.Len())
+			}
 			return nil
 		})
+		rs.tables = rs.tables.Add(tbl.Statistics())
+		return err
 	})
 }
+
+func (rs *resultStats) add(other resultStats) {
+	rs.tables = rs.tables.Add(other.tables)
+	rs.pointsWritten += other.pointsWritten
+}
+
+// runStatistics combines the query and table statistics into a platform.RunStatistics.
+func (rs *resultStats) runStatistics() platform.RunStatistics {
+	return platform.RunStatistics{
+		TotalDuration:   rs.query.TotalDuration,
+		QueueDuration:   rs.query.QueueDuration,
+		CompileDuration: rs.query.CompileDuration,
+		PlanDuration:    rs.query.PlanDuration,
+		ExecuteDuration: rs.query.ExecuteDuration,
+		ValuesRead:      int64(rs.query.ScannedValues + rs.tables.ScannedValues),
+		BytesRead:       int64(rs.query.ScannedBytes + rs.tables.ScannedBytes),
+		PointsWritten:   rs.pointsWritten,
+	}
+}
+
+// toResults returns the names of the results of spec that are produced by to().
+// The to() transformation passes on a row for every point it writes, so the
+// rows of these results are the points the query wrote.
+func toResults(spec *flux.Spec) map[string]bool {
+	kinds := make(map[flux.OperationID]flux.OperationKind, len(spec.Operations))
+	for _, op := range spec.Operations {
+		kinds[op.ID] = op.Spec.Kind()
+	}
+	parents := make(map[flux.OperationID][]flux.OperationID)
+	hasChildren := make(map[flux.OperationID]bool)
+	for _, e := range spec.Edges {
+		parents[e.Child] = append(parents[e.Child], e.Parent)
+		hasChildren[e.Parent] = true
+	}
+
+	names := make(map[string]bool)
+	for _, op := range spec.Operations {
+		switch {
+		case op.Spec.Kind() == outputs.ToKind && !hasChildren[op.ID]:
+			// A terminal to() is yielded implicitly.
+			names[plan.DefaultYieldName] = true
+		case op.Spec.Kind() == transformations.YieldKind:
+			for _, p := range parents[op.ID] {
+				if kinds[p] == outputs.ToKind {
+					names[op.Spec.(*transformations.YieldOpSpec).Name] = true
+				}
+			}
+		}
+	}
+	return names
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/lang"
 	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/values"
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/query"
@@ -129,6 +130,17 @@ func (s *fakeQueryService) WaitForQueryLive(t *testing.T, script string) {
 	t.Fatalf("Did not see live query %q in time", script)
 }
 
+// fakeQueryStatistics are the statistics reported by every fakeQuery.
+var fakeQueryStatistics = flux.Statistics{
+	TotalDuration:   10 * time.Millisecond,
+	QueueDuration:   time.Millisecond,
+	CompileDuration: 2 * time.Millisecond,
+	PlanDuration:    3 * time.Millisecond,
+	ExecuteDuration: 4 * time.Millisecond,
+	ScannedValues:   5,
+	ScannedBytes:    40,
+}
+
 type fakeQuery struct {
 	ready       chan map[string]flux.Result
 	wait        chan struct{} // Blocks Ready from returning.
@@ -142,7 +154,7 @@ var _ flux.Query = (*fakeQuery)(nil)
 func (q *fakeQuery) Spec() *flux.Spec                     { return nil }
 func (q *fakeQuery) Done()                                {}
 func (q *fakeQuery) Cancel()                              { close(q.ready) }
-func (q *fakeQuery) Statistics() flux.Statistics          { return flux.Statistics{} }
+func (q *fakeQuery) Statistics() flux.Statistics          { return fakeQueryStatistics }
 func (q *fakeQuery) Ready() <-chan map[string]flux.Result { return q.ready }
 
 func (q *fakeQuery) Err() error {
@@ -195,7 +207,7 @@ func newFakeResult() *fakeResult {
 	if err != nil {
 		panic(err)
 	}
-	return &fakeResult{name: "res", table: t}
+	return &fakeResult{name: plan.DefaultYieldName, table: t}
 }
 
 func (r *fakeResult) Statistics() flux.Statistics {
@@ -261,6 +273,7 @@ func createSyncSystem() *system {
 func TestExecutor(t *testing.T) {
 	for _, fn := range []createSysFn{createAsyncSystem, createSyncSystem} {
 		testExecutorQuerySuccess(t, fn)
+		testExecutorPointsWritten(t, fn)
 		testExecutorQueryFailure(t, fn)
 		testExecutorPromiseCancel(t, fn)
 		testExecutorServiceError(t, fn)
@@ -328,6 +341,57 @@ func testExecutorQuerySuccess(t *testing.T, fn createSysFn) {
 		if !reflect.DeepEqual(res, res2) {
 			t.Fatalf("second call to wait returned a different result: %#v", res2)
 		}
+
+		expStats := platform.RunStatistics{
+			TotalDuration:   10 * time.Millisecond,
+			QueueDuration:   time.Millisecond,
+			CompileDuration: 2 * time.Millisecond,
+			PlanDuration:    3 * time.Millisecond,
+			ExecuteDuration: 4 * time.Millisecond,
+			ValuesRead:      5,
+			BytesRead:       40,
+		}
+		if got := res.Statistics(); got != expStats {
+			t.Fatalf("unexpected run statistics: got %#v, exp %#v", got, expStats)
+		}
+	})
+}
+
+func testExecutorPointsWritten(t *testing.T, fn createSysFn) {
+	var orgID = platformtesting.MustIDBase16("aaaaaaaaaaaaaaaa")
+	var userID = platformtesting.MustIDBase16("baaaaaaaaaaaaaab")
+	sys := fn()
+	t.Run(sys.name+"/PointsWritten", func(t *testing.T) {
+		t.Parallel()
+
+		script := fmt.Sprintf(`option task = {
+			name: %q,
+			every: 1m,
+		}
+		from(bucket: "one") |> range(start: -1m) |> to(bucket: "two", org: "org")`, t.Name())
+		tid, err := sys.st.CreateTask(context.Background(), backend.CreateTaskRequest{Org: orgID, User: userID, Script: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+		rp, err := sys.ex.Execute(context.Background(), backend.QueuedRun{TaskID: tid, RunID: platform.ID(1), Now: 123})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sys.svc.WaitForQueryLive(t, script)
+		sys.svc.SucceedQuery(script)
+		res, err := rp.Wait()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := res.Err(); got != nil {
+			t.Fatal(got)
+		}
+
+		// The fake result of the to() call has a single row.
+		if got := res.Statistics().PointsWritten; got != 1 {
+			t.Fatalf("expected 1 point written, got %d", got)
+		}
 	})
 }
 
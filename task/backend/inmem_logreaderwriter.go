@@ -38,10 +38,11 @@ func (r *runReaderWriter) UpdateRunState(ctx context.Context, rlb RunLogBase, wh
 	if !ok {
 		sf := time.Unix(rlb.RunScheduledFor, 0).UTC()
 		run := &platform.Run{
-			ID:           rlb.RunID,
-			TaskID:       rlb.Task.ID,
-			Status:       status.String(),
-			ScheduledFor: sf.Format(time.RFC3339),
+			ID:            rlb.RunID,
+			TaskID:        rlb.Task.ID,
+			Status:        status.String(),
+			ScheduledFor:  sf.Format(time.RFC3339),
+			ScriptVersion: int(rlb.Task.Version),
 		}
 		if rlb.RequestedAt != 0 {
 			run.RequestedAt = time.Unix(rlb.RequestedAt, 0).UTC().Format(time.RFC3339)
@@ -75,6 +76,17 @@ func (r *runReaderWriter) AddRunLog(ctx context.Context, rlb RunLogBase, when ti
 	return nil
 }
 
+func (r *runReaderWriter) UpdateRunStatistics(ctx context.Context, rlb RunLogBase, when time.Time, stats platform.RunStatistics) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	existingRun, ok := r.byRunID[rlb.RunID.String()]
+	if !ok {
+		return ErrRunNotFound
+	}
+	existingRun.Statistics = &stats
+	return nil
+}
+
 func (r *runReaderWriter) ListRuns(ctx context.Context, runFilter platform.RunFilter) ([]*platform.Run, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
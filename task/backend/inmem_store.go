@@ -12,6 +12,7 @@ import (
 )
 
 var _ Store = (*inmem)(nil)
+var _ LeaseStore = (*inmem)(nil)
 
 // inmem is an in-memory task store.
 type inmem struct {
@@ -24,14 +25,21 @@ type inmem struct {
 	tasks []StoreTask
 
 	meta map[platform.ID]StoreTaskMeta
+
+	leases map[platform.ID]TaskLease
+
+	versions map[platform.ID][]StoreTaskVersion
 }
 
 // NewInMemStore returns a new in-memory store.
 // This store is not designed to be efficient, it is here for testing purposes.
 func NewInMemStore() Store {
 	return &inmem{
-		idgen: snowflake.NewIDGenerator(),
-		meta:  map[platform.ID]StoreTaskMeta{},
+		idgen:  snowflake.NewIDGenerator(),
+		meta:   map[platform.ID]StoreTaskMeta{},
+		leases: map[platform.ID]TaskLease{},
+
+		versions: map[platform.ID][]StoreTaskVersion{},
 	}
 }
 
@@ -52,6 +60,8 @@ func (s *inmem) CreateTask(_ context.Context, req CreateTaskRequest) (platform.I
 		Name: o.Name,
 
 		Script: req.Script,
+
+		Version: 1,
 	}
 
 	s.mu.Lock()
@@ -59,6 +69,7 @@ func (s *inmem) CreateTask(_ context.Context, req CreateTaskRequest) (platform.I
 
 	s.tasks = append(s.tasks, task)
 	s.meta[id] = NewStoreTaskMeta(req, o)
+	s.versions[id] = []StoreTaskVersion{NewStoreTaskVersion(id, task.Version, req.Script, o, req.User)}
 
 	return id, nil
 }
@@ -88,8 +99,10 @@ func (s *inmem) UpdateTask(_ context.Context, req UpdateTaskRequest) (UpdateTask
 			if err != nil {
 				return res, err
 			}
-		} else {
+		} else if req.Script != t.Script {
 			t.Script = req.Script
+			t.Version++
+			s.versions[t.ID] = append(s.versions[t.ID], NewStoreTaskVersion(t.ID, t.Version, t.Script, op, req.User))
 		}
 		t.Name = op.Name
 
@@ -112,6 +125,10 @@ func (s *inmem) UpdateTask(_ context.Context, req UpdateTaskRequest) (UpdateTask
 		stm.Status = string(req.Status)
 		s.meta[req.ID] = stm
 	}
+	if req.MaxConcurrency != 0 {
+		stm.MaxConcurrency = req.MaxConcurrency
+		s.meta[req.ID] = stm
+	}
 	res.NewMeta = stm
 
 	return res, nil
@@ -159,6 +176,9 @@ func (s *inmem) ListTasks(_ context.Context, params TaskSearchParams) ([]StoreTa
 		if user.Valid() && user != t.User {
 			continue
 		}
+		if params.Match != nil && !params.Match(t.ID, t.Org) {
+			continue
+		}
 
 		out = append(out, StoreTaskWithMeta{Task: t})
 		if len(out) >= lim {
@@ -246,13 +266,85 @@ func (s *inmem) DeleteTask(_ context.Context, id platform.ID) (deleted bool, err
 	// Delete entry from slice.
 	s.tasks = append(s.tasks[:idx], s.tasks[idx+1:]...)
 	delete(s.meta, id)
+	delete(s.leases, id)
+	delete(s.versions, id)
 	return true, nil
 }
 
+func (s *inmem) AcquireTaskLease(_ context.Context, taskID platform.ID, owner string, now, expires int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.meta[taskID]; !ok {
+		return ErrTaskNotFound
+	}
+	if l, ok := s.leases[taskID]; ok && l.Owner != owner && !l.Expired(now) {
+		return ErrTaskLeased
+	}
+
+	s.leases[taskID] = TaskLease{TaskID: taskID, Owner: owner, Expires: expires}
+	return nil
+}
+
+func (s *inmem) ReleaseTaskLease(_ context.Context, taskID platform.ID, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.leases[taskID]
+	if !ok {
+		return ErrTaskLeaseNotFound
+	}
+	if l.Owner != owner {
+		return ErrTaskLeased
+	}
+
+	delete(s.leases, taskID)
+	return nil
+}
+
+func (s *inmem) FindTaskLease(_ context.Context, taskID platform.ID) (*TaskLease, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	l, ok := s.leases[taskID]
+	if !ok {
+		return nil, ErrTaskLeaseNotFound
+	}
+	return &l, nil
+}
+
 func (s *inmem) Close() error {
 	return nil
 }
 
+func (s *inmem) ListTaskVersions(_ context.Context, taskID platform.ID) ([]StoreTaskVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.meta[taskID]; !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	return append([]StoreTaskVersion(nil), s.versions[taskID]...), nil
+}
+
+func (s *inmem) FindTaskVersion(_ context.Context, taskID platform.ID, version uint32) (*StoreTaskVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.meta[taskID]; !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	for _, v := range s.versions[taskID] {
+		if v.Version == version {
+			return &v, nil
+		}
+	}
+
+	return nil, ErrTaskVersionNotFound
+}
+
 func (s *inmem) CreateNextRun(ctx context.Context, taskID platform.ID, now int64) (RunCreation, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -277,6 +369,15 @@ func (s *inmem) CreateNextRun(ctx context.Context, taskID platform.ID, now int64
 
 // FinishRun removes runID from the list of running tasks and if its `now` is later then last completed update it.
 func (s *inmem) FinishRun(ctx context.Context, taskID, runID platform.ID) error {
+	return s.finishRun(taskID, runID, (*StoreTaskMeta).FinishRun)
+}
+
+// SucceedRun finishes runID and if its `now` is later then last succeeded update it.
+func (s *inmem) SucceedRun(ctx context.Context, taskID, runID platform.ID) error {
+	return s.finishRun(taskID, runID, (*StoreTaskMeta).SucceedRun)
+}
+
+func (s *inmem) finishRun(taskID, runID platform.ID, finish func(*StoreTaskMeta, platform.ID) bool) error {
 	s.mu.RLock()
 	stm, ok := s.meta[taskID]
 	s.mu.RUnlock()
@@ -285,7 +386,7 @@ func (s *inmem) FinishRun(ctx context.Context, taskID, runID platform.ID) error
 		return errors.New("taskRunner not found")
 	}
 
-	if !stm.FinishRun(runID) {
+	if !finish(&stm, runID) {
 		return errors.New("run not found")
 	}
 
@@ -296,6 +397,25 @@ func (s *inmem) FinishRun(ctx context.Context, taskID, runID platform.ID) error
 	return nil
 }
 
+// IncrementRunTry increments the try of the running run with runID.
+func (s *inmem) IncrementRunTry(ctx context.Context, taskID, runID platform.ID) (uint32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stm, ok := s.meta[taskID]
+	if !ok {
+		return 0, errors.New("taskRunner not found")
+	}
+
+	try, ok := stm.IncrementRunTry(runID)
+	if !ok {
+		return 0, errors.New("run not found")
+	}
+
+	s.meta[taskID] = stm
+	return try, nil
+}
+
 func (s *inmem) ManuallyRunTimeRange(_ context.Context, taskID platform.ID, start, end, requestedAt int64) (*StoreTaskMetaManualRun, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -340,8 +460,10 @@ func (s *inmem) delete(ctx context.Context, id platform.ID, f func(StoreTask) pl
 		return ctx.Err()
 	default:
 	}
-	for i := range deletingTasks {
-		delete(s.meta, s.tasks[i].ID)
+	for _, id := range deletingTasks {
+		delete(s.meta, id)
+		delete(s.leases, id)
+		delete(s.versions, id)
 	}
 	s.tasks = newTasks
 	return nil
@@ -0,0 +1,49 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"github.com/influxdata/platform"
+)
+
+var (
+	// ErrTaskLeased is returned when attempting to lease a task whose lease is held by another owner.
+	ErrTaskLeased = errors.New("task leased by another owner")
+
+	// ErrTaskLeaseNotFound is returned when a task is not leased.
+	ErrTaskLeaseNotFound = errors.New("task lease not found")
+)
+
+// TaskLease is a claim on a task by a single owner, such as an influxd node,
+// so that several schedulers sharing one Store each execute a distinct set of tasks.
+type TaskLease struct {
+	TaskID platform.ID
+
+	// Owner identifies the holder of the lease.
+	Owner string
+
+	// Expires is the Unix timestamp after which the lease may be taken by another owner.
+	Expires int64
+}
+
+// Expired returns true if the lease is no longer valid at the Unix timestamp now.
+func (l TaskLease) Expired(now int64) bool {
+	return now >= l.Expires
+}
+
+// LeaseStore persists task leases alongside the tasks of a Store.
+type LeaseStore interface {
+	// AcquireTaskLease leases the task to owner until the Unix timestamp expires.
+	// The lease is granted if the task is not leased, its lease has expired at now,
+	// or owner already holds it, in which case the lease is renewed.
+	// Otherwise, ErrTaskLeased is returned.
+	AcquireTaskLease(ctx context.Context, taskID platform.ID, owner string, now, expires int64) error
+
+	// ReleaseTaskLease removes the lease on the task, if it is held by owner.
+	ReleaseTaskLease(ctx context.Context, taskID platform.ID, owner string) error
+
+	// FindTaskLease returns the lease on the task.
+	// If the task is not leased, the returned lease is nil and ErrTaskLeaseNotFound is returned.
+	FindTaskLease(ctx context.Context, taskID platform.ID) (*TaskLease, error)
+}
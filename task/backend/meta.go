@@ -77,6 +77,42 @@ func (stm *StoreTaskMeta) FinishRun(runID platform.ID) bool {
 	return false
 }
 
+// SucceedRun finishes the run matching runID like FinishRun, for a run that succeeded.
+// If the run was naturally scheduled and its Now value is greater than m's LatestSucceeded value,
+// it also updates the value of LatestSucceeded to the run's Now value.
+//
+// If runID matched a run, SucceedRun returns true. Otherwise it returns false.
+func (stm *StoreTaskMeta) SucceedRun(runID platform.ID) bool {
+	for _, runner := range stm.CurrentlyRunning {
+		if platform.ID(runner.RunID) != runID {
+			continue
+		}
+
+		natural := runner.RangeStart == 0 && runner.RangeEnd == 0 && runner.RequestedAt == 0
+		if natural && runner.Now > stm.LatestSucceeded {
+			stm.LatestSucceeded = runner.Now
+		}
+		return stm.FinishRun(runID)
+	}
+	return false
+}
+
+// IncrementRunTry increments the Try of the run matching runID in m's CurrentlyRunning slice,
+// before the run is attempted again.
+//
+// If runID matched a run, IncrementRunTry returns the run's new Try and true. Otherwise it returns 0 and false.
+func (stm *StoreTaskMeta) IncrementRunTry(runID platform.ID) (uint32, bool) {
+	for _, runner := range stm.CurrentlyRunning {
+		if platform.ID(runner.RunID) != runID {
+			continue
+		}
+
+		runner.Try++
+		return runner.Try, true
+	}
+	return 0, false
+}
+
 // CreateNextRun attempts to update stm's CurrentlyRunning slice with a new run.
 // The new run's now is assigned the earliest possible time according to stm.EffectiveCron,
 // that is later than any in-progress run and stm's LatestCompleted timestamp.
@@ -128,6 +164,7 @@ func (stm *StoreTaskMeta) CreateNextRun(now int64, makeID func() (platform.ID, e
 		Created: QueuedRun{
 			RunID: id,
 			Now:   nextScheduledUnix,
+			Try:   1,
 		},
 		NextDue:  sch.Next(nextScheduled).Unix() + int64(stm.Offset),
 		HasQueue: len(stm.ManualRuns) > 0,
@@ -185,6 +222,7 @@ func (stm *StoreTaskMeta) createNextRunFromQueue(now, nextDue int64, sch cron.Sc
 		Created: QueuedRun{
 			RunID:       id,
 			Now:         runNow,
+			Try:         1,
 			RequestedAt: q.RequestedAt,
 		},
 		NextDue:  nextDue,
@@ -262,6 +300,7 @@ func (stm StoreTaskMeta) Equal(other StoreTaskMeta) bool {
 		stm.Status != other.Status ||
 		stm.EffectiveCron != other.EffectiveCron ||
 		stm.Offset != other.Offset ||
+		stm.LatestSucceeded != other.LatestSucceeded ||
 		len(stm.CurrentlyRunning) != len(other.CurrentlyRunning) ||
 		len(stm.ManualRuns) != len(other.ManualRuns) {
 		return false
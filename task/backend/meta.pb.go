@@ -35,7 +35,9 @@ type StoreTaskMeta struct {
 	// effective_cron is the effective cron string as reported by the task's options.
 	EffectiveCron string `protobuf:"bytes,5,opt,name=effective_cron,json=effectiveCron,proto3" json:"effective_cron,omitempty"`
 	// Task's configured delay, in seconds.
-	Offset               int32                     `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	Offset int32 `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	// latest_succeeded is the unix timestamp of the latest "naturally" scheduled run that succeeded.
+	LatestSucceeded      int64                     `protobuf:"varint,7,opt,name=latest_succeeded,json=latestSucceeded,proto3" json:"latest_succeeded,omitempty"`
 	ManualRuns           []*StoreTaskMetaManualRun `protobuf:"bytes,16,rep,name=manual_runs,json=manualRuns" json:"manual_runs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
 	XXX_sizecache        int32                     `json:"-"`
@@ -116,6 +118,13 @@ func (m *StoreTaskMeta) GetOffset() int32 {
 	return 0
 }
 
+func (m *StoreTaskMeta) GetLatestSucceeded() int64 {
+	if m != nil {
+		return m.LatestSucceeded
+	}
+	return 0
+}
+
 func (m *StoreTaskMeta) GetManualRuns() []*StoreTaskMetaManualRun {
 	if m != nil {
 		return m.ManualRuns
@@ -358,6 +367,11 @@ func (m *StoreTaskMeta) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintMeta(dAtA, i, uint64(m.Offset))
 	}
+	if m.LatestSucceeded != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintMeta(dAtA, i, uint64(m.LatestSucceeded))
+	}
 	if len(m.ManualRuns) > 0 {
 		for _, msg := range m.ManualRuns {
 			dAtA[i] = 0x82
@@ -501,6 +515,9 @@ func (m *StoreTaskMeta) Size() (n int) {
 	if m.Offset != 0 {
 		n += 1 + sovMeta(uint64(m.Offset))
 	}
+	if m.LatestSucceeded != 0 {
+		n += 1 + sovMeta(uint64(m.LatestSucceeded))
+	}
 	if len(m.ManualRuns) > 0 {
 		for _, e := range m.ManualRuns {
 			l = e.Size()
@@ -743,6 +760,25 @@ func (m *StoreTaskMeta) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LatestSucceeded", wireType)
+			}
+			m.LatestSucceeded = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LatestSucceeded |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 16:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ManualRuns", wireType)
@@ -1212,35 +1248,36 @@ var (
 func init() { proto.RegisterFile("meta.proto", fileDescriptor_meta_d42b29c328506298) }
 
 var fileDescriptor_meta_d42b29c328506298 = []byte{
-	// 474 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0xc1, 0x6e, 0x13, 0x31,
-	0x10, 0x86, 0xd9, 0x6e, 0x36, 0x90, 0x09, 0x69, 0x17, 0xab, 0xaa, 0x16, 0x90, 0xd2, 0x25, 0x02,
-	0x11, 0x2e, 0x8b, 0x04, 0x12, 0x27, 0x2e, 0x34, 0x70, 0xe8, 0xa1, 0x17, 0x97, 0x13, 0x12, 0x5a,
-	0xb9, 0x5e, 0x6f, 0x14, 0x65, 0xd7, 0x2e, 0xf6, 0x18, 0x92, 0xb7, 0xe0, 0x51, 0xb8, 0xf2, 0x06,
-	0x1c, 0x79, 0x02, 0x84, 0xc2, 0x7b, 0x20, 0x64, 0x3b, 0x0d, 0x50, 0x72, 0x40, 0xbd, 0xcd, 0x7c,
-	0x5a, 0x8f, 0xff, 0xff, 0xdf, 0x31, 0x40, 0x2b, 0x90, 0x15, 0xe7, 0x5a, 0xa1, 0x22, 0xf7, 0xb9,
-	0x6a, 0x8b, 0x99, 0xac, 0x1b, 0xbb, 0xa8, 0x98, 0xa3, 0x0d, 0xc3, 0x5a, 0xe9, 0xb6, 0x40, 0x66,
-	0xe6, 0xc5, 0x19, 0xe3, 0x73, 0x21, 0xab, 0x3b, 0xfb, 0x53, 0x35, 0x55, 0xfe, 0xc0, 0x63, 0x57,
-	0x85, 0xb3, 0xa3, 0x9f, 0x3b, 0x30, 0x38, 0x45, 0xa5, 0xc5, 0x6b, 0x66, 0xe6, 0x27, 0x02, 0x19,
-	0x79, 0x08, 0x7b, 0x2d, 0x5b, 0x94, 0x5c, 0x49, 0x6e, 0xb5, 0x16, 0x92, 0x2f, 0xb3, 0x28, 0x8f,
-	0xc6, 0x09, 0xdd, 0x6d, 0xd9, 0x62, 0xf2, 0x9b, 0x92, 0x47, 0x90, 0x36, 0x0c, 0x85, 0xc1, 0x92,
-	0xab, 0xf6, 0xbc, 0x11, 0x28, 0xaa, 0x6c, 0x27, 0x8f, 0xc6, 0x31, 0xdd, 0x0b, 0x7c, 0x72, 0x81,
-	0xc9, 0x01, 0x74, 0x0d, 0x32, 0xb4, 0x26, 0x8b, 0xf3, 0x68, 0xdc, 0xa3, 0xeb, 0x8e, 0x70, 0xb8,
-	0x15, 0xc6, 0x61, 0xb3, 0x2c, 0xb5, 0x95, 0x72, 0x26, 0xa7, 0x59, 0x27, 0x8f, 0xc7, 0xfd, 0x27,
-	0xcf, 0x8a, 0xff, 0x71, 0x55, 0xfc, 0xa5, 0x9d, 0x5a, 0x49, 0xd3, 0xcd, 0x40, 0x1a, 0xe6, 0x91,
-	0x07, 0xb0, 0x2b, 0xea, 0x5a, 0x70, 0x9c, 0xbd, 0x17, 0x25, 0xd7, 0x4a, 0x66, 0x89, 0x17, 0x31,
-	0xd8, 0xd0, 0x89, 0x56, 0xd2, 0x69, 0x54, 0x75, 0x6d, 0x04, 0x66, 0x5d, 0x6f, 0x77, 0xdd, 0x91,
-	0xb7, 0xd0, 0x6f, 0x99, 0xb4, 0xac, 0x71, 0x02, 0x4d, 0x96, 0x7a, 0x75, 0xcf, 0xaf, 0xa0, 0xee,
-	0xc4, 0x4f, 0x71, 0x1a, 0xa1, 0xbd, 0x28, 0xcd, 0xe8, 0x73, 0x04, 0xe9, 0x65, 0x13, 0x24, 0x85,
-	0x58, 0xaa, 0x0f, 0x3e, 0xf7, 0x98, 0xba, 0xd2, 0x11, 0xd4, 0x4b, 0x9f, 0xef, 0x80, 0xba, 0x92,
-	0xe4, 0xd0, 0xd5, 0x56, 0x96, 0xb3, 0xca, 0x67, 0xda, 0x39, 0xea, 0xad, 0xbe, 0x1d, 0x26, 0xd4,
-	0xca, 0xe3, 0x97, 0x34, 0xd1, 0x56, 0x1e, 0x57, 0xe4, 0x10, 0xfa, 0x9a, 0xc9, 0xa9, 0x28, 0x0d,
-	0x32, 0x8d, 0x59, 0xc7, 0x4f, 0x03, 0x8f, 0x4e, 0x1d, 0x21, 0x77, 0xa1, 0x17, 0x3e, 0x10, 0xb2,
-	0xf2, 0xa1, 0xc4, 0xf4, 0x86, 0x07, 0xaf, 0x64, 0x45, 0xee, 0xc1, 0x4d, 0x2d, 0xde, 0x59, 0x61,
-	0x50, 0x54, 0x25, 0x0b, 0xa9, 0xc4, 0xb4, 0xbf, 0x61, 0x2f, 0x70, 0xf4, 0x29, 0x82, 0x83, 0xed,
-	0x16, 0xc9, 0x3e, 0x24, 0xe1, 0xd6, 0xe0, 0x21, 0x34, 0xce, 0x85, 0xbb, 0x2a, 0x6c, 0x89, 0x2b,
-	0xb7, 0x2e, 0x51, 0xbc, 0x7d, 0x89, 0x2e, 0x0b, 0xea, 0xfc, 0x23, 0xe8, 0x8f, 0x4c, 0x92, 0xed,
-	0x99, 0x1c, 0xdd, 0xfe, 0xb2, 0x1a, 0x46, 0x5f, 0x57, 0xc3, 0xe8, 0xfb, 0x6a, 0x18, 0x7d, 0xfc,
-	0x31, 0xbc, 0xf6, 0xe6, 0xfa, 0xfa, 0x67, 0x9d, 0x75, 0xfd, 0x8b, 0x78, 0xfa, 0x2b, 0x00, 0x00,
-	0xff, 0xff, 0x93, 0x87, 0xe2, 0xd0, 0x5b, 0x03, 0x00, 0x00,
+	// 485 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0xcd, 0x8e, 0xd3, 0x30,
+	0x10, 0x80, 0x09, 0x69, 0xba, 0xd4, 0xa5, 0xbb, 0xc1, 0x5a, 0xad, 0x02, 0x48, 0xdd, 0x50, 0x81,
+	0x28, 0x97, 0x20, 0x81, 0xc4, 0x89, 0x0b, 0x5b, 0x38, 0xec, 0x61, 0x2f, 0x2e, 0x27, 0x24, 0x14,
+	0x79, 0xed, 0x49, 0x55, 0x35, 0xb1, 0x17, 0xff, 0x40, 0xfb, 0x16, 0xbc, 0x06, 0x37, 0xae, 0xbc,
+	0x01, 0x47, 0x9e, 0x00, 0xa1, 0xf2, 0x22, 0xc8, 0x76, 0x5b, 0x4a, 0xe9, 0x01, 0x71, 0x1b, 0x7f,
+	0x8a, 0xc7, 0xf3, 0xcd, 0x4c, 0x10, 0x6a, 0xc0, 0xd0, 0xe2, 0x4a, 0x49, 0x23, 0xf1, 0x7d, 0x26,
+	0x9b, 0x62, 0x2a, 0xaa, 0xda, 0xce, 0x39, 0x75, 0xb4, 0xa6, 0xa6, 0x92, 0xaa, 0x29, 0x0c, 0xd5,
+	0xb3, 0xe2, 0x92, 0xb2, 0x19, 0x08, 0x7e, 0xe7, 0x78, 0x22, 0x27, 0xd2, 0x5f, 0x78, 0xec, 0xa2,
+	0x70, 0x77, 0xf0, 0x29, 0x46, 0xbd, 0xb1, 0x91, 0x0a, 0x5e, 0x53, 0x3d, 0xbb, 0x00, 0x43, 0xf1,
+	0x43, 0x74, 0xd4, 0xd0, 0x79, 0xc9, 0xa4, 0x60, 0x56, 0x29, 0x10, 0x6c, 0x91, 0x45, 0x79, 0x34,
+	0x4c, 0xc8, 0x61, 0x43, 0xe7, 0xa3, 0xdf, 0x14, 0x3f, 0x42, 0x69, 0x4d, 0x0d, 0x68, 0x53, 0x32,
+	0xd9, 0x5c, 0xd5, 0x60, 0x80, 0x67, 0xd7, 0xf3, 0x68, 0x18, 0x93, 0xa3, 0xc0, 0x47, 0x6b, 0x8c,
+	0x4f, 0x50, 0x5b, 0x1b, 0x6a, 0xac, 0xce, 0xe2, 0x3c, 0x1a, 0x76, 0xc8, 0xea, 0x84, 0x19, 0xba,
+	0x15, 0xd2, 0x99, 0x7a, 0x51, 0x2a, 0x2b, 0xc4, 0x54, 0x4c, 0xb2, 0x56, 0x1e, 0x0f, 0xbb, 0x4f,
+	0x9e, 0x15, 0xff, 0x62, 0x55, 0xfc, 0x51, 0x3b, 0xb1, 0x82, 0xa4, 0x9b, 0x84, 0x24, 0xe4, 0xc3,
+	0x0f, 0xd0, 0x21, 0x54, 0x15, 0x30, 0x33, 0x7d, 0x0f, 0x25, 0x53, 0x52, 0x64, 0x89, 0x2f, 0xa2,
+	0xb7, 0xa1, 0x23, 0x25, 0x85, 0xab, 0x51, 0x56, 0x95, 0x06, 0x93, 0xb5, 0xbd, 0xee, 0xea, 0xb4,
+	0xa5, 0xa9, 0x2d, 0x63, 0x00, 0x1c, 0x78, 0x76, 0xb0, 0xad, 0x39, 0x5e, 0x63, 0xfc, 0x16, 0x75,
+	0x1b, 0x2a, 0x2c, 0xad, 0x9d, 0x8b, 0xce, 0x52, 0x2f, 0xf2, 0xfc, 0x3f, 0x44, 0x2e, 0x7c, 0x16,
+	0xa7, 0x83, 0x9a, 0x75, 0xa8, 0x07, 0x5f, 0x22, 0x94, 0xee, 0xfa, 0xe2, 0x14, 0xc5, 0x42, 0x7e,
+	0xf0, 0x23, 0x8a, 0x89, 0x0b, 0x1d, 0x31, 0x6a, 0xe1, 0x47, 0xd1, 0x23, 0x2e, 0xc4, 0x39, 0x6a,
+	0x2b, 0x2b, 0xca, 0x29, 0xf7, 0xed, 0x6f, 0x9d, 0x75, 0x96, 0xdf, 0x4f, 0x13, 0x62, 0xc5, 0xf9,
+	0x4b, 0x92, 0x28, 0x2b, 0xce, 0x39, 0x3e, 0x45, 0x5d, 0x45, 0xc5, 0x04, 0x4a, 0x6d, 0xa8, 0x32,
+	0x59, 0xcb, 0x67, 0x43, 0x1e, 0x8d, 0x1d, 0xc1, 0x77, 0x51, 0x27, 0x7c, 0x00, 0x82, 0xfb, 0xfe,
+	0xc5, 0xe4, 0x86, 0x07, 0xaf, 0x04, 0xc7, 0xf7, 0xd0, 0x4d, 0x05, 0xef, 0x2c, 0x68, 0x03, 0xbc,
+	0xa4, 0xa1, 0x81, 0x31, 0xe9, 0x6e, 0xd8, 0x0b, 0x33, 0xf8, 0x1c, 0xa1, 0x93, 0xfd, 0x8a, 0xf8,
+	0x18, 0x25, 0xe1, 0xd5, 0xe0, 0x10, 0x0e, 0xce, 0xc2, 0x3d, 0x15, 0x16, 0xca, 0x85, 0x7b, 0xf7,
+	0x2d, 0xde, 0xbf, 0x6f, 0xbb, 0x05, 0xb5, 0xfe, 0x2a, 0x68, 0xab, 0x27, 0xc9, 0xfe, 0x9e, 0x9c,
+	0xdd, 0xfe, 0xba, 0xec, 0x47, 0xdf, 0x96, 0xfd, 0xe8, 0xc7, 0xb2, 0x1f, 0x7d, 0xfc, 0xd9, 0xbf,
+	0xf6, 0xe6, 0x60, 0x35, 0xac, 0xcb, 0xb6, 0xff, 0x79, 0x9e, 0xfe, 0x1a, 0x00, 0xeb, 0x5e, 0xd9,
+	0xa1, 0x86, 0x03, 0x00, 0x00,
 }
@@ -213,6 +213,81 @@ func TestMeta_CreateNextRun_Delay(t *testing.T) {
 	}
 }
 
+func TestMeta_IncrementRunTry(t *testing.T) {
+	stm := backend.StoreTaskMeta{
+		MaxConcurrency:  2,
+		Status:          "enabled",
+		EffectiveCron:   "* * * * *", // Every minute.
+		LatestCompleted: 60,
+	}
+
+	rc, err := stm.CreateNextRun(300, makeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rc.Created.Try != 1 {
+		t.Fatalf("expected created run to be on try 1, got %d", rc.Created.Try)
+	}
+
+	for exp := uint32(2); exp <= 3; exp++ {
+		try, ok := stm.IncrementRunTry(rc.Created.RunID)
+		if !ok {
+			t.Fatal("expected run to be found")
+		}
+		if try != exp {
+			t.Fatalf("expected try %d, got %d", exp, try)
+		}
+		if got := stm.CurrentlyRunning[0].Try; got != exp {
+			t.Fatalf("expected currently running run to be on try %d, got %d", exp, got)
+		}
+	}
+
+	if _, ok := stm.IncrementRunTry(platform.ID(rc.Created.RunID + 1)); ok {
+		t.Fatal("expected unknown run to not be found")
+	}
+}
+
+func TestMeta_SucceedRun(t *testing.T) {
+	stm := backend.StoreTaskMeta{
+		MaxConcurrency:  2,
+		Status:          "enabled",
+		EffectiveCron:   "* * * * *", // Every minute.
+		LatestCompleted: 60,
+		LatestSucceeded: 60,
+	}
+
+	failed, err := stm.CreateNextRun(300, makeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	succeeded, err := stm.CreateNextRun(300, makeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A failed run completes the schedule, but isn't a success.
+	if !stm.FinishRun(failed.Created.RunID) {
+		t.Fatal("expected run to be found")
+	}
+	if stm.LatestCompleted != 120 || stm.LatestSucceeded != 60 {
+		t.Fatalf("expected latest completed 120 and latest succeeded 60, got %d and %d", stm.LatestCompleted, stm.LatestSucceeded)
+	}
+
+	if !stm.SucceedRun(succeeded.Created.RunID) {
+		t.Fatal("expected run to be found")
+	}
+	if stm.LatestCompleted != 180 || stm.LatestSucceeded != 180 {
+		t.Fatalf("expected latest completed and latest succeeded 180, got %d and %d", stm.LatestCompleted, stm.LatestSucceeded)
+	}
+	if len(stm.CurrentlyRunning) != 0 {
+		t.Fatalf("expected no running runs, got %d", len(stm.CurrentlyRunning))
+	}
+
+	if stm.SucceedRun(succeeded.Created.RunID) {
+		t.Fatal("expected finished run to not be found")
+	}
+}
+
 func TestMeta_ManuallyRunTimeRange(t *testing.T) {
 	now := time.Now().Unix()
 	stm := backend.StoreTaskMeta{
@@ -14,6 +14,16 @@ const (
 	runIDField        = "runID"
 	scheduledForField = "scheduledFor"
 	requestedAtField  = "requestedAt"
+	versionField      = "scriptVersion"
+
+	totalDurationField   = "totalDuration"
+	queueDurationField   = "queueDuration"
+	compileDurationField = "compileDuration"
+	planDurationField    = "planDuration"
+	executeDurationField = "executeDuration"
+	valuesReadField      = "valuesRead"
+	bytesReadField       = "bytesRead"
+	pointsWrittenField   = "pointsWritten"
 
 	taskIDTag = "taskID"
 	statusTag = "status"
@@ -43,12 +53,15 @@ func (p *PointLogWriter) UpdateRunState(ctx context.Context, rlb RunLogBase, whe
 		models.NewTag([]byte(statusTag), []byte(status.String())),
 		models.NewTag([]byte(taskIDTag), []byte(rlb.Task.ID.String())),
 	}
-	fields := make(map[string]interface{}, 3)
+	fields := make(map[string]interface{}, 4)
 	fields[runIDField] = rlb.RunID.String()
 	fields[scheduledForField] = time.Unix(rlb.RunScheduledFor, 0).UTC().Format(time.RFC3339)
 	if rlb.RequestedAt != 0 {
 		fields[requestedAtField] = time.Unix(rlb.RequestedAt, 0).UTC().Format(time.RFC3339)
 	}
+	if rlb.Task.Version != 0 {
+		fields[versionField] = int64(rlb.Task.Version)
+	}
 
 	pt, err := models.NewPoint("records", tags, fields, when)
 	if err != nil {
@@ -85,3 +98,31 @@ func (p *PointLogWriter) AddRunLog(ctx context.Context, rlb RunLogBase, when tim
 
 	return p.pointsWriter.WritePoints(exploded)
 }
+
+func (p *PointLogWriter) UpdateRunStatistics(ctx context.Context, rlb RunLogBase, when time.Time, stats platform.RunStatistics) error {
+	tags := models.Tags{
+		models.NewTag([]byte(taskIDTag), []byte(rlb.Task.ID.String())),
+	}
+	fields := map[string]interface{}{
+		runIDField:           rlb.RunID.String(),
+		totalDurationField:   int64(stats.TotalDuration),
+		queueDurationField:   int64(stats.QueueDuration),
+		compileDurationField: int64(stats.CompileDuration),
+		planDurationField:    int64(stats.PlanDuration),
+		executeDurationField: int64(stats.ExecuteDuration),
+		valuesReadField:      stats.ValuesRead,
+		bytesReadField:       stats.BytesRead,
+		pointsWrittenField:   stats.PointsWritten,
+	}
+	pt, err := models.NewPoint("stats", tags, fields, when)
+	if err != nil {
+		return err
+	}
+
+	exploded, err := tsdb.ExplodePoints(rlb.Task.Org, taskSystemBucketID, []models.Point{pt})
+	if err != nil {
+		return err
+	}
+
+	return p.pointsWriter.WritePoints(exploded)
+}
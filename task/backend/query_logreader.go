@@ -121,11 +121,19 @@ main = from(bucketID: "000000000000000a")
   |> pivot(rowKey:["runID"], columnKey: ["status"], valueColumn: "_time")
   |> filter(fn: (r) => r.runID > %q)
 
+stats = from(bucketID: "000000000000000a")
+  |> range(start: -24h)
+  |> filter(fn: (r) => r._measurement == "stats" and r.taskID == %q)
+  |> pivot(rowKey:["_time"], columnKey: ["_field"], valueColumn: "_value")
+  |> group(columns: ["taskID", "runID", "_measurement"])
+
 join(tables: {main: main, supl: supl}, on: ["_start", "_stop", "orgID", "taskID", "runID", "_measurement"])
   |> group(columns: ["_measurement"])
   %s
   |> yield(name: "result")
-  `, runFilter.Task.String(), scheduledBefore, scheduledAfter, runFilter.Task.String(), afterID, limit)
+
+stats |> yield(name: "stats")
+  `, runFilter.Task.String(), scheduledBefore, scheduledAfter, runFilter.Task.String(), afterID, runFilter.Task.String(), limit)
 
 	auth, err := pctx.GetAuthorizer(ctx)
 	if err != nil {
@@ -160,6 +168,12 @@ logs = from(bucketID: "000000000000000a")
   |> pivot(rowKey:["_time"], columnKey: ["_field"], valueColumn: "_value")
 	|> filter(fn: (r) => r.runID == %q)
 
+stats = from(bucketID: "000000000000000a")
+  |> range(start: -24h)
+  |> filter(fn: (r) => r._measurement == "stats")
+  |> pivot(rowKey:["_time"], columnKey: ["_field"], valueColumn: "_value")
+  |> filter(fn: (r) => r.runID == %q)
+
 main = from(bucketID: "000000000000000a")
   |> range(start: -24h)
   |> filter(fn: (r) => r._measurement == "records")
@@ -173,7 +187,9 @@ join(
 ) |> yield(name: "result")
 
 logs |> yield(name: "logs")
-  `, runID.String(), runID.String(), runID.String())
+
+stats |> yield(name: "stats")
+  `, runID.String(), runID.String(), runID.String(), runID.String())
 
 	auth, err := pctx.GetAuthorizer(ctx)
 	if err != nil {
@@ -219,18 +235,25 @@ func queryIttrToRuns(results flux.ResultIterator) ([]*platform.Run, error) {
 
 // runExtractor is used to decode query results to runs.
 type runExtractor struct {
-	runs map[platform.ID]platform.Run
+	runs  map[platform.ID]platform.Run
+	stats map[platform.ID]platform.RunStatistics
 }
 
 func newRunExtractor() *runExtractor {
-	return &runExtractor{runs: make(map[platform.ID]platform.Run)}
+	return &runExtractor{
+		runs:  make(map[platform.ID]platform.Run),
+		stats: make(map[platform.ID]platform.RunStatistics),
+	}
 }
 
 // Runs returns the runExtractor's stored runs as a slice.
 func (re *runExtractor) Runs() []*platform.Run {
 	runs := make([]*platform.Run, 0, len(re.runs))
-	for _, r := range re.runs {
+	for id, r := range re.runs {
 		r := r
+		if stats, ok := re.stats[id]; ok {
+			r.Statistics = &stats
+		}
 		runs = append(runs, &r)
 	}
 
@@ -254,6 +277,8 @@ func (re *runExtractor) Extract(tbl flux.Table) error {
 		return tbl.Do(re.extractRecord)
 	case "logs":
 		return tbl.Do(re.extractLog)
+	case "stats":
+		return tbl.Do(re.extractStats)
 	default:
 		return fmt.Errorf("unknown measurement: %q", mv.Str())
 	}
@@ -268,6 +293,8 @@ func (re *runExtractor) extractRecord(cr flux.ColReader) error {
 				r.RequestedAt = cr.Strings(j)[i]
 			case scheduledForField:
 				r.ScheduledFor = cr.Strings(j)[i]
+			case versionField:
+				r.ScriptVersion = int(cr.Ints(j)[i])
 			case "status":
 				r.Status = cr.Strings(j)[i]
 			case "runID":
@@ -338,3 +365,44 @@ func (re *runExtractor) extractLog(cr flux.ColReader) error {
 
 	return nil
 }
+
+func (re *runExtractor) extractStats(cr flux.ColReader) error {
+	for i := 0; i < cr.Len(); i++ {
+		var runID platform.ID
+		var stats platform.RunStatistics
+		for j, col := range cr.Cols() {
+			switch col.Label {
+			case runIDField:
+				id, err := platform.IDFromString(cr.Strings(j)[i])
+				if err != nil {
+					return err
+				}
+				runID = *id
+			case totalDurationField:
+				stats.TotalDuration = time.Duration(cr.Ints(j)[i])
+			case queueDurationField:
+				stats.QueueDuration = time.Duration(cr.Ints(j)[i])
+			case compileDurationField:
+				stats.CompileDuration = time.Duration(cr.Ints(j)[i])
+			case planDurationField:
+				stats.PlanDuration = time.Duration(cr.Ints(j)[i])
+			case executeDurationField:
+				stats.ExecuteDuration = time.Duration(cr.Ints(j)[i])
+			case valuesReadField:
+				stats.ValuesRead = cr.Ints(j)[i]
+			case bytesReadField:
+				stats.BytesRead = cr.Ints(j)[i]
+			case pointsWrittenField:
+				stats.PointsWritten = cr.Ints(j)[i]
+			}
+		}
+
+		if !runID.Valid() {
+			return errors.New("extractStats: did not find valid run ID in table")
+		}
+
+		re.stats[runID] = stats
+	}
+
+	return nil
+}
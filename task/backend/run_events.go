@@ -0,0 +1,138 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+// runEventBufferSize is the number of events that may be pending delivery to a subscriber.
+// A subscriber that falls further behind is unsubscribed, and its channel closed.
+const runEventBufferSize = 256
+
+// RunEventHub publishes the run state changes and logs written through its LogWriter to subscribers,
+// so that runs can be followed as they happen rather than by polling a LogReader.
+type RunEventHub struct {
+	mu     sync.Mutex
+	nextID uint64
+	subs   map[uint64]*runEventSub
+}
+
+var _ platform.RunEventService = (*RunEventHub)(nil)
+
+type runEventSub struct {
+	filter platform.RunEventFilter
+	ch     chan platform.RunEvent
+}
+
+func (s *runEventSub) matches(e platform.RunEvent) bool {
+	if e.TaskID != s.filter.Task {
+		return false
+	}
+	return s.filter.Run == nil || *s.filter.Run == e.RunID
+}
+
+// NewRunEventHub returns a RunEventHub with no subscribers.
+func NewRunEventHub() *RunEventHub {
+	return &RunEventHub{subs: make(map[uint64]*runEventSub)}
+}
+
+// LogWriter returns a LogWriter that writes to lw and publishes each run state change and log to the hub's subscribers.
+func (h *RunEventHub) LogWriter(lw LogWriter) LogWriter {
+	return &runEventLogWriter{LogWriter: lw, hub: h}
+}
+
+// SubscribeRunEvents delivers the run events matching filter until ctx is canceled.
+// Events are not buffered for subscribers that do not keep up;
+// instead, the subscription ends and the returned channel is closed early.
+func (h *RunEventHub) SubscribeRunEvents(ctx context.Context, filter platform.RunEventFilter) (<-chan platform.RunEvent, error) {
+	if !filter.Task.Valid() {
+		return nil, errors.New("run events require a valid task ID")
+	}
+
+	sub := &runEventSub{
+		filter: filter,
+		ch:     make(chan platform.RunEvent, runEventBufferSize),
+	}
+
+	h.mu.Lock()
+	id := h.nextID
+	h.nextID++
+	h.subs[id] = sub
+	h.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		h.unsubscribe(id)
+	}()
+
+	return sub.ch, nil
+}
+
+// unsubscribe closes the channel of the subscriber with the given ID, if it is still subscribed.
+func (h *RunEventHub) unsubscribe(id uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if sub, ok := h.subs[id]; ok {
+		delete(h.subs, id)
+		close(sub.ch)
+	}
+}
+
+func (h *RunEventHub) publish(e platform.RunEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for id, sub := range h.subs {
+		if !sub.matches(e) {
+			continue
+		}
+
+		select {
+		case sub.ch <- e:
+		default:
+			// The subscriber is too far behind to deliver events in order; drop it.
+			delete(h.subs, id)
+			close(sub.ch)
+		}
+	}
+}
+
+// runEventLogWriter is a LogWriter that publishes to a RunEventHub after writing to the underlying LogWriter.
+type runEventLogWriter struct {
+	LogWriter
+	hub *RunEventHub
+}
+
+func (w *runEventLogWriter) UpdateRunState(ctx context.Context, base RunLogBase, when time.Time, state RunStatus) error {
+	err := w.LogWriter.UpdateRunState(ctx, base, when, state)
+
+	e := newRunEvent(base, when)
+	e.Status = state.String()
+	e.Done = state == RunSuccess || state == RunFail || state == RunCanceled
+	w.hub.publish(e)
+
+	return err
+}
+
+func (w *runEventLogWriter) AddRunLog(ctx context.Context, base RunLogBase, when time.Time, log string) error {
+	err := w.LogWriter.AddRunLog(ctx, base, when, log)
+
+	e := newRunEvent(base, when)
+	e.Log = platform.Log(log)
+	w.hub.publish(e)
+
+	return err
+}
+
+func newRunEvent(base RunLogBase, when time.Time) platform.RunEvent {
+	return platform.RunEvent{
+		TaskID: base.Task.ID,
+		RunID:  base.RunID,
+		Time:   when.UTC().Format(time.RFC3339Nano),
+	}
+}
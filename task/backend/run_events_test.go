@@ -0,0 +1,141 @@
+package backend_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+)
+
+func TestRunEventHub(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hub := backend.NewRunEventHub()
+	rw := backend.NewInMemRunReaderWriter()
+	lw := hub.LogWriter(rw)
+
+	task := &backend.StoreTask{ID: idGen.ID(), Org: idGen.ID()}
+	otherTask := &backend.StoreTask{ID: idGen.ID(), Org: task.Org}
+	runID := idGen.ID()
+	otherRunID := idGen.ID()
+
+	taskEvents, err := hub.SubscribeRunEvents(ctx, platform.RunEventFilter{Task: task.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	runEvents, err := hub.SubscribeRunEvents(ctx, platform.RunEventFilter{Task: task.ID, Run: &runID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1000, 0)
+	run := backend.RunLogBase{Task: task, RunID: runID, RunScheduledFor: 1000}
+	otherRun := backend.RunLogBase{Task: task, RunID: otherRunID, RunScheduledFor: 1000}
+	if err := lw.UpdateRunState(ctx, run, now, backend.RunStarted); err != nil {
+		t.Fatal(err)
+	}
+	if err := lw.UpdateRunState(ctx, otherRun, now, backend.RunStarted); err != nil {
+		t.Fatal(err)
+	}
+	if err := lw.UpdateRunState(ctx, backend.RunLogBase{Task: otherTask, RunID: idGen.ID(), RunScheduledFor: 1000}, now, backend.RunStarted); err != nil {
+		t.Fatal(err)
+	}
+	if err := lw.AddRunLog(ctx, run, now.Add(time.Second), "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := lw.UpdateRunState(ctx, run, now.Add(2*time.Second), backend.RunSuccess); err != nil {
+		t.Fatal(err)
+	}
+
+	// The events should also have been written through to the underlying LogWriter.
+	r, err := rw.FindRunByID(ctx, task.Org, runID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Status != backend.RunSuccess.String() {
+		t.Fatalf("expected underlying run status %q, got %q", backend.RunSuccess.String(), r.Status)
+	}
+
+	next := func(ch <-chan platform.RunEvent) platform.RunEvent {
+		t.Helper()
+		select {
+		case e := <-ch:
+			return e
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for run event")
+		}
+		panic("unreachable")
+	}
+
+	// The run subscriber sees only the events for its run, in order.
+	if e := next(runEvents); e.RunID != runID || e.Status != "started" || e.Done {
+		t.Fatalf("unexpected first run event: %#v", e)
+	}
+	if e := next(runEvents); e.Log != "hello" || e.Status != "" || e.Time != "1970-01-01T00:16:41Z" {
+		t.Fatalf("unexpected log run event: %#v", e)
+	}
+	if e := next(runEvents); e.Status != "success" || !e.Done {
+		t.Fatalf("unexpected final run event: %#v", e)
+	}
+
+	// The task subscriber sees events for every run of the task, but not of other tasks.
+	var ids []platform.ID
+	for i := 0; i < 4; i++ {
+		e := next(taskEvents)
+		if e.TaskID != task.ID {
+			t.Fatalf("unexpected task ID in event: %#v", e)
+		}
+		ids = append(ids, e.RunID)
+	}
+	if ids[1] != otherRunID {
+		t.Fatalf("expected second task event to be for run %s, got %s", otherRunID.String(), ids[1].String())
+	}
+
+	// Canceling the subscription closes the channel.
+	cancel()
+	select {
+	case _, ok := <-runEvents:
+		if ok {
+			t.Fatal("expected no more events after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for channel to close")
+	}
+
+	if _, err := hub.SubscribeRunEvents(context.Background(), platform.RunEventFilter{}); err == nil {
+		t.Fatal("expected error subscribing without a task ID")
+	}
+}
+
+func TestRunEventHub_SlowSubscriber(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hub := backend.NewRunEventHub()
+	lw := hub.LogWriter(backend.NopLogWriter{})
+
+	task := &backend.StoreTask{ID: idGen.ID(), Org: idGen.ID()}
+	events, err := hub.SubscribeRunEvents(ctx, platform.RunEventFilter{Task: task.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Never reading from the channel, the subscriber must not block the writer.
+	run := backend.RunLogBase{Task: task, RunID: idGen.ID()}
+	for i := 0; i < 1000; i++ {
+		if err := lw.AddRunLog(ctx, run, time.Now(), "log"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	n := 0
+	for range events {
+		n++
+	}
+	if n == 0 || n >= 1000 {
+		t.Fatalf("expected slow subscriber to receive some but not all events, got %d", n)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/options"
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -26,6 +27,14 @@ var (
 	ErrTaskAlreadyClaimed = errors.New("task already claimed")
 )
 
+const (
+	// DefaultRetryBackoff is how long the scheduler waits before the first retry of a failed run.
+	DefaultRetryBackoff = time.Second
+
+	// DefaultMaxRetryBackoff is the longest the scheduler waits before retrying a failed run.
+	DefaultMaxRetryBackoff = time.Minute
+)
+
 // DesiredState persists the desired state of a run.
 type DesiredState interface {
 	// CreateNextRun requests the next run from the desired state, delegating to (*StoreTaskMeta).CreateNextRun.
@@ -39,6 +48,20 @@ type DesiredState interface {
 	// FinishRun indicates that the given run is no longer intended to be executed.
 	// This may be called after a successful or failed execution, or upon cancellation.
 	FinishRun(ctx context.Context, taskID, runID platform.ID) error
+
+	// SucceedRun is FinishRun for a run that executed successfully.
+	// Upstream tasks are gated on the runs recorded with SucceedRun.
+	SucceedRun(ctx context.Context, taskID, runID platform.ID) error
+
+	// IncrementRunTry records that the given run is about to be attempted again,
+	// delegating to (*StoreTaskMeta).IncrementRunTry. It returns the run's new try.
+	IncrementRunTry(ctx context.Context, taskID, runID platform.ID) (uint32, error)
+}
+
+// TaskMetaReader reads the meta of a task.
+// The scheduler uses it to find whether the upstream tasks of a task have completed a run.
+type TaskMetaReader interface {
+	FindTaskMetaByID(ctx context.Context, id platform.ID) (*StoreTaskMeta, error)
 }
 
 // Executor handles execution of a run.
@@ -66,6 +89,9 @@ type QueuedRun struct {
 	// The Unix timestamp (seconds since January 1, 1970 UTC) that will be set
 	// as the "now" option when executing the task.
 	Now int64
+
+	// Try is the attempt number of the run, starting at 1.
+	Try uint32
 }
 
 // RunPromise represents an in-progress run whose result is not yet known.
@@ -92,7 +118,8 @@ type RunResult interface {
 	// IsRetryable returns true if the error was non-terminal and the run is eligible for retry.
 	IsRetryable() bool
 
-	// TODO(mr): add more detail here like number of points written, execution time, etc.
+	// Statistics reports how the run's query executed.
+	Statistics() platform.RunStatistics
 }
 
 // Scheduler accepts tasks and handles their scheduling.
@@ -160,6 +187,23 @@ func WithLogger(logger *zap.Logger) TickSchedulerOption {
 	}
 }
 
+// WithRetryBackoff sets how long the scheduler waits before retrying a failed run.
+// The wait starts at initial and doubles with every attempt, up to max.
+func WithRetryBackoff(initial, max time.Duration) TickSchedulerOption {
+	return func(s *TickScheduler) {
+		s.retryBackoff = initial
+		s.maxRetryBackoff = max
+	}
+}
+
+// WithTaskMetaReader sets how the scheduler reads the meta of upstream tasks.
+// If not set, the scheduler uses the desired state when it implements TaskMetaReader.
+func WithTaskMetaReader(r TaskMetaReader) TickSchedulerOption {
+	return func(s *TickScheduler) {
+		s.metaReader = r
+	}
+}
+
 // NewScheduler returns a new scheduler with the given desired state and the given now UTC timestamp.
 func NewScheduler(desiredState DesiredState, executor Executor, lw LogWriter, now int64, opts ...TickSchedulerOption) *TickScheduler {
 	o := &TickScheduler{
@@ -171,6 +215,12 @@ func NewScheduler(desiredState DesiredState, executor Executor, lw LogWriter, no
 		logger:         zap.NewNop(),
 		wg:             &sync.WaitGroup{},
 		metrics:        newSchedulerMetrics(),
+
+		retryBackoff:    DefaultRetryBackoff,
+		maxRetryBackoff: DefaultMaxRetryBackoff,
+	}
+	if r, ok := desiredState.(TaskMetaReader); ok {
+		o.metaReader = r
 	}
 
 	for _, opt := range opts {
@@ -184,12 +234,15 @@ type TickScheduler struct {
 	desiredState DesiredState
 	executor     Executor
 	logWriter    LogWriter
+	metaReader   TaskMetaReader
 
 	now    int64
 	logger *zap.Logger
 
 	metrics *schedulerMetrics
 
+	retryBackoff, maxRetryBackoff time.Duration
+
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     *sync.WaitGroup
@@ -340,9 +393,9 @@ func (s *TickScheduler) UpdateTask(task *StoreTask, meta *StoreTaskMeta) error {
 
 	s.taskSchedulers[task.ID] = nts
 
-	next, hasQueue := ts.NextDue()
+	next, hasQueue := nts.NextDue()
 	if now := atomic.LoadInt64(&s.now); now >= next || hasQueue {
-		ts.Work()
+		nts.Work()
 	}
 
 	return nil
@@ -395,6 +448,17 @@ type taskScheduler struct {
 
 	metrics *schedulerMetrics
 
+	// Maximum number of attempts of a run whose failure is retryable, from the task's retry option.
+	maxTries int64
+
+	retryBackoff, maxRetryBackoff time.Duration
+
+	// Upstream tasks that must complete a run before this task's run for the same time starts,
+	// from the task's dependsOn option.
+	dependsOn  []platform.ID
+	metaReader TaskMetaReader
+	offset     int64 // Offset of the task's runs, in seconds.
+
 	nextDueMu     sync.RWMutex // Protects following fields.
 	nextDue       int64        // Unix timestamp of next due.
 	nextDueSource int64        // Run time that produced nextDue.
@@ -414,6 +478,14 @@ func newTaskScheduler(
 		return nil, err
 	}
 
+	// A task whose options can't be read just isn't retried, and doesn't wait for upstream tasks.
+	maxTries := int64(1)
+	var dependsOn []platform.ID
+	if opts, err := options.FromScript(task.Script); err == nil {
+		maxTries = opts.Retry
+		dependsOn = opts.DependsOn
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	ts := &taskScheduler{
 		now:           &s.now,
@@ -427,6 +499,14 @@ func newTaskScheduler(
 		nextDue:       firstDue,
 		nextDueSource: math.MinInt64,
 		hasQueue:      len(meta.ManualRuns) > 0,
+
+		maxTries:        maxTries,
+		retryBackoff:    s.retryBackoff,
+		maxRetryBackoff: s.maxRetryBackoff,
+
+		dependsOn:  dependsOn,
+		metaReader: s.metaReader,
+		offset:     int64(meta.Offset),
 	}
 
 	for i := range ts.runners {
@@ -453,7 +533,7 @@ func (ts *taskScheduler) WorkCurrentlyRunning(meta *StoreTaskMeta) error {
 	for _, cr := range meta.CurrentlyRunning {
 		foundWorker := false
 		for _, r := range ts.runners {
-			qr := QueuedRun{TaskID: ts.task.ID, RunID: platform.ID(cr.RunID), Now: cr.Now}
+			qr := QueuedRun{TaskID: ts.task.ID, RunID: platform.ID(cr.RunID), Now: cr.Now, Try: cr.Try}
 			if r.RestartRun(qr) {
 				foundWorker = true
 				break
@@ -468,6 +548,18 @@ func (ts *taskScheduler) WorkCurrentlyRunning(meta *StoreTaskMeta) error {
 	return nil
 }
 
+// RetryBackoff returns how long to wait before retrying a run whose try-th attempt failed.
+func (ts *taskScheduler) RetryBackoff(try uint32) time.Duration {
+	d := ts.retryBackoff
+	for i := uint32(1); i < try && d < ts.maxRetryBackoff; i++ {
+		d *= 2
+	}
+	if d > ts.maxRetryBackoff {
+		d = ts.maxRetryBackoff
+	}
+	return d
+}
+
 // Cancel interrupts this taskScheduler and its runners.
 func (ts *taskScheduler) Cancel() {
 	ts.cancel()
@@ -491,6 +583,39 @@ func (ts *taskScheduler) SetNextDue(nextDue int64, hasQueue bool, source int64)
 	ts.hasQueue = hasQueue
 }
 
+// UpstreamsCompleted reports whether every upstream task has succeeded in its run scheduled for the Unix timestamp scheduledFor.
+// An upstream's run is considered successful once the upstream's LatestSucceeded has reached scheduledFor.
+//
+// A non-nil error is returned if an upstream task was deleted or is inactive, so the run it waits for will never succeed.
+func (ts *taskScheduler) UpstreamsCompleted(ctx context.Context, scheduledFor int64) (bool, error) {
+	if len(ts.dependsOn) == 0 {
+		return true, nil
+	}
+	if ts.metaReader == nil {
+		ts.logger.Warn("Task has upstream tasks, but the scheduler can't read their progress")
+		return false, nil
+	}
+
+	for _, id := range ts.dependsOn {
+		meta, err := ts.metaReader.FindTaskMetaByID(ctx, id)
+		if err == ErrTaskNotFound {
+			return false, fmt.Errorf("upstream task %s was deleted", id)
+		}
+		if err != nil {
+			ts.logger.Info("Failed to read upstream task", zap.String("upstream_task_id", id.String()), zap.Error(err))
+			return false, nil
+		}
+		if meta.LatestSucceeded >= scheduledFor {
+			continue
+		}
+		if meta.Status == string(TaskInactive) {
+			return false, fmt.Errorf("upstream task %s is inactive", id)
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
 // A runner is one eligible "concurrency slot" for a given task.
 type runner struct {
 	state *uint32
@@ -582,18 +707,33 @@ func (r *runner) RestartRun(qr QueuedRun) bool {
 	r.ts.runningMu.Unlock()
 	go r.executeAndWait(rCtx.Context, qr, runLogger)
 
-	r.updateRunState(qr, RunStarted, runLogger)
+	r.updateRunState(qr, RunStarted, runLogger, nil)
 	return true
 }
 
 // startFromWorking attempts to create a run if one is due, and then begins execution on a separate goroutine.
 // r.state must be runnerWorking when this is called.
 func (r *runner) startFromWorking(now int64) {
-	if nextDue, hasQueue := r.ts.NextDue(); now < nextDue && !hasQueue {
+	nextDue, hasQueue := r.ts.NextDue()
+	if now < nextDue && !hasQueue {
 		// Not ready for a new run. Go idle again.
 		atomic.StoreUint32(r.state, runnerIdle)
 		return
 	}
+	if now >= nextDue {
+		completed, err := r.ts.UpstreamsCompleted(r.ctx, nextDue-r.ts.offset)
+		if err != nil {
+			// An upstream task will never complete the run, so fail the scheduled run rather than wait forever.
+			r.failBlockedRun(now, err)
+			return
+		}
+		if !completed {
+			// The scheduled run is due, but waits for its upstream tasks. Try again on a later tick.
+			r.logger.Debug("Waiting for upstream tasks", zap.Int64("scheduled_for", nextDue-r.ts.offset))
+			atomic.StoreUint32(r.state, runnerIdle)
+			return
+		}
+	}
 	ctx, cancel := context.WithCancel(r.ctx)
 	rc, err := r.desiredState.CreateNextRun(ctx, r.task.ID, now)
 	if err != nil {
@@ -617,7 +757,29 @@ func (r *runner) startFromWorking(now int64) {
 	r.wg.Add(1)
 	go r.executeAndWait(ctx, qr, runLogger)
 
-	r.updateRunState(qr, RunStarted, runLogger)
+	r.updateRunState(qr, RunStarted, runLogger, nil)
+}
+
+// failBlockedRun creates the next run and fails it without executing it, recording why its upstream tasks block it.
+// r.state must be runnerWorking when this is called.
+func (r *runner) failBlockedRun(now int64, blockErr error) {
+	defer atomic.StoreUint32(r.state, runnerIdle)
+
+	rc, err := r.desiredState.CreateNextRun(r.ctx, r.task.ID, now)
+	if err != nil {
+		r.logger.Info("Failed to create run", zap.Error(err))
+		return
+	}
+	qr := rc.Created
+	r.ts.SetNextDue(rc.NextDue, rc.HasQueue, qr.Now)
+
+	runLogger := r.logger.With(zap.String("run_id", qr.RunID.String()), zap.Int64("now", qr.Now))
+	runLogger.Info("Upstream task blocks run; failing it", zap.Error(blockErr))
+	r.updateRunState(qr, RunStarted, runLogger, nil)
+	if err := r.desiredState.FinishRun(r.ctx, qr.TaskID, qr.RunID); err != nil {
+		runLogger.Info("Failed to finish run", zap.Error(err))
+	}
+	r.updateRunState(qr, RunFail, runLogger, blockErr)
 }
 
 func (r *runner) clearRunning(id platform.ID) {
@@ -633,74 +795,139 @@ func (r *runner) executeAndWait(ctx context.Context, qr QueuedRun, runLogger *za
 	sp, spCtx := opentracing.StartSpanFromContext(ctx, "task.run.execution")
 	defer sp.Finish()
 
-	rp, err := r.executor.Execute(spCtx, qr)
+	if qr.Try == 0 {
+		qr.Try = 1
+	}
+
+	var res RunResult
+	for {
+		var err error
+		res, err = r.execute(spCtx, qr)
+		if err == ErrRunCanceled {
+			r.cancelRun(qr, runLogger)
+			return
+		}
+		if err != nil {
+			runLogger.Info("Failed to execute run", zap.Error(err))
+			r.clearRunning(qr.RunID)
+			r.updateRunState(qr, RunFail, runLogger, err)
+			atomic.StoreUint32(r.state, runnerIdle)
+			return
+		}
 
-	if err != nil {
-		// TODO(mr): retry? and log error.
+		runErr := res.Err()
+		if runErr == nil || !res.IsRetryable() || int64(qr.Try) >= r.ts.maxTries {
+			break
+		}
+
+		backoff := r.ts.RetryBackoff(qr.Try)
+		runLogger.Info("Run attempt failed; retrying", zap.Uint32("try", qr.Try), zap.Duration("backoff", backoff), zap.Error(runErr))
+		r.addRunLog(qr, fmt.Sprintf("Attempt %d failed: %v; retrying in %s", qr.Try, runErr, backoff))
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			r.cancelRun(qr, runLogger)
+			return
+		case <-r.ctx.Done():
+			r.cancelRun(qr, runLogger)
+			return
+		}
+
+		try, err := r.desiredState.IncrementRunTry(r.ctx, qr.TaskID, qr.RunID)
+		if err != nil {
+			runLogger.Info("Failed to record run try", zap.Error(err))
+			try = qr.Try + 1
+		}
+		qr.Try = try
+	}
+	r.clearRunning(qr.RunID)
+	r.updateRunStatistics(qr, res.Statistics(), runLogger)
+
+	finish := r.desiredState.FinishRun
+	if res.Err() == nil {
+		finish = r.desiredState.SucceedRun
+	}
+	if err := finish(r.ctx, qr.TaskID, qr.RunID); err != nil {
+		runLogger.Info("Failed to finish run", zap.Error(err))
+		// TODO(mr): retry?
+		// Need to think about what it means if there was an error finishing a run.
 		atomic.StoreUint32(r.state, runnerIdle)
-		r.updateRunState(qr, RunFail, runLogger)
+		r.updateRunState(qr, RunFail, runLogger, err)
 		return
 	}
+	if err := res.Err(); err != nil {
+		r.updateRunState(qr, RunFail, runLogger, err)
+		runLogger.Info("Execution failed", zap.Uint32("try", qr.Try), zap.Error(err))
+	} else {
+		r.updateRunState(qr, RunSuccess, runLogger, nil)
+		runLogger.Info("Execution succeeded")
+	}
+
+	// Check again if there is a new run available, without returning to idle state.
+	r.startFromWorking(atomic.LoadInt64(r.ts.now))
+}
+
+// execute executes a single attempt of qr and waits for its result.
+// If ctx or the runner's context is canceled first, the attempt is canceled and ErrRunCanceled is returned.
+func (r *runner) execute(ctx context.Context, qr QueuedRun) (RunResult, error) {
+	rp, err := r.executor.Execute(ctx, qr)
+	if err != nil {
+		return nil, err
+	}
 
 	ready := make(chan struct{})
+	defer close(ready)
 	go func() {
 		// If the runner's context is canceled, cancel the RunPromise.
 		select {
 		case <-ctx.Done():
-			r.clearRunning(qr.RunID)
 			rp.Cancel()
 		// Canceled context.
 		case <-r.ctx.Done():
-			r.clearRunning(qr.RunID)
 			rp.Cancel()
 		// Wait finished.
 		case <-ready:
-			r.clearRunning(qr.RunID)
 		}
 	}()
 
-	// TODO(mr): handle res.IsRetryable().
-	_, err = rp.Wait()
-	close(ready)
-	if err != nil {
-		if err == ErrRunCanceled {
-			_ = r.desiredState.FinishRun(r.ctx, qr.TaskID, qr.RunID)
-			r.updateRunState(qr, RunCanceled, runLogger)
-
-			// Move on to the next execution, for a canceled run.
-			r.startFromWorking(atomic.LoadInt64(r.ts.now))
-			return
-		}
-
-		runLogger.Info("Failed to wait for execution result", zap.Error(err))
-		// TODO(mr): retry?
-		r.updateRunState(qr, RunFail, runLogger)
-		atomic.StoreUint32(r.state, runnerIdle)
-		return
-	}
+	return rp.Wait()
+}
 
-	if err := r.desiredState.FinishRun(r.ctx, qr.TaskID, qr.RunID); err != nil {
-		runLogger.Info("Failed to finish run", zap.Error(err))
-		// TODO(mr): retry?
-		// Need to think about what it means if there was an error finishing a run.
-		atomic.StoreUint32(r.state, runnerIdle)
-		r.updateRunState(qr, RunFail, runLogger)
-		return
-	}
-	r.updateRunState(qr, RunSuccess, runLogger)
-	runLogger.Info("Execution succeeded")
+// cancelRun finishes a canceled run and moves on to the next execution.
+func (r *runner) cancelRun(qr QueuedRun, runLogger *zap.Logger) {
+	r.clearRunning(qr.RunID)
+	_ = r.desiredState.FinishRun(r.ctx, qr.TaskID, qr.RunID)
+	r.updateRunState(qr, RunCanceled, runLogger, nil)
 
-	// Check again if there is a new run available, without returning to idle state.
+	// Move on to the next execution, for a canceled run.
 	r.startFromWorking(atomic.LoadInt64(r.ts.now))
 }
 
-func (r *runner) updateRunState(qr QueuedRun, s RunStatus, runLogger *zap.Logger) {
-	rlb := RunLogBase{
+func (r *runner) runLogBase(qr QueuedRun) RunLogBase {
+	return RunLogBase{
 		Task:            r.task,
 		RunID:           qr.RunID,
 		RunScheduledFor: qr.Now,
 		RequestedAt:     qr.RequestedAt,
 	}
+}
+
+func (r *runner) addRunLog(qr QueuedRun, log string) {
+	if err := r.logWriter.AddRunLog(r.ctx, r.runLogBase(qr), time.Now(), log); err != nil {
+		r.logger.Info("Error adding run log", zap.String("run_id", qr.RunID.String()), zap.Error(err))
+	}
+}
+
+// updateRunStatistics records how the run's query executed.
+func (r *runner) updateRunStatistics(qr QueuedRun, stats platform.RunStatistics, runLogger *zap.Logger) {
+	if err := r.logWriter.UpdateRunStatistics(r.ctx, r.runLogBase(qr), time.Now(), stats); err != nil {
+		runLogger.Info("Error updating run statistics", zap.Error(err))
+	}
+}
+
+// updateRunState records the run's new state. For a failed run, runErr is written to the run log.
+func (r *runner) updateRunState(qr QueuedRun, s RunStatus, runLogger *zap.Logger, runErr error) {
+	rlb := r.runLogBase(qr)
 
 	switch s {
 	case RunStarted:
@@ -711,7 +938,11 @@ func (r *runner) updateRunState(qr QueuedRun, s RunStatus, runLogger *zap.Logger
 		r.logWriter.AddRunLog(r.ctx, rlb, time.Now(), "Completed successfully")
 	case RunFail:
 		r.ts.metrics.FinishRun(r.task.ID.String(), false)
-		r.logWriter.AddRunLog(r.ctx, rlb, time.Now(), "Failed")
+		msg := "Failed"
+		if runErr != nil {
+			msg = fmt.Sprintf("Failed: %v", runErr)
+		}
+		r.logWriter.AddRunLog(r.ctx, rlb, time.Now(), msg)
 	case RunCanceled:
 		r.ts.metrics.FinishRun(r.task.ID.String(), false)
 		r.logWriter.AddRunLog(r.ctx, rlb, time.Now(), "Canceled")
@@ -302,6 +302,46 @@ func TestScheduler_UpdateTask(t *testing.T) {
 	p[0].Finish(mock.NewRunResult(nil, false), nil)
 }
 
+func TestScheduler_UpdateTaskWorksQueue(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	s := backend.NewScheduler(d, e, backend.NopLogWriter{}, 3059, backend.WithLogger(zaptest.NewLogger(t)))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	task := &backend.StoreTask{
+		ID: platform.ID(1),
+	}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		EffectiveCron:   "* * * * *", // Every minute.
+		LatestCompleted: 3000,
+	}
+
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	// A manual run queued by the update must start without waiting for the next tick.
+	meta.ManualRuns = []*backend.StoreTaskMetaManualRun{
+		{Start: 120, End: 120, LatestCompleted: 119, RequestedAt: 3059},
+	}
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.UpdateTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p[0].Run().Now != 120 {
+		t.Fatalf("expected the manual run at 120 to start, got %d", p[0].Run().Now)
+	}
+	p[0].Finish(mock.NewRunResult(nil, false), nil)
+}
+
 func TestScheduler_Queue(t *testing.T) {
 	d := mock.NewDesiredState()
 	e := mock.NewExecutor()
@@ -384,6 +424,266 @@ func TestScheduler_Queue(t *testing.T) {
 	}
 }
 
+func TestScheduler_Retry(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	rl := backend.NewInMemRunReaderWriter()
+	s := backend.NewScheduler(d, e, rl, 5, backend.WithLogger(zaptest.NewLogger(t)), backend.WithRetryBackoff(time.Millisecond, 2*time.Millisecond))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	task := &backend.StoreTask{
+		ID: platform.ID(1),
+		Script: `option task = {name: "a task", every: 1s, retry: 3}
+from(bucket: "b") |> range(start: -1h)`,
+	}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		EffectiveCron:   "@every 1s",
+		LatestCompleted: 5,
+	}
+
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	// pollForTry waits for the run to be executing the given try.
+	pollForTry := func(try uint32) *mock.RunPromise {
+		t.Helper()
+		for i := 0; i < 50; i++ {
+			for _, rp := range e.RunningFor(task.ID) {
+				if rp.Run().Try == try {
+					return rp
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("did not see try %d executing", try)
+		return nil
+	}
+
+	s.Tick(6)
+	rp := pollForTry(1)
+	runID := rp.Run().RunID
+
+	// Retryable failures are attempted again, up to the task's retry option.
+	rp.Finish(mock.NewRunResult(errors.New("transient failure 1"), true), nil)
+	rp = pollForTry(2)
+	if rp.Run().RunID != runID {
+		t.Fatalf("expected retry of run %s, got run %s", runID, rp.Run().RunID)
+	}
+	pollForRunStatus(t, rl, task.ID, 1, 0, backend.RunStarted.String())
+
+	rp.Finish(mock.NewRunResult(errors.New("transient failure 2"), true), nil)
+	rp = pollForTry(3)
+	rp.Finish(mock.NewRunResult(errors.New("transient failure 3"), true), nil)
+
+	pollForRunStatus(t, rl, task.ID, 1, 0, backend.RunFail.String())
+	if created := d.CreatedFor(task.ID); len(created) != 0 {
+		t.Fatalf("expected failed run to be finished, got %v", created)
+	}
+
+	logs, err := rl.ListLogs(context.Background(), platform.LogFilter{Task: &task.ID, Run: &runID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var all []string
+	for _, l := range logs {
+		all = append(all, string(l))
+	}
+	joined := strings.Join(all, "\n")
+	for _, exp := range []string{
+		"Attempt 1 failed: transient failure 1; retrying in 1ms",
+		"Attempt 2 failed: transient failure 2; retrying in 2ms",
+		"Failed: transient failure 3",
+	} {
+		if !strings.Contains(joined, exp) {
+			t.Errorf("expected run log to contain %q, got:\n%s", exp, joined)
+		}
+	}
+}
+
+func TestScheduler_DependsOn(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	s := backend.NewScheduler(d, e, backend.NopLogWriter{}, 5, backend.WithLogger(zaptest.NewLogger(t)))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	upstreamID := platform.ID(1)
+	d.SetTaskMeta(upstreamID, backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		EffectiveCron:   "@every 1s",
+		LatestCompleted: 5,
+		LatestSucceeded: 5,
+	})
+
+	task := &backend.StoreTask{
+		ID: platform.ID(2),
+		Script: `option task = {name: "downstream", every: 1s, dependsOn: ["0000000000000001"]}
+from(bucket: "b") |> range(start: -1h)`,
+	}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		EffectiveCron:   "@every 1s",
+		LatestCompleted: 5,
+	}
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	// The run for 6 is due, but the upstream task hasn't completed its run for 6.
+	s.Tick(6)
+	s.Tick(7)
+	if _, err := d.PollForNumberCreated(task.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// The upstream's run for 6 failed.
+	d.SetTaskMeta(upstreamID, backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		EffectiveCron:   "@every 1s",
+		LatestCompleted: 6,
+		LatestSucceeded: 5,
+	})
+	s.Tick(8)
+	if _, err := d.PollForNumberCreated(task.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	d.SetTaskMeta(upstreamID, backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		EffectiveCron:   "@every 1s",
+		LatestCompleted: 6,
+		LatestSucceeded: 6,
+	})
+	s.Tick(9)
+	created, err := d.PollForNumberCreated(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created[0].Now != 6 {
+		t.Fatalf("expected the run for 6 to be created, got run for %d", created[0].Now)
+	}
+
+	// The run for 7 waits for the upstream again.
+	rp, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rp[0].Finish(mock.NewRunResult(nil, false), nil)
+	s.Tick(10)
+	if _, err := d.PollForNumberCreated(task.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScheduler_DependsOnDeletedUpstream(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	rl := backend.NewInMemRunReaderWriter()
+	s := backend.NewScheduler(d, e, rl, 5, backend.WithLogger(zaptest.NewLogger(t)))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	task := &backend.StoreTask{
+		ID: platform.ID(2),
+		Script: `option task = {name: "downstream", every: 1s, dependsOn: ["0000000000000001"]}
+from(bucket: "b") |> range(start: -1h)`,
+	}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		EffectiveCron:   "@every 1s",
+		LatestCompleted: 5,
+	}
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	// The upstream task doesn't exist, so the run for 6 fails without executing.
+	s.Tick(6)
+	pollForRunStatus(t, rl, task.ID, 1, 0, backend.RunFail.String())
+	if _, err := e.PollForNumberRunning(task.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := rl.ListRuns(context.Background(), platform.RunFilter{Task: &task.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logs, err := rl.ListLogs(context.Background(), platform.LogFilter{Task: &task.ID, Run: &runs[0].ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, l := range logs {
+		if strings.Contains(string(l), "upstream task 0000000000000001 was deleted") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected the run log to report the deleted upstream task, got %q", logs)
+	}
+}
+
+func TestScheduler_NonRetryableFailure(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	rl := backend.NewInMemRunReaderWriter()
+	s := backend.NewScheduler(d, e, rl, 5, backend.WithLogger(zaptest.NewLogger(t)), backend.WithRetryBackoff(time.Millisecond, time.Millisecond))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	task := &backend.StoreTask{
+		ID: platform.ID(1),
+		Script: `option task = {name: "a task", every: 1s, retry: 3}
+from(bucket: "b") |> range(start: -1h)`,
+	}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		EffectiveCron:   "@every 1s",
+		LatestCompleted: 5,
+	}
+
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Tick(6)
+	promises, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runID := promises[0].Run().RunID
+	promises[0].Finish(mock.NewRunResult(errors.New("bad query"), false), nil)
+
+	pollForRunStatus(t, rl, task.ID, 1, 0, backend.RunFail.String())
+	if _, err := e.PollForNumberRunning(task.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	logs, err := rl.ListLogs(context.Background(), platform.LogFilter{Task: &task.ID, Run: &runID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, l := range logs {
+		if strings.Contains(string(l), "Failed: bad query") {
+			found = true
+		}
+		if strings.Contains(string(l), "retrying") {
+			t.Errorf("expected no retry of a non-retryable failure, got log %q", l)
+		}
+	}
+	if !found {
+		t.Errorf("expected failure reason in run logs, got %v", logs)
+	}
+}
+
 // pollForRunStatus tries a few times to find runs matching supplied conditions, before failing.
 func pollForRunStatus(t *testing.T, r backend.LogReader, taskID platform.ID, expCount, expIndex int, expStatus string) {
 	t.Helper()
@@ -467,13 +767,23 @@ func TestScheduler_RunLog(t *testing.T) {
 	}
 
 	// Finish with success.
-	promises[0].Finish(mock.NewRunResult(nil, false), nil)
+	stats := platform.RunStatistics{ExecuteDuration: time.Second, ValuesRead: 3}
+	promises[0].Finish(mock.NewRunResult(nil, false).WithStatistics(stats), nil)
 	if _, err := e.PollForNumberRunning(task.ID, 0); err != nil {
 		t.Fatal(err)
 	}
 
 	pollForRunStatus(t, rl, task.ID, 1, 0, backend.RunSuccess.String())
 
+	// Statistics are recorded before the final run state.
+	runs, err = rl.ListRuns(context.Background(), platform.RunFilter{Task: &task.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := runs[0].Statistics; got == nil || *got != stats {
+		t.Fatalf("expected run statistics %#v, got %#v", stats, got)
+	}
+
 	// Create a new run, but fail this time.
 	s.Tick(7)
 	promises, err = e.PollForNumberRunning(task.ID, 1)
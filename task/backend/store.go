@@ -172,6 +172,14 @@ type UpdateTaskRequest struct {
 	// The new desired task status.
 	// If empty, do not modify the existing status.
 	Status TaskStatus
+
+	// The new maximum number of concurrent runs of the task, overriding the concurrency option of its script.
+	// If zero, do not modify the existing maximum.
+	MaxConcurrency int32
+
+	// The user making the change, recorded as the author of a new script version.
+	// May be left invalid if the change is not made on behalf of a user.
+	User platform.ID
 }
 
 // UpdateTaskResult describes the result of modifying a single task.
@@ -223,6 +231,20 @@ type Store interface {
 	// FinishRun removes runID from the list of running tasks and if its `now` is later then last completed update it.
 	FinishRun(ctx context.Context, taskID, runID platform.ID) error
 
+	// SucceedRun is FinishRun for a run that succeeded. It also records the run as the task's latest succeeded run.
+	SucceedRun(ctx context.Context, taskID, runID platform.ID) error
+
+	// ListTaskVersions returns every recorded script version of the task, oldest first.
+	ListTaskVersions(ctx context.Context, taskID platform.ID) ([]StoreTaskVersion, error)
+
+	// FindTaskVersion returns the given script version of the task.
+	// If the task has no such version, the returned version is nil and ErrTaskVersionNotFound is returned.
+	FindTaskVersion(ctx context.Context, taskID platform.ID, version uint32) (*StoreTaskVersion, error)
+
+	// IncrementRunTry increments the try of the running run with runID, before the run is attempted again.
+	// It returns the run's new try.
+	IncrementRunTry(ctx context.Context, taskID, runID platform.ID) (uint32, error)
+
 	// ManuallyRunTimeRange enqueues a request to run the task with the given ID for all schedules no earlier than start and no later than end (Unix timestamps).
 	// requestedAt is the Unix timestamp when the request was initiated.
 	// ManuallyRunTimeRange must delegate to an underlying StoreTaskMeta's ManuallyRunTimeRange method.
@@ -260,6 +282,9 @@ type LogWriter interface {
 
 	// AddRunLog adds a log line to the run.
 	AddRunLog(ctx context.Context, base RunLogBase, when time.Time, log string) error
+
+	// UpdateRunStatistics records how the run's query executed.
+	UpdateRunStatistics(ctx context.Context, base RunLogBase, when time.Time, stats platform.RunStatistics) error
 }
 
 // NopLogWriter is a LogWriter that doesn't do anything when its methods are called.
@@ -274,6 +299,10 @@ func (NopLogWriter) AddRunLog(context.Context, RunLogBase, time.Time, string) er
 	return nil
 }
 
+func (NopLogWriter) UpdateRunStatistics(context.Context, RunLogBase, time.Time, platform.RunStatistics) error {
+	return nil
+}
+
 // LogReader reads log information and log data from a store.
 type LogReader interface {
 	// ListRuns returns a list of runs belonging to a task.
@@ -318,6 +347,10 @@ type TaskSearchParams struct {
 	// If zero, the implementation picks an appropriate default page size.
 	// Valid page sizes are implementation-dependent.
 	PageSize int
+
+	// Return only tasks for which Match returns true, given the ID and
+	// organization ID of the task. Applied before PageSize. May be nil.
+	Match func(id, org platform.ID) bool
 }
 
 // StoreTask is a stored representation of a Task.
@@ -332,6 +365,10 @@ type StoreTask struct {
 
 	// The script content of the task.
 	Script string
+
+	// The script version of the task; see StoreTaskVersion.
+	// Zero if the task was created before script versions were recorded.
+	Version uint32
 }
 
 // StoreTaskWithMeta is a single struct with a StoreTask and a StoreTaskMeta.
@@ -382,14 +419,19 @@ func (StoreValidation) CreateArgs(req CreateTaskRequest) (options.Options, error
 }
 
 // UpdateArgs validates the UpdateTaskRequest.
-// If the update only includes a new status (i.e. req.Script is empty), the returned options are zero.
-// If the update contains neither a new script nor a new status, or if the script is invalid, an error is returned.
+// If the update doesn't include a new script (i.e. req.Script is empty), the returned options are zero.
+// If the update contains neither a new script, a new status nor a new maximum concurrency,
+// or if the script or maximum concurrency is invalid, an error is returned.
 func (StoreValidation) UpdateArgs(req UpdateTaskRequest) (options.Options, error) {
 	var missing []string
 	var o options.Options
 
-	if req.Script == "" && req.Status == "" {
-		missing = append(missing, "script or status")
+	if req.MaxConcurrency < 0 || req.MaxConcurrency > options.MaxConcurrency {
+		return o, fmt.Errorf("max concurrency must be between 1 and %d", options.MaxConcurrency)
+	}
+
+	if req.Script == "" && req.Status == "" && req.MaxConcurrency == 0 {
+		missing = append(missing, "script, status or max concurrency")
 	} else {
 		if req.Script != "" {
 			var err error
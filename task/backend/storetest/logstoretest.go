@@ -44,6 +44,10 @@ func NewRunStoreTest(name string, crf CreateRunStoreFunc, drf DestroyRunStoreFun
 				t.Parallel()
 				listLogsTest(t, crf, drf)
 			})
+			t.Run("RunStatistics", func(t *testing.T) {
+				t.Parallel()
+				runStatisticsTest(t, crf, drf)
+			})
 		})
 	}
 }
@@ -55,15 +59,17 @@ func updateRunState(t *testing.T, crf CreateRunStoreFunc, drf DestroyRunStoreFun
 	now := time.Now().UTC()
 
 	task := &backend.StoreTask{
-		ID:  platformtesting.MustIDBase16("ab01ab01ab01ab01"),
-		Org: platformtesting.MustIDBase16("ab01ab01ab01ab05"),
+		ID:      platformtesting.MustIDBase16("ab01ab01ab01ab01"),
+		Org:     platformtesting.MustIDBase16("ab01ab01ab01ab05"),
+		Version: 2,
 	}
 	scheduledFor := now.Add(-3 * time.Second)
 	run := platform.Run{
-		ID:           platformtesting.MustIDBase16("2c20766972747573"),
-		TaskID:       task.ID,
-		Status:       "started",
-		ScheduledFor: scheduledFor.Format(time.RFC3339),
+		ID:            platformtesting.MustIDBase16("2c20766972747573"),
+		TaskID:        task.ID,
+		Status:        "started",
+		ScheduledFor:  scheduledFor.Format(time.RFC3339),
+		ScriptVersion: 2,
 	}
 	rlb := backend.RunLogBase{
 		Task:            task,
@@ -398,3 +404,71 @@ func listLogsTest(t *testing.T, crf CreateRunStoreFunc, drf DestroyRunStoreFunc)
 		t.Fatal("not all logs retrieved")
 	}
 }
+
+func runStatisticsTest(t *testing.T, crf CreateRunStoreFunc, drf DestroyRunStoreFunc) {
+	writer, reader := crf(t)
+	defer drf(t, writer, reader)
+
+	task := &backend.StoreTask{
+		ID:  platformtesting.MustIDBase16("ab01ab01ab01ab01"),
+		Org: platformtesting.MustIDBase16("ab01ab01ab01ab05"),
+	}
+	now := time.Now().UTC()
+	sf := now.Add(-3 * time.Second)
+	sa := now.Add(-2 * time.Second)
+	fa := now.Add(-1 * time.Second)
+
+	run := platform.Run{
+		ID:           platformtesting.MustIDBase16("2c20766972747573"),
+		TaskID:       task.ID,
+		Status:       "success",
+		ScheduledFor: sf.Format(time.RFC3339),
+		StartedAt:    sa.Format(time.RFC3339Nano),
+		FinishedAt:   fa.Format(time.RFC3339Nano),
+		Statistics: &platform.RunStatistics{
+			TotalDuration:   900 * time.Millisecond,
+			QueueDuration:   100 * time.Millisecond,
+			CompileDuration: 20 * time.Millisecond,
+			PlanDuration:    30 * time.Millisecond,
+			ExecuteDuration: 750 * time.Millisecond,
+			ValuesRead:      1000,
+			BytesRead:       8000,
+			PointsWritten:   10,
+		},
+	}
+	rlb := backend.RunLogBase{
+		Task:            task,
+		RunID:           run.ID,
+		RunScheduledFor: sf.Unix(),
+	}
+
+	ctx := pcontext.SetAuthorizer(context.Background(), new(platform.Authorization))
+	if err := writer.UpdateRunState(ctx, rlb, sa, backend.RunStarted); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.UpdateRunStatistics(ctx, rlb, fa, *run.Statistics); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.UpdateRunState(ctx, rlb, fa, backend.RunSuccess); err != nil {
+		t.Fatal(err)
+	}
+
+	returnedRun, err := reader.FindRunByID(ctx, task.Org, run.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(run, *returnedRun); diff != "" {
+		t.Fatalf("unexpected run found: -want/+got: %s", diff)
+	}
+
+	runs, err := reader.ListRuns(ctx, platform.RunFilter{Task: &task.ID, Org: &task.Org})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 {
+		t.Fatalf("expected 1 run, got %d", len(runs))
+	}
+	if diff := cmp.Diff(run.Statistics, runs[0].Statistics); diff != "" {
+		t.Fatalf("unexpected run statistics: -want/+got: %s", diff)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
@@ -32,7 +33,10 @@ func NewStoreTest(name string, cf CreateStoreFunc, df DestroyStoreFunc, funcName
 			"DeleteTask",
 			"CreateNextRun",
 			"FinishRun",
+			"IncrementRunTry",
 			"ManuallyRunTimeRange",
+			"TaskLease",
+			"TaskVersions",
 		}
 	}
 	availableFuncs := map[string]TestFunc{
@@ -45,6 +49,9 @@ func NewStoreTest(name string, cf CreateStoreFunc, df DestroyStoreFunc, funcName
 		"DeleteTask":           testStoreDelete,
 		"CreateNextRun":        testStoreCreateNextRun,
 		"FinishRun":            testStoreFinishRun,
+		"IncrementRunTry":      testStoreIncrementRunTry,
+		"TaskLease":            testStoreTaskLease,
+		"TaskVersions":         testStoreTaskVersions,
 		"ManuallyRunTimeRange": testStoreManuallyRunTimeRange,
 		"DeleteOrg":            testStoreDeleteOrg,
 		"DeleteUser":           testStoreDeleteUser,
@@ -422,6 +429,43 @@ from(bucket:"test") |> range(start:-1h)`
 		}
 	})
 
+	t.Run("match before page size", func(t *testing.T) {
+		s := create(t)
+		defer destroy(t, s)
+
+		userID := platform.ID(2)
+		var ids []platform.ID
+		for i := 0; i < 4; i++ {
+			// Alternate the organization of the tasks.
+			orgID := platform.ID(1 + i%2*10)
+			id, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: orgID, User: userID, Script: fmt.Sprintf(scriptFmt, i)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, id)
+		}
+
+		// Match the second and the last task, but only in the organization of the last.
+		match := func(id, org platform.ID) bool {
+			return (id == ids[1] || id == ids[3]) && org == platform.ID(11)
+		}
+		ts, err := s.ListTasks(context.Background(), backend.TaskSearchParams{User: userID, PageSize: 2, Match: match})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ts) != 2 || ts[0].Task.ID != ids[1] || ts[1].Task.ID != ids[3] {
+			t.Fatalf("expected tasks %v and %v, got %v", ids[1], ids[3], ts)
+		}
+
+		ts, err = s.ListTasks(context.Background(), backend.TaskSearchParams{Org: platform.ID(1), Match: match})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ts) != 0 {
+			t.Fatalf("expected no tasks, got %d", len(ts))
+		}
+	})
+
 	t.Run("invalid params", func(t *testing.T) {
 		s := create(t)
 		defer destroy(t, s)
@@ -947,6 +991,248 @@ from(bucket:"test") |> range(start:-1h)`
 	}
 }
 
+func testStoreIncrementRunTry(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "a task",
+		cron: "* * * * *",
+		retry: 3,
+	}
+
+from(bucket:"test") |> range(start:-1h)`
+	s := create(t)
+	defer destroy(t, s)
+
+	task, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rc, err := s.CreateNextRun(context.Background(), task, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rc.Created.Try != 1 {
+		t.Fatalf("expected created run to be on try 1, got %d", rc.Created.Try)
+	}
+
+	try, err := s.IncrementRunTry(context.Background(), task, rc.Created.RunID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if try != 2 {
+		t.Fatalf("expected try 2, got %d", try)
+	}
+
+	meta, err := s.FindTaskMetaByID(context.Background(), task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(meta.CurrentlyRunning) != 1 || meta.CurrentlyRunning[0].Try != 2 {
+		t.Fatalf("expected currently running run to be on try 2, got %v", meta.CurrentlyRunning)
+	}
+
+	if err := s.FinishRun(context.Background(), task, rc.Created.RunID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.IncrementRunTry(context.Background(), task, rc.Created.RunID); err == nil {
+		t.Fatal("expected failure when incrementing the try of a finished run")
+	}
+}
+
+func testStoreTaskLease(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "a task",
+		cron: "* * * * *",
+	}
+
+from(bucket:"test") |> range(start:-1h)`
+	s := create(t)
+	defer destroy(t, s)
+
+	ls, ok := s.(backend.LeaseStore)
+	if !ok {
+		t.Skip("store does not implement backend.LeaseStore")
+	}
+	ctx := context.Background()
+
+	task, err := s.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ls.FindTaskLease(ctx, task); err != backend.ErrTaskLeaseNotFound {
+		t.Fatalf("expected ErrTaskLeaseNotFound before leasing, got %v", err)
+	}
+	if err := ls.AcquireTaskLease(ctx, platform.ID(task+1), "a", 100, 160); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected ErrTaskNotFound when leasing unknown task, got %v", err)
+	}
+
+	if err := ls.AcquireTaskLease(ctx, task, "a", 100, 160); err != nil {
+		t.Fatal(err)
+	}
+	l, err := ls.FindTaskLease(ctx, task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.TaskID != task || l.Owner != "a" || l.Expires != 160 {
+		t.Fatalf("unexpected lease %#v", l)
+	}
+
+	// Another owner can't take an unexpired lease, but the owner can renew it.
+	if err := ls.AcquireTaskLease(ctx, task, "b", 159, 219); err != backend.ErrTaskLeased {
+		t.Fatalf("expected ErrTaskLeased, got %v", err)
+	}
+	if err := ls.AcquireTaskLease(ctx, task, "a", 150, 210); err != nil {
+		t.Fatal(err)
+	}
+	if err := ls.AcquireTaskLease(ctx, task, "b", 209, 269); err != backend.ErrTaskLeased {
+		t.Fatalf("expected ErrTaskLeased after renewal, got %v", err)
+	}
+
+	// Once expired, another owner takes over.
+	if err := ls.AcquireTaskLease(ctx, task, "b", 210, 270); err != nil {
+		t.Fatal(err)
+	}
+	if l, err := ls.FindTaskLease(ctx, task); err != nil || l.Owner != "b" {
+		t.Fatalf("expected lease owned by b, got %#v, %v", l, err)
+	}
+
+	if err := ls.ReleaseTaskLease(ctx, task, "a"); err != backend.ErrTaskLeased {
+		t.Fatalf("expected ErrTaskLeased when releasing another owner's lease, got %v", err)
+	}
+	if err := ls.ReleaseTaskLease(ctx, task, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ls.FindTaskLease(ctx, task); err != backend.ErrTaskLeaseNotFound {
+		t.Fatalf("expected ErrTaskLeaseNotFound after release, got %v", err)
+	}
+
+	// Deleting the task removes its lease.
+	if err := ls.AcquireTaskLease(ctx, task, "a", 300, 360); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.DeleteTask(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ls.FindTaskLease(ctx, task); err != backend.ErrTaskLeaseNotFound {
+		t.Fatalf("expected ErrTaskLeaseNotFound after deleting task, got %v", err)
+	}
+}
+
+func testStoreTaskVersions(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "a task",
+		every: 1m,
+	}
+
+from(bucket:"x") |> range(start:-1h)`
+
+	const script2 = `option task = {
+		name: "a task2",
+		every: 2m,
+	}
+
+from(bucket:"y") |> range(start:-1h)`
+
+	s := create(t)
+	defer destroy(t, s)
+	ctx := context.Background()
+
+	id, err := s.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	task, err := s.FindTaskByID(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Version != 1 {
+		t.Fatalf("expected new task at version 1, got %d", task.Version)
+	}
+
+	// Changing the script records a new version authored by the requesting user.
+	res, err := s.UpdateTask(ctx, backend.UpdateTaskRequest{ID: id, Script: script2, User: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.NewTask.Version != 2 {
+		t.Fatalf("expected updated task at version 2, got %d", res.NewTask.Version)
+	}
+
+	// Changing only the status, or setting the same script, does not.
+	if res, err = s.UpdateTask(ctx, backend.UpdateTaskRequest{ID: id, Status: backend.TaskInactive, User: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if res.NewTask.Version != 2 {
+		t.Fatalf("expected status update to keep version 2, got %d", res.NewTask.Version)
+	}
+	if res, err = s.UpdateTask(ctx, backend.UpdateTaskRequest{ID: id, Script: script2, User: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if res.NewTask.Version != 2 {
+		t.Fatalf("expected unchanged script to keep version 2, got %d", res.NewTask.Version)
+	}
+
+	tasks, err := s.ListTasks(ctx, backend.TaskSearchParams{Org: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 || tasks[0].Task.Version != 2 {
+		t.Fatalf("expected listed task at version 2, got %#v", tasks)
+	}
+
+	versions, err := s.ListTaskVersions(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %d", len(versions))
+	}
+	for i, exp := range []struct {
+		script string
+		name   string
+		every  time.Duration
+		author platform.ID
+	}{
+		{script: script, name: "a task", every: time.Minute, author: 2},
+		{script: script2, name: "a task2", every: 2 * time.Minute, author: 3},
+	} {
+		v := versions[i]
+		if v.TaskID != id || v.Version != uint32(i+1) {
+			t.Fatalf("unexpected version identity at index %d: %#v", i, v)
+		}
+		if v.Script != exp.script || v.Author != exp.author || v.CreatedAt == 0 {
+			t.Fatalf("unexpected version at index %d: %#v", i, v)
+		}
+		if v.Options.Name != exp.name || v.Options.Every != exp.every {
+			t.Fatalf("unexpected options at index %d: %#v", i, v.Options)
+		}
+	}
+
+	v, err := s.FindTaskVersion(ctx, id, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*v, versions[0]) {
+		t.Fatalf("expected version %#v, got %#v", versions[0], *v)
+	}
+	if _, err := s.FindTaskVersion(ctx, id, 3); err != backend.ErrTaskVersionNotFound {
+		t.Fatalf("expected ErrTaskVersionNotFound, got %v", err)
+	}
+	if _, err := s.ListTaskVersions(ctx, platform.ID(id+1)); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected ErrTaskNotFound for unknown task, got %v", err)
+	}
+
+	// Deleting the task removes its versions.
+	if _, err := s.DeleteTask(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ListTaskVersions(ctx, id); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected ErrTaskNotFound after deleting task, got %v", err)
+	}
+}
+
 func testStoreManuallyRunTimeRange(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
 	const script = `option task = {
 		name: "a task",
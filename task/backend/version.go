@@ -0,0 +1,46 @@
+package backend
+
+import (
+	"errors"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/options"
+)
+
+// ErrTaskVersionNotFound is returned when a task has no script version matching the requested version.
+var ErrTaskVersionNotFound = errors.New("task version not found")
+
+// StoreTaskVersion is an immutable record of a task's script.
+// A Store records a new version when a task is created and each time its script changes.
+type StoreTaskVersion struct {
+	TaskID platform.ID
+
+	// Version numbers start at 1 and increase by one for each change to the script.
+	Version uint32
+
+	// The script content of the task at this version.
+	Script string
+
+	// Options are the task options parsed from Script.
+	Options options.Options
+
+	// Author is the ID of the user who created or updated the task.
+	// It is invalid if the change was not made on behalf of a user.
+	Author platform.ID
+
+	// Unix timestamp of when the version was recorded.
+	CreatedAt int64
+}
+
+// NewStoreTaskVersion returns the StoreTaskVersion to record for a task whose script is now script.
+func NewStoreTaskVersion(taskID platform.ID, version uint32, script string, opts options.Options, author platform.ID) StoreTaskVersion {
+	return StoreTaskVersion{
+		TaskID:    taskID,
+		Version:   version,
+		Script:    script,
+		Options:   opts,
+		Author:    author,
+		CreatedAt: time.Now().Unix(),
+	}
+}
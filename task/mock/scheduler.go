@@ -175,6 +175,7 @@ type DesiredState struct {
 }
 
 var _ backend.DesiredState = (*DesiredState)(nil)
+var _ backend.TaskMetaReader = (*DesiredState)(nil)
 
 func NewDesiredState() *DesiredState {
 	return &DesiredState{
@@ -193,6 +194,18 @@ func (d *DesiredState) SetTaskMeta(taskID platform.ID, meta backend.StoreTaskMet
 	d.meta[taskID.String()] = meta
 }
 
+// FindTaskMetaByID returns the task meta set for the given task ID.
+func (d *DesiredState) FindTaskMetaByID(_ context.Context, taskID platform.ID) (*backend.StoreTaskMeta, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	meta, ok := d.meta[taskID.String()]
+	if !ok {
+		return nil, backend.ErrTaskNotFound
+	}
+	return &meta, nil
+}
+
 // CreateNextRun creates the next run for the given task.
 // Refer to the documentation for SetTaskPeriod to understand how the times are determined.
 func (d *DesiredState) CreateNextRun(_ context.Context, taskID platform.ID, now int64) (backend.RunCreation, error) {
@@ -225,13 +238,21 @@ func (d *DesiredState) CreateNextRun(_ context.Context, taskID platform.ID, now
 }
 
 func (d *DesiredState) FinishRun(_ context.Context, taskID, runID platform.ID) error {
+	return d.finishRun(taskID, runID, (*backend.StoreTaskMeta).FinishRun)
+}
+
+func (d *DesiredState) SucceedRun(_ context.Context, taskID, runID platform.ID) error {
+	return d.finishRun(taskID, runID, (*backend.StoreTaskMeta).SucceedRun)
+}
+
+func (d *DesiredState) finishRun(taskID, runID platform.ID, finish func(*backend.StoreTaskMeta, platform.ID) bool) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
 	tid := taskID.String()
 	rid := runID.String()
 	m := d.meta[tid]
-	if !m.FinishRun(runID) {
+	if !finish(&m, runID) {
 		var knownIDs []string
 		for _, r := range m.CurrentlyRunning {
 			knownIDs = append(knownIDs, platform.ID(r.RunID).String())
@@ -243,6 +264,20 @@ func (d *DesiredState) FinishRun(_ context.Context, taskID, runID platform.ID) e
 	return nil
 }
 
+func (d *DesiredState) IncrementRunTry(_ context.Context, taskID, runID platform.ID) (uint32, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	tid := taskID.String()
+	m := d.meta[tid]
+	try, ok := m.IncrementRunTry(runID)
+	if !ok {
+		return 0, fmt.Errorf("unknown run ID %s", runID.String())
+	}
+	d.meta[tid] = m
+	return try, nil
+}
+
 func (d *DesiredState) CreatedFor(taskID platform.ID) []backend.QueuedRun {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -424,6 +459,7 @@ func (p *RunPromise) Finish(r backend.RunResult, err error) {
 type RunResult struct {
 	err         error
 	isRetryable bool
+	stats       platform.RunStatistics
 }
 
 var _ backend.RunResult = (*RunResult)(nil)
@@ -439,3 +475,13 @@ func (rr *RunResult) Err() error {
 func (rr *RunResult) IsRetryable() bool {
 	return rr.isRetryable
 }
+
+// WithStatistics sets the statistics reported by rr and returns rr.
+func (rr *RunResult) WithStatistics(stats platform.RunStatistics) *RunResult {
+	rr.stats = stats
+	return rr
+}
+
+func (rr *RunResult) Statistics() platform.RunStatistics {
+	return rr.stats
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/platform"
 	cron "gopkg.in/robfig/cron.v2"
 )
 
@@ -25,8 +27,11 @@ func EnableScriptCacheForTest() {
 	optionCache = make(map[string]Options)
 }
 
-const maxConcurrency = 100
+// MaxConcurrency is the largest number of concurrent runs a task may have.
+const MaxConcurrency = 100
+
 const maxRetry = 10
+const maxDependsOn = 10
 
 // Options are the task-related options that can be specified in a Flux script.
 type Options struct {
@@ -45,6 +50,10 @@ type Options struct {
 	Concurrency int64
 
 	Retry int64
+
+	// DependsOn lists the IDs of upstream tasks.
+	// A run scheduled for a time is only started once every upstream task has completed its run for that time.
+	DependsOn []platform.ID
 }
 
 // FromScript extracts Options from a Flux script.
@@ -127,6 +136,30 @@ func FromScript(script string) (Options, error) {
 		opt.Retry = retryVal.Int()
 	}
 
+	if dependsOnVal, ok := optObject.Get("dependsOn"); ok {
+		if err := checkNature(dependsOnVal.PolyType().Nature(), semantic.Array); err != nil {
+			return opt, err
+		}
+		var err error
+		dependsOnVal.Array().Range(func(i int, v values.Value) {
+			if err != nil {
+				return
+			}
+			if err = checkNature(v.PolyType().Nature(), semantic.String); err != nil {
+				return
+			}
+			var id *platform.ID
+			if id, err = platform.IDFromString(v.Str()); err != nil {
+				err = fmt.Errorf("invalid task ID %q in dependsOn: %v", v.Str(), err)
+				return
+			}
+			opt.DependsOn = append(opt.DependsOn, *id)
+		})
+		if err != nil {
+			return opt, err
+		}
+	}
+
 	if err := opt.Validate(); err != nil {
 		return opt, err
 	}
@@ -172,8 +205,8 @@ func (o *Options) Validate() error {
 
 	if o.Concurrency < 1 {
 		errs = append(errs, "concurrency must be at least 1")
-	} else if o.Concurrency > maxConcurrency {
-		errs = append(errs, fmt.Sprintf("concurrency exceeded max of %d", maxConcurrency))
+	} else if o.Concurrency > MaxConcurrency {
+		errs = append(errs, fmt.Sprintf("concurrency exceeded max of %d", MaxConcurrency))
 	}
 
 	if o.Retry < 1 {
@@ -182,6 +215,17 @@ func (o *Options) Validate() error {
 		errs = append(errs, fmt.Sprintf("retry exceeded max of %d", maxRetry))
 	}
 
+	if len(o.DependsOn) > maxDependsOn {
+		errs = append(errs, fmt.Sprintf("dependsOn exceeded max of %d tasks", maxDependsOn))
+	}
+	seen := make(map[platform.ID]bool, len(o.DependsOn))
+	for _, id := range o.DependsOn {
+		if seen[id] {
+			errs = append(errs, fmt.Sprintf("dependsOn lists task %s more than once", id))
+		}
+		seen[id] = true
+	}
+
 	if len(errs) == 0 {
 		return nil
 	}
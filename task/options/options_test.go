@@ -3,10 +3,12 @@ package options_test
 import (
 	"fmt"
 	"math"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
 	_ "github.com/influxdata/platform/query/builtin"
 	"github.com/influxdata/platform/task/options"
 )
@@ -31,6 +33,13 @@ func scriptGenerator(opt options.Options, body string) string {
 	if opt.Retry != 0 {
 		taskData = fmt.Sprintf("%s  retry: %d,\n", taskData, opt.Retry)
 	}
+	if len(opt.DependsOn) > 0 {
+		ids := make([]string, len(opt.DependsOn))
+		for i, id := range opt.DependsOn {
+			ids[i] = fmt.Sprintf("%q", id.String())
+		}
+		taskData = fmt.Sprintf("%s  dependsOn: [%s],\n", taskData, strings.Join(ids, ", "))
+	}
 	if body == "" {
 		body = `from(bucket: "test")
     |> range(start:-1h)`
@@ -58,6 +67,10 @@ func TestFromScript(t *testing.T) {
 		{script: "option task = {\n  name: \"name\",\n  concurrency: 1,\n  every: 1,\n\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
 		{script: scriptGenerator(options.Options{Name: "name", Retry: 20, Every: time.Hour}, ""), shouldErr: true},
 		{script: "option task = {\n  name: \"name\",\n  retry: 0,\n  every: 1m0s,\n\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
+		{script: scriptGenerator(options.Options{Name: "name", Every: time.Hour, DependsOn: []platform.ID{1, 2}}, ""), exp: options.Options{Name: "name", Every: time.Hour, Concurrency: 1, Retry: 1, DependsOn: []platform.ID{1, 2}}},
+		{script: scriptGenerator(options.Options{Name: "name", Every: time.Hour, DependsOn: []platform.ID{1, 1}}, ""), shouldErr: true},
+		{script: "option task = {\n  name: \"name\",\n  every: 1h,\n  dependsOn: [\"not an id\"],\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
+		{script: "option task = {\n  name: \"name\",\n  every: 1h,\n  dependsOn: \"0000000000000001\",\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
 		{script: scriptGenerator(options.Options{Name: "name"}, ""), shouldErr: true},
 		{script: scriptGenerator(options.Options{}, ""), shouldErr: true},
 	} {
@@ -144,6 +157,12 @@ func TestValidate(t *testing.T) {
 	if err := bad.Validate(); err == nil {
 		t.Error("expected error for retry too large")
 	}
+
+	*bad = good
+	bad.DependsOn = []platform.ID{1, 1}
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for duplicate dependencies")
+	}
 }
 
 func TestEffectiveCronString(t *testing.T) {
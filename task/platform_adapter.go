@@ -3,11 +3,14 @@ package task
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/influxdata/platform"
+	pctx "github.com/influxdata/platform/context"
 	"github.com/influxdata/platform/task/backend"
 	"github.com/influxdata/platform/task/options"
+	cron "gopkg.in/robfig/cron.v2"
 )
 
 type RunController interface {
@@ -15,15 +18,36 @@ type RunController interface {
 	//TODO: add retry run to this.
 }
 
+// LabelledResourceFinder finds the resources that have a label of a name.
+type LabelledResourceFinder interface {
+	FindLabelledResources(ctx context.Context, rt platform.ResourceType, name string) (platform.LabelledResources, error)
+}
+
+// AdapterOption configures the task service returned by PlatformAdapter.
+type AdapterOption func(*pAdapter)
+
+// WithLabelledResourceFinder sets the finder used to filter tasks by label.
+// Without it, finding tasks by label fails.
+func WithLabelledResourceFinder(f LabelledResourceFinder) AdapterOption {
+	return func(p *pAdapter) {
+		p.lf = f
+	}
+}
+
 // PlatformAdapter wraps a task.Store into the platform.TaskService interface.
-func PlatformAdapter(s backend.Store, r backend.LogReader, rc RunController) platform.TaskService {
-	return pAdapter{s: s, r: r}
+func PlatformAdapter(s backend.Store, r backend.LogReader, rc RunController, opts ...AdapterOption) platform.TaskService {
+	p := pAdapter{s: s, r: r, rc: rc}
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return p
 }
 
 type pAdapter struct {
 	s  backend.Store
 	rc RunController
 	r  backend.LogReader
+	lf LabelledResourceFinder
 }
 
 var _ platform.TaskService = pAdapter{}
@@ -53,6 +77,20 @@ func (p pAdapter) FindTasks(ctx context.Context, filter platform.TaskFilter) ([]
 	if filter.After != nil {
 		params.After = *filter.After
 	}
+	if filter.Label != nil {
+		if p.lf == nil {
+			return nil, 0, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  "finding tasks by label is not supported",
+			}
+		}
+		labelled, err := p.lf.FindLabelledResources(ctx, platform.TaskResourceType, *filter.Label)
+		if err != nil {
+			return nil, 0, err
+		}
+		// Only the labels of the organization of a task apply to it.
+		params.Match = labelled.Has
+	}
 	ts, err := p.s.ListTasks(ctx, params)
 	if err != nil {
 		return nil, 0, err
@@ -75,6 +113,10 @@ func (p pAdapter) CreateTask(ctx context.Context, t *platform.Task) error {
 		return err
 	}
 
+	if err := backend.ValidateDependencies(ctx, p.s, platform.InvalidID(), t.Organization, opts.DependsOn); err != nil {
+		return err
+	}
+
 	// TODO(mr): decide whether we allow user to configure scheduleAfter. https://github.com/influxdata/platform/issues/595
 	scheduleAfter := time.Now().Unix()
 
@@ -97,22 +139,47 @@ func (p pAdapter) CreateTask(ctx context.Context, t *platform.Task) error {
 	t.ID = id
 	t.Every = opts.Every.String()
 	t.Cron = opts.Cron
+	t.Concurrency = opts.Concurrency
 
 	return nil
 }
 
 func (p pAdapter) UpdateTask(ctx context.Context, id platform.ID, upd platform.TaskUpdate) (*platform.Task, error) {
-	if upd.Flux == nil && upd.Status == nil {
+	if upd.Flux == nil && upd.Status == nil && upd.Concurrency == nil {
 		return nil, errors.New("cannot update task without content")
 	}
 
 	req := backend.UpdateTaskRequest{ID: id}
+	if auth, err := pctx.GetAuthorizer(ctx); err == nil {
+		req.User = auth.GetUserID()
+	}
 	if upd.Flux != nil {
 		req.Script = *upd.Flux
+
+		opts, err := options.FromScript(req.Script)
+		if err != nil {
+			return nil, err
+		}
+		t, err := p.s.FindTaskByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := backend.ValidateDependencies(ctx, p.s, id, t.Org, opts.DependsOn); err != nil {
+			return nil, err
+		}
 	}
 	if upd.Status != nil {
 		req.Status = backend.TaskStatus(*upd.Status)
 	}
+	if upd.Concurrency != nil {
+		if *upd.Concurrency < 1 || *upd.Concurrency > options.MaxConcurrency {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("concurrency must be between 1 and %d", options.MaxConcurrency),
+			}
+		}
+		req.MaxConcurrency = int32(*upd.Concurrency)
+	}
 	res, err := p.s.UpdateTask(ctx, req)
 	if err != nil {
 		return nil, err
@@ -132,6 +199,8 @@ func (p pAdapter) UpdateTask(ctx context.Context, id platform.ID, upd platform.T
 		Every:  opts.Every.String(),
 		Cron:   opts.Cron,
 		Offset: opts.Offset.String(),
+
+		Concurrency: int64(res.NewMeta.MaxConcurrency),
 	}
 
 	t, err := p.s.FindTaskByID(ctx, id)
@@ -160,16 +229,101 @@ func (p pAdapter) FindLogs(ctx context.Context, filter platform.LogFilter) ([]*p
 }
 
 func (p pAdapter) FindRuns(ctx context.Context, filter platform.RunFilter) ([]*platform.Run, int, error) {
-	runs, err := p.r.ListRuns(ctx, filter)
-	return runs, len(runs), err
+	runs, listErr := p.r.ListRuns(ctx, filter)
+	// A task whose runs are all pending has no runs in the log yet.
+	if filter.Task == nil || (listErr != nil && listErr != backend.ErrRunNotFound) {
+		return runs, len(runs), listErr
+	}
+
+	// Include the runs that are in progress or queued, which the log may not know about yet.
+	meta, err := p.s.FindTaskMetaByID(ctx, *filter.Task)
+	if err != nil {
+		return nil, 0, err
+	}
+	logged := make(map[platform.ID]*platform.Run, len(runs))
+	for _, r := range runs {
+		logged[r.ID] = r
+	}
+	for _, pr := range pendingRuns(*filter.Task, meta) {
+		if r, ok := logged[pr.ID]; ok && pr.ID.Valid() {
+			r.Try = pr.Try
+			continue
+		}
+		if filter.Limit > 0 && len(runs) >= filter.Limit {
+			break
+		}
+		if (filter.AfterTime != "" && pr.ScheduledFor < filter.AfterTime) || (filter.BeforeTime != "" && pr.ScheduledFor > filter.BeforeTime) {
+			continue
+		}
+		runs = append(runs, pr)
+	}
+	if len(runs) == 0 {
+		return nil, 0, listErr
+	}
+	return runs, len(runs), nil
 }
 
 func (p pAdapter) FindRunByID(ctx context.Context, taskID, id platform.ID) (*platform.Run, error) {
-	task, err := p.s.FindTaskByID(ctx, taskID)
+	task, meta, err := p.s.FindTaskByIDWithMeta(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending *platform.Run
+	for _, pr := range pendingRuns(taskID, meta) {
+		if pr.ID == id {
+			pending = pr
+			break
+		}
+	}
+
+	run, err := p.r.FindRunByID(ctx, task.Org, id)
+	if err == backend.ErrRunNotFound && pending != nil {
+		return pending, nil
+	}
 	if err != nil {
 		return nil, err
 	}
-	return p.r.FindRunByID(ctx, task.Org, id)
+	if pending != nil {
+		run.Try = pending.Try
+	}
+	return run, nil
+}
+
+// pendingRuns returns the runs of a task that are in progress, followed by its queued manual runs in queue order.
+func pendingRuns(taskID platform.ID, meta *backend.StoreTaskMeta) []*platform.Run {
+	runs := make([]*platform.Run, 0, len(meta.CurrentlyRunning)+len(meta.ManualRuns))
+	for _, cr := range meta.CurrentlyRunning {
+		r := &platform.Run{
+			ID:           platform.ID(cr.RunID),
+			TaskID:       taskID,
+			Status:       backend.RunStarted.String(),
+			ScheduledFor: time.Unix(cr.Now, 0).UTC().Format(time.RFC3339),
+			Try:          int(cr.Try),
+		}
+		if cr.RequestedAt != 0 {
+			r.RequestedAt = time.Unix(cr.RequestedAt, 0).UTC().Format(time.RFC3339)
+		}
+		runs = append(runs, r)
+	}
+
+	// A queued run covers a range of schedule times; report the earliest one not yet run.
+	sch, err := cron.Parse(meta.EffectiveCron)
+	for i, mr := range meta.ManualRuns {
+		scheduledFor := mr.Start
+		if err == nil {
+			scheduledFor = sch.Next(time.Unix(mr.LatestCompleted, 0)).Unix()
+		}
+		runs = append(runs, &platform.Run{
+			ID:            platform.ID(mr.RunID),
+			TaskID:        taskID,
+			Status:        backend.RunScheduled.String(),
+			ScheduledFor:  time.Unix(scheduledFor, 0).UTC().Format(time.RFC3339),
+			RequestedAt:   time.Unix(mr.RequestedAt, 0).UTC().Format(time.RFC3339),
+			QueuePosition: i + 1,
+		})
+	}
+	return runs
 }
 
 func (p pAdapter) RetryRun(ctx context.Context, taskID, id platform.ID) (*platform.Run, error) {
@@ -205,6 +359,52 @@ func (p pAdapter) RetryRun(ctx context.Context, taskID, id platform.ID) (*platfo
 	}, nil
 }
 
+func (p pAdapter) ForceRun(ctx context.Context, taskID platform.ID, scheduledFor int64) (*platform.Run, error) {
+	m, err := p.s.ManuallyRunTimeRange(ctx, taskID, scheduledFor, scheduledFor, time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	// Report the run as it is queued, with its position in the queue.
+	meta, err := p.s.FindTaskMetaByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range pendingRuns(taskID, meta) {
+		if r.ID == platform.ID(m.RunID) {
+			return r, nil
+		}
+	}
+	// The run may already have been picked up by the scheduler.
+	return p.FindRunByID(ctx, taskID, platform.ID(m.RunID))
+}
+
+func (p pAdapter) FindTaskVersions(ctx context.Context, taskID platform.ID) ([]*platform.TaskVersion, error) {
+	vs, err := p.s.ListTaskVersions(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	pvs := make([]*platform.TaskVersion, len(vs))
+	for i := range vs {
+		pvs[i] = toPlatformTaskVersion(vs[i])
+	}
+	return pvs, nil
+}
+
+func (p pAdapter) RollbackTask(ctx context.Context, taskID platform.ID, version int) (*platform.Task, error) {
+	if version <= 0 {
+		return nil, backend.ErrTaskVersionNotFound
+	}
+
+	v, err := p.s.FindTaskVersion(ctx, taskID, uint32(version))
+	if err != nil {
+		return nil, err
+	}
+
+	return p.UpdateTask(ctx, taskID, platform.TaskUpdate{Flux: &v.Script})
+}
+
 func (p pAdapter) CancelRun(ctx context.Context, taskID, runID platform.ID) error {
 	return p.rc.CancelRun(ctx, taskID, runID)
 }
@@ -235,6 +435,26 @@ func toPlatformTask(t backend.StoreTask, m *backend.StoreTaskMeta) (*platform.Ta
 	if m != nil {
 		pt.Status = string(m.Status)
 		pt.LatestCompleted = time.Unix(m.LatestCompleted, 0).Format(time.RFC3339)
+		pt.Concurrency = int64(m.MaxConcurrency)
 	}
 	return pt, nil
 }
+
+func toPlatformTaskVersion(v backend.StoreTaskVersion) *platform.TaskVersion {
+	pv := &platform.TaskVersion{
+		TaskID:    v.TaskID,
+		Version:   int(v.Version),
+		Flux:      v.Script,
+		Name:      v.Options.Name,
+		Cron:      v.Options.Cron,
+		AuthorID:  v.Author,
+		CreatedAt: time.Unix(v.CreatedAt, 0).UTC().Format(time.RFC3339),
+	}
+	if v.Options.Every != 0 {
+		pv.Every = v.Options.Every.String()
+	}
+	if v.Options.Offset != 0 {
+		pv.Offset = v.Options.Offset.String()
+	}
+	return pv
+}
@@ -76,6 +76,11 @@ func TestTaskService(t *testing.T, fn BackendComponentFactory) {
 			t.Parallel()
 			testMetaUpdate(t, sys)
 		})
+
+		t.Run("Task Versions", func(t *testing.T) {
+			t.Parallel()
+			testTaskVersions(t, sys)
+		})
 	})
 }
 
@@ -219,6 +224,31 @@ func testTaskCRUD(t *testing.T, sys *System) {
 		t.Fatalf("expected task status to be inactive, got %q", f.Status)
 	}
 
+	// Update task: concurrency only, overriding the script's option.
+	if f.Concurrency != 100 {
+		t.Fatalf("expected concurrency from the script's option, got %d", f.Concurrency)
+	}
+	newConcurrency := int64(3)
+	f, err = sys.ts.UpdateTask(sys.Ctx, origID, platform.TaskUpdate{Concurrency: &newConcurrency})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Flux != newFlux {
+		t.Fatalf("flux unexpected updated: %s", f.Flux)
+	}
+	if f.Concurrency != newConcurrency {
+		t.Fatalf("expected concurrency %d, got %d", newConcurrency, f.Concurrency)
+	}
+	if meta, err := sys.S.FindTaskMetaByID(sys.Ctx, origID); err != nil {
+		t.Fatal(err)
+	} else if meta.MaxConcurrency != int32(newConcurrency) {
+		t.Fatalf("expected max concurrency %d in task meta, got %d", newConcurrency, meta.MaxConcurrency)
+	}
+	badConcurrency := int64(0)
+	if _, err := sys.ts.UpdateTask(sys.Ctx, origID, platform.TaskUpdate{Concurrency: &badConcurrency}); err == nil {
+		t.Fatal("expected an error updating concurrency to 0")
+	}
+
 	// Delete task.
 	if err := sys.ts.DeleteTask(sys.Ctx, origID); err != nil {
 		t.Fatal(err)
@@ -274,6 +304,77 @@ func testMetaUpdate(t *testing.T, sys *System) {
 	}
 }
 
+func testTaskVersions(t *testing.T, sys *System) {
+	orgID, userID, _ := creds(t, sys)
+
+	origFlux := fmt.Sprintf(scriptFmt, 0)
+	task := &platform.Task{Organization: orgID, Owner: platform.User{ID: userID}, Flux: origFlux}
+	if err := sys.ts.CreateTask(sys.Ctx, task); err != nil {
+		t.Fatal(err)
+	}
+
+	// Update the script, then update only the status, which should not record a version.
+	newFlux := fmt.Sprintf(scriptFmt, 1)
+	if _, err := sys.ts.UpdateTask(sys.Ctx, task.ID, platform.TaskUpdate{Flux: &newFlux}); err != nil {
+		t.Fatal(err)
+	}
+	newStatus := string(backend.TaskInactive)
+	if _, err := sys.ts.UpdateTask(sys.Ctx, task.ID, platform.TaskUpdate{Status: &newStatus}); err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := sys.ts.FindTaskVersions(sys.Ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %d: %#v", len(versions), versions)
+	}
+	for i, want := range []string{origFlux, newFlux} {
+		v := versions[i]
+		if v.Version != i+1 {
+			t.Fatalf("versions[%d]: expected version %d, got %d", i, i+1, v.Version)
+		}
+		if v.Flux != want {
+			t.Fatalf("versions[%d]: wrong flux; want %q, got %q", i, want, v.Flux)
+		}
+		if v.TaskID != task.ID {
+			t.Fatalf("versions[%d]: wrong task ID; want %s, got %s", i, task.ID.String(), v.TaskID.String())
+		}
+	}
+	if versions[0].Name != "task #0" || versions[1].Name != "task #1" {
+		t.Fatalf("wrong names on versions: %q, %q", versions[0].Name, versions[1].Name)
+	}
+
+	// Roll back to the original script.
+	f, err := sys.ts.RollbackTask(sys.Ctx, task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Flux != origFlux {
+		t.Fatalf("wrong flux after rollback; want %q, got %q", origFlux, f.Flux)
+	}
+	if f.Status != newStatus {
+		t.Fatalf("rollback unexpectedly changed status to %q", f.Status)
+	}
+
+	versions, err = sys.ts.FindTaskVersions(sys.Ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("expected rollback to record a third version, got %d", len(versions))
+	}
+	if versions[2].Version != 3 || versions[2].Flux != origFlux {
+		t.Fatalf("unexpected version after rollback: %#v", versions[2])
+	}
+
+	// Rolling back to a version that does not exist should fail.
+	if _, err := sys.ts.RollbackTask(sys.Ctx, task.ID, 10); err == nil {
+		t.Fatal("expected error rolling back to missing version")
+	}
+}
+
 func testTaskRuns(t *testing.T, sys *System) {
 	orgID, userID, _ := creds(t, sys)
 
@@ -496,6 +597,63 @@ func testTaskRuns(t *testing.T, sys *System) {
 		}
 	})
 
+	t.Run("ForceRun and pending runs", func(t *testing.T) {
+		t.Parallel()
+
+		task := &platform.Task{Organization: orgID, Owner: platform.User{ID: userID}, Flux: fmt.Sprintf(scriptFmt, 0)}
+		if err := sys.ts.CreateTask(sys.Ctx, task); err != nil {
+			t.Fatal(err)
+		}
+
+		// A run in progress that the scheduler hasn't logged yet.
+		rc, err := sys.S.CreateNextRun(sys.Ctx, task.ID, time.Now().Add(5*time.Minute).UTC().Unix())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Queue two manual runs, on the task's every-minute schedule.
+		scheduledFor := time.Now().Add(-time.Hour).Truncate(time.Minute).UTC()
+		var forced []*platform.Run
+		for i := 0; i < 2; i++ {
+			sf := scheduledFor.Add(time.Duration(i) * time.Minute)
+			r, err := sys.ts.ForceRun(sys.Ctx, task.ID, sf.Unix())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.TaskID != task.ID || r.Status != backend.RunScheduled.String() || r.ScheduledFor != sf.Format(time.RFC3339) {
+				t.Fatalf("unexpected forced run %+v", r)
+			}
+			if r.QueuePosition != i+1 {
+				t.Fatalf("expected forced run at queue position %d, got %d", i+1, r.QueuePosition)
+			}
+			forced = append(forced, r)
+		}
+
+		runs, _, err := sys.ts.FindRuns(sys.Ctx, platform.RunFilter{Org: &orgID, Task: &task.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(runs) != 3 {
+			t.Fatalf("expected the running run and 2 queued runs, got %v", runs)
+		}
+		if runs[0].ID != rc.Created.RunID || runs[0].Try != 1 || runs[0].QueuePosition != 0 || runs[0].Status != backend.RunStarted.String() {
+			t.Fatalf("unexpected running run %+v", runs[0])
+		}
+		for i, r := range runs[1:] {
+			if r.ID != forced[i].ID || r.QueuePosition != i+1 || r.Status != backend.RunScheduled.String() {
+				t.Fatalf("unexpected queued run %+v", r)
+			}
+		}
+
+		found, err := sys.ts.FindRunByID(sys.Ctx, task.ID, forced[1].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(found, runs[2]); diff != "" {
+			t.Fatalf("difference between listed run and found run: %s", diff)
+		}
+	})
+
 	t.Run("FindLogs", func(t *testing.T) {
 		t.Parallel()
 
@@ -0,0 +1,300 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/platform"
+	pctx "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/task/backend"
+)
+
+var fluxStringEscaper = strings.NewReplacer(
+	"\\", `\\`,
+	"\"", `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+)
+
+// TemplateService is a platform.TaskTemplateService that also instantiates tasks from templates
+// through a platform.TaskService, and rolls changes to a template out to the tasks instantiated from it.
+type TemplateService struct {
+	platform.TaskTemplateService
+	TaskService   platform.TaskService
+	BucketService platform.BucketService
+}
+
+var _ platform.TaskTemplateService = (*TemplateService)(nil)
+var _ platform.TaskTemplateInstanceService = (*TemplateService)(nil)
+
+// NewTemplateService returns a TemplateService that stores templates in tts, creates and updates tasks in ts,
+// and looks up the buckets bound to bucket parameters in bs.
+func NewTemplateService(tts platform.TaskTemplateService, ts platform.TaskService, bs platform.BucketService) *TemplateService {
+	return &TemplateService{
+		TaskTemplateService: tts,
+		TaskService:         ts,
+		BucketService:       bs,
+	}
+}
+
+// RenderTemplate returns the Flux of the task instantiated from t with values,
+// which is t's Flux with a params record holding the value of every parameter
+// inserted after its package and import declarations.
+func RenderTemplate(t *platform.TaskTemplate, values map[string]string) (string, error) {
+	bound, err := t.Bind(values)
+	if err != nil {
+		return "", err
+	}
+	if len(bound) == 0 {
+		return t.Flux, nil
+	}
+
+	types := make(map[string]platform.TaskTemplateParamType, len(t.Params))
+	names := make([]string, 0, len(t.Params))
+	for _, p := range t.Params {
+		types[p.Name] = p.Type
+		names = append(names, p.Name)
+	}
+	sort.Strings(names)
+
+	off, err := declarationsEnd(t.Flux)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString(t.Flux[:off])
+	if off > 0 {
+		b.WriteString("\n\n")
+	}
+	b.WriteString("params = {")
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(name)
+		b.WriteString(": ")
+		if types[name] == platform.TaskTemplateDurationParam {
+			// Bind has already checked that the value is a duration literal.
+			b.WriteString(bound[name])
+		} else {
+			b.WriteString(`"`)
+			b.WriteString(fluxStringEscaper.Replace(bound[name]))
+			b.WriteString(`"`)
+		}
+	}
+	b.WriteString("}")
+	rest := t.Flux[off:]
+	if !strings.HasPrefix(rest, "\n") {
+		b.WriteString("\n\n")
+	}
+	b.WriteString(rest)
+	return b.String(), nil
+}
+
+// declarationsEnd returns the offset in flux just past its package clause and import declarations,
+// which must precede any other statement. It returns 0 if flux has neither.
+func declarationsEnd(flux string) (int, error) {
+	prog, err := parser.NewAST(flux)
+	if err != nil {
+		return 0, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "failed to parse template flux",
+			Err:  err,
+		}
+	}
+
+	var last *ast.SourceLocation
+	if prog.Package != nil {
+		last = prog.Package.Loc
+	}
+	if n := len(prog.Imports); n > 0 {
+		last = prog.Imports[n-1].Loc
+	}
+	if last == nil {
+		return 0, nil
+	}
+
+	// Positions are 1-based, and the end column is just past the declaration.
+	off := 0
+	for line := 1; line < last.End.Line; line++ {
+		i := strings.IndexByte(flux[off:], '\n')
+		if i < 0 {
+			return len(flux), nil
+		}
+		off += i + 1
+	}
+	if off += last.End.Column - 1; off > len(flux) {
+		off = len(flux)
+	}
+	return off, nil
+}
+
+// render returns the Flux of the task instantiated from t with values,
+// after checking that every bucket parameter names a bucket in t's organization.
+func (s *TemplateService) render(ctx context.Context, t *platform.TaskTemplate, values map[string]string) (string, error) {
+	bound, err := t.Bind(values)
+	if err != nil {
+		return "", err
+	}
+	for _, p := range t.Params {
+		if p.Type != platform.TaskTemplateBucketParam {
+			continue
+		}
+		name := bound[p.Name]
+		if _, err := s.BucketService.FindBucket(ctx, platform.BucketFilter{OrganizationID: &t.OrganizationID, Name: &name}); err != nil {
+			return "", &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("parameter %q: bucket %q not found", p.Name, name),
+				Err:  err,
+			}
+		}
+	}
+	return RenderTemplate(t, values)
+}
+
+// InstantiateTaskTemplate creates a task for each instance of inst, and links the tasks to the template.
+// Every instance is rendered before any task is created, so that an invalid instance creates no tasks.
+func (s *TemplateService) InstantiateTaskTemplate(ctx context.Context, id platform.ID, inst platform.TaskTemplateInstantiation) ([]*platform.Task, error) {
+	op := "task/instantiate task template"
+	if len(inst.Instances) == 0 {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Msg:  "at least one instance is required",
+		}
+	}
+
+	t, err := s.TaskTemplateService.FindTaskTemplateByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	scripts := make([]string, 0, len(inst.Instances))
+	for i, values := range inst.Instances {
+		script, err := s.render(ctx, t, values)
+		if err != nil {
+			return nil, &platform.Error{
+				Code: platform.ErrorCode(err),
+				Op:   op,
+				Msg:  fmt.Sprintf("instance %d", i),
+				Err:  err,
+			}
+		}
+		scripts = append(scripts, script)
+	}
+
+	var owner platform.ID
+	if auth, err := pctx.GetAuthorizer(ctx); err == nil {
+		owner = auth.GetUserID()
+	}
+
+	tasks := make([]*platform.Task, 0, len(scripts))
+	instances := append([]platform.TaskTemplateInstance{}, t.Instances...)
+	for i, script := range scripts {
+		task := &platform.Task{
+			Organization: t.OrganizationID,
+			Flux:         script,
+			Status:       inst.Status,
+		}
+		task.Owner.ID = owner
+		if err := s.TaskService.CreateTask(ctx, task); err != nil {
+			// Keep the links to the tasks that were created, so they can still be rolled out.
+			_ = s.linkInstances(ctx, id, instances)
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Op:   op,
+				Msg:  fmt.Sprintf("failed to create task for instance %d", i),
+				Err:  err,
+			}
+		}
+		tasks = append(tasks, task)
+		instances = append(instances, platform.TaskTemplateInstance{
+			TaskID: task.ID,
+			Params: inst.Instances[i],
+		})
+	}
+
+	if err := s.linkInstances(ctx, id, instances); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (s *TemplateService) linkInstances(ctx context.Context, id platform.ID, instances []platform.TaskTemplateInstance) error {
+	_, err := s.TaskTemplateService.UpdateTaskTemplate(ctx, id, platform.TaskTemplateUpdate{Instances: &instances})
+	return err
+}
+
+// RolloutTaskTemplate updates every task instantiated from the template to the template's current Flux.
+// Instances whose task has been deleted are unlinked from the template.
+func (s *TemplateService) RolloutTaskTemplate(ctx context.Context, id platform.ID) ([]*platform.Task, error) {
+	op := "task/rollout task template"
+	t, err := s.TaskTemplateService.FindTaskTemplateByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Render every instance first, so that a template change that doesn't fit an instance updates no tasks.
+	scripts := make([]string, len(t.Instances))
+	for i, inst := range t.Instances {
+		script, err := s.render(ctx, t, inst.Params)
+		if err != nil {
+			return nil, &platform.Error{
+				Code: platform.ErrorCode(err),
+				Op:   op,
+				Msg:  fmt.Sprintf("task %s", inst.TaskID),
+				Err:  err,
+			}
+		}
+		scripts[i] = script
+	}
+
+	tasks := make([]*platform.Task, 0, len(t.Instances))
+	instances := make([]platform.TaskTemplateInstance, 0, len(t.Instances))
+	for i, inst := range t.Instances {
+		task, err := s.TaskService.FindTaskByID(ctx, inst.TaskID)
+		if isTaskNotFound(task, err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, inst)
+
+		if task.Flux == scripts[i] {
+			tasks = append(tasks, task)
+			continue
+		}
+		task, err = s.TaskService.UpdateTask(ctx, inst.TaskID, platform.TaskUpdate{Flux: &scripts[i]})
+		if err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Op:   op,
+				Msg:  fmt.Sprintf("failed to update task %s", inst.TaskID),
+				Err:  err,
+			}
+		}
+		tasks = append(tasks, task)
+	}
+
+	if len(instances) != len(t.Instances) {
+		if err := s.linkInstances(ctx, id, instances); err != nil {
+			return nil, err
+		}
+	}
+	return tasks, nil
+}
+
+// isTaskNotFound reports whether a task lookup found no task.
+func isTaskNotFound(t *platform.Task, err error) bool {
+	if err == nil {
+		return t == nil
+	}
+	return err == backend.ErrTaskNotFound || platform.ErrorCode(err) == platform.ENotFound
+}
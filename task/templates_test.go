@@ -0,0 +1,204 @@
+package task_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/inmem"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/task"
+	"github.com/influxdata/platform/task/options"
+)
+
+const templateFlux = `option task = {name: "cpu " + params.host, every: params.every}
+
+from(bucket: params.bucket)
+	|> range(start: -params.every)
+	|> filter(fn: (r) => r.host == params.host)`
+
+func TestRenderTemplate(t *testing.T) {
+	tt := &platform.TaskTemplate{
+		OrganizationID: platform.ID(1),
+		Name:           "cpu",
+		Flux:           templateFlux,
+		Params: []platform.TaskTemplateParam{
+			{Name: "host", Type: platform.TaskTemplateStringParam},
+			{Name: "every", Type: platform.TaskTemplateDurationParam, Default: "1m"},
+			{Name: "bucket", Type: platform.TaskTemplateBucketParam, Default: "telegraf"},
+		},
+	}
+
+	script, err := task.RenderTemplate(tt, map[string]string{"host": `a "quoted" host`, "every": "5m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts, err := options.FromScript(script)
+	if err != nil {
+		t.Fatalf("rendered script is not a valid task: %v\n%s", err, script)
+	}
+	if opts.Name != `cpu a "quoted" host` || opts.Every != 5*time.Minute {
+		t.Fatalf("unexpected options %+v", opts)
+	}
+
+	for _, values := range []map[string]string{
+		{},
+		{"host": "a", "every": "five minutes"},
+		{"host": "a", "unknown": "b"},
+	} {
+		if _, err := task.RenderTemplate(tt, values); platform.ErrorCode(err) != platform.EInvalid {
+			t.Errorf("expected an invalid error for %v, got %v", values, err)
+		}
+	}
+}
+
+func TestRenderTemplate_Imports(t *testing.T) {
+	tt := &platform.TaskTemplate{
+		OrganizationID: platform.ID(1),
+		Name:           "cpu",
+		Flux: `package main
+// The imports must precede the params.
+import "strings"
+import s "strings"
+
+` + templateFlux,
+		Params: []platform.TaskTemplateParam{
+			{Name: "host", Type: platform.TaskTemplateStringParam},
+			{Name: "every", Type: platform.TaskTemplateDurationParam, Default: "1m"},
+			{Name: "bucket", Type: platform.TaskTemplateBucketParam, Default: "telegraf"},
+		},
+	}
+
+	script, err := task.RenderTemplate(tt, map[string]string{"host": "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog, err := parser.NewAST(script)
+	if err != nil {
+		t.Fatalf("rendered script does not parse: %v\n%s", err, script)
+	}
+	if prog.Package == nil || len(prog.Imports) != 2 {
+		t.Fatalf("expected the package clause and imports to be kept, got:\n%s", script)
+	}
+	if len(prog.Body) == 0 {
+		t.Fatalf("expected statements, got:\n%s", script)
+	}
+	if v, ok := prog.Body[0].(*ast.VariableAssignment); !ok || v.ID.Name != "params" {
+		t.Fatalf("expected the params record to be the first statement, got:\n%s", script)
+	}
+	if !strings.HasSuffix(script, templateFlux) {
+		t.Fatalf("expected the template's statements to follow the params, got:\n%s", script)
+	}
+}
+
+func TestTemplateService(t *testing.T) {
+	ctx := context.Background()
+
+	tasks := make(map[platform.ID]*platform.Task)
+	ts := &mock.TaskService{
+		CreateTaskFn: func(ctx context.Context, t *platform.Task) error {
+			t.ID = platform.ID(len(tasks) + 100)
+			tasks[t.ID] = t
+			return nil
+		},
+		FindTaskByIDFn: func(ctx context.Context, id platform.ID) (*platform.Task, error) {
+			return tasks[id], nil
+		},
+		UpdateTaskFn: func(ctx context.Context, id platform.ID, upd platform.TaskUpdate) (*platform.Task, error) {
+			t := tasks[id]
+			t.Flux = *upd.Flux
+			return t, nil
+		},
+	}
+
+	store := inmem.NewService()
+	org := &platform.Organization{Name: "org"}
+	if err := store.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+	orgID := org.ID
+	if err := store.CreateBucket(ctx, &platform.Bucket{OrganizationID: orgID, Name: "telegraf"}); err != nil {
+		t.Fatal(err)
+	}
+	s := task.NewTemplateService(store, ts, store)
+
+	tt := &platform.TaskTemplate{
+		OrganizationID: orgID,
+		Name:           "cpu",
+		Flux:           templateFlux,
+		Params: []platform.TaskTemplateParam{
+			{Name: "host", Type: platform.TaskTemplateStringParam},
+			{Name: "every", Type: platform.TaskTemplateDurationParam, Default: "1m"},
+			{Name: "bucket", Type: platform.TaskTemplateBucketParam, Default: "telegraf"},
+		},
+	}
+	if err := s.CreateTaskTemplate(ctx, tt); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("bucket must exist", func(t *testing.T) {
+		_, err := s.InstantiateTaskTemplate(ctx, tt.ID, platform.TaskTemplateInstantiation{
+			Instances: []map[string]string{{"host": "a"}, {"host": "b", "bucket": "missing"}},
+		})
+		if platform.ErrorCode(err) != platform.EInvalid {
+			t.Fatalf("expected an invalid error, got %v", err)
+		}
+		if len(tasks) != 0 {
+			t.Fatal("expected no task to be created")
+		}
+	})
+
+	created, err := s.InstantiateTaskTemplate(ctx, tt.ID, platform.TaskTemplateInstantiation{
+		Instances: []map[string]string{{"host": "a"}, {"host": "b", "every": "10m"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(created))
+	}
+
+	linked, err := s.FindTaskTemplates(ctx, platform.TaskTemplateFilter{TaskID: &created[1].ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(linked) != 1 || len(linked[0].Instances) != 2 {
+		t.Fatalf("expected the tasks to be linked to the template, got %+v", linked)
+	}
+
+	// Changing a default applies to the instances that don't set the parameter.
+	flux := strings.Replace(templateFlux, `"cpu "`, `"cpu on "`, 1)
+	params := append([]platform.TaskTemplateParam{}, tt.Params...)
+	params[1].Default = "2m"
+	if _, err := s.UpdateTaskTemplate(ctx, tt.ID, platform.TaskTemplateUpdate{Flux: &flux, Params: &params}); err != nil {
+		t.Fatal(err)
+	}
+	delete(tasks, created[0].ID)
+
+	rolled, err := s.RolloutTaskTemplate(ctx, tt.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rolled) != 1 || rolled[0].ID != created[1].ID {
+		t.Fatalf("expected only the remaining task to be rolled out, got %+v", rolled)
+	}
+	opts, err := options.FromScript(tasks[created[1].ID].Flux)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Name != "cpu on b" || opts.Every != 10*time.Minute {
+		t.Fatalf("unexpected options after rollout %+v", opts)
+	}
+
+	got, err := s.FindTaskTemplateByID(ctx, tt.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Instances) != 1 || got.Instances[0].TaskID != created[1].ID {
+		t.Fatalf("expected the deleted task to be unlinked, got %+v", got.Instances)
+	}
+}
@@ -0,0 +1,314 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/platform"
+	platcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/query"
+	"github.com/influxdata/platform/query/functions/outputs"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/options"
+	cron "gopkg.in/robfig/cron.v2"
+)
+
+// defaultScheduleCount is how many scheduled times ValidateTask returns when the request does not say.
+const defaultScheduleCount = 5
+
+// maxScheduleCount bounds the scheduled times ValidateTask returns.
+const maxScheduleCount = 100
+
+type validationService struct {
+	bs platform.BucketService
+	qs query.QueryService
+	// now returns the time that requests without a time are validated as of.
+	now func() time.Time
+}
+
+// NewValidationService returns a TaskValidationService that looks up the buckets a script accesses in bs,
+// and executes scripts with qs when execution is requested.
+func NewValidationService(bs platform.BucketService, qs query.QueryService) platform.TaskValidationService {
+	return &validationService{
+		bs:  bs,
+		qs:  qs,
+		now: time.Now,
+	}
+}
+
+func (s *validationService) ValidateTask(ctx context.Context, req platform.TaskValidationRequest) (*platform.TaskValidation, error) {
+	now := s.now().UTC()
+	if req.Now != "" {
+		t, err := time.Parse(time.RFC3339, req.Now)
+		if err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  "invalid now",
+				Err:  err,
+			}
+		}
+		now = t
+	}
+
+	count := req.ScheduleCount
+	if count == 0 {
+		count = defaultScheduleCount
+	}
+	if count < 0 || count > maxScheduleCount {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("scheduleCount must be between 1 and %d", maxScheduleCount),
+		}
+	}
+
+	opts, err := options.FromScript(req.Flux)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid task options",
+			Err:  err,
+		}
+	}
+
+	v := &platform.TaskValidation{
+		Name:        opts.Name,
+		Cron:        opts.Cron,
+		Concurrency: opts.Concurrency,
+		Retry:       opts.Retry,
+	}
+	if opts.Every != 0 {
+		v.Every = opts.Every.String()
+	}
+	if opts.Offset != 0 {
+		v.Offset = opts.Offset.String()
+	}
+
+	// Schedule from where the scheduler would start a task created now.
+	stm := backend.NewStoreTaskMeta(backend.CreateTaskRequest{ScheduleAfter: now.Unix()}, opts)
+	sch, err := cron.Parse(stm.EffectiveCron)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid task schedule",
+			Err:  err,
+		}
+	}
+	v.Schedule = make([]string, 0, count)
+	for t := time.Unix(stm.LatestCompleted, 0); len(v.Schedule) < count; {
+		t = sch.Next(t)
+		v.Schedule = append(v.Schedule, t.UTC().Format(time.RFC3339))
+	}
+
+	spec, err := flux.Compile(ctx, req.Flux, now)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid flux",
+			Err:  err,
+		}
+	}
+
+	if v.ReadBuckets, v.WriteBuckets, err = s.bucketsAccessed(ctx, req.Organization, spec); err != nil {
+		return nil, err
+	}
+
+	if v.Plan, err = physicalPlan(spec); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "failed to plan query",
+			Err:  err,
+		}
+	}
+
+	if req.Execute {
+		// Executing reads data, so it requires the same permission to read as running the task would.
+		for _, b := range v.ReadBuckets {
+			if !b.Permitted {
+				return nil, &platform.Error{
+					Code: platform.EForbidden,
+					Msg:  fmt.Sprintf("no read permission for bucket %q", b.Name),
+				}
+			}
+		}
+		if v.Results, err = s.execute(ctx, req.Organization, spec); err != nil {
+			return nil, err
+		}
+	}
+
+	return v, nil
+}
+
+// bucketsAccessed returns the buckets read and written by spec,
+// and whether the authorizer in ctx, if any, permits the access.
+func (s *validationService) bucketsAccessed(ctx context.Context, orgID platform.ID, spec *flux.Spec) (read, write []platform.TaskValidationBucket, err error) {
+	readFilters, writeFilters, err := query.BucketsAccessed(spec)
+	if err != nil {
+		return nil, nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "could not determine buckets accessed by query",
+			Err:  err,
+		}
+	}
+	// The from() of flux does not report the bucket it reads, so look for it directly.
+	err = spec.Walk(func(o *flux.Operation) error {
+		if from, ok := o.Spec.(*inputs.FromOpSpec); ok {
+			var f platform.BucketFilter
+			if from.BucketID != "" {
+				id, err := platform.IDFromString(from.BucketID)
+				if err != nil {
+					return err
+				}
+				f.ID = id
+			} else {
+				f.Name = &from.Bucket
+			}
+			readFilters = append(readFilters, f)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "could not determine buckets accessed by query",
+			Err:  err,
+		}
+	}
+
+	auth, _ := platcontext.GetAuthorizer(ctx)
+	find := func(filter platform.BucketFilter, perm func(platform.ID) platform.Permission) platform.TaskValidationBucket {
+		if filter.Organization != nil && *filter.Organization == "" {
+			filter.Organization = nil
+		}
+		if filter.ID == nil && filter.Organization == nil && filter.OrganizationID == nil {
+			filter.OrganizationID = &orgID
+		}
+
+		var vb platform.TaskValidationBucket
+		if filter.Name != nil {
+			vb.Name = *filter.Name
+		}
+		if filter.Organization != nil {
+			vb.Organization = *filter.Organization
+		}
+
+		b, err := s.bs.FindBucket(ctx, filter)
+		if err != nil {
+			vb.Error = err.Error()
+			return vb
+		}
+		vb.ID, vb.Name, vb.Organization = b.ID, b.Name, b.Organization
+
+		vb.Permitted = auth != nil && auth.Allowed(perm(b.ID))
+		return vb
+	}
+
+	read = make([]platform.TaskValidationBucket, 0, len(readFilters))
+	for _, f := range readFilters {
+		read = append(read, find(f, platform.ReadBucketPermission))
+	}
+	write = make([]platform.TaskValidationBucket, 0, len(writeFilters))
+	for _, f := range writeFilters {
+		write = append(write, find(f, platform.WriteBucketPermission))
+	}
+	return read, write, nil
+}
+
+// physicalPlan returns the nodes of the physical plan of spec.
+func physicalPlan(spec *flux.Spec) ([]platform.TaskValidationPlanNode, error) {
+	lp, err := plan.NewLogicalPlanner().Plan(spec)
+	if err != nil {
+		return nil, err
+	}
+	pp, err := plan.NewPhysicalPlanner().Plan(lp)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []platform.TaskValidationPlanNode
+	err = pp.TopDownWalk(func(pn plan.PlanNode) error {
+		n := platform.TaskValidationPlanNode{
+			ID:   string(pn.ID()),
+			Kind: string(pn.Kind()),
+		}
+		for _, pred := range pn.Predecessors() {
+			n.Predecessors = append(n.Predecessors, string(pred.ID()))
+		}
+		nodes = append(nodes, n)
+		return nil
+	})
+	return nodes, err
+}
+
+// withoutOutputs returns a copy of spec without its to() operations,
+// so that the tables they would write are returned as results instead.
+func withoutOutputs(spec *flux.Spec) *flux.Spec {
+	removed := make(map[flux.OperationID]bool)
+	out := &flux.Spec{Now: spec.Now, Resources: spec.Resources}
+	for _, op := range spec.Operations {
+		if op.Spec.Kind() == outputs.ToKind {
+			removed[op.ID] = true
+			continue
+		}
+		out.Operations = append(out.Operations, op)
+	}
+
+	// Connect the parents of each removed operation to its children.
+	parents := make(map[flux.OperationID][]flux.OperationID)
+	for _, e := range spec.Edges {
+		parents[e.Child] = append(parents[e.Child], e.Parent)
+	}
+	var sources func(id flux.OperationID) []flux.OperationID
+	sources = func(id flux.OperationID) []flux.OperationID {
+		if !removed[id] {
+			return []flux.OperationID{id}
+		}
+		var ids []flux.OperationID
+		for _, p := range parents[id] {
+			ids = append(ids, sources(p)...)
+		}
+		return ids
+	}
+	for _, e := range spec.Edges {
+		if removed[e.Child] {
+			continue
+		}
+		for _, p := range sources(e.Parent) {
+			out.Edges = append(out.Edges, flux.Edge{Parent: p, Child: e.Child})
+		}
+	}
+	return out
+}
+
+// execute runs spec without its outputs, returning its results as annotated CSV.
+func (s *validationService) execute(ctx context.Context, orgID platform.ID, spec *flux.Spec) (string, error) {
+	it, err := s.qs.Query(ctx, &query.Request{
+		OrganizationID: orgID,
+		Compiler:       lang.SpecCompiler{Spec: withoutOutputs(spec)},
+	})
+	if err != nil {
+		return "", &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "failed to execute task",
+			Err:  err,
+		}
+	}
+	defer it.Release()
+
+	var buf bytes.Buffer
+	enc := csv.NewMultiResultEncoder(csv.DefaultEncoderConfig())
+	if _, err := enc.Encode(&buf, it); err != nil {
+		return "", &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "failed to execute task",
+			Err:  err,
+		}
+	}
+	return buf.String(), nil
+}
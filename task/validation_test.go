@@ -0,0 +1,154 @@
+package task_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/query"
+	qmock "github.com/influxdata/platform/query/mock"
+	"github.com/influxdata/platform/task"
+)
+
+const validationScript = `option task = {name: "downsample", every: 1h, offset: 10m, retry: 3}
+
+from(bucket: "src")
+	|> range(start: -1h)
+	|> to(bucket: "dst", org: "myorg")`
+
+func TestValidationService(t *testing.T) {
+	orgID := platform.ID(1)
+	buckets := map[string]*platform.Bucket{
+		"src": {ID: 10, Name: "src", Organization: "myorg", OrganizationID: orgID},
+		"dst": {ID: 11, Name: "dst", Organization: "myorg", OrganizationID: orgID},
+	}
+	bs := mock.NewBucketService()
+	bs.FindBucketFn = func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+		if b, ok := buckets[*filter.Name]; ok {
+			return b, nil
+		}
+		return nil, &platform.Error{Code: platform.ENotFound, Msg: "bucket not found"}
+	}
+
+	var executed *flux.Spec
+	qs := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			executed = req.Compiler.(lang.SpecCompiler).Spec
+			res := &executetest.Result{
+				Nm: "_result",
+				Tbls: []*executetest.Table{{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{{execute.Time(0), 42.5}},
+				}},
+			}
+			return flux.NewSliceResultIterator([]flux.Result{res}), nil
+		},
+	}
+
+	svc := task.NewValidationService(bs, qs)
+	auth := &platform.Authorization{
+		Status:      platform.Active,
+		Permissions: []platform.Permission{platform.ReadBucketPermission(10)},
+	}
+	ctx := pcontext.SetAuthorizer(context.Background(), auth)
+
+	v, err := svc.ValidateTask(ctx, platform.TaskValidationRequest{
+		Organization:  orgID,
+		Flux:          validationScript,
+		Now:           "2018-11-01T10:30:00Z",
+		ScheduleCount: 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.Name != "downsample" || v.Every != "1h0m0s" || v.Offset != "10m0s" || v.Retry != 3 || v.Concurrency != 1 {
+		t.Fatalf("unexpected options %+v", v)
+	}
+	if diff := cmp.Diff(v.Schedule, []string{"2018-11-01T11:00:00Z", "2018-11-01T12:00:00Z", "2018-11-01T13:00:00Z"}); diff != "" {
+		t.Errorf("unexpected schedule -got/+want\n%s", diff)
+	}
+	if diff := cmp.Diff(v.ReadBuckets, []platform.TaskValidationBucket{{ID: 10, Name: "src", Organization: "myorg", Permitted: true}}); diff != "" {
+		t.Errorf("unexpected read buckets -got/+want\n%s", diff)
+	}
+	if diff := cmp.Diff(v.WriteBuckets, []platform.TaskValidationBucket{{ID: 11, Name: "dst", Organization: "myorg"}}); diff != "" {
+		t.Errorf("unexpected write buckets -got/+want\n%s", diff)
+	}
+	if len(v.Plan) == 0 {
+		t.Error("expected a query plan")
+	}
+	if executed != nil || v.Results != "" {
+		t.Error("expected the script not to be executed unless requested")
+	}
+
+	// Executing the script returns what it would write, without writing it.
+	v, err = svc.ValidateTask(ctx, platform.TaskValidationRequest{
+		Organization: orgID,
+		Flux:         validationScript,
+		Now:          "2018-11-01T10:30:00Z",
+		Execute:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if executed == nil {
+		t.Fatal("expected the script to be executed")
+	}
+	for _, op := range executed.Operations {
+		if op.Spec.Kind() == "to" {
+			t.Fatal("expected to() to be removed from the executed script")
+		}
+	}
+	if len(executed.Edges) != len(executed.Operations)-1 {
+		t.Fatalf("expected the remaining operations to stay connected, got %d edges for %d operations", len(executed.Edges), len(executed.Operations))
+	}
+	if !strings.Contains(v.Results, "42.5") {
+		t.Errorf("expected the results to be returned, got %q", v.Results)
+	}
+}
+
+func TestValidationService_ExecuteForbidden(t *testing.T) {
+	bs := mock.NewBucketService()
+	bs.FindBucketFn = func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+		return &platform.Bucket{ID: 10, Name: *filter.Name}, nil
+	}
+	qs := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			t.Fatal("expected the script not to be executed")
+			return nil, nil
+		},
+	}
+	svc := task.NewValidationService(bs, qs)
+	ctx := pcontext.SetAuthorizer(context.Background(), &platform.Authorization{Status: platform.Active})
+
+	_, err := svc.ValidateTask(ctx, platform.TaskValidationRequest{Organization: 1, Flux: validationScript, Execute: true})
+	if platform.ErrorCode(err) != platform.EForbidden {
+		t.Fatalf("expected executing without read permission to be forbidden, got %v", err)
+	}
+}
+
+func TestValidationService_Invalid(t *testing.T) {
+	svc := task.NewValidationService(mock.NewBucketService(), &qmock.QueryService{})
+
+	for _, req := range []platform.TaskValidationRequest{
+		{Flux: `from(bucket: "src") |> range(start: -1h)`},
+		{Flux: `option task = {name: "a", every: 1h, cron: "* * * * *"} from(bucket: "src")`},
+		{Flux: validationScript, Now: "yesterday"},
+		{Flux: validationScript, ScheduleCount: 1000},
+	} {
+		if _, err := svc.ValidateTask(context.Background(), req); platform.ErrorCode(err) != platform.EInvalid {
+			t.Errorf("expected request %+v to be invalid, got %v", req, err)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// TaskTemplateParamType is the kind of value a task template parameter takes.
+type TaskTemplateParamType string
+
+const (
+	// TaskTemplateStringParam is bound as a Flux string.
+	TaskTemplateStringParam TaskTemplateParamType = "string"
+	// TaskTemplateDurationParam is bound as a Flux duration, such as 1h or 5m30s.
+	TaskTemplateDurationParam TaskTemplateParamType = "duration"
+	// TaskTemplateBucketParam is bound as a Flux string holding the name of a bucket
+	// in the template's organization.
+	TaskTemplateBucketParam TaskTemplateParamType = "bucket"
+)
+
+var (
+	taskTemplateParamNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	fluxDurationPattern          = regexp.MustCompile(`^([0-9]+(y|mo|w|d|h|m|s|ms|us|µs|ns))+$`)
+)
+
+// TaskTemplateParam is a parameter of a task template.
+// A task template's Flux refers to the value of each parameter as params.<name>.
+type TaskTemplateParam struct {
+	Name        string                `json:"name"`
+	Type        TaskTemplateParamType `json:"type"`
+	Description string                `json:"description,omitempty"`
+	// Default is the value of the parameter when an instance does not set it.
+	// A parameter without a default must be set by every instance.
+	Default string `json:"default,omitempty"`
+}
+
+// Valid returns an error if value can't be bound to the parameter.
+func (p TaskTemplateParam) Valid(value string) error {
+	switch p.Type {
+	case TaskTemplateStringParam:
+	case TaskTemplateBucketParam:
+		if value == "" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("parameter %q requires a bucket name", p.Name),
+			}
+		}
+	case TaskTemplateDurationParam:
+		if !fluxDurationPattern.MatchString(value) {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("parameter %q requires a duration, got %q", p.Name, value),
+			}
+		}
+	default:
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("parameter %q must be of type string, duration or bucket", p.Name),
+		}
+	}
+	return nil
+}
+
+// TaskTemplateInstance links a task to the template it was instantiated from.
+type TaskTemplateInstance struct {
+	TaskID ID `json:"taskID"`
+	// Params are the values the task was instantiated with, without defaults,
+	// so that a change to a default applies to the task when the template is rolled out.
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// TaskTemplate is the Flux of a task, parameterized so that many tasks can be instantiated from it.
+type TaskTemplate struct {
+	ID             ID                  `json:"id,omitempty"`
+	OrganizationID ID                  `json:"orgID"`
+	Name           string              `json:"name"`
+	Description    string              `json:"description,omitempty"`
+	Flux           string              `json:"flux"`
+	Params         []TaskTemplateParam `json:"params,omitempty"`
+
+	// Instances are the tasks instantiated from the template.
+	Instances []TaskTemplateInstance `json:"instances,omitempty"`
+}
+
+// Valid returns an error if tasks can't be instantiated from the template.
+func (t *TaskTemplate) Valid() error {
+	if !t.OrganizationID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "task template requires an organization",
+		}
+	}
+	if t.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "task template name is required",
+		}
+	}
+	if t.Flux == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "task template flux is required",
+		}
+	}
+
+	names := make(map[string]bool, len(t.Params))
+	for _, p := range t.Params {
+		if !taskTemplateParamNamePattern.MatchString(p.Name) {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("invalid parameter name %q", p.Name),
+			}
+		}
+		if names[p.Name] {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("parameter %q is declared more than once", p.Name),
+			}
+		}
+		names[p.Name] = true
+
+		switch p.Type {
+		case TaskTemplateStringParam, TaskTemplateDurationParam, TaskTemplateBucketParam:
+		default:
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("parameter %q must be of type string, duration or bucket", p.Name),
+			}
+		}
+		if p.Default != "" {
+			if err := p.Valid(p.Default); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Bind returns the value of every parameter of the template for an instance that sets values,
+// using defaults for the parameters it does not set.
+func (t *TaskTemplate) Bind(values map[string]string) (map[string]string, error) {
+	bound := make(map[string]string, len(t.Params))
+	for _, p := range t.Params {
+		v, ok := values[p.Name]
+		if !ok {
+			if p.Default == "" {
+				return nil, &Error{
+					Code: EInvalid,
+					Msg:  fmt.Sprintf("parameter %q is required", p.Name),
+				}
+			}
+			v = p.Default
+		}
+		if err := p.Valid(v); err != nil {
+			return nil, err
+		}
+		bound[p.Name] = v
+	}
+	for name := range values {
+		if _, ok := bound[name]; !ok {
+			return nil, &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("task template has no parameter %q", name),
+			}
+		}
+	}
+	return bound, nil
+}
+
+// TaskTemplateUpdate is a set of changes to a task template. Nil fields are left unchanged.
+type TaskTemplateUpdate struct {
+	Name        *string              `json:"name,omitempty"`
+	Description *string              `json:"description,omitempty"`
+	Flux        *string              `json:"flux,omitempty"`
+	Params      *[]TaskTemplateParam `json:"params,omitempty"`
+
+	// Instances replaces the links to the template's tasks.
+	// It is set by the service that instantiates tasks, so it is never decoded from a request.
+	Instances *[]TaskTemplateInstance `json:"-"`
+}
+
+// Apply applies the non-nil fields of the update to t.
+func (u TaskTemplateUpdate) Apply(t *TaskTemplate) {
+	if u.Name != nil {
+		t.Name = *u.Name
+	}
+	if u.Description != nil {
+		t.Description = *u.Description
+	}
+	if u.Flux != nil {
+		t.Flux = *u.Flux
+	}
+	if u.Params != nil {
+		t.Params = *u.Params
+	}
+	if u.Instances != nil {
+		t.Instances = *u.Instances
+	}
+}
+
+// TaskTemplateFilter represents a set of filters that restrict the returned task templates.
+type TaskTemplateFilter struct {
+	OrganizationID *ID
+	// TaskID restricts the templates to the one the task was instantiated from.
+	TaskID *ID
+}
+
+// TaskTemplateService manages task templates.
+type TaskTemplateService interface {
+	// FindTaskTemplateByID returns a single task template by ID.
+	FindTaskTemplateByID(ctx context.Context, id ID) (*TaskTemplate, error)
+
+	// FindTaskTemplates returns the task templates that match filter.
+	FindTaskTemplates(ctx context.Context, filter TaskTemplateFilter) ([]*TaskTemplate, error)
+
+	// CreateTaskTemplate creates a new task template and sets t.ID with the new identifier.
+	CreateTaskTemplate(ctx context.Context, t *TaskTemplate) error
+
+	// UpdateTaskTemplate updates a single task template with a changeset and returns the updated template.
+	UpdateTaskTemplate(ctx context.Context, id ID, upd TaskTemplateUpdate) (*TaskTemplate, error)
+
+	// DeleteTaskTemplate removes a task template by ID.
+	// The tasks instantiated from the template are left as they are.
+	DeleteTaskTemplate(ctx context.Context, id ID) error
+}
+
+// TaskTemplateInstantiation requests tasks to be instantiated from a task template.
+type TaskTemplateInstantiation struct {
+	// Status is the status of the new tasks. It defaults to the default status of tasks.
+	Status string `json:"status,omitempty"`
+	// Instances holds the parameter values of each task to instantiate.
+	Instances []map[string]string `json:"instances"`
+}
+
+// TaskTemplateInstanceService instantiates tasks from task templates.
+type TaskTemplateInstanceService interface {
+	// InstantiateTaskTemplate creates a task for each instance of inst, and links the tasks to the template.
+	InstantiateTaskTemplate(ctx context.Context, id ID, inst TaskTemplateInstantiation) ([]*Task, error)
+
+	// RolloutTaskTemplate updates every task instantiated from the template to the template's current Flux.
+	RolloutTaskTemplate(ctx context.Context, id ID) ([]*Task, error)
+}
@@ -1,15 +1,23 @@
 package platform
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/BurntSushi/toml"
 	"github.com/influxdata/platform/telegraf/plugins"
+	"github.com/influxdata/platform/telegraf/plugins/aggregators"
 	"github.com/influxdata/platform/telegraf/plugins/inputs"
 	"github.com/influxdata/platform/telegraf/plugins/outputs"
+	"github.com/influxdata/platform/telegraf/plugins/processors"
 )
 
 // TelegrafConfigStore represents a service for managing telegraf config data.
@@ -26,7 +34,7 @@ type TelegrafConfigStore interface {
 
 	// FindTelegrafConfigs returns a list of telegraf configs that match filter and the total count of matching telegraf configs.
 	// Additional options provide pagination & sorting.
-	FindTelegrafConfigs(ctx context.Context, filter UserResourceMappingFilter, opt ...FindOptions) ([]*TelegrafConfig, int, error)
+	FindTelegrafConfigs(ctx context.Context, filter TelegrafConfigFilter, opt ...FindOptions) ([]*TelegrafConfig, int, error)
 
 	// CreateTelegrafConfig creates a new telegraf config and sets b.ID with the new identifier.
 	CreateTelegrafConfig(ctx context.Context, tc *TelegrafConfig, userID ID, now time.Time) error
@@ -39,6 +47,13 @@ type TelegrafConfigStore interface {
 	DeleteTelegrafConfig(ctx context.Context, id ID) error
 }
 
+// TelegrafConfigFilter represents a set of filters that restrict the returned telegraf configs.
+type TelegrafConfigFilter struct {
+	UserResourceMappingFilter
+	// Label restricts the telegraf configs to those with a label of this name.
+	Label *string
+}
+
 // TelegrafConfig stores telegraf config for one telegraf instance.
 type TelegrafConfig struct {
 	ID        ID
@@ -49,6 +64,13 @@ type TelegrafConfig struct {
 
 	Agent   TelegrafAgentConfig
 	Plugins []TelegrafPlugin
+	// GlobalTags are the tags added to every metric, kept as they were
+	// parsed from the [global_tags] table.
+	GlobalTags plugins.Table
+
+	// AgentAuthorizationID is the authorization created for the agents
+	// running the config, if any.
+	AgentAuthorizationID ID
 }
 
 // TOML returns the telegraf toml config string.
@@ -58,38 +80,51 @@ func (tc TelegrafConfig) TOML() string {
 		plugins += p.Config.TOML()
 	}
 	interval := time.Duration(tc.Agent.Interval * 1000000)
-	return fmt.Sprintf(`# Configuration for telegraf agent
+	flushInterval := 10 * time.Second
+	if tc.Agent.FlushInterval > 0 {
+		flushInterval = time.Duration(tc.Agent.FlushInterval * 1000000)
+	}
+	metricBatchSize := 1000
+	if tc.Agent.MetricBatchSize > 0 {
+		metricBatchSize = tc.Agent.MetricBatchSize
+	}
+	globalTags := ""
+	if len(tc.GlobalTags) > 0 {
+		globalTags = encodeTOMLTable("global_tags", tc.GlobalTags) + "\n"
+	}
+	agent := tc.Agent
+	return fmt.Sprintf(`%s# Configuration for telegraf agent
 [agent]
   ## Default data collection interval for all inputs
   interval = "%s"
   ## Rounds collection interval to 'interval'
   ## ie, if interval="10s" then always collect on :00, :10, :20, etc.
-  round_interval = true
+  round_interval = %s
 
   ## Telegraf will send metrics to outputs in batches of at most
   ## metric_batch_size metrics.
   ## This controls the size of writes that Telegraf sends to output plugins.
-  metric_batch_size = 1000
+  metric_batch_size = %d
 
   ## For failed writes, telegraf will cache metric_buffer_limit metrics for each
   ## output, and will flush this buffer on a successful write. Oldest metrics
   ## are dropped first when this buffer fills.
   ## This buffer only fills when writes fail to output plugin(s).
-  metric_buffer_limit = 10000
+  metric_buffer_limit = %s
 
   ## Collection jitter is used to jitter the collection by a random amount.
   ## Each plugin will sleep for a random time within jitter before collecting.
   ## This can be used to avoid many plugins querying things like sysfs at the
   ## same time, which can have a measurable effect on the system.
-  collection_jitter = "0s"
+  collection_jitter = %s
 
   ## Default flushing interval for all outputs. Maximum flush_interval will be
   ## flush_interval + flush_jitter
-  flush_interval = "10s"
+  flush_interval = "%s"
   ## Jitter the flush interval by a random amount. This is primarily to avoid
   ## large write spikes for users running a large number of telegraf instances.
   ## ie, a jitter of 5s and interval 10s means flushes will happen every 10-15s
-  flush_jitter = "0s"
+  flush_jitter = %s
 
   ## By default or when set to "0s", precision will be set to the same
   ## timestamp order as the collection interval, with the maximum being 1s.
@@ -98,21 +133,110 @@ func (tc TelegrafConfig) TOML() string {
   ## Precision will NOT be used for service inputs. It is up to each individual
   ## service input to set the timestamp at the appropriate precision.
   ## Valid time units are "ns", "us" (or "µs"), "ms", "s".
-  precision = ""
+  precision = "%s"
 
   ## Logging configuration:
   ## Run telegraf with debug log messages.
-  debug = false
+  debug = %s
   ## Run telegraf in quiet mode (error log messages only).
-  quiet = false
+  quiet = %s
   ## Specify the log file name. The empty string means to log to stderr.
-  logfile = ""
+  logfile = %s
 
   ## Override default hostname, if empty use os.Hostname()
-  hostname = ""
+  hostname = %s
   ## If set to true, do no set the "host" tag in the telegraf agent.
-  omit_hostname = false
-%s`, interval.String(), plugins)
+  omit_hostname = %s
+%s%s`,
+		globalTags,
+		interval.String(),
+		agent.setting("round_interval"),
+		metricBatchSize,
+		agent.setting("metric_buffer_limit"),
+		agent.setting("collection_jitter"),
+		flushInterval.String(),
+		agent.setting("flush_jitter"),
+		tc.Agent.Precision,
+		agent.setting("debug"),
+		agent.setting("quiet"),
+		agent.setting("logfile"),
+		agent.setting("hostname"),
+		agent.setting("omit_hostname"),
+		agent.otherSettings(),
+		plugins)
+}
+
+// telegrafAgentDefaults are the values TelegrafConfig.TOML writes for the
+// agent settings of its template that are not set. They are telegraf's own
+// defaults, so settings with these values are not kept when decoding.
+var telegrafAgentDefaults = plugins.Table{
+	"round_interval":      true,
+	"metric_buffer_limit": int64(10000),
+	"collection_jitter":   "0s",
+	"flush_jitter":        "0s",
+	"debug":               false,
+	"quiet":               false,
+	"logfile":             "",
+	"hostname":            "",
+	"omit_hostname":       false,
+}
+
+// isTelegrafAgentField reports whether the agent setting key is decoded into
+// a field of TelegrafAgentConfig rather than kept in its Settings.
+func isTelegrafAgentField(key string) bool {
+	switch key {
+	case "interval", "flush_interval", "metric_batch_size", "precision":
+		return true
+	}
+	return false
+}
+
+// setting returns the toml value of the agent setting key of the template.
+func (a TelegrafAgentConfig) setting(key string) string {
+	if v, ok := a.Settings[key]; ok {
+		s := encodeTOMLTable("", plugins.Table{key: v})
+		// A table can't be the value of a setting of the template.
+		if strings.HasPrefix(s, key+" = ") {
+			return strings.TrimSpace(strings.TrimPrefix(s, key+" = "))
+		}
+	}
+	s := encodeTOMLTable("", plugins.Table{key: telegrafAgentDefaults[key]})
+	return strings.TrimSpace(strings.TrimPrefix(s, key+" = "))
+}
+
+// otherSettings returns the toml of the agent settings the template of
+// TelegrafConfig.TOML knows nothing about.
+func (a TelegrafAgentConfig) otherSettings() string {
+	other := make(plugins.Table)
+	for k, v := range a.Settings {
+		if _, ok := telegrafAgentDefaults[k]; !ok && !isTelegrafAgentField(k) {
+			other[k] = v
+		}
+	}
+	if len(other) == 0 {
+		return ""
+	}
+	s := encodeTOMLTable("agent", other)
+	// Drop the table header as the settings are appended to the [agent]
+	// table of the template.
+	return strings.TrimPrefix(s, "[agent]\n")
+}
+
+// encodeTOMLTable encodes table as the toml table name, or as top level
+// keys if name is empty. Tables are validated when decoded, so an encoding
+// error results in an empty string.
+func encodeTOMLTable(name string, table plugins.Table) string {
+	var v interface{} = map[string]interface{}(table)
+	if name != "" {
+		v = map[string]interface{}{name: v}
+	}
+	var buf bytes.Buffer
+	enc := toml.NewEncoder(&buf)
+	enc.Indent = "  "
+	if err := enc.Encode(v); err != nil {
+		return ""
+	}
+	return buf.String()
 }
 
 // telegrafConfigEncode is the helper struct for json encoding.
@@ -126,6 +250,10 @@ type telegrafConfigEncode struct {
 	Agent TelegrafAgentConfig `json:"agent"`
 
 	Plugins []telegrafPluginEncode `json:"plugins"`
+
+	GlobalTags plugins.Table `json:"globalTags,omitempty"`
+
+	AgentAuthorizationID ID `json:"agentAuthorizationID,omitempty"`
 }
 
 // telegrafPluginEncode is the helper struct for json encoding.
@@ -148,6 +276,10 @@ type telegrafConfigDecode struct {
 	Agent TelegrafAgentConfig `json:"agent"`
 
 	Plugins []telegrafPluginDecode `json:"plugins"`
+
+	GlobalTags plugins.Table `json:"globalTags,omitempty"`
+
+	AgentAuthorizationID ID `json:"agentAuthorizationID,omitempty"`
 }
 
 // telegrafPluginDecode is the helper struct for json decoding.
@@ -169,6 +301,18 @@ type TelegrafPlugin struct {
 type TelegrafAgentConfig struct {
 	// Interval at which to gather information in miliseconds.
 	Interval int64 `json:"collectionInterval"`
+	// FlushInterval at which to write to the outputs in miliseconds.
+	// Telegraf's default of 10s is used when it is zero.
+	FlushInterval int64 `json:"flushInterval,omitempty"`
+	// MetricBatchSize is the most metrics written to an output at once.
+	// Telegraf's default of 1000 is used when it is zero.
+	MetricBatchSize int `json:"metricBatchSize,omitempty"`
+	// Precision of the collected timestamps, exp "1s". When empty it is
+	// derived from the collection interval.
+	Precision string `json:"precision,omitempty"`
+	// Settings are the other agent settings, exp "hostname", kept as they
+	// were parsed from the [agent] table.
+	Settings plugins.Table `json:"settings,omitempty"`
 }
 
 // TelegrafPluginConfig interface for all plugins.
@@ -202,6 +346,10 @@ func (tc *TelegrafConfig) MarshalJSON() ([]byte, error) {
 		LastMod:   tc.LastMod,
 		LastModBy: tc.LastModBy,
 		Plugins:   make([]telegrafPluginEncode, len(tc.Plugins)),
+
+		GlobalTags: tc.GlobalTags,
+
+		AgentAuthorizationID: tc.AgentAuthorizationID,
 	}
 	for k, p := range tc.Plugins {
 		tce.Plugins[k] = telegrafPluginEncode{
@@ -215,7 +363,42 @@ func (tc *TelegrafConfig) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalTOML implements toml.Unmarshaler interface.
+// Plugins without a typed config are kept as plugins.Raw. Go maps are
+// unordered, so the plugins are added in the order telegraf runs them and by
+// name within a section; use DecodeTOML to keep the order of the file.
 func (tc *TelegrafConfig) UnmarshalTOML(data interface{}) error {
+	return tc.unmarshalTOML(data, nil)
+}
+
+// DecodeTOML decodes a telegraf.conf into tc, keeping the plugins in the
+// order they appear in the file.
+func (tc *TelegrafConfig) DecodeTOML(r io.Reader) error {
+	var data map[string]interface{}
+	md, err := toml.DecodeReader(r, &data)
+	if err != nil {
+		return err
+	}
+	var order []telegrafPluginKey
+	for _, k := range md.Keys() {
+		// Every [[inputs.mem]] table of a plugin is listed as a key of its own.
+		if len(k) != 2 {
+			continue
+		}
+		if _, ok := telegrafPluginSections[k[0]]; ok {
+			order = append(order, telegrafPluginKey{section: k[0], name: k[1]})
+		}
+	}
+	return tc.unmarshalTOML(data, order)
+}
+
+// telegrafPluginKey is a table of a plugin in a telegraf config, exp
+// [[inputs.cpu]].
+type telegrafPluginKey struct {
+	section string
+	name    string
+}
+
+func (tc *TelegrafConfig) unmarshalTOML(data interface{}, order []telegrafPluginKey) error {
 	dataOk, ok := data.(map[string]interface{})
 	if !ok {
 		return errors.New("blank string")
@@ -224,41 +407,74 @@ func (tc *TelegrafConfig) UnmarshalTOML(data interface{}) error {
 	if !ok {
 		return errors.New("agent is missing")
 	}
-
-	intervalStr, ok := agent["interval"].(string)
-	if !ok {
-		return errors.New("agent interval is not string")
-	}
-
-	interval, err := time.ParseDuration(intervalStr)
-	if err != nil {
+	if err := tc.Agent.unmarshalTOML(agent); err != nil {
 		return err
 	}
-	tc.Agent = TelegrafAgentConfig{
-		Interval: interval.Nanoseconds() / 1000000,
-	}
 
+	sections := make(map[string]map[string]interface{})
 	for tp, ps := range dataOk {
 		if tp == "agent" {
 			continue
 		}
-		plugins, ok := ps.(map[string]interface{})
-		if !ok {
-			return &Error{
-				Msg: "bad plugin type",
+		if tp == "global_tags" {
+			table, ok := ps.(map[string]interface{})
+			if !ok {
+				return &Error{
+					Code: EInvalid,
+					Msg:  "global_tags is not a table",
+				}
+			}
+			if len(table) > 0 {
+				tc.GlobalTags = table
 			}
+			continue
 		}
-		for name, configDataArray := range plugins {
-			if configDataArray == nil {
-				if err := tc.parseTOMLPluginConfig(tp, name, configDataArray); err != nil {
-					return err
+		if _, ok := telegrafPluginSections[tp]; ok {
+			plugins, ok := ps.(map[string]interface{})
+			if !ok {
+				return &Error{
+					Code: EInvalid,
+					Msg:  "bad plugin type",
 				}
+			}
+			sections[tp] = plugins
+			continue
+		}
+		// Other tables are only accepted when they are empty.
+		if table, ok := ps.(map[string]interface{}); !ok || len(table) > 0 {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf(ErrUnsupportTelegrafPluginType, tp),
+			}
+		}
+	}
+
+	if order == nil {
+		order = sortedTelegrafPluginKeys(sections)
+	}
+	// seen counts the tables already added for a plugin configured more than
+	// once, exp [[inputs.mem]].
+	seen := make(map[telegrafPluginKey]int)
+	for _, k := range order {
+		switch configData := sections[k.section][k.name].(type) {
+		case []map[string]interface{}:
+			i := seen[k]
+			if i >= len(configData) {
 				continue
 			}
-			for _, configData := range configDataArray.([]map[string]interface{}) {
-				if err := tc.parseTOMLPluginConfig(tp, name, configData); err != nil {
-					return err
-				}
+			seen[k]++
+			if err := tc.parseTOMLPluginConfig(k.section, k.name, configData[i]); err != nil {
+				return err
+			}
+		default:
+			// A plugin may also be configured by a single table, exp
+			// [inputs.cpu], or by no table at all.
+			if seen[k] > 0 {
+				continue
+			}
+			seen[k]++
+			if err := tc.parseTOMLPluginConfig(k.section, k.name, configData); err != nil {
+				return err
 			}
 		}
 	}
@@ -266,24 +482,89 @@ func (tc *TelegrafConfig) UnmarshalTOML(data interface{}) error {
 	return nil
 }
 
-func (tc *TelegrafConfig) parseTOMLPluginConfig(typ, name string, configData interface{}) error {
-	var ok bool
-	var p TelegrafPluginConfig
-	switch typ {
-	case "inputs":
-		p, ok = availableInputPlugins[name]
-	case "outputs":
-		p, ok = availableOutputPlugins[name]
-	default:
-		return &Error{
-			Msg: fmt.Sprintf(ErrUnsupportTelegrafPluginType, typ),
+// sortedTelegrafPluginKeys returns a key for every table of the plugins in the
+// order telegraf runs them and by name within a section.
+func sortedTelegrafPluginKeys(sections map[string]map[string]interface{}) []telegrafPluginKey {
+	var keys []telegrafPluginKey
+	for _, tp := range []string{"inputs", "processors", "aggregators", "outputs"} {
+		names := make([]string, 0, len(sections[tp]))
+		for name := range sections[tp] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			n := 1
+			if tables, ok := sections[tp][name].([]map[string]interface{}); ok {
+				n = len(tables)
+			}
+			for i := 0; i < n; i++ {
+				keys = append(keys, telegrafPluginKey{section: tp, name: name})
+			}
 		}
 	}
+	return keys
+}
+
+func (a *TelegrafAgentConfig) unmarshalTOML(agent map[string]interface{}) error {
+	intervalStr, ok := agent["interval"].(string)
+	if !ok {
+		return errors.New("agent interval is not string")
+	}
+	interval, err := time.ParseDuration(intervalStr)
+	if err != nil {
+		return err
+	}
+	*a = TelegrafAgentConfig{
+		Interval: interval.Nanoseconds() / 1000000,
+	}
+
+	if v, ok := agent["flush_interval"]; ok {
+		flushIntervalStr, ok := v.(string)
+		if !ok {
+			return errors.New("agent flush_interval is not string")
+		}
+		flushInterval, err := time.ParseDuration(flushIntervalStr)
+		if err != nil {
+			return err
+		}
+		a.FlushInterval = flushInterval.Nanoseconds() / 1000000
+	}
+
+	if v, ok := agent["metric_batch_size"]; ok {
+		metricBatchSize, ok := v.(int64)
+		if !ok {
+			return errors.New("agent metric_batch_size is not an integer")
+		}
+		a.MetricBatchSize = int(metricBatchSize)
+	}
+
+	if v, ok := agent["precision"]; ok {
+		if a.Precision, ok = v.(string); !ok {
+			return errors.New("agent precision is not string")
+		}
+	}
+
+	for k, v := range agent {
+		if isTelegrafAgentField(k) || reflect.DeepEqual(v, telegrafAgentDefaults[k]) {
+			continue
+		}
+		if a.Settings == nil {
+			a.Settings = make(plugins.Table)
+		}
+		a.Settings[k] = v
+	}
+	return nil
+}
+
+func (tc *TelegrafConfig) parseTOMLPluginConfig(section, name string, configData interface{}) error {
+	typ, ok := telegrafPluginSections[section]
 	if !ok {
 		return &Error{
-			Msg: fmt.Sprintf(ErrUnsupportTelegrafPluginName, name, typ),
+			Code: EInvalid,
+			Msg:  fmt.Sprintf(ErrUnsupportTelegrafPluginType, section),
 		}
 	}
+	p := newTelegrafPluginConfig(typ, name)
 	if err := p.UnmarshalTOML(configData); err != nil {
 		return err
 	}
@@ -307,6 +588,10 @@ func (tc *TelegrafConfig) UnmarshalJSON(b []byte) error {
 		LastModBy: tcd.LastModBy,
 		Agent:     tcd.Agent,
 		Plugins:   make([]TelegrafPlugin, len(tcd.Plugins)),
+
+		GlobalTags: tcd.GlobalTags,
+
+		AgentAuthorizationID: tcd.AgentAuthorizationID,
 	}
 	return decodePluginRaw(tcd, tc)
 }
@@ -314,13 +599,8 @@ func (tc *TelegrafConfig) UnmarshalJSON(b []byte) error {
 func decodePluginRaw(tcd *telegrafConfigDecode, tc *TelegrafConfig) (err error) {
 	op := "unmarshal telegraf config raw plugin"
 	for k, pr := range tcd.Plugins {
-		var config TelegrafPluginConfig
-		var ok bool
 		switch pr.Type {
-		case plugins.Input:
-			config, ok = availableInputPlugins[pr.Name]
-		case plugins.Output:
-			config, ok = availableOutputPlugins[pr.Name]
+		case plugins.Input, plugins.Output, plugins.Processor, plugins.Aggregator:
 		default:
 			return &Error{
 				Code: EInvalid,
@@ -328,54 +608,89 @@ func decodePluginRaw(tcd *telegrafConfigDecode, tc *TelegrafConfig) (err error)
 				Op:   op,
 			}
 		}
-		if ok {
-			if err = json.Unmarshal(pr.Config, config); err != nil {
-				return &Error{
-					Code: EInvalid,
-					Err:  err,
-					Op:   op,
-				}
-			}
-			tc.Plugins[k] = TelegrafPlugin{
-				Comment: pr.Comment,
-				Config:  config,
+		config := newTelegrafPluginConfig(pr.Type, pr.Name)
+		if err = json.Unmarshal(pr.Config, config); err != nil {
+			return &Error{
+				Code: EInvalid,
+				Err:  err,
+				Op:   op,
 			}
-			continue
 		}
-		return &Error{
-			Code: EInvalid,
-			Op:   op,
-			Msg:  fmt.Sprintf(ErrUnsupportTelegrafPluginName, pr.Name, pr.Type),
+		tc.Plugins[k] = TelegrafPlugin{
+			Comment: pr.Comment,
+			Config:  config,
 		}
-
 	}
 	return nil
 }
 
-var availableInputPlugins = map[string]TelegrafPluginConfig{
-	"cpu":          &inputs.CPUStats{},
-	"disk":         &inputs.DiskStats{},
-	"diskio":       &inputs.DiskIO{},
-	"docker":       &inputs.Docker{},
-	"file":         &inputs.File{},
-	"kernel":       &inputs.Kernel{},
-	"kubernetes":   &inputs.Kubernetes{},
-	"logparser":    &inputs.LogParserPlugin{},
-	"mem":          &inputs.MemStats{},
-	"net_response": &inputs.NetResponse{},
-	"net":          &inputs.NetIOStats{},
-	"ngnix":        &inputs.Nginx{},
-	"processes":    &inputs.Processes{},
-	"procstats":    &inputs.Procstat{},
-	"prometheus":   &inputs.Prometheus{},
-	"redis":        &inputs.Redis{},
-	"swap":         &inputs.SwapStats{},
-	"syslog":       &inputs.Syslog{},
-	"system":       &inputs.SystemStats{},
-	"tail":         &inputs.Tail{},
-}
-
-var availableOutputPlugins = map[string]TelegrafPluginConfig{
-	"file":        &outputs.File{},
-	"influxdb_v2": &outputs.InfluxDBV2{},
+// newTelegrafPluginConfig returns an empty config for the plugin. Plugins
+// without a typed config are returned as plugins.Raw.
+func newTelegrafPluginConfig(typ plugins.Type, name string) TelegrafPluginConfig {
+	var fn func() TelegrafPluginConfig
+	switch typ {
+	case plugins.Input:
+		fn = availableInputPlugins[name]
+	case plugins.Output:
+		fn = availableOutputPlugins[name]
+	case plugins.Processor:
+		fn = availableProcessorPlugins[name]
+	case plugins.Aggregator:
+		fn = availableAggregatorPlugins[name]
+	}
+	if fn == nil {
+		return &plugins.Raw{
+			Name:       name,
+			PluginType: typ,
+		}
+	}
+	return fn()
+}
+
+// telegrafPluginSections maps the toml sections of a telegraf config to the
+// type of the plugins configured in them.
+var telegrafPluginSections = map[string]plugins.Type{
+	"inputs":      plugins.Input,
+	"outputs":     plugins.Output,
+	"processors":  plugins.Processor,
+	"aggregators": plugins.Aggregator,
+}
+
+var availableInputPlugins = map[string]func() TelegrafPluginConfig{
+	"cpu":          func() TelegrafPluginConfig { return &inputs.CPUStats{} },
+	"disk":         func() TelegrafPluginConfig { return &inputs.DiskStats{} },
+	"diskio":       func() TelegrafPluginConfig { return &inputs.DiskIO{} },
+	"docker":       func() TelegrafPluginConfig { return &inputs.Docker{} },
+	"file":         func() TelegrafPluginConfig { return &inputs.File{} },
+	"kernel":       func() TelegrafPluginConfig { return &inputs.Kernel{} },
+	"kubernetes":   func() TelegrafPluginConfig { return &inputs.Kubernetes{} },
+	"logparser":    func() TelegrafPluginConfig { return &inputs.LogParserPlugin{} },
+	"mem":          func() TelegrafPluginConfig { return &inputs.MemStats{} },
+	"net_response": func() TelegrafPluginConfig { return &inputs.NetResponse{} },
+	"net":          func() TelegrafPluginConfig { return &inputs.NetIOStats{} },
+	"nginx":        func() TelegrafPluginConfig { return &inputs.Nginx{} },
+	"processes":    func() TelegrafPluginConfig { return &inputs.Processes{} },
+	"procstat":     func() TelegrafPluginConfig { return &inputs.Procstat{} },
+	"prometheus":   func() TelegrafPluginConfig { return &inputs.Prometheus{} },
+	"redis":        func() TelegrafPluginConfig { return &inputs.Redis{} },
+	"swap":         func() TelegrafPluginConfig { return &inputs.SwapStats{} },
+	"syslog":       func() TelegrafPluginConfig { return &inputs.Syslog{} },
+	"system":       func() TelegrafPluginConfig { return &inputs.SystemStats{} },
+	"tail":         func() TelegrafPluginConfig { return &inputs.Tail{} },
+}
+
+var availableOutputPlugins = map[string]func() TelegrafPluginConfig{
+	"file":        func() TelegrafPluginConfig { return &outputs.File{} },
+	"influxdb_v2": func() TelegrafPluginConfig { return &outputs.InfluxDBV2{} },
+}
+
+var availableProcessorPlugins = map[string]func() TelegrafPluginConfig{
+	"converter": func() TelegrafPluginConfig { return &processors.Converter{} },
+	"regex":     func() TelegrafPluginConfig { return &processors.Regex{} },
+	"rename":    func() TelegrafPluginConfig { return &processors.Rename{} },
+}
+
+var availableAggregatorPlugins = map[string]func() TelegrafPluginConfig{
+	"basicstats": func() TelegrafPluginConfig { return &aggregators.BasicStats{} },
+	"minmax":     func() TelegrafPluginConfig { return &aggregators.MinMax{} },
 }
@@ -0,0 +1,99 @@
+// Package telegraf provides services for distributing telegraf configs to
+// the telegraf agents that run them.
+package telegraf
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/telegraf/plugins/outputs"
+)
+
+var _ platform.TelegrafAgentAuthorizationService = (*AgentAuthorizationService)(nil)
+
+// AgentAuthorizationService mints the authorizations telegraf agents use to
+// fetch their config and write to the buckets of its influxdb_v2 outputs.
+type AgentAuthorizationService struct {
+	TelegrafConfigStore  platform.TelegrafConfigStore
+	BucketService        platform.BucketService
+	AuthorizationService platform.AuthorizationService
+
+	// Now returns the current time, time.Now if nil.
+	Now func() time.Time
+}
+
+// CreateTelegrafAgentAuthorization creates an authorization for userID that
+// may only read the telegraf config and write to the buckets of its
+// influxdb_v2 outputs. The token is set on those outputs and the
+// authorization previously created for the config is deleted.
+func (s *AgentAuthorizationService) CreateTelegrafAgentAuthorization(ctx context.Context, id platform.ID, userID platform.ID) (*platform.Authorization, error) {
+	tc, err := s.TelegrafConfigStore.FindTelegrafConfigByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var outs []*outputs.InfluxDBV2
+	perms := []platform.Permission{platform.ReadTelegrafPermission(tc.ID)}
+	for _, p := range tc.Plugins {
+		out, ok := p.Config.(*outputs.InfluxDBV2)
+		if !ok {
+			continue
+		}
+		b, err := s.BucketService.FindBucket(ctx, platform.BucketFilter{
+			Organization: &out.Organization,
+			Name:         &out.Bucket,
+		})
+		if err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("bucket %q of organization %q for influxdb_v2 output not found", out.Bucket, out.Organization),
+				Err:  err,
+			}
+		}
+		outs = append(outs, out)
+		perms = append(perms, platform.WriteBucketPermission(b.ID))
+	}
+	if len(outs) == 0 {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "telegraf config has no influxdb_v2 output to write to",
+		}
+	}
+
+	a := &platform.Authorization{
+		UserID:      userID,
+		Description: fmt.Sprintf("telegraf agents of %s", tc.Name),
+		Permissions: perms,
+	}
+	if err := s.AuthorizationService.CreateAuthorization(ctx, a); err != nil {
+		return nil, err
+	}
+
+	oldID := tc.AgentAuthorizationID
+	for _, out := range outs {
+		out.Token = a.Token
+	}
+	tc.AgentAuthorizationID = a.ID
+	if _, err := s.TelegrafConfigStore.UpdateTelegrafConfig(ctx, tc.ID, tc, userID, s.now()); err != nil {
+		// The config still uses the old authorization, so the new one must go.
+		s.AuthorizationService.DeleteAuthorization(ctx, a.ID)
+		return nil, err
+	}
+
+	if oldID.Valid() {
+		if err := s.AuthorizationService.DeleteAuthorization(ctx, oldID); err != nil && platform.ErrorCode(err) != platform.ENotFound {
+			return nil, err
+		}
+	}
+
+	return a, nil
+}
+
+func (s *AgentAuthorizationService) now() time.Time {
+	if s.Now == nil {
+		return time.Now()
+	}
+	return s.Now()
+}
@@ -0,0 +1,127 @@
+package telegraf_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/inmem"
+	"github.com/influxdata/platform/telegraf"
+	"github.com/influxdata/platform/telegraf/plugins/inputs"
+	"github.com/influxdata/platform/telegraf/plugins/outputs"
+)
+
+func newAgentAuthorizationService(t *testing.T) (*telegraf.AgentAuthorizationService, *inmem.Service, *platform.User, *platform.Bucket) {
+	t.Helper()
+	ctx := context.Background()
+	s := inmem.NewService()
+
+	u := &platform.User{Name: "u1"}
+	if err := s.CreateUser(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	org := &platform.Organization{Name: "o1"}
+	if err := s.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+	b := &platform.Bucket{Name: "b1", OrganizationID: org.ID}
+	if err := s.CreateBucket(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := &telegraf.AgentAuthorizationService{
+		TelegrafConfigStore:  s,
+		BucketService:        s,
+		AuthorizationService: s,
+		Now:                  func() time.Time { return time.Date(2006, 5, 4, 1, 2, 3, 0, time.UTC) },
+	}
+	return svc, s, u, b
+}
+
+func TestAgentAuthorizationService_CreateTelegrafAgentAuthorization(t *testing.T) {
+	svc, s, u, b := newAgentAuthorizationService(t)
+	ctx := context.Background()
+	userID := u.ID
+
+	tc := &platform.TelegrafConfig{
+		Name: "tc1",
+		Plugins: []platform.TelegrafPlugin{
+			{Config: &inputs.CPUStats{}},
+			{Config: &outputs.InfluxDBV2{
+				URLs:         []string{"http://127.0.0.1:9999"},
+				Organization: "o1",
+				Bucket:       "b1",
+			}},
+		},
+	}
+	if err := s.CreateTelegrafConfig(ctx, tc, userID, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	a1, err := svc.CreateTelegrafAgentAuthorization(ctx, tc.ID, userID)
+	if err != nil {
+		t.Fatalf("unexpected error creating agent authorization: %v", err)
+	}
+	if a1.UserID != userID {
+		t.Errorf("expected authorization for user %s, got %s", userID, a1.UserID)
+	}
+	if !a1.Allowed(platform.WriteBucketPermission(b.ID)) {
+		t.Errorf("expected authorization to write to bucket %s", b.ID)
+	}
+	if !a1.Allowed(platform.ReadTelegrafPermission(tc.ID)) {
+		t.Errorf("expected authorization to read telegraf config %s", tc.ID)
+	}
+	if len(a1.Permissions) != 2 {
+		t.Errorf("expected 2 permissions, got %v", a1.Permissions)
+	}
+
+	got, err := s.FindTelegrafConfigByID(ctx, tc.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AgentAuthorizationID != a1.ID {
+		t.Errorf("expected agent authorization %s, got %s", a1.ID, got.AgentAuthorizationID)
+	}
+	if out := got.Plugins[1].Config.(*outputs.InfluxDBV2); out.Token != a1.Token {
+		t.Errorf("expected output token %q, got %q", a1.Token, out.Token)
+	}
+
+	a2, err := svc.CreateTelegrafAgentAuthorization(ctx, tc.ID, userID)
+	if err != nil {
+		t.Fatalf("unexpected error replacing agent authorization: %v", err)
+	}
+	if _, err := s.FindAuthorizationByID(ctx, a1.ID); platform.ErrorCode(err) != platform.ENotFound {
+		t.Errorf("expected replaced authorization to be deleted, got %v", err)
+	}
+	if _, err := s.FindAuthorizationByID(ctx, a2.ID); err != nil {
+		t.Errorf("expected new authorization to exist, got %v", err)
+	}
+}
+
+func TestAgentAuthorizationService_RequiresOutput(t *testing.T) {
+	svc, s, u, _ := newAgentAuthorizationService(t)
+	ctx := context.Background()
+	userID := u.ID
+
+	tcs := []*platform.TelegrafConfig{
+		{
+			Name:    "no output",
+			Plugins: []platform.TelegrafPlugin{{Config: &inputs.CPUStats{}}},
+		},
+		{
+			Name: "missing bucket",
+			Plugins: []platform.TelegrafPlugin{
+				{Config: &outputs.InfluxDBV2{Organization: "o1", Bucket: "b2"}},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		if err := s.CreateTelegrafConfig(ctx, tc, userID, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := svc.CreateTelegrafAgentAuthorization(ctx, tc.ID, userID); platform.ErrorCode(err) != platform.EInvalid {
+			t.Errorf("%s: expected invalid error, got %v", tc.Name, err)
+		}
+	}
+}
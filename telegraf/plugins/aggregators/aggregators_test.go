@@ -0,0 +1,145 @@
+package aggregators
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/platform/telegraf/plugins"
+)
+
+// local plugin
+type telegrafPluginConfig interface {
+	TOML() string
+	Type() plugins.Type
+	PluginName() string
+	UnmarshalTOML(data interface{}) error
+}
+
+func TestType(t *testing.T) {
+	b := baseAggregator{}
+	if b.Type() != plugins.Aggregator {
+		t.Fatalf("aggregator plugins type should be aggregator, got %s", b.Type())
+	}
+}
+
+func TestTOML(t *testing.T) {
+	cases := []struct {
+		name    string
+		plugins map[telegrafPluginConfig]string
+	}{
+		{
+			name: "standard testing",
+			plugins: map[telegrafPluginConfig]string{
+				&BasicStats{
+					baseAggregator: baseAggregator{
+						Period: 30000,
+					},
+					Stats: []string{"count", "mean"},
+				}: `[[aggregators.basicstats]]
+  ## The period on which to flush & clear the aggregator.
+  period = "30s"
+  ## If true, the original metric will be dropped by the
+  ## aggregator and will not get sent to the output plugins.
+  drop_original = false
+
+  ## Configures which basic stats to push as fields
+  stats = ["count", "mean"]
+`,
+				&MinMax{
+					baseAggregator: baseAggregator{
+						Period:       60000,
+						DropOriginal: true,
+					},
+				}: `[[aggregators.minmax]]
+  ## The period on which to flush & clear the aggregator.
+  period = "1m0s"
+  ## If true, the original metric will be dropped by the
+  ## aggregator and will not get sent to the output plugins.
+  drop_original = true
+`,
+			},
+		},
+	}
+	for _, c := range cases {
+		for aggregator, toml := range c.plugins {
+			if toml != aggregator.TOML() {
+				t.Fatalf("%s failed want %s, got %v", c.name, toml, aggregator.TOML())
+			}
+		}
+	}
+}
+
+func TestDecodeTOML(t *testing.T) {
+	cases := []struct {
+		name    string
+		want    telegrafPluginConfig
+		wantErr error
+		output  telegrafPluginConfig
+		data    interface{}
+	}{
+		{
+			name:    "basicstats empty",
+			want:    &BasicStats{},
+			wantErr: errors.New("bad period for basicstats aggregator plugin"),
+			output:  &BasicStats{},
+		},
+		{
+			name:    "basicstats missing period",
+			want:    &BasicStats{},
+			wantErr: errors.New("period is missing for basicstats aggregator plugin"),
+			output:  &BasicStats{},
+			data:    map[string]interface{}{},
+		},
+		{
+			name: "basicstats",
+			want: &BasicStats{
+				baseAggregator: baseAggregator{
+					Period: 10000,
+				},
+				Stats: []string{"min", "max"},
+			},
+			output: &BasicStats{},
+			data: map[string]interface{}{
+				"period": "10s",
+				"stats":  []interface{}{"min", "max"},
+			},
+		},
+		{
+			name:    "minmax bad drop_original",
+			want:    &MinMax{baseAggregator: baseAggregator{Period: 30000}},
+			wantErr: errors.New("drop_original is not a boolean"),
+			output:  &MinMax{},
+			data: map[string]interface{}{
+				"period":        "30s",
+				"drop_original": "yes",
+			},
+		},
+		{
+			name: "minmax",
+			want: &MinMax{
+				baseAggregator: baseAggregator{
+					Period:       30000,
+					DropOriginal: true,
+				},
+			},
+			output: &MinMax{},
+			data: map[string]interface{}{
+				"period":        "30s",
+				"drop_original": true,
+			},
+		},
+	}
+	for _, c := range cases {
+		err := c.output.UnmarshalTOML(c.data)
+		if c.wantErr != nil && (err == nil || err.Error() != c.wantErr.Error()) {
+			t.Fatalf("%s failed want err %s, got %v", c.name, c.wantErr.Error(), err)
+		}
+		if c.wantErr == nil && err != nil {
+			t.Fatalf("%s failed want err nil, got %v", c.name, err)
+		}
+		if !reflect.DeepEqual(c.output, c.want) {
+			t.Fatalf("%s failed want %v, got %v", c.name, c.want, c.output)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package aggregators
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/platform/telegraf/plugins"
+)
+
+type baseAggregator struct {
+	// Period is the aggregation window in milliseconds.
+	Period int64 `json:"period"`
+	// DropOriginal drops the metrics that are aggregated so only the
+	// aggregates are passed on to the outputs.
+	DropOriginal bool `json:"dropOriginal"`
+}
+
+func (b baseAggregator) Type() plugins.Type {
+	return plugins.Aggregator
+}
+
+func (b baseAggregator) toml() string {
+	return fmt.Sprintf(`  ## The period on which to flush & clear the aggregator.
+  period = "%s"
+  ## If true, the original metric will be dropped by the
+  ## aggregator and will not get sent to the output plugins.
+  drop_original = %t
+`, time.Duration(b.Period*int64(time.Millisecond)), b.DropOriginal)
+}
+
+func (b *baseAggregator) unmarshalTOML(data map[string]interface{}, name string) error {
+	periodStr, ok := data["period"].(string)
+	if !ok {
+		return fmt.Errorf("period is missing for %s aggregator plugin", name)
+	}
+	period, err := time.ParseDuration(periodStr)
+	if err != nil {
+		return err
+	}
+	b.Period = period.Nanoseconds() / int64(time.Millisecond)
+	if v, ok := data["drop_original"]; ok {
+		if b.DropOriginal, ok = v.(bool); !ok {
+			return errors.New("drop_original is not a boolean")
+		}
+	}
+	return nil
+}
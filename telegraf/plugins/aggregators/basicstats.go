@@ -0,0 +1,54 @@
+package aggregators
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BasicStats is based on telegraf basicstats aggregator plugin.
+type BasicStats struct {
+	baseAggregator
+	// Stats are the statistics to compute, all of them if empty.
+	Stats []string `json:"stats"`
+}
+
+// PluginName is based on telegraf plugin name.
+func (b *BasicStats) PluginName() string {
+	return "basicstats"
+}
+
+// TOML encodes to toml string.
+func (b *BasicStats) TOML() string {
+	s := make([]string, len(b.Stats))
+	for k, v := range b.Stats {
+		s[k] = strconv.Quote(v)
+	}
+	return fmt.Sprintf(`[[aggregators.%s]]
+%s
+  ## Configures which basic stats to push as fields
+  stats = [%s]
+`, b.PluginName(), b.baseAggregator.toml(), strings.Join(s, ", "))
+}
+
+// UnmarshalTOML decodes the parsed data to the object
+func (b *BasicStats) UnmarshalTOML(data interface{}) error {
+	dataOK, ok := data.(map[string]interface{})
+	if !ok {
+		return errors.New("bad period for basicstats aggregator plugin")
+	}
+	if err := b.baseAggregator.unmarshalTOML(dataOK, b.PluginName()); err != nil {
+		return err
+	}
+	if v, ok := dataOK["stats"]; ok {
+		stats, ok := v.([]interface{})
+		if !ok {
+			return errors.New("stats is not an array for basicstats aggregator plugin")
+		}
+		for _, st := range stats {
+			b.Stats = append(b.Stats, st.(string))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package aggregators
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MinMax is based on telegraf minmax aggregator plugin.
+type MinMax struct {
+	baseAggregator
+}
+
+// PluginName is based on telegraf plugin name.
+func (m *MinMax) PluginName() string {
+	return "minmax"
+}
+
+// TOML encodes to toml string.
+func (m *MinMax) TOML() string {
+	return fmt.Sprintf(`[[aggregators.%s]]
+%s`, m.PluginName(), m.baseAggregator.toml())
+}
+
+// UnmarshalTOML decodes the parsed data to the object
+func (m *MinMax) UnmarshalTOML(data interface{}) error {
+	dataOK, ok := data.(map[string]interface{})
+	if !ok {
+		return errors.New("bad period for minmax aggregator plugin")
+	}
+	return m.baseAggregator.unmarshalTOML(dataOK, m.PluginName())
+}
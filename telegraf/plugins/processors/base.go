@@ -0,0 +1,9 @@
+package processors
+
+import "github.com/influxdata/platform/telegraf/plugins"
+
+type baseProcessor int
+
+func (b baseProcessor) Type() plugins.Type {
+	return plugins.Processor
+}
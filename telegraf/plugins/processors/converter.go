@@ -0,0 +1,124 @@
+package processors
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Converter is based on telegraf converter processor plugin.
+type Converter struct {
+	baseProcessor
+	Tags   *ConverterConversion `json:"tags,omitempty"`
+	Fields *ConverterConversion `json:"fields,omitempty"`
+}
+
+// ConverterConversion lists the tags or fields to convert to each type.
+// Tag is only valid for fields.
+type ConverterConversion struct {
+	Tag      []string `json:"tag,omitempty"`
+	String   []string `json:"string,omitempty"`
+	Integer  []string `json:"integer,omitempty"`
+	Unsigned []string `json:"unsigned,omitempty"`
+	Boolean  []string `json:"boolean,omitempty"`
+	Float    []string `json:"float,omitempty"`
+}
+
+// PluginName is based on telegraf plugin name.
+func (c *Converter) PluginName() string {
+	return "converter"
+}
+
+// TOML encodes to toml string.
+func (c *Converter) TOML() string {
+	s := ""
+	if c.Tags != nil {
+		s += fmt.Sprintf("  [processors.%s.tags]\n", c.PluginName())
+		s += c.Tags.toml()
+	}
+	if c.Fields != nil {
+		s += fmt.Sprintf("  [processors.%s.fields]\n", c.PluginName())
+		s += c.Fields.toml()
+	}
+	return fmt.Sprintf(`[[processors.%s]]
+  ## Tags and fields listed under each type are converted to that type.
+%s`, c.PluginName(), s)
+}
+
+func (cc *ConverterConversion) toml() string {
+	s := ""
+	for _, kv := range []struct {
+		key  string
+		keys []string
+	}{
+		{"tag", cc.Tag},
+		{"string", cc.String},
+		{"integer", cc.Integer},
+		{"unsigned", cc.Unsigned},
+		{"boolean", cc.Boolean},
+		{"float", cc.Float},
+	} {
+		if len(kv.keys) == 0 {
+			continue
+		}
+		q := make([]string, len(kv.keys))
+		for i, k := range kv.keys {
+			q[i] = strconv.Quote(k)
+		}
+		s += fmt.Sprintf("    %s = [%s]\n", kv.key, strings.Join(q, ", "))
+	}
+	return s
+}
+
+// UnmarshalTOML decodes the parsed data to the object
+func (c *Converter) UnmarshalTOML(data interface{}) error {
+	dataOK, ok := data.(map[string]interface{})
+	if !ok {
+		return errors.New("bad conversions for converter processor plugin")
+	}
+	var err error
+	if c.Tags, err = decodeConverterConversion(dataOK["tags"]); err != nil {
+		return err
+	}
+	if c.Fields, err = decodeConverterConversion(dataOK["fields"]); err != nil {
+		return err
+	}
+	return nil
+}
+
+func decodeConverterConversion(data interface{}) (*ConverterConversion, error) {
+	if data == nil {
+		return nil, nil
+	}
+	table, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("conversion is not a table for converter processor plugin")
+	}
+	cc := new(ConverterConversion)
+	for key, dst := range map[string]*[]string{
+		"tag":      &cc.Tag,
+		"string":   &cc.String,
+		"integer":  &cc.Integer,
+		"unsigned": &cc.Unsigned,
+		"boolean":  &cc.Boolean,
+		"float":    &cc.Float,
+	} {
+		v, ok := table[key]
+		if !ok {
+			continue
+		}
+		keys, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s is not an array for converter processor plugin", key)
+		}
+		for _, k := range keys {
+			s, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s is not an array of strings for converter processor plugin", key)
+			}
+			*dst = append(*dst, s)
+		}
+	}
+	return cc, nil
+}
@@ -0,0 +1,213 @@
+package processors
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/platform/telegraf/plugins"
+)
+
+// local plugin
+type telegrafPluginConfig interface {
+	TOML() string
+	Type() plugins.Type
+	PluginName() string
+	UnmarshalTOML(data interface{}) error
+}
+
+func TestType(t *testing.T) {
+	b := baseProcessor(0)
+	if b.Type() != plugins.Processor {
+		t.Fatalf("processor plugins type should be processor, got %s", b.Type())
+	}
+}
+
+func TestTOML(t *testing.T) {
+	cases := []struct {
+		name    string
+		plugins map[telegrafPluginConfig]string
+	}{
+		{
+			name: "test empty plugins",
+			plugins: map[telegrafPluginConfig]string{
+				&Rename{}: `[[processors.rename]]
+  ## Measurements, tags and fields are renamed by the replace tables below.
+`,
+				&Regex{}: `[[processors.regex]]
+  ## Tag and field values are transformed by the conversion tables below.
+`,
+				&Converter{}: `[[processors.converter]]
+  ## Tags and fields listed under each type are converted to that type.
+`,
+			},
+		},
+		{
+			name: "standard testing",
+			plugins: map[telegrafPluginConfig]string{
+				&Rename{
+					Replaces: []RenameReplacement{
+						{Measurement: "network_interface_throughput", Dest: "throughput"},
+						{Tag: "hostname", Dest: "host"},
+						{Field: "lower", Dest: "min"},
+					},
+				}: `[[processors.rename]]
+  ## Measurements, tags and fields are renamed by the replace tables below.
+  [[processors.rename.replace]]
+    measurement = "network_interface_throughput"
+    dest = "throughput"
+  [[processors.rename.replace]]
+    tag = "hostname"
+    dest = "host"
+  [[processors.rename.replace]]
+    field = "lower"
+    dest = "min"
+`,
+				&Regex{
+					Tags: []RegexConverter{
+						{Key: "resp_code", Pattern: `^(\d)\d\d$`, Replacement: "${1}xx"},
+					},
+					Fields: []RegexConverter{
+						{Key: "request", Pattern: `^/api(?P<method>/[\w/]+)\S*`, Replacement: "${method}", ResultKey: "method"},
+					},
+				}: `[[processors.regex]]
+  ## Tag and field values are transformed by the conversion tables below.
+  [[processors.regex.tags]]
+    key = "resp_code"
+    pattern = "^(\\d)\\d\\d$"
+    replacement = "${1}xx"
+  [[processors.regex.fields]]
+    key = "request"
+    pattern = "^/api(?P<method>/[\\w/]+)\\S*"
+    replacement = "${method}"
+    result_key = "method"
+`,
+				&Converter{
+					Tags: &ConverterConversion{
+						Integer: []string{"port"},
+					},
+					Fields: &ConverterConversion{
+						Tag:   []string{"host"},
+						Float: []string{"usage", "load"},
+					},
+				}: `[[processors.converter]]
+  ## Tags and fields listed under each type are converted to that type.
+  [processors.converter.tags]
+    integer = ["port"]
+  [processors.converter.fields]
+    tag = ["host"]
+    float = ["usage", "load"]
+`,
+			},
+		},
+	}
+	for _, c := range cases {
+		for processor, toml := range c.plugins {
+			if toml != processor.TOML() {
+				t.Fatalf("%s failed want %s, got %v", c.name, toml, processor.TOML())
+			}
+		}
+	}
+}
+
+func TestDecodeTOML(t *testing.T) {
+	cases := []struct {
+		name    string
+		want    telegrafPluginConfig
+		wantErr error
+		output  telegrafPluginConfig
+		data    interface{}
+	}{
+		{
+			name:    "rename empty",
+			want:    &Rename{},
+			wantErr: errors.New("bad replace for rename processor plugin"),
+			output:  &Rename{},
+		},
+		{
+			name:    "rename missing dest",
+			want:    &Rename{},
+			wantErr: errors.New("dest is missing for rename processor plugin"),
+			output:  &Rename{},
+			data: map[string]interface{}{
+				"replace": []map[string]interface{}{
+					{"tag": "hostname"},
+				},
+			},
+		},
+		{
+			name: "rename",
+			want: &Rename{
+				Replaces: []RenameReplacement{
+					{Tag: "hostname", Dest: "host"},
+				},
+			},
+			output: &Rename{},
+			data: map[string]interface{}{
+				"replace": []map[string]interface{}{
+					{"tag": "hostname", "dest": "host"},
+				},
+			},
+		},
+		{
+			name:    "regex missing pattern",
+			want:    &Regex{},
+			wantErr: errors.New("pattern is missing for regex processor plugin"),
+			output:  &Regex{},
+			data: map[string]interface{}{
+				"tags": []map[string]interface{}{
+					{"key": "resp_code"},
+				},
+			},
+		},
+		{
+			name: "regex",
+			want: &Regex{
+				Fields: []RegexConverter{
+					{Key: "request", Pattern: "^/api", Replacement: "", ResultKey: "method"},
+				},
+			},
+			output: &Regex{},
+			data: map[string]interface{}{
+				"fields": []map[string]interface{}{
+					{"key": "request", "pattern": "^/api", "result_key": "method"},
+				},
+			},
+		},
+		{
+			name:    "converter bad fields",
+			want:    &Converter{},
+			wantErr: errors.New("conversion is not a table for converter processor plugin"),
+			output:  &Converter{},
+			data: map[string]interface{}{
+				"fields": "",
+			},
+		},
+		{
+			name: "converter",
+			want: &Converter{
+				Fields: &ConverterConversion{
+					Integer: []string{"a", "b"},
+				},
+			},
+			output: &Converter{},
+			data: map[string]interface{}{
+				"fields": map[string]interface{}{
+					"integer": []interface{}{"a", "b"},
+				},
+			},
+		},
+	}
+	for _, c := range cases {
+		err := c.output.UnmarshalTOML(c.data)
+		if c.wantErr != nil && (err == nil || err.Error() != c.wantErr.Error()) {
+			t.Fatalf("%s failed want err %s, got %v", c.name, c.wantErr.Error(), err)
+		}
+		if c.wantErr == nil && err != nil {
+			t.Fatalf("%s failed want err nil, got %v", c.name, err)
+		}
+		if !reflect.DeepEqual(c.output, c.want) {
+			t.Fatalf("%s failed want %v, got %v", c.name, c.want, c.output)
+		}
+	}
+}
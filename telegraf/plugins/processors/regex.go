@@ -0,0 +1,95 @@
+package processors
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Regex is based on telegraf regex processor plugin.
+type Regex struct {
+	baseProcessor
+	Tags   []RegexConverter `json:"tags"`
+	Fields []RegexConverter `json:"fields"`
+}
+
+// RegexConverter replaces the value of the tag or field Key matching
+// Pattern with Replacement. If ResultKey is set the result is written to a
+// new tag or field instead.
+type RegexConverter struct {
+	Key         string `json:"key"`
+	Pattern     string `json:"pattern"`
+	Replacement string `json:"replacement"`
+	ResultKey   string `json:"resultKey,omitempty"`
+}
+
+// PluginName is based on telegraf plugin name.
+func (r *Regex) PluginName() string {
+	return "regex"
+}
+
+// TOML encodes to toml string.
+func (r *Regex) TOML() string {
+	s := ""
+	for _, c := range r.Tags {
+		s += c.toml(r.PluginName(), "tags")
+	}
+	for _, c := range r.Fields {
+		s += c.toml(r.PluginName(), "fields")
+	}
+	return fmt.Sprintf(`[[processors.%s]]
+  ## Tag and field values are transformed by the conversion tables below.
+%s`, r.PluginName(), s)
+}
+
+func (c RegexConverter) toml(name, kind string) string {
+	s := fmt.Sprintf(`  [[processors.%s.%s]]
+    key = %s
+    pattern = %s
+    replacement = %s
+`, name, kind, strconv.Quote(c.Key), strconv.Quote(c.Pattern), strconv.Quote(c.Replacement))
+	if c.ResultKey != "" {
+		s += fmt.Sprintf("    result_key = %s\n", strconv.Quote(c.ResultKey))
+	}
+	return s
+}
+
+// UnmarshalTOML decodes the parsed data to the object
+func (r *Regex) UnmarshalTOML(data interface{}) error {
+	dataOK, ok := data.(map[string]interface{})
+	if !ok {
+		return errors.New("bad conversions for regex processor plugin")
+	}
+	var err error
+	if r.Tags, err = decodeRegexConverters(dataOK["tags"]); err != nil {
+		return err
+	}
+	if r.Fields, err = decodeRegexConverters(dataOK["fields"]); err != nil {
+		return err
+	}
+	return nil
+}
+
+func decodeRegexConverters(data interface{}) ([]RegexConverter, error) {
+	if data == nil {
+		return nil, nil
+	}
+	tables, ok := data.([]map[string]interface{})
+	if !ok {
+		return nil, errors.New("conversions are not an array of tables for regex processor plugin")
+	}
+	cs := make([]RegexConverter, 0, len(tables))
+	for _, t := range tables {
+		var c RegexConverter
+		if c.Key, ok = t["key"].(string); !ok {
+			return nil, errors.New("key is missing for regex processor plugin")
+		}
+		if c.Pattern, ok = t["pattern"].(string); !ok {
+			return nil, errors.New("pattern is missing for regex processor plugin")
+		}
+		c.Replacement, _ = t["replacement"].(string)
+		c.ResultKey, _ = t["result_key"].(string)
+		cs = append(cs, c)
+	}
+	return cs, nil
+}
@@ -0,0 +1,71 @@
+package processors
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Rename is based on telegraf rename processor plugin.
+type Rename struct {
+	baseProcessor
+	Replaces []RenameReplacement `json:"replaces"`
+}
+
+// RenameReplacement renames a measurement, tag or field to Dest.
+// Exactly one of Measurement, Tag or Field should be set.
+type RenameReplacement struct {
+	Measurement string `json:"measurement,omitempty"`
+	Tag         string `json:"tag,omitempty"`
+	Field       string `json:"field,omitempty"`
+	Dest        string `json:"dest"`
+}
+
+// PluginName is based on telegraf plugin name.
+func (r *Rename) PluginName() string {
+	return "rename"
+}
+
+// TOML encodes to toml string.
+func (r *Rename) TOML() string {
+	s := ""
+	for _, rp := range r.Replaces {
+		s += fmt.Sprintf("  [[processors.%s.replace]]\n", r.PluginName())
+		switch {
+		case rp.Measurement != "":
+			s += fmt.Sprintf("    measurement = %s\n", strconv.Quote(rp.Measurement))
+		case rp.Tag != "":
+			s += fmt.Sprintf("    tag = %s\n", strconv.Quote(rp.Tag))
+		case rp.Field != "":
+			s += fmt.Sprintf("    field = %s\n", strconv.Quote(rp.Field))
+		}
+		s += fmt.Sprintf("    dest = %s\n", strconv.Quote(rp.Dest))
+	}
+	return fmt.Sprintf(`[[processors.%s]]
+  ## Measurements, tags and fields are renamed by the replace tables below.
+%s`, r.PluginName(), s)
+}
+
+// UnmarshalTOML decodes the parsed data to the object
+func (r *Rename) UnmarshalTOML(data interface{}) error {
+	dataOK, ok := data.(map[string]interface{})
+	if !ok {
+		return errors.New("bad replace for rename processor plugin")
+	}
+	replaces, ok := dataOK["replace"].([]map[string]interface{})
+	if !ok {
+		return errors.New("replace is not an array of tables for rename processor plugin")
+	}
+	for _, rp := range replaces {
+		var rr RenameReplacement
+		rr.Measurement, _ = rp["measurement"].(string)
+		rr.Tag, _ = rp["tag"].(string)
+		rr.Field, _ = rp["field"].(string)
+		rr.Dest, ok = rp["dest"].(string)
+		if !ok {
+			return errors.New("dest is missing for rename processor plugin")
+		}
+		r.Replaces = append(r.Replaces, rr)
+	}
+	return nil
+}
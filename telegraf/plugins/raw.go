@@ -0,0 +1,124 @@
+package plugins
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Raw is a telegraf plugin without a typed config. Its settings are kept as
+// they were parsed from the telegraf toml, so plugins the platform knows
+// nothing about still round trip through a stored telegraf config.
+// Comments in the original toml are not kept.
+type Raw struct {
+	Name       string
+	PluginType Type
+	Config     map[string]interface{}
+}
+
+// PluginName is the telegraf plugin name.
+func (r *Raw) PluginName() string {
+	return r.Name
+}
+
+// Type is the telegraf plugin type.
+func (r *Raw) Type() Type {
+	return r.PluginType
+}
+
+// TOML encodes to toml string. The config is validated when decoded, so an
+// encoding error results in an empty string.
+func (r *Raw) TOML() string {
+	config := r.Config
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+	section := string(r.PluginType) + "s"
+
+	// Encode the config nested under its section so that sub-tables get
+	// their fully qualified names, then drop the section table header the
+	// encoder writes as it would be repeated for every raw plugin.
+	var buf bytes.Buffer
+	enc := toml.NewEncoder(&buf)
+	enc.Indent = "  "
+	if err := enc.Encode(map[string]interface{}{
+		section: map[string]interface{}{
+			r.Name: []map[string]interface{}{config},
+		},
+	}); err != nil {
+		return ""
+	}
+
+	var s string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if line == "" || line == "["+section+"]" {
+			continue
+		}
+		s += strings.TrimPrefix(line, "  ") + "\n"
+	}
+	return s
+}
+
+// UnmarshalTOML decodes the parsed data to the object
+func (r *Raw) UnmarshalTOML(data interface{}) error {
+	config, _ := data.(map[string]interface{})
+	if err := Table(config).Validate(); err != nil {
+		return err
+	}
+	r.Config = config
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r *Raw) MarshalJSON() ([]byte, error) {
+	if r.Config == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(r.Config)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The config must be encodable to toml.
+func (r *Raw) UnmarshalJSON(b []byte) error {
+	var config Table
+	if err := config.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	r.Config = config
+	return nil
+}
+
+// normalizeJSON converts values decoded from json into the types the toml
+// decoder produces, so that integers stay integers and arrays of tables stay
+// arrays of tables when encoded back to toml.
+func normalizeJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = normalizeJSON(e)
+		}
+		return v
+	case []interface{}:
+		tables := make([]map[string]interface{}, 0, len(v))
+		for i, e := range v {
+			v[i] = normalizeJSON(e)
+			if t, ok := v[i].(map[string]interface{}); ok {
+				tables = append(tables, t)
+			}
+		}
+		if len(v) > 0 && len(tables) == len(v) {
+			return tables
+		}
+		return v
+	default:
+		return v
+	}
+}
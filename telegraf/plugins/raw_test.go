@@ -0,0 +1,75 @@
+package plugins
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRawTOML(t *testing.T) {
+	r := &Raw{
+		Name:       "kafka",
+		PluginType: Output,
+		Config: map[string]interface{}{
+			"brokers": []interface{}{"localhost:9092"},
+			"topic":   "telegraf",
+			"sasl": map[string]interface{}{
+				"username": "u",
+			},
+		},
+	}
+	want := `[[outputs.kafka]]
+  brokers = ["localhost:9092"]
+  topic = "telegraf"
+  [outputs.kafka.sasl]
+    username = "u"
+`
+	if got := r.TOML(); got != want {
+		t.Fatalf("raw plugin toml is incorrect, want %s, got %s", want, got)
+	}
+}
+
+func TestRawJSON(t *testing.T) {
+	r := &Raw{
+		Name:       "kafka_consumer",
+		PluginType: Input,
+		Config: map[string]interface{}{
+			"max_message_len": int64(1000000),
+			"ratio":           0.5,
+			"topics":          []interface{}{"telegraf"},
+			"tags": []map[string]interface{}{
+				{"dc": "us-west"},
+			},
+		},
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("unexpected error encoding raw plugin: %v", err)
+	}
+	got := &Raw{
+		Name:       r.Name,
+		PluginType: r.PluginType,
+	}
+	if err := json.Unmarshal(b, got); err != nil {
+		t.Fatalf("unexpected error decoding raw plugin: %v", err)
+	}
+	if !reflect.DeepEqual(got, r) {
+		t.Fatalf("raw plugin json round trip is incorrect, want %v, got %v", r, got)
+	}
+}
+
+func TestRawJSONInvalid(t *testing.T) {
+	for _, b := range []string{
+		`{"brokers": null}`,
+		`{"brokers": ["localhost:9092", 9092]}`,
+		`{"sasl": {"username": null}}`,
+	} {
+		r := &Raw{
+			Name:       "kafka",
+			PluginType: Output,
+		}
+		if err := json.Unmarshal([]byte(b), r); err == nil {
+			t.Fatalf("expected an error decoding raw plugin config %s, got %v", b, r.Config)
+		}
+	}
+}
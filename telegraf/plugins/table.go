@@ -0,0 +1,77 @@
+package plugins
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Table is a toml table kept as it was parsed, exp the [global_tags] of a
+// telegraf config.
+type Table map[string]interface{}
+
+// Validate returns an error if the table can't be encoded back to toml, exp
+// for a null value or an array mixing strings and numbers.
+func (t Table) Validate() error {
+	if err := validateNoNull("", t); err != nil {
+		return err
+	}
+	return toml.NewEncoder(ioutil.Discard).Encode(map[string]interface{}(t))
+}
+
+// validateNoNull returns an error for a nil value, which toml can't represent
+// and the encoder would silently drop.
+func validateNoNull(key string, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		return fmt.Errorf("toml: %s has no value", key)
+	case Table:
+		return validateNoNull(key, map[string]interface{}(v))
+	case map[string]interface{}:
+		for k, e := range v {
+			if key != "" {
+				k = key + "." + k
+			}
+			if err := validateNoNull(k, e); err != nil {
+				return err
+			}
+		}
+	case []map[string]interface{}:
+		for _, e := range v {
+			if err := validateNoNull(key, e); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, e := range v {
+			if err := validateNoNull(key, e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Values are
+// converted to the types the toml decoder produces.
+func (t *Table) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var config map[string]interface{}
+	if err := dec.Decode(&config); err != nil {
+		return err
+	}
+	if config == nil {
+		*t = nil
+		return nil
+	}
+	table := Table(normalizeJSON(config).(map[string]interface{}))
+	if err := table.Validate(); err != nil {
+		return err
+	}
+	*t = table
+	return nil
+}
@@ -0,0 +1,66 @@
+package platform
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultTelegrafAgentStaleAfter is how long after its last check-in a
+// telegraf agent is considered stale.
+const DefaultTelegrafAgentStaleAfter = 10 * time.Minute
+
+// TelegrafAgent is a telegraf agent that fetches its config from the platform.
+// An agent is identified by the config it runs and its hostname.
+type TelegrafAgent struct {
+	TelegrafConfigID ID        `json:"telegrafConfigID"`
+	Hostname         string    `json:"hostname"`
+	Version          string    `json:"version,omitempty"`
+	LastSeen         time.Time `json:"lastSeen"`
+}
+
+// Stale returns true if the agent has not checked in within staleAfter of now.
+func (a *TelegrafAgent) Stale(now time.Time, staleAfter time.Duration) bool {
+	return now.Sub(a.LastSeen) > staleAfter
+}
+
+// Valid returns an error if the agent cannot be identified.
+func (a *TelegrafAgent) Valid() error {
+	if !a.TelegrafConfigID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "telegraf config ID is required",
+		}
+	}
+	if a.Hostname == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "telegraf agent hostname is required",
+		}
+	}
+	return nil
+}
+
+// TelegrafAgentFilter represents a set of filters that restrict the returned telegraf agents.
+type TelegrafAgentFilter struct {
+	TelegrafConfigID *ID
+	Hostname         *string
+}
+
+// TelegrafAgentService records the telegraf agents that fetch their configs.
+type TelegrafAgentService interface {
+	// FindTelegrafAgents returns the telegraf agents that match filter.
+	FindTelegrafAgents(ctx context.Context, filter TelegrafAgentFilter) ([]*TelegrafAgent, error)
+
+	// CheckInTelegrafAgent records that an agent fetched its config at
+	// a.LastSeen, adding the agent if it has not checked in before.
+	CheckInTelegrafAgent(ctx context.Context, a *TelegrafAgent) error
+}
+
+// TelegrafAgentAuthorizationService mints the authorizations telegraf agents use.
+type TelegrafAgentAuthorizationService interface {
+	// CreateTelegrafAgentAuthorization creates an authorization for userID
+	// that may only read the telegraf config and write to the buckets of its
+	// influxdb_v2 outputs. The token is set on those outputs and replaces the
+	// authorization previously created for the config.
+	CreateTelegrafAgentAuthorization(ctx context.Context, id ID, userID ID) (*Authorization, error)
+}
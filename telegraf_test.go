@@ -4,12 +4,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/BurntSushi/toml"
 
 	"github.com/influxdata/platform/telegraf/plugins"
+	"github.com/influxdata/platform/telegraf/plugins/aggregators"
 	"github.com/influxdata/platform/telegraf/plugins/outputs"
+	"github.com/influxdata/platform/telegraf/plugins/processors"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/platform/telegraf/plugins/inputs"
@@ -28,33 +31,13 @@ func (u *unsupportedPluginType) PluginName() string {
 }
 
 func (u *unsupportedPluginType) Type() plugins.Type {
-	return plugins.Aggregator
+	return plugins.Type("bad_type")
 }
 
 func (u *unsupportedPluginType) UnmarshalTOML(data interface{}) error {
 	return nil
 }
 
-type unsupportedPlugin struct {
-	Field string `json:"field"`
-}
-
-func (u *unsupportedPlugin) TOML() string {
-	return ""
-}
-
-func (u *unsupportedPlugin) PluginName() string {
-	return "kafka"
-}
-
-func (u *unsupportedPlugin) Type() plugins.Type {
-	return plugins.Output
-}
-
-func (u *unsupportedPlugin) UnmarshalTOML(data interface{}) error {
-	return nil
-}
-
 func TestTelegrafConfigJSON(t *testing.T) {
 	id1, _ := IDFromString("020f755c3c082000")
 	id2, _ := IDFromString("020f755c3c082002")
@@ -96,47 +79,82 @@ func TestTelegrafConfigJSON(t *testing.T) {
 							Token: "tok1",
 						},
 					},
+					{
+						Config: &processors.Rename{
+							Replaces: []processors.RenameReplacement{
+								{Tag: "hostname", Dest: "host"},
+							},
+						},
+					},
+					{
+						Config: &aggregators.BasicStats{
+							Stats: []string{"min", "max"},
+						},
+					},
 				},
 			},
 		},
 		{
-			name: "unsupported plugin type",
+			name: "plugin without a typed config",
 			cfg: &TelegrafConfig{
 				ID:        *id1,
 				Name:      "n1",
 				LastModBy: *id2,
 				Plugins: []TelegrafPlugin{
 					{
-						Comment: "comment3",
-						Config: &unsupportedPluginType{
-							Field: "f1",
+						Comment: "comment5",
+						Config: &plugins.Raw{
+							Name:       "kafka",
+							PluginType: plugins.Output,
+							Config: map[string]interface{}{
+								"brokers":         []interface{}{"localhost:9092"},
+								"max_message_len": int64(1000000),
+								"sasl": map[string]interface{}{
+									"username": "u",
+								},
+							},
 						},
 					},
 				},
 			},
-			err: &Error{
-				Code: EInvalid,
-				Msg:  fmt.Sprintf(ErrUnsupportTelegrafPluginType, "aggregator"),
-				Op:   "unmarshal telegraf config raw plugin",
+		},
+		{
+			name: "global tags and agent settings",
+			cfg: &TelegrafConfig{
+				ID:        *id1,
+				Name:      "n1",
+				LastModBy: *id2,
+				Agent: TelegrafAgentConfig{
+					Interval: 4000,
+					Settings: plugins.Table{
+						"hostname":            "h1",
+						"metric_buffer_limit": int64(50000),
+					},
+				},
+				Plugins: []TelegrafPlugin{},
+				GlobalTags: plugins.Table{
+					"dc": "us-west",
+				},
 			},
 		},
 		{
-			name: "unsupported plugin",
+			name: "unsupported plugin type",
 			cfg: &TelegrafConfig{
 				ID:        *id1,
 				Name:      "n1",
 				LastModBy: *id2,
 				Plugins: []TelegrafPlugin{
 					{
-						Config: &unsupportedPlugin{
-							Field: "f2",
+						Comment: "comment3",
+						Config: &unsupportedPluginType{
+							Field: "f1",
 						},
 					},
 				},
 			},
 			err: &Error{
 				Code: EInvalid,
-				Msg:  fmt.Sprintf(ErrUnsupportTelegrafPluginName, "kafka", plugins.Output),
+				Msg:  fmt.Sprintf(ErrUnsupportTelegrafPluginType, "bad_type"),
 				Op:   "unmarshal telegraf config raw plugin",
 			},
 		},
@@ -311,3 +329,204 @@ func TestTOML(t *testing.T) {
 		t.Fatalf("telegraf toml parsing issue, want %q, got %q", tc, tcr)
 	}
 }
+
+func TestTOMLImport(t *testing.T) {
+	conf := `# Global tags can be specified here in key="value" format.
+[global_tags]
+
+[agent]
+  interval = "10s"
+  metric_batch_size = 5000
+  flush_interval = "30s"
+  precision = "1s"
+
+[[inputs.cpu]]
+  percpu = true
+
+[[inputs.kafka_consumer]]
+  brokers = ["localhost:9092"]
+  topics = ["telegraf"]
+  max_message_len = 1000000
+  [inputs.kafka_consumer.tags]
+    dc = "us-west"
+
+[[processors.rename]]
+  [[processors.rename.replace]]
+    tag = "hostname"
+    dest = "host"
+
+[[aggregators.minmax]]
+  period = "30s"
+  drop_original = true
+
+[[outputs.influxdb_v2]]
+  urls = ["http://127.0.0.1:9999"]
+  token = "tok1"
+  organization = "org1"
+  bucket = "bucket1"
+`
+	want := &TelegrafConfig{
+		Agent: TelegrafAgentConfig{
+			Interval:        10000,
+			FlushInterval:   30000,
+			MetricBatchSize: 5000,
+			Precision:       "1s",
+		},
+		Plugins: []TelegrafPlugin{
+			{Config: &inputs.CPUStats{}},
+			{
+				Config: &plugins.Raw{
+					Name:       "kafka_consumer",
+					PluginType: plugins.Input,
+					Config: map[string]interface{}{
+						"brokers":         []interface{}{"localhost:9092"},
+						"topics":          []interface{}{"telegraf"},
+						"max_message_len": int64(1000000),
+						"tags": map[string]interface{}{
+							"dc": "us-west",
+						},
+					},
+				},
+			},
+			{
+				Config: &processors.Rename{
+					Replaces: []processors.RenameReplacement{
+						{Tag: "hostname", Dest: "host"},
+					},
+				},
+			},
+			{
+				Config: &aggregators.MinMax{},
+			},
+			{
+				Config: &outputs.InfluxDBV2{
+					URLs:         []string{"http://127.0.0.1:9999"},
+					Token:        "tok1",
+					Organization: "org1",
+					Bucket:       "bucket1",
+				},
+			},
+		},
+	}
+	minmax := want.Plugins[3].Config.(*aggregators.MinMax)
+	minmax.Period = 30000
+	minmax.DropOriginal = true
+
+	got := new(TelegrafConfig)
+	if _, err := toml.Decode(conf, got); err != nil {
+		t.Fatalf("unexpected error decoding telegraf toml: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("telegraf toml import is incorrect, want %+v, got %+v", want, got)
+	}
+
+	// The config rendered from the import must import to the same config.
+	again := new(TelegrafConfig)
+	if _, err := toml.Decode(got.TOML(), again); err != nil {
+		t.Fatalf("unexpected error decoding rendered telegraf toml: %v\n%s", err, got.TOML())
+	}
+	if !reflect.DeepEqual(again, want) {
+		t.Fatalf("telegraf toml round trip is incorrect, want %+v, got %+v", want, again)
+	}
+}
+
+func TestTOMLDecodeOrder(t *testing.T) {
+	conf := `[agent]
+  interval = "10s"
+
+[[outputs.file]]
+  files = ["stdout"]
+
+[[inputs.mem]]
+
+[[inputs.cpu]]
+  percpu = true
+
+[[inputs.mem]]
+
+[[inputs.disk]]
+`
+	got := new(TelegrafConfig)
+	if err := got.DecodeTOML(strings.NewReader(conf)); err != nil {
+		t.Fatalf("unexpected error decoding telegraf toml: %v", err)
+	}
+	var names []string
+	for _, p := range got.Plugins {
+		names = append(names, fmt.Sprintf("%s.%s", p.Config.Type(), p.Config.PluginName()))
+	}
+	want := []string{"output.file", "input.mem", "input.cpu", "input.mem", "input.disk"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("telegraf plugins are not in the order of the file, want %v, got %v", want, names)
+	}
+}
+
+func TestTOMLImportGlobalTagsAndAgentSettings(t *testing.T) {
+	conf := `[global_tags]
+  dc = "us-west"
+
+[agent]
+  interval = "10s"
+  metric_batch_size = 5000
+  flush_interval = "30s"
+  precision = "1s"
+  hostname = "h1"
+  metric_buffer_limit = 50000
+  utc = true
+
+[[inputs.cpu]]
+`
+	want := &TelegrafConfig{
+		Agent: TelegrafAgentConfig{
+			Interval:        10000,
+			FlushInterval:   30000,
+			MetricBatchSize: 5000,
+			Precision:       "1s",
+			Settings: plugins.Table{
+				"hostname":            "h1",
+				"metric_buffer_limit": int64(50000),
+				"utc":                 true,
+			},
+		},
+		Plugins: []TelegrafPlugin{
+			{Config: &inputs.CPUStats{}},
+		},
+		GlobalTags: plugins.Table{
+			"dc": "us-west",
+		},
+	}
+
+	got := new(TelegrafConfig)
+	if err := got.DecodeTOML(strings.NewReader(conf)); err != nil {
+		t.Fatalf("unexpected error decoding telegraf toml: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("telegraf toml import is incorrect, want %+v, got %+v", want, got)
+	}
+
+	rendered := got.TOML()
+	for _, s := range []string{"[global_tags]\n  dc = \"us-west\"\n", `  hostname = "h1"`, "  metric_buffer_limit = 50000\n", "  utc = true\n"} {
+		if !strings.Contains(rendered, s) {
+			t.Fatalf("rendered telegraf toml is missing %q:\n%s", s, rendered)
+		}
+	}
+	again := new(TelegrafConfig)
+	if err := again.DecodeTOML(strings.NewReader(rendered)); err != nil {
+		t.Fatalf("unexpected error decoding rendered telegraf toml: %v\n%s", err, rendered)
+	}
+	if !reflect.DeepEqual(again, want) {
+		t.Fatalf("telegraf toml round trip is incorrect, want %+v, got %+v", want, again)
+	}
+}
+
+func TestTOMLImportUnsupportedTable(t *testing.T) {
+	conf := `[agent]
+  interval = "10s"
+
+[unknown]
+  dc = "us-west"
+`
+	err := toml.Unmarshal([]byte(conf), new(TelegrafConfig))
+	if ErrorCode(err) != EInvalid {
+		t.Fatalf("expected invalid error, got %v", err)
+	}
+}
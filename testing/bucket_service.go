@@ -37,6 +37,8 @@ type BucketFields struct {
 	IDGenerator   platform.IDGenerator
 	Buckets       []*platform.Bucket
 	Organizations []*platform.Organization
+	Labels        []*platform.Label
+	LabelMappings []*platform.LabelMapping
 }
 
 type bucketServiceF func(
@@ -468,6 +470,7 @@ func FindBuckets(
 		name           string
 		organization   string
 		organizationID platform.ID
+		label          string
 	}
 
 	type wants struct {
@@ -480,6 +483,115 @@ func FindBuckets(
 		args   args
 		wants  wants
 	}{
+		{
+			name: "find buckets by label",
+			fields: BucketFields{
+				Organizations: []*platform.Organization{
+					{
+						Name: "theorg",
+						ID:   MustIDBase16(orgOneID),
+					},
+				},
+				Buckets: []*platform.Bucket{
+					{
+						ID:             MustIDBase16(bucketOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "abc",
+					},
+					{
+						ID:             MustIDBase16(bucketTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "xyz",
+					},
+				},
+				Labels: []*platform.Label{
+					{
+						ID:             MustIDBase16(labelOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "prod",
+					},
+				},
+				LabelMappings: []*platform.LabelMapping{
+					{
+						LabelID:      MustIDBase16(labelOneID),
+						ResourceID:   MustIDBase16(bucketTwoID),
+						ResourceType: platform.BucketResourceType,
+					},
+				},
+			},
+			args: args{
+				label: "prod",
+			},
+			wants: wants{
+				buckets: []*platform.Bucket{
+					{
+						ID:             MustIDBase16(bucketTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Organization:   "theorg",
+						Name:           "xyz",
+					},
+				},
+			},
+		},
+		{
+			name: "find buckets by label of their organization",
+			fields: BucketFields{
+				Organizations: []*platform.Organization{
+					{
+						Name: "theorg",
+						ID:   MustIDBase16(orgOneID),
+					},
+					{
+						Name: "otherorg",
+						ID:   MustIDBase16(orgTwoID),
+					},
+				},
+				Buckets: []*platform.Bucket{
+					{
+						ID:             MustIDBase16(bucketOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "abc",
+					},
+					{
+						ID:             MustIDBase16(bucketTwoID),
+						OrganizationID: MustIDBase16(orgTwoID),
+						Name:           "xyz",
+					},
+				},
+				Labels: []*platform.Label{
+					{
+						ID:             MustIDBase16(labelOneID),
+						OrganizationID: MustIDBase16(orgTwoID),
+						Name:           "prod",
+					},
+				},
+				LabelMappings: []*platform.LabelMapping{
+					{
+						LabelID:      MustIDBase16(labelOneID),
+						ResourceID:   MustIDBase16(bucketOneID),
+						ResourceType: platform.BucketResourceType,
+					},
+					{
+						LabelID:      MustIDBase16(labelOneID),
+						ResourceID:   MustIDBase16(bucketTwoID),
+						ResourceType: platform.BucketResourceType,
+					},
+				},
+			},
+			args: args{
+				label: "prod",
+			},
+			wants: wants{
+				buckets: []*platform.Bucket{
+					{
+						ID:             MustIDBase16(bucketTwoID),
+						OrganizationID: MustIDBase16(orgTwoID),
+						Organization:   "otherorg",
+						Name:           "xyz",
+					},
+				},
+			},
+		},
 		{
 			name: "find all buckets",
 			fields: BucketFields{
@@ -735,6 +847,9 @@ func FindBuckets(
 			if tt.args.name != "" {
 				filter.Name = &tt.args.name
 			}
+			if tt.args.label != "" {
+				filter.Label = &tt.args.label
+			}
 
 			buckets, _, err := s.FindBuckets(ctx, filter)
 			diffPlatformErrors(tt.name, err, tt.wants.err, opPrefix, t)
@@ -0,0 +1,405 @@
+package testing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+)
+
+// CheckFields includes prepopulated data for check tests.
+type CheckFields struct {
+	IDGenerator platform.IDGenerator
+	Checks      []*platform.Check
+}
+
+// CheckService tests all the service functions.
+func CheckService(
+	init func(CheckFields, *testing.T) (platform.CheckService, func()), t *testing.T,
+) {
+	tests := []struct {
+		name string
+		fn   func(init func(CheckFields, *testing.T) (platform.CheckService, func()),
+			t *testing.T)
+	}{
+		{
+			name: "CreateCheck",
+			fn:   CreateCheck,
+		},
+		{
+			name: "FindCheckByID",
+			fn:   FindCheckByID,
+		},
+		{
+			name: "FindChecks",
+			fn:   FindChecks,
+		},
+		{
+			name: "UpdateCheck",
+			fn:   UpdateCheck,
+		},
+		{
+			name: "DeleteCheck",
+			fn:   DeleteCheck,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(init, t)
+		})
+	}
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func newTestCheck(id, orgID, taskID string, name string) *platform.Check {
+	return &platform.Check{
+		ID:             MustIDBase16(id),
+		OrganizationID: MustIDBase16(orgID),
+		Name:           name,
+		Type:           platform.ThresholdCheck,
+		Status:         platform.CheckActive,
+		Query:          `from(bucket: "telegraf") |> range(start: -5m) |> filter(fn: (r) => r._field == "usage_idle")`,
+		Every:          "1m",
+		Thresholds: []platform.CheckThreshold{
+			{Level: platform.CritLevel, Below: float64Ptr(10)},
+		},
+		TaskID: MustIDBase16(taskID),
+	}
+}
+
+// CreateCheck testing.
+func CreateCheck(
+	init func(CheckFields, *testing.T) (platform.CheckService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		check *platform.Check
+	}
+	type wants struct {
+		err    error
+		checks []*platform.Check
+	}
+
+	created := newTestCheck(oneID, twoID, threeID, "cpu idle")
+	created.Status = ""
+	want := newTestCheck(oneID, twoID, threeID, "cpu idle")
+
+	invalid := newTestCheck(oneID, twoID, threeID, "cpu idle")
+	invalid.Thresholds = nil
+
+	tests := []struct {
+		name   string
+		fields CheckFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "create a check that defaults to active",
+			fields: CheckFields{
+				IDGenerator: mock.NewIDGenerator(oneID, t),
+			},
+			args: args{
+				check: created,
+			},
+			wants: wants{
+				checks: []*platform.Check{want},
+			},
+		},
+		{
+			name: "threshold check without thresholds",
+			fields: CheckFields{
+				IDGenerator: mock.NewIDGenerator(oneID, t),
+			},
+			args: args{
+				check: invalid,
+			},
+			wants: wants{
+				err:    &platform.Error{Code: platform.EInvalid},
+				checks: []*platform.Check{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.CreateCheck(ctx, tt.args.check)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error code '%s' got '%s'", platform.ErrorCode(tt.wants.err), platform.ErrorCode(err))
+			}
+
+			checks, err := s.FindChecks(ctx, platform.CheckFilter{})
+			if err != nil {
+				t.Fatalf("failed to retrieve checks: %v", err)
+			}
+			if diff := cmp.Diff(checks, tt.wants.checks); diff != "" {
+				t.Errorf("checks are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindCheckByID testing.
+func FindCheckByID(
+	init func(CheckFields, *testing.T) (platform.CheckService, func()),
+	t *testing.T,
+) {
+	check := newTestCheck(oneID, twoID, threeID, "cpu idle")
+
+	type wants struct {
+		err   error
+		check *platform.Check
+	}
+
+	tests := []struct {
+		name   string
+		fields CheckFields
+		id     platform.ID
+		wants  wants
+	}{
+		{
+			name:   "find a check",
+			fields: CheckFields{Checks: []*platform.Check{check}},
+			id:     MustIDBase16(oneID),
+			wants: wants{
+				check: check,
+			},
+		},
+		{
+			name:   "check not found",
+			fields: CheckFields{Checks: []*platform.Check{check}},
+			id:     MustIDBase16(fourID),
+			wants: wants{
+				err: &platform.Error{Code: platform.ENotFound},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			c, err := s.FindCheckByID(ctx, tt.id)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error code '%s' got '%s'", platform.ErrorCode(tt.wants.err), platform.ErrorCode(err))
+			}
+			if diff := cmp.Diff(c, tt.wants.check); diff != "" {
+				t.Errorf("checks are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindChecks testing.
+func FindChecks(
+	init func(CheckFields, *testing.T) (platform.CheckService, func()),
+	t *testing.T,
+) {
+	checks := []*platform.Check{
+		newTestCheck(oneID, oneID, threeID, "a"),
+		newTestCheck(twoID, oneID, fourID, "b"),
+		newTestCheck(threeID, twoID, twoID, "c"),
+	}
+
+	tests := []struct {
+		name   string
+		filter platform.CheckFilter
+		want   []*platform.Check
+	}{
+		{
+			name: "find all checks",
+			want: checks,
+		},
+		{
+			name:   "find checks by organization",
+			filter: platform.CheckFilter{OrganizationID: MustIDBase16Ptr(oneID)},
+			want:   checks[:2],
+		},
+		{
+			name:   "find check by task",
+			filter: platform.CheckFilter{TaskID: MustIDBase16Ptr(fourID)},
+			want:   checks[1:2],
+		},
+		{
+			name:   "find checks of an organization without checks",
+			filter: platform.CheckFilter{OrganizationID: MustIDBase16Ptr(fourID)},
+			want:   []*platform.Check{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(CheckFields{Checks: checks}, t)
+			defer done()
+			ctx := context.Background()
+
+			got, err := s.FindChecks(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("failed to retrieve checks: %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("checks are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// UpdateCheck testing.
+func UpdateCheck(
+	init func(CheckFields, *testing.T) (platform.CheckService, func()),
+	t *testing.T,
+) {
+	name := "cpu idle low"
+	status := platform.CheckInactive
+	thresholds := []platform.CheckThreshold{
+		{Level: platform.WarnLevel, Below: float64Ptr(20)},
+		{Level: platform.CritLevel, Below: float64Ptr(5)},
+	}
+	emptyQuery := ""
+
+	updated := newTestCheck(oneID, twoID, threeID, name)
+	updated.Status = status
+	updated.Thresholds = thresholds
+
+	type wants struct {
+		err   error
+		check *platform.Check
+	}
+
+	tests := []struct {
+		name  string
+		id    platform.ID
+		upd   platform.CheckUpdate
+		wants wants
+	}{
+		{
+			name: "update name, status and thresholds",
+			id:   MustIDBase16(oneID),
+			upd: platform.CheckUpdate{
+				Name:       &name,
+				Status:     &status,
+				Thresholds: &thresholds,
+			},
+			wants: wants{
+				check: updated,
+			},
+		},
+		{
+			name: "update to an invalid check",
+			id:   MustIDBase16(oneID),
+			upd: platform.CheckUpdate{
+				Query: &emptyQuery,
+			},
+			wants: wants{
+				err: &platform.Error{Code: platform.EInvalid},
+			},
+		},
+		{
+			name: "update a missing check",
+			id:   MustIDBase16(fourID),
+			upd: platform.CheckUpdate{
+				Name: &name,
+			},
+			wants: wants{
+				err: &platform.Error{Code: platform.ENotFound},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(CheckFields{Checks: []*platform.Check{newTestCheck(oneID, twoID, threeID, "cpu idle")}}, t)
+			defer done()
+			ctx := context.Background()
+
+			c, err := s.UpdateCheck(ctx, tt.id, tt.upd)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error code '%s' got '%s'", platform.ErrorCode(tt.wants.err), platform.ErrorCode(err))
+			}
+			if diff := cmp.Diff(c, tt.wants.check); diff != "" {
+				t.Errorf("checks are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// DeleteCheck testing.
+func DeleteCheck(
+	init func(CheckFields, *testing.T) (platform.CheckService, func()),
+	t *testing.T,
+) {
+	checks := []*platform.Check{
+		newTestCheck(oneID, twoID, threeID, "a"),
+		newTestCheck(twoID, twoID, fourID, "b"),
+	}
+
+	type wants struct {
+		err    error
+		checks []*platform.Check
+	}
+
+	tests := []struct {
+		name  string
+		id    platform.ID
+		wants wants
+	}{
+		{
+			name: "delete a check",
+			id:   MustIDBase16(oneID),
+			wants: wants{
+				checks: checks[1:],
+			},
+		},
+		{
+			name: "delete a missing check",
+			id:   MustIDBase16(fourID),
+			wants: wants{
+				err:    &platform.Error{Code: platform.ENotFound},
+				checks: checks,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(CheckFields{Checks: checks}, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.DeleteCheck(ctx, tt.id)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error code '%s' got '%s'", platform.ErrorCode(tt.wants.err), platform.ErrorCode(err))
+			}
+
+			got, err := s.FindChecks(ctx, platform.CheckFilter{})
+			if err != nil {
+				t.Fatalf("failed to retrieve checks: %v", err)
+			}
+			if diff := cmp.Diff(got, tt.wants.checks); diff != "" {
+				t.Errorf("checks are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
@@ -3,12 +3,18 @@ package testing
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"sort"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+)
+
+const (
+	labelOneID   = "41a9f7288d4e2d64"
+	labelTwoID   = "b7c5355e1134b11c"
+	labelThreeID = "c8d6466f2245c22d"
 )
 
 var labelCmpOptions = cmp.Options{
@@ -18,17 +24,18 @@ var labelCmpOptions = cmp.Options{
 	cmp.Transformer("Sort", func(in []*platform.Label) []*platform.Label {
 		out := append([]*platform.Label(nil), in...) // Copy input to avoid mutating it
 		sort.Slice(out, func(i, j int) bool {
-			if out[i].Name != out[j].Name {
-				return out[i].Name < out[j].Name
-			}
-			return out[i].ResourceID.String() < out[j].ResourceID.String()
+			return out[i].ID.String() < out[j].ID.String()
 		})
 		return out
 	}),
 }
 
+// LabelFields will include the IDGenerator, labels and the mappings of
+// labels to resources.
 type LabelFields struct {
-	Labels []*platform.Label
+	IDGenerator platform.IDGenerator
+	Labels      []*platform.Label
+	Mappings    []*platform.LabelMapping
 }
 
 type labelServiceF func(
@@ -53,10 +60,26 @@ func LabelService(
 			name: "FindLabels",
 			fn:   FindLabels,
 		},
+		{
+			name: "FindLabelByID",
+			fn:   FindLabelByID,
+		},
+		{
+			name: "UpdateLabel",
+			fn:   UpdateLabel,
+		},
 		{
 			name: "DeleteLabel",
 			fn:   DeleteLabel,
 		},
+		{
+			name: "CreateLabelMapping",
+			fn:   CreateLabelMapping,
+		},
+		{
+			name: "DeleteLabelMapping",
+			fn:   DeleteLabelMapping,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -65,6 +88,20 @@ func LabelService(
 	}
 }
 
+func checkLabelError(t *testing.T, err, want error) {
+	t.Helper()
+	if (err != nil) != (want != nil) {
+		t.Fatalf("expected error '%v' got '%v'", want, err)
+	}
+
+	if err != nil && want != nil {
+		if platform.ErrorCode(err) != platform.ErrorCode(want) {
+			t.Fatalf("expected error code to match '%v' got '%v'", platform.ErrorCode(want), platform.ErrorCode(err))
+		}
+	}
+}
+
+// CreateLabel testing
 func CreateLabel(
 	init func(LabelFields, *testing.T) (platform.LabelService, func()),
 	t *testing.T,
@@ -86,28 +123,71 @@ func CreateLabel(
 		{
 			name: "basic create label",
 			fields: LabelFields{
+				IDGenerator: mock.NewIDGenerator(labelTwoID, t),
+				Labels: []*platform.Label{
+					{
+						ID:             MustIDBase16(labelOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "Tag1",
+					},
+				},
+			},
+			args: args{
+				label: &platform.Label{
+					OrganizationID: MustIDBase16(orgOneID),
+					Name:           "Tag2",
+					Properties: map[string]string{
+						"color": "fff000",
+					},
+				},
+			},
+			wants: wants{
+				labels: []*platform.Label{
+					{
+						ID:             MustIDBase16(labelOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "Tag1",
+					},
+					{
+						ID:             MustIDBase16(labelTwoID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "Tag2",
+						Properties: map[string]string{
+							"color": "fff000",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "labels of different organizations may share a name",
+			fields: LabelFields{
+				IDGenerator: mock.NewIDGenerator(labelTwoID, t),
 				Labels: []*platform.Label{
 					{
-						ResourceID: MustIDBase16(bucketOneID),
-						Name:       "Tag1",
+						ID:             MustIDBase16(labelOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "Tag1",
 					},
 				},
 			},
 			args: args{
 				label: &platform.Label{
-					ResourceID: MustIDBase16(bucketOneID),
-					Name:       "Tag2",
+					OrganizationID: MustIDBase16(bucketTwoID),
+					Name:           "Tag1",
 				},
 			},
 			wants: wants{
 				labels: []*platform.Label{
 					{
-						ResourceID: MustIDBase16(bucketOneID),
-						Name:       "Tag1",
+						ID:             MustIDBase16(labelOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "Tag1",
 					},
 					{
-						ResourceID: MustIDBase16(bucketOneID),
-						Name:       "Tag2",
+						ID:             MustIDBase16(labelTwoID),
+						OrganizationID: MustIDBase16(bucketTwoID),
+						Name:           "Tag1",
 					},
 				},
 			},
@@ -115,27 +195,49 @@ func CreateLabel(
 		{
 			name: "duplicate labels fail",
 			fields: LabelFields{
+				IDGenerator: mock.NewIDGenerator(labelTwoID, t),
 				Labels: []*platform.Label{
 					{
-						ResourceID: MustIDBase16(bucketOneID),
-						Name:       "Tag1",
+						ID:             MustIDBase16(labelOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "Tag1",
 					},
 				},
 			},
 			args: args{
 				label: &platform.Label{
-					ResourceID: MustIDBase16(bucketOneID),
-					Name:       "Tag1",
+					OrganizationID: MustIDBase16(orgOneID),
+					Name:           "Tag1",
 				},
 			},
 			wants: wants{
 				labels: []*platform.Label{
 					{
-						ResourceID: MustIDBase16(bucketOneID),
-						Name:       "Tag1",
+						ID:             MustIDBase16(labelOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "Tag1",
 					},
 				},
-				err: fmt.Errorf("label Tag1 already exists"),
+				err: &platform.Error{
+					Code: platform.EConflict,
+				},
+			},
+		},
+		{
+			name: "labels require an organization",
+			fields: LabelFields{
+				IDGenerator: mock.NewIDGenerator(labelTwoID, t),
+			},
+			args: args{
+				label: &platform.Label{
+					Name: "Tag1",
+				},
+			},
+			wants: wants{
+				labels: []*platform.Label{},
+				err: &platform.Error{
+					Code: platform.EInvalid,
+				},
 			},
 		},
 	}
@@ -146,16 +248,7 @@ func CreateLabel(
 			defer done()
 			ctx := context.Background()
 			err := s.CreateLabel(ctx, tt.args.label)
-			if (err != nil) != (tt.wants.err != nil) {
-				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
-			}
-
-			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
-				}
-			}
-			defer s.DeleteLabel(ctx, *tt.args.label)
+			checkLabelError(t, err, tt.wants.err)
 
 			labels, err := s.FindLabels(ctx, platform.LabelFilter{})
 			if err != nil {
@@ -168,6 +261,7 @@ func CreateLabel(
 	}
 }
 
+// FindLabels testing
 func FindLabels(
 	init func(LabelFields, *testing.T) (platform.LabelService, func()),
 	t *testing.T,
@@ -180,6 +274,26 @@ func FindLabels(
 		labels []*platform.Label
 	}
 
+	fields := LabelFields{
+		Labels: []*platform.Label{
+			{
+				ID:             MustIDBase16(labelOneID),
+				OrganizationID: MustIDBase16(orgOneID),
+				Name:           "Tag1",
+			},
+			{
+				ID:             MustIDBase16(labelTwoID),
+				OrganizationID: MustIDBase16(orgOneID),
+				Name:           "Tag2",
+			},
+			{
+				ID:             MustIDBase16(labelThreeID),
+				OrganizationID: MustIDBase16(bucketTwoID),
+				Name:           "Tag1",
+			},
+		},
+	}
+
 	tests := []struct {
 		name   string
 		fields LabelFields
@@ -187,67 +301,51 @@ func FindLabels(
 		wants  wants
 	}{
 		{
-			name: "basic find labels",
-			fields: LabelFields{
-				Labels: []*platform.Label{
-					{
-						ResourceID: MustIDBase16(bucketOneID),
-						Name:       "Tag1",
-					},
-					{
-						ResourceID: MustIDBase16(bucketOneID),
-						Name:       "Tag2",
-					},
-				},
-			},
+			name:   "basic find labels",
+			fields: fields,
 			args: args{
 				filter: platform.LabelFilter{},
 			},
 			wants: wants{
-				labels: []*platform.Label{
-					{
-						ResourceID: MustIDBase16(bucketOneID),
-						Name:       "Tag1",
-					},
-					{
-						ResourceID: MustIDBase16(bucketOneID),
-						Name:       "Tag2",
-					},
-				},
+				labels: fields.Labels,
 			},
 		},
 		{
-			name: "find labels filtering",
-			fields: LabelFields{
-				Labels: []*platform.Label{
-					{
-						ResourceID: MustIDBase16(bucketOneID),
-						Name:       "Tag1",
-					},
-					{
-						ResourceID: MustIDBase16(bucketOneID),
-						Name:       "Tag2",
-					},
-					{
-						ResourceID: MustIDBase16(bucketTwoID),
-						Name:       "Tag1",
-					},
+			name:   "find labels by organization",
+			fields: fields,
+			args: args{
+				filter: platform.LabelFilter{
+					OrganizationID: MustIDBase16Ptr(orgOneID),
 				},
 			},
+			wants: wants{
+				labels: fields.Labels[:2],
+			},
+		},
+		{
+			name:   "find labels by name",
+			fields: fields,
 			args: args{
 				filter: platform.LabelFilter{
-					ResourceID: MustIDBase16(bucketOneID),
-					Name:       "Tag1",
+					Name: "Tag1",
 				},
 			},
 			wants: wants{
-				labels: []*platform.Label{
-					{
-						ResourceID: MustIDBase16(bucketOneID),
-						Name:       "Tag1",
-					},
+				labels: []*platform.Label{fields.Labels[0], fields.Labels[2]},
+			},
+		},
+		{
+			name:   "find labels by organization and name",
+			fields: fields,
+			args: args{
+				filter: platform.LabelFilter{
+					OrganizationID: MustIDBase16Ptr(bucketTwoID),
+					Name:           "Tag1",
 				},
 			},
+			wants: wants{
+				labels: fields.Labels[2:],
+			},
 		},
 	}
 
@@ -255,17 +353,9 @@ func FindLabels(
 		t.Run(tt.name, func(t *testing.T) {
 			s, done := init(tt.fields, t)
 			defer done()
-			ctx := context.TODO()
+			ctx := context.Background()
 			labels, err := s.FindLabels(ctx, tt.args.filter)
-			if (err != nil) != (tt.wants.err != nil) {
-				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
-			}
-
-			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
-				}
-			}
+			checkLabelError(t, err, tt.wants.err)
 
 			if diff := cmp.Diff(labels, tt.wants.labels, labelCmpOptions...); diff != "" {
 				t.Errorf("labels are different -got/+want\ndiff %s", diff)
@@ -274,16 +364,17 @@ func FindLabels(
 	}
 }
 
-func DeleteLabel(
+// FindLabelByID testing
+func FindLabelByID(
 	init func(LabelFields, *testing.T) (platform.LabelService, func()),
 	t *testing.T,
 ) {
 	type args struct {
-		label platform.Label
+		id platform.ID
 	}
 	type wants struct {
-		err    error
-		labels []*platform.Label
+		err   error
+		label *platform.Label
 	}
 
 	tests := []struct {
@@ -293,58 +384,170 @@ func DeleteLabel(
 		wants  wants
 	}{
 		{
-			name: "basic delete label",
+			name: "find label by id",
 			fields: LabelFields{
 				Labels: []*platform.Label{
 					{
-						ResourceID: MustIDBase16(bucketOneID),
-						Name:       "Tag1",
-					},
-					{
-						ResourceID: MustIDBase16(bucketOneID),
-						Name:       "Tag2",
+						ID:             MustIDBase16(labelOneID),
+						OrganizationID: MustIDBase16(orgOneID),
+						Name:           "Tag1",
 					},
 				},
 			},
 			args: args{
-				label: platform.Label{
-					ResourceID: MustIDBase16(bucketOneID),
-					Name:       "Tag1",
+				id: MustIDBase16(labelOneID),
+			},
+			wants: wants{
+				label: &platform.Label{
+					ID:             MustIDBase16(labelOneID),
+					OrganizationID: MustIDBase16(orgOneID),
+					Name:           "Tag1",
 				},
 			},
+		},
+		{
+			name:   "missing labels are not found",
+			fields: LabelFields{},
+			args: args{
+				id: MustIDBase16(labelOneID),
+			},
 			wants: wants{
-				labels: []*platform.Label{
-					{
-						ResourceID: MustIDBase16(bucketOneID),
-						Name:       "Tag2",
-					},
+				err: &platform.Error{
+					Code: platform.ENotFound,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+			label, err := s.FindLabelByID(ctx, tt.args.id)
+			checkLabelError(t, err, tt.wants.err)
+
+			if diff := cmp.Diff(label, tt.wants.label); diff != "" {
+				t.Errorf("label is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// UpdateLabel testing
+func UpdateLabel(
+	init func(LabelFields, *testing.T) (platform.LabelService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		id     platform.ID
+		update platform.LabelUpdate
+	}
+	type wants struct {
+		err   error
+		label *platform.Label
+	}
+
+	name := "Tag3"
+	duplicate := "Tag2"
+
+	fields := LabelFields{
+		Labels: []*platform.Label{
+			{
+				ID:             MustIDBase16(labelOneID),
+				OrganizationID: MustIDBase16(orgOneID),
+				Name:           "Tag1",
+				Properties: map[string]string{
+					"color":       "fff000",
+					"description": "the first tag",
 				},
 			},
+			{
+				ID:             MustIDBase16(labelTwoID),
+				OrganizationID: MustIDBase16(orgOneID),
+				Name:           "Tag2",
+			},
 		},
+	}
+
+	tests := []struct {
+		name   string
+		fields LabelFields
+		args   args
+		wants  wants
+	}{
 		{
-			name: "deleting a non-existant label",
-			fields: LabelFields{
-				Labels: []*platform.Label{
-					{
-						ResourceID: MustIDBase16(bucketOneID),
-						Name:       "Tag1",
+			name:   "rename a label",
+			fields: fields,
+			args: args{
+				id: MustIDBase16(labelOneID),
+				update: platform.LabelUpdate{
+					Name: &name,
+				},
+			},
+			wants: wants{
+				label: &platform.Label{
+					ID:             MustIDBase16(labelOneID),
+					OrganizationID: MustIDBase16(orgOneID),
+					Name:           "Tag3",
+					Properties: map[string]string{
+						"color":       "fff000",
+						"description": "the first tag",
 					},
 				},
 			},
+		},
+		{
+			name:   "update and remove properties",
+			fields: fields,
 			args: args{
-				label: platform.Label{
-					ResourceID: MustIDBase16(bucketOneID),
-					Name:       "Tag2",
+				id: MustIDBase16(labelOneID),
+				update: platform.LabelUpdate{
+					Properties: map[string]string{
+						"color":       "000fff",
+						"description": "",
+					},
 				},
 			},
 			wants: wants{
-				labels: []*platform.Label{
-					{
-						ResourceID: MustIDBase16(bucketOneID),
-						Name:       "Tag1",
+				label: &platform.Label{
+					ID:             MustIDBase16(labelOneID),
+					OrganizationID: MustIDBase16(orgOneID),
+					Name:           "Tag1",
+					Properties: map[string]string{
+						"color": "000fff",
 					},
 				},
-				err: platform.ErrLabelNotFound,
+			},
+		},
+		{
+			name:   "names remain unique within an organization",
+			fields: fields,
+			args: args{
+				id: MustIDBase16(labelOneID),
+				update: platform.LabelUpdate{
+					Name: &duplicate,
+				},
+			},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.EConflict,
+				},
+			},
+		},
+		{
+			name:   "missing labels are not found",
+			fields: fields,
+			args: args{
+				id: MustIDBase16(labelThreeID),
+				update: platform.LabelUpdate{
+					Name: &name,
+				},
+			},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.ENotFound,
+				},
 			},
 		},
 	}
@@ -353,17 +556,98 @@ func DeleteLabel(
 		t.Run(tt.name, func(t *testing.T) {
 			s, done := init(tt.fields, t)
 			defer done()
-			ctx := context.TODO()
-			err := s.DeleteLabel(ctx, tt.args.label)
-			if (err != nil) != (tt.wants.err != nil) {
-				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
-			}
+			ctx := context.Background()
+			label, err := s.UpdateLabel(ctx, tt.args.id, tt.args.update)
+			checkLabelError(t, err, tt.wants.err)
 
-			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
-				}
+			if diff := cmp.Diff(label, tt.wants.label); diff != "" {
+				t.Errorf("label is different -got/+want\ndiff %s", diff)
 			}
+		})
+	}
+}
+
+// DeleteLabel testing
+func DeleteLabel(
+	init func(LabelFields, *testing.T) (platform.LabelService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		id platform.ID
+	}
+	type wants struct {
+		err            error
+		labels         []*platform.Label
+		resourceLabels []*platform.Label
+	}
+
+	fields := LabelFields{
+		Labels: []*platform.Label{
+			{
+				ID:             MustIDBase16(labelOneID),
+				OrganizationID: MustIDBase16(orgOneID),
+				Name:           "Tag1",
+			},
+			{
+				ID:             MustIDBase16(labelTwoID),
+				OrganizationID: MustIDBase16(orgOneID),
+				Name:           "Tag2",
+			},
+		},
+		Mappings: []*platform.LabelMapping{
+			{
+				LabelID:      MustIDBase16(labelOneID),
+				ResourceID:   MustIDBase16(bucketOneID),
+				ResourceType: platform.BucketResourceType,
+			},
+			{
+				LabelID:      MustIDBase16(labelTwoID),
+				ResourceID:   MustIDBase16(bucketOneID),
+				ResourceType: platform.BucketResourceType,
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields LabelFields
+		args   args
+		wants  wants
+	}{
+		{
+			name:   "deleting a label removes it from its resources",
+			fields: fields,
+			args: args{
+				id: MustIDBase16(labelOneID),
+			},
+			wants: wants{
+				labels:         fields.Labels[1:],
+				resourceLabels: fields.Labels[1:],
+			},
+		},
+		{
+			name:   "deleting a non-existent label",
+			fields: fields,
+			args: args{
+				id: MustIDBase16(labelThreeID),
+			},
+			wants: wants{
+				labels:         fields.Labels,
+				resourceLabels: fields.Labels,
+				err: &platform.Error{
+					Code: platform.ENotFound,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+			err := s.DeleteLabel(ctx, tt.args.id)
+			checkLabelError(t, err, tt.wants.err)
 
 			labels, err := s.FindLabels(ctx, platform.LabelFilter{})
 			if err != nil {
@@ -372,6 +656,231 @@ func DeleteLabel(
 			if diff := cmp.Diff(labels, tt.wants.labels, labelCmpOptions...); diff != "" {
 				t.Errorf("labels are different -got/+want\ndiff %s", diff)
 			}
+
+			labels, err = s.FindResourceLabels(ctx, platform.LabelMappingFilter{
+				ResourceID:   MustIDBase16(bucketOneID),
+				ResourceType: platform.BucketResourceType,
+			})
+			if err != nil {
+				t.Fatalf("failed to retrieve resource labels: %v", err)
+			}
+			if diff := cmp.Diff(labels, tt.wants.resourceLabels, labelCmpOptions...); diff != "" {
+				t.Errorf("resource labels are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// CreateLabelMapping testing
+func CreateLabelMapping(
+	init func(LabelFields, *testing.T) (platform.LabelService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		mapping *platform.LabelMapping
+	}
+	type wants struct {
+		err    error
+		labels []*platform.Label
+	}
+
+	fields := LabelFields{
+		Labels: []*platform.Label{
+			{
+				ID:             MustIDBase16(labelOneID),
+				OrganizationID: MustIDBase16(orgOneID),
+				Name:           "Tag1",
+			},
+			{
+				ID:             MustIDBase16(labelTwoID),
+				OrganizationID: MustIDBase16(orgOneID),
+				Name:           "Tag2",
+			},
+		},
+		Mappings: []*platform.LabelMapping{
+			{
+				LabelID:      MustIDBase16(labelOneID),
+				ResourceID:   MustIDBase16(bucketOneID),
+				ResourceType: platform.BucketResourceType,
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields LabelFields
+		args   args
+		wants  wants
+	}{
+		{
+			name:   "apply a label to a resource",
+			fields: fields,
+			args: args{
+				mapping: &platform.LabelMapping{
+					LabelID:      MustIDBase16(labelTwoID),
+					ResourceID:   MustIDBase16(bucketOneID),
+					ResourceType: platform.BucketResourceType,
+				},
+			},
+			wants: wants{
+				labels: fields.Labels,
+			},
+		},
+		{
+			name:   "a label is applied to a resource once",
+			fields: fields,
+			args: args{
+				mapping: &platform.LabelMapping{
+					LabelID:      MustIDBase16(labelOneID),
+					ResourceID:   MustIDBase16(bucketOneID),
+					ResourceType: platform.BucketResourceType,
+				},
+			},
+			wants: wants{
+				labels: fields.Labels[:1],
+				err: &platform.Error{
+					Code: platform.EConflict,
+				},
+			},
+		},
+		{
+			name:   "missing labels cannot be applied",
+			fields: fields,
+			args: args{
+				mapping: &platform.LabelMapping{
+					LabelID:      MustIDBase16(labelThreeID),
+					ResourceID:   MustIDBase16(bucketOneID),
+					ResourceType: platform.BucketResourceType,
+				},
+			},
+			wants: wants{
+				labels: fields.Labels[:1],
+				err: &platform.Error{
+					Code: platform.ENotFound,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+			err := s.CreateLabelMapping(ctx, tt.args.mapping)
+			checkLabelError(t, err, tt.wants.err)
+
+			labels, err := s.FindResourceLabels(ctx, platform.LabelMappingFilter{
+				ResourceID:   MustIDBase16(bucketOneID),
+				ResourceType: platform.BucketResourceType,
+			})
+			if err != nil {
+				t.Fatalf("failed to retrieve resource labels: %v", err)
+			}
+			if diff := cmp.Diff(labels, tt.wants.labels, labelCmpOptions...); diff != "" {
+				t.Errorf("resource labels are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// DeleteLabelMapping testing
+func DeleteLabelMapping(
+	init func(LabelFields, *testing.T) (platform.LabelService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		mapping *platform.LabelMapping
+	}
+	type wants struct {
+		err    error
+		labels []*platform.Label
+	}
+
+	fields := LabelFields{
+		Labels: []*platform.Label{
+			{
+				ID:             MustIDBase16(labelOneID),
+				OrganizationID: MustIDBase16(orgOneID),
+				Name:           "Tag1",
+			},
+			{
+				ID:             MustIDBase16(labelTwoID),
+				OrganizationID: MustIDBase16(orgOneID),
+				Name:           "Tag2",
+			},
+		},
+		Mappings: []*platform.LabelMapping{
+			{
+				LabelID:      MustIDBase16(labelOneID),
+				ResourceID:   MustIDBase16(bucketOneID),
+				ResourceType: platform.BucketResourceType,
+			},
+			{
+				LabelID:      MustIDBase16(labelTwoID),
+				ResourceID:   MustIDBase16(bucketOneID),
+				ResourceType: platform.BucketResourceType,
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields LabelFields
+		args   args
+		wants  wants
+	}{
+		{
+			name:   "remove a label from a resource",
+			fields: fields,
+			args: args{
+				mapping: &platform.LabelMapping{
+					LabelID:      MustIDBase16(labelOneID),
+					ResourceID:   MustIDBase16(bucketOneID),
+					ResourceType: platform.BucketResourceType,
+				},
+			},
+			wants: wants{
+				labels: fields.Labels[1:],
+			},
+		},
+		{
+			name:   "removing a label that is not applied",
+			fields: fields,
+			args: args{
+				mapping: &platform.LabelMapping{
+					LabelID:      MustIDBase16(labelOneID),
+					ResourceID:   MustIDBase16(bucketTwoID),
+					ResourceType: platform.BucketResourceType,
+				},
+			},
+			wants: wants{
+				labels: fields.Labels,
+				err: &platform.Error{
+					Code: platform.ENotFound,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+			err := s.DeleteLabelMapping(ctx, tt.args.mapping)
+			checkLabelError(t, err, tt.wants.err)
+
+			labels, err := s.FindResourceLabels(ctx, platform.LabelMappingFilter{
+				ResourceID:   MustIDBase16(bucketOneID),
+				ResourceType: platform.BucketResourceType,
+			})
+			if err != nil {
+				t.Fatalf("failed to retrieve resource labels: %v", err)
+			}
+			if diff := cmp.Diff(labels, tt.wants.labels, labelCmpOptions...); diff != "" {
+				t.Errorf("resource labels are different -got/+want\ndiff %s", diff)
+			}
 		})
 	}
 }
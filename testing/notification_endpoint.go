@@ -0,0 +1,337 @@
+package testing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+)
+
+// NotificationEndpointFields includes prepopulated data for notification endpoint tests.
+type NotificationEndpointFields struct {
+	IDGenerator           platform.IDGenerator
+	NotificationEndpoints []*platform.NotificationEndpoint
+}
+
+// NotificationEndpointService tests all the service functions.
+func NotificationEndpointService(
+	init func(NotificationEndpointFields, *testing.T) (platform.NotificationEndpointService, func()), t *testing.T,
+) {
+	tests := []struct {
+		name string
+		fn   func(init func(NotificationEndpointFields, *testing.T) (platform.NotificationEndpointService, func()),
+			t *testing.T)
+	}{
+		{
+			name: "CreateNotificationEndpoint",
+			fn:   CreateNotificationEndpoint,
+		},
+		{
+			name: "FindNotificationEndpoints",
+			fn:   FindNotificationEndpoints,
+		},
+		{
+			name: "UpdateNotificationEndpoint",
+			fn:   UpdateNotificationEndpoint,
+		},
+		{
+			name: "DeleteNotificationEndpoint",
+			fn:   DeleteNotificationEndpoint,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(init, t)
+		})
+	}
+}
+
+func newTestNotificationEndpoints() []*platform.NotificationEndpoint {
+	return []*platform.NotificationEndpoint{
+		{
+			ID:             MustIDBase16(oneID),
+			OrganizationID: MustIDBase16(oneID),
+			Name:           "ops webhook",
+			Type:           platform.HTTPEndpoint,
+			URL:            "http://example.com/hook",
+			Headers:        map[string]string{"X-Team": "ops"},
+		},
+		{
+			ID:             MustIDBase16(twoID),
+			OrganizationID: MustIDBase16(oneID),
+			Name:           "on call",
+			Type:           platform.PagerDutyEndpoint,
+			Token:          "routing-key",
+		},
+		{
+			ID:             MustIDBase16(threeID),
+			OrganizationID: MustIDBase16(twoID),
+			Name:           "email",
+			Type:           platform.SMTPEndpoint,
+			SMTP: &platform.SMTPConfig{
+				Host: "localhost",
+				Port: 25,
+				From: "alerts@example.com",
+				To:   []string{"ops@example.com"},
+			},
+		},
+	}
+}
+
+// CreateNotificationEndpoint testing.
+func CreateNotificationEndpoint(
+	init func(NotificationEndpointFields, *testing.T) (platform.NotificationEndpointService, func()),
+	t *testing.T,
+) {
+	type wants struct {
+		err       error
+		endpoints []*platform.NotificationEndpoint
+	}
+
+	tests := []struct {
+		name     string
+		endpoint *platform.NotificationEndpoint
+		wants    wants
+	}{
+		{
+			name: "create a slack endpoint",
+			endpoint: &platform.NotificationEndpoint{
+				OrganizationID: MustIDBase16(twoID),
+				Name:           "alerts channel",
+				Type:           platform.SlackEndpoint,
+				URL:            "https://hooks.slack.com/services/T0/B0/X",
+			},
+			wants: wants{
+				endpoints: []*platform.NotificationEndpoint{
+					{
+						ID:             MustIDBase16(oneID),
+						OrganizationID: MustIDBase16(twoID),
+						Name:           "alerts channel",
+						Type:           platform.SlackEndpoint,
+						URL:            "https://hooks.slack.com/services/T0/B0/X",
+					},
+				},
+			},
+		},
+		{
+			name: "smtp endpoint without recipients",
+			endpoint: &platform.NotificationEndpoint{
+				OrganizationID: MustIDBase16(twoID),
+				Name:           "email",
+				Type:           platform.SMTPEndpoint,
+				SMTP: &platform.SMTPConfig{
+					Host: "localhost",
+					Port: 25,
+					From: "alerts@example.com",
+				},
+			},
+			wants: wants{
+				err:       &platform.Error{Code: platform.EInvalid},
+				endpoints: []*platform.NotificationEndpoint{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(NotificationEndpointFields{IDGenerator: mock.NewIDGenerator(oneID, t)}, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.CreateNotificationEndpoint(ctx, tt.endpoint)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error code '%s' got '%s'", platform.ErrorCode(tt.wants.err), platform.ErrorCode(err))
+			}
+
+			endpoints, err := s.FindNotificationEndpoints(ctx, platform.NotificationEndpointFilter{})
+			if err != nil {
+				t.Fatalf("failed to retrieve notification endpoints: %v", err)
+			}
+			if diff := cmp.Diff(endpoints, tt.wants.endpoints); diff != "" {
+				t.Errorf("notification endpoints are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindNotificationEndpoints testing.
+func FindNotificationEndpoints(
+	init func(NotificationEndpointFields, *testing.T) (platform.NotificationEndpointService, func()),
+	t *testing.T,
+) {
+	endpoints := newTestNotificationEndpoints()
+
+	tests := []struct {
+		name   string
+		filter platform.NotificationEndpointFilter
+		want   []*platform.NotificationEndpoint
+	}{
+		{
+			name: "find all notification endpoints",
+			want: endpoints,
+		},
+		{
+			name:   "find notification endpoints by organization",
+			filter: platform.NotificationEndpointFilter{OrganizationID: MustIDBase16Ptr(oneID)},
+			want:   endpoints[:2],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(NotificationEndpointFields{NotificationEndpoints: endpoints}, t)
+			defer done()
+			ctx := context.Background()
+
+			got, err := s.FindNotificationEndpoints(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("failed to retrieve notification endpoints: %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("notification endpoints are different -got/+want\ndiff %s", diff)
+			}
+
+			for _, want := range tt.want {
+				e, err := s.FindNotificationEndpointByID(ctx, want.ID)
+				if err != nil {
+					t.Fatalf("failed to retrieve notification endpoint %s: %v", want.ID, err)
+				}
+				if diff := cmp.Diff(e, want); diff != "" {
+					t.Errorf("notification endpoints are different -got/+want\ndiff %s", diff)
+				}
+			}
+		})
+	}
+}
+
+// UpdateNotificationEndpoint testing.
+func UpdateNotificationEndpoint(
+	init func(NotificationEndpointFields, *testing.T) (platform.NotificationEndpointService, func()),
+	t *testing.T,
+) {
+	url := "http://example.com/other"
+	badURL := "not a url"
+
+	updated := newTestNotificationEndpoints()[0]
+	updated.URL = url
+
+	type wants struct {
+		err      error
+		endpoint *platform.NotificationEndpoint
+	}
+
+	tests := []struct {
+		name  string
+		id    platform.ID
+		upd   platform.NotificationEndpointUpdate
+		wants wants
+	}{
+		{
+			name: "update url",
+			id:   MustIDBase16(oneID),
+			upd:  platform.NotificationEndpointUpdate{URL: &url},
+			wants: wants{
+				endpoint: updated,
+			},
+		},
+		{
+			name: "update to an invalid url",
+			id:   MustIDBase16(oneID),
+			upd:  platform.NotificationEndpointUpdate{URL: &badURL},
+			wants: wants{
+				err: &platform.Error{Code: platform.EInvalid},
+			},
+		},
+		{
+			name: "update a missing notification endpoint",
+			id:   MustIDBase16(fourID),
+			upd:  platform.NotificationEndpointUpdate{URL: &url},
+			wants: wants{
+				err: &platform.Error{Code: platform.ENotFound},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(NotificationEndpointFields{NotificationEndpoints: newTestNotificationEndpoints()}, t)
+			defer done()
+			ctx := context.Background()
+
+			e, err := s.UpdateNotificationEndpoint(ctx, tt.id, tt.upd)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error code '%s' got '%s'", platform.ErrorCode(tt.wants.err), platform.ErrorCode(err))
+			}
+			if diff := cmp.Diff(e, tt.wants.endpoint); diff != "" {
+				t.Errorf("notification endpoints are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// DeleteNotificationEndpoint testing.
+func DeleteNotificationEndpoint(
+	init func(NotificationEndpointFields, *testing.T) (platform.NotificationEndpointService, func()),
+	t *testing.T,
+) {
+	endpoints := newTestNotificationEndpoints()
+
+	type wants struct {
+		err       error
+		endpoints []*platform.NotificationEndpoint
+	}
+
+	tests := []struct {
+		name  string
+		id    platform.ID
+		wants wants
+	}{
+		{
+			name: "delete a notification endpoint",
+			id:   MustIDBase16(twoID),
+			wants: wants{
+				endpoints: []*platform.NotificationEndpoint{endpoints[0], endpoints[2]},
+			},
+		},
+		{
+			name: "delete a missing notification endpoint",
+			id:   MustIDBase16(fourID),
+			wants: wants{
+				err:       &platform.Error{Code: platform.ENotFound},
+				endpoints: endpoints,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(NotificationEndpointFields{NotificationEndpoints: endpoints}, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.DeleteNotificationEndpoint(ctx, tt.id)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error code '%s' got '%s'", platform.ErrorCode(tt.wants.err), platform.ErrorCode(err))
+			}
+
+			got, err := s.FindNotificationEndpoints(ctx, platform.NotificationEndpointFilter{})
+			if err != nil {
+				t.Fatalf("failed to retrieve notification endpoints: %v", err)
+			}
+			if diff := cmp.Diff(got, tt.wants.endpoints); diff != "" {
+				t.Errorf("notification endpoints are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
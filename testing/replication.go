@@ -0,0 +1,346 @@
+package testing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+)
+
+// ReplicationRuleFields includes prepopulated data for replication rule tests.
+type ReplicationRuleFields struct {
+	IDGenerator      platform.IDGenerator
+	ReplicationRules []*platform.ReplicationRule
+}
+
+// ReplicationRuleService tests all the service functions.
+func ReplicationRuleService(
+	init func(ReplicationRuleFields, *testing.T) (platform.ReplicationRuleService, func()), t *testing.T,
+) {
+	tests := []struct {
+		name string
+		fn   func(init func(ReplicationRuleFields, *testing.T) (platform.ReplicationRuleService, func()),
+			t *testing.T)
+	}{
+		{
+			name: "CreateReplicationRule",
+			fn:   CreateReplicationRule,
+		},
+		{
+			name: "FindReplicationRules",
+			fn:   FindReplicationRules,
+		},
+		{
+			name: "UpdateReplicationRule",
+			fn:   UpdateReplicationRule,
+		},
+		{
+			name: "DeleteReplicationRule",
+			fn:   DeleteReplicationRule,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(init, t)
+		})
+	}
+}
+
+func newTestReplicationRules() []*platform.ReplicationRule {
+	return []*platform.ReplicationRule{
+		{
+			ID:             MustIDBase16(oneID),
+			OrganizationID: MustIDBase16(oneID),
+			BucketID:       MustIDBase16(oneID),
+			Name:           "standby",
+			RemoteURL:      "http://standby:9999",
+			RemoteToken:    "token",
+			RemoteOrgID:    MustIDBase16(oneID),
+			RemoteBucketID: MustIDBase16(oneID),
+		},
+		{
+			ID:             MustIDBase16(twoID),
+			OrganizationID: MustIDBase16(oneID),
+			BucketID:       MustIDBase16(twoID),
+			Name:           "archive",
+			RemoteURL:      "https://archive.example.com",
+			RemoteOrgID:    MustIDBase16(threeID),
+			RemoteBucketID: MustIDBase16(fourID),
+		},
+		{
+			ID:             MustIDBase16(threeID),
+			OrganizationID: MustIDBase16(twoID),
+			BucketID:       MustIDBase16(threeID),
+			Name:           "standby",
+			RemoteURL:      "http://standby:9999",
+			RemoteOrgID:    MustIDBase16(twoID),
+			RemoteBucketID: MustIDBase16(threeID),
+		},
+	}
+}
+
+// CreateReplicationRule testing.
+func CreateReplicationRule(
+	init func(ReplicationRuleFields, *testing.T) (platform.ReplicationRuleService, func()),
+	t *testing.T,
+) {
+	type wants struct {
+		err   error
+		rules []*platform.ReplicationRule
+	}
+
+	tests := []struct {
+		name  string
+		rule  *platform.ReplicationRule
+		wants wants
+	}{
+		{
+			name: "create a replication rule",
+			rule: &platform.ReplicationRule{
+				OrganizationID: MustIDBase16(twoID),
+				BucketID:       MustIDBase16(threeID),
+				Name:           "standby",
+				RemoteURL:      "http://standby:9999",
+				RemoteToken:    "token",
+				RemoteOrgID:    MustIDBase16(twoID),
+				RemoteBucketID: MustIDBase16(threeID),
+			},
+			wants: wants{
+				rules: []*platform.ReplicationRule{
+					{
+						ID:             MustIDBase16(oneID),
+						OrganizationID: MustIDBase16(twoID),
+						BucketID:       MustIDBase16(threeID),
+						Name:           "standby",
+						RemoteURL:      "http://standby:9999",
+						RemoteToken:    "token",
+						RemoteOrgID:    MustIDBase16(twoID),
+						RemoteBucketID: MustIDBase16(threeID),
+					},
+				},
+			},
+		},
+		{
+			name: "replication rule without a remote bucket",
+			rule: &platform.ReplicationRule{
+				OrganizationID: MustIDBase16(twoID),
+				BucketID:       MustIDBase16(threeID),
+				Name:           "standby",
+				RemoteURL:      "http://standby:9999",
+				RemoteOrgID:    MustIDBase16(twoID),
+			},
+			wants: wants{
+				err:   &platform.Error{Code: platform.EInvalid},
+				rules: []*platform.ReplicationRule{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(ReplicationRuleFields{IDGenerator: mock.NewIDGenerator(oneID, t)}, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.CreateReplicationRule(ctx, tt.rule)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error code '%s' got '%s'", platform.ErrorCode(tt.wants.err), platform.ErrorCode(err))
+			}
+
+			rules, err := s.FindReplicationRules(ctx, platform.ReplicationRuleFilter{})
+			if err != nil {
+				t.Fatalf("failed to retrieve replication rules: %v", err)
+			}
+			if diff := cmp.Diff(rules, tt.wants.rules); diff != "" {
+				t.Errorf("replication rules are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindReplicationRules testing.
+func FindReplicationRules(
+	init func(ReplicationRuleFields, *testing.T) (platform.ReplicationRuleService, func()),
+	t *testing.T,
+) {
+	rules := newTestReplicationRules()
+
+	tests := []struct {
+		name   string
+		filter platform.ReplicationRuleFilter
+		want   []*platform.ReplicationRule
+	}{
+		{
+			name: "find all replication rules",
+			want: rules,
+		},
+		{
+			name:   "find replication rules by organization",
+			filter: platform.ReplicationRuleFilter{OrganizationID: MustIDBase16Ptr(oneID)},
+			want:   rules[:2],
+		},
+		{
+			name:   "find replication rules by bucket",
+			filter: platform.ReplicationRuleFilter{BucketID: MustIDBase16Ptr(twoID)},
+			want:   rules[1:2],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(ReplicationRuleFields{ReplicationRules: rules}, t)
+			defer done()
+			ctx := context.Background()
+
+			got, err := s.FindReplicationRules(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("failed to retrieve replication rules: %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("replication rules are different -got/+want\ndiff %s", diff)
+			}
+
+			for _, want := range tt.want {
+				e, err := s.FindReplicationRuleByID(ctx, want.ID)
+				if err != nil {
+					t.Fatalf("failed to retrieve replication rule %s: %v", want.ID, err)
+				}
+				if diff := cmp.Diff(e, want); diff != "" {
+					t.Errorf("replication rules are different -got/+want\ndiff %s", diff)
+				}
+			}
+		})
+	}
+}
+
+// UpdateReplicationRule testing.
+func UpdateReplicationRule(
+	init func(ReplicationRuleFields, *testing.T) (platform.ReplicationRuleService, func()),
+	t *testing.T,
+) {
+	url := "http://other:9999"
+	badURL := "not a url"
+
+	updated := newTestReplicationRules()[0]
+	updated.RemoteURL = url
+
+	type wants struct {
+		err  error
+		rule *platform.ReplicationRule
+	}
+
+	tests := []struct {
+		name  string
+		id    platform.ID
+		upd   platform.ReplicationRuleUpdate
+		wants wants
+	}{
+		{
+			name: "update remote url",
+			id:   MustIDBase16(oneID),
+			upd:  platform.ReplicationRuleUpdate{RemoteURL: &url},
+			wants: wants{
+				rule: updated,
+			},
+		},
+		{
+			name: "update to an invalid remote url",
+			id:   MustIDBase16(oneID),
+			upd:  platform.ReplicationRuleUpdate{RemoteURL: &badURL},
+			wants: wants{
+				err: &platform.Error{Code: platform.EInvalid},
+			},
+		},
+		{
+			name: "update a missing replication rule",
+			id:   MustIDBase16(fourID),
+			upd:  platform.ReplicationRuleUpdate{RemoteURL: &url},
+			wants: wants{
+				err: &platform.Error{Code: platform.ENotFound},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(ReplicationRuleFields{ReplicationRules: newTestReplicationRules()}, t)
+			defer done()
+			ctx := context.Background()
+
+			e, err := s.UpdateReplicationRule(ctx, tt.id, tt.upd)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error code '%s' got '%s'", platform.ErrorCode(tt.wants.err), platform.ErrorCode(err))
+			}
+			if diff := cmp.Diff(e, tt.wants.rule); diff != "" {
+				t.Errorf("replication rules are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// DeleteReplicationRule testing.
+func DeleteReplicationRule(
+	init func(ReplicationRuleFields, *testing.T) (platform.ReplicationRuleService, func()),
+	t *testing.T,
+) {
+	rules := newTestReplicationRules()
+
+	type wants struct {
+		err   error
+		rules []*platform.ReplicationRule
+	}
+
+	tests := []struct {
+		name  string
+		id    platform.ID
+		wants wants
+	}{
+		{
+			name: "delete a replication rule",
+			id:   MustIDBase16(twoID),
+			wants: wants{
+				rules: []*platform.ReplicationRule{rules[0], rules[2]},
+			},
+		},
+		{
+			name: "delete a missing replication rule",
+			id:   MustIDBase16(fourID),
+			wants: wants{
+				err:   &platform.Error{Code: platform.ENotFound},
+				rules: rules,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(ReplicationRuleFields{ReplicationRules: rules}, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.DeleteReplicationRule(ctx, tt.id)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error code '%s' got '%s'", platform.ErrorCode(tt.wants.err), platform.ErrorCode(err))
+			}
+
+			got, err := s.FindReplicationRules(ctx, platform.ReplicationRuleFilter{})
+			if err != nil {
+				t.Fatalf("failed to retrieve replication rules: %v", err)
+			}
+			if diff := cmp.Diff(got, tt.wants.rules); diff != "" {
+				t.Errorf("replication rules are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,448 @@
+package testing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+)
+
+// TaskTemplateFields includes prepopulated data for task template tests.
+type TaskTemplateFields struct {
+	IDGenerator   platform.IDGenerator
+	TaskTemplates []*platform.TaskTemplate
+}
+
+// TaskTemplateService tests all the service functions.
+func TaskTemplateService(
+	init func(TaskTemplateFields, *testing.T) (platform.TaskTemplateService, func()), t *testing.T,
+) {
+	tests := []struct {
+		name string
+		fn   func(init func(TaskTemplateFields, *testing.T) (platform.TaskTemplateService, func()),
+			t *testing.T)
+	}{
+		{
+			name: "CreateTaskTemplate",
+			fn:   CreateTaskTemplate,
+		},
+		{
+			name: "FindTaskTemplateByID",
+			fn:   FindTaskTemplateByID,
+		},
+		{
+			name: "FindTaskTemplates",
+			fn:   FindTaskTemplates,
+		},
+		{
+			name: "UpdateTaskTemplate",
+			fn:   UpdateTaskTemplate,
+		},
+		{
+			name: "DeleteTaskTemplate",
+			fn:   DeleteTaskTemplate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(init, t)
+		})
+	}
+}
+
+func newTestTaskTemplate(id, orgID string, name string, taskIDs ...string) *platform.TaskTemplate {
+	t := &platform.TaskTemplate{
+		ID:             MustIDBase16(id),
+		OrganizationID: MustIDBase16(orgID),
+		Name:           name,
+		Flux: `option task = {name: params.host, every: params.every}
+from(bucket: params.bucket) |> range(start: -params.every) |> filter(fn: (r) => r.host == params.host)`,
+		Params: []platform.TaskTemplateParam{
+			{Name: "host", Type: platform.TaskTemplateStringParam},
+			{Name: "every", Type: platform.TaskTemplateDurationParam, Default: "1m"},
+			{Name: "bucket", Type: platform.TaskTemplateBucketParam, Default: "telegraf"},
+		},
+	}
+	for _, id := range taskIDs {
+		t.Instances = append(t.Instances, platform.TaskTemplateInstance{
+			TaskID: MustIDBase16(id),
+			Params: map[string]string{"host": name},
+		})
+	}
+	return t
+}
+
+// CreateTaskTemplate testing.
+func CreateTaskTemplate(
+	init func(TaskTemplateFields, *testing.T) (platform.TaskTemplateService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		template *platform.TaskTemplate
+	}
+	type wants struct {
+		err       error
+		templates []*platform.TaskTemplate
+	}
+
+	duplicate := newTestTaskTemplate(oneID, twoID, "cpu")
+	duplicate.Params = append(duplicate.Params, platform.TaskTemplateParam{Name: "host", Type: platform.TaskTemplateStringParam})
+
+	badDefault := newTestTaskTemplate(oneID, twoID, "cpu")
+	badDefault.Params[1].Default = "one minute"
+
+	badType := newTestTaskTemplate(oneID, twoID, "cpu")
+	badType.Params[0].Type = "int"
+
+	tests := []struct {
+		name   string
+		fields TaskTemplateFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "create a task template",
+			fields: TaskTemplateFields{
+				IDGenerator: mock.NewIDGenerator(oneID, t),
+			},
+			args: args{
+				template: newTestTaskTemplate(oneID, twoID, "cpu"),
+			},
+			wants: wants{
+				templates: []*platform.TaskTemplate{newTestTaskTemplate(oneID, twoID, "cpu")},
+			},
+		},
+		{
+			name: "parameter declared twice",
+			fields: TaskTemplateFields{
+				IDGenerator: mock.NewIDGenerator(oneID, t),
+			},
+			args: args{
+				template: duplicate,
+			},
+			wants: wants{
+				err:       &platform.Error{Code: platform.EInvalid},
+				templates: []*platform.TaskTemplate{},
+			},
+		},
+		{
+			name: "duration parameter with an invalid default",
+			fields: TaskTemplateFields{
+				IDGenerator: mock.NewIDGenerator(oneID, t),
+			},
+			args: args{
+				template: badDefault,
+			},
+			wants: wants{
+				err:       &platform.Error{Code: platform.EInvalid},
+				templates: []*platform.TaskTemplate{},
+			},
+		},
+		{
+			name: "parameter of an unknown type",
+			fields: TaskTemplateFields{
+				IDGenerator: mock.NewIDGenerator(oneID, t),
+			},
+			args: args{
+				template: badType,
+			},
+			wants: wants{
+				err:       &platform.Error{Code: platform.EInvalid},
+				templates: []*platform.TaskTemplate{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.CreateTaskTemplate(ctx, tt.args.template)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error code '%s' got '%s'", platform.ErrorCode(tt.wants.err), platform.ErrorCode(err))
+			}
+
+			templates, err := s.FindTaskTemplates(ctx, platform.TaskTemplateFilter{})
+			if err != nil {
+				t.Fatalf("failed to retrieve task templates: %v", err)
+			}
+			if diff := cmp.Diff(templates, tt.wants.templates); diff != "" {
+				t.Errorf("task templates are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindTaskTemplateByID testing.
+func FindTaskTemplateByID(
+	init func(TaskTemplateFields, *testing.T) (platform.TaskTemplateService, func()),
+	t *testing.T,
+) {
+	template := newTestTaskTemplate(oneID, twoID, "cpu", threeID)
+
+	type wants struct {
+		err      error
+		template *platform.TaskTemplate
+	}
+
+	tests := []struct {
+		name   string
+		fields TaskTemplateFields
+		id     platform.ID
+		wants  wants
+	}{
+		{
+			name:   "find a task template",
+			fields: TaskTemplateFields{TaskTemplates: []*platform.TaskTemplate{template}},
+			id:     MustIDBase16(oneID),
+			wants: wants{
+				template: template,
+			},
+		},
+		{
+			name:   "task template not found",
+			fields: TaskTemplateFields{TaskTemplates: []*platform.TaskTemplate{template}},
+			id:     MustIDBase16(fourID),
+			wants: wants{
+				err: &platform.Error{Code: platform.ENotFound},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			got, err := s.FindTaskTemplateByID(ctx, tt.id)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error code '%s' got '%s'", platform.ErrorCode(tt.wants.err), platform.ErrorCode(err))
+			}
+			if diff := cmp.Diff(got, tt.wants.template); diff != "" {
+				t.Errorf("task templates are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindTaskTemplates testing.
+func FindTaskTemplates(
+	init func(TaskTemplateFields, *testing.T) (platform.TaskTemplateService, func()),
+	t *testing.T,
+) {
+	templates := []*platform.TaskTemplate{
+		newTestTaskTemplate(oneID, oneID, "a", threeID),
+		newTestTaskTemplate(twoID, oneID, "b", fourID),
+		newTestTaskTemplate(threeID, twoID, "c"),
+	}
+
+	tests := []struct {
+		name   string
+		filter platform.TaskTemplateFilter
+		want   []*platform.TaskTemplate
+	}{
+		{
+			name: "find all task templates",
+			want: templates,
+		},
+		{
+			name:   "find task templates by organization",
+			filter: platform.TaskTemplateFilter{OrganizationID: MustIDBase16Ptr(oneID)},
+			want:   templates[:2],
+		},
+		{
+			name:   "find the task template of a task",
+			filter: platform.TaskTemplateFilter{TaskID: MustIDBase16Ptr(fourID)},
+			want:   templates[1:2],
+		},
+		{
+			name:   "find task templates of an organization without templates",
+			filter: platform.TaskTemplateFilter{OrganizationID: MustIDBase16Ptr(fourID)},
+			want:   []*platform.TaskTemplate{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(TaskTemplateFields{TaskTemplates: templates}, t)
+			defer done()
+			ctx := context.Background()
+
+			got, err := s.FindTaskTemplates(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("failed to retrieve task templates: %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("task templates are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// UpdateTaskTemplate testing.
+func UpdateTaskTemplate(
+	init func(TaskTemplateFields, *testing.T) (platform.TaskTemplateService, func()),
+	t *testing.T,
+) {
+	name := "memory"
+	params := []platform.TaskTemplateParam{
+		{Name: "host", Type: platform.TaskTemplateStringParam, Default: "localhost"},
+	}
+	instances := []platform.TaskTemplateInstance{
+		{TaskID: MustIDBase16(fourID)},
+	}
+	emptyFlux := ""
+
+	updated := newTestTaskTemplate(oneID, twoID, "cpu", threeID)
+	updated.Name = name
+	updated.Params = params
+
+	linked := newTestTaskTemplate(oneID, twoID, "cpu")
+	linked.Instances = instances
+
+	type wants struct {
+		err      error
+		template *platform.TaskTemplate
+	}
+
+	tests := []struct {
+		name  string
+		id    platform.ID
+		upd   platform.TaskTemplateUpdate
+		wants wants
+	}{
+		{
+			name: "update name and parameters",
+			id:   MustIDBase16(oneID),
+			upd: platform.TaskTemplateUpdate{
+				Name:   &name,
+				Params: &params,
+			},
+			wants: wants{
+				template: updated,
+			},
+		},
+		{
+			name: "replace instances",
+			id:   MustIDBase16(oneID),
+			upd: platform.TaskTemplateUpdate{
+				Instances: &instances,
+			},
+			wants: wants{
+				template: linked,
+			},
+		},
+		{
+			name: "update to an invalid task template",
+			id:   MustIDBase16(oneID),
+			upd: platform.TaskTemplateUpdate{
+				Flux: &emptyFlux,
+			},
+			wants: wants{
+				err: &platform.Error{Code: platform.EInvalid},
+			},
+		},
+		{
+			name: "update a missing task template",
+			id:   MustIDBase16(fourID),
+			upd: platform.TaskTemplateUpdate{
+				Name: &name,
+			},
+			wants: wants{
+				err: &platform.Error{Code: platform.ENotFound},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(TaskTemplateFields{TaskTemplates: []*platform.TaskTemplate{newTestTaskTemplate(oneID, twoID, "cpu", threeID)}}, t)
+			defer done()
+			ctx := context.Background()
+
+			got, err := s.UpdateTaskTemplate(ctx, tt.id, tt.upd)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error code '%s' got '%s'", platform.ErrorCode(tt.wants.err), platform.ErrorCode(err))
+			}
+			if diff := cmp.Diff(got, tt.wants.template); diff != "" {
+				t.Errorf("task templates are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// DeleteTaskTemplate testing.
+func DeleteTaskTemplate(
+	init func(TaskTemplateFields, *testing.T) (platform.TaskTemplateService, func()),
+	t *testing.T,
+) {
+	templates := []*platform.TaskTemplate{
+		newTestTaskTemplate(oneID, twoID, "a"),
+		newTestTaskTemplate(twoID, twoID, "b"),
+	}
+
+	type wants struct {
+		err       error
+		templates []*platform.TaskTemplate
+	}
+
+	tests := []struct {
+		name  string
+		id    platform.ID
+		wants wants
+	}{
+		{
+			name: "delete a task template",
+			id:   MustIDBase16(oneID),
+			wants: wants{
+				templates: templates[1:],
+			},
+		},
+		{
+			name: "delete a missing task template",
+			id:   MustIDBase16(fourID),
+			wants: wants{
+				err:       &platform.Error{Code: platform.ENotFound},
+				templates: templates,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(TaskTemplateFields{TaskTemplates: templates}, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.DeleteTaskTemplate(ctx, tt.id)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+				t.Fatalf("expected error code '%s' got '%s'", platform.ErrorCode(tt.wants.err), platform.ErrorCode(err))
+			}
+
+			got, err := s.FindTaskTemplates(ctx, platform.TaskTemplateFilter{})
+			if err != nil {
+				t.Fatalf("failed to retrieve task templates: %v", err)
+			}
+			if diff := cmp.Diff(got, tt.wants.templates); diff != "" {
+				t.Errorf("task templates are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
@@ -319,9 +319,11 @@ func CreateTelegrafConfig(
 				}
 			}
 
-			tcs, _, err := s.FindTelegrafConfigs(ctx, platform.UserResourceMappingFilter{
-				UserID:       MustIDBase16(threeID),
-				ResourceType: platform.TelegrafResourceType,
+			tcs, _, err := s.FindTelegrafConfigs(ctx, platform.TelegrafConfigFilter{
+				UserResourceMappingFilter: platform.UserResourceMappingFilter{
+					UserID:       MustIDBase16(threeID),
+					ResourceType: platform.TelegrafResourceType,
+				},
 			})
 			if err != nil {
 				t.Fatalf("failed to retrieve telegraf configs: %v", err)
@@ -962,7 +964,7 @@ func FindTelegrafConfigs(
 			defer done()
 			ctx := context.Background()
 
-			tcs, n, err := s.FindTelegrafConfigs(ctx, tt.args.filter)
+			tcs, n, err := s.FindTelegrafConfigs(ctx, platform.TelegrafConfigFilter{UserResourceMappingFilter: tt.args.filter})
 			if err != nil && tt.wants.err == nil {
 				t.Fatalf("expected errors to be nil got '%v'", err)
 			}
@@ -1474,9 +1476,11 @@ func DeleteTelegrafConfig(
 					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
 				}
 			}
-			tcs, n, err := s.FindTelegrafConfigs(ctx, platform.UserResourceMappingFilter{
-				UserID:       tt.args.userID,
-				ResourceType: platform.TelegrafResourceType,
+			tcs, n, err := s.FindTelegrafConfigs(ctx, platform.TelegrafConfigFilter{
+				UserResourceMappingFilter: platform.UserResourceMappingFilter{
+					UserID:       tt.args.userID,
+					ResourceType: platform.TelegrafResourceType,
+				},
 			})
 			if err != nil && tt.wants.err == nil {
 				t.Fatalf("expected errors to be nil got '%v'", err)
@@ -0,0 +1,255 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+)
+
+// TelegrafAgentFields includes prepopulated data for telegraf agent tests.
+type TelegrafAgentFields struct {
+	TelegrafAgents []*platform.TelegrafAgent
+}
+
+// TelegrafAgentService tests all the service functions.
+func TelegrafAgentService(
+	init func(TelegrafAgentFields, *testing.T) (platform.TelegrafAgentService, func()), t *testing.T,
+) {
+	tests := []struct {
+		name string
+		fn   func(init func(TelegrafAgentFields, *testing.T) (platform.TelegrafAgentService, func()),
+			t *testing.T)
+	}{
+		{
+			name: "CheckInTelegrafAgent",
+			fn:   CheckInTelegrafAgent,
+		},
+		{
+			name: "FindTelegrafAgents",
+			fn:   FindTelegrafAgents,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(init, t)
+		})
+	}
+}
+
+// CheckInTelegrafAgent testing.
+func CheckInTelegrafAgent(
+	init func(TelegrafAgentFields, *testing.T) (platform.TelegrafAgentService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		agent *platform.TelegrafAgent
+	}
+	type wants struct {
+		err    error
+		agents []*platform.TelegrafAgent
+	}
+
+	tests := []struct {
+		name   string
+		fields TelegrafAgentFields
+		args   args
+		wants  wants
+	}{
+		{
+			name:   "check in a new agent",
+			fields: TelegrafAgentFields{},
+			args: args{
+				agent: &platform.TelegrafAgent{
+					TelegrafConfigID: MustIDBase16(oneID),
+					Hostname:         "host1",
+					Version:          "1.9.0",
+					LastSeen:         time.Date(2006, 5, 4, 1, 2, 3, 0, time.UTC),
+				},
+			},
+			wants: wants{
+				agents: []*platform.TelegrafAgent{
+					{
+						TelegrafConfigID: MustIDBase16(oneID),
+						Hostname:         "host1",
+						Version:          "1.9.0",
+						LastSeen:         time.Date(2006, 5, 4, 1, 2, 3, 0, time.UTC),
+					},
+				},
+			},
+		},
+		{
+			name: "check in an existing agent",
+			fields: TelegrafAgentFields{
+				TelegrafAgents: []*platform.TelegrafAgent{
+					{
+						TelegrafConfigID: MustIDBase16(oneID),
+						Hostname:         "host1",
+						Version:          "1.8.3",
+						LastSeen:         time.Date(2006, 5, 4, 1, 2, 3, 0, time.UTC),
+					},
+				},
+			},
+			args: args{
+				agent: &platform.TelegrafAgent{
+					TelegrafConfigID: MustIDBase16(oneID),
+					Hostname:         "host1",
+					Version:          "1.9.0",
+					LastSeen:         time.Date(2006, 5, 4, 1, 12, 3, 0, time.UTC),
+				},
+			},
+			wants: wants{
+				agents: []*platform.TelegrafAgent{
+					{
+						TelegrafConfigID: MustIDBase16(oneID),
+						Hostname:         "host1",
+						Version:          "1.9.0",
+						LastSeen:         time.Date(2006, 5, 4, 1, 12, 3, 0, time.UTC),
+					},
+				},
+			},
+		},
+		{
+			name:   "agent without hostname",
+			fields: TelegrafAgentFields{},
+			args: args{
+				agent: &platform.TelegrafAgent{
+					TelegrafConfigID: MustIDBase16(oneID),
+					LastSeen:         time.Date(2006, 5, 4, 1, 2, 3, 0, time.UTC),
+				},
+			},
+			wants: wants{
+				err:    &platform.Error{Code: platform.EInvalid},
+				agents: []*platform.TelegrafAgent{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.CheckInTelegrafAgent(ctx, tt.args.agent)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+			if err != nil && tt.wants.err != nil {
+				if platform.ErrorCode(err) != platform.ErrorCode(tt.wants.err) {
+					t.Fatalf("expected error code '%s' got '%s'", platform.ErrorCode(tt.wants.err), platform.ErrorCode(err))
+				}
+			}
+
+			agents, err := s.FindTelegrafAgents(ctx, platform.TelegrafAgentFilter{})
+			if err != nil {
+				t.Fatalf("failed to retrieve telegraf agents: %v", err)
+			}
+			if diff := cmp.Diff(agents, tt.wants.agents); diff != "" {
+				t.Errorf("telegraf agents are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindTelegrafAgents testing.
+func FindTelegrafAgents(
+	init func(TelegrafAgentFields, *testing.T) (platform.TelegrafAgentService, func()),
+	t *testing.T,
+) {
+	agents := []*platform.TelegrafAgent{
+		{
+			TelegrafConfigID: MustIDBase16(oneID),
+			Hostname:         "host1",
+			LastSeen:         time.Date(2006, 5, 4, 1, 2, 3, 0, time.UTC),
+		},
+		{
+			TelegrafConfigID: MustIDBase16(oneID),
+			Hostname:         "host2",
+			LastSeen:         time.Date(2006, 5, 4, 1, 2, 3, 0, time.UTC),
+		},
+		{
+			TelegrafConfigID: MustIDBase16(twoID),
+			Hostname:         "host1",
+			LastSeen:         time.Date(2006, 5, 4, 1, 2, 3, 0, time.UTC),
+		},
+	}
+	hostname := "host1"
+
+	type args struct {
+		filter platform.TelegrafAgentFilter
+	}
+	type wants struct {
+		agents []*platform.TelegrafAgent
+	}
+
+	tests := []struct {
+		name   string
+		fields TelegrafAgentFields
+		args   args
+		wants  wants
+	}{
+		{
+			name:   "find all agents",
+			fields: TelegrafAgentFields{TelegrafAgents: agents},
+			args:   args{},
+			wants: wants{
+				agents: agents,
+			},
+		},
+		{
+			name:   "find agents by config",
+			fields: TelegrafAgentFields{TelegrafAgents: agents},
+			args: args{
+				filter: platform.TelegrafAgentFilter{
+					TelegrafConfigID: MustIDBase16Ptr(oneID),
+				},
+			},
+			wants: wants{
+				agents: agents[:2],
+			},
+		},
+		{
+			name:   "find agents by hostname",
+			fields: TelegrafAgentFields{TelegrafAgents: agents},
+			args: args{
+				filter: platform.TelegrafAgentFilter{
+					Hostname: &hostname,
+				},
+			},
+			wants: wants{
+				agents: []*platform.TelegrafAgent{agents[0], agents[2]},
+			},
+		},
+		{
+			name:   "find agents of a config without agents",
+			fields: TelegrafAgentFields{TelegrafAgents: agents},
+			args: args{
+				filter: platform.TelegrafAgentFilter{
+					TelegrafConfigID: MustIDBase16Ptr(threeID),
+				},
+			},
+			wants: wants{
+				agents: []*platform.TelegrafAgent{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			agents, err := s.FindTelegrafAgents(ctx, tt.args.filter)
+			if err != nil {
+				t.Fatalf("failed to retrieve telegraf agents: %v", err)
+			}
+			if diff := cmp.Diff(agents, tt.wants.agents); diff != "" {
+				t.Errorf("telegraf agents are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
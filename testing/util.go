@@ -54,3 +54,9 @@ func MustIDBase16(s string) platform.ID {
 	}
 	return *id
 }
+
+// MustIDBase16Ptr is an helper to ensure a correct ID pointer is built during testing.
+func MustIDBase16Ptr(s string) *platform.ID {
+	id := MustIDBase16(s)
+	return &id
+}
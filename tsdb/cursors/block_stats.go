@@ -0,0 +1,85 @@
+package cursors
+
+// FloatBlockStats summarizes a block of float points.
+type FloatBlockStats struct {
+	// FirstTime and LastTime are the times of the first and last points of the block.
+	FirstTime, LastTime int64
+
+	// Count is the number of points and Sum is the sum of their values.
+	Count int64
+	Sum   float64
+
+	// Min and Max are the least and greatest values, found first at MinTime and MaxTime.
+	Min, Max         float64
+	MinTime, MaxTime int64
+}
+
+// IntegerBlockStats summarizes a block of integer points.
+type IntegerBlockStats struct {
+	// FirstTime and LastTime are the times of the first and last points of the block.
+	FirstTime, LastTime int64
+
+	// Count is the number of points and Sum is the sum of their values.
+	Count int64
+	Sum   int64
+
+	// Min and Max are the least and greatest values, found first at MinTime and MaxTime.
+	Min, Max         int64
+	MinTime, MaxTime int64
+}
+
+// UnsignedBlockStats summarizes a block of unsigned points.
+type UnsignedBlockStats struct {
+	// FirstTime and LastTime are the times of the first and last points of the block.
+	FirstTime, LastTime int64
+
+	// Count is the number of points and Sum is the sum of their values.
+	Count int64
+	Sum   uint64
+
+	// Min and Max are the least and greatest values, found first at MinTime and MaxTime.
+	Min, Max         uint64
+	MinTime, MaxTime int64
+}
+
+// FloatBlockStatsCursor is a FloatArrayCursor that can summarize whole blocks of
+// its points instead of returning them.
+type FloatBlockStatsCursor interface {
+	FloatArrayCursor
+
+	// PeekBlockStats returns the statistics of the next points of the cursor, if
+	// they make up a block the cursor has statistics for. The points are only
+	// consumed by a following call to SkipBlock.
+	PeekBlockStats() (FloatBlockStats, bool)
+
+	// SkipBlock moves the cursor past the points returned by PeekBlockStats.
+	SkipBlock()
+}
+
+// IntegerBlockStatsCursor is an IntegerArrayCursor that can summarize whole blocks of
+// its points instead of returning them.
+type IntegerBlockStatsCursor interface {
+	IntegerArrayCursor
+
+	// PeekBlockStats returns the statistics of the next points of the cursor, if
+	// they make up a block the cursor has statistics for. The points are only
+	// consumed by a following call to SkipBlock.
+	PeekBlockStats() (IntegerBlockStats, bool)
+
+	// SkipBlock moves the cursor past the points returned by PeekBlockStats.
+	SkipBlock()
+}
+
+// UnsignedBlockStatsCursor is an UnsignedArrayCursor that can summarize whole blocks of
+// its points instead of returning them.
+type UnsignedBlockStatsCursor interface {
+	UnsignedArrayCursor
+
+	// PeekBlockStats returns the statistics of the next points of the cursor, if
+	// they make up a block the cursor has statistics for. The points are only
+	// consumed by a following call to SkipBlock.
+	PeekBlockStats() (UnsignedBlockStats, bool)
+
+	// SkipBlock moves the cursor past the points returned by PeekBlockStats.
+	SkipBlock()
+}
@@ -195,6 +195,28 @@ func (f *SeriesFile) DisableCompactions() {
 	}
 }
 
+// Compact compacts the index of each partition, dropping the series that
+// have been deleted from them, and removes the segments holding only deleted
+// series.
+func (f *SeriesFile) Compact() error {
+	for _, p := range f.partitions {
+		if err := p.Compact(); err != nil {
+			return err
+		}
+	}
+
+	// Removed segments are unmapped, so wait for all Retains to be released.
+	f.refs.Lock()
+	defer f.refs.Unlock()
+
+	for _, p := range f.partitions {
+		if err := p.removeDeadSegments(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Wait waits for all Retains to be released.
 func (f *SeriesFile) Wait() {
 	f.refs.Lock()
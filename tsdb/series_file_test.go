@@ -217,6 +217,51 @@ func TestSeriesFile_DeleteSeriesID(t *testing.T) {
 	}
 }
 
+// Ensure compacting the series file drops deleted series from its count.
+func TestSeriesFile_Compact(t *testing.T) {
+	sfile := MustOpenSeriesFile()
+	defer sfile.Close()
+
+	collection := &tsdb.SeriesCollection{
+		Names: [][]byte{[]byte("m1"), []byte("m2")},
+		Tags:  []models.Tags{{}, {}},
+		Types: []models.FieldType{models.String, models.String},
+	}
+	if err := sfile.CreateSeriesListIfNotExists(collection); err != nil {
+		t.Fatal(err)
+	}
+	id := sfile.SeriesID([]byte("m1"), nil, nil)
+
+	if err := sfile.DeleteSeriesID(id); err != nil {
+		t.Fatal(err)
+	} else if err := sfile.Compact(); err != nil {
+		t.Fatal(err)
+	} else if n := sfile.SeriesCount(); n != 1 {
+		t.Fatalf("unexpected series count after compaction: %d", n)
+	} else if !sfile.IsDeleted(id) {
+		t.Fatal("expected deletion after compaction")
+	}
+
+	// Recreating the deleted series assigns it a new id.
+	if err := sfile.CreateSeriesListIfNotExists(&tsdb.SeriesCollection{
+		Names: [][]byte{[]byte("m1")},
+		Tags:  []models.Tags{{}},
+		Types: []models.FieldType{models.String},
+	}); err != nil {
+		t.Fatal(err)
+	} else if newID := sfile.SeriesID([]byte("m1"), nil, nil); newID.IsZero() || newID == id {
+		t.Fatalf("unexpected id for recreated series: %v", newID)
+	}
+
+	if err := sfile.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if n := sfile.SeriesCount(); n != 2 {
+		t.Fatalf("unexpected series count after reopen: %d", n)
+	} else if !sfile.IsDeleted(id) {
+		t.Fatal("expected deletion after reopen")
+	}
+}
+
 // Series represents name/tagset pairs that are used in testing.
 type Series struct {
 	Name    []byte
@@ -150,6 +150,10 @@ func (idx *SeriesIndex) Recover(segments []*SeriesSegment) error {
 	return nil
 }
 
+// MaxSeriesID returns the highest series id inserted into the index,
+// including the ids of deleted series.
+func (idx *SeriesIndex) MaxSeriesID() SeriesID { return idx.maxSeriesID }
+
 // Count returns the number of series in the index.
 func (idx *SeriesIndex) Count() uint64 {
 	return idx.OnDiskCount() + idx.InMemCount()
@@ -41,6 +41,10 @@ type SeriesPartition struct {
 	index    *SeriesIndex
 	seq      uint64 // series id sequence
 
+	// deadSegments are the ids of the segments found by the last compaction
+	// to hold only deleted series.
+	deadSegments []uint16
+
 	compacting          bool
 	compactionsDisabled int
 
@@ -91,6 +95,12 @@ func (p *SeriesPartition) Open() error {
 		} else if p.index.Recover(p.segments); err != nil {
 			return err
 		}
+
+		// The segments holding the highest series ids may have been removed,
+		// so never hand out an id below the highest one the index has seen.
+		if seq := p.index.MaxSeriesID(); seq.RawID() >= p.seq {
+			p.seq = seq.RawID() + SeriesFilePartitionN
+		}
 		return nil
 	}(); err != nil {
 		p.Close()
@@ -295,37 +305,109 @@ func (p *SeriesPartition) CreateSeriesListIfNotExists(collection *SeriesCollecti
 	// Check if we've crossed the compaction threshold.
 	if p.compactionsEnabled() && !p.compacting && p.CompactThreshold != 0 && p.index.InMemCount() >= uint64(p.CompactThreshold) {
 		p.compacting = true
-		log, logEnd := logger.NewOperation(p.Logger, "Series partition compaction", "series_partition_compaction", zap.String("path", p.path))
-
 		p.wg.Add(1)
-		p.tracker.IncCompactionsActive()
 		go func() {
 			defer p.wg.Done()
+			p.compact()
+		}()
+	}
 
-			compactor := NewSeriesPartitionCompactor()
-			compactor.cancel = p.closing
-			duration, err := compactor.Compact(p)
-			if err != nil {
-				p.tracker.IncCompactionErr()
-				log.Error("series partition compaction failed", zap.Error(err))
-			} else {
-				p.tracker.IncCompactionOK(duration)
-			}
+	return nil
+}
 
-			logEnd()
+// Compact rebuilds the on-disk index of the partition, dropping the series
+// that have been deleted from it. The segments left holding only deleted
+// series are removed by SeriesFile.Compact. Compact returns without compacting
+// if a compaction is already running or compactions are disabled.
+func (p *SeriesPartition) Compact() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return ErrSeriesPartitionClosed
+	} else if !p.compactionsEnabled() || p.compacting {
+		p.mu.Unlock()
+		return nil
+	}
+	p.compacting = true
+	p.wg.Add(1)
+	p.mu.Unlock()
+
+	defer p.wg.Done()
+	return p.compact()
+}
 
-			// Clear compaction flag.
-			p.mu.Lock()
-			p.compacting = false
-			p.mu.Unlock()
-			p.tracker.DecCompactionsActive()
+// compact compacts the index of the partition. The compacting flag must be
+// set by the caller and is cleared once the compaction completes.
+func (p *SeriesPartition) compact() error {
+	log, logEnd := logger.NewOperation(p.Logger, "Series partition compaction", "series_partition_compaction", zap.String("path", p.path))
+	p.tracker.IncCompactionsActive()
 
-			// Disk size may have changed due to compaction.
-			p.tracker.SetDiskSize(p.DiskSize())
-		}()
+	compactor := NewSeriesPartitionCompactor()
+	compactor.cancel = p.closing
+	duration, err := compactor.Compact(p)
+	if err != nil {
+		p.tracker.IncCompactionErr()
+		log.Error("series partition compaction failed", zap.Error(err))
+	} else {
+		p.tracker.IncCompactionOK(duration)
 	}
 
-	return nil
+	logEnd()
+
+	// Clear compaction flag.
+	p.mu.Lock()
+	p.compacting = false
+	p.mu.Unlock()
+	p.tracker.DecCompactionsActive()
+
+	// Disk size may have changed due to compaction.
+	p.tracker.SetDiskSize(p.DiskSize())
+	return err
+}
+
+// removeDeadSegments closes and deletes the segments found by the last
+// compaction to hold only deleted series. The caller must ensure no series
+// keys read from the partition are in use.
+func (p *SeriesPartition) removeDeadSegments() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrSeriesPartitionClosed
+	} else if len(p.deadSegments) == 0 {
+		return nil
+	}
+
+	dead := make(map[uint16]struct{}, len(p.deadSegments))
+	for _, id := range p.deadSegments {
+		dead[id] = struct{}{}
+	}
+	p.deadSegments = nil
+
+	var err error
+	segments := p.segments[:0]
+	for i, segment := range p.segments {
+		// The active segment is never removed.
+		if _, ok := dead[segment.ID()]; !ok || i == len(p.segments)-1 {
+			segments = append(segments, segment)
+			continue
+		}
+
+		if e := segment.Close(); e != nil && err == nil {
+			err = e
+		}
+		if e := os.Remove(segment.path); e != nil && err == nil {
+			err = e
+		}
+	}
+	for i := len(segments); i < len(p.segments); i++ {
+		p.segments[i] = nil
+	}
+	p.segments = segments
+
+	p.tracker.SetSegments(uint64(len(p.segments)))
+	p.tracker.SetDiskSize(p.diskSize())
+	return err
 }
 
 // Compacting returns if the SeriesPartition is currently compacting.
@@ -714,7 +796,8 @@ func (c *SeriesPartitionCompactor) Compact(p *SeriesPartition) (time.Duration, e
 
 	// Compact index to a temporary location.
 	indexPath := index.path + ".compacting"
-	if err := c.compactIndexTo(index, seriesN, segments, indexPath); err != nil {
+	deadSegments, err := c.compactIndexTo(index, seriesN, segments, indexPath)
+	if err != nil {
 		return 0, err
 	}
 	duration := time.Since(now)
@@ -737,6 +820,9 @@ func (c *SeriesPartitionCompactor) Compact(p *SeriesPartition) (time.Duration, e
 		if err := p.index.Recover(p.segments); err != nil {
 			return err
 		}
+
+		// The compacted index no longer references these segments.
+		p.deadSegments = deadSegments
 		return nil
 	}(); err != nil {
 		return 0, err
@@ -745,7 +831,10 @@ func (c *SeriesPartitionCompactor) Compact(p *SeriesPartition) (time.Duration, e
 	return duration, nil
 }
 
-func (c *SeriesPartitionCompactor) compactIndexTo(index *SeriesIndex, seriesN uint64, segments []*SeriesSegment, path string) error {
+// compactIndexTo writes an index of the live series in segments to path. It
+// returns the ids of the segments, other than the last one, that hold no live
+// series and can be removed once the new index is in place.
+func (c *SeriesPartitionCompactor) compactIndexTo(index *SeriesIndex, seriesN uint64, segments []*SeriesSegment, path string) ([]uint16, error) {
 	hdr := NewSeriesIndexHeader()
 	hdr.Count = seriesN
 	hdr.Capacity = pow2((int64(hdr.Count) * 100) / SeriesIndexLoadFactor)
@@ -756,10 +845,13 @@ func (c *SeriesPartitionCompactor) compactIndexTo(index *SeriesIndex, seriesN ui
 
 	// Reindex all partitions.
 	var entryN int
+	var liveN uint64 // Number of series that are not tombstoned.
+	usages := make([]seriesSegmentUsage, 0, len(segments))
 	for _, segment := range segments {
 		errDone := errors.New("done")
+		usage := seriesSegmentUsage{id: segment.ID()}
 
-		if err := segment.ForEachEntry(func(flag uint8, id SeriesIDTyped, offset int64, key []byte) error {
+		err := segment.ForEachEntry(func(flag uint8, id SeriesIDTyped, offset int64, key []byte) error {
 			// Make sure we don't go past the offset where the compaction began.
 			if offset > index.maxOffset {
 				return errDone
@@ -774,16 +866,18 @@ func (c *SeriesPartitionCompactor) compactIndexTo(index *SeriesIndex, seriesN ui
 				}
 			}
 
+			untypedID := id.SeriesID()
+
 			// Only process insert entries.
 			switch flag {
 			case SeriesEntryInsertFlag: // fallthrough
 			case SeriesEntryTombstoneFlag:
+				usage.tombstone(untypedID)
 				return nil
 			default:
 				return fmt.Errorf("unexpected series partition log entry flag: %d", flag)
 			}
-
-			untypedID := id.SeriesID()
+			usage.insert(untypedID)
 
 			// Save max series identifier processed.
 			hdr.MaxSeriesID, hdr.MaxOffset = untypedID, offset
@@ -792,21 +886,34 @@ func (c *SeriesPartitionCompactor) compactIndexTo(index *SeriesIndex, seriesN ui
 			if index.IsDeleted(untypedID) {
 				return nil
 			}
+			liveN++
+			usage.live = true
 
 			// Insert into maps.
 			c.insertIDOffsetMap(idOffsetMap, hdr.Capacity, untypedID, offset)
 			return c.insertKeyIDMap(keyIDMap, hdr.Capacity, segments, key, offset, id)
-		}); err == errDone {
+		})
+		if err == errDone {
 			break
 		} else if err != nil {
-			return err
+			return nil, err
 		}
+		usages = append(usages, usage)
 	}
 
+	// The last segment may still be written to, so it is never removed.
+	if len(usages) == len(segments) && len(usages) > 0 {
+		usages = usages[:len(usages)-1]
+	}
+	deadSegments := deadSeriesSegments(usages)
+
+	// Deleted series are not part of the compacted index.
+	hdr.Count = liveN
+
 	// Open file handler.
 	f, err := os.Create(path)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer f.Close()
 
@@ -816,24 +923,92 @@ func (c *SeriesPartitionCompactor) compactIndexTo(index *SeriesIndex, seriesN ui
 
 	// Write header.
 	if _, err := hdr.WriteTo(f); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Write maps.
 	if _, err := f.Write(keyIDMap); err != nil {
-		return err
+		return nil, err
 	} else if _, err := f.Write(idOffsetMap); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Sync & close.
 	if err := f.Sync(); err != nil {
-		return err
+		return nil, err
 	} else if err := f.Close(); err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return deadSegments, nil
+}
+
+// seriesSegmentUsage summarizes the entries of a segment read by a compaction.
+type seriesSegmentUsage struct {
+	id           uint16
+	minID, maxID SeriesID // range of the inserted series ids
+	live         bool     // true if an inserted series is not deleted
+	tombstones   []SeriesID
+}
+
+func (u *seriesSegmentUsage) insert(id SeriesID) {
+	if u.minID.IsZero() || id.Less(u.minID) {
+		u.minID = id
+	}
+	if id.Greater(u.maxID) {
+		u.maxID = id
+	}
+}
+
+func (u *seriesSegmentUsage) tombstone(id SeriesID) {
+	// Tombstones only matter when deciding if a dead segment can be removed.
+	if !u.live {
+		u.tombstones = append(u.tombstones, id)
+	}
+}
+
+// deadSeriesSegments returns the ids of the segments with no live series.
+// A segment holding the tombstone of a series inserted into a segment that is
+// kept is itself kept, so rebuilding the index from the remaining segments
+// cannot bring the series back.
+func deadSeriesSegments(usages []seriesSegmentUsage) []uint16 {
+	dead := make(map[uint16]bool, len(usages))
+	for _, u := range usages {
+		dead[u.id] = !u.live
+	}
+
+	// insertedIn returns the segment that inserted the series, if still present.
+	insertedIn := func(id SeriesID) (uint16, bool) {
+		for _, u := range usages {
+			if !u.minID.IsZero() && !id.Less(u.minID) && !id.Greater(u.maxID) {
+				return u.id, true
+			}
+		}
+		return 0, false
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, u := range usages {
+			if !dead[u.id] {
+				continue
+			}
+			for _, id := range u.tombstones {
+				if segmentID, ok := insertedIn(id); ok && !dead[segmentID] {
+					dead[u.id], changed = false, true
+					break
+				}
+			}
+		}
+	}
+
+	var ids []uint16
+	for _, u := range usages {
+		if dead[u.id] {
+			ids = append(ids, u.id)
+		}
+	}
+	return ids
 }
 
 func (c *SeriesPartitionCompactor) insertKeyIDMap(dst []byte, capacity int64, segments []*SeriesSegment, key []byte, offset int64, id SeriesIDTyped) error {
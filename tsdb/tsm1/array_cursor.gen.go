@@ -26,6 +26,9 @@ type floatArrayAscendingCursor struct {
 		values    *tsdb.FloatArray
 		pos       int
 		keyCursor *KeyCursor
+
+		// deferred is set when keyCursor has moved to a block that has not been read into values.
+		deferred bool
 	}
 
 	end   int64
@@ -49,6 +52,13 @@ func (c *floatArrayAscendingCursor) reset(seek, end int64, cacheValues Values, t
 	})
 
 	c.tsm.keyCursor = tsmKeyCursor
+	c.tsm.deferred = false
+	if _, ok := tsmKeyCursor.blockStats(); ok {
+		// the first block starts after seek, so defer reading it as it may be skipped
+		c.tsm.pos = 0
+		c.tsm.deferred = true
+		return
+	}
 	c.tsm.values = c.readArrayBlock()
 	c.tsm.pos = sort.Search(c.tsm.values.Len(), func(i int) bool {
 		return c.tsm.values.Timestamps[i] >= seek
@@ -71,6 +81,11 @@ func (c *floatArrayAscendingCursor) Stats() cursors.CursorStats { return c.stats
 
 // Next returns the next key/value for the cursor.
 func (c *floatArrayAscendingCursor) Next() *tsdb.FloatArray {
+	if c.tsm.deferred {
+		c.tsm.values = c.readArrayBlock()
+		c.tsm.deferred = false
+	}
+
 	pos := 0
 	cvals := c.cache.values
 	tvals := c.tsm.values
@@ -109,7 +124,7 @@ func (c *floatArrayAscendingCursor) Next() *tsdb.FloatArray {
 				// optimization: all points served from TSM data
 				copy(c.res.Timestamps, tvals.Timestamps)
 				pos += copy(c.res.Values, tvals.Values)
-				c.nextTSM()
+				c.deferNextTSM()
 			} else {
 				// copy as much as we can
 				n := copy(c.res.Timestamps[pos:], tvals.Timestamps[c.tsm.pos:])
@@ -117,7 +132,7 @@ func (c *floatArrayAscendingCursor) Next() *tsdb.FloatArray {
 				pos += n
 				c.tsm.pos += n
 				if c.tsm.pos >= len(tvals.Timestamps) {
-					c.nextTSM()
+					c.deferNextTSM()
 				}
 			}
 		}
@@ -158,6 +173,39 @@ func (c *floatArrayAscendingCursor) nextTSM() *tsdb.FloatArray {
 	return c.tsm.values
 }
 
+// deferNextTSM moves to the next TSM block without reading it, so that the block
+// may be skipped using its statistics instead.
+func (c *floatArrayAscendingCursor) deferNextTSM() {
+	c.tsm.keyCursor.Next()
+	c.tsm.pos = 0
+	c.tsm.deferred = true
+}
+
+// PeekBlockStats returns the statistics of the next TSM block, when the block has
+// not been read, lies within the range of the cursor and no cache values precede
+// or overlap it.
+func (c *floatArrayAscendingCursor) PeekBlockStats() (cursors.FloatBlockStats, bool) {
+	if !c.tsm.deferred {
+		return cursors.FloatBlockStats{}, false
+	}
+
+	entry, ok := c.tsm.keyCursor.blockStats()
+	if !ok || entry.MaxTime > c.end {
+		return cursors.FloatBlockStats{}, false
+	}
+
+	if c.cache.pos < len(c.cache.values) && c.cache.values[c.cache.pos].UnixNano() <= entry.MaxTime {
+		return cursors.FloatBlockStats{}, false
+	}
+
+	return floatBlockStats(entry), true
+}
+
+// SkipBlock moves past the TSM block returned by PeekBlockStats without reading it.
+func (c *floatArrayAscendingCursor) SkipBlock() {
+	c.tsm.keyCursor.skipBlock()
+}
+
 func (c *floatArrayAscendingCursor) readArrayBlock() *tsdb.FloatArray {
 	values, _ := c.tsm.keyCursor.ReadFloatArrayBlock(c.tsm.buf)
 	return values
@@ -334,6 +382,9 @@ type integerArrayAscendingCursor struct {
 		values    *tsdb.IntegerArray
 		pos       int
 		keyCursor *KeyCursor
+
+		// deferred is set when keyCursor has moved to a block that has not been read into values.
+		deferred bool
 	}
 
 	end   int64
@@ -357,6 +408,13 @@ func (c *integerArrayAscendingCursor) reset(seek, end int64, cacheValues Values,
 	})
 
 	c.tsm.keyCursor = tsmKeyCursor
+	c.tsm.deferred = false
+	if _, ok := tsmKeyCursor.blockStats(); ok {
+		// the first block starts after seek, so defer reading it as it may be skipped
+		c.tsm.pos = 0
+		c.tsm.deferred = true
+		return
+	}
 	c.tsm.values = c.readArrayBlock()
 	c.tsm.pos = sort.Search(c.tsm.values.Len(), func(i int) bool {
 		return c.tsm.values.Timestamps[i] >= seek
@@ -379,6 +437,11 @@ func (c *integerArrayAscendingCursor) Stats() cursors.CursorStats { return c.sta
 
 // Next returns the next key/value for the cursor.
 func (c *integerArrayAscendingCursor) Next() *tsdb.IntegerArray {
+	if c.tsm.deferred {
+		c.tsm.values = c.readArrayBlock()
+		c.tsm.deferred = false
+	}
+
 	pos := 0
 	cvals := c.cache.values
 	tvals := c.tsm.values
@@ -417,7 +480,7 @@ func (c *integerArrayAscendingCursor) Next() *tsdb.IntegerArray {
 				// optimization: all points served from TSM data
 				copy(c.res.Timestamps, tvals.Timestamps)
 				pos += copy(c.res.Values, tvals.Values)
-				c.nextTSM()
+				c.deferNextTSM()
 			} else {
 				// copy as much as we can
 				n := copy(c.res.Timestamps[pos:], tvals.Timestamps[c.tsm.pos:])
@@ -425,7 +488,7 @@ func (c *integerArrayAscendingCursor) Next() *tsdb.IntegerArray {
 				pos += n
 				c.tsm.pos += n
 				if c.tsm.pos >= len(tvals.Timestamps) {
-					c.nextTSM()
+					c.deferNextTSM()
 				}
 			}
 		}
@@ -466,6 +529,39 @@ func (c *integerArrayAscendingCursor) nextTSM() *tsdb.IntegerArray {
 	return c.tsm.values
 }
 
+// deferNextTSM moves to the next TSM block without reading it, so that the block
+// may be skipped using its statistics instead.
+func (c *integerArrayAscendingCursor) deferNextTSM() {
+	c.tsm.keyCursor.Next()
+	c.tsm.pos = 0
+	c.tsm.deferred = true
+}
+
+// PeekBlockStats returns the statistics of the next TSM block, when the block has
+// not been read, lies within the range of the cursor and no cache values precede
+// or overlap it.
+func (c *integerArrayAscendingCursor) PeekBlockStats() (cursors.IntegerBlockStats, bool) {
+	if !c.tsm.deferred {
+		return cursors.IntegerBlockStats{}, false
+	}
+
+	entry, ok := c.tsm.keyCursor.blockStats()
+	if !ok || entry.MaxTime > c.end {
+		return cursors.IntegerBlockStats{}, false
+	}
+
+	if c.cache.pos < len(c.cache.values) && c.cache.values[c.cache.pos].UnixNano() <= entry.MaxTime {
+		return cursors.IntegerBlockStats{}, false
+	}
+
+	return integerBlockStats(entry), true
+}
+
+// SkipBlock moves past the TSM block returned by PeekBlockStats without reading it.
+func (c *integerArrayAscendingCursor) SkipBlock() {
+	c.tsm.keyCursor.skipBlock()
+}
+
 func (c *integerArrayAscendingCursor) readArrayBlock() *tsdb.IntegerArray {
 	values, _ := c.tsm.keyCursor.ReadIntegerArrayBlock(c.tsm.buf)
 	return values
@@ -642,6 +738,9 @@ type unsignedArrayAscendingCursor struct {
 		values    *tsdb.UnsignedArray
 		pos       int
 		keyCursor *KeyCursor
+
+		// deferred is set when keyCursor has moved to a block that has not been read into values.
+		deferred bool
 	}
 
 	end   int64
@@ -665,6 +764,13 @@ func (c *unsignedArrayAscendingCursor) reset(seek, end int64, cacheValues Values
 	})
 
 	c.tsm.keyCursor = tsmKeyCursor
+	c.tsm.deferred = false
+	if _, ok := tsmKeyCursor.blockStats(); ok {
+		// the first block starts after seek, so defer reading it as it may be skipped
+		c.tsm.pos = 0
+		c.tsm.deferred = true
+		return
+	}
 	c.tsm.values = c.readArrayBlock()
 	c.tsm.pos = sort.Search(c.tsm.values.Len(), func(i int) bool {
 		return c.tsm.values.Timestamps[i] >= seek
@@ -687,6 +793,11 @@ func (c *unsignedArrayAscendingCursor) Stats() cursors.CursorStats { return c.st
 
 // Next returns the next key/value for the cursor.
 func (c *unsignedArrayAscendingCursor) Next() *tsdb.UnsignedArray {
+	if c.tsm.deferred {
+		c.tsm.values = c.readArrayBlock()
+		c.tsm.deferred = false
+	}
+
 	pos := 0
 	cvals := c.cache.values
 	tvals := c.tsm.values
@@ -725,7 +836,7 @@ func (c *unsignedArrayAscendingCursor) Next() *tsdb.UnsignedArray {
 				// optimization: all points served from TSM data
 				copy(c.res.Timestamps, tvals.Timestamps)
 				pos += copy(c.res.Values, tvals.Values)
-				c.nextTSM()
+				c.deferNextTSM()
 			} else {
 				// copy as much as we can
 				n := copy(c.res.Timestamps[pos:], tvals.Timestamps[c.tsm.pos:])
@@ -733,7 +844,7 @@ func (c *unsignedArrayAscendingCursor) Next() *tsdb.UnsignedArray {
 				pos += n
 				c.tsm.pos += n
 				if c.tsm.pos >= len(tvals.Timestamps) {
-					c.nextTSM()
+					c.deferNextTSM()
 				}
 			}
 		}
@@ -774,6 +885,39 @@ func (c *unsignedArrayAscendingCursor) nextTSM() *tsdb.UnsignedArray {
 	return c.tsm.values
 }
 
+// deferNextTSM moves to the next TSM block without reading it, so that the block
+// may be skipped using its statistics instead.
+func (c *unsignedArrayAscendingCursor) deferNextTSM() {
+	c.tsm.keyCursor.Next()
+	c.tsm.pos = 0
+	c.tsm.deferred = true
+}
+
+// PeekBlockStats returns the statistics of the next TSM block, when the block has
+// not been read, lies within the range of the cursor and no cache values precede
+// or overlap it.
+func (c *unsignedArrayAscendingCursor) PeekBlockStats() (cursors.UnsignedBlockStats, bool) {
+	if !c.tsm.deferred {
+		return cursors.UnsignedBlockStats{}, false
+	}
+
+	entry, ok := c.tsm.keyCursor.blockStats()
+	if !ok || entry.MaxTime > c.end {
+		return cursors.UnsignedBlockStats{}, false
+	}
+
+	if c.cache.pos < len(c.cache.values) && c.cache.values[c.cache.pos].UnixNano() <= entry.MaxTime {
+		return cursors.UnsignedBlockStats{}, false
+	}
+
+	return unsignedBlockStats(entry), true
+}
+
+// SkipBlock moves past the TSM block returned by PeekBlockStats without reading it.
+func (c *unsignedArrayAscendingCursor) SkipBlock() {
+	c.tsm.keyCursor.skipBlock()
+}
+
 func (c *unsignedArrayAscendingCursor) readArrayBlock() *tsdb.UnsignedArray {
 	values, _ := c.tsm.keyCursor.ReadUnsignedArrayBlock(c.tsm.buf)
 	return values
@@ -950,6 +1094,9 @@ type stringArrayAscendingCursor struct {
 		values    *tsdb.StringArray
 		pos       int
 		keyCursor *KeyCursor
+
+		// deferred is set when keyCursor has moved to a block that has not been read into values.
+		deferred bool
 	}
 
 	end   int64
@@ -973,6 +1120,13 @@ func (c *stringArrayAscendingCursor) reset(seek, end int64, cacheValues Values,
 	})
 
 	c.tsm.keyCursor = tsmKeyCursor
+	c.tsm.deferred = false
+	if _, ok := tsmKeyCursor.blockStats(); ok {
+		// the first block starts after seek, so defer reading it as it may be skipped
+		c.tsm.pos = 0
+		c.tsm.deferred = true
+		return
+	}
 	c.tsm.values = c.readArrayBlock()
 	c.tsm.pos = sort.Search(c.tsm.values.Len(), func(i int) bool {
 		return c.tsm.values.Timestamps[i] >= seek
@@ -995,6 +1149,11 @@ func (c *stringArrayAscendingCursor) Stats() cursors.CursorStats { return c.stat
 
 // Next returns the next key/value for the cursor.
 func (c *stringArrayAscendingCursor) Next() *tsdb.StringArray {
+	if c.tsm.deferred {
+		c.tsm.values = c.readArrayBlock()
+		c.tsm.deferred = false
+	}
+
 	pos := 0
 	cvals := c.cache.values
 	tvals := c.tsm.values
@@ -1033,7 +1192,7 @@ func (c *stringArrayAscendingCursor) Next() *tsdb.StringArray {
 				// optimization: all points served from TSM data
 				copy(c.res.Timestamps, tvals.Timestamps)
 				pos += copy(c.res.Values, tvals.Values)
-				c.nextTSM()
+				c.deferNextTSM()
 			} else {
 				// copy as much as we can
 				n := copy(c.res.Timestamps[pos:], tvals.Timestamps[c.tsm.pos:])
@@ -1041,7 +1200,7 @@ func (c *stringArrayAscendingCursor) Next() *tsdb.StringArray {
 				pos += n
 				c.tsm.pos += n
 				if c.tsm.pos >= len(tvals.Timestamps) {
-					c.nextTSM()
+					c.deferNextTSM()
 				}
 			}
 		}
@@ -1084,6 +1243,14 @@ func (c *stringArrayAscendingCursor) nextTSM() *tsdb.StringArray {
 	return c.tsm.values
 }
 
+// deferNextTSM moves to the next TSM block without reading it, so that the block
+// may be skipped using its statistics instead.
+func (c *stringArrayAscendingCursor) deferNextTSM() {
+	c.tsm.keyCursor.Next()
+	c.tsm.pos = 0
+	c.tsm.deferred = true
+}
+
 func (c *stringArrayAscendingCursor) readArrayBlock() *tsdb.StringArray {
 	values, _ := c.tsm.keyCursor.ReadStringArrayBlock(c.tsm.buf)
 	return values
@@ -1262,6 +1429,9 @@ type booleanArrayAscendingCursor struct {
 		values    *tsdb.BooleanArray
 		pos       int
 		keyCursor *KeyCursor
+
+		// deferred is set when keyCursor has moved to a block that has not been read into values.
+		deferred bool
 	}
 
 	end   int64
@@ -1285,6 +1455,13 @@ func (c *booleanArrayAscendingCursor) reset(seek, end int64, cacheValues Values,
 	})
 
 	c.tsm.keyCursor = tsmKeyCursor
+	c.tsm.deferred = false
+	if _, ok := tsmKeyCursor.blockStats(); ok {
+		// the first block starts after seek, so defer reading it as it may be skipped
+		c.tsm.pos = 0
+		c.tsm.deferred = true
+		return
+	}
 	c.tsm.values = c.readArrayBlock()
 	c.tsm.pos = sort.Search(c.tsm.values.Len(), func(i int) bool {
 		return c.tsm.values.Timestamps[i] >= seek
@@ -1307,6 +1484,11 @@ func (c *booleanArrayAscendingCursor) Stats() cursors.CursorStats { return c.sta
 
 // Next returns the next key/value for the cursor.
 func (c *booleanArrayAscendingCursor) Next() *tsdb.BooleanArray {
+	if c.tsm.deferred {
+		c.tsm.values = c.readArrayBlock()
+		c.tsm.deferred = false
+	}
+
 	pos := 0
 	cvals := c.cache.values
 	tvals := c.tsm.values
@@ -1345,7 +1527,7 @@ func (c *booleanArrayAscendingCursor) Next() *tsdb.BooleanArray {
 				// optimization: all points served from TSM data
 				copy(c.res.Timestamps, tvals.Timestamps)
 				pos += copy(c.res.Values, tvals.Values)
-				c.nextTSM()
+				c.deferNextTSM()
 			} else {
 				// copy as much as we can
 				n := copy(c.res.Timestamps[pos:], tvals.Timestamps[c.tsm.pos:])
@@ -1353,7 +1535,7 @@ func (c *booleanArrayAscendingCursor) Next() *tsdb.BooleanArray {
 				pos += n
 				c.tsm.pos += n
 				if c.tsm.pos >= len(tvals.Timestamps) {
-					c.nextTSM()
+					c.deferNextTSM()
 				}
 			}
 		}
@@ -1394,6 +1576,14 @@ func (c *booleanArrayAscendingCursor) nextTSM() *tsdb.BooleanArray {
 	return c.tsm.values
 }
 
+// deferNextTSM moves to the next TSM block without reading it, so that the block
+// may be skipped using its statistics instead.
+func (c *booleanArrayAscendingCursor) deferNextTSM() {
+	c.tsm.keyCursor.Next()
+	c.tsm.pos = 0
+	c.tsm.deferred = true
+}
+
 func (c *booleanArrayAscendingCursor) readArrayBlock() *tsdb.BooleanArray {
 	values, _ := c.tsm.keyCursor.ReadBooleanArrayBlock(c.tsm.buf)
 	return values
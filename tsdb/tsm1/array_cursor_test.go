@@ -0,0 +1,148 @@
+package tsm1
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform/tsdb"
+	"github.com/influxdata/platform/tsdb/cursors"
+)
+
+// newBlockStatsFileStore returns a file store holding two float blocks of cpu,
+// at 0-3 and at 10-13.
+func newBlockStatsFileStore(t *testing.T) (*FileStore, func()) {
+	dir, err := ioutil.TempDir("", "tsm1-array-cursor")
+	if err != nil {
+		t.Fatalf("unexpected error creating dir: %v", err)
+	}
+
+	f, err := os.Create(filepath.Join(dir, "000000001-000000001.tsm"))
+	if err != nil {
+		t.Fatalf("unexpected error creating file: %v", err)
+	}
+
+	w, err := NewTSMWriter(f)
+	if err != nil {
+		t.Fatalf("unexpected error creating writer: %v", err)
+	}
+	for _, values := range [][]Value{
+		{NewValue(0, 4.0), NewValue(1, 2.0), NewValue(2, 8.0), NewValue(3, 2.0)},
+		{NewValue(10, 1.0), NewValue(11, 1.0), NewValue(12, 3.0), NewValue(13, 5.0)},
+	} {
+		if err := w.Write([]byte("cpu"), values); err != nil {
+			t.Fatalf("unexpected error writing: %v", err)
+		}
+	}
+	if err := w.WriteIndex(); err != nil {
+		t.Fatalf("unexpected error writing index: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+
+	fs := NewFileStore(dir)
+	if err := fs.Open(); err != nil {
+		t.Fatalf("unexpected error opening file store: %v", err)
+	}
+	return fs, func() {
+		fs.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestFloatArrayAscendingCursor_BlockStats(t *testing.T) {
+	first := cursors.FloatBlockStats{
+		FirstTime: 0, LastTime: 3,
+		Count: 4, Sum: 16,
+		Min: 2, MinTime: 1,
+		Max: 8, MaxTime: 2,
+	}
+	second := cursors.FloatBlockStats{
+		FirstTime: 10, LastTime: 13,
+		Count: 4, Sum: 10,
+		Min: 1, MinTime: 10,
+		Max: 5, MaxTime: 13,
+	}
+
+	tests := []struct {
+		name      string
+		seek, end int64
+		cache     Values
+		delete    []TimeRange
+		want      []cursors.FloatBlockStats
+		next      *tsdb.FloatArray
+	}{
+		{
+			name: "whole blocks",
+			seek: 0, end: 20,
+			want: []cursors.FloatBlockStats{first, second},
+			next: &tsdb.FloatArray{Timestamps: []int64{}, Values: []float64{}},
+		},
+		{
+			name: "block after end",
+			seek: 0, end: 12,
+			want: []cursors.FloatBlockStats{first},
+			next: &tsdb.FloatArray{Timestamps: []int64{10, 11, 12}, Values: []float64{1, 1, 3}},
+		},
+		{
+			name: "block before seek",
+			seek: 1, end: 20,
+			next: &tsdb.FloatArray{Timestamps: []int64{1, 2, 3}, Values: []float64{2, 8, 2}},
+		},
+		{
+			name: "cache overlaps block",
+			seek: 0, end: 20,
+			cache: Values{NewValue(2, 9.0)},
+			next:  &tsdb.FloatArray{Timestamps: []int64{0, 1, 2, 3}, Values: []float64{4, 2, 9, 2}},
+		},
+		{
+			name: "cache after blocks",
+			seek: 0, end: 20,
+			cache: Values{NewValue(15, 9.0)},
+			want:  []cursors.FloatBlockStats{first, second},
+			next:  &tsdb.FloatArray{Timestamps: []int64{15}, Values: []float64{9}},
+		},
+		{
+			name: "tombstone",
+			seek: 0, end: 20,
+			delete: []TimeRange{{Min: 2, Max: 2}},
+			next:   &tsdb.FloatArray{Timestamps: []int64{0, 1, 3}, Values: []float64{4, 2, 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs, cleanup := newBlockStatsFileStore(t)
+			defer cleanup()
+			for _, r := range tt.delete {
+				if err := fs.DeleteRange([][]byte{[]byte("cpu")}, r.Min, r.Max); err != nil {
+					t.Fatalf("unexpected error deleting: %v", err)
+				}
+			}
+
+			c := newFloatArrayAscendingCursor()
+			c.reset(tt.seek, tt.end, tt.cache, fs.KeyCursor(context.Background(), []byte("cpu"), tt.seek, true))
+			defer c.Close()
+
+			var got []cursors.FloatBlockStats
+			for {
+				s, ok := c.PeekBlockStats()
+				if !ok {
+					break
+				}
+				got = append(got, s)
+				c.SkipBlock()
+			}
+			if !cmp.Equal(got, tt.want) {
+				t.Errorf("unexpected block stats -want/+got:\n%s", cmp.Diff(tt.want, got))
+			}
+
+			if next := c.Next(); !cmp.Equal(next, tt.next) {
+				t.Errorf("unexpected next points -want/+got:\n%s", cmp.Diff(tt.next, next))
+			}
+		})
+	}
+}
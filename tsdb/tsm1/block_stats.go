@@ -0,0 +1,178 @@
+package tsm1
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/influxdata/platform/tsdb"
+	"github.com/influxdata/platform/tsdb/cursors"
+)
+
+// BlockStats summarizes the values of a block of float, integer or unsigned values,
+// so that aggregates over the whole block can be answered without decoding it.
+//
+// Sum, Min and Max hold the bits of values of the type of the block: the
+// math.Float64bits of a float, the two's complement of an integer, or an unsigned.
+type BlockStats struct {
+	// Count is the number of values in the block. A block without statistics has a Count of 0.
+	Count int64
+
+	// Sum is the sum of the values.
+	Sum uint64
+
+	// Min and Max are the least and greatest values, found first at MinTime and MaxTime.
+	Min, Max         uint64
+	MinTime, MaxTime int64
+}
+
+// UnmarshalBinary decodes BlockStats from a byte slice.
+func (s *BlockStats) UnmarshalBinary(b []byte) error {
+	if len(b) < blockStatsSize {
+		return fmt.Errorf("unmarshalBinary: short buf: %v < %v", len(b), blockStatsSize)
+	}
+	s.Count = int64(binary.BigEndian.Uint64(b[:8]))
+	s.Sum = binary.BigEndian.Uint64(b[8:16])
+	s.Min = binary.BigEndian.Uint64(b[16:24])
+	s.MinTime = int64(binary.BigEndian.Uint64(b[24:32]))
+	s.Max = binary.BigEndian.Uint64(b[32:40])
+	s.MaxTime = int64(binary.BigEndian.Uint64(b[40:48]))
+	return nil
+}
+
+// AppendTo writes a binary-encoded version of BlockStats to b, allocating
+// and returning a new slice, if necessary.
+func (s *BlockStats) AppendTo(b []byte) []byte {
+	if len(b) < blockStatsSize {
+		if cap(b) < blockStatsSize {
+			b = make([]byte, blockStatsSize)
+		} else {
+			b = b[:blockStatsSize]
+		}
+	}
+
+	binary.BigEndian.PutUint64(b[:8], uint64(s.Count))
+	binary.BigEndian.PutUint64(b[8:16], s.Sum)
+	binary.BigEndian.PutUint64(b[16:24], s.Min)
+	binary.BigEndian.PutUint64(b[24:32], uint64(s.MinTime))
+	binary.BigEndian.PutUint64(b[32:40], s.Max)
+	binary.BigEndian.PutUint64(b[40:48], uint64(s.MaxTime))
+
+	return b
+}
+
+// blockStatsDecoder computes the statistics of blocks, reusing the arrays it decodes them into.
+type blockStatsDecoder struct {
+	floats    tsdb.FloatArray
+	integers  tsdb.IntegerArray
+	unsigneds tsdb.UnsignedArray
+}
+
+// blockStats decodes block and returns its statistics. Blocks of strings
+// and booleans have no statistics.
+func (d *blockStatsDecoder) blockStats(block []byte) (BlockStats, error) {
+	var s BlockStats
+	switch block[0] {
+	case BlockFloat64:
+		if err := DecodeFloatArrayBlock(block, &d.floats); err != nil {
+			return s, err
+		}
+		a := &d.floats
+		s.Count = int64(a.Len())
+		var sum float64
+		min, max := a.Values[0], a.Values[0]
+		s.MinTime, s.MaxTime = a.Timestamps[0], a.Timestamps[0]
+		for i, v := range a.Values {
+			sum += v
+			if v < min {
+				min, s.MinTime = v, a.Timestamps[i]
+			}
+			if v > max {
+				max, s.MaxTime = v, a.Timestamps[i]
+			}
+		}
+		s.Sum, s.Min, s.Max = math.Float64bits(sum), math.Float64bits(min), math.Float64bits(max)
+
+	case BlockInteger:
+		if err := DecodeIntegerArrayBlock(block, &d.integers); err != nil {
+			return s, err
+		}
+		a := &d.integers
+		s.Count = int64(a.Len())
+		var sum int64
+		min, max := a.Values[0], a.Values[0]
+		s.MinTime, s.MaxTime = a.Timestamps[0], a.Timestamps[0]
+		for i, v := range a.Values {
+			sum += v
+			if v < min {
+				min, s.MinTime = v, a.Timestamps[i]
+			}
+			if v > max {
+				max, s.MaxTime = v, a.Timestamps[i]
+			}
+		}
+		s.Sum, s.Min, s.Max = uint64(sum), uint64(min), uint64(max)
+
+	case BlockUnsigned:
+		if err := DecodeUnsignedArrayBlock(block, &d.unsigneds); err != nil {
+			return s, err
+		}
+		a := &d.unsigneds
+		s.Count = int64(a.Len())
+		min, max := a.Values[0], a.Values[0]
+		s.MinTime, s.MaxTime = a.Timestamps[0], a.Timestamps[0]
+		for i, v := range a.Values {
+			s.Sum += v
+			if v < min {
+				min, s.MinTime = v, a.Timestamps[i]
+			}
+			if v > max {
+				max, s.MaxTime = v, a.Timestamps[i]
+			}
+		}
+		s.Min, s.Max = min, max
+	}
+	return s, nil
+}
+
+// floatBlockStats returns the statistics of the float block of e.
+func floatBlockStats(e *IndexEntry) cursors.FloatBlockStats {
+	return cursors.FloatBlockStats{
+		FirstTime: e.MinTime,
+		LastTime:  e.MaxTime,
+		Count:     e.Stats.Count,
+		Sum:       math.Float64frombits(e.Stats.Sum),
+		Min:       math.Float64frombits(e.Stats.Min),
+		Max:       math.Float64frombits(e.Stats.Max),
+		MinTime:   e.Stats.MinTime,
+		MaxTime:   e.Stats.MaxTime,
+	}
+}
+
+// integerBlockStats returns the statistics of the integer block of e.
+func integerBlockStats(e *IndexEntry) cursors.IntegerBlockStats {
+	return cursors.IntegerBlockStats{
+		FirstTime: e.MinTime,
+		LastTime:  e.MaxTime,
+		Count:     e.Stats.Count,
+		Sum:       int64(e.Stats.Sum),
+		Min:       int64(e.Stats.Min),
+		Max:       int64(e.Stats.Max),
+		MinTime:   e.Stats.MinTime,
+		MaxTime:   e.Stats.MaxTime,
+	}
+}
+
+// unsignedBlockStats returns the statistics of the unsigned block of e.
+func unsignedBlockStats(e *IndexEntry) cursors.UnsignedBlockStats {
+	return cursors.UnsignedBlockStats{
+		FirstTime: e.MinTime,
+		LastTime:  e.MaxTime,
+		Count:     e.Stats.Count,
+		Sum:       e.Stats.Sum,
+		Min:       e.Stats.Min,
+		Max:       e.Stats.Max,
+		MinTime:   e.Stats.MinTime,
+		MaxTime:   e.Stats.MaxTime,
+	}
+}
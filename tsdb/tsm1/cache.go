@@ -30,7 +30,22 @@ var (
 // ErrCacheMemorySizeLimitExceeded returns an error indicating an operation
 // could not be completed due to exceeding the cache-max-memory-size setting.
 func ErrCacheMemorySizeLimitExceeded(n, limit uint64) error {
-	return fmt.Errorf("cache-max-memory-size exceeded: (%d/%d)", n, limit)
+	return &cacheFullError{n: n, limit: limit}
+}
+
+type cacheFullError struct {
+	n, limit uint64
+}
+
+func (e *cacheFullError) Error() string {
+	return fmt.Sprintf("cache-max-memory-size exceeded: (%d/%d)", e.n, e.limit)
+}
+
+// IsCacheFull returns true if err indicates that a write exceeded the
+// cache-max-memory-size setting.
+func IsCacheFull(err error) bool {
+	_, ok := err.(*cacheFullError)
+	return ok
 }
 
 // entry is a set of values and some metadata.
@@ -165,6 +180,13 @@ type Cache struct {
 	store   storer
 	maxSize uint64
 
+	// maxWriteWait is how long admit waits for memory to be freed when the
+	// cache is full. waiting is the number of writes waiting, and freed is
+	// closed and reset when memory is freed.
+	maxWriteWait time.Duration
+	waiting      int32
+	freed        chan struct{}
+
 	// snapshots are the cache objects that are currently being written to tsm files
 	// they're kept in memory while flushing so they can be queried along with the cache.
 	// they are read only and should never be modified
@@ -403,6 +425,7 @@ func (c *Cache) ClearSnapshot(success bool) {
 		snapshotSize := c.tracker.SnapshotSize()
 		c.tracker.SetSnapshotsActive(0)
 		c.tracker.SubMemBytes(snapshotSize) // decrement the number of bytes in cache
+		defer c.notifyFreed()
 
 		// Reset the snapshot to a fresh Cache.
 		c.snapshot = &Cache{
@@ -590,15 +613,98 @@ func (c *Cache) DeleteRange(keys [][]byte, min, max int64) {
 	}
 	c.tracker.DecCacheSize(total) // Decrease the live cache size.
 	c.tracker.SetMemBytes(uint64(c.Size()))
+	c.notifyFreed()
 }
 
 // SetMaxSize updates the memory limit of the cache.
 func (c *Cache) SetMaxSize(size uint64) {
 	c.mu.Lock()
 	c.maxSize = size
+	c.notifyFreed()
 	c.mu.Unlock()
 }
 
+// SetMaxWriteWait sets how long writes admitted by the engine wait for
+// snapshots to free memory when the cache is full. Zero rejects such writes
+// immediately.
+func (c *Cache) SetMaxWriteWait(d time.Duration) {
+	c.mu.Lock()
+	c.maxWriteWait = d
+	c.mu.Unlock()
+}
+
+// Full returns true if the cache has reached its memory limit or writes are
+// waiting for memory to be freed.
+func (c *Cache) Full() bool {
+	return atomic.LoadInt32(&c.waiting) > 0 || c.maxSize > 0 && c.Size() >= c.maxSize
+}
+
+// admit waits until the cache has room for values, returning an error if
+// it does not within the max write wait of the cache.
+func (c *Cache) admit(values map[string][]Value) error {
+	limit := c.maxSize // maxSize is safe for reading without a lock.
+	if limit == 0 {
+		return nil
+	}
+
+	var addedSize uint64
+	for _, v := range values {
+		addedSize += uint64(Values(v).Size())
+	}
+	if c.Size()+addedSize <= limit {
+		return nil
+	}
+
+	c.mu.RLock()
+	wait := c.maxWriteWait
+	c.mu.RUnlock()
+
+	atomic.AddInt32(&c.waiting, 1)
+	defer atomic.AddInt32(&c.waiting, -1)
+
+	start := time.Now()
+	defer func() { c.tracker.ObserveWriteWait(time.Since(start)) }()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for {
+		// Take the channel before checking the size so that memory freed in
+		// between is not missed.
+		freed := c.freedC()
+		n := c.Size() + addedSize
+		if n <= limit {
+			return nil
+		}
+
+		select {
+		case <-freed:
+		case <-timer.C:
+			c.tracker.IncWritesErr()
+			c.tracker.AddWrittenBytesDrop(addedSize)
+			return ErrCacheMemorySizeLimitExceeded(n, limit)
+		}
+	}
+}
+
+// freedC returns a channel closed the next time memory of the cache is freed.
+func (c *Cache) freedC() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.freed == nil {
+		c.freed = make(chan struct{})
+	}
+	return c.freed
+}
+
+// notifyFreed wakes the writes waiting for memory. c.mu must be held.
+func (c *Cache) notifyFreed() {
+	if c.freed != nil {
+		close(c.freed)
+		c.freed = nil
+	}
+}
+
 // values returns the values for the key. It assumes the data is already sorted.
 // It doesn't lock the cache but it does read-lock the entry if there is one for the key.
 // values should only be used in compact.go in the CacheKeyIterator.
@@ -623,6 +729,25 @@ func (c *Cache) ApplyEntryFn(f func(key []byte, entry *entry) error) error {
 	return store.applySerial(f)
 }
 
+// ApplyEntryFnWithSnapshot applies the function f to each entry in the Cache,
+// and then to each entry in the snapshot being written, if any.
+func (c *Cache) ApplyEntryFnWithSnapshot(f func(key []byte, entry *entry) error) error {
+	if err := c.ApplyEntryFn(f); err != nil {
+		return err
+	}
+
+	c.mu.RLock()
+	var store storer
+	if c.snapshot != nil {
+		store = c.snapshot.store
+	}
+	c.mu.RUnlock()
+	if store == nil {
+		return nil
+	}
+	return store.applySerial(f)
+}
+
 // CacheLoader processes a set of WAL segment files, and loads a cache with the data
 // contained within those files.  Processing of the supplied files take place in the
 // order they exist in the files slice.
@@ -723,6 +848,14 @@ func (c *Cache) UpdateAge() {
 	c.tracker.SetAge(time.Since(c.lastSnapshot))
 }
 
+// UpdatePressure updates the pressure statistic, the ratio of the size of the
+// cache to its memory limit.
+func (c *Cache) UpdatePressure() {
+	if limit := c.maxSize; limit > 0 {
+		c.tracker.SetPressure(float64(c.Size()) / float64(limit))
+	}
+}
+
 // cacheTracker tracks writes to the cache and snapshots.
 //
 // As well as being responsible for providing atomic reads and writes to the
@@ -883,6 +1016,18 @@ func (t *cacheTracker) SetAge(d time.Duration) {
 	t.metrics.Age.With(labels).Set(d.Seconds())
 }
 
+// SetPressure sets the ratio of the size of the cache to its memory limit.
+func (t *cacheTracker) SetPressure(ratio float64) {
+	labels := t.Labels()
+	t.metrics.Pressure.With(labels).Set(ratio)
+}
+
+// ObserveWriteWait records the time a write waited for memory to be freed.
+func (t *cacheTracker) ObserveWriteWait(d time.Duration) {
+	labels := t.Labels()
+	t.metrics.WriteWait.With(labels).Observe(d.Seconds())
+}
+
 func valueType(v Value) byte {
 	switch v.(type) {
 	case FloatValue:
@@ -14,6 +14,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/snappy"
 )
@@ -563,6 +564,49 @@ func TestCache_CacheWriteMemoryExceeded(t *testing.T) {
 	}
 }
 
+func TestCache_AdmitWaitsForMemory(t *testing.T) {
+	v0 := NewValue(1, 1.0)
+	v1 := NewValue(2, 2.0)
+	values := map[string][]Value{"bar": {v1}}
+
+	c := NewCache(uint64(v1.Size()))
+	if err := c.Write([]byte("foo"), Values{v0}); err != nil {
+		t.Fatalf("failed to write key foo to cache: %s", err.Error())
+	}
+
+	// Without a write wait, a full cache rejects writes immediately.
+	if err := c.admit(values); !IsCacheFull(err) {
+		t.Fatalf("wrong error admitting key bar to cache: %v", err)
+	}
+
+	// The write is rejected if no memory is freed in time.
+	c.SetMaxWriteWait(10 * time.Millisecond)
+	if err := c.admit(values); !IsCacheFull(err) {
+		t.Fatalf("wrong error admitting key bar to cache: %v", err)
+	}
+
+	// The write is admitted once a snapshot frees memory.
+	c.SetMaxWriteWait(time.Minute)
+	if _, err := c.Snapshot(); err != nil {
+		t.Fatalf("failed to snapshot cache: %v", err)
+	}
+
+	errC := make(chan error, 1)
+	go func() { errC <- c.admit(values) }()
+
+	for atomic.LoadInt32(&c.waiting) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	c.ClearSnapshot(true)
+
+	if err := <-errC; err != nil {
+		t.Fatalf("failed to admit key bar to cache: %v", err)
+	}
+	if c.Full() {
+		t.Fatal("cache should not be full after clearing the snapshot")
+	}
+}
+
 func TestCache_Deduplicate_Concurrent(t *testing.T) {
 	if testing.Short() || os.Getenv("GORACE") != "" || os.Getenv("APPVEYOR") != "" {
 		t.Skip("Skipping test in short, race, appveyor mode.")
@@ -695,6 +695,9 @@ type Compactor struct {
 	// RateLimit is the limit for disk writes for all concurrent compactions.
 	RateLimit limiter.Rate
 
+	// Partitioner, if set, splits snapshots into one generation per partition.
+	Partitioner Partitioner
+
 	formatFileName FormatFileNameFunc
 	parseFileName  ParseFileNameFunc
 
@@ -836,26 +839,40 @@ func (c *Compactor) WriteSnapshot(cache *Cache) ([]string, error) {
 		throttle = false
 	}
 
-	splits := cache.Split(concurrency)
+	// When partitioned, each partition is written to its own generation so
+	// that no file holds the values of more than one partition.
+	var splits []*Cache
+	if c.Partitioner != nil {
+		var err error
+		if splits, err = splitByPartition(cache, c.Partitioner); err != nil {
+			return nil, err
+		}
+	} else {
+		splits = cache.Split(concurrency)
+	}
 
 	type res struct {
 		files []string
 		err   error
 	}
 
-	resC := make(chan res, concurrency)
-	for i := 0; i < concurrency; i++ {
+	resC := make(chan res, len(splits))
+	sem := make(chan struct{}, concurrency)
+	for _, sp := range splits {
 		go func(sp *Cache) {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
 			iter := NewCacheKeyIterator(sp, MaxPointsPerBlock, intC)
 			files, err := c.writeNewFiles(c.FileStore.NextGeneration(), 0, nil, iter, throttle)
 			resC <- res{files: files, err: err}
 
-		}(splits[i])
+		}(sp)
 	}
 
 	var err error
-	files := make([]string, 0, concurrency)
-	for i := 0; i < concurrency; i++ {
+	files := make([]string, 0, len(splits))
+	for range splits {
 		result := <-resC
 		if result.err != nil {
 			err = result.err
@@ -1156,7 +1173,7 @@ func (c *Compactor) write(path string, iter KeyIterator, throttle bool) (err err
 		}
 
 		// Write the key and value
-		if err := w.WriteBlock(key, minTime, maxTime, block); err == ErrMaxBlocksExceeded {
+		if err := w.WriteBlockWithStats(key, minTime, maxTime, block, iter.BlockStats()); err == ErrMaxBlocksExceeded {
 			if err := w.WriteIndex(); err != nil {
 				return err
 			}
@@ -1223,6 +1240,10 @@ type KeyIterator interface {
 	// or any error that occurred.
 	Read() (key []byte, minTime int64, maxTime int64, data []byte, err error)
 
+	// BlockStats returns the statistics of the block last returned by Read, with
+	// a Count of 0 if they are not known.
+	BlockStats() BlockStats
+
 	// Close closes the iterator.
 	Close() error
 
@@ -1292,6 +1313,10 @@ type block struct {
 	b                []byte
 	tombstones       []TimeRange
 
+	// stats are the statistics of b from the index of its file. They are
+	// zero for blocks re-encoded by the compaction.
+	stats BlockStats
+
 	// readMin, readMax are the timestamps range of values have been
 	// read and encoded from this block.
 	readMin, readMax int64
@@ -1431,6 +1456,7 @@ RETRY:
 				blk.key = key
 				blk.typ = typ
 				blk.b = b
+				blk.stats = iter.BlockStats()
 				blk.tombstones = tombstones
 				blk.readMin = math.MaxInt64
 				blk.readMax = math.MinInt64
@@ -1463,6 +1489,7 @@ RETRY:
 					blk.key = key
 					blk.typ = typ
 					blk.b = b
+					blk.stats = iter.BlockStats()
 					blk.tombstones = tombstones
 					blk.readMin = math.MaxInt64
 					blk.readMax = math.MinInt64
@@ -1553,6 +1580,14 @@ func (k *tsmKeyIterator) Read() ([]byte, int64, int64, []byte, error) {
 	return block.key, block.minTime, block.maxTime, block.b, k.err
 }
 
+// BlockStats returns the statistics of the block last returned by Read.
+func (k *tsmKeyIterator) BlockStats() BlockStats {
+	if len(k.merged) == 0 {
+		return BlockStats{}
+	}
+	return k.merged[0].stats
+}
+
 func (k *tsmKeyIterator) Close() error {
 	k.values = nil
 	k.pos = nil
@@ -1723,6 +1758,7 @@ RETRY:
 			blk.key = key
 			blk.typ = typ
 			blk.b = b
+			blk.stats = iter.BlockStats()
 			blk.tombstones = tombstones
 			blk.readMin = math.MaxInt64
 			blk.readMax = math.MinInt64
@@ -1755,6 +1791,7 @@ RETRY:
 				blk.key = key
 				blk.typ = typ
 				blk.b = b
+				blk.stats = iter.BlockStats()
 				blk.tombstones = tombstones
 				blk.readMin = math.MaxInt64
 				blk.readMax = math.MinInt64
@@ -1844,6 +1881,14 @@ func (k *tsmBatchKeyIterator) Read() ([]byte, int64, int64, []byte, error) {
 	return block.key, block.minTime, block.maxTime, block.b, k.err
 }
 
+// BlockStats returns the statistics of the block last returned by Read.
+func (k *tsmBatchKeyIterator) BlockStats() BlockStats {
+	if len(k.merged) == 0 {
+		return BlockStats{}
+	}
+	return k.merged[0].stats
+}
+
 func (k *tsmBatchKeyIterator) Close() error {
 	k.values = nil
 	k.pos = nil
@@ -2022,6 +2067,12 @@ func (c *cacheKeyIterator) Read() ([]byte, int64, int64, []byte, error) {
 	return blk.k, blk.minTime, blk.maxTime, blk.b, blk.err
 }
 
+// BlockStats returns no statistics, as the blocks are encoded from the cache
+// and their statistics are computed when they are written.
+func (c *cacheKeyIterator) BlockStats() BlockStats {
+	return BlockStats{}
+}
+
 func (c *cacheKeyIterator) Close() error {
 	return nil
 }
@@ -1133,6 +1133,59 @@ func TestTSMKeyIterator_Single(t *testing.T) {
 	}
 }
 
+// Tests that the statistics of blocks copied as is are taken from the index of
+// their file, and that re-encoded blocks have none.
+func TestTSMKeyIterator_BlockStats(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		fn   func(size int, fast bool, interrupt chan struct{}, readers ...*tsm1.TSMReader) (tsm1.KeyIterator, error)
+	}{
+		{name: "tsm", fn: tsm1.NewTSMKeyIterator},
+		{name: "tsm batch", fn: tsm1.NewTSMBatchKeyIterator},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := MustTempDir()
+			defer os.RemoveAll(dir)
+
+			r1 := MustTSMReader(dir, 1, map[string][]tsm1.Value{
+				"cpu,host=A#!~#value": {tsm1.NewValue(1, 1.5), tsm1.NewValue(2, 2.5)},
+				"mem,host=A#!~#value": {tsm1.NewValue(1, 1.0)},
+			})
+			r2 := MustTSMReader(dir, 2, map[string][]tsm1.Value{
+				"mem,host=A#!~#value": {tsm1.NewValue(1, 2.0)},
+			})
+
+			iter, err := tt.fn(1000, false, nil, r1, r2)
+			if err != nil {
+				t.Fatalf("unexpected error creating key iterator: %v", err)
+			}
+
+			exp := map[string]tsm1.BlockStats{
+				// Only in r1, so copied as is.
+				"cpu,host=A#!~#value": r1.Entries([]byte("cpu,host=A#!~#value"))[0].Stats,
+				// Merged from r1 and r2, so re-encoded.
+				"mem,host=A#!~#value": {},
+			}
+			if exp["cpu,host=A#!~#value"].Count != 2 {
+				t.Fatalf("expected the statistics of 2 values in the index, got %+v", exp["cpu,host=A#!~#value"])
+			}
+			for iter.Next() {
+				key, _, _, _, err := iter.Read()
+				if err != nil {
+					t.Fatalf("unexpected error read: %v", err)
+				}
+				if got := iter.BlockStats(); !cmp.Equal(got, exp[string(key)]) {
+					t.Fatalf("unexpected stats of %s -exp/+got:\n%s", key, cmp.Diff(exp[string(key)], got))
+				}
+				delete(exp, string(key))
+			}
+			if len(exp) != 0 {
+				t.Fatalf("keys not read: %v", exp)
+			}
+		})
+	}
+}
+
 // Tests that duplicate point values are merged.  There is only one case
 // where this could happen and that is when a compaction completed and we replace
 // the old TSM file with a new one and we crash just before deleting the old file.
@@ -37,6 +37,7 @@ func NewConfig() Config {
 
 		Cache: CacheConfig{
 			MaxMemorySize:             toml.Size(DefaultCacheMaxMemorySize),
+			MaxWriteWait:              toml.Duration(DefaultCacheMaxWriteWait),
 			SnapshotMemorySize:        toml.Size(DefaultCacheSnapshotMemorySize),
 			SnapshotWriteColdDuration: toml.Duration(DefaultCacheSnapshotWriteColdDuration),
 		},
@@ -83,6 +84,7 @@ const (
 	DefaultCacheMaxMemorySize             = 1024 * 1024 * 1024 // 1GB
 	DefaultCacheSnapshotMemorySize        = 25 * 1024 * 1024   // 25MB
 	DefaultCacheSnapshotWriteColdDuration = time.Duration(10 * time.Minute)
+	DefaultCacheMaxWriteWait              = time.Duration(5 * time.Second)
 )
 
 // CacheConfig holds all of the configuration for the in memory cache of values that
@@ -92,6 +94,11 @@ type CacheConfig struct {
 	// rejecting writes.
 	MaxMemorySize toml.Size `toml:"max-memory-size"`
 
+	// MaxWriteWait is the length of time a write waits for snapshots to free
+	// memory when the cache has reached MaxMemorySize, before it is rejected.
+	// A value of 0 rejects such writes immediately.
+	MaxWriteWait toml.Duration `toml:"max-write-wait"`
+
 	// SnapshotMemorySize is the size at which the engine will snapshot the cache and
 	// write it to a TSM file, freeing up memory
 	SnapshotMemorySize toml.Size `toml:"snapshot-memory-size"`
@@ -105,6 +105,13 @@ var WithCompactionPlanner = func(planner CompactionPlanner) EngineOption {
 	}
 }
 
+// WithPartitioner partitions the TSM files of the engine by p.
+var WithPartitioner = func(p Partitioner) EngineOption {
+	return func(e *Engine) {
+		e.WithPartitioner(p)
+	}
+}
+
 // Engine represents a storage engine with compressed blocks.
 type Engine struct {
 	mu sync.RWMutex
@@ -149,6 +156,10 @@ type Engine struct {
 	// a snapshot of the cache to a TSM file
 	CacheFlushWriteColdDuration time.Duration
 
+	// compactFullWriteColdDuration is the write cold duration of the
+	// planners created by WithPartitioner.
+	compactFullWriteColdDuration time.Duration
+
 	// Invoked when creating a backup file "as new".
 	formatFileName FormatFileNameFunc
 
@@ -171,6 +182,7 @@ func NewEngine(path string, idx *tsi1.Index, config Config, options ...EngineOpt
 	fs.tsmMMAPWillNeed = config.MADVWillNeed
 
 	cache := NewCache(uint64(config.Cache.MaxMemorySize))
+	cache.SetMaxWriteWait(time.Duration(config.Cache.MaxWriteWait))
 
 	c := NewCompactor()
 	c.Dir = path
@@ -217,6 +229,7 @@ func NewEngine(path string, idx *tsi1.Index, config Config, options ...EngineOpt
 
 		CacheFlushMemorySizeThreshold: uint64(config.Cache.SnapshotMemorySize),
 		CacheFlushWriteColdDuration:   time.Duration(config.Cache.SnapshotWriteColdDuration),
+		compactFullWriteColdDuration:  time.Duration(config.Compaction.FullWriteColdDuration),
 		enableCompactionsOnOpen:       true,
 		formatFileName:                DefaultFormatFileName,
 		compactionLimiter:             limiter.NewFixed(maxCompactions),
@@ -249,6 +262,14 @@ func (e *Engine) WithCompactionPlanner(planner CompactionPlanner) {
 	e.CompactionPlan = planner
 }
 
+// WithPartitioner makes snapshots write the values of each partition of p to
+// files of their own, and the engine compact the files of each partition
+// separately. It replaces the compaction planner of the engine.
+func (e *Engine) WithPartitioner(p Partitioner) {
+	e.Compactor.Partitioner = p
+	e.CompactionPlan = NewPartitionedPlanner(e.FileStore, p, e.compactFullWriteColdDuration)
+}
+
 // SetDefaultMetricLabels sets the default labels for metrics on the engine.
 // It must be called before the Engine is opened.
 func (e *Engine) SetDefaultMetricLabels(labels prometheus.Labels) {
@@ -648,6 +669,12 @@ func (e *Engine) WritePoints(points []models.Point) error {
 		}
 	}
 
+	// Wait for room in the cache before taking the engine lock, which the
+	// snapshots freeing memory need.
+	if err := e.Cache.admit(values); err != nil {
+		return err
+	}
+
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 
@@ -920,21 +947,12 @@ func (e *Engine) deleteSeriesRange(seriesKeys [][]byte, min, max int64) error {
 	// Have we deleted all values for the series? If so, we need to remove
 	// the series from the index.
 	if len(seriesKeys) > 0 {
-		buf := make([]byte, 1024) // For use when accessing series file.
-		ids := tsdb.NewSeriesIDSet()
-		measurements := make(map[string]struct{}, 1)
-
+		dropKeys := seriesKeys[:0]
 		for _, k := range seriesKeys {
 			if len(k) == 0 {
 				continue // This key was wiped because it shouldn't be removed from index.
 			}
 
-			name, tags := models.ParseKeyBytes(k)
-			sid := e.sfile.SeriesID(name, tags, buf)
-			if sid.IsZero() {
-				continue
-			}
-
 			// See if this series was found in the cache earlier
 			i := bytesutil.SearchBytes(deleteKeys, k)
 
@@ -952,37 +970,137 @@ func (e *Engine) deleteSeriesRange(seriesKeys [][]byte, min, max int64) error {
 			if hasCacheValues {
 				continue
 			}
+			dropKeys = append(dropKeys, k)
+		}
 
-			measurements[string(name)] = struct{}{}
-			// Remove the series from the local index.
-			if err := e.index.DropSeries(sid, k, false); err != nil {
-				return err
-			}
+		if _, err := e.dropSeries(dropKeys); err != nil {
+			return err
+		}
+	}
 
-			// Add the id to the set of delete ids.
-			ids.Add(sid)
+	return nil
+}
+
+// ReclaimSeries removes the series of seriesKeys without values in the cache
+// or the TSM files from the index and the series file, and returns the number
+// of series removed. seriesKeys must be sorted. Writes to the series must be
+// held back by the caller, or values written while they are removed would not
+// be indexed.
+func (e *Engine) ReclaimSeries(seriesKeys [][]byte) (int, error) {
+	keys, err := e.SeriesWithoutValues(seriesKeys)
+	if err != nil {
+		return 0, err
+	}
+	return e.dropSeries(keys)
+}
+
+// SeriesWithoutValues returns the keys of seriesKeys of the series without
+// values in the cache or the TSM files. seriesKeys must be sorted.
+func (e *Engine) SeriesWithoutValues(seriesKeys [][]byte) ([][]byte, error) {
+	if len(seriesKeys) == 0 {
+		return nil, nil
+	}
+
+	var mu sync.Mutex
+	found := make([]bool, len(seriesKeys))
+	mark := func(i int) {
+		mu.Lock()
+		found[i] = true
+		mu.Unlock()
+	}
+
+	// Values move from the cache to its snapshot and from the snapshot to the
+	// TSM files, so they are looked up in that order to not miss the values of
+	// a snapshot written concurrently.
+	if err := e.Cache.ApplyEntryFnWithSnapshot(func(k []byte, entry *entry) error {
+		seriesKey, _ := SeriesAndFieldFromCompositeKey(k)
+		i := bytesutil.SearchBytes(seriesKeys, seriesKey)
+		if i < len(seriesKeys) && bytes.Equal(seriesKey, seriesKeys[i]) && entry.count() > 0 {
+			mark(i)
 		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
 
-		for k := range measurements {
-			if err := e.index.DropMeasurementIfSeriesNotExist([]byte(k)); err != nil {
-				return err
+	if err := e.FileStore.Apply(func(r TSMFile) error {
+		minKey, maxKey := r.KeyRange()
+		minKey, _ = SeriesAndFieldFromCompositeKey(minKey)
+		maxKey, _ = SeriesAndFieldFromCompositeKey(maxKey)
+
+		n := r.KeyCount()
+		var prefix []byte
+		for i, seriesKey := range seriesKeys {
+			if bytes.Compare(seriesKey, minKey) < 0 {
+				continue
+			} else if bytes.Compare(seriesKey, maxKey) > 0 {
+				break
 			}
-		}
 
-		// Remove the remaining ids from the series file as they no longer exist
-		// in any shard.
-		var err error
-		ids.ForEach(func(id tsdb.SeriesID) {
-			if err1 := e.sfile.DeleteSeriesID(id); err1 != nil {
-				err = err1
+			// Seek to the first field of the series.
+			prefix = append(append(prefix[:0], seriesKey...), keyFieldSeparatorBytes...)
+			if j := r.Seek(prefix); j < n {
+				if key, _ := r.KeyAt(j); bytes.HasPrefix(key, prefix) {
+					mark(i)
+				}
 			}
-		})
-		if err != nil {
-			return err
 		}
+		return nil
+	}); err != nil {
+		return nil, err
 	}
 
-	return nil
+	var keys [][]byte
+	for i, k := range seriesKeys {
+		if !found[i] {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+// dropSeries removes the series of seriesKeys from the index and the series
+// file, and returns the number of series removed.
+func (e *Engine) dropSeries(seriesKeys [][]byte) (int, error) {
+	buf := make([]byte, 1024) // For use when accessing series file.
+	ids := tsdb.NewSeriesIDSet()
+	measurements := make(map[string]struct{}, 1)
+
+	for _, k := range seriesKeys {
+		name, tags := models.ParseKeyBytes(k)
+		sid := e.sfile.SeriesID(name, tags, buf)
+		if sid.IsZero() {
+			continue
+		}
+
+		measurements[string(name)] = struct{}{}
+		// Remove the series from the local index.
+		if err := e.index.DropSeries(sid, k, false); err != nil {
+			return 0, err
+		}
+
+		// Add the id to the set of delete ids.
+		ids.Add(sid)
+	}
+
+	for k := range measurements {
+		if err := e.index.DropMeasurementIfSeriesNotExist([]byte(k)); err != nil {
+			return 0, err
+		}
+	}
+
+	// Remove the remaining ids from the series file as they no longer exist
+	// in any shard.
+	var err error
+	ids.ForEach(func(id tsdb.SeriesID) {
+		if err1 := e.sfile.DeleteSeriesID(id); err1 != nil {
+			err = err1
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(ids.Cardinality()), nil
 }
 
 // DeleteMeasurement deletes a measurement and all related series.
@@ -1276,6 +1394,7 @@ func (e *Engine) compactCache() {
 
 		case <-t.C:
 			e.Cache.UpdateAge()
+			e.Cache.UpdatePressure()
 			if e.ShouldCompactCache(time.Now()) {
 				start := time.Now()
 				e.traceLogger.Info("Compacting cache", zap.String("path", e.path))
@@ -1631,6 +1750,51 @@ func (e *Engine) KeyCursor(ctx context.Context, key []byte, t int64, ascending b
 	return e.FileStore.KeyCursor(ctx, key, t, ascending)
 }
 
+// DropFiles removes the TSM files for which fn returns true, returning their
+// paths. Unlike deletes, no tombstones are written and the index is not
+// updated, so callers must ensure fn only matches files whose values are no
+// longer needed.
+func (e *Engine) DropFiles(fn func(FileStat) bool) ([]string, error) {
+	matching := func() []string {
+		var paths []string
+		for _, stat := range e.FileStore.Stats() {
+			if fn(stat) {
+				paths = append(paths, stat.Path)
+			}
+		}
+		return paths
+	}
+
+	if len(matching()) == 0 {
+		return nil, nil
+	}
+
+	// Stop level compactions so that the files are not being compacted, and
+	// match them again as compactions may have replaced them.
+	e.disableLevelCompactions(true)
+	defer e.enableLevelCompactions(true)
+
+	paths := matching()
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	if err := e.FileStore.Replace(paths, nil); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// HasValuesInRange returns true if the cache or the file store holds values
+// for key between min and max, inclusive.
+func (e *Engine) HasValuesInRange(key []byte, min, max int64) bool {
+	for _, v := range e.Cache.Values(key) {
+		if ts := v.UnixNano(); ts >= min && ts <= max {
+			return true
+		}
+	}
+	return e.FileStore.HasValuesInRange(key, min, max)
+}
+
 // IteratorCost produces the cost of an iterator.
 func (e *Engine) IteratorCost(measurement string, opt query.IteratorOptions) (query.IteratorCost, error) {
 	// Determine if this measurement exists. If it does not, then no shards are
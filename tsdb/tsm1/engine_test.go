@@ -7,6 +7,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
 	"sync"
@@ -154,6 +155,135 @@ func TestEngine_DeleteSeries(t *testing.T) {
 	}
 }
 
+func TestEngine_DropFiles(t *testing.T) {
+	p1 := MustParsePointString("cpu,host=A value=1.1 1000000000")
+	p2 := MustParsePointString("cpu,host=B value=1.2 2000000000")
+	p3 := MustParsePointString("cpu,host=A value=1.3 12000000000")
+
+	e, err := NewEngine()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e.WithPartitioner(measurementPartitioner{window: int64(10 * time.Second)})
+
+	// mock the planner so compactions don't run during the test
+	e.CompactionPlan = &mockPlanner{}
+	if err := e.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	if err := e.writePoints(p1, p2, p3); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+	if err := e.WriteSnapshot(); err != nil {
+		t.Fatalf("failed to snapshot: %s", err.Error())
+	}
+
+	if exp, got := 2, len(e.FileStore.Stats()); exp != got {
+		t.Fatalf("file count mismatch: exp %v, got %v", exp, got)
+	}
+
+	dropped, err := e.DropFiles(func(stat tsm1.FileStat) bool {
+		return stat.MaxTime < int64(10*time.Second)
+	})
+	if err != nil {
+		t.Fatalf("failed to drop files: %v", err)
+	}
+	if exp, got := 1, len(dropped); exp != got {
+		t.Fatalf("dropped file count mismatch: exp %v, got %v", exp, got)
+	}
+	if _, err := os.Stat(dropped[0]); !os.IsNotExist(err) {
+		t.Fatalf("dropped file still exists: %v", err)
+	}
+
+	stats := e.FileStore.Stats()
+	if exp, got := 1, len(stats); exp != got {
+		t.Fatalf("file count mismatch: exp %v, got %v", exp, got)
+	}
+	if exp, got := int64(12000000000), stats[0].MinTime; exp != got {
+		t.Fatalf("remaining file mismatch: exp min time %v, got %v", exp, got)
+	}
+
+	if dropped, err := e.DropFiles(func(tsm1.FileStat) bool { return false }); err != nil || len(dropped) != 0 {
+		t.Fatalf("unexpected drop: %v, %v", dropped, err)
+	}
+}
+
+func TestEngine_ReclaimSeries(t *testing.T) {
+	p1 := MustParsePointString("cpu,host=A value=1.1 1000000000")
+	p2 := MustParsePointString("cpu,host=B value=1.2 12000000000")
+	p3 := MustParsePointString("mem,host=C value=1.3 13000000000")
+
+	e, err := NewEngine()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e.WithPartitioner(measurementPartitioner{window: int64(10 * time.Second)})
+
+	// mock the planner so compactions don't run during the test
+	e.CompactionPlan = &mockPlanner{}
+	if err := e.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	if err := e.writePoints(p1, p2); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+	if err := e.WriteSnapshot(); err != nil {
+		t.Fatalf("failed to snapshot: %s", err.Error())
+	}
+	if err := e.writePoints(p3); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	// Drop the file holding the only values of cpu,host=A.
+	if _, err := e.DropFiles(func(stat tsm1.FileStat) bool {
+		return stat.MaxTime < int64(10*time.Second)
+	}); err != nil {
+		t.Fatalf("failed to drop files: %v", err)
+	}
+
+	id := e.sfile.SeriesID([]byte("cpu"), models.NewTags(map[string]string{"host": "A"}), nil)
+	keys := [][]byte{[]byte("cpu,host=A"), []byte("cpu,host=B"), []byte("mem,host=C")}
+
+	if got, err := e.SeriesWithoutValues(keys); err != nil {
+		t.Fatal(err)
+	} else if exp := keys[:1]; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("series without values mismatch: exp %q, got %q", exp, got)
+	}
+
+	if n, err := e.ReclaimSeries(keys); err != nil {
+		t.Fatalf("failed to reclaim series: %v", err)
+	} else if n != 1 {
+		t.Fatalf("reclaimed series count mismatch: exp 1, got %d", n)
+	}
+	if exp, got := uint64(2), e.SeriesIDSet().Cardinality(); exp != got {
+		t.Fatalf("series cardinality mismatch: exp %d, got %d", exp, got)
+	}
+	if !e.sfile.IsDeleted(id) {
+		t.Fatal("expected reclaimed series to be deleted from the series file")
+	}
+
+	// Writing a reclaimed series again indexes it with a new id.
+	if err := e.writePoints(MustParsePointString("cpu,host=A value=1.4 14000000000")); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+	newID := e.sfile.SeriesID([]byte("cpu"), models.NewTags(map[string]string{"host": "A"}), nil)
+	if newID.IsZero() || newID == id {
+		t.Fatalf("unexpected id for written series: %v", newID)
+	}
+	if exp, got := uint64(3), e.SeriesIDSet().Cardinality(); exp != got {
+		t.Fatalf("series cardinality mismatch: exp %d, got %d", exp, got)
+	}
+	if n, err := e.ReclaimSeries(keys); err != nil || n != 0 {
+		t.Fatalf("unexpected reclaim: %d, %v", n, err)
+	}
+}
+
 func TestEngine_DeleteSeriesRange(t *testing.T) {
 	// Create a few points.
 	p1 := MustParsePointString("cpu,host=0 value=1.1 6000000000") // Should not be deleted
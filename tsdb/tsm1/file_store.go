@@ -691,6 +691,39 @@ func (f *FileStore) Cost(key []byte, min, max int64) query.IteratorCost {
 	return f.cost(key, min, max)
 }
 
+// HasValuesInRange returns true if any file has a block of key overlapping min
+// and max, inclusive, that is not entirely deleted. Only the time ranges of the
+// blocks are compared, so it may return true when no value of a block falls
+// within min and max.
+func (f *FileStore) HasValuesInRange(key []byte, min, max int64) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var cache []IndexEntry
+	for _, fd := range f.files {
+		if !fd.OverlapsTimeRange(min, max) {
+			continue
+		}
+
+		tombstones := fd.TombstoneRange(key)
+	ENTRIES:
+		for _, ie := range fd.ReadEntries(key, &cache) {
+			if !ie.OverlapsTimeRange(min, max) {
+				continue
+			}
+
+			// Skip blocks that only contain values that are tombstoned.
+			for _, t := range tombstones {
+				if t.Min <= ie.MinTime && t.Max >= ie.MaxTime {
+					continue ENTRIES
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
 // Reader returns a TSMReader for path if one is currently managed by the FileStore.
 // Otherwise it returns nil. If it returns a file, you must call Unref on it when
 // you are done, and never use it after that.
@@ -1405,6 +1438,44 @@ func (c *KeyCursor) nextDescending() {
 	}
 }
 
+// blockStats returns the index entry of the current block, when the statistics of the
+// block summarize the next values of the cursor: the cursor is ascending, none of the
+// values of the block have been read, and no other block or tombstone overlaps it.
+func (c *KeyCursor) blockStats() (*IndexEntry, bool) {
+	if !c.ascending || len(c.current) == 0 {
+		return nil, false
+	}
+
+	first := c.current[0]
+	if first.entry.Stats.Count == 0 || first.readMax >= first.entry.MinTime {
+		return nil, false
+	}
+
+	for _, cur := range c.current[1:] {
+		if !cur.read() && cur.entry.OverlapsTimeRange(first.entry.MinTime, first.entry.MaxTime) {
+			return nil, false
+		}
+	}
+
+	for _, t := range first.r.TombstoneRange(c.key) {
+		if first.entry.OverlapsTimeRange(t.Min, t.Max) {
+			return nil, false
+		}
+	}
+
+	return &first.entry, true
+}
+
+// skipBlock marks the values of the current block as read and moves the cursor past it.
+func (c *KeyCursor) skipBlock() {
+	if len(c.current) == 0 {
+		return
+	}
+	first := c.current[0]
+	first.markRead(first.entry.MinTime, first.entry.MaxTime)
+	c.Next()
+}
+
 type purger struct {
 	mu        sync.RWMutex
 	fileStore *FileStore
@@ -53,6 +53,48 @@ func TestFileStore_Read(t *testing.T) {
 	}
 }
 
+func TestFileStore_HasValuesInRange(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	fs := tsm1.NewFileStore(dir)
+
+	// Setup 3 files
+	data := []keyValues{
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(0, 1.0), tsm1.NewValue(5, 2.0)}},
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(20, 3.0)}},
+		keyValues{"mem", []tsm1.Value{tsm1.NewValue(10, 1.0)}},
+	}
+
+	files, err := newFiles(dir, data...)
+	if err != nil {
+		t.Fatalf("unexpected error creating files: %v", err)
+	}
+
+	fs.Replace(nil, files)
+
+	if err := fs.DeleteRange([][]byte{[]byte("cpu")}, 15, 25); err != nil {
+		t.Fatalf("unexpected error deleting range: %v", err)
+	}
+
+	tests := []struct {
+		key      string
+		min, max int64
+		exp      bool
+	}{
+		{key: "cpu", min: 0, max: 0, exp: true},
+		{key: "cpu", min: 2, max: 3, exp: true},
+		{key: "cpu", min: 6, max: 30, exp: false},
+		{key: "mem", min: 10, max: 10, exp: true},
+		{key: "mem", min: 0, max: 9, exp: false},
+		{key: "disk", min: 0, max: 30, exp: false},
+	}
+	for _, tt := range tests {
+		if got := fs.HasValuesInRange([]byte(tt.key), tt.min, tt.max); got != tt.exp {
+			t.Errorf("HasValuesInRange(%s, %d, %d): got %v, exp %v", tt.key, tt.min, tt.max, got, tt.exp)
+		}
+	}
+}
+
 func TestFileStore_SeekToAsc_FromStart(t *testing.T) {
 	dir := MustTempDir()
 	defer os.RemoveAll(dir)
@@ -174,6 +174,8 @@ type cacheMetrics struct {
 	SnapshotsActive  *prometheus.GaugeVec
 	Age              *prometheus.GaugeVec
 	SnapshottedBytes *prometheus.CounterVec
+	Pressure         *prometheus.GaugeVec
+	WriteWait        *prometheus.HistogramVec
 
 	// The following metrics include a ``"status" = {ok, error, dropped}` label
 	WrittenBytes *prometheus.CounterVec
@@ -222,6 +224,19 @@ func newCacheMetrics(labels prometheus.Labels) *cacheMetrics {
 			Name:      "snapshot_bytes",
 			Help:      "Number of bytes snapshotted.",
 		}, names),
+		Pressure: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: cacheSubsystem,
+			Name:      "pressure_ratio",
+			Help:      "Ratio of the size of the cache to its maximum memory size.",
+		}, names),
+		WriteWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: cacheSubsystem,
+			Name:      "write_wait_seconds",
+			Help:      "Time writes waited for snapshots to free memory in a full cache.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+		}, names),
 		WrittenBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: cacheSubsystem,
@@ -245,6 +260,8 @@ func (m *cacheMetrics) PrometheusCollectors() []prometheus.Collector {
 		m.SnapshotsActive,
 		m.Age,
 		m.SnapshottedBytes,
+		m.Pressure,
+		m.WriteWait,
 		m.WrittenBytes,
 		m.Writes,
 	}
@@ -255,6 +272,7 @@ type walMetrics struct {
 	OldSegmentBytes     *prometheus.GaugeVec
 	CurrentSegmentBytes *prometheus.GaugeVec
 	Segments            *prometheus.GaugeVec
+	SyncWaiters         *prometheus.GaugeVec
 	Writes              *prometheus.CounterVec
 }
 
@@ -288,6 +306,12 @@ func newWALMetrics(labels prometheus.Labels) *walMetrics {
 			Name:      "segments_total",
 			Help:      "Number of WAL segment files on disk.",
 		}, names),
+		SyncWaiters: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: walSubsystem,
+			Name:      "sync_waiters",
+			Help:      "Number of writes waiting for the WAL to be synced to disk. Writes fail when too many are waiting.",
+		}, names),
 		Writes: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: walSubsystem,
@@ -303,6 +327,7 @@ func (m *walMetrics) PrometheusCollectors() []prometheus.Collector {
 		m.OldSegmentBytes,
 		m.CurrentSegmentBytes,
 		m.Segments,
+		m.SyncWaiters,
 		m.Writes,
 	}
 }
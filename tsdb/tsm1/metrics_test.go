@@ -147,6 +147,7 @@ func TestMetrics_WAL(t *testing.T) {
 		base + "old_segment_bytes",
 		base + "current_segment_bytes",
 		base + "segments_total",
+		base + "sync_waiters",
 	}
 
 	counters := []string{
@@ -158,6 +159,7 @@ func TestMetrics_WAL(t *testing.T) {
 		tracker.SetOldSegmentSize(uint64(i + len(gauges[0])))
 		tracker.SetCurrentSegmentSize(uint64(i + len(gauges[1])))
 		tracker.SetSegments(uint64(i + len(gauges[2])))
+		tracker.SetSyncWaiters(i + len(gauges[3]))
 
 		labels := tracker.Labels()
 		labels["status"] = "ok"
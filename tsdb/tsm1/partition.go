@@ -0,0 +1,222 @@
+package tsm1
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// A Partitioner assigns the values of the engine to partitions. When an engine
+// has a Partitioner, each TSM file written by a snapshot holds the values of a
+// single partition and files of different partitions are never compacted
+// together, so that the files of a partition can be removed as a whole.
+type Partitioner interface {
+	// Partition returns the partition of the value of key at time t. The keys
+	// of a group must form a contiguous range of keys.
+	Partition(key []byte, t int64) Partition
+}
+
+// Partition identifies a range of time of a group of keys.
+type Partition struct {
+	Group    string // Group identifies the keys of the partition.
+	Min, Max int64  // Min and Max are the first and last times of the partition.
+}
+
+// filePartition returns the partition of the file of stat according to p. Files
+// holding values of more than one partition, such as files written before p
+// was set, belong to the zero Partition.
+func filePartition(p Partitioner, stat FileStat) Partition {
+	min := p.Partition(stat.MinKey, stat.MinTime)
+	if max := p.Partition(stat.MaxKey, stat.MaxTime); max != min {
+		return Partition{}
+	}
+	return min
+}
+
+// splitByPartition splits the values of the cache c into one cache per
+// partition of p. The values of each key of c must be sorted and deduplicated.
+func splitByPartition(c *Cache, p Partitioner) ([]*Cache, error) {
+	stores := make(map[Partition]*ring)
+	var caches []*Cache
+
+	err := c.ApplyEntryFn(func(key []byte, e *entry) error {
+		e.mu.RLock()
+		values := e.values
+		e.mu.RUnlock()
+
+		for len(values) > 0 {
+			part := p.Partition(key, values[0].UnixNano())
+			n := sort.Search(len(values), func(i int) bool { return values[i].UnixNano() > part.Max })
+			if n == 0 {
+				n = 1 // Guard against a partition ending before its first value.
+			}
+
+			store := stores[part]
+			if store == nil {
+				var err error
+				if store, err = newring(ringShards); err != nil {
+					return err
+				}
+				stores[part] = store
+				caches = append(caches, &Cache{store: store})
+			}
+
+			if _, err := store.write(key, values[:n]); err != nil {
+				return err
+			}
+			values = values[n:]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return caches, nil
+}
+
+// PartitionedPlanner implements CompactionPlanner by planning the files of each
+// partition of a Partitioner with a DefaultPlanner of its own, so that files of
+// different partitions are never compacted together.
+type PartitionedPlanner struct {
+	partitioner       Partitioner
+	writeColdDuration time.Duration
+
+	mu           sync.Mutex
+	fs           fileStore
+	lastModified time.Time
+	planners     map[Partition]*DefaultPlanner
+}
+
+// NewPartitionedPlanner returns a planner of the files of fs partitioned by p.
+func NewPartitionedPlanner(fs fileStore, p Partitioner, writeColdDuration time.Duration) *PartitionedPlanner {
+	return &PartitionedPlanner{
+		partitioner:       p,
+		writeColdDuration: writeColdDuration,
+		fs:                fs,
+		planners:          make(map[Partition]*DefaultPlanner),
+	}
+}
+
+func (p *PartitionedPlanner) SetFileStore(fs *FileStore) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.fs = fs
+	p.lastModified = time.Time{}
+	p.planners = make(map[Partition]*DefaultPlanner)
+}
+
+// Plan returns the full compaction plans of all partitions.
+func (p *PartitionedPlanner) Plan(lastWrite time.Time) []CompactionGroup {
+	var groups []CompactionGroup
+	for _, planner := range p.partitionPlanners() {
+		groups = append(groups, planner.Plan(lastWrite)...)
+	}
+	return groups
+}
+
+// PlanLevel returns the level compaction plans of all partitions.
+func (p *PartitionedPlanner) PlanLevel(level int) []CompactionGroup {
+	var groups []CompactionGroup
+	for _, planner := range p.partitionPlanners() {
+		groups = append(groups, planner.PlanLevel(level)...)
+	}
+	return groups
+}
+
+// PlanOptimize returns the optimize compaction plans of all partitions.
+func (p *PartitionedPlanner) PlanOptimize() []CompactionGroup {
+	var groups []CompactionGroup
+	for _, planner := range p.partitionPlanners() {
+		groups = append(groups, planner.PlanOptimize()...)
+	}
+	return groups
+}
+
+func (p *PartitionedPlanner) Release(groups []CompactionGroup) {
+	for _, planner := range p.partitionPlanners() {
+		planner.Release(groups)
+	}
+}
+
+// FullyCompacted returns true if every partition is fully compacted.
+func (p *PartitionedPlanner) FullyCompacted() bool {
+	for _, planner := range p.partitionPlanners() {
+		if !planner.FullyCompacted() {
+			return false
+		}
+	}
+	return true
+}
+
+// ForceFull causes the planner to return a full compaction plan of every
+// partition the next time Plan is called.
+func (p *PartitionedPlanner) ForceFull() {
+	for _, planner := range p.partitionPlanners() {
+		planner.ForceFull()
+	}
+}
+
+// partitionPlanners returns the planners of the partitions of the current
+// files, creating planners for new partitions and removing the planners of
+// partitions without files or files in use.
+func (p *PartitionedPlanner) partitionPlanners() []*DefaultPlanner {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if lastModified := p.fs.LastModified(); !lastModified.Equal(p.lastModified) || len(p.planners) == 0 {
+		p.lastModified = lastModified
+
+		current := make(map[Partition]struct{})
+		for _, stat := range p.fs.Stats() {
+			current[filePartition(p.partitioner, stat)] = struct{}{}
+		}
+
+		for part := range current {
+			if _, ok := p.planners[part]; ok {
+				continue
+			}
+			planner := NewDefaultPlanner(&partitionFileStore{
+				fileStore:   p.fs,
+				partitioner: p.partitioner,
+				partition:   part,
+			}, p.writeColdDuration)
+			p.planners[part] = planner
+		}
+
+		for part, planner := range p.planners {
+			if _, ok := current[part]; ok {
+				continue
+			}
+			planner.mu.RLock()
+			inUse := len(planner.filesInUse)
+			planner.mu.RUnlock()
+			if inUse == 0 {
+				delete(p.planners, part)
+			}
+		}
+	}
+
+	planners := make([]*DefaultPlanner, 0, len(p.planners))
+	for _, planner := range p.planners {
+		planners = append(planners, planner)
+	}
+	return planners
+}
+
+// partitionFileStore is the view of a fileStore restricted to the files of a
+// single partition.
+type partitionFileStore struct {
+	fileStore
+	partitioner Partitioner
+	partition   Partition
+}
+
+func (fs *partitionFileStore) Stats() []FileStat {
+	var stats []FileStat
+	for _, stat := range fs.fileStore.Stats() {
+		if filePartition(fs.partitioner, stat) == fs.partition {
+			stats = append(stats, stat)
+		}
+	}
+	return stats
+}
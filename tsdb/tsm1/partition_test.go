@@ -0,0 +1,156 @@
+package tsm1_test
+
+import (
+	"bytes"
+	"os"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform/tsdb/tsm1"
+)
+
+// measurementPartitioner partitions keys by measurement and time by window.
+type measurementPartitioner struct {
+	window int64
+}
+
+func (p measurementPartitioner) Partition(key []byte, t int64) tsm1.Partition {
+	name := key
+	if i := bytes.IndexByte(key, ','); i >= 0 {
+		name = key[:i]
+	}
+	min := t - t%p.window
+	return tsm1.Partition{Group: string(name), Min: min, Max: min + p.window - 1}
+}
+
+// generationFileStore is a fakeFileStore returning increasing generations.
+type generationFileStore struct {
+	fakeFileStore
+	gen int32
+}
+
+func (w *generationFileStore) NextGeneration() int {
+	return int(atomic.AddInt32(&w.gen, 1))
+}
+
+func TestCompactor_SnapshotPartitioned(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	c := tsm1.NewCache(0)
+	for k, v := range map[string][]tsm1.Value{
+		"cpu,host=A#!~#value": {tsm1.NewValue(1, 1.0), tsm1.NewValue(5, 2.0), tsm1.NewValue(12, 3.0), tsm1.NewValue(25, 4.0)},
+		"cpu,host=B#!~#value": {tsm1.NewValue(3, 5.0)},
+		"mem,host=A#!~#value": {tsm1.NewValue(3, 6.0)},
+	} {
+		if err := c.Write([]byte(k), v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fs := &generationFileStore{}
+	defer fs.Close()
+
+	compactor := tsm1.NewCompactor()
+	compactor.Dir = dir
+	compactor.FileStore = fs
+	compactor.Partitioner = measurementPartitioner{window: 10}
+	compactor.Open()
+
+	files, err := compactor.WriteSnapshot(c)
+	if err != nil {
+		t.Fatalf("unexpected error writing snapshot: %v", err)
+	}
+
+	type file struct {
+		minKey, maxKey   string
+		minTime, maxTime int64
+		keys             int
+	}
+	var got []file
+	for _, f := range files {
+		r := MustOpenTSMReader(f)
+		minKey, maxKey := r.KeyRange()
+		minTime, maxTime := r.TimeRange()
+		got = append(got, file{string(minKey), string(maxKey), minTime, maxTime, r.KeyCount()})
+		r.Close()
+	}
+	sort.Slice(got, func(i, j int) bool {
+		if got[i].minKey != got[j].minKey {
+			return got[i].minKey < got[j].minKey
+		}
+		return got[i].minTime < got[j].minTime
+	})
+
+	exp := []file{
+		{"cpu,host=A#!~#value", "cpu,host=B#!~#value", 1, 5, 2},
+		{"cpu,host=A#!~#value", "cpu,host=A#!~#value", 12, 12, 1},
+		{"cpu,host=A#!~#value", "cpu,host=A#!~#value", 25, 25, 1},
+		{"mem,host=A#!~#value", "mem,host=A#!~#value", 3, 3, 1},
+	}
+	if len(got) != len(exp) {
+		t.Fatalf("files length mismatch: got %v, exp %v", got, exp)
+	}
+	for i := range exp {
+		if got[i] != exp[i] {
+			t.Fatalf("file %d mismatch: got %+v, exp %+v", i, got[i], exp[i])
+		}
+	}
+}
+
+func TestPartitionedPlanner_Plan(t *testing.T) {
+	data := []tsm1.FileStat{
+		{Path: "01-01.tsm1", MinKey: []byte("cpu,host=A"), MaxKey: []byte("cpu,host=B"), MinTime: 0, MaxTime: 9},
+		{Path: "02-01.tsm1", MinKey: []byte("cpu,host=A"), MaxKey: []byte("cpu,host=A"), MinTime: 10, MaxTime: 19},
+		{Path: "03-01.tsm1", MinKey: []byte("cpu,host=B"), MaxKey: []byte("cpu,host=C"), MinTime: 1, MaxTime: 8},
+		{Path: "04-01.tsm1", MinKey: []byte("mem,host=A"), MaxKey: []byte("mem,host=A"), MinTime: 0, MaxTime: 5},
+		{Path: "05-01.tsm1", MinKey: []byte("cpu,host=A"), MaxKey: []byte("mem,host=A"), MinTime: 0, MaxTime: 5},
+		{Path: "06-01.tsm1", MinKey: []byte("cpu,host=A"), MaxKey: []byte("cpu,host=B"), MinTime: 12, MaxTime: 15},
+		{Path: "07-01.tsm1", MinKey: []byte("mem,host=A"), MaxKey: []byte("mem,host=B"), MinTime: 10, MaxTime: 15},
+	}
+
+	cp := tsm1.NewPartitionedPlanner(
+		&fakeFileStore{
+			PathsFn: func() []tsm1.FileStat {
+				return data
+			},
+		},
+		measurementPartitioner{window: 10},
+		time.Nanosecond,
+	)
+
+	groups := cp.Plan(time.Now().Add(-time.Second))
+	for _, g := range groups {
+		sort.Strings(g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0] < groups[j][0] })
+
+	exp := []tsm1.CompactionGroup{
+		{"01-01.tsm1", "03-01.tsm1"},
+		{"02-01.tsm1", "06-01.tsm1"},
+	}
+	if len(groups) != len(exp) {
+		t.Fatalf("groups mismatch: got %v, exp %v", groups, exp)
+	}
+	for i := range exp {
+		if len(groups[i]) != len(exp[i]) {
+			t.Fatalf("group %d mismatch: got %v, exp %v", i, groups[i], exp[i])
+		}
+		for j := range exp[i] {
+			if groups[i][j] != exp[i][j] {
+				t.Fatalf("group %d mismatch: got %v, exp %v", i, groups[i], exp[i])
+			}
+		}
+	}
+
+	// Planned files are not planned again until released.
+	if groups := cp.Plan(time.Now().Add(-time.Second)); len(groups) != 0 {
+		t.Fatalf("unexpected groups before release: %v", groups)
+	}
+	cp.Release(groups)
+	if groups := cp.Plan(time.Now().Add(-time.Second)); len(groups) != len(exp) {
+		t.Fatalf("groups mismatch after release: got %v, exp %v", groups, exp)
+	}
+}
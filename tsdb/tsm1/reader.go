@@ -203,6 +203,12 @@ func (b *BlockIterator) Read() (key []byte, minTime int64, maxTime int64, typ by
 	return b.key, b.entries[0].MinTime, b.entries[0].MaxTime, b.typ, checksum, buf, err
 }
 
+// BlockStats returns the statistics of the current block, with a Count of 0 if
+// the file has none for it.
+func (b *BlockIterator) BlockStats() BlockStats {
+	return b.entries[0].Stats
+}
+
 // Err returns any errors encounter during iteration.
 func (b *BlockIterator) Err() error {
 	return b.err
@@ -933,7 +939,7 @@ func (d *indirectIndex) Key(idx int, entries *[]IndexEntry) ([]byte, byte, []Ind
 	ofs := binary.BigEndian.Uint32(d.offsets[idx*4 : idx*4+4])
 	n, key := readKey(d.b[ofs:])
 
-	typ := d.b[int(ofs)+n]
+	typ := d.b[int(ofs)+n] &^ indexStatsFlag
 
 	var ie indexEntries
 	if entries != nil {
@@ -961,7 +967,7 @@ func (d *indirectIndex) KeyAt(idx int) ([]byte, byte) {
 
 	n, key := readKey(d.b[ofs:])
 	ofs = ofs + int32(n)
-	typ := d.b[ofs]
+	typ := d.b[ofs] &^ indexStatsFlag
 	d.mu.RUnlock()
 	return key, typ
 }
@@ -1183,7 +1189,7 @@ func (d *indirectIndex) Type(key []byte) (byte, error) {
 	if ofs < len(d.b) {
 		n, _ := readKey(d.b[ofs:])
 		ofs += n
-		return d.b[ofs], nil
+		return d.b[ofs] &^ indexStatsFlag, nil
 	}
 	return 0, fmt.Errorf("key does not exist: %s", key)
 }
@@ -1247,7 +1253,14 @@ func (d *indirectIndex) UnmarshalBinary(b []byte) error {
 		if i+2 >= iMax {
 			return fmt.Errorf("indirectIndex: not enough data for key length value")
 		}
-		i += 3 + int32(binary.BigEndian.Uint16(b[i:i+2]))
+		i += 2 + int32(binary.BigEndian.Uint16(b[i:i+2]))
+
+		// The type flags whether the entries are followed by block statistics
+		if i >= iMax {
+			return fmt.Errorf("indirectIndex: not enough data for block type")
+		}
+		entrySize := int32(indexEntrySizeOf(b[i]))
+		i++
 
 		// count of index entries
 		if i+indexCountSize >= iMax {
@@ -1265,7 +1278,7 @@ func (d *indirectIndex) UnmarshalBinary(b []byte) error {
 			minTime = minT
 		}
 
-		i += (count - 1) * indexEntrySize
+		i += (count - 1) * entrySize
 
 		// Find the max time for the block
 		if i+16 >= iMax {
@@ -1276,7 +1289,7 @@ func (d *indirectIndex) UnmarshalBinary(b []byte) error {
 			maxTime = maxT
 		}
 
-		i += indexEntrySize
+		i += entrySize
 	}
 
 	firstOfs := offsets[0]
@@ -1590,23 +1603,30 @@ func (a *indexEntries) Less(i, j int) bool {
 	return a.entries[i].MinTime < a.entries[j].MinTime
 }
 
+// entrySize returns the size in bytes of each of the encoded entries.
+func (a *indexEntries) entrySize() int {
+	return indexEntrySizeOf(a.Type)
+}
+
 func (a *indexEntries) MarshalBinary() ([]byte, error) {
-	buf := make([]byte, len(a.entries)*indexEntrySize)
+	size := a.entrySize()
+	buf := make([]byte, len(a.entries)*size)
 
 	for i, entry := range a.entries {
-		entry.AppendTo(buf[indexEntrySize*i:])
+		a.appendEntry(buf[size*i:], &entry)
 	}
 
 	return buf, nil
 }
 
 func (a *indexEntries) WriteTo(w io.Writer) (total int64, err error) {
-	var buf [indexEntrySize]byte
+	var buf [indexEntrySize + blockStatsSize]byte
+	size := a.entrySize()
 	var n int
 
 	for _, entry := range a.entries {
-		entry.AppendTo(buf[:])
-		n, err = w.Write(buf[:])
+		a.appendEntry(buf[:size], &entry)
+		n, err = w.Write(buf[:size])
 		total += int64(n)
 		if err != nil {
 			return total, err
@@ -1616,6 +1636,23 @@ func (a *indexEntries) WriteTo(w io.Writer) (total int64, err error) {
 	return total, nil
 }
 
+// appendEntry encodes entry to b, followed by its statistics if the entries store them.
+func (a *indexEntries) appendEntry(b []byte, entry *IndexEntry) {
+	entry.AppendTo(b)
+	if a.Type&indexStatsFlag != 0 {
+		entry.Stats.AppendTo(b[indexEntrySize:])
+	}
+}
+
+// indexEntrySizeOf returns the size in bytes of each index entry of a key with the
+// given block type, which flags whether the entries are followed by block statistics.
+func indexEntrySizeOf(typ byte) int {
+	if typ&indexStatsFlag != 0 {
+		return indexEntrySize + blockStatsSize
+	}
+	return indexEntrySize
+}
+
 func readKey(b []byte) (n int, key []byte) {
 	// 2 byte size of key
 	n, size := 2, int(binary.BigEndian.Uint16(b[:2]))
@@ -1646,15 +1683,23 @@ func readEntries(b []byte, entries *indexEntries) (n int, err error) {
 		entries.entries = entries.entries[:count]
 	}
 
+	size := entries.entrySize()
 	b = b[indexCountSize+indexTypeSize:]
 	for i := 0; i < len(entries.entries); i++ {
-		if err = entries.entries[i].UnmarshalBinary(b); err != nil {
+		e := &entries.entries[i]
+		if err = e.UnmarshalBinary(b); err != nil {
 			return 0, fmt.Errorf("readEntries: unmarshal error: %v", err)
 		}
-		b = b[indexEntrySize:]
+		e.Stats = BlockStats{}
+		if size > indexEntrySize {
+			if err = e.Stats.UnmarshalBinary(b[indexEntrySize:]); err != nil {
+				return 0, fmt.Errorf("readEntries: unmarshal error: %v", err)
+			}
+		}
+		b = b[size:]
 	}
 
-	n += count * indexEntrySize
+	n += count * size
 
 	return
 }
@@ -295,6 +295,7 @@ func (l *WAL) sync() {
 		errC := <-l.syncWaiters
 		errC <- err
 	}
+	l.tracker.SetSyncWaiters(0)
 }
 
 // WriteMulti writes the given values to the WAL. It returns the WAL segment ID to
@@ -433,6 +434,7 @@ func (l *WAL) writeToLog(entry WALEntry) (int, error) {
 		default:
 			return -1, fmt.Errorf("error syncing wal")
 		}
+		l.tracker.SetSyncWaiters(len(l.syncWaiters))
 		l.scheduleSync()
 
 		// Update stats for current segment size
@@ -654,6 +656,12 @@ func (t *walTracker) DecSegments() {
 	t.metrics.Segments.With(labels).Dec()
 }
 
+// SetSyncWaiters sets the number of writes waiting for the WAL to be synced.
+func (t *walTracker) SetSyncWaiters(n int) {
+	labels := t.labels
+	t.metrics.SyncWaiters.With(labels).Set(float64(n))
+}
+
 // WALEntry is record stored in each WAL segment.  Each entry has a type
 // and an opaque, type dependent byte slice data attribute.
 type WALEntry interface {
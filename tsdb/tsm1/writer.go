@@ -52,6 +52,17 @@ file, we can use the size to determine how much to read in a given IO.
 │ 2 bytes │ N bytes │1 byte│2 bytes│ 8 bytes │ 8 bytes │8 bytes │4 bytes │   │
 └─────────┴─────────┴──────┴───────┴─────────┴─────────┴────────┴────────┴───┘
 
+Since version 2, a key whose blocks have statistics has the high bit of its type
+set, and each of its index entries is followed by the statistics of its block.
+An entry whose block has no statistics stores a count of 0.
+
+┌───────────────────────────────────────────────────────────────┐
+│                       Block Statistics                        │
+├─────────┬─────────┬─────────┬──────────┬─────────┬────────────┤
+│  Count  │   Sum   │   Min   │ Min Time │   Max   │  Max Time  │
+│ 8 bytes │ 8 bytes │ 8 bytes │ 8 bytes  │ 8 bytes │  8 bytes   │
+└─────────┴─────────┴─────────┴──────────┴─────────┴────────────┘
+
 The last section is the footer that stores the offset of the start of the index.
 
 ┌─────────┐
@@ -83,11 +94,17 @@ const (
 	MagicNumber uint32 = 0x16D116D1
 
 	// Version indicates the version of the TSM file format.
-	Version byte = 1
+	Version byte = 2
 
 	// Size in bytes of an index entry
 	indexEntrySize = 28
 
+	// Size in bytes of the block statistics following an index entry of a key that stores them
+	blockStatsSize = 48
+
+	// Bit set in the block type of a key whose index entries are followed by block statistics
+	indexStatsFlag = 0x80
+
 	// Size in bytes used to store the count of index entries for a key
 	indexCountSize = 2
 
@@ -137,6 +154,11 @@ type TSMWriter interface {
 	// timestamp values are used as the minimum and maximum values for the index entry.
 	WriteBlock(key []byte, minTime, maxTime int64, block []byte) error
 
+	// WriteBlockWithStats is like WriteBlock, but records stats as the statistics of the
+	// block rather than decoding the block to compute them.  A stats with a Count of 0 is
+	// unknown, and the statistics are computed from the block.
+	WriteBlockWithStats(key []byte, minTime, maxTime int64, block []byte, stats BlockStats) error
+
 	// WriteIndex finishes the TSM write streams and writes the index.
 	WriteIndex() error
 
@@ -160,6 +182,9 @@ type IndexWriter interface {
 	// Add records a new block entry for a key in the index.
 	Add(key []byte, blockType byte, minTime, maxTime int64, offset int64, size uint32)
 
+	// AddEntry records a new block entry, including its statistics, for a key in the index.
+	AddEntry(key []byte, blockType byte, entry IndexEntry)
+
 	// Entries returns all index entries for a key.
 	Entries(key []byte) []IndexEntry
 
@@ -190,6 +215,9 @@ type IndexEntry struct {
 
 	// The size in bytes of the block in the file.
 	Size uint32
+
+	// The statistics of the values of the block, if it has them.
+	Stats BlockStats
 }
 
 // UnmarshalBinary decodes an IndexEntry from a byte slice.
@@ -276,6 +304,15 @@ type directIndex struct {
 }
 
 func (d *directIndex) Add(key []byte, blockType byte, minTime, maxTime int64, offset int64, size uint32) {
+	d.AddEntry(key, blockType, IndexEntry{
+		MinTime: minTime,
+		MaxTime: maxTime,
+		Offset:  offset,
+		Size:    size,
+	})
+}
+
+func (d *directIndex) AddEntry(key []byte, blockType byte, entry IndexEntry) {
 	// Is this the first block being added?
 	if len(d.key) == 0 {
 		// size of the key stored in the index
@@ -288,15 +325,7 @@ func (d *directIndex) Add(key []byte, blockType byte, minTime, maxTime int64, of
 			d.indexEntries = &indexEntries{}
 		}
 		d.indexEntries.Type = blockType
-		d.indexEntries.entries = append(d.indexEntries.entries, IndexEntry{
-			MinTime: minTime,
-			MaxTime: maxTime,
-			Offset:  offset,
-			Size:    size,
-		})
-
-		// size of the encoded index entry
-		d.size += indexEntrySize
+		d.addEntry(entry)
 		d.keyCount++
 		return
 	}
@@ -305,15 +334,7 @@ func (d *directIndex) Add(key []byte, blockType byte, minTime, maxTime int64, of
 	cmp := bytes.Compare(d.key, key)
 	if cmp == 0 {
 		// The last block is still this key
-		d.indexEntries.entries = append(d.indexEntries.entries, IndexEntry{
-			MinTime: minTime,
-			MaxTime: maxTime,
-			Offset:  offset,
-			Size:    size,
-		})
-
-		// size of the encoded index entry
-		d.size += indexEntrySize
+		d.addEntry(entry)
 
 	} else if cmp < 0 {
 		d.flush(d.w)
@@ -327,15 +348,7 @@ func (d *directIndex) Add(key []byte, blockType byte, minTime, maxTime int64, of
 
 		d.key = key
 		d.indexEntries.Type = blockType
-		d.indexEntries.entries = append(d.indexEntries.entries, IndexEntry{
-			MinTime: minTime,
-			MaxTime: maxTime,
-			Offset:  offset,
-			Size:    size,
-		})
-
-		// size of the encoded index entry
-		d.size += indexEntrySize
+		d.addEntry(entry)
 		d.keyCount++
 	} else {
 		// Keys can't be added out of order.
@@ -343,6 +356,20 @@ func (d *directIndex) Add(key []byte, blockType byte, minTime, maxTime int64, of
 	}
 }
 
+// addEntry appends entry to the entries of the current key.  Once an entry of the key has
+// statistics, the key is flagged as storing statistics with every one of its entries.
+func (d *directIndex) addEntry(entry IndexEntry) {
+	if entry.Stats.Count > 0 && d.indexEntries.Type&indexStatsFlag == 0 {
+		d.indexEntries.Type |= indexStatsFlag
+		// size of the statistics of the entries already added
+		d.size += uint32(len(d.indexEntries.entries) * blockStatsSize)
+	}
+	d.indexEntries.entries = append(d.indexEntries.entries, entry)
+
+	// size of the encoded index entry
+	d.size += uint32(d.indexEntries.entrySize())
+}
+
 func (d *directIndex) entries(key []byte) []IndexEntry {
 	if len(d.key) == 0 {
 		return nil
@@ -555,6 +582,9 @@ type tsmWriter struct {
 	lastSync int64
 
 	stats MeasurementStats
+
+	// decoder computes the statistics of the blocks written.
+	decoder blockStatsDecoder
 }
 
 // NewTSMWriter returns a new TSMWriter writing to w.
@@ -636,6 +666,11 @@ func (t *tsmWriter) Write(key []byte, values Values) error {
 		return err
 	}
 
+	blockStats, err := t.decoder.blockStats(block)
+	if err != nil {
+		return err
+	}
+
 	var checksum [crc32.Size]byte
 	binary.BigEndian.PutUint32(checksum[:], crc32.ChecksumIEEE(block))
 
@@ -651,7 +686,13 @@ func (t *tsmWriter) Write(key []byte, values Values) error {
 	n += len(checksum)
 
 	// Record this block in index
-	t.index.Add(key, blockType, values[0].UnixNano(), values[len(values)-1].UnixNano(), t.n, uint32(n))
+	t.index.AddEntry(key, blockType, IndexEntry{
+		MinTime: values[0].UnixNano(),
+		MaxTime: values[len(values)-1].UnixNano(),
+		Offset:  t.n,
+		Size:    uint32(n),
+		Stats:   blockStats,
+	})
 
 	// Add block size to measurement stats.
 	name := models.ParseName(key)
@@ -671,6 +712,13 @@ func (t *tsmWriter) Write(key []byte, values Values) error {
 // exceeds max entries for a given key, ErrMaxBlocksExceeded is returned.  This indicates
 // that the index is now full for this key and no future writes to this key will succeed.
 func (t *tsmWriter) WriteBlock(key []byte, minTime, maxTime int64, block []byte) error {
+	return t.WriteBlockWithStats(key, minTime, maxTime, block, BlockStats{})
+}
+
+// WriteBlockWithStats writes block for the given key and time range to the TSM file, with
+// stats as its statistics.  Compactions use it to copy blocks along with the statistics
+// of their source index entries, so that only re-encoded blocks are decoded.
+func (t *tsmWriter) WriteBlockWithStats(key []byte, minTime, maxTime int64, block []byte, stats BlockStats) error {
 	if len(key) > maxKeyLength {
 		return ErrMaxKeyLengthExceeded
 	}
@@ -685,6 +733,12 @@ func (t *tsmWriter) WriteBlock(key []byte, minTime, maxTime int64, block []byte)
 		return err
 	}
 
+	if stats.Count == 0 {
+		if stats, err = t.decoder.blockStats(block); err != nil {
+			return err
+		}
+	}
+
 	// Write header only after we have some data to write.
 	if t.n == 0 {
 		if err := t.writeHeader(); err != nil {
@@ -707,7 +761,13 @@ func (t *tsmWriter) WriteBlock(key []byte, minTime, maxTime int64, block []byte)
 	n += len(checksum)
 
 	// Record this block in index
-	t.index.Add(key, blockType, minTime, maxTime, t.n, uint32(n))
+	t.index.AddEntry(key, blockType, IndexEntry{
+		MinTime: minTime,
+		MaxTime: maxTime,
+		Offset:  t.n,
+		Size:    uint32(n),
+		Stats:   stats,
+	})
 
 	// Add block size to measurement stats.
 	name := models.ParseName(key)
@@ -855,7 +915,7 @@ func (t *tsmWriter) Size() uint32 {
 }
 
 // verifyVersion verifies that the reader's bytes are a TSM byte
-// stream of the correct version (1)
+// stream of a version this package can read (1 or 2)
 func verifyVersion(r io.ReadSeeker) error {
 	_, err := r.Seek(0, 0)
 	if err != nil {
@@ -873,7 +933,7 @@ func verifyVersion(r io.ReadSeeker) error {
 	if err != nil {
 		return fmt.Errorf("init: error reading version: %v", err)
 	}
-	if b[0] != Version {
+	if b[0] < 1 || b[0] > Version {
 		return fmt.Errorf("init: file is version %b. expected %b", b[0], Version)
 	}
 
@@ -4,8 +4,10 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/binary"
+	"hash/crc32"
 	"io"
 	"io/ioutil"
+	"math"
 	"os"
 	"testing"
 
@@ -546,6 +548,250 @@ func TestTSMWriter_WriteBlock_Multiple(t *testing.T) {
 	}
 }
 
+func TestTSMWriter_BlockStats(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	f := MustTempFile(dir)
+
+	w, err := tsm1.NewTSMWriter(f)
+	if err != nil {
+		t.Fatalf("unexpected error creating writer: %v", err)
+	}
+
+	// cpu is written from values, disk from an encoded block.
+	if err := w.Write([]byte("cpu"), []tsm1.Value{
+		tsm1.NewValue(0, 3.0),
+		tsm1.NewValue(1, 1.0),
+		tsm1.NewValue(2, 5.0),
+		tsm1.NewValue(3, 1.0),
+	}); err != nil {
+		t.Fatalf("unexpected error writing: %v", err)
+	}
+
+	block, err := tsm1.Values([]tsm1.Value{
+		tsm1.NewValue(10, int64(-4)),
+		tsm1.NewValue(11, int64(7)),
+		tsm1.NewValue(12, int64(7)),
+	}).Encode(nil)
+	if err != nil {
+		t.Fatalf("unexpected error encoding: %v", err)
+	}
+	if err := w.WriteBlock([]byte("disk"), 10, 12, block); err != nil {
+		t.Fatalf("unexpected error writing block: %v", err)
+	}
+
+	if err := w.Write([]byte("mem"), []tsm1.Value{tsm1.NewValue(0, "idle")}); err != nil {
+		t.Fatalf("unexpected error writing: %v", err)
+	}
+
+	if err := w.WriteIndex(); err != nil {
+		t.Fatalf("unexpected error writing index: %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+
+	fd, err := os.Open(f.Name())
+	if err != nil {
+		t.Fatalf("unexpected error open file: %v", err)
+	}
+
+	r, err := tsm1.NewTSMReader(fd)
+	if err != nil {
+		t.Fatalf("unexpected error created reader: %v", err)
+	}
+	defer r.Close()
+
+	tests := []struct {
+		key   string
+		typ   byte
+		stats tsm1.BlockStats
+	}{
+		{
+			key: "cpu",
+			typ: tsm1.BlockFloat64,
+			stats: tsm1.BlockStats{
+				Count:   4,
+				Sum:     math.Float64bits(10),
+				Min:     math.Float64bits(1),
+				MinTime: 1,
+				Max:     math.Float64bits(5),
+				MaxTime: 2,
+			},
+		},
+		{
+			key: "disk",
+			typ: tsm1.BlockInteger,
+			stats: tsm1.BlockStats{
+				Count:   3,
+				Sum:     10,
+				Min:     ^uint64(3), // -4
+				MinTime: 10,
+				Max:     7,
+				MaxTime: 11,
+			},
+		},
+		{
+			// strings have no statistics
+			key: "mem",
+			typ: tsm1.BlockString,
+		},
+	}
+	for _, tt := range tests {
+		typ, err := r.Type([]byte(tt.key))
+		if err != nil {
+			t.Fatalf("unexpected error reading type of %s: %v", tt.key, err)
+		}
+		if typ != tt.typ {
+			t.Errorf("unexpected type of %s: got %v, exp %v", tt.key, typ, tt.typ)
+		}
+
+		entries := r.Entries([]byte(tt.key))
+		if len(entries) != 1 {
+			t.Fatalf("unexpected entries of %s: got %d, exp 1", tt.key, len(entries))
+		}
+		if !cmp.Equal(entries[0].Stats, tt.stats) {
+			t.Errorf("unexpected stats of %s -exp/+got:\n%s", tt.key, cmp.Diff(tt.stats, entries[0].Stats))
+		}
+	}
+
+	values, err := r.ReadAll([]byte("disk"))
+	if err != nil {
+		t.Fatalf("unexpected error reading: %v", err)
+	}
+	if got, exp := len(values), 3; got != exp {
+		t.Fatalf("read values length mismatch: got %v, exp %v", got, exp)
+	}
+}
+
+func TestTSMWriter_WriteBlockWithStats(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	f := MustTempFile(dir)
+
+	w, err := tsm1.NewTSMWriter(f)
+	if err != nil {
+		t.Fatalf("unexpected error creating writer: %v", err)
+	}
+
+	block, err := tsm1.Values([]tsm1.Value{
+		tsm1.NewValue(0, int64(1)),
+		tsm1.NewValue(1, int64(2)),
+	}).Encode(nil)
+	if err != nil {
+		t.Fatalf("unexpected error encoding: %v", err)
+	}
+
+	// The given statistics are recorded as is, without decoding the block.
+	stats := tsm1.BlockStats{Count: 2, Sum: 30, Min: 10, MinTime: 0, Max: 20, MaxTime: 1}
+	if err := w.WriteBlockWithStats([]byte("cpu"), 0, 1, block, stats); err != nil {
+		t.Fatalf("unexpected error writing block: %v", err)
+	}
+	// Unknown statistics are computed from the block.
+	if err := w.WriteBlockWithStats([]byte("disk"), 0, 1, block, tsm1.BlockStats{}); err != nil {
+		t.Fatalf("unexpected error writing block: %v", err)
+	}
+
+	if err := w.WriteIndex(); err != nil {
+		t.Fatalf("unexpected error writing index: %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+
+	fd, err := os.Open(f.Name())
+	if err != nil {
+		t.Fatalf("unexpected error open file: %v", err)
+	}
+
+	r, err := tsm1.NewTSMReader(fd)
+	if err != nil {
+		t.Fatalf("unexpected error created reader: %v", err)
+	}
+	defer r.Close()
+
+	for key, exp := range map[string]tsm1.BlockStats{
+		"cpu":  stats,
+		"disk": {Count: 2, Sum: 3, Min: 1, MinTime: 0, Max: 2, MaxTime: 1},
+	} {
+		entries := r.Entries([]byte(key))
+		if len(entries) != 1 {
+			t.Fatalf("unexpected entries of %s: got %d, exp 1", key, len(entries))
+		}
+		if !cmp.Equal(entries[0].Stats, exp) {
+			t.Errorf("unexpected stats of %s -exp/+got:\n%s", key, cmp.Diff(exp, entries[0].Stats))
+		}
+	}
+}
+
+func TestTSMReader_Version1(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	f := MustTempFile(dir)
+
+	// Write a file as versions before block statistics did: the entries of
+	// the index only hold the time range, offset and size of each block.
+	values := []tsm1.Value{tsm1.NewValue(0, 1.0), tsm1.NewValue(1, 2.0)}
+	block, err := tsm1.Values(values).Encode(nil)
+	if err != nil {
+		t.Fatalf("unexpected error encoding: %v", err)
+	}
+
+	var buf bytes.Buffer
+	var b [8]byte
+	binary.BigEndian.PutUint32(b[:4], tsm1.MagicNumber)
+	buf.Write(b[:4])
+	buf.WriteByte(1)
+	binary.BigEndian.PutUint32(b[:4], crc32.ChecksumIEEE(block))
+	buf.Write(b[:4])
+	buf.Write(block)
+
+	index := tsm1.NewIndexWriter()
+	index.Add([]byte("cpu"), tsm1.BlockFloat64, 0, 1, 5, uint32(4+len(block)))
+	indexPos := buf.Len()
+	if _, err := index.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error writing index: %v", err)
+	}
+	binary.BigEndian.PutUint64(b[:], uint64(indexPos))
+	buf.Write(b[:])
+
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		t.Fatalf("unexpected error writing file: %v", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("unexpected error seeking: %v", err)
+	}
+
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		t.Fatalf("unexpected error created reader: %v", err)
+	}
+	defer r.Close()
+
+	entries := r.Entries([]byte("cpu"))
+	if len(entries) != 1 {
+		t.Fatalf("unexpected entries: got %d, exp 1", len(entries))
+	}
+	if got, exp := entries[0].Stats.Count, int64(0); got != exp {
+		t.Fatalf("unexpected stats count: got %v, exp %v", got, exp)
+	}
+
+	readValues, err := r.ReadAll([]byte("cpu"))
+	if err != nil {
+		t.Fatalf("unexpected error reading: %v", err)
+	}
+	if len(readValues) != len(values) {
+		t.Fatalf("read values length mismatch: got %v, exp %v", len(readValues), len(values))
+	}
+	for i, v := range values {
+		if v.Value() != readValues[i].Value() {
+			t.Fatalf("read value mismatch(%d): got %v, exp %v", i, readValues[i].Value(), v.Value())
+		}
+	}
+}
+
 func TestTSMWriter_WriteBlock_MaxKey(t *testing.T) {
 	dir := MustTempDir()
 	defer os.RemoveAll(dir)
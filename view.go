@@ -468,3 +468,21 @@ type DecimalPlaces struct {
 	IsEnforced bool  `json:"isEnforced"`
 	Digits     int32 `json:"digits"`
 }
+
+// ViewQueries returns the queries used by a view's properties. Views that do
+// not run queries, such as markdown views, return nil.
+func ViewQueries(p ViewProperties) []DashboardQuery {
+	switch vis := p.(type) {
+	case XYViewProperties:
+		return vis.Queries
+	case LinePlusSingleStatProperties:
+		return vis.Queries
+	case SingleStatViewProperties:
+		return vis.Queries
+	case GaugeViewProperties:
+		return vis.Queries
+	case TableViewProperties:
+		return vis.Queries
+	}
+	return nil
+}